@@ -65,6 +65,11 @@ ${PROTOCCOMPILER} -I. -Ibackend/api/${API_VERSION} \
     backend/api/${API_VERSION}/*.proto
 # Move *.swagger.json files into swagger folder.
 cp -a ${TMP_OUTPUT}/backend/api/${API_VERSION}/*.swagger.json ./backend/api/${API_VERSION}/swagger
+# Hack to fix an issue with go-swagger, which cannot resolve the x-stream-definitions that protoc-gen-swagger
+# refers the responses of server-streaming methods to. They are moved to the definitions instead.
+for f in $(grep -l x-stream-definitions ${TMP_OUTPUT}/backend/api/${API_VERSION}/*.swagger.json); do
+    jq 'walk(if type == "object" and has("$ref") and (."$ref" | startswith("#/x-stream-definitions/")) then ."$ref" |= sub("#/x-stream-definitions/(?<name>.*)"; "#/definitions/\(.name)StreamResult") else . end) | .definitions += ((."x-stream-definitions" // {}) | with_entries(.key += "StreamResult")) | del(."x-stream-definitions")' "$f" > "$f.tmp" && mv "$f.tmp" "./backend/api/${API_VERSION}/swagger/$(basename "$f")"
+done
 # Generate a single swagger json file from the swagger json files of all models.
 # Note: use backend/backend/api/${API_VERSION}/swagger/{run,job,experiment,pipeline,pipeline.upload,healthz}.swagger.json when apt-get can install jq-1.6
 if [ $API_VERSION -eq v1beta1 ]; then
@@ -0,0 +1,387 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        v3.17.3
+// source: backend/api/v1beta1/maintenance.proto
+
+package go_client
+
+import (
+	context "context"
+	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SetReadOnlyModeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether the server should only serve reads.
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+}
+
+func (x *SetReadOnlyModeRequest) Reset() {
+	*x = SetReadOnlyModeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_api_v1beta1_maintenance_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetReadOnlyModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReadOnlyModeRequest) ProtoMessage() {}
+
+func (x *SetReadOnlyModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_api_v1beta1_maintenance_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReadOnlyModeRequest.ProtoReflect.Descriptor instead.
+func (*SetReadOnlyModeRequest) Descriptor() ([]byte, []int) {
+	return file_backend_api_v1beta1_maintenance_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetReadOnlyModeRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type ReadOnlyMode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether the server is in read-only maintenance mode.
+	ReadOnly bool `protobuf:"varint,1,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+}
+
+func (x *ReadOnlyMode) Reset() {
+	*x = ReadOnlyMode{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_api_v1beta1_maintenance_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadOnlyMode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadOnlyMode) ProtoMessage() {}
+
+func (x *ReadOnlyMode) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_api_v1beta1_maintenance_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadOnlyMode.ProtoReflect.Descriptor instead.
+func (*ReadOnlyMode) Descriptor() ([]byte, []int) {
+	return file_backend_api_v1beta1_maintenance_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReadOnlyMode) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+var File_backend_api_v1beta1_maintenance_proto protoreflect.FileDescriptor
+
+var file_backend_api_v1beta1_maintenance_proto_rawDesc = []byte{
+	0x0a, 0x25, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31,
+	0x62, 0x65, 0x74, 0x61, 0x31, 0x2f, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x61, 0x70, 0x69, 0x1a, 0x1c, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74,
+	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d,
+	0x67, 0x65, 0x6e, 0x2d, 0x73, 0x77, 0x61, 0x67, 0x67, 0x65, 0x72, 0x2f, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x32, 0x0a, 0x16, 0x53, 0x65, 0x74, 0x52, 0x65, 0x61, 0x64,
+	0x4f, 0x6e, 0x6c, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x2b, 0x0a, 0x0c, 0x52, 0x65, 0x61,
+	0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x61,
+	0x64, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65,
+	0x61, 0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x32, 0xf4, 0x01, 0x0a, 0x12, 0x4d, 0x61, 0x69, 0x6e, 0x74,
+	0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x6a, 0x0a,
+	0x11, 0x47, 0x65, 0x74, 0x52, 0x65, 0x61, 0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x4d, 0x6f, 0x64, 0x65,
+	0x56, 0x31, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x52, 0x65, 0x61, 0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x22, 0x2a, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x24, 0x12, 0x22, 0x2f, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x76, 0x31, 0x62,
+	0x65, 0x74, 0x61, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x72, 0x65, 0x61, 0x64, 0x5f,
+	0x6f, 0x6e, 0x6c, 0x79, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x72, 0x0a, 0x11, 0x53, 0x65, 0x74,
+	0x52, 0x65, 0x61, 0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x56, 0x31, 0x12, 0x1b,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x65, 0x61, 0x64, 0x4f, 0x6e, 0x6c, 0x79,
+	0x4d, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x22, 0x2d,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x27, 0x1a, 0x22, 0x2f, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x76, 0x31,
+	0x62, 0x65, 0x74, 0x61, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x72, 0x65, 0x61, 0x64,
+	0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x3a, 0x01, 0x2a, 0x42, 0x8d, 0x01,
+	0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x75, 0x62,
+	0x65, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x2f,
+	0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x62, 0x65,
+	0x74, 0x61, 0x31, 0x2f, 0x67, 0x6f, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x92, 0x41, 0x4d,
+	0x52, 0x1c, 0x0a, 0x07, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x11, 0x12, 0x0f, 0x0a,
+	0x0d, 0x1a, 0x0b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5a, 0x1f,
+	0x0a, 0x1d, 0x0a, 0x06, 0x42, 0x65, 0x61, 0x72, 0x65, 0x72, 0x12, 0x13, 0x08, 0x02, 0x1a, 0x0d,
+	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x20, 0x02, 0x62,
+	0x0c, 0x0a, 0x0a, 0x0a, 0x06, 0x42, 0x65, 0x61, 0x72, 0x65, 0x72, 0x12, 0x00, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_backend_api_v1beta1_maintenance_proto_rawDescOnce sync.Once
+	file_backend_api_v1beta1_maintenance_proto_rawDescData = file_backend_api_v1beta1_maintenance_proto_rawDesc
+)
+
+func file_backend_api_v1beta1_maintenance_proto_rawDescGZIP() []byte {
+	file_backend_api_v1beta1_maintenance_proto_rawDescOnce.Do(func() {
+		file_backend_api_v1beta1_maintenance_proto_rawDescData = protoimpl.X.CompressGZIP(file_backend_api_v1beta1_maintenance_proto_rawDescData)
+	})
+	return file_backend_api_v1beta1_maintenance_proto_rawDescData
+}
+
+var file_backend_api_v1beta1_maintenance_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_backend_api_v1beta1_maintenance_proto_goTypes = []interface{}{
+	(*SetReadOnlyModeRequest)(nil), // 0: api.SetReadOnlyModeRequest
+	(*ReadOnlyMode)(nil),           // 1: api.ReadOnlyMode
+	(*emptypb.Empty)(nil),          // 2: google.protobuf.Empty
+}
+var file_backend_api_v1beta1_maintenance_proto_depIdxs = []int32{
+	2, // 0: api.MaintenanceService.GetReadOnlyModeV1:input_type -> google.protobuf.Empty
+	0, // 1: api.MaintenanceService.SetReadOnlyModeV1:input_type -> api.SetReadOnlyModeRequest
+	1, // 2: api.MaintenanceService.GetReadOnlyModeV1:output_type -> api.ReadOnlyMode
+	1, // 3: api.MaintenanceService.SetReadOnlyModeV1:output_type -> api.ReadOnlyMode
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_backend_api_v1beta1_maintenance_proto_init() }
+func file_backend_api_v1beta1_maintenance_proto_init() {
+	if File_backend_api_v1beta1_maintenance_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_backend_api_v1beta1_maintenance_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetReadOnlyModeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_api_v1beta1_maintenance_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadOnlyMode); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_backend_api_v1beta1_maintenance_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_backend_api_v1beta1_maintenance_proto_goTypes,
+		DependencyIndexes: file_backend_api_v1beta1_maintenance_proto_depIdxs,
+		MessageInfos:      file_backend_api_v1beta1_maintenance_proto_msgTypes,
+	}.Build()
+	File_backend_api_v1beta1_maintenance_proto = out.File
+	file_backend_api_v1beta1_maintenance_proto_rawDesc = nil
+	file_backend_api_v1beta1_maintenance_proto_goTypes = nil
+	file_backend_api_v1beta1_maintenance_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// MaintenanceServiceClient is the client API for MaintenanceService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type MaintenanceServiceClient interface {
+	// Tells whether the server is in read-only maintenance mode, in which it only serves reads.
+	GetReadOnlyModeV1(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ReadOnlyMode, error)
+	// Turns read-only maintenance mode on or off until the server restarts. Only the admin identities may
+	// change it, in single-user mode as well as in multi-user mode.
+	SetReadOnlyModeV1(ctx context.Context, in *SetReadOnlyModeRequest, opts ...grpc.CallOption) (*ReadOnlyMode, error)
+}
+
+type maintenanceServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMaintenanceServiceClient(cc grpc.ClientConnInterface) MaintenanceServiceClient {
+	return &maintenanceServiceClient{cc}
+}
+
+func (c *maintenanceServiceClient) GetReadOnlyModeV1(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ReadOnlyMode, error) {
+	out := new(ReadOnlyMode)
+	err := c.cc.Invoke(ctx, "/api.MaintenanceService/GetReadOnlyModeV1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *maintenanceServiceClient) SetReadOnlyModeV1(ctx context.Context, in *SetReadOnlyModeRequest, opts ...grpc.CallOption) (*ReadOnlyMode, error) {
+	out := new(ReadOnlyMode)
+	err := c.cc.Invoke(ctx, "/api.MaintenanceService/SetReadOnlyModeV1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MaintenanceServiceServer is the server API for MaintenanceService service.
+type MaintenanceServiceServer interface {
+	// Tells whether the server is in read-only maintenance mode, in which it only serves reads.
+	GetReadOnlyModeV1(context.Context, *emptypb.Empty) (*ReadOnlyMode, error)
+	// Turns read-only maintenance mode on or off until the server restarts. Only the admin identities may
+	// change it, in single-user mode as well as in multi-user mode.
+	SetReadOnlyModeV1(context.Context, *SetReadOnlyModeRequest) (*ReadOnlyMode, error)
+}
+
+// UnimplementedMaintenanceServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedMaintenanceServiceServer struct {
+}
+
+func (*UnimplementedMaintenanceServiceServer) GetReadOnlyModeV1(context.Context, *emptypb.Empty) (*ReadOnlyMode, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReadOnlyModeV1 not implemented")
+}
+func (*UnimplementedMaintenanceServiceServer) SetReadOnlyModeV1(context.Context, *SetReadOnlyModeRequest) (*ReadOnlyMode, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReadOnlyModeV1 not implemented")
+}
+
+func RegisterMaintenanceServiceServer(s *grpc.Server, srv MaintenanceServiceServer) {
+	s.RegisterService(&_MaintenanceService_serviceDesc, srv)
+}
+
+func _MaintenanceService_GetReadOnlyModeV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MaintenanceServiceServer).GetReadOnlyModeV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.MaintenanceService/GetReadOnlyModeV1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MaintenanceServiceServer).GetReadOnlyModeV1(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MaintenanceService_SetReadOnlyModeV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReadOnlyModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MaintenanceServiceServer).SetReadOnlyModeV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.MaintenanceService/SetReadOnlyModeV1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MaintenanceServiceServer).SetReadOnlyModeV1(ctx, req.(*SetReadOnlyModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _MaintenanceService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.MaintenanceService",
+	HandlerType: (*MaintenanceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetReadOnlyModeV1",
+			Handler:    _MaintenanceService_GetReadOnlyModeV1_Handler,
+		},
+		{
+			MethodName: "SetReadOnlyModeV1",
+			Handler:    _MaintenanceService_SetReadOnlyModeV1_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "backend/api/v1beta1/maintenance.proto",
+}
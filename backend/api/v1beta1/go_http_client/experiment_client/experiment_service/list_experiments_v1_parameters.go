@@ -80,6 +80,11 @@ type ListExperimentsV1Params struct {
 
 	*/
 	Filter *string
+	/*IncludeDeleted
+	  Whether to also list the experiments that were soft deleted and not reaped yet.
+
+	*/
+	IncludeDeleted *bool
 	/*PageSize
 	  The number of experiments to be listed per page. If there are more
 	experiments than this number, the response message will contain a
@@ -160,6 +165,17 @@ func (o *ListExperimentsV1Params) SetFilter(filter *string) {
 	o.Filter = filter
 }
 
+// WithIncludeDeleted adds the includeDeleted to the list experiments v1 params
+func (o *ListExperimentsV1Params) WithIncludeDeleted(includeDeleted *bool) *ListExperimentsV1Params {
+	o.SetIncludeDeleted(includeDeleted)
+	return o
+}
+
+// SetIncludeDeleted adds the includeDeleted to the list experiments v1 params
+func (o *ListExperimentsV1Params) SetIncludeDeleted(includeDeleted *bool) {
+	o.IncludeDeleted = includeDeleted
+}
+
 // WithPageSize adds the pageSize to the list experiments v1 params
 func (o *ListExperimentsV1Params) WithPageSize(pageSize *int32) *ListExperimentsV1Params {
 	o.SetPageSize(pageSize)
@@ -239,6 +255,22 @@ func (o *ListExperimentsV1Params) WriteToRequest(r runtime.ClientRequest, reg st
 
 	}
 
+	if o.IncludeDeleted != nil {
+
+		// query param include_deleted
+		var qrIncludeDeleted bool
+		if o.IncludeDeleted != nil {
+			qrIncludeDeleted = *o.IncludeDeleted
+		}
+		qIncludeDeleted := swag.FormatBool(qrIncludeDeleted)
+		if qIncludeDeleted != "" {
+			if err := r.SetQueryParam("include_deleted", qIncludeDeleted); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	if o.PageSize != nil {
 
 		// query param page_size
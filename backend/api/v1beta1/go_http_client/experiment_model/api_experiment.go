@@ -36,6 +36,10 @@ type APIExperiment struct {
 	// For Experiment, the only valid resource reference is a single Namespace.
 	ResourceReferences []*APIResourceReference `json:"resource_references"`
 
+	// Output. The version of the experiment, bumped by every update of it. An
+	// update given the version it read fails if the experiment changed since.
+	ResourceVersion string `json:"resource_version,omitempty"`
+
 	// Output. Specifies whether this experiment is in archived or available state.
 	StorageState APIExperimentStorageState `json:"storage_state,omitempty"`
 }
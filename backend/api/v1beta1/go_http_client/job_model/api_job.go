@@ -47,6 +47,14 @@ type APIJob struct {
 	// Required input field. Job name provided by user. Not unique.
 	Name string `json:"name,omitempty"`
 
+	// Output. When the job is expected to create its next run. It is unset if
+	// the job won't create any, in which case next_run_reason says why.
+	// Format: date-time
+	NextRunAt strfmt.DateTime `json:"next_run_at,omitempty"`
+
+	// Output. Why the job won't create any more runs.
+	NextRunReason string `json:"next_run_reason,omitempty"`
+
 	// Optional input field. Whether the job should catch up if behind schedule.
 	// If true, the job will only schedule the latest interval if behind schedule.
 	// If false, the job will catch up on each past interval.
@@ -88,6 +96,10 @@ func (m *APIJob) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateNextRunAt(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validatePipelineSpec(formats); err != nil {
 		res = append(res, err)
 	}
@@ -139,6 +151,19 @@ func (m *APIJob) validateMode(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *APIJob) validateNextRunAt(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.NextRunAt) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("next_run_at", "body", "date-time", m.NextRunAt.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *APIJob) validatePipelineSpec(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.PipelineSpec) { // not required
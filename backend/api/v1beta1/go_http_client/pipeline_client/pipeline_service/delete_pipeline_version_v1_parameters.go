@@ -13,6 +13,7 @@ import (
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/swag"
 
 	strfmt "github.com/go-openapi/strfmt"
 )
@@ -61,6 +62,13 @@ for the delete pipeline version v1 operation typically these are written to a ht
 */
 type DeletePipelineVersionV1Params struct {
 
+	/*Cascade
+	  Delete the version even though active runs or jobs were created from it.
+	The runs are kept, and the jobs are disabled, but none of them references
+	the version anymore.
+
+	*/
+	Cascade *bool
 	/*VersionID
 	  The ID of the pipeline version to be deleted.
 
@@ -105,6 +113,17 @@ func (o *DeletePipelineVersionV1Params) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithCascade adds the cascade to the delete pipeline version v1 params
+func (o *DeletePipelineVersionV1Params) WithCascade(cascade *bool) *DeletePipelineVersionV1Params {
+	o.SetCascade(cascade)
+	return o
+}
+
+// SetCascade adds the cascade to the delete pipeline version v1 params
+func (o *DeletePipelineVersionV1Params) SetCascade(cascade *bool) {
+	o.Cascade = cascade
+}
+
 // WithVersionID adds the versionID to the delete pipeline version v1 params
 func (o *DeletePipelineVersionV1Params) WithVersionID(versionID string) *DeletePipelineVersionV1Params {
 	o.SetVersionID(versionID)
@@ -124,6 +143,22 @@ func (o *DeletePipelineVersionV1Params) WriteToRequest(r runtime.ClientRequest,
 	}
 	var res []error
 
+	if o.Cascade != nil {
+
+		// query param cascade
+		var qrCascade bool
+		if o.Cascade != nil {
+			qrCascade = *o.Cascade
+		}
+		qCascade := swag.FormatBool(qrCascade)
+		if qCascade != "" {
+			if err := r.SetQueryParam("cascade", qCascade); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	// path param version_id
 	if err := r.SetPathParam("version_id", o.VersionID); err != nil {
 		return err
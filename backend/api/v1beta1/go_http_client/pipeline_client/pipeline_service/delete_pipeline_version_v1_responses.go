@@ -54,7 +54,7 @@ func NewDeletePipelineVersionV1OK() *DeletePipelineVersionV1OK {
 A successful response.
 */
 type DeletePipelineVersionV1OK struct {
-	Payload interface{}
+	Payload *pipeline_model.APIDeletePipelineVersionResponse
 }
 
 func (o *DeletePipelineVersionV1OK) Error() string {
@@ -63,8 +63,10 @@ func (o *DeletePipelineVersionV1OK) Error() string {
 
 func (o *DeletePipelineVersionV1OK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
+	o.Payload = new(pipeline_model.APIDeletePipelineVersionResponse)
+
 	// response payload
-	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
 		return err
 	}
 
@@ -20,8 +20,11 @@ import (
 // NewGetPipelineVersionTemplateParams creates a new GetPipelineVersionTemplateParams object
 // with the default values initialized.
 func NewGetPipelineVersionTemplateParams() *GetPipelineVersionTemplateParams {
-	var ()
+	var (
+		formatDefault = string("RAW")
+	)
 	return &GetPipelineVersionTemplateParams{
+		Format: &formatDefault,
 
 		timeout: cr.DefaultTimeout,
 	}
@@ -30,8 +33,11 @@ func NewGetPipelineVersionTemplateParams() *GetPipelineVersionTemplateParams {
 // NewGetPipelineVersionTemplateParamsWithTimeout creates a new GetPipelineVersionTemplateParams object
 // with the default values initialized, and the ability to set a timeout on a request
 func NewGetPipelineVersionTemplateParamsWithTimeout(timeout time.Duration) *GetPipelineVersionTemplateParams {
-	var ()
+	var (
+		formatDefault = string("RAW")
+	)
 	return &GetPipelineVersionTemplateParams{
+		Format: &formatDefault,
 
 		timeout: timeout,
 	}
@@ -40,8 +46,11 @@ func NewGetPipelineVersionTemplateParamsWithTimeout(timeout time.Duration) *GetP
 // NewGetPipelineVersionTemplateParamsWithContext creates a new GetPipelineVersionTemplateParams object
 // with the default values initialized, and the ability to set a context for a request
 func NewGetPipelineVersionTemplateParamsWithContext(ctx context.Context) *GetPipelineVersionTemplateParams {
-	var ()
+	var (
+		formatDefault = string("RAW")
+	)
 	return &GetPipelineVersionTemplateParams{
+		Format: &formatDefault,
 
 		Context: ctx,
 	}
@@ -50,8 +59,11 @@ func NewGetPipelineVersionTemplateParamsWithContext(ctx context.Context) *GetPip
 // NewGetPipelineVersionTemplateParamsWithHTTPClient creates a new GetPipelineVersionTemplateParams object
 // with the default values initialized, and the ability to set a custom HTTPClient for a request
 func NewGetPipelineVersionTemplateParamsWithHTTPClient(client *http.Client) *GetPipelineVersionTemplateParams {
-	var ()
+	var (
+		formatDefault = string("RAW")
+	)
 	return &GetPipelineVersionTemplateParams{
+		Format:     &formatDefault,
 		HTTPClient: client,
 	}
 }
@@ -61,6 +73,16 @@ for the get pipeline version template operation typically these are written to a
 */
 type GetPipelineVersionTemplateParams struct {
 
+	/*Format
+	  The format to return the template in. The template is returned as it was
+	stored if not specified.
+
+	 - RAW: The template as it was stored.
+	 - ARGO_YAML: The template as an Argo workflow in YAML.
+	 - JSON: The template in JSON.
+
+	*/
+	Format *string
 	/*VersionID
 	  The ID of the pipeline version whose template is to be retrieved.
 
@@ -105,6 +127,17 @@ func (o *GetPipelineVersionTemplateParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithFormat adds the format to the get pipeline version template params
+func (o *GetPipelineVersionTemplateParams) WithFormat(format *string) *GetPipelineVersionTemplateParams {
+	o.SetFormat(format)
+	return o
+}
+
+// SetFormat adds the format to the get pipeline version template params
+func (o *GetPipelineVersionTemplateParams) SetFormat(format *string) {
+	o.Format = format
+}
+
 // WithVersionID adds the versionID to the get pipeline version template params
 func (o *GetPipelineVersionTemplateParams) WithVersionID(versionID string) *GetPipelineVersionTemplateParams {
 	o.SetVersionID(versionID)
@@ -124,6 +157,22 @@ func (o *GetPipelineVersionTemplateParams) WriteToRequest(r runtime.ClientReques
 	}
 	var res []error
 
+	if o.Format != nil {
+
+		// query param format
+		var qrFormat string
+		if o.Format != nil {
+			qrFormat = *o.Format
+		}
+		qFormat := qrFormat
+		if qFormat != "" {
+			if err := r.SetQueryParam("format", qFormat); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	// path param version_id
 	if err := r.SetPathParam("version_id", o.VersionID); err != nil {
 		return err
@@ -343,6 +343,35 @@ func (a *Client) ListPipelinesV1(params *ListPipelinesV1Params, authInfo runtime
 
 }
 
+/*
+StreamPipelineVersionTemplate streams the template of the specified pipeline version in chunks for templates too large for get pipeline version template to return in a single message the stream ends with an error status if the template can t be read to its end so a truncated template is never taken for a complete one
+*/
+func (a *Client) StreamPipelineVersionTemplate(params *StreamPipelineVersionTemplateParams, authInfo runtime.ClientAuthInfoWriter) (*StreamPipelineVersionTemplateOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewStreamPipelineVersionTemplateParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "StreamPipelineVersionTemplate",
+		Method:             "GET",
+		PathPattern:        "/apis/v1beta1/pipeline_versions/{version_id}/templates:stream",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &StreamPipelineVersionTemplateReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*StreamPipelineVersionTemplateOK), nil
+
+}
+
 /*
 UpdatePipelineDefaultVersionV1 updates the default pipeline version of a specific pipeline
 */
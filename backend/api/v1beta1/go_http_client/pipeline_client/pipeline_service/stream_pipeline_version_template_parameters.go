@@ -0,0 +1,186 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package pipeline_service
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+
+	strfmt "github.com/go-openapi/strfmt"
+)
+
+// NewStreamPipelineVersionTemplateParams creates a new StreamPipelineVersionTemplateParams object
+// with the default values initialized.
+func NewStreamPipelineVersionTemplateParams() *StreamPipelineVersionTemplateParams {
+	var (
+		formatDefault = string("RAW")
+	)
+	return &StreamPipelineVersionTemplateParams{
+		Format: &formatDefault,
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewStreamPipelineVersionTemplateParamsWithTimeout creates a new StreamPipelineVersionTemplateParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewStreamPipelineVersionTemplateParamsWithTimeout(timeout time.Duration) *StreamPipelineVersionTemplateParams {
+	var (
+		formatDefault = string("RAW")
+	)
+	return &StreamPipelineVersionTemplateParams{
+		Format: &formatDefault,
+
+		timeout: timeout,
+	}
+}
+
+// NewStreamPipelineVersionTemplateParamsWithContext creates a new StreamPipelineVersionTemplateParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewStreamPipelineVersionTemplateParamsWithContext(ctx context.Context) *StreamPipelineVersionTemplateParams {
+	var (
+		formatDefault = string("RAW")
+	)
+	return &StreamPipelineVersionTemplateParams{
+		Format: &formatDefault,
+
+		Context: ctx,
+	}
+}
+
+// NewStreamPipelineVersionTemplateParamsWithHTTPClient creates a new StreamPipelineVersionTemplateParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewStreamPipelineVersionTemplateParamsWithHTTPClient(client *http.Client) *StreamPipelineVersionTemplateParams {
+	var (
+		formatDefault = string("RAW")
+	)
+	return &StreamPipelineVersionTemplateParams{
+		Format:     &formatDefault,
+		HTTPClient: client,
+	}
+}
+
+/*
+StreamPipelineVersionTemplateParams contains all the parameters to send to the API endpoint
+for the stream pipeline version template operation typically these are written to a http.Request
+*/
+type StreamPipelineVersionTemplateParams struct {
+
+	/*Format
+	  The format to return the template in. The template is returned as it was
+	stored if not specified.
+
+	 - RAW: The template as it was stored.
+	 - ARGO_YAML: The template as an Argo workflow in YAML.
+	 - JSON: The template in JSON.
+
+	*/
+	Format *string
+	/*VersionID
+	  The ID of the pipeline version whose template is to be retrieved.
+
+	*/
+	VersionID string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the stream pipeline version template params
+func (o *StreamPipelineVersionTemplateParams) WithTimeout(timeout time.Duration) *StreamPipelineVersionTemplateParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the stream pipeline version template params
+func (o *StreamPipelineVersionTemplateParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the stream pipeline version template params
+func (o *StreamPipelineVersionTemplateParams) WithContext(ctx context.Context) *StreamPipelineVersionTemplateParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the stream pipeline version template params
+func (o *StreamPipelineVersionTemplateParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the stream pipeline version template params
+func (o *StreamPipelineVersionTemplateParams) WithHTTPClient(client *http.Client) *StreamPipelineVersionTemplateParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the stream pipeline version template params
+func (o *StreamPipelineVersionTemplateParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithFormat adds the format to the stream pipeline version template params
+func (o *StreamPipelineVersionTemplateParams) WithFormat(format *string) *StreamPipelineVersionTemplateParams {
+	o.SetFormat(format)
+	return o
+}
+
+// SetFormat adds the format to the stream pipeline version template params
+func (o *StreamPipelineVersionTemplateParams) SetFormat(format *string) {
+	o.Format = format
+}
+
+// WithVersionID adds the versionID to the stream pipeline version template params
+func (o *StreamPipelineVersionTemplateParams) WithVersionID(versionID string) *StreamPipelineVersionTemplateParams {
+	o.SetVersionID(versionID)
+	return o
+}
+
+// SetVersionID adds the versionId to the stream pipeline version template params
+func (o *StreamPipelineVersionTemplateParams) SetVersionID(versionID string) {
+	o.VersionID = versionID
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *StreamPipelineVersionTemplateParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if o.Format != nil {
+
+		// query param format
+		var qrFormat string
+		if o.Format != nil {
+			qrFormat = *o.Format
+		}
+		qFormat := qrFormat
+		if qFormat != "" {
+			if err := r.SetQueryParam("format", qFormat); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	// path param version_id
+	if err := r.SetPathParam("version_id", o.VersionID); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package pipeline_service
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	pipeline_model "github.com/kubeflow/pipelines/backend/api/v1beta1/go_http_client/pipeline_model"
+)
+
+// StreamPipelineVersionTemplateReader is a Reader for the StreamPipelineVersionTemplate structure.
+type StreamPipelineVersionTemplateReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *StreamPipelineVersionTemplateReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+
+	case 200:
+		result := NewStreamPipelineVersionTemplateOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+
+	default:
+		result := NewStreamPipelineVersionTemplateDefault(response.Code())
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		if response.Code()/100 == 2 {
+			return result, nil
+		}
+		return nil, result
+	}
+}
+
+// NewStreamPipelineVersionTemplateOK creates a StreamPipelineVersionTemplateOK with default headers values
+func NewStreamPipelineVersionTemplateOK() *StreamPipelineVersionTemplateOK {
+	return &StreamPipelineVersionTemplateOK{}
+}
+
+/*
+StreamPipelineVersionTemplateOK handles this case with default header values.
+
+A successful response.(streaming responses)
+*/
+type StreamPipelineVersionTemplateOK struct {
+	Payload *pipeline_model.APITemplateChunkStreamResult
+}
+
+func (o *StreamPipelineVersionTemplateOK) Error() string {
+	return fmt.Sprintf("[GET /apis/v1beta1/pipeline_versions/{version_id}/templates:stream][%d] streamPipelineVersionTemplateOK  %+v", 200, o.Payload)
+}
+
+func (o *StreamPipelineVersionTemplateOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(pipeline_model.APITemplateChunkStreamResult)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewStreamPipelineVersionTemplateDefault creates a StreamPipelineVersionTemplateDefault with default headers values
+func NewStreamPipelineVersionTemplateDefault(code int) *StreamPipelineVersionTemplateDefault {
+	return &StreamPipelineVersionTemplateDefault{
+		_statusCode: code,
+	}
+}
+
+/*
+StreamPipelineVersionTemplateDefault handles this case with default header values.
+
+StreamPipelineVersionTemplateDefault stream pipeline version template default
+*/
+type StreamPipelineVersionTemplateDefault struct {
+	_statusCode int
+
+	Payload *pipeline_model.APIStatus
+}
+
+// Code gets the status code for the stream pipeline version template default response
+func (o *StreamPipelineVersionTemplateDefault) Code() int {
+	return o._statusCode
+}
+
+func (o *StreamPipelineVersionTemplateDefault) Error() string {
+	return fmt.Sprintf("[GET /apis/v1beta1/pipeline_versions/{version_id}/templates:stream][%d] StreamPipelineVersionTemplate default  %+v", o._statusCode, o.Payload)
+}
+
+func (o *StreamPipelineVersionTemplateDefault) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(pipeline_model.APIStatus)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
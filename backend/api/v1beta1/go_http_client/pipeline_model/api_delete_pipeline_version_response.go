@@ -0,0 +1,46 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package pipeline_model
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/swag"
+)
+
+// APIDeletePipelineVersionResponse api delete pipeline version response
+// swagger:model apiDeletePipelineVersionResponse
+type APIDeletePipelineVersionResponse struct {
+
+	// The number of jobs that no longer reference the deleted version.
+	AffectedJobs int32 `json:"affected_jobs,omitempty"`
+
+	// The number of runs that no longer reference the deleted version.
+	AffectedRuns int32 `json:"affected_runs,omitempty"`
+}
+
+// Validate validates this api delete pipeline version response
+func (m *APIDeletePipelineVersionResponse) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APIDeletePipelineVersionResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APIDeletePipelineVersionResponse) UnmarshalBinary(b []byte) error {
+	var res APIDeletePipelineVersionResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -40,6 +40,10 @@ type APIPipeline struct {
 	// Output. Unique pipeline ID. Generated by API server.
 	ID string `json:"id,omitempty"`
 
+	// Optional input field. Labels that categorize the pipeline, e.g. team=ml.
+	// ListPipelines filters on them as labels.<key>.
+	Labels map[string]string `json:"labels,omitempty"`
+
 	// Optional input field. Pipeline name provided by user. If not specified,
 	// file name is used as pipeline name.
 	Name string `json:"name,omitempty"`
@@ -60,6 +64,10 @@ type APIPipeline struct {
 	// inside PipelineVersion when all usage of the former has been changed to use
 	// the latter.
 	URL *APIURL `json:"url,omitempty"`
+
+	// Output. The number of ready versions of the pipeline. ListPipelines
+	// filters on it as version_count, e.g. to find pipelines without versions.
+	VersionCount string `json:"version_count,omitempty"`
 }
 
 // Validate validates this api pipeline
@@ -0,0 +1,71 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package pipeline_model
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/validate"
+)
+
+// GetPipelineVersionTemplateRequestTemplateFormat The formats a template can be returned in.
+//
+//   - RAW: The template as it was stored.
+//   - ARGO_YAML: The template as an Argo workflow in YAML.
+//   - JSON: The template in JSON.
+//
+// swagger:model GetPipelineVersionTemplateRequestTemplateFormat
+type GetPipelineVersionTemplateRequestTemplateFormat string
+
+const (
+
+	// GetPipelineVersionTemplateRequestTemplateFormatRAW captures enum value "RAW"
+	GetPipelineVersionTemplateRequestTemplateFormatRAW GetPipelineVersionTemplateRequestTemplateFormat = "RAW"
+
+	// GetPipelineVersionTemplateRequestTemplateFormatARGOYAML captures enum value "ARGO_YAML"
+	GetPipelineVersionTemplateRequestTemplateFormatARGOYAML GetPipelineVersionTemplateRequestTemplateFormat = "ARGO_YAML"
+
+	// GetPipelineVersionTemplateRequestTemplateFormatJSON captures enum value "JSON"
+	GetPipelineVersionTemplateRequestTemplateFormatJSON GetPipelineVersionTemplateRequestTemplateFormat = "JSON"
+)
+
+// for schema
+var getPipelineVersionTemplateRequestTemplateFormatEnum []interface{}
+
+func init() {
+	var res []GetPipelineVersionTemplateRequestTemplateFormat
+	if err := json.Unmarshal([]byte(`["RAW","ARGO_YAML","JSON"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		getPipelineVersionTemplateRequestTemplateFormatEnum = append(getPipelineVersionTemplateRequestTemplateFormatEnum, v)
+	}
+}
+
+func (m GetPipelineVersionTemplateRequestTemplateFormat) validateGetPipelineVersionTemplateRequestTemplateFormatEnum(path, location string, value GetPipelineVersionTemplateRequestTemplateFormat) error {
+	if err := validate.Enum(path, location, value, getPipelineVersionTemplateRequestTemplateFormatEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate validates this get pipeline version template request template format
+func (m GetPipelineVersionTemplateRequestTemplateFormat) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	// value enum
+	if err := m.validateGetPipelineVersionTemplateRequestTemplateFormatEnum("", "body", m); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package run_service
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	run_model "github.com/kubeflow/pipelines/backend/api/v1beta1/go_http_client/run_model"
+)
+
+// BulkCreateRunsV1Reader is a Reader for the BulkCreateRunsV1 structure.
+type BulkCreateRunsV1Reader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *BulkCreateRunsV1Reader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+
+	case 200:
+		result := NewBulkCreateRunsV1OK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+
+	default:
+		result := NewBulkCreateRunsV1Default(response.Code())
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		if response.Code()/100 == 2 {
+			return result, nil
+		}
+		return nil, result
+	}
+}
+
+// NewBulkCreateRunsV1OK creates a BulkCreateRunsV1OK with default headers values
+func NewBulkCreateRunsV1OK() *BulkCreateRunsV1OK {
+	return &BulkCreateRunsV1OK{}
+}
+
+/*
+BulkCreateRunsV1OK handles this case with default header values.
+
+A successful response.
+*/
+type BulkCreateRunsV1OK struct {
+	Payload *run_model.APIBulkCreateRunsResponse
+}
+
+func (o *BulkCreateRunsV1OK) Error() string {
+	return fmt.Sprintf("[POST /apis/v1beta1/runs:batchCreate][%d] bulkCreateRunsV1OK  %+v", 200, o.Payload)
+}
+
+func (o *BulkCreateRunsV1OK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(run_model.APIBulkCreateRunsResponse)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewBulkCreateRunsV1Default creates a BulkCreateRunsV1Default with default headers values
+func NewBulkCreateRunsV1Default(code int) *BulkCreateRunsV1Default {
+	return &BulkCreateRunsV1Default{
+		_statusCode: code,
+	}
+}
+
+/*
+BulkCreateRunsV1Default handles this case with default header values.
+
+BulkCreateRunsV1Default bulk create runs v1 default
+*/
+type BulkCreateRunsV1Default struct {
+	_statusCode int
+
+	Payload *run_model.APIStatus
+}
+
+// Code gets the status code for the bulk create runs v1 default response
+func (o *BulkCreateRunsV1Default) Code() int {
+	return o._statusCode
+}
+
+func (o *BulkCreateRunsV1Default) Error() string {
+	return fmt.Sprintf("[POST /apis/v1beta1/runs:batchCreate][%d] BulkCreateRunsV1 default  %+v", o._statusCode, o.Payload)
+}
+
+func (o *BulkCreateRunsV1Default) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(run_model.APIStatus)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
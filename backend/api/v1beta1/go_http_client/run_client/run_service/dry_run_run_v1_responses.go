@@ -0,0 +1,114 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package run_service
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	run_model "github.com/kubeflow/pipelines/backend/api/v1beta1/go_http_client/run_model"
+)
+
+// DryRunRunV1Reader is a Reader for the DryRunRunV1 structure.
+type DryRunRunV1Reader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *DryRunRunV1Reader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+
+	case 200:
+		result := NewDryRunRunV1OK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+
+	default:
+		result := NewDryRunRunV1Default(response.Code())
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		if response.Code()/100 == 2 {
+			return result, nil
+		}
+		return nil, result
+	}
+}
+
+// NewDryRunRunV1OK creates a DryRunRunV1OK with default headers values
+func NewDryRunRunV1OK() *DryRunRunV1OK {
+	return &DryRunRunV1OK{}
+}
+
+/*
+DryRunRunV1OK handles this case with default header values.
+
+A successful response.
+*/
+type DryRunRunV1OK struct {
+	Payload *run_model.APIDryRunRunResponse
+}
+
+func (o *DryRunRunV1OK) Error() string {
+	return fmt.Sprintf("[POST /apis/v1beta1/runs:dryRun][%d] dryRunRunV1OK  %+v", 200, o.Payload)
+}
+
+func (o *DryRunRunV1OK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(run_model.APIDryRunRunResponse)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewDryRunRunV1Default creates a DryRunRunV1Default with default headers values
+func NewDryRunRunV1Default(code int) *DryRunRunV1Default {
+	return &DryRunRunV1Default{
+		_statusCode: code,
+	}
+}
+
+/*
+DryRunRunV1Default handles this case with default header values.
+
+DryRunRunV1Default dry run run v1 default
+*/
+type DryRunRunV1Default struct {
+	_statusCode int
+
+	Payload *run_model.APIStatus
+}
+
+// Code gets the status code for the dry run run v1 default response
+func (o *DryRunRunV1Default) Code() int {
+	return o._statusCode
+}
+
+func (o *DryRunRunV1Default) Error() string {
+	return fmt.Sprintf("[POST /apis/v1beta1/runs:dryRun][%d] DryRunRunV1 default  %+v", o._statusCode, o.Payload)
+}
+
+func (o *DryRunRunV1Default) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(run_model.APIStatus)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
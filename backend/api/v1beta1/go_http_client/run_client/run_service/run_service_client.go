@@ -53,6 +53,35 @@ func (a *Client) ArchiveRunV1(params *ArchiveRunV1Params, authInfo runtime.Clien
 
 }
 
+/*
+BulkCreateRunsV1 creates several runs at once by default either all of the runs are created or none of them is
+*/
+func (a *Client) BulkCreateRunsV1(params *BulkCreateRunsV1Params, authInfo runtime.ClientAuthInfoWriter) (*BulkCreateRunsV1OK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewBulkCreateRunsV1Params()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "BulkCreateRunsV1",
+		Method:             "POST",
+		PathPattern:        "/apis/v1beta1/runs:batchCreate",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &BulkCreateRunsV1Reader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*BulkCreateRunsV1OK), nil
+
+}
+
 /*
 CreateRunV1 creates a new run
 */
@@ -111,6 +140,35 @@ func (a *Client) DeleteRunV1(params *DeleteRunV1Params, authInfo runtime.ClientA
 
 }
 
+/*
+DryRunRunV1 compiles a run the same way as create run v1 without submitting its workflow or storing it
+*/
+func (a *Client) DryRunRunV1(params *DryRunRunV1Params, authInfo runtime.ClientAuthInfoWriter) (*DryRunRunV1OK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewDryRunRunV1Params()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "DryRunRunV1",
+		Method:             "POST",
+		PathPattern:        "/apis/v1beta1/runs:dryRun",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &DryRunRunV1Reader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*DryRunRunV1OK), nil
+
+}
+
 /*
 GetRunV1 finds a specific run by ID
 */
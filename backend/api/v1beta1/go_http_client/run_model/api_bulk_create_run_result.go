@@ -0,0 +1,96 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package run_model
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/swag"
+)
+
+// APIBulkCreateRunResult api bulk create run result
+// swagger:model apiBulkCreateRunResult
+type APIBulkCreateRunResult struct {
+
+	// The created run. It is unset if the run failed.
+	Run *APIRunDetail `json:"run,omitempty"`
+
+	// Why the run failed. The code is 0 for the runs that were created.
+	Status *APIStatus `json:"status,omitempty"`
+}
+
+// Validate validates this api bulk create run result
+func (m *APIBulkCreateRunResult) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateRun(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateStatus(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *APIBulkCreateRunResult) validateRun(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Run) { // not required
+		return nil
+	}
+
+	if m.Run != nil {
+		if err := m.Run.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("run")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *APIBulkCreateRunResult) validateStatus(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Status) { // not required
+		return nil
+	}
+
+	if m.Status != nil {
+		if err := m.Status.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("status")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APIBulkCreateRunResult) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APIBulkCreateRunResult) UnmarshalBinary(b []byte) error {
+	var res APIBulkCreateRunResult
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
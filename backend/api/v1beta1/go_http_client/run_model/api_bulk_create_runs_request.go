@@ -0,0 +1,84 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package run_model
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"strconv"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/swag"
+)
+
+// APIBulkCreateRunsRequest api bulk create runs request
+// swagger:model apiBulkCreateRunsRequest
+type APIBulkCreateRunsRequest struct {
+
+	// Whether to create every run independently of the others. Otherwise, the runs created so far are removed
+	// as soon as one of them fails, and the error is returned.
+	AllowPartialFailure bool `json:"allow_partial_failure,omitempty"`
+
+	// The runs to create.
+	Runs []*APIRun `json:"runs"`
+}
+
+// Validate validates this api bulk create runs request
+func (m *APIBulkCreateRunsRequest) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateRuns(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *APIBulkCreateRunsRequest) validateRuns(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Runs) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Runs); i++ {
+		if swag.IsZero(m.Runs[i]) { // not required
+			continue
+		}
+
+		if m.Runs[i] != nil {
+			if err := m.Runs[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("runs" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APIBulkCreateRunsRequest) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APIBulkCreateRunsRequest) UnmarshalBinary(b []byte) error {
+	var res APIBulkCreateRunsRequest
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
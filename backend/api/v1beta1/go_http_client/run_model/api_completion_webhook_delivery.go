@@ -0,0 +1,74 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package run_model
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// APICompletionWebhookDelivery The delivery of the completion webhook of a run.
+// swagger:model apiCompletionWebhookDelivery
+type APICompletionWebhookDelivery struct {
+
+	// The time the delivery was last attempted.
+	// Format: date-time
+	AttemptedAt strfmt.DateTime `json:"attempted_at,omitempty"`
+
+	// Why the delivery failed, if it did.
+	Message string `json:"message,omitempty"`
+
+	// One of PENDING, DELIVERED or FAILED.
+	Status string `json:"status,omitempty"`
+}
+
+// Validate validates this api completion webhook delivery
+func (m *APICompletionWebhookDelivery) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAttemptedAt(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *APICompletionWebhookDelivery) validateAttemptedAt(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.AttemptedAt) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("attempted_at", "body", "date-time", m.AttemptedAt.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APICompletionWebhookDelivery) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APICompletionWebhookDelivery) UnmarshalBinary(b []byte) error {
+	var res APICompletionWebhookDelivery
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
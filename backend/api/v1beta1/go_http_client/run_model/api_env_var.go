@@ -0,0 +1,78 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package run_model
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/swag"
+)
+
+// APIEnvVar api env var
+// swagger:model apiEnvVar
+type APIEnvVar struct {
+
+	// The name of the environment variable.
+	Name string `json:"name,omitempty"`
+
+	// The value of the environment variable.
+	Value string `json:"value,omitempty"`
+
+	// Where the value of the environment variable is read from, if it has no
+	// value.
+	ValueFrom *APIEnvVarSource `json:"value_from,omitempty"`
+}
+
+// Validate validates this api env var
+func (m *APIEnvVar) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateValueFrom(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *APIEnvVar) validateValueFrom(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ValueFrom) { // not required
+		return nil
+	}
+
+	if m.ValueFrom != nil {
+		if err := m.ValueFrom.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("value_from")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APIEnvVar) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APIEnvVar) UnmarshalBinary(b []byte) error {
+	var res APIEnvVar
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
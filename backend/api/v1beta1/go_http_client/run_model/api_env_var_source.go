@@ -0,0 +1,146 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package run_model
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/swag"
+)
+
+// APIEnvVarSource api env var source
+// swagger:model apiEnvVarSource
+type APIEnvVarSource struct {
+
+	// A key of a config map.
+	ConfigMapKeyRef *APIKeySelector `json:"config_map_key_ref,omitempty"`
+
+	// A field of the pod, e.g. metadata.name.
+	FieldRef *APIObjectFieldSelector `json:"field_ref,omitempty"`
+
+	// A resource of the container, e.g. limits.memory.
+	ResourceFieldRef *APIResourceFieldSelector `json:"resource_field_ref,omitempty"`
+
+	// A key of a secret.
+	SecretKeyRef *APIKeySelector `json:"secret_key_ref,omitempty"`
+}
+
+// Validate validates this api env var source
+func (m *APIEnvVarSource) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateConfigMapKeyRef(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateFieldRef(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateResourceFieldRef(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSecretKeyRef(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *APIEnvVarSource) validateConfigMapKeyRef(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ConfigMapKeyRef) { // not required
+		return nil
+	}
+
+	if m.ConfigMapKeyRef != nil {
+		if err := m.ConfigMapKeyRef.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("config_map_key_ref")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *APIEnvVarSource) validateFieldRef(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.FieldRef) { // not required
+		return nil
+	}
+
+	if m.FieldRef != nil {
+		if err := m.FieldRef.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("field_ref")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *APIEnvVarSource) validateResourceFieldRef(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ResourceFieldRef) { // not required
+		return nil
+	}
+
+	if m.ResourceFieldRef != nil {
+		if err := m.ResourceFieldRef.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("resource_field_ref")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *APIEnvVarSource) validateSecretKeyRef(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.SecretKeyRef) { // not required
+		return nil
+	}
+
+	if m.SecretKeyRef != nil {
+		if err := m.SecretKeyRef.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("secret_key_ref")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APIEnvVarSource) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APIEnvVarSource) UnmarshalBinary(b []byte) error {
+	var res APIEnvVarSource
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
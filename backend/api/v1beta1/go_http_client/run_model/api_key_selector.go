@@ -0,0 +1,49 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package run_model
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/swag"
+)
+
+// APIKeySelector api key selector
+// swagger:model apiKeySelector
+type APIKeySelector struct {
+
+	// The key of the secret or config map.
+	Key string `json:"key,omitempty"`
+
+	// The name of the secret or config map.
+	Name string `json:"name,omitempty"`
+
+	// Whether the secret or config map and the key may be missing.
+	Optional bool `json:"optional,omitempty"`
+}
+
+// Validate validates this api key selector
+func (m *APIKeySelector) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APIKeySelector) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APIKeySelector) UnmarshalBinary(b []byte) error {
+	var res APIKeySelector
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,46 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package run_model
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/swag"
+)
+
+// APIObjectFieldSelector api object field selector
+// swagger:model apiObjectFieldSelector
+type APIObjectFieldSelector struct {
+
+	// The version of the schema the field path is written in, v1 by default.
+	APIVersion string `json:"api_version,omitempty"`
+
+	// The path of the field.
+	FieldPath string `json:"field_path,omitempty"`
+}
+
+// Validate validates this api object field selector
+func (m *APIObjectFieldSelector) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APIObjectFieldSelector) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APIObjectFieldSelector) UnmarshalBinary(b []byte) error {
+	var res APIObjectFieldSelector
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,49 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package run_model
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/swag"
+)
+
+// APIResourceFieldSelector api resource field selector
+// swagger:model apiResourceFieldSelector
+type APIResourceFieldSelector struct {
+
+	// The container whose resource is read, the one of the variable by default.
+	ContainerName string `json:"container_name,omitempty"`
+
+	// The quantity the resource is divided by, e.g. 1Mi.
+	Divisor string `json:"divisor,omitempty"`
+
+	// The resource, e.g. limits.memory.
+	Resource string `json:"resource,omitempty"`
+}
+
+// Validate validates this api resource field selector
+func (m *APIResourceFieldSelector) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APIResourceFieldSelector) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APIResourceFieldSelector) UnmarshalBinary(b []byte) error {
+	var res APIResourceFieldSelector
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,47 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package run_model
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/swag"
+)
+
+// APIResourceRequirements The compute resources of a container. Quantities use the Kubernetes format,
+// e.g. 500m or 8Gi.
+// swagger:model apiResourceRequirements
+type APIResourceRequirements struct {
+
+	// The maximum amount of each resource the container may use, e.g. memory.
+	Limits map[string]string `json:"limits,omitempty"`
+
+	// The amount of each resource the container is guaranteed, e.g. cpu.
+	Requests map[string]string `json:"requests,omitempty"`
+}
+
+// Validate validates this api resource requirements
+func (m *APIResourceRequirements) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APIResourceRequirements) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APIResourceRequirements) UnmarshalBinary(b []byte) error {
+	var res APIResourceRequirements
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
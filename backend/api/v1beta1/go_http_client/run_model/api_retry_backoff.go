@@ -0,0 +1,50 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package run_model
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/swag"
+)
+
+// APIRetryBackoff api retry backoff
+// swagger:model apiRetryBackoff
+type APIRetryBackoff struct {
+
+	// The wait before the first retry, a number of seconds or a duration, e.g.
+	// 90s or 2m.
+	Duration string `json:"duration,omitempty"`
+
+	// The factor the wait is multiplied by at each retry.
+	Factor int32 `json:"factor,omitempty"`
+
+	// The maximum wait before a retry.
+	MaxDuration string `json:"max_duration,omitempty"`
+}
+
+// Validate validates this api retry backoff
+func (m *APIRetryBackoff) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APIRetryBackoff) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APIRetryBackoff) UnmarshalBinary(b []byte) error {
+	var res APIRetryBackoff
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,85 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package run_model
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/swag"
+)
+
+// APIRetryStrategy api retry strategy
+// swagger:model apiRetryStrategy
+type APIRetryStrategy struct {
+
+	// The wait before each retry.
+	Backoff *APIRetryBackoff `json:"backoff,omitempty"`
+
+	// Whether the retry strategy replaces the ones the templates have.
+	Force bool `json:"force,omitempty"`
+
+	// The maximum number of retries of a failed step.
+	Limit int32 `json:"limit,omitempty"`
+
+	// When a failed step is retried, one of Always, OnFailure, OnError and
+	// OnTransientError. Steps are retried on failures only by default.
+	RetryPolicy string `json:"retry_policy,omitempty"`
+
+	// The templates the retry strategy is set on. All the templates that run a
+	// pod get it if none is named.
+	Templates []string `json:"templates"`
+}
+
+// Validate validates this api retry strategy
+func (m *APIRetryStrategy) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateBackoff(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *APIRetryStrategy) validateBackoff(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Backoff) { // not required
+		return nil
+	}
+
+	if m.Backoff != nil {
+		if err := m.Backoff.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("backoff")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APIRetryStrategy) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APIRetryStrategy) UnmarshalBinary(b []byte) error {
+	var res APIRetryStrategy
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
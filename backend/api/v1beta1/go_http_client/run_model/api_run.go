@@ -19,13 +19,40 @@ import (
 // swagger:model apiRun
 type APIRun struct {
 
+	// Optional input field. The HTTP(S) URL POSTed to when the run reaches a
+	// terminal state. In multi-user mode its host must be allowed by the server.
+	// The default webhook of the experiment is used if not specified. Output.
+	// The completion webhook the run set.
+	CompletionWebhook string `json:"completion_webhook,omitempty"`
+
+	// Output. The last delivery of the completion webhook of the run, unset if
+	// none was attempted.
+	CompletionWebhookDelivery *APICompletionWebhookDelivery `json:"completion_webhook_delivery,omitempty"`
+
 	// Output. The time that the run created.
 	// Format: date-time
 	CreatedAt strfmt.DateTime `json:"created_at,omitempty"`
 
+	// Output. The identity of the user that created the run, if the request was
+	// authenticated. ListRuns filters on it as created_by.
+	CreatedBy string `json:"created_by,omitempty"`
+
 	// Optional input field. Describing the purpose of the run
 	Description string `json:"description,omitempty"`
 
+	// Output. How long the workflow of the run executed, or has been executing
+	// until now if the run is active.
+	DurationSeconds string `json:"duration_seconds,omitempty"`
+
+	// Optional input field. The environment variables added to the containers of
+	// the run. Secrets and config maps are read from the namespace of the run.
+	// Output. The environment variables the run added.
+	Env []*APIEnvVar `json:"env"`
+
+	// Optional input field. The templates whose containers get the environment
+	// variables. All of them get the variables if none is named.
+	EnvTemplates []string `json:"env_templates"`
+
 	// In case any error happens retrieving a run field, only run ID
 	// and the error message is returned. Client has the flexibility of choosing
 	// how to handle error. This is especially useful during listing call.
@@ -38,6 +65,10 @@ type APIRun struct {
 	// Output. Unique run ID. Generated by API server.
 	ID string `json:"id,omitempty"`
 
+	// Output. The labels of the workflow of the run, e.g. model-name. ListRuns
+	// filters on them as labels.<key>.
+	Labels map[string]string `json:"labels,omitempty"`
+
 	// Output. The metrics of the run. The metrics are reported by ReportMetrics
 	// API.
 	Metrics []*APIRunMetric `json:"metrics"`
@@ -46,15 +77,52 @@ type APIRun struct {
 	// or auto generated if run is created by scheduled job. Not unique.
 	Name string `json:"name,omitempty"`
 
+	// Output. The namespace the run belongs to.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Optional input field. The node selector added to every pod of the run. Keys
+	// must be valid label keys. Output. The node selector of the workflow of the
+	// run, including the one declared in the manifest.
+	NodeSelector map[string]string `json:"node_selector,omitempty"`
+
+	// Optional input field. The object store URI, e.g. gs://bucket/prefix, the
+	// output artifacts of the run are stored under. It takes precedence over the
+	// pipeline root of the runtime config and of the namespace. Output. The
+	// pipeline root the run uses.
+	PipelineRoot string `json:"pipeline_root,omitempty"`
+
 	// Required input field.
 	// Describing what the pipeline manifest and parameters to use for the run.
 	PipelineSpec *APIPipelineSpec `json:"pipeline_spec,omitempty"`
 
+	// Optional input field. The labels and annotations set on every pod of the
+	// run. Keys prefixed with kubeflow.org, workflows.argoproj.io or pipeline are
+	// reserved. Output. The labels and annotations the run set.
+	PodMetadata *APIPodMetadata `json:"pod_metadata,omitempty"`
+
+	// Optional input field. The priority class set on every pod of the run. It
+	// must exist. Output. The priority class of the workflow of the run,
+	// including one declared in the manifest.
+	PriorityClassName string `json:"priority_class_name,omitempty"`
+
+	// Output. How long the run waited from its creation until its first pod
+	// started, or until now if none has.
+	QueueWaitSeconds string `json:"queue_wait_seconds,omitempty"`
+
+	// Optional input field. The container resources set on the templates of
+	// the workflow of the run, keyed by template name. Every template must exist
+	// in the workflow. Output. The container resources the run set.
+	ResourceOverrides map[string]APIResourceRequirements `json:"resource_overrides,omitempty"`
+
 	// Optional input field. Specify which resource this run belongs to.
 	// When creating a run from a particular pipeline version, the pipeline
 	// version can be specified here.
 	ResourceReferences []*APIResourceReference `json:"resource_references"`
 
+	// Optional input field. The retry strategy set on the templates of the run.
+	// Output. The retry strategy the run set.
+	RetryStrategy *APIRetryStrategy `json:"retry_strategy,omitempty"`
+
 	// Output. When this run is scheduled to run. This could be different from
 	// created_at. For example, if a run is from a backfilling job that was
 	// supposed to run 2 month ago, the scheduled_at is 2 month ago,
@@ -63,24 +131,54 @@ type APIRun struct {
 	ScheduledAt strfmt.DateTime `json:"scheduled_at,omitempty"`
 
 	// Optional input field. Specify which Kubernetes service account this run uses.
+	// In multi-user mode, the user must be allowed to use the service account in
+	// the namespace of the run, unless it is the default pipeline runner.
 	ServiceAccount string `json:"service_account,omitempty"`
 
 	// Output. The status of the run.
 	// One of [Pending, Running, Succeeded, Skipped, Failed, Error]
 	Status string `json:"status,omitempty"`
 
+	// Output. Where the status of the run is read from: live, from the workflow
+	// of the run, or archived, from the snapshot persisted when the run finished,
+	// once the TTL of the workflow has passed and it was garbage collected.
+	StatusSource string `json:"status_source,omitempty"`
+
 	// Output. Specify whether this run is in archived or available mode.
 	StorageState APIRunStorageState `json:"storage_state,omitempty"`
+
+	// Optional input field. The tolerations added to every pod of the run.
+	// Output. The tolerations of the workflow of the run, including those
+	// declared in the manifest.
+	Tolerations []*APIToleration `json:"tolerations"`
+
+	// Optional input field. The number of seconds the workflow of the run is
+	// kept after it finishes, overriding the TTL declared in the manifest. Zero
+	// deletes the workflow as soon as it finishes. Output. The effective TTL,
+	// unset if the workflow is kept until it is garbage collected.
+	TTLSecondsAfterFinished int32 `json:"ttl_seconds_after_finished,omitempty"`
+
+	// Output. The UID of the workflow of the run. It is kept after the workflow
+	// is deleted.
+	WorkflowUID string `json:"workflow_uid,omitempty"`
 }
 
 // Validate validates this api run
 func (m *APIRun) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateCompletionWebhookDelivery(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateCreatedAt(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validateEnv(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateFinishedAt(formats); err != nil {
 		res = append(res, err)
 	}
@@ -93,10 +191,22 @@ func (m *APIRun) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validatePodMetadata(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateResourceOverrides(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateResourceReferences(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validateRetryStrategy(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateScheduledAt(formats); err != nil {
 		res = append(res, err)
 	}
@@ -105,12 +215,34 @@ func (m *APIRun) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateTolerations(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
 
+func (m *APIRun) validateCompletionWebhookDelivery(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.CompletionWebhookDelivery) { // not required
+		return nil
+	}
+
+	if m.CompletionWebhookDelivery != nil {
+		if err := m.CompletionWebhookDelivery.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("completion_webhook_delivery")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *APIRun) validateCreatedAt(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.CreatedAt) { // not required
@@ -124,6 +256,31 @@ func (m *APIRun) validateCreatedAt(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *APIRun) validateEnv(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Env) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Env); i++ {
+		if swag.IsZero(m.Env[i]) { // not required
+			continue
+		}
+
+		if m.Env[i] != nil {
+			if err := m.Env[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("env" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *APIRun) validateFinishedAt(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.FinishedAt) { // not required
@@ -180,6 +337,46 @@ func (m *APIRun) validatePipelineSpec(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *APIRun) validatePodMetadata(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.PodMetadata) { // not required
+		return nil
+	}
+
+	if m.PodMetadata != nil {
+		if err := m.PodMetadata.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("pod_metadata")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *APIRun) validateResourceOverrides(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ResourceOverrides) { // not required
+		return nil
+	}
+
+	for k := range m.ResourceOverrides {
+
+		if err := validate.Required("resource_overrides"+"."+k, "body", m.ResourceOverrides[k]); err != nil {
+			return err
+		}
+		if val, ok := m.ResourceOverrides[k]; ok {
+			if err := val.Validate(formats); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *APIRun) validateResourceReferences(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.ResourceReferences) { // not required
@@ -205,6 +402,24 @@ func (m *APIRun) validateResourceReferences(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *APIRun) validateRetryStrategy(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.RetryStrategy) { // not required
+		return nil
+	}
+
+	if m.RetryStrategy != nil {
+		if err := m.RetryStrategy.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("retry_strategy")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *APIRun) validateScheduledAt(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.ScheduledAt) { // not required
@@ -234,6 +449,31 @@ func (m *APIRun) validateStorageState(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *APIRun) validateTolerations(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Tolerations) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Tolerations); i++ {
+		if swag.IsZero(m.Tolerations[i]) { // not required
+			continue
+		}
+
+		if m.Tolerations[i] != nil {
+			if err := m.Tolerations[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("tolerations" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *APIRun) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package run_model
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/swag"
+)
+
+// APIToleration A Kubernetes toleration of the pods of a run.
+// swagger:model apiToleration
+type APIToleration struct {
+
+	// The taint effect the toleration matches, one of NoSchedule, PreferNoSchedule
+	// or NoExecute. Empty matches all effects.
+	Effect string `json:"effect,omitempty"`
+
+	// The taint key the toleration matches. Empty matches all taints, with the
+	// Exists operator.
+	Key string `json:"key,omitempty"`
+
+	// One of Equal, the default, or Exists.
+	Operator string `json:"operator,omitempty"`
+
+	// How long a pod stays bound to a node tainted with NoExecute. Unset
+	// tolerates the taint forever.
+	TolerationSeconds string `json:"toleration_seconds,omitempty"`
+
+	// The taint value the toleration matches, with the Equal operator.
+	Value string `json:"value,omitempty"`
+}
+
+// Validate validates this api toleration
+func (m *APIToleration) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APIToleration) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APIToleration) UnmarshalBinary(b []byte) error {
+	var res APIToleration
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -192,6 +192,34 @@ func (a *Client) UnarchiveExperiment(params *UnarchiveExperimentParams) (*Unarch
 
 }
 
+/*
+UpdateExperiment updates the display name and description of an experiment the update fails with a conflict if resource version is set and the experiment was updated since it was read at that version
+*/
+func (a *Client) UpdateExperiment(params *UpdateExperimentParams) (*UpdateExperimentOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewUpdateExperimentParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "UpdateExperiment",
+		Method:             "PATCH",
+		PathPattern:        "/apis/v2beta1/experiments/{experiment.experiment_id}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &UpdateExperimentReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*UpdateExperimentOK), nil
+
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
@@ -68,6 +68,11 @@ type ListExperimentsParams struct {
 
 	*/
 	Filter *string
+	/*IncludeDeleted
+	  Whether to also list the experiments that were soft deleted and not reaped yet.
+
+	*/
+	IncludeDeleted *bool
 	/*Namespace
 	  Which namespace to filter the experiments on.
 
@@ -143,6 +148,17 @@ func (o *ListExperimentsParams) SetFilter(filter *string) {
 	o.Filter = filter
 }
 
+// WithIncludeDeleted adds the includeDeleted to the list experiments params
+func (o *ListExperimentsParams) WithIncludeDeleted(includeDeleted *bool) *ListExperimentsParams {
+	o.SetIncludeDeleted(includeDeleted)
+	return o
+}
+
+// SetIncludeDeleted adds the includeDeleted to the list experiments params
+func (o *ListExperimentsParams) SetIncludeDeleted(includeDeleted *bool) {
+	o.IncludeDeleted = includeDeleted
+}
+
 // WithNamespace adds the namespace to the list experiments params
 func (o *ListExperimentsParams) WithNamespace(namespace *string) *ListExperimentsParams {
 	o.SetNamespace(namespace)
@@ -211,6 +227,22 @@ func (o *ListExperimentsParams) WriteToRequest(r runtime.ClientRequest, reg strf
 
 	}
 
+	if o.IncludeDeleted != nil {
+
+		// query param include_deleted
+		var qrIncludeDeleted bool
+		if o.IncludeDeleted != nil {
+			qrIncludeDeleted = *o.IncludeDeleted
+		}
+		qIncludeDeleted := swag.FormatBool(qrIncludeDeleted)
+		if qIncludeDeleted != "" {
+			if err := r.SetQueryParam("include_deleted", qIncludeDeleted); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	if o.Namespace != nil {
 
 		// query param namespace
@@ -0,0 +1,68 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package experiment_service
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	experiment_model "github.com/kubeflow/pipelines/backend/api/v2beta1/go_http_client/experiment_model"
+)
+
+// UpdateExperimentReader is a Reader for the UpdateExperiment structure.
+type UpdateExperimentReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *UpdateExperimentReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+
+	case 200:
+		result := NewUpdateExperimentOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewUpdateExperimentOK creates a UpdateExperimentOK with default headers values
+func NewUpdateExperimentOK() *UpdateExperimentOK {
+	return &UpdateExperimentOK{}
+}
+
+/*
+UpdateExperimentOK handles this case with default header values.
+
+A successful response.
+*/
+type UpdateExperimentOK struct {
+	Payload *experiment_model.V2beta1Experiment
+}
+
+func (o *UpdateExperimentOK) Error() string {
+	return fmt.Sprintf("[PATCH /apis/v2beta1/experiments/{experiment.experiment_id}][%d] updateExperimentOK  %+v", 200, o.Payload)
+}
+
+func (o *UpdateExperimentOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(experiment_model.V2beta1Experiment)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -33,6 +33,11 @@ type V2beta1Experiment struct {
 	// Optional input field. Specify the namespace this experiment belongs to.
 	Namespace string `json:"namespace,omitempty"`
 
+	// The version of the experiment, bumped by every update of it. Optional
+	// input field of UpdateExperiment: the update fails if the experiment is no
+	// longer at this version.
+	ResourceVersion string `json:"resource_version,omitempty"`
+
 	// Output. Specifies whether this experiment is in archived or available state.
 	StorageState V2beta1ExperimentStorageState `json:"storage_state,omitempty"`
 }
@@ -45,6 +45,14 @@ type V2beta1RecurringRun struct {
 	// Namespace this recurring run belongs to.
 	Namespace string `json:"namespace,omitempty"`
 
+	// Output. When the recurring run is expected to create its next run. It is
+	// unset if it won't create any, in which case next_run_reason says why.
+	// Format: date-time
+	NextRunAt strfmt.DateTime `json:"next_run_at,omitempty"`
+
+	// Output. Why the recurring run won't create any more runs.
+	NextRunReason string `json:"next_run_reason,omitempty"`
+
 	// Optional input field. Whether the recurring run should catch up if behind schedule.
 	// If true, the recurring run will only schedule the latest interval if behind schedule.
 	// If false, the recurring run will catch up on each past interval.
@@ -89,6 +97,10 @@ func (m *V2beta1RecurringRun) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateNextRunAt(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateRuntimeConfig(formats); err != nil {
 		res = append(res, err)
 	}
@@ -140,6 +152,19 @@ func (m *V2beta1RecurringRun) validateMode(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *V2beta1RecurringRun) validateNextRunAt(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.NextRunAt) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("next_run_at", "body", "date-time", m.NextRunAt.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *V2beta1RecurringRun) validateRuntimeConfig(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.RuntimeConfig) { // not required
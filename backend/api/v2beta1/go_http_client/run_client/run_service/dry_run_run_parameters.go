@@ -0,0 +1,161 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package run_service
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	run_model "github.com/kubeflow/pipelines/backend/api/v2beta1/go_http_client/run_model"
+)
+
+// NewDryRunRunParams creates a new DryRunRunParams object
+// with the default values initialized.
+func NewDryRunRunParams() *DryRunRunParams {
+	var ()
+	return &DryRunRunParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewDryRunRunParamsWithTimeout creates a new DryRunRunParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewDryRunRunParamsWithTimeout(timeout time.Duration) *DryRunRunParams {
+	var ()
+	return &DryRunRunParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewDryRunRunParamsWithContext creates a new DryRunRunParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewDryRunRunParamsWithContext(ctx context.Context) *DryRunRunParams {
+	var ()
+	return &DryRunRunParams{
+
+		Context: ctx,
+	}
+}
+
+// NewDryRunRunParamsWithHTTPClient creates a new DryRunRunParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewDryRunRunParamsWithHTTPClient(client *http.Client) *DryRunRunParams {
+	var ()
+	return &DryRunRunParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+DryRunRunParams contains all the parameters to send to the API endpoint
+for the dry run run operation typically these are written to a http.Request
+*/
+type DryRunRunParams struct {
+
+	/*Body
+	  Run to be created.
+
+	*/
+	Body *run_model.V2beta1Run
+	/*ExperimentID
+	  The ID of the parent experiment.
+
+	*/
+	ExperimentID string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the dry run run params
+func (o *DryRunRunParams) WithTimeout(timeout time.Duration) *DryRunRunParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the dry run run params
+func (o *DryRunRunParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the dry run run params
+func (o *DryRunRunParams) WithContext(ctx context.Context) *DryRunRunParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the dry run run params
+func (o *DryRunRunParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the dry run run params
+func (o *DryRunRunParams) WithHTTPClient(client *http.Client) *DryRunRunParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the dry run run params
+func (o *DryRunRunParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithBody adds the body to the dry run run params
+func (o *DryRunRunParams) WithBody(body *run_model.V2beta1Run) *DryRunRunParams {
+	o.SetBody(body)
+	return o
+}
+
+// SetBody adds the body to the dry run run params
+func (o *DryRunRunParams) SetBody(body *run_model.V2beta1Run) {
+	o.Body = body
+}
+
+// WithExperimentID adds the experimentID to the dry run run params
+func (o *DryRunRunParams) WithExperimentID(experimentID string) *DryRunRunParams {
+	o.SetExperimentID(experimentID)
+	return o
+}
+
+// SetExperimentID adds the experimentId to the dry run run params
+func (o *DryRunRunParams) SetExperimentID(experimentID string) {
+	o.ExperimentID = experimentID
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *DryRunRunParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if o.Body != nil {
+		if err := r.SetBodyParam(o.Body); err != nil {
+			return err
+		}
+	}
+
+	// path param experiment_id
+	if err := r.SetPathParam("experiment_id", o.ExperimentID); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package run_service
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	run_model "github.com/kubeflow/pipelines/backend/api/v2beta1/go_http_client/run_model"
+)
+
+// DryRunRunReader is a Reader for the DryRunRun structure.
+type DryRunRunReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *DryRunRunReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+
+	case 200:
+		result := NewDryRunRunOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+
+	default:
+		result := NewDryRunRunDefault(response.Code())
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		if response.Code()/100 == 2 {
+			return result, nil
+		}
+		return nil, result
+	}
+}
+
+// NewDryRunRunOK creates a DryRunRunOK with default headers values
+func NewDryRunRunOK() *DryRunRunOK {
+	return &DryRunRunOK{}
+}
+
+/*
+DryRunRunOK handles this case with default header values.
+
+A successful response.
+*/
+type DryRunRunOK struct {
+	Payload *run_model.V2beta1DryRunRunResponse
+}
+
+func (o *DryRunRunOK) Error() string {
+	return fmt.Sprintf("[POST /apis/v2beta1/experiments/{experiment_id}/runs:dryRun][%d] dryRunRunOK  %+v", 200, o.Payload)
+}
+
+func (o *DryRunRunOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(run_model.V2beta1DryRunRunResponse)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewDryRunRunDefault creates a DryRunRunDefault with default headers values
+func NewDryRunRunDefault(code int) *DryRunRunDefault {
+	return &DryRunRunDefault{
+		_statusCode: code,
+	}
+}
+
+/*
+DryRunRunDefault handles this case with default header values.
+
+DryRunRunDefault dry run run default
+*/
+type DryRunRunDefault struct {
+	_statusCode int
+
+	Payload *run_model.GooglerpcStatus
+}
+
+// Code gets the status code for the dry run run default response
+func (o *DryRunRunDefault) Code() int {
+	return o._statusCode
+}
+
+func (o *DryRunRunDefault) Error() string {
+	return fmt.Sprintf("[POST /apis/v2beta1/experiments/{experiment_id}/runs:dryRun][%d] DryRunRun default  %+v", o._statusCode, o.Payload)
+}
+
+func (o *DryRunRunDefault) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(run_model.GooglerpcStatus)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -111,6 +111,35 @@ func (a *Client) DeleteRun(params *DeleteRunParams, authInfo runtime.ClientAuthI
 
 }
 
+/*
+DryRunRun compiles a run the same way as create run without submitting its workflow or storing it
+*/
+func (a *Client) DryRunRun(params *DryRunRunParams, authInfo runtime.ClientAuthInfoWriter) (*DryRunRunOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewDryRunRunParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "DryRunRun",
+		Method:             "POST",
+		PathPattern:        "/apis/v2beta1/experiments/{experiment_id}/runs:dryRun",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &DryRunRunReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*DryRunRunOK), nil
+
+}
+
 /*
 GetRun finds a specific run by ID
 */
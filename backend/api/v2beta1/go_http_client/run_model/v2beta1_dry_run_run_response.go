@@ -0,0 +1,46 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package run_model
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/swag"
+)
+
+// V2beta1DryRunRunResponse v2beta1 dry run run response
+// swagger:model v2beta1DryRunRunResponse
+type V2beta1DryRunRunResponse struct {
+
+	// The problems found when validating the workflow. The run would fail to be created if there are any.
+	Diagnostics []string `json:"diagnostics"`
+
+	// The workflow that would be submitted for the run, in YAML.
+	WorkflowManifest string `json:"workflow_manifest,omitempty"`
+}
+
+// Validate validates this v2beta1 dry run run response
+func (m *V2beta1DryRunRunResponse) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *V2beta1DryRunRunResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *V2beta1DryRunRunResponse) UnmarshalBinary(b []byte) error {
+	var res V2beta1DryRunRunResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
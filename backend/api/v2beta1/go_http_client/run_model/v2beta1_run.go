@@ -23,6 +23,10 @@ type V2beta1Run struct {
 	// Format: date-time
 	CreatedAt strfmt.DateTime `json:"created_at,omitempty"`
 
+	// Output. The identity of the user that created the run, if the request was
+	// authenticated. ListRuns filters on it as created_by.
+	CreatedBy string `json:"created_by,omitempty"`
+
 	// Optional input. Short description of the run.
 	Description string `json:"description,omitempty"`
 
@@ -30,6 +34,10 @@ type V2beta1Run struct {
 	// or auto generated if run is created by a recurring run.
 	DisplayName string `json:"display_name,omitempty"`
 
+	// Output. How long the workflow of the run executed, or has been executing
+	// until now if the run is active.
+	DurationSeconds string `json:"duration_seconds,omitempty"`
+
 	// In case any error happens retrieving a run field, only run ID
 	// and the error message is returned. Client has the flexibility of choosing
 	// how to handle the error. This is especially useful during listing call.
@@ -43,12 +51,23 @@ type V2beta1Run struct {
 	// Format: date-time
 	FinishedAt strfmt.DateTime `json:"finished_at,omitempty"`
 
+	// Output. The labels of the workflow of the run, e.g. model-name. ListRuns
+	// filters on them as labels.<key>.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Output. The namespace the run belongs to.
+	Namespace string `json:"namespace,omitempty"`
+
 	// ID of existing pipeline.
 	PipelineID string `json:"pipeline_id,omitempty"`
 
 	// Pipeline spec.
 	PipelineSpec interface{} `json:"pipeline_spec,omitempty"`
 
+	// Output. How long the run waited from its creation until its first pod
+	// started, or until now if none has.
+	QueueWaitSeconds string `json:"queue_wait_seconds,omitempty"`
+
 	// ID of the recurring run that triggered this run.
 	RecurringRunID string `json:"recurring_run_id,omitempty"`
 
@@ -71,6 +90,8 @@ type V2beta1Run struct {
 	ScheduledAt strfmt.DateTime `json:"scheduled_at,omitempty"`
 
 	// Optional input. Specifies which kubernetes service account is used.
+	// In multi-user mode, the user must be allowed to use the service account in
+	// the namespace of the run, unless it is the default pipeline runner.
 	ServiceAccount string `json:"service_account,omitempty"`
 
 	// Output. Runtime state of a run.
@@ -80,8 +101,17 @@ type V2beta1Run struct {
 	// of state transitions.
 	StateHistory []*V2beta1RuntimeStatus `json:"state_history"`
 
+	// Output. Where the status of the run is read from: live, from the workflow
+	// of the run, or archived, from the snapshot persisted when the run finished,
+	// once the TTL of the workflow has passed and it was garbage collected.
+	StatusSource string `json:"status_source,omitempty"`
+
 	// Output. Specifies whether this run is in archived or available mode.
 	StorageState V2beta1RunStorageState `json:"storage_state,omitempty"`
+
+	// Output. The UID of the workflow of the run. It is kept after the workflow
+	// is deleted.
+	WorkflowUID string `json:"workflow_uid,omitempty"`
 }
 
 // Validate validates this v2beta1 run
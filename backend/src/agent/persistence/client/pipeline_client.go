@@ -31,6 +31,8 @@ import (
 
 const (
 	addressTemp = "%s:%s"
+	// maxWorkflowReportBatchSize is the most workflows sent in one ReportWorkflowsV1 call.
+	maxWorkflowReportBatchSize = 100
 )
 
 type PipelineClientInterface interface {
@@ -45,6 +47,14 @@ type PipelineClient struct {
 	timeout             time.Duration
 	reportServiceClient api.ReportServiceClient
 	runServiceClient    api.RunServiceClient
+	// workflowReports queues the workflows reported by the workers until they're sent.
+	workflowReports chan *workflowReport
+}
+
+// workflowReport is a workflow waiting to be reported, and where to send the outcome.
+type workflowReport struct {
+	workflow util.ExecutionSpec
+	result   chan error
 }
 
 func NewPipelineClient(
@@ -67,45 +77,98 @@ func NewPipelineClient(
 			"Failed to get RPC connection. Error: %s", err.Error())
 	}
 
-	return &PipelineClient{
+	pipelineClient := &PipelineClient{
 		initializeTimeout:   initializeTimeout,
 		timeout:             timeout,
 		reportServiceClient: api.NewReportServiceClient(connection),
 		runServiceClient:    api.NewRunServiceClient(connection),
-	}, nil
+		workflowReports:     make(chan *workflowReport),
+	}
+	go pipelineClient.sendWorkflowReports()
+	return pipelineClient, nil
 }
 
+// ReportWorkflow reports a workflow to the API server. The workflows reported by several workers at the same
+// time are sent together in a single ReportWorkflowsV1 call.
 func (p *PipelineClient) ReportWorkflow(workflow util.ExecutionSpec) error {
+	report := &workflowReport{workflow: workflow, result: make(chan error, 1)}
+	p.workflowReports <- report
+	return <-report.result
+}
+
+// sendWorkflowReports sends the queued workflows in batches. While a batch is being sent, the workflows
+// reported in the meantime queue up for the next one, so batches only wait for the call in flight.
+func (p *PipelineClient) sendWorkflowReports() {
+	for report := range p.workflowReports {
+		batch := []*workflowReport{report}
+	collect:
+		for len(batch) < maxWorkflowReportBatchSize {
+			select {
+			case report := <-p.workflowReports:
+				batch = append(batch, report)
+			default:
+				break collect
+			}
+		}
+		workflows := make([]util.ExecutionSpec, len(batch))
+		for i, report := range batch {
+			workflows[i] = report.workflow
+		}
+		for i, err := range p.reportWorkflows(workflows) {
+			batch[i].result <- err
+		}
+	}
+}
+
+// reportWorkflows sends workflows in one ReportWorkflowsV1 call, returning an error for each of them.
+func (p *PipelineClient) reportWorkflows(workflows []util.ExecutionSpec) []error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
 
-	_, err := p.reportServiceClient.ReportWorkflowV1(ctx, &api.ReportWorkflowRequest{
-		Workflow: workflow.ToStringForStore(),
-	})
-
+	request := &api.ReportWorkflowsRequest{Workflows: make([]string, len(workflows))}
+	for i, workflow := range workflows {
+		request.Workflows[i] = workflow.ToStringForStore()
+	}
+	errs := make([]error, len(workflows))
+	response, err := p.reportServiceClient.ReportWorkflowsV1(ctx, request)
+	if err == nil && len(response.GetResults()) != len(workflows) {
+		err = status.Errorf(codes.Internal, "got %v results for %v workflows", len(response.GetResults()), len(workflows))
+	}
 	if err != nil {
-		statusCode, _ := status.FromError(err)
-		if statusCode.Code() == codes.InvalidArgument || statusCode.Code() == codes.NotFound {
-			// Do not retry if either:
-			// * there is something wrong with the workflow
-			// * the workflow has been deleted by someone else
-			return util.NewCustomError(err, util.CUSTOM_CODE_PERMANENT,
-				"Error while reporting workflow resource (code: %v, message: %v): %v, %+v",
-				statusCode.Code(),
-				statusCode.Message(),
-				err.Error(),
-				workflow.ToStringForStore())
-		} else {
-			// Retry otherwise
-			return util.NewCustomError(err, util.CUSTOM_CODE_TRANSIENT,
-				"Error while reporting workflow resource (code: %v, message: %v): %v, %+v",
-				statusCode.Code(),
-				statusCode.Message(),
-				err.Error(),
-				workflow.ToStringForStore())
+		for i, workflow := range workflows {
+			errs[i] = newReportWorkflowError(status.Convert(err), err, workflow)
 		}
+		return errs
 	}
-	return nil
+	for i, result := range response.GetResults() {
+		if codes.Code(result.GetCode()) != codes.OK {
+			statusCode := status.New(codes.Code(result.GetCode()), result.GetError())
+			errs[i] = newReportWorkflowError(statusCode, statusCode.Err(), workflows[i])
+		}
+	}
+	return errs
+}
+
+// newReportWorkflowError classifies the failure to report a workflow as permanent or transient.
+func newReportWorkflowError(statusCode *status.Status, err error, workflow util.ExecutionSpec) error {
+	if statusCode.Code() == codes.InvalidArgument || statusCode.Code() == codes.NotFound {
+		// Do not retry if either:
+		// * there is something wrong with the workflow
+		// * the workflow has been deleted by someone else
+		return util.NewCustomError(err, util.CUSTOM_CODE_PERMANENT,
+			"Error while reporting workflow resource (code: %v, message: %v): %v, %+v",
+			statusCode.Code(),
+			statusCode.Message(),
+			err.Error(),
+			workflow.ToStringForStore())
+	}
+	// Retry otherwise
+	return util.NewCustomError(err, util.CUSTOM_CODE_TRANSIENT,
+		"Error while reporting workflow resource (code: %v, message: %v): %v, %+v",
+		statusCode.Code(),
+		statusCode.Message(),
+		err.Error(),
+		workflow.ToStringForStore())
 }
 
 func (p *PipelineClient) ReportScheduledWorkflow(swf *util.ScheduledWorkflow) error {
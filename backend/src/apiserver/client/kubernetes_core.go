@@ -7,26 +7,38 @@ import (
 	"github.com/golang/glog"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	schedulingv1 "k8s.io/client-go/kubernetes/typed/scheduling/v1"
 )
 
 type KubernetesCoreInterface interface {
 	PodClient(namespace string) v1.PodInterface
+	SecretClient(namespace string) v1.SecretInterface
+	PriorityClassClient() schedulingv1.PriorityClassInterface
 }
 
 type KubernetesCore struct {
-	coreV1Client v1.CoreV1Interface
+	coreV1Client       v1.CoreV1Interface
+	schedulingV1Client schedulingv1.SchedulingV1Interface
 }
 
 func (c *KubernetesCore) PodClient(namespace string) v1.PodInterface {
 	return c.coreV1Client.Pods(namespace)
 }
 
+func (c *KubernetesCore) SecretClient(namespace string) v1.SecretInterface {
+	return c.coreV1Client.Secrets(namespace)
+}
+
+func (c *KubernetesCore) PriorityClassClient() schedulingv1.PriorityClassInterface {
+	return c.schedulingV1Client.PriorityClasses()
+}
+
 func createKubernetesCore(clientParams util.ClientParameters) (KubernetesCoreInterface, error) {
 	clientSet, err := getKubernetesClientset(clientParams)
 	if err != nil {
 		return nil, err
 	}
-	return &KubernetesCore{clientSet.CoreV1()}, nil
+	return &KubernetesCore{clientSet.CoreV1(), clientSet.SchedulingV1()}, nil
 }
 
 // CreateKubernetesCoreOrFatal creates a new client for the Kubernetes pod.
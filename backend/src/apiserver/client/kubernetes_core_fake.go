@@ -20,11 +20,15 @@ import (
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	policyv1 "k8s.io/api/policy/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/client-go/kubernetes/fake"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	schedulingv1 "k8s.io/client-go/kubernetes/typed/scheduling/v1"
 )
 
 type FakeKuberneteCoreClient struct {
-	podClientFake *FakePodClient
+	podClientFake    *FakePodClient
+	coreV1Fake       v1.CoreV1Interface
+	schedulingV1Fake schedulingv1.SchedulingV1Interface
 }
 
 func (c *FakeKuberneteCoreClient) PodClient(namespace string) v1.PodInterface {
@@ -34,22 +38,44 @@ func (c *FakeKuberneteCoreClient) PodClient(namespace string) v1.PodInterface {
 	return c.podClientFake
 }
 
+// SecretClient returns an in-memory client of the secrets of a namespace.
+func (c *FakeKuberneteCoreClient) SecretClient(namespace string) v1.SecretInterface {
+	return c.coreV1Fake.Secrets(namespace)
+}
+
+// PriorityClassClient returns an in-memory client of the priority classes of the cluster.
+func (c *FakeKuberneteCoreClient) PriorityClassClient() schedulingv1.PriorityClassInterface {
+	return c.schedulingV1Fake.PriorityClasses()
+}
+
 func NewFakeKuberneteCoresClient() *FakeKuberneteCoreClient {
-	return &FakeKuberneteCoreClient{&FakePodClient{}}
+	clientSet := fake.NewSimpleClientset()
+	return &FakeKuberneteCoreClient{&FakePodClient{}, clientSet.CoreV1(), clientSet.SchedulingV1()}
 }
 
 type FakeKubernetesCoreClientWithBadPodClient struct {
-	podClientFake *FakeBadPodClient
+	podClientFake    *FakeBadPodClient
+	coreV1Fake       v1.CoreV1Interface
+	schedulingV1Fake schedulingv1.SchedulingV1Interface
 }
 
 func NewFakeKubernetesCoreClientWithBadPodClient() *FakeKubernetesCoreClientWithBadPodClient {
-	return &FakeKubernetesCoreClientWithBadPodClient{&FakeBadPodClient{}}
+	clientSet := fake.NewSimpleClientset()
+	return &FakeKubernetesCoreClientWithBadPodClient{&FakeBadPodClient{}, clientSet.CoreV1(), clientSet.SchedulingV1()}
 }
 
 func (c *FakeKubernetesCoreClientWithBadPodClient) PodClient(namespace string) v1.PodInterface {
 	return c.podClientFake
 }
 
+func (c *FakeKubernetesCoreClientWithBadPodClient) SecretClient(namespace string) v1.SecretInterface {
+	return c.coreV1Fake.Secrets(namespace)
+}
+
+func (c *FakeKubernetesCoreClientWithBadPodClient) PriorityClassClient() schedulingv1.PriorityClassInterface {
+	return c.schedulingV1Fake.PriorityClasses()
+}
+
 func (c *FakePodClient) EvictV1(context.Context, *policyv1.Eviction) error {
 	return nil
 }
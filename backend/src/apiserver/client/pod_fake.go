@@ -62,8 +62,7 @@ func (FakePodClient) Get(ctx context.Context, name string, options v1.GetOptions
 }
 
 func (FakePodClient) List(ctx context.Context, opts v1.ListOptions) (*corev1.PodList, error) {
-	glog.Error("This fake method is not yet implemented.")
-	return nil, nil
+	return &corev1.PodList{}, nil
 }
 
 func (FakePodClient) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
@@ -100,6 +99,10 @@ type FakeBadPodClient struct {
 	FakePodClient
 }
 
+func (FakeBadPodClient) List(ctx context.Context, opts v1.ListOptions) (*corev1.PodList, error) {
+	return nil, errors.New("failed to list pods")
+}
+
 func (FakeBadPodClient) Delete(ctx context.Context, name string, options v1.DeleteOptions) error {
 	return errors.New("failed to delete pod")
 }
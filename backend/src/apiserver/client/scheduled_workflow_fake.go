@@ -16,6 +16,7 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 
 	"github.com/golang/glog"
@@ -54,8 +55,51 @@ func (c *FakeScheduledWorkflowClient) Delete(ctx context.Context, name string, o
 	return k8errors.NewNotFound(k8schema.ParseGroupResource("scheduledworkflows.kubeflow.org"), name)
 }
 
+// Patch applies JSON merge patches to stored scheduled workflows. Patches of
+// other types, and patches of unknown scheduled workflows, are ignored.
 func (c *FakeScheduledWorkflowClient) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, subresources ...string) (result *v1beta1.ScheduledWorkflow, err error) {
-	return nil, nil
+	scheduledWorkflow, ok := c.scheduledWorkflows[name]
+	if !ok || pt != types.MergePatchType {
+		return nil, nil
+	}
+	var original, patch map[string]interface{}
+	originalBytes, err := json.Marshal(scheduledWorkflow)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(originalBytes, &original); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return nil, err
+	}
+	patchedBytes, err := json.Marshal(mergePatch(original, patch))
+	if err != nil {
+		return nil, err
+	}
+	patched := &v1beta1.ScheduledWorkflow{}
+	if err := json.Unmarshal(patchedBytes, patched); err != nil {
+		return nil, err
+	}
+	c.scheduledWorkflows[name] = patched
+	return patched, nil
+}
+
+func mergePatch(original map[string]interface{}, patch map[string]interface{}) map[string]interface{} {
+	for key, value := range patch {
+		if value == nil {
+			delete(original, key)
+			continue
+		}
+		patchMap, isPatchMap := value.(map[string]interface{})
+		originalMap, isOriginalMap := original[key].(map[string]interface{})
+		if isPatchMap && isOriginalMap {
+			original[key] = mergePatch(originalMap, patchMap)
+		} else {
+			original[key] = value
+		}
+	}
+	return original
 }
 
 func (c *FakeScheduledWorkflowClient) Get(ctx context.Context, name string, options v1.GetOptions) (*v1beta1.ScheduledWorkflow, error) {
@@ -0,0 +1,138 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	authzv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxCachedSubjectAccessReviews bounds the number of reviews kept by the cache. Expired reviews are dropped once
+// it is reached, and all of them if that isn't enough.
+const maxCachedSubjectAccessReviews = 10000
+
+// Metric variables. Please prefix the metric names with subject_access_review_cache_.
+var (
+	subjectAccessReviewCacheHits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "subject_access_review_cache_hits",
+		Help: "The number of SubjectAccessReviews answered from the cache",
+	})
+
+	subjectAccessReviewCacheMisses = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "subject_access_review_cache_misses",
+		Help: "The number of SubjectAccessReviews sent to the Kubernetes API",
+	})
+)
+
+// subjectAccessReviewKey identifies the reviews that have the same result: the same user asking for the same
+// access to the same resource.
+type subjectAccessReviewKey struct {
+	user       string
+	attributes authzv1.ResourceAttributes
+}
+
+type cachedSubjectAccessReview struct {
+	review    *authzv1.SubjectAccessReview
+	expiresAt time.Time
+}
+
+// CachedSubjectAccessReviewClient keeps the results of SubjectAccessReviews for a while, so that the requests
+// of a user don't each need a round trip to the Kubernetes API. Denials are kept for a shorter time than
+// grants, and failed reviews aren't kept at all.
+type CachedSubjectAccessReviewClient struct {
+	client    SubjectAccessReviewInterface
+	allowTTL  time.Duration
+	denyTTL   time.Duration
+	now       func() time.Time
+	mutex     sync.Mutex
+	responses map[subjectAccessReviewKey]*cachedSubjectAccessReview
+}
+
+// NewCachedSubjectAccessReviewClient caches the allowed results of client for allowTTL, and the denied ones for
+// denyTTL. A TTL of 0 or less disables caching of those results.
+func NewCachedSubjectAccessReviewClient(client SubjectAccessReviewInterface, allowTTL time.Duration, denyTTL time.Duration) *CachedSubjectAccessReviewClient {
+	return &CachedSubjectAccessReviewClient{
+		client:    client,
+		allowTTL:  allowTTL,
+		denyTTL:   denyTTL,
+		now:       time.Now,
+		responses: make(map[subjectAccessReviewKey]*cachedSubjectAccessReview),
+	}
+}
+
+func (c *CachedSubjectAccessReviewClient) Create(ctx context.Context, sar *authzv1.SubjectAccessReview, opts v1.CreateOptions) (*authzv1.SubjectAccessReview, error) {
+	key, ok := subjectAccessReviewKeyOf(sar)
+	if !ok {
+		return c.client.Create(ctx, sar, opts)
+	}
+	c.mutex.Lock()
+	cached, found := c.responses[key]
+	if found && c.now().Before(cached.expiresAt) {
+		c.mutex.Unlock()
+		subjectAccessReviewCacheHits.Inc()
+		return cached.review.DeepCopy(), nil
+	}
+	c.mutex.Unlock()
+
+	subjectAccessReviewCacheMisses.Inc()
+	result, err := c.client.Create(ctx, sar, opts)
+	if err != nil {
+		return nil, err
+	}
+	ttl := c.denyTTL
+	if result.Status.Allowed {
+		ttl = c.allowTTL
+	}
+	if ttl > 0 {
+		c.mutex.Lock()
+		c.makeRoom()
+		c.responses[key] = &cachedSubjectAccessReview{review: result.DeepCopy(), expiresAt: c.now().Add(ttl)}
+		c.mutex.Unlock()
+	}
+	return result, nil
+}
+
+// makeRoom drops expired reviews once the cache is full. It must be called with the mutex held.
+func (c *CachedSubjectAccessReviewClient) makeRoom() {
+	if len(c.responses) < maxCachedSubjectAccessReviews {
+		return
+	}
+	now := c.now()
+	for key, cached := range c.responses {
+		if !now.Before(cached.expiresAt) {
+			delete(c.responses, key)
+		}
+	}
+	if len(c.responses) >= maxCachedSubjectAccessReviews {
+		c.responses = make(map[subjectAccessReviewKey]*cachedSubjectAccessReview)
+	}
+}
+
+// subjectAccessReviewKeyOf returns the cache key of a review. Only reviews of a user's access to a resource are
+// cached, the ones also naming groups, a UID or extra attributes of the user always go to the Kubernetes API.
+func subjectAccessReviewKeyOf(sar *authzv1.SubjectAccessReview) (subjectAccessReviewKey, bool) {
+	spec := sar.Spec
+	if spec.ResourceAttributes == nil || spec.NonResourceAttributes != nil || len(spec.Groups) > 0 ||
+		len(spec.Extra) > 0 || spec.UID != "" {
+		return subjectAccessReviewKey{}, false
+	}
+	return subjectAccessReviewKey{user: spec.User, attributes: *spec.ResourceAttributes}, true
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	authzv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// countingSubjectAccessReviewClient allows the requests in the allowed namespaces and counts the reviews.
+type countingSubjectAccessReviewClient struct {
+	allowedNamespaces map[string]bool
+	err               error
+	calls             int
+}
+
+func (c *countingSubjectAccessReviewClient) Create(ctx context.Context, sar *authzv1.SubjectAccessReview, opts v1.CreateOptions) (*authzv1.SubjectAccessReview, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	allowed := c.allowedNamespaces[sar.Spec.ResourceAttributes.Namespace]
+	return &authzv1.SubjectAccessReview{Status: authzv1.SubjectAccessReviewStatus{Allowed: allowed}}, nil
+}
+
+func newReview(user string, namespace string, verb string) *authzv1.SubjectAccessReview {
+	return &authzv1.SubjectAccessReview{Spec: authzv1.SubjectAccessReviewSpec{
+		User:               user,
+		ResourceAttributes: &authzv1.ResourceAttributes{Namespace: namespace, Verb: verb, Resource: "runs"},
+	}}
+}
+
+func TestCachedSubjectAccessReviewClient(t *testing.T) {
+	fake := &countingSubjectAccessReviewClient{allowedNamespaces: map[string]bool{"ns1": true}}
+	cache := NewCachedSubjectAccessReviewClient(fake, 10*time.Second, time.Second)
+	now := time.Unix(1000, 0)
+	cache.now = func() time.Time { return now }
+
+	result, err := cache.Create(context.Background(), newReview("user", "ns1", "get"), v1.CreateOptions{})
+	assert.Nil(t, err)
+	assert.True(t, result.Status.Allowed)
+	result, err = cache.Create(context.Background(), newReview("user", "ns1", "get"), v1.CreateOptions{})
+	assert.Nil(t, err)
+	assert.True(t, result.Status.Allowed)
+	assert.Equal(t, 1, fake.calls)
+
+	// Any other user, namespace or verb is reviewed again.
+	cache.Create(context.Background(), newReview("other", "ns1", "get"), v1.CreateOptions{})
+	cache.Create(context.Background(), newReview("user", "ns1", "delete"), v1.CreateOptions{})
+	cache.Create(context.Background(), newReview("user", "ns2", "get"), v1.CreateOptions{})
+	assert.Equal(t, 4, fake.calls)
+
+	// Denials expire sooner than grants.
+	now = now.Add(2 * time.Second)
+	result, err = cache.Create(context.Background(), newReview("user", "ns2", "get"), v1.CreateOptions{})
+	assert.Nil(t, err)
+	assert.False(t, result.Status.Allowed)
+	assert.Equal(t, 5, fake.calls)
+	cache.Create(context.Background(), newReview("user", "ns1", "get"), v1.CreateOptions{})
+	assert.Equal(t, 5, fake.calls)
+
+	now = now.Add(10 * time.Second)
+	cache.Create(context.Background(), newReview("user", "ns1", "get"), v1.CreateOptions{})
+	assert.Equal(t, 6, fake.calls)
+}
+
+func TestCachedSubjectAccessReviewClient_NotCached(t *testing.T) {
+	fake := &countingSubjectAccessReviewClient{allowedNamespaces: map[string]bool{"ns1": true}}
+	cache := NewCachedSubjectAccessReviewClient(fake, 10*time.Second, 0)
+
+	// Denials aren't cached without a TTL.
+	cache.Create(context.Background(), newReview("user", "ns2", "get"), v1.CreateOptions{})
+	cache.Create(context.Background(), newReview("user", "ns2", "get"), v1.CreateOptions{})
+	assert.Equal(t, 2, fake.calls)
+
+	// Reviews naming groups always go to the client.
+	review := newReview("user", "ns1", "get")
+	review.Spec.Groups = []string{"admins"}
+	cache.Create(context.Background(), review, v1.CreateOptions{})
+	cache.Create(context.Background(), review, v1.CreateOptions{})
+	assert.Equal(t, 4, fake.calls)
+
+	// Failed reviews aren't cached.
+	fake.err = errors.New("unavailable")
+	_, err := cache.Create(context.Background(), newReview("user", "ns3", "get"), v1.CreateOptions{})
+	assert.NotNil(t, err)
+	fake.err = nil
+	result, err := cache.Create(context.Background(), newReview("user", "ns3", "get"), v1.CreateOptions{})
+	assert.Nil(t, err)
+	assert.False(t, result.Status.Allowed)
+	assert.Equal(t, 6, fake.calls)
+}
@@ -64,3 +64,59 @@ func (FakeSubjectAccessReviewClientError) Create(*authzv1.SubjectAccessReview) (
 func NewFakeSubjectAccessReviewClientError() FakeSubjectAccessReviewClientError {
 	return FakeSubjectAccessReviewClientError{}
 }
+
+type FakeSubjectAccessReviewClientForNamespaces struct {
+	allowedNamespaces map[string]bool
+}
+
+func (c FakeSubjectAccessReviewClientForNamespaces) Create(ctx context.Context, review *authzv1.SubjectAccessReview, opts v1.CreateOptions) (*authzv1.SubjectAccessReview, error) {
+	allowed := review.Spec.ResourceAttributes != nil && c.allowedNamespaces[review.Spec.ResourceAttributes.Namespace]
+	reason := ""
+	if !allowed {
+		reason = "this is not allowed"
+	}
+	return &authzv1.SubjectAccessReview{Status: authzv1.SubjectAccessReviewStatus{
+		Allowed:         allowed,
+		Denied:          false,
+		Reason:          reason,
+		EvaluationError: "",
+	}}, nil
+}
+
+// NewFakeSubjectAccessReviewClientForNamespaces returns a fake client that only allows requests in the
+// given namespaces.
+func NewFakeSubjectAccessReviewClientForNamespaces(namespaces ...string) FakeSubjectAccessReviewClientForNamespaces {
+	allowedNamespaces := make(map[string]bool)
+	for _, namespace := range namespaces {
+		allowedNamespaces[namespace] = true
+	}
+	return FakeSubjectAccessReviewClientForNamespaces{allowedNamespaces: allowedNamespaces}
+}
+
+type FakeSubjectAccessReviewClientForResources struct {
+	deniedResources map[string]bool
+}
+
+func (c FakeSubjectAccessReviewClientForResources) Create(ctx context.Context, review *authzv1.SubjectAccessReview, opts v1.CreateOptions) (*authzv1.SubjectAccessReview, error) {
+	allowed := review.Spec.ResourceAttributes == nil || !c.deniedResources[review.Spec.ResourceAttributes.Resource]
+	reason := ""
+	if !allowed {
+		reason = "this is not allowed"
+	}
+	return &authzv1.SubjectAccessReview{Status: authzv1.SubjectAccessReviewStatus{
+		Allowed:         allowed,
+		Denied:          false,
+		Reason:          reason,
+		EvaluationError: "",
+	}}, nil
+}
+
+// NewFakeSubjectAccessReviewClientDenyingResources returns a fake client that allows all requests except the
+// ones on the given resource types.
+func NewFakeSubjectAccessReviewClientDenyingResources(resources ...string) FakeSubjectAccessReviewClientForResources {
+	deniedResources := make(map[string]bool)
+	for _, resource := range resources {
+		deniedResources[resource] = true
+	}
+	return FakeSubjectAccessReviewClientForResources{deniedResources: deniedResources}
+}
@@ -126,6 +126,9 @@ func (c *FakeWorkflowClient) Patch(ctx context.Context, name string, pt types.Pa
 			if ok {
 				newActiveDeadlineSeconds := int64(0)
 				workflow.Spec.ActiveDeadlineSeconds = &newActiveDeadlineSeconds
+				if shutdown, ok := spec["shutdown"].(string); ok {
+					workflow.Spec.Shutdown = v1alpha1.ShutdownStrategy(shutdown)
+				}
 				return util.NewWorkflow(workflow), nil
 			}
 		}
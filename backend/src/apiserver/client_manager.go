@@ -18,6 +18,7 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff"
@@ -72,6 +73,7 @@ type ClientManager struct {
 	resourceReferenceStore    storage.ResourceReferenceStoreInterface
 	dBStatusStore             storage.DBStatusStoreInterface
 	defaultExperimentStore    storage.DefaultExperimentStoreInterface
+	idempotencyKeyStore       storage.IdempotencyKeyStoreInterface
 	objectStore               storage.ObjectStoreInterface
 	execClient                util.ExecutionClient
 	swfClient                 client.SwfClientInterface
@@ -116,6 +118,10 @@ func (c *ClientManager) DefaultExperimentStore() storage.DefaultExperimentStoreI
 	return c.defaultExperimentStore
 }
 
+func (c *ClientManager) IdempotencyKeyStore() storage.IdempotencyKeyStoreInterface {
+	return c.idempotencyKeyStore
+}
+
 func (c *ClientManager) ObjectStore() storage.ObjectStoreInterface {
 	return c.objectStore
 }
@@ -175,6 +181,7 @@ func (c *ClientManager) init() {
 	c.resourceReferenceStore = storage.NewResourceReferenceStore(db)
 	c.dBStatusStore = storage.NewDBStatusStore(db)
 	c.defaultExperimentStore = storage.NewDefaultExperimentStore(db)
+	c.idempotencyKeyStore = storage.NewIdempotencyKeyStore(db, c.time)
 	c.objectStore = initMinioClient(common.GetDurationConfig(initConnectionTimeout))
 
 	// Use default value of client QPS (5) & burst (10) defined in
@@ -198,6 +205,10 @@ func (c *ClientManager) init() {
 
 	if common.IsMultiUserMode() {
 		c.subjectAccessReviewClient = client.CreateSubjectAccessReviewClientOrFatal(common.GetDurationConfig(initConnectionTimeout), clientParams)
+		if ttl := common.GetSubjectAccessReviewCacheTTL(); ttl > 0 {
+			c.subjectAccessReviewClient = client.NewCachedSubjectAccessReviewClient(
+				c.subjectAccessReviewClient, ttl, common.GetSubjectAccessReviewDeniedCacheTTL())
+		}
 		c.tokenReviewClient = client.CreateTokenReviewClientOrFatal(common.GetDurationConfig(initConnectionTimeout), clientParams)
 		c.authenticators = auth.GetAuthenticators(c.tokenReviewClient)
 	}
@@ -247,7 +258,11 @@ func initDBClient(initConnectionTimeout time.Duration) *storage.DB {
 		&model.RunMetric{},
 		&model.Task{},
 		&model.DBStatus{},
-		&model.DefaultExperiment{})
+		&model.DefaultExperiment{},
+		&model.NamespaceDefaultExperiment{},
+		&model.IdempotencyKey{},
+		&model.Label{},
+		&model.RunStatusEvent{})
 
 	if response.Error != nil {
 		glog.Fatalf("Failed to initialize the databases.")
@@ -258,6 +273,11 @@ func initDBClient(initConnectionTimeout time.Duration) *storage.DB {
 		glog.Fatalf("Failed to drop unique key on experiment name. Error: %s", response.Error)
 	}
 
+	err = recomputeExperimentNameUniqueIndex(db)
+	if err != nil {
+		glog.Fatalf("Failed to scope the unique key on experiment name to namespaces. Error: %s", err)
+	}
+
 	response = db.Model(&model.Pipeline{}).RemoveIndex("Name")
 	if response.Error != nil {
 		glog.Fatalf("Failed to drop unique key on pipeline name. Error: %s", response.Error)
@@ -278,6 +298,37 @@ func initDBClient(initConnectionTimeout time.Duration) *storage.DB {
 		glog.Fatalf("Failed to create index experimentuuid_conditions_finishedatinsec on run_details. Error: %s", response.Error)
 	}
 
+	response = db.Model(&model.RunDetail{}).AddIndex("namespace_name", "Namespace", "Name")
+	if response.Error != nil {
+		glog.Fatalf("Failed to create index namespace_name on run_details. Error: %s", response.Error)
+	}
+
+	response = db.Model(&model.RunDetail{}).AddIndex("namespace_createatinsec", "Namespace", "CreatedAtInSec")
+	if response.Error != nil {
+		glog.Fatalf("Failed to create index namespace_createatinsec on run_details. Error: %s", response.Error)
+	}
+
+	response = db.Model(&model.RunDetail{}).AddIndex("createatinsec", "CreatedAtInSec")
+	if response.Error != nil {
+		glog.Fatalf("Failed to create index createatinsec on run_details. Error: %s", response.Error)
+	}
+
+	response = db.Model(&model.RunDetail{}).AddIndex("workflowuid", "WorkflowUID")
+	if response.Error != nil {
+		glog.Fatalf("Failed to create index workflowuid on run_details. Error: %s", response.Error)
+	}
+
+	response = db.Model(&model.RunDetail{}).AddIndex("experimentuuid_storagestate_state", "ExperimentUUID", "StorageState", "State")
+	if response.Error != nil {
+		glog.Fatalf("Failed to create index experimentuuid_storagestate_state on run_details. Error: %s", response.Error)
+	}
+
+	// Serves the filters of runs on metrics.
+	response = db.Model(&model.RunMetric{}).AddIndex("name_numbervalue", "Name", "NumberValue")
+	if response.Error != nil {
+		glog.Fatalf("Failed to create index name_numbervalue on run_metrics. Error: %s", response.Error)
+	}
+
 	response = db.Model(&model.Pipeline{}).AddUniqueIndex("name_namespace_index", "Name", "Namespace")
 	if response.Error != nil {
 		glog.Fatalf("Failed to create index name_namespace_index on run_details. Error: %s", response.Error)
@@ -308,6 +359,14 @@ func initDBClient(initConnectionTimeout time.Duration) *storage.DB {
 	if err != nil {
 		glog.Fatalf("Failed to backfill experiment UUID in run_details table: %s", err)
 	}
+	err = backfillRunStateFromConditions(db)
+	if err != nil {
+		glog.Fatalf("Failed to backfill State in run_details table: %s", err)
+	}
+	err = backfillExperimentLastRunCreatedAt(db)
+	if err != nil {
+		glog.Fatalf("Failed to backfill LastRunCreatedAtInSec in experiments table: %s", err)
+	}
 
 	response = db.Model(&model.Pipeline{}).ModifyColumn("Description", "longtext not null")
 	if response.Error != nil {
@@ -473,6 +532,90 @@ func initPipelineVersionsFromPipelines(db *gorm.DB) {
 	tx.Commit()
 }
 
+// recomputeExperimentNameUniqueIndex makes experiment names unique per namespace. It drops the unique
+// indexes on experiments that cover the name but not the namespace, which older versions created, and
+// makes sure idx_name_namespace covers (Name, Namespace). In single-user mode every experiment has an
+// empty namespace, so names stay globally unique.
+func recomputeExperimentNameUniqueIndex(db *gorm.DB) error {
+	rows, err := db.CommonDB().Query(`
+		SELECT
+			INDEX_NAME, GROUP_CONCAT(COLUMN_NAME ORDER BY SEQ_IN_INDEX)
+		FROM
+			information_schema.statistics
+		WHERE
+			TABLE_SCHEMA = DATABASE()
+			AND TABLE_NAME = 'experiments'
+			AND NON_UNIQUE = 0
+			AND INDEX_NAME <> 'PRIMARY'
+		GROUP BY
+			INDEX_NAME
+	`)
+	if err != nil {
+		return err
+	}
+	indexColumns := make(map[string]string)
+	for rows.Next() {
+		var name, columns string
+		if err := rows.Scan(&name, &columns); err != nil {
+			rows.Close()
+			return err
+		}
+		indexColumns[name] = columns
+	}
+	rows.Close()
+
+	const nameNamespaceIndex = "idx_name_namespace"
+	for name, columns := range indexColumns {
+		if columns == "Name,Namespace" {
+			continue
+		}
+		if !strings.Contains(","+columns+",", ",Name,") {
+			continue
+		}
+		glog.Infof("Dropping unique index %v on experiments(%v)", name, columns)
+		if _, err := db.CommonDB().Exec(fmt.Sprintf("DROP INDEX `%s` ON experiments", name)); err != nil {
+			return err
+		}
+		delete(indexColumns, name)
+	}
+	if _, ok := indexColumns[nameNamespaceIndex]; !ok {
+		return db.Model(&model.Experiment{}).AddUniqueIndex(nameNamespaceIndex, "Name", "Namespace").Error
+	}
+	return nil
+}
+
+// backfillRunStateFromConditions fills the State column of runs stored before the column was
+// introduced. The state is derived from Conditions, the same way RunStateFromConditions does.
+func backfillRunStateFromConditions(db *gorm.DB) error {
+	_, err := db.CommonDB().Exec(`
+		UPDATE
+			run_details
+		SET
+			State = CASE WHEN Conditions = '' THEN ? ELSE UPPER(Conditions) END
+		WHERE
+			State = ''
+	`, model.RunStatePending)
+	return err
+}
+
+// backfillExperimentLastRunCreatedAt fills the LastRunCreatedAtInSec column of experiments whose runs were
+// created before the column was introduced.
+func backfillExperimentLastRunCreatedAt(db *gorm.DB) error {
+	_, err := db.CommonDB().Exec(`
+		UPDATE
+			experiments
+		SET
+			LastRunCreatedAtInSec = (
+				SELECT COALESCE(MAX(run_details.CreatedAtInSec), 0)
+				FROM run_details
+				WHERE run_details.ExperimentUUID = experiments.UUID
+			)
+		WHERE
+			LastRunCreatedAtInSec = 0
+	`)
+	return err
+}
+
 func backfillExperimentIDToRunTable(db *gorm.DB) (retError error) {
 	// check if there is any row in the run table has experiment ID being empty
 	rows, err := db.CommonDB().Query(`SELECT ExperimentUUID FROM run_details WHERE ExperimentUUID = '' LIMIT 1`)
@@ -15,10 +15,15 @@
 package common
 
 import (
+	"net"
+	"os"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"github.com/spf13/viper"
 )
 
@@ -32,6 +37,80 @@ const (
 	KubeflowUserIDPrefix                    string = "KUBEFLOW_USERID_PREFIX"
 	UpdatePipelineVersionByDefault          string = "AUTO_UPDATE_PIPELINE_DEFAULT_VERSION"
 	TokenReviewAudience                     string = "TOKEN_REVIEW_AUDIENCE"
+	ExperimentSoftDelete                    string = "EXPERIMENT_SOFT_DELETE"
+	ExperimentSoftDeleteRetention           string = "EXPERIMENT_SOFT_DELETE_RETENTION"
+	ExperimentReaperInterval                string = "EXPERIMENT_REAPER_INTERVAL"
+	PipelineURLFetchTimeout                 string = "PIPELINE_URL_FETCH_TIMEOUT"
+	PipelineURLMaxBytes                     string = "PIPELINE_URL_MAX_BYTES"
+	PipelineURLRedirectAllowlist            string = "PIPELINE_URL_REDIRECT_ALLOWLIST"
+	MaxManifestBytes                        string = "MAX_MANIFEST_BYTES"
+	ManifestCompressionBatchSize            string = "MANIFEST_COMPRESSION_BATCH_SIZE"
+	ManifestCompressionBatchInterval        string = "MANIFEST_COMPRESSION_BATCH_INTERVAL"
+	IdempotencyKeyTTL                       string = "IDEMPOTENCY_KEY_TTL"
+	MaxActiveRunsPerNamespace               string = "MAX_ACTIVE_RUNS_PER_NAMESPACE"
+	MaxActiveRunsPerNamespaceOverrides      string = "MAX_ACTIVE_RUNS_PER_NAMESPACE_OVERRIDES"
+	MaxDeleteRunsBatchSize                  string = "MAX_DELETE_RUNS_BATCH_SIZE"
+	ReportWorkflowsBatchSize                string = "REPORT_WORKFLOWS_BATCH_SIZE"
+	ImpersonationAdminIdentities            string = "IMPERSONATION_ADMIN_IDENTITIES"
+	ArgoClientRetryMaxAttempts              string = "ARGO_CLIENT_RETRY_MAX_ATTEMPTS"
+	ArgoClientRetryInitialInterval          string = "ARGO_CLIENT_RETRY_INITIAL_INTERVAL"
+	ArgoClientRetryMaxInterval              string = "ARGO_CLIENT_RETRY_MAX_INTERVAL"
+	ArgoClientRetryMultiplier               string = "ARGO_CLIENT_RETRY_MULTIPLIER"
+	MaxRunStatusEvents                      string = "MAX_RUN_STATUS_EVENTS"
+	SubjectAccessReviewCacheTTL             string = "SUBJECT_ACCESS_REVIEW_CACHE_TTL"
+	SubjectAccessReviewDeniedCacheTTL       string = "SUBJECT_ACCESS_REVIEW_DENIED_CACHE_TTL"
+	ReadOnlyMode                            string = "READ_ONLY_MODE"
+	PipelineRootBuckets                     string = "PIPELINE_ROOT_BUCKETS"
+	PipelineRootS3Endpoint                  string = "PIPELINE_ROOT_S3_ENDPOINT"
+	PipelineRootS3Insecure                  string = "PIPELINE_ROOT_S3_INSECURE"
+	PipelineRootCredentialsSecret           string = "PIPELINE_ROOT_CREDENTIALS_SECRET"
+	NamespacePipelineRoots                  string = "NAMESPACE_PIPELINE_ROOTS"
+	DefaultPipelineRoot                     string = "DEFAULT_PIPELINE_ROOT"
+	RunRetention                            string = "RUN_RETENTION"
+	RunRetentionOverrides                   string = "RUN_RETENTION_NAMESPACE_OVERRIDES"
+	RunReaperInterval                       string = "RUN_REAPER_INTERVAL"
+	RunReaperBatchSize                      string = "RUN_REAPER_BATCH_SIZE"
+	RunReaperArchive                        string = "RUN_REAPER_ARCHIVE"
+	RunReaperKeepMetrics                    string = "RUN_REAPER_KEEP_METRICS"
+	RunReaperKeepStatusSnapshots            string = "RUN_REAPER_KEEP_STATUS_SNAPSHOTS"
+	ShutdownDrainTimeout                    string = "SHUTDOWN_DRAIN_TIMEOUT"
+	CompletionWebhookAllowedHosts           string = "COMPLETION_WEBHOOK_ALLOWED_HOSTS"
+	CompletionWebhookTimeout                string = "COMPLETION_WEBHOOK_TIMEOUT"
+	CompletionWebhookMaxAttempts            string = "COMPLETION_WEBHOOK_MAX_ATTEMPTS"
+	CompletionWebhookRetryInitialInterval   string = "COMPLETION_WEBHOOK_RETRY_INITIAL_INTERVAL"
+	CompletionWebhookRetryMaxInterval       string = "COMPLETION_WEBHOOK_RETRY_MAX_INTERVAL"
+)
+
+const (
+	DefaultExperimentSoftDeleteRetention = 30 * 24 * time.Hour
+	DefaultExperimentReaperInterval      = time.Hour
+	DefaultPipelineURLFetchTimeout       = 30 * time.Second
+	DefaultPipelineURLMaxBytes           = 32 << 20
+	DefaultMaxManifestBytes              = 16 << 20
+	DefaultManifestCompressionBatchSize  = 100
+	DefaultManifestCompressionInterval   = time.Second
+	DefaultIdempotencyKeyTTL             = 24 * time.Hour
+	DefaultMaxDeleteRunsBatchSize        = 500
+	DefaultReportWorkflowsBatchSize      = 100
+	DefaultArgoRetryMaxAttempts          = 3
+	DefaultArgoRetryInitialInterval      = 500 * time.Millisecond
+	DefaultArgoRetryMaxInterval          = 5 * time.Second
+	DefaultArgoRetryMultiplier           = 2.0
+	DefaultMaxRunStatusEvents            = 100
+	DefaultSubjectAccessReviewCacheTTL   = 10 * time.Second
+	DefaultSubjectAccessReviewDeniedTTL  = time.Second
+	DefaultPipelineRootCredentialsSecret = "mlpipeline-minio-artifact"
+	DefaultDefaultPipelineRoot           = "minio://mlpipeline/v2/artifacts"
+	DefaultRunReaperInterval             = time.Hour
+	DefaultRunReaperBatchSize            = 100
+	DefaultCompletionWebhookTimeout      = 10 * time.Second
+	DefaultCompletionWebhookMaxAttempts  = 5
+	DefaultCompletionWebhookInitialRetry = time.Second
+	DefaultCompletionWebhookMaxRetry     = 30 * time.Second
+	// Shorter than the default termination grace period of Kubernetes pods, 30s.
+	DefaultShutdownDrainTimeout = 20 * time.Second
+	// Denials are never kept longer, so that granting access takes effect quickly.
+	MaxSubjectAccessReviewDeniedTTL = 5 * time.Second
 )
 
 func IsPipelineVersionUpdatedByDefault() bool {
@@ -92,6 +171,362 @@ func GetDurationConfig(configName string) time.Duration {
 	return viper.GetDuration(configName)
 }
 
+func GetDurationConfigWithDefault(configName string, value time.Duration) time.Duration {
+	if !viper.IsSet(configName) {
+		return value
+	}
+	return viper.GetDuration(configName)
+}
+
+func IsExperimentSoftDeleteEnabled() bool {
+	return GetBoolConfigWithDefault(ExperimentSoftDelete, false)
+}
+
+func GetExperimentSoftDeleteRetention() time.Duration {
+	return GetDurationConfigWithDefault(ExperimentSoftDeleteRetention, DefaultExperimentSoftDeleteRetention)
+}
+
+func GetExperimentReaperInterval() time.Duration {
+	return GetDurationConfigWithDefault(ExperimentReaperInterval, DefaultExperimentReaperInterval)
+}
+
+func GetPipelineURLFetchTimeout() time.Duration {
+	return GetDurationConfigWithDefault(PipelineURLFetchTimeout, DefaultPipelineURLFetchTimeout)
+}
+
+func GetPipelineURLMaxBytes() int {
+	return GetIntConfigWithDefault(PipelineURLMaxBytes, DefaultPipelineURLMaxBytes)
+}
+
+// GetManifestCompressionBatchSize returns how many rows the migration that compresses stored manifests updates
+// at a time. A value of 0 or less disables the migration.
+func GetManifestCompressionBatchSize() int {
+	return GetIntConfigWithDefault(ManifestCompressionBatchSize, DefaultManifestCompressionBatchSize)
+}
+
+// GetManifestCompressionBatchInterval returns how long the migration that compresses stored manifests waits
+// between batches.
+func GetManifestCompressionBatchInterval() time.Duration {
+	return GetDurationConfigWithDefault(ManifestCompressionBatchInterval, DefaultManifestCompressionInterval)
+}
+
+// GetMaxManifestBytes returns the maximum size of an uploaded pipeline or an inline run manifest. A value of 0
+// or less disables the limit.
+func GetMaxManifestBytes() int {
+	return GetIntConfigWithDefault(MaxManifestBytes, DefaultMaxManifestBytes)
+}
+
+// GetPipelineURLRedirectAllowlist returns the hosts that pipeline URL downloads may be redirected to,
+// in addition to the host of the requested URL.
+func GetPipelineURLRedirectAllowlist() []string {
+	var hosts []string
+	for _, host := range strings.Split(GetStringConfigWithDefault(PipelineURLRedirectAllowlist, ""), ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// GetCompletionWebhookAllowedHosts returns the hosts, optionally with a port, that completion webhooks may
+// be sent to in multi-user mode.
+func GetCompletionWebhookAllowedHosts() []string {
+	var hosts []string
+	for _, host := range strings.Split(GetStringConfigWithDefault(CompletionWebhookAllowedHosts, ""), ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// GetCompletionWebhookTimeout returns how long a single delivery attempt of a completion webhook may take.
+func GetCompletionWebhookTimeout() time.Duration {
+	return GetDurationConfigWithDefault(CompletionWebhookTimeout, DefaultCompletionWebhookTimeout)
+}
+
+// GetCompletionWebhookMaxAttempts returns how many times the delivery of a completion webhook is attempted in
+// total. One disables retries.
+func GetCompletionWebhookMaxAttempts() int {
+	attempts := GetIntConfigWithDefault(CompletionWebhookMaxAttempts, DefaultCompletionWebhookMaxAttempts)
+	if attempts < 1 {
+		return 1
+	}
+	return attempts
+}
+
+func GetCompletionWebhookRetryInitialInterval() time.Duration {
+	return GetDurationConfigWithDefault(CompletionWebhookRetryInitialInterval, DefaultCompletionWebhookInitialRetry)
+}
+
+func GetCompletionWebhookRetryMaxInterval() time.Duration {
+	return GetDurationConfigWithDefault(CompletionWebhookRetryMaxInterval, DefaultCompletionWebhookMaxRetry)
+}
+
+// IsImpersonationAdmin returns whether the user is one of the comma-separated admin identities that may act
+// on behalf of a namespace.
+func IsImpersonationAdmin(userIdentity string) bool {
+	for _, admin := range strings.Split(GetStringConfigWithDefault(ImpersonationAdminIdentities, ""), ",") {
+		if admin = strings.TrimSpace(admin); admin != "" && admin == userIdentity {
+			return true
+		}
+	}
+	return false
+}
+
+func GetIdempotencyKeyTTL() time.Duration {
+	return GetDurationConfigWithDefault(IdempotencyKeyTTL, DefaultIdempotencyKeyTTL)
+}
+
+func GetMaxDeleteRunsBatchSize() int {
+	return GetIntConfigWithDefault(MaxDeleteRunsBatchSize, DefaultMaxDeleteRunsBatchSize)
+}
+
+// GetReportWorkflowsBatchSize returns how many run statuses a batch of workflow reports stores per
+// transaction. A value of 0 or less stores each run in its own transaction.
+func GetReportWorkflowsBatchSize() int {
+	if size := GetIntConfigWithDefault(ReportWorkflowsBatchSize, DefaultReportWorkflowsBatchSize); size > 0 {
+		return size
+	}
+	return 1
+}
+
+// GetArgoClientRetryMaxAttempts returns how many times a retryable Argo client call is attempted in total.
+// One disables retries.
+func GetArgoClientRetryMaxAttempts() int {
+	attempts := GetIntConfigWithDefault(ArgoClientRetryMaxAttempts, DefaultArgoRetryMaxAttempts)
+	if attempts < 1 {
+		return 1
+	}
+	return attempts
+}
+
+func GetArgoClientRetryInitialInterval() time.Duration {
+	return GetDurationConfigWithDefault(ArgoClientRetryInitialInterval, DefaultArgoRetryInitialInterval)
+}
+
+func GetArgoClientRetryMaxInterval() time.Duration {
+	return GetDurationConfigWithDefault(ArgoClientRetryMaxInterval, DefaultArgoRetryMaxInterval)
+}
+
+func GetArgoClientRetryMultiplier() float64 {
+	return GetFloat64ConfigWithDefault(ArgoClientRetryMultiplier, DefaultArgoRetryMultiplier)
+}
+
+// GetMaxRunStatusEvents returns how many status events are kept per run. Older events are pruned.
+func GetMaxRunStatusEvents() int {
+	maxEvents := GetIntConfigWithDefault(MaxRunStatusEvents, DefaultMaxRunStatusEvents)
+	if maxEvents < 1 {
+		return 1
+	}
+	return maxEvents
+}
+
+// GetSubjectAccessReviewCacheTTL returns how long the result of a SubjectAccessReview that allowed a request is
+// reused for the same request. A value of 0 or less disables the cache.
+func GetSubjectAccessReviewCacheTTL() time.Duration {
+	return GetDurationConfigWithDefault(SubjectAccessReviewCacheTTL, DefaultSubjectAccessReviewCacheTTL)
+}
+
+// GetSubjectAccessReviewDeniedCacheTTL returns how long the result of a SubjectAccessReview that denied a
+// request is reused, at most MaxSubjectAccessReviewDeniedTTL and no longer than allowed results.
+func GetSubjectAccessReviewDeniedCacheTTL() time.Duration {
+	ttl := GetDurationConfigWithDefault(SubjectAccessReviewDeniedCacheTTL, DefaultSubjectAccessReviewDeniedTTL)
+	if ttl > MaxSubjectAccessReviewDeniedTTL {
+		ttl = MaxSubjectAccessReviewDeniedTTL
+	}
+	if allowTTL := GetSubjectAccessReviewCacheTTL(); ttl > allowTTL {
+		ttl = allowTTL
+	}
+	return ttl
+}
+
+// readOnlyModeOverride is the read-only mode set by SetReadOnlyMode, which takes precedence over the configured
+// one: 0 if it wasn't set, 1 if the mode is off, and 2 if it is on.
+var readOnlyModeOverride int32
+
+// IsReadOnlyMode returns whether the API server is in read-only maintenance mode, in which it rejects the
+// requests that would change anything.
+func IsReadOnlyMode() bool {
+	switch atomic.LoadInt32(&readOnlyModeOverride) {
+	case 1:
+		return false
+	case 2:
+		return true
+	}
+	return GetBoolConfigWithDefault(ReadOnlyMode, false)
+}
+
+// SetReadOnlyMode turns read-only maintenance mode on or off until the API server restarts.
+func SetReadOnlyMode(enabled bool) {
+	override := int32(1)
+	if enabled {
+		override = 2
+	}
+	atomic.StoreInt32(&readOnlyModeOverride, override)
+}
+
+// draining is set once the API server starts shutting down.
+var draining int32
+
+// IsDraining returns whether the API server is shutting down, in which case it rejects the requests that would
+// change anything while it finishes serving those it already accepted.
+func IsDraining() bool {
+	return atomic.LoadInt32(&draining) == 1
+}
+
+// SetDraining marks the API server as shutting down, or not.
+func SetDraining(enabled bool) {
+	value := int32(0)
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&draining, value)
+}
+
+// GetShutdownDrainTimeout returns how long the API server waits, once told to shut down, for the requests it
+// is serving to finish.
+func GetShutdownDrainTimeout() time.Duration {
+	return GetDurationConfigWithDefault(ShutdownDrainTimeout, DefaultShutdownDrainTimeout)
+}
+
+// GetMaxActiveRunsPerNamespace returns how many runs the namespace may have pending or running at the same
+// time. The limit of a namespace in the overrides map takes precedence over the global limit. Zero means
+// there is no limit.
+func GetMaxActiveRunsPerNamespace(namespace string) int {
+	if viper.IsSet(MaxActiveRunsPerNamespaceOverrides) {
+		overrides := viper.GetStringMapString(MaxActiveRunsPerNamespaceOverrides)
+		if value, ok := overrides[strings.ToLower(namespace)]; ok {
+			limit, err := strconv.Atoi(value)
+			if err == nil && limit >= 0 {
+				return limit
+			}
+			glog.Warningf("Ignoring invalid %s value %q for namespace %s", MaxActiveRunsPerNamespaceOverrides, value, namespace)
+		}
+	}
+	return GetIntConfigWithDefault(MaxActiveRunsPerNamespace, 0)
+}
+
+// GetRunRetention returns how long runs are kept after they finish before the run reaper deletes or archives
+// them. Zero keeps them forever.
+func GetRunRetention() time.Duration {
+	return GetDurationConfigWithDefault(RunRetention, 0)
+}
+
+// GetRunRetentionOverrides returns the retention of the runs of the namespaces that don't use the global one.
+// Zero keeps the runs of a namespace forever.
+func GetRunRetentionOverrides() map[string]time.Duration {
+	overrides := map[string]time.Duration{}
+	if !viper.IsSet(RunRetentionOverrides) {
+		return overrides
+	}
+	for namespace, value := range viper.GetStringMapString(RunRetentionOverrides) {
+		retention, err := time.ParseDuration(value)
+		if err != nil || retention < 0 {
+			glog.Warningf("Ignoring invalid %s value %q for namespace %s", RunRetentionOverrides, value, namespace)
+			continue
+		}
+		overrides[namespace] = retention
+	}
+	return overrides
+}
+
+// IsRunReaperEnabled returns whether the runs of some namespace have a retention.
+func IsRunReaperEnabled() bool {
+	if GetRunRetention() > 0 {
+		return true
+	}
+	for _, retention := range GetRunRetentionOverrides() {
+		if retention > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func GetRunReaperInterval() time.Duration {
+	return GetDurationConfigWithDefault(RunReaperInterval, DefaultRunReaperInterval)
+}
+
+func GetRunReaperBatchSize() int {
+	if size := GetIntConfigWithDefault(RunReaperBatchSize, DefaultRunReaperBatchSize); size > 0 {
+		return size
+	}
+	return DefaultRunReaperBatchSize
+}
+
+// IsPipelineRootBucketAllowed returns whether the runs of the namespace may store their outputs in the bucket.
+// The buckets of each namespace are a comma-separated list in the PIPELINE_ROOT_BUCKETS map, along with the
+// bucket of the pipeline root of the namespace.
+func IsPipelineRootBucketAllowed(namespace string, bucket string) bool {
+	if namespaceRoot := GetNamespacePipelineRoot(namespace); namespaceRoot != "" {
+		if root, err := util.ParseArtifactRoot(namespaceRoot); err == nil && root.Bucket == bucket {
+			return true
+		}
+	}
+	if !viper.IsSet(PipelineRootBuckets) {
+		return false
+	}
+	buckets := viper.GetStringMapString(PipelineRootBuckets)[strings.ToLower(namespace)]
+	for _, allowed := range strings.Split(buckets, ",") {
+		if strings.TrimSpace(allowed) == bucket {
+			return true
+		}
+	}
+	return false
+}
+
+// GetNamespacePipelineRoot returns the pipeline root of the runs of the namespace that don't set one, from the
+// NAMESPACE_PIPELINE_ROOTS map, or "" if the runs of the namespace write to the default bucket.
+func GetNamespacePipelineRoot(namespace string) string {
+	if !viper.IsSet(NamespacePipelineRoots) {
+		return ""
+	}
+	return viper.GetStringMapString(NamespacePipelineRoots)[strings.ToLower(namespace)]
+}
+
+// ValidateNamespacePipelineRoots checks that the pipeline roots of the NAMESPACE_PIPELINE_ROOTS map are valid
+// pipeline roots, so that a bad mapping is found when the server starts rather than when runs are created.
+func ValidateNamespacePipelineRoots() error {
+	if !viper.IsSet(NamespacePipelineRoots) {
+		return nil
+	}
+	for namespace, root := range viper.GetStringMapString(NamespacePipelineRoots) {
+		if _, err := util.ParseArtifactRoot(root); err != nil {
+			return util.Wrapf(err, "Invalid %s entry for namespace %s", NamespacePipelineRoots, namespace)
+		}
+	}
+	return nil
+}
+
+// GetPipelineRootS3Endpoint returns the endpoint of the s3 and minio pipeline roots of runs, and whether it is
+// reached without TLS. It defaults to the object store of the server.
+func GetPipelineRootS3Endpoint() (string, bool) {
+	if viper.IsSet(PipelineRootS3Endpoint) {
+		return GetStringConfig(PipelineRootS3Endpoint), GetBoolConfigWithDefault(PipelineRootS3Insecure, false)
+	}
+	host := GetStringConfigWithDefault("ObjectStoreConfig.Host", os.Getenv("MINIO_SERVICE_SERVICE_HOST"))
+	port := GetStringConfigWithDefault("ObjectStoreConfig.Port", os.Getenv("MINIO_SERVICE_SERVICE_PORT"))
+	secure := GetBoolConfigWithDefault("ObjectStoreConfig.Secure", GetBoolFromStringWithDefault(os.Getenv("MINIO_SERVICE_SECURE"), false))
+	if port == "" {
+		return host, !secure
+	}
+	return net.JoinHostPort(host, port), !secure
+}
+
+// GetPipelineRootCredentialsSecret returns the secret of the namespace of a run holding the credentials of the
+// s3 and minio pipeline roots.
+func GetPipelineRootCredentialsSecret() string {
+	return GetStringConfigWithDefault(PipelineRootCredentialsSecret, DefaultPipelineRootCredentialsSecret)
+}
+
+// GetDefaultPipelineRoot returns the pipeline root of the runs that don't set one. It defaults to the default
+// root of the launcher, and should be set to the defaultPipelineRoot of the kfp-launcher config map when the
+// map changes it.
+func GetDefaultPipelineRoot() string {
+	return GetStringConfigWithDefault(DefaultPipelineRoot, DefaultDefaultPipelineRoot)
+}
+
 func IsMultiUserMode() bool {
 	return GetBoolConfigWithDefault(MultiUserMode, false)
 }
@@ -0,0 +1,40 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespacePipelineRoots(t *testing.T) {
+	defer viper.Set(NamespacePipelineRoots, nil)
+	assert.Nil(t, ValidateNamespacePipelineRoots())
+	assert.Equal(t, "", GetNamespacePipelineRoot("team-a"))
+
+	viper.Set(NamespacePipelineRoots, map[string]string{"team-a": "gs://team-a/runs", "team-b": "minio://team-b"})
+	assert.Nil(t, ValidateNamespacePipelineRoots())
+	assert.Equal(t, "gs://team-a/runs", GetNamespacePipelineRoot("Team-A"))
+	assert.Equal(t, "", GetNamespacePipelineRoot("team-c"))
+	assert.True(t, IsPipelineRootBucketAllowed("team-a", "team-a"))
+	assert.False(t, IsPipelineRootBucketAllowed("team-a", "team-b"))
+
+	viper.Set(NamespacePipelineRoots, map[string]string{"team-a": "http://team-a/runs"})
+	err := ValidateNamespacePipelineRoots()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "team-a")
+}
@@ -33,6 +33,7 @@ const (
 	RbacKubeflowGroup    = "kubeflow.org"
 	RbacPipelinesGroup   = "pipelines.kubeflow.org"
 	RbacPipelinesVersion = "v1beta1"
+	RbacSchedulingGroup  = "scheduling.k8s.io"
 
 	RbacResourceTypePipelines      = "pipelines"
 	RbacResourceTypeExperiments    = "experiments"
@@ -40,6 +41,12 @@ const (
 	RbacResourceTypeJobs           = "jobs"
 	RbacResourceTypeViewers        = "viewers"
 	RbacResourceTypeVisualizations = "visualizations"
+	// Service accounts are core Kubernetes resources, authorized with the "use" verb.
+	RbacResourceTypeServiceAccounts = "serviceaccounts"
+	// Secrets are core Kubernetes resources, read by runs whose parameters refer to them.
+	RbacResourceTypeSecrets = "secrets"
+	// Priority classes are cluster-wide Kubernetes resources, authorized in a namespace with the "use" verb.
+	RbacResourceTypePriorityClasses = "priorityclasses"
 
 	RbacResourceVerbArchive       = "archive"
 	RbacResourceVerbUpdate        = "update"
@@ -54,11 +61,14 @@ const (
 	RbacResourceVerbUnarchive     = "unarchive"
 	RbacResourceVerbReportMetrics = "reportMetrics"
 	RbacResourceVerbReadArtifact  = "readArtifact"
+	RbacResourceVerbUse           = "use"
 )
 
 const (
-	Owner   model.Relationship = "Owner"
-	Creator model.Relationship = "Creator"
+	Owner       model.Relationship = "Owner"
+	Creator     model.Relationship = "Creator"
+	RetriedFrom model.Relationship = "RetriedFrom"
+	ClonedFrom  model.Relationship = "ClonedFrom"
 )
 
 const (
@@ -70,6 +80,64 @@ const (
 
 const DefaultTokenReviewAudience string = "pipelines.kubeflow.org"
 
+// IdempotencyKeyHeader is the gRPC metadata key of the idempotency key of a CreateRun request.
+// HTTP clients send it as the Grpc-Metadata-Idempotency-Key header.
+const IdempotencyKeyHeader string = "idempotency-key"
+
+// TTLSecondsAfterFinishedHeader is the gRPC metadata key of the number of seconds the workflow of a CreateRun
+// request is kept after it finishes, overriding the TTL declared in the manifest. Zero deletes the workflow as
+// soon as it finishes. HTTP clients send it as the Grpc-Metadata-Ttl-Seconds-After-Finished header. v1 runs set
+// it in their ttl_seconds_after_finished field instead, which takes precedence.
+const TTLSecondsAfterFinishedHeader string = "ttl-seconds-after-finished"
+
+// ResourceOverridesHeader is the gRPC metadata key of the container resources a CreateRun request sets on the
+// templates of its workflow. The value is a JSON object from template name to resource requirements, e.g.
+// {"train": {"limits": {"memory": "8Gi"}}}. HTTP clients send it as the Grpc-Metadata-Resource-Overrides header.
+// v1 runs set them in their resource_overrides field instead, which takes precedence.
+const ResourceOverridesHeader string = "resource-overrides"
+
+// PodMetadataHeader is the gRPC metadata key of the labels and annotations a CreateRun request sets on every pod
+// of its workflow. The value is a JSON object, e.g. {"labels": {"cost-center": "ml-research"}, "annotations": {}}.
+// HTTP clients send it as the Grpc-Metadata-Pod-Metadata header. v1 runs set them in their pod_metadata field
+// instead, which takes precedence.
+const PodMetadataHeader string = "pod-metadata"
+
+// SchedulingHeader is the gRPC metadata key of the node selector and tolerations a CreateRun request adds to
+// its workflow, e.g. {"nodeSelector": {"pool": "gpu"}, "tolerations": [{"key": "nvidia.com/gpu",
+// "operator": "Exists", "effect": "NoSchedule"}]}. HTTP clients send it as the Grpc-Metadata-Scheduling header.
+// v1 runs set them in their node_selector and tolerations fields instead, which take precedence.
+const SchedulingHeader string = "scheduling"
+
+// RunEnvHeader is the gRPC metadata key of the environment variables a CreateRun request adds to the containers
+// of its workflow, those of the named templates or all of them, e.g. {"env": [{"name": "FLAG", "value": "on"},
+// {"name": "TOKEN", "valueFrom": {"secretKeyRef": {"name": "s", "key": "k"}}}], "templates": ["train"]}.
+// HTTP clients send it as the Grpc-Metadata-Run-Env header. v1 runs set them in their env and env_templates
+// fields instead, which take precedence.
+const RunEnvHeader string = "run-env"
+
+// RetryStrategyHeader is the gRPC metadata key of the retry strategy a CreateRun request sets on the templates of
+// its workflow, those named or all those that run a pod, e.g. {"limit": 3, "retryPolicy": "OnError", "backoff":
+// {"duration": "10s", "factor": 2, "maxDuration": "5m"}, "templates": ["train"]}. Templates keep their own retry
+// strategy unless "force" is true. HTTP clients send it as the Grpc-Metadata-Retry-Strategy header.
+// v1 runs set it in their retry_strategy field instead, which takes precedence.
+const RetryStrategyHeader string = "retry-strategy"
+
+// PriorityClassNameHeader is the gRPC metadata key of the priority class a CreateRun request sets on every pod of
+// its workflow, e.g. production. HTTP clients send it as the Grpc-Metadata-Priority-Class-Name header.
+// v1 runs set it in their priority_class_name field instead, which takes precedence.
+const PriorityClassNameHeader string = "priority-class-name"
+
+// CompletionWebhookHeader is the gRPC metadata key of the URL a CreateRun request asks to be notified at when the
+// run completes. HTTP clients send it as the Grpc-Metadata-Completion-Webhook header.
+const CompletionWebhookHeader string = "completion-webhook"
+
+// CatchUpHeader is the gRPC metadata key a CreateJob request sets to "true" for the job to backfill the runs it
+// misses. New jobs don't catch up otherwise. HTTP clients send it as the Grpc-Metadata-Catch-Up header.
+const CatchUpHeader string = "catch-up"
+
+// ImpersonateNamespaceHeader is the header an admin identity uses to act on behalf of a namespace.
+const ImpersonateNamespaceHeader string = "x-impersonate-namespace"
+
 func ToModelResourceType(apiType api.ResourceType) (model.ResourceType, error) {
 	switch apiType {
 	case api.ResourceType_EXPERIMENT:
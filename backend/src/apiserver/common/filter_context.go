@@ -24,4 +24,6 @@ type ReferenceKey struct {
 type FilterContext struct {
 	// Filter by a specific reference key
 	*ReferenceKey
+	// Filter by namespace, if not nil. Only runs are filtered this way.
+	Namespaces []string
 }
@@ -15,8 +15,12 @@
 package common
 
 import (
-	api "github.com/kubeflow/pipelines/backend/api/v1beta1/go_client"
+	"sort"
 	"strings"
+
+	api "github.com/kubeflow/pipelines/backend/api/v1beta1/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
@@ -64,3 +68,41 @@ func PatchPipelineDefaultParameter(text string) (string, error) {
 	}
 	return text, nil
 }
+
+// ParseLabels parses labels given as "key=value" pairs. Duplicate keys are rejected.
+func ParseLabels(pairs []string) (map[string]string, error) {
+	labels := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, util.NewInvalidInputError("Invalid label %q. Labels must have the format key=value", pair)
+		}
+		key := strings.TrimSpace(parts[0])
+		if _, ok := labels[key]; ok {
+			return nil, util.NewInvalidInputError("Duplicate label key %q", key)
+		}
+		labels[key] = strings.TrimSpace(parts[1])
+	}
+	if err := ValidateLabels(labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// ValidateLabels checks that label keys and values follow the syntax of Kubernetes labels.
+func ValidateLabels(labels map[string]string) error {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return util.NewInvalidInputError("Invalid label key %q: %v", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(labels[key]); len(errs) > 0 {
+			return util.NewInvalidInputError("Invalid value of label %q: %v", key, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
@@ -102,3 +102,19 @@ func TestGetExperimentIDFromResourceReferences(t *testing.T) {
 			"TestGetExperimentIDFromResourceReferences(%v) has unexpected result.", tc.name)
 	}
 }
+
+func TestParseLabels(t *testing.T) {
+	labels, err := ParseLabels([]string{"team=ml", "example.com/env=prod"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"team": "ml", "example.com/env": "prod"}, labels)
+
+	_, err = ParseLabels([]string{"team=ml", "team=infra"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Duplicate label key")
+
+	_, err = ParseLabels([]string{"team"})
+	assert.NotNil(t, err)
+
+	_, err = ParseLabels([]string{"bad key=ml"})
+	assert.NotNil(t, err)
+}
@@ -19,6 +19,9 @@ package filter
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/golang/protobuf/jsonpb"
@@ -43,6 +46,40 @@ type Filter struct {
 	in map[string][]interface{}
 
 	substring map[string][]interface{}
+
+	// labels holds the values that a label, keyed by its key, must be equal to.
+	labels map[string][]string
+
+	metrics []*MetricPredicate
+}
+
+// LabelKeyPrefix is the prefix of predicate keys that filter on a label of the resource, e.g. a
+// predicate on "labels.team" matches resources with the label "team". Only the EQUALS operation
+// with a string value is supported on labels.
+const LabelKeyPrefix = "labels."
+
+// MetricKeyPrefix is the prefix of predicate keys that filter runs on a metric they reported, e.g. a
+// predicate on "metrics.accuracy" with the GREATER_THAN operation and the string value "0.9" matches the
+// runs whose accuracy is greater than 0.9. Runs that didn't report the metric never match. Only comparisons
+// are supported on metrics, with int, long or numeric string values.
+const MetricKeyPrefix = "metrics."
+
+// MetricPredicate compares the value of a metric to a number.
+type MetricPredicate struct {
+	Name string
+	// Operator is the SQL comparison operator, e.g. >=.
+	Operator string
+	Value    float64
+}
+
+// metricOperators are the SQL operators of the operations supported on metrics.
+var metricOperators = map[api.Predicate_Op]string{
+	api.Predicate_EQUALS:              "=",
+	api.Predicate_NOT_EQUALS:          "<>",
+	api.Predicate_GREATER_THAN:        ">",
+	api.Predicate_GREATER_THAN_EQUALS: ">=",
+	api.Predicate_LESS_THAN:           "<",
+	api.Predicate_LESS_THAN_EQUALS:    "<=",
 }
 
 // filterForMarshaling is a helper struct for marshaling Filter into JSON. This
@@ -60,6 +97,11 @@ type filterForMarshaling struct {
 	IN map[string][]interface{}
 
 	SUBSTRING map[string][]interface{}
+
+	LABELS map[string][]string
+
+	// Omitted when empty, so that the page tokens of filters without metrics don't change.
+	METRICS []*MetricPredicate `json:",omitempty"`
 }
 
 // MarshalJSON implements JSON Marshaler for Filter.
@@ -79,6 +121,8 @@ func (f *Filter) MarshalJSON() ([]byte, error) {
 		LTE:         f.lte,
 		IN:          f.in,
 		SUBSTRING:   f.substring,
+		LABELS:      f.labels,
+		METRICS:     f.metrics,
 	})
 }
 
@@ -104,6 +148,8 @@ func (f *Filter) UnmarshalJSON(b []byte) error {
 	f.lte = ffm.LTE
 	f.in = ffm.IN
 	f.substring = ffm.SUBSTRING
+	f.labels = ffm.LABELS
+	f.metrics = ffm.METRICS
 
 	return nil
 }
@@ -120,6 +166,7 @@ func New(filterProto *api.Filter) (*Filter, error) {
 		lte:         make(map[string][]interface{}, 0),
 		in:          make(map[string][]interface{}, 0),
 		substring:   make(map[string][]interface{}, 0),
+		labels:      make(map[string][]string, 0),
 	}
 
 	if err := f.parseFilterProto(); err != nil {
@@ -141,6 +188,10 @@ func NewWithKeyMap(filterProto *api.Filter, keyMap map[string]string, modelName
 	}
 
 	for _, pred := range filterProto.Predicates {
+		if strings.HasPrefix(pred.Key, LabelKeyPrefix) || strings.HasPrefix(pred.Key, MetricKeyPrefix) {
+			// Labels and metrics are not columns of the model.
+			continue
+		}
 		k, ok := keyMap[pred.Key]
 		if !ok {
 			return nil, util.NewInvalidInputError("no support for filtering on unrecognized field %q", pred.Key)
@@ -150,6 +201,36 @@ func NewWithKeyMap(filterProto *api.Filter, keyMap map[string]string, modelName
 	return New(filterProto)
 }
 
+// HasPredicateOn returns true if any predicate in the Filter f is on the given key.
+func (f *Filter) HasPredicateOn(key string) bool {
+	for _, m := range []map[string][]interface{}{f.eq, f.neq, f.gt, f.gte, f.lt, f.lte, f.in, f.substring} {
+		if _, ok := m[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// HasLabelPredicates returns true if the Filter f filters on any label.
+func (f *Filter) HasLabelPredicates() bool {
+	return len(f.labels) > 0
+}
+
+// Labels returns the values that each label, keyed by its key, must be equal to.
+func (f *Filter) Labels() map[string][]string {
+	return f.labels
+}
+
+// HasMetricPredicates returns true if the Filter f filters on any metric.
+func (f *Filter) HasMetricPredicates() bool {
+	return len(f.metrics) > 0
+}
+
+// Metrics returns the comparisons on metrics of the Filter f, in the order of its predicates.
+func (f *Filter) Metrics() []*MetricPredicate {
+	return f.metrics
+}
+
 // AddToSelect builds a WHERE clause from the Filter f, adds it to the supplied
 // SelectBuilder object and returns it for use in SQL queries.
 func (f *Filter) AddToSelect(sb squirrel.SelectBuilder) squirrel.SelectBuilder {
@@ -247,6 +328,18 @@ func checkPredicate(p *api.Predicate) error {
 
 func (f *Filter) parseFilterProto() error {
 	for _, pred := range f.filterProto.Predicates {
+		if strings.HasPrefix(pred.Key, LabelKeyPrefix) {
+			if err := f.addLabelPredicate(pred); err != nil {
+				return err
+			}
+			continue
+		}
+		if strings.HasPrefix(pred.Key, MetricKeyPrefix) {
+			if err := f.addMetricPredicate(pred); err != nil {
+				return err
+			}
+			continue
+		}
 		if err := checkPredicate(pred); err != nil {
 			return err
 		}
@@ -281,6 +374,50 @@ func (f *Filter) parseFilterProto() error {
 	return nil
 }
 
+func (f *Filter) addLabelPredicate(p *api.Predicate) error {
+	labelKey := strings.TrimPrefix(p.Key, LabelKeyPrefix)
+	if labelKey == "" {
+		return util.NewInvalidInputError("no label key in predicate key %q", p.Key)
+	}
+	if p.Op != api.Predicate_EQUALS {
+		return util.NewInvalidInputError("cannot use operator %v on label %q, only EQUALS is supported", p.Op, labelKey)
+	}
+	v, ok := p.Value.(*api.Predicate_StringValue)
+	if !ok {
+		return util.NewInvalidInputError("cannot use non string value type %T on label %q", p.Value, labelKey)
+	}
+	f.labels[labelKey] = append(f.labels[labelKey], v.StringValue)
+	return nil
+}
+
+func (f *Filter) addMetricPredicate(p *api.Predicate) error {
+	name := strings.TrimPrefix(p.Key, MetricKeyPrefix)
+	if name == "" {
+		return util.NewInvalidInputError("no metric name in predicate key %q", p.Key)
+	}
+	operator, ok := metricOperators[p.Op]
+	if !ok {
+		return util.NewInvalidInputError("cannot use operator %v on metric %q, only comparisons are supported", p.Op, name)
+	}
+	var value float64
+	switch t := p.Value.(type) {
+	case *api.Predicate_IntValue:
+		value = float64(t.IntValue)
+	case *api.Predicate_LongValue:
+		value = float64(t.LongValue)
+	case *api.Predicate_StringValue:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(t.StringValue), 64)
+		if err != nil || math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+			return util.NewInvalidInputError("cannot compare metric %q to %q, which is not a number", name, t.StringValue)
+		}
+		value = parsed
+	default:
+		return util.NewInvalidInputError("cannot use value type %T on metric %q, only numbers are supported", p.Value, name)
+	}
+	f.metrics = append(f.metrics, &MetricPredicate{Name: name, Operator: operator, Value: value})
+	return nil
+}
+
 func addPredicateValue(m map[string][]interface{}, p *api.Predicate) error {
 	switch t := p.Value.(type) {
 	case *api.Predicate_IntValue:
@@ -122,6 +122,22 @@ func TestValidNewFiltersWithKeyMap(t *testing.T) {
 				key: "name" op: IS_SUBSTRING string_value: "pipeline" }`,
 			&Filter{substring: map[string][]interface{}{"pipelines.Name": {"pipeline"}}},
 		},
+		{
+			`predicates { key: "labels.team" op: EQUALS string_value: "ml" }
+			 predicates { key: "name" op: EQUALS string_value: "pipeline" }`,
+			&Filter{
+				eq:     map[string][]interface{}{"pipelines.Name": {"pipeline"}},
+				labels: map[string][]string{"team": {"ml"}},
+			},
+		},
+		{
+			`predicates { key: "metrics.accuracy" op: GREATER_THAN string_value: "0.9" }
+			 predicates { key: "metrics.epochs" op: LESS_THAN_EQUALS int_value: 10 }`,
+			&Filter{metrics: []*MetricPredicate{
+				{Name: "accuracy", Operator: ">", Value: 0.9},
+				{Name: "epochs", Operator: "<=", Value: 10},
+			}},
+		},
 	}
 
 	for _, test := range tests {
@@ -207,6 +223,34 @@ func TestInvalidFilters(t *testing.T) {
 			`predicates { key: "total" op: LESS_THAN
 				timestamp_value { seconds: -100000000000 }}`,
 		},
+		// Labels only support equality with a string.
+		{
+			`predicates { key: "labels.team" op: NOT_EQUALS string_value: "ml" }`,
+		},
+		{
+			`predicates { key: "labels.team" op: EQUALS int_value: 10 }`,
+		},
+		// No label key
+		{
+			`predicates { key: "labels." op: EQUALS string_value: "ml" }`,
+		},
+		// Metrics only support comparisons with a number.
+		{
+			`predicates { key: "metrics.accuracy" op: IS_SUBSTRING string_value: "0.9" }`,
+		},
+		{
+			`predicates { key: "metrics.accuracy" op: GREATER_THAN string_value: "high" }`,
+		},
+		{
+			`predicates { key: "metrics.accuracy" op: GREATER_THAN string_value: "NaN" }`,
+		},
+		{
+			`predicates { key: "metrics.accuracy" op: GREATER_THAN timestamp_value { seconds: 10 }}`,
+		},
+		// No metric name
+		{
+			`predicates { key: "metrics." op: GREATER_THAN int_value: 1 }`,
+		},
 	}
 
 	for _, test := range tests {
@@ -325,7 +369,7 @@ func TestMarshalJSON(t *testing.T) {
 		eq: map[string][]interface{}{"name": {"SomeName"}},
 	}
 
-	want := `{"FilterProto":"{\"predicates\":[{\"op\":\"EQUALS\",\"key\":\"Name\",\"stringValue\":\"SomeName\"}]}","EQ":{"name":["SomeName"]},"NEQ":null,"GT":null,"GTE":null,"LT":null,"LTE":null,"IN":null,"SUBSTRING":null}`
+	want := `{"FilterProto":"{\"predicates\":[{\"op\":\"EQUALS\",\"key\":\"Name\",\"stringValue\":\"SomeName\"}]}","EQ":{"name":["SomeName"]},"NEQ":null,"GT":null,"GTE":null,"LT":null,"LTE":null,"IN":null,"SUBSTRING":null,"LABELS":null}`
 
 	got, err := json.Marshal(f)
 	if err != nil || string(got) != want {
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/server"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"google.golang.org/grpc"
 )
@@ -27,6 +28,20 @@ import (
 // For more details, see https://github.com/grpc/grpc-go/blob/master/interceptor.go
 func apiServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 	glog.Infof("%v handler starting", info.FullMethod)
+	// Mutations are rejected here, before any handler, while the server is in read-only maintenance mode.
+	if err = server.CheckReadOnlyMode(info.FullMethod); err != nil {
+		glog.Infof("%v rejected in read-only mode", info.FullMethod)
+		return nil, util.ToGRPCError(err)
+	}
+	// Once the server is shutting down, new mutations are rejected and those in flight get to finish.
+	if server.IsMutatingMethod(info.FullMethod) {
+		done, err := server.BeginMutation()
+		if err != nil {
+			glog.Infof("%v rejected while shutting down", info.FullMethod)
+			return nil, util.ToGRPCError(err)
+		}
+		defer done()
+	}
 	resp, err = handler(ctx, req)
 	if err != nil {
 		util.LogError(util.Wrapf(err, "%s call failed", info.FullMethod))
@@ -37,3 +52,19 @@ func apiServerInterceptor(ctx context.Context, req interface{}, info *grpc.Unary
 	glog.Infof("%v handler finished", info.FullMethod)
 	return
 }
+
+// apiServerStreamInterceptor is apiServerInterceptor for the methods streaming their responses. Errors end the
+// stream with their gRPC status, so that clients can tell an interrupted stream from a complete one.
+func apiServerStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	glog.Infof("%v handler starting", info.FullMethod)
+	if err = server.CheckReadOnlyMode(info.FullMethod); err != nil {
+		glog.Infof("%v rejected in read-only mode", info.FullMethod)
+		return util.ToGRPCError(err)
+	}
+	if err = handler(srv, ss); err != nil {
+		util.LogError(util.Wrapf(err, "%s call failed", info.FullMethod))
+		return util.ToGRPCError(err)
+	}
+	glog.Infof("%v handler finished", info.FullMethod)
+	return nil
+}
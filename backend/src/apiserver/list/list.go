@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	sq "github.com/Masterminds/squirrel"
@@ -37,8 +38,10 @@ import (
 // represent a query for an initial set of results, in which page
 // SortByFieldValue and KeyFieldValue are nil. If the latter fields are not nil,
 // then token represents a query for a subsequent set of results (i.e., the next
-// page of results), with the two values pointing to the first record in the
-// next set of results.
+// page of results), with the two values pointing to the last record of the
+// previous set of results. Since the query compares (sort value, key) tuples
+// instead of using an offset, pages stay stable when rows are inserted or
+// deleted between calls.
 type token struct {
 	// SortByFieldName is the field name to use when sorting.
 	SortByFieldName string
@@ -57,11 +60,19 @@ type token struct {
 	// IsDesc is true if the sorting order should be descending.
 	IsDesc bool
 
+	// IsLastSeen is true if the ***FieldValue fields refer to the last record
+	// of the previous page, which is then excluded from the next page. Tokens
+	// without it refer to the first record of the next page, which is included.
+	IsLastSeen bool
+
 	// ModelName is the table where ***FieldName belongs to.
 	ModelName string
 
 	// Filter represents the filtering that should be applied in the query.
 	Filter *filter.Filter
+
+	// IncludeDeleted is true if soft deleted resources are listed too.
+	IncludeDeleted bool
 }
 
 func (t *token) unmarshal(pageToken string) error {
@@ -102,7 +113,7 @@ type Options struct {
 // of the one supplied in opts.
 func (o *Options) Matches(opts *Options) bool {
 	return o.SortByFieldName == opts.SortByFieldName && o.SortByFieldPrefix == opts.SortByFieldPrefix &&
-		o.IsDesc == opts.IsDesc &&
+		o.IsDesc == opts.IsDesc && o.IncludeDeleted == opts.IncludeDeleted &&
 		reflect.DeepEqual(o.Filter, opts.Filter)
 }
 
@@ -164,10 +175,22 @@ func NewOptions(listable Listable, pageSize int, sortBy string, filterProto *api
 
 	// Filtering.
 	if filterProto != nil {
+		if rewrite, ok := predicateRewriters[listable.GetModelName()]; ok {
+			filterProto, err = rewriteFilter(filterProto, rewrite)
+			if err != nil {
+				return nil, err
+			}
+		}
 		f, err := filter.NewWithKeyMap(filterProto, listable.APIToModelFieldMap(), listable.GetModelName())
 		if err != nil {
 			return nil, err
 		}
+		if _, ok := listable.(LabeledListable); f.HasLabelPredicates() && !ok {
+			return nil, util.NewInvalidInputError("no support for filtering on labels of listable type %s", reflect.ValueOf(listable).Elem().Type().Name())
+		}
+		if _, ok := listable.(MetricListable); f.HasMetricPredicates() && !ok {
+			return nil, util.NewInvalidInputError("no support for filtering on metrics of listable type %s", reflect.ValueOf(listable).Elem().Type().Name())
+		}
 		token.Filter = f
 	}
 
@@ -188,18 +211,30 @@ func (o *Options) AddPaginationToSelect(sqlBuilder sq.SelectBuilder) sq.SelectBu
 // AddSortingToSelect adds Order By clause.
 func (o *Options) AddSortingToSelect(sqlBuilder sq.SelectBuilder) sq.SelectBuilder {
 	// When sorting by a direct field in the listable model (i.e., name in Run or uuid in Pipeline), a sortByFieldPrefix can be specified; when sorting by a field in an array-typed dictionary (i.e., a run metric inside the metrics in Run), a sortByFieldPrefix is not needed.
-	// If next row's value is specified, set those values in the clause.
+	// If the boundary row's value is specified, set those values in the clause. The key is
+	// compared in the same direction as the sort field, matching the ORDER BY below.
 	if o.SortByFieldValue != nil && o.KeyFieldValue != nil {
+		sortByField := o.SortByFieldPrefix + o.SortByFieldName
+		keyField := o.KeyFieldPrefix + o.KeyFieldName
+		var keyCondition sq.Sqlizer
+		switch {
+		case o.IsDesc && o.IsLastSeen:
+			keyCondition = sq.Lt{keyField: o.KeyFieldValue}
+		case o.IsDesc:
+			keyCondition = sq.LtOrEq{keyField: o.KeyFieldValue}
+		case o.IsLastSeen:
+			keyCondition = sq.Gt{keyField: o.KeyFieldValue}
+		default:
+			keyCondition = sq.GtOrEq{keyField: o.KeyFieldValue}
+		}
 		if o.IsDesc {
 			sqlBuilder = sqlBuilder.
-				Where(sq.Or{sq.Lt{o.SortByFieldPrefix + o.SortByFieldName: o.SortByFieldValue},
-					sq.And{sq.Eq{o.SortByFieldPrefix + o.SortByFieldName: o.SortByFieldValue},
-						sq.LtOrEq{o.KeyFieldPrefix + o.KeyFieldName: o.KeyFieldValue}}})
+				Where(sq.Or{sq.Lt{sortByField: o.SortByFieldValue},
+					sq.And{sq.Eq{sortByField: o.SortByFieldValue}, keyCondition}})
 		} else {
 			sqlBuilder = sqlBuilder.
-				Where(sq.Or{sq.Gt{o.SortByFieldPrefix + o.SortByFieldName: o.SortByFieldValue},
-					sq.And{sq.Eq{o.SortByFieldPrefix + o.SortByFieldName: o.SortByFieldValue},
-						sq.GtOrEq{o.KeyFieldPrefix + o.KeyFieldName: o.KeyFieldValue}}})
+				Where(sq.Or{sq.Gt{sortByField: o.SortByFieldValue},
+					sq.And{sq.Eq{sortByField: o.SortByFieldValue}, keyCondition}})
 		}
 	}
 
@@ -225,6 +260,56 @@ func (o *Options) AddFilterToSelect(sqlBuilder sq.SelectBuilder) sq.SelectBuilde
 	return sqlBuilder
 }
 
+// AddLabelFilterToSelect adds a WHERE clause for every label predicate in the Options o to the
+// supplied SelectBuilder, matching the rows whose key field identifies a resource of the given type
+// with that label, and returns the new SelectBuilder containing these.
+func (o *Options) AddLabelFilterToSelect(sqlBuilder sq.SelectBuilder, resourceType model.ResourceType) sq.SelectBuilder {
+	if o.Filter == nil {
+		return sqlBuilder
+	}
+	keyField := o.KeyFieldPrefix + o.KeyFieldName
+	labels := o.Filter.Labels()
+	// Iterate in a stable order so that the same filter always yields the same query.
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range labels[key] {
+			sqlBuilder = sqlBuilder.Where(sq.Expr(
+				keyField+" IN (SELECT ResourceUUID FROM labels WHERE ResourceType = ? AND LabelKey = ? AND LabelValue = ?)",
+				resourceType, key, value))
+		}
+	}
+	return sqlBuilder
+}
+
+// AddMetricFilterToSelect adds a WHERE clause for every metric predicate in the Options o to the supplied
+// SelectBuilder, matching the rows whose key field identifies a run whose latest value of the metric satisfies
+// the predicate, and returns the new SelectBuilder containing these. A metric is stored once per node that
+// reported it, so the latest value is the one of the node that reported it last, with ties going to the
+// greatest node ID.
+//
+// Every predicate is a subquery on run_metrics that the name_numbervalue index answers, so its cost grows
+// with the number of runs that reported the metric with a matching value rather than with the number of runs
+// being listed. Predicates on metrics reported by most runs cost about as much as listing every run.
+func (o *Options) AddMetricFilterToSelect(sqlBuilder sq.SelectBuilder) sq.SelectBuilder {
+	if o.Filter == nil {
+		return sqlBuilder
+	}
+	keyField := o.KeyFieldPrefix + o.KeyFieldName
+	for _, metric := range o.Filter.Metrics() {
+		// The operator is one of the comparisons the filter accepts, not anything the caller wrote.
+		sqlBuilder = sqlBuilder.Where(sq.Expr(
+			keyField+" IN (SELECT rm.RunUUID FROM run_metrics AS rm WHERE rm.Name = ? AND rm.NumberValue "+metric.Operator+" ?"+
+				" AND NOT EXISTS (SELECT 1 FROM run_metrics AS newer WHERE newer.RunUUID = rm.RunUUID AND newer.Name = rm.Name"+
+				" AND (newer.ReportedAtInSec > rm.ReportedAtInSec OR (newer.ReportedAtInSec = rm.ReportedAtInSec AND newer.NodeID > rm.NodeID))))",
+			metric.Name, metric.Value))
+	}
+	return sqlBuilder
+}
+
 // FilterOnResourceReference filters the given resource's table by rows from the ResourceReferences
 // table that match an optional given filter, and returns the rebuilt SelectBuilder
 func FilterOnResourceReference(tableName string, columns []string, resourceType model.ResourceType,
@@ -321,9 +406,23 @@ type Listable interface {
 	GetFieldValue(name string) interface{}
 }
 
+// LabeledListable is a Listable whose resources carry labels, which can be filtered on.
+type LabeledListable interface {
+	Listable
+	// GetLabels returns the labels of a listable object.
+	GetLabels() map[string]string
+}
+
+// MetricListable is a Listable whose resources report metrics, which can be filtered on.
+type MetricListable interface {
+	Listable
+	// GetMetrics returns the metrics of a listable object.
+	GetMetrics() []*model.RunMetric
+}
+
 // NextPageToken returns a string that can be used to fetch the subsequent set
-// of results using the same listing options in o, starting with listable as the
-// first record.
+// of results using the same listing options in o, starting right after
+// listable, which should be the last record of the current page.
 func (o *Options) NextPageToken(listable Listable) (string, error) {
 	t, err := o.nextPageToken(listable)
 	if err != nil {
@@ -354,6 +453,7 @@ func (o *Options) nextPageToken(listable Listable) (*token, error) {
 		KeyFieldValue:     keyField.Interface(),
 		KeyFieldPrefix:    listable.GetKeyFieldPrefix(),
 		IsDesc:            o.IsDesc,
+		IsLastSeen:        true,
 		Filter:            o.Filter,
 		ModelName:         o.ModelName,
 	}, nil
@@ -380,3 +480,96 @@ func validatePageSize(pageSize int) (int, error) {
 
 	return pageSize, nil
 }
+
+// predicateRewriters rewrite, by model name, the predicates on fields that don't have a column of their own into
+// predicates on columns.
+var predicateRewriters = map[string]func(p *api.Predicate) ([]*api.Predicate, error){
+	"jobs":      rewriteJobPredicate,
+	"pipelines": rewritePipelinePredicate,
+	// Runs have no model name.
+	"": rewriteRunPredicate,
+}
+
+func rewriteFilter(filterProto *api.Filter, rewrite func(p *api.Predicate) ([]*api.Predicate, error)) (*api.Filter, error) {
+	rewritten := &api.Filter{}
+	for _, p := range filterProto.GetPredicates() {
+		predicates, err := rewrite(p)
+		if err != nil {
+			return nil, err
+		}
+		rewritten.Predicates = append(rewritten.Predicates, predicates...)
+	}
+	return rewritten, nil
+}
+
+// Schedule types of jobs that can be filtered on with the "schedule_type" key.
+const (
+	JobScheduleTypeCron     = "CRON"
+	JobScheduleTypePeriodic = "PERIODIC"
+)
+
+// rewriteJobPredicate rewrites the predicates of job filters on these keys:
+//   - "enabled", with the value "true" or "false", matches the jobs that were enabled or disabled through the
+//     API. A job whose end time has passed is still enabled, even though it doesn't create runs anymore.
+//   - "schedule_type", with the value "CRON" or "PERIODIC", matches the jobs triggered by a cron schedule or at
+//     a fixed interval.
+//
+// Both support the EQUALS and NOT_EQUALS operations. Other predicates are kept as they are.
+func rewriteJobPredicate(p *api.Predicate) ([]*api.Predicate, error) {
+	if p.GetKey() != "enabled" && p.GetKey() != "schedule_type" {
+		return []*api.Predicate{p}, nil
+	}
+	if p.GetOp() != api.Predicate_EQUALS && p.GetOp() != api.Predicate_NOT_EQUALS {
+		return nil, util.NewInvalidInputError("cannot use operator %v on %q, only EQUALS and NOT_EQUALS are supported", p.GetOp(), p.GetKey())
+	}
+	equals := p.GetOp() == api.Predicate_EQUALS
+	value := strings.ToLower(p.GetStringValue())
+	if p.GetKey() == "enabled" {
+		if value != "true" && value != "false" {
+			return nil, util.NewInvalidInputError("\"enabled\" must be \"true\" or \"false\", got %v", p.GetValue())
+		}
+		enabled := int64(0)
+		if value == "true" {
+			enabled = 1
+		}
+		return []*api.Predicate{{Key: "enabled", Op: p.GetOp(), Value: &api.Predicate_LongValue{LongValue: enabled}}}, nil
+	}
+	// A job has a single trigger, so not being triggered by one type means being triggered by the other.
+	cron := strings.ToUpper(value) == JobScheduleTypeCron
+	if !cron && strings.ToUpper(value) != JobScheduleTypePeriodic {
+		return nil, util.NewInvalidInputError("\"schedule_type\" must be %q or %q, got %v", JobScheduleTypeCron, JobScheduleTypePeriodic, p.GetValue())
+	}
+	if cron == equals {
+		return []*api.Predicate{{Key: "cron_schedule.cron", Op: api.Predicate_NOT_EQUALS, Value: &api.Predicate_StringValue{StringValue: ""}}}, nil
+	}
+	return []*api.Predicate{{Key: "periodic_schedule.interval_second", Op: api.Predicate_GREATER_THAN, Value: &api.Predicate_LongValue{LongValue: 0}}}, nil
+}
+
+// rewriteRunPredicate drops the predicates of run filters on "created_by" in single-user mode, where the user
+// that created a run isn't known. Other predicates are kept as they are.
+func rewriteRunPredicate(p *api.Predicate) ([]*api.Predicate, error) {
+	if p.GetKey() == "created_by" && !common.IsMultiUserMode() {
+		return nil, nil
+	}
+	return []*api.Predicate{p}, nil
+}
+
+// rewritePipelinePredicate rewrites the predicates of pipeline filters on "has_versions", with the value "true"
+// or "false", into predicates on the number of ready versions of the pipelines. It supports the EQUALS and
+// NOT_EQUALS operations. Other predicates are kept as they are.
+func rewritePipelinePredicate(p *api.Predicate) ([]*api.Predicate, error) {
+	if p.GetKey() != "has_versions" {
+		return []*api.Predicate{p}, nil
+	}
+	if p.GetOp() != api.Predicate_EQUALS && p.GetOp() != api.Predicate_NOT_EQUALS {
+		return nil, util.NewInvalidInputError("cannot use operator %v on %q, only EQUALS and NOT_EQUALS are supported", p.GetOp(), p.GetKey())
+	}
+	value := strings.ToLower(p.GetStringValue())
+	if value != "true" && value != "false" {
+		return nil, util.NewInvalidInputError("\"has_versions\" must be \"true\" or \"false\", got %v", p.GetValue())
+	}
+	if (value == "true") == (p.GetOp() == api.Predicate_EQUALS) {
+		return []*api.Predicate{{Key: "version_count", Op: api.Predicate_GREATER_THAN, Value: &api.Predicate_LongValue{LongValue: 0}}}, nil
+	}
+	return []*api.Predicate{{Key: "version_count", Op: api.Predicate_EQUALS, Value: &api.Predicate_LongValue{LongValue: 0}}}, nil
+}
@@ -14,7 +14,9 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/testing/protocmp"
 )
 
@@ -126,6 +128,7 @@ func TestNextPageToken_ValidTokens(t *testing.T) {
 				KeyFieldValue:     "uuid123",
 				KeyFieldPrefix:    "",
 				IsDesc:            true,
+				IsLastSeen:        true,
 			},
 		},
 		{
@@ -140,6 +143,7 @@ func TestNextPageToken_ValidTokens(t *testing.T) {
 				KeyFieldValue:     "uuid123",
 				KeyFieldPrefix:    "",
 				IsDesc:            true,
+				IsLastSeen:        true,
 			},
 		},
 		{
@@ -154,6 +158,7 @@ func TestNextPageToken_ValidTokens(t *testing.T) {
 				KeyFieldValue:     "uuid123",
 				KeyFieldPrefix:    "",
 				IsDesc:            false,
+				IsLastSeen:        true,
 			},
 		},
 		{
@@ -172,6 +177,7 @@ func TestNextPageToken_ValidTokens(t *testing.T) {
 				KeyFieldValue:     "uuid123",
 				KeyFieldPrefix:    "",
 				IsDesc:            false,
+				IsLastSeen:        true,
 				Filter:            testFilter,
 			},
 		},
@@ -190,6 +196,7 @@ func TestNextPageToken_ValidTokens(t *testing.T) {
 				KeyFieldValue:     "uuid123",
 				KeyFieldPrefix:    "",
 				IsDesc:            false,
+				IsLastSeen:        true,
 			},
 		},
 	}
@@ -597,6 +604,40 @@ func TestAddPaginationAndFilterToSelect(t *testing.T) {
 			wantSQL:  "SELECT * FROM MyTable WHERE (SortField > ? OR (SortField = ? AND KeyField >= ?)) ORDER BY SortField ASC, KeyField ASC LIMIT 124",
 			wantArgs: []interface{}{"value", "value", 1111},
 		},
+		{
+			in: &Options{
+				PageSize: 123,
+				token: &token{
+					SortByFieldName:   "SortField",
+					SortByFieldValue:  "value",
+					SortByFieldPrefix: "",
+					KeyFieldName:      "KeyField",
+					KeyFieldValue:     1111,
+					KeyFieldPrefix:    "",
+					IsDesc:            true,
+					IsLastSeen:        true,
+				},
+			},
+			wantSQL:  "SELECT * FROM MyTable WHERE (SortField < ? OR (SortField = ? AND KeyField < ?)) ORDER BY SortField DESC, KeyField DESC LIMIT 124",
+			wantArgs: []interface{}{"value", "value", 1111},
+		},
+		{
+			in: &Options{
+				PageSize: 123,
+				token: &token{
+					SortByFieldName:   "SortField",
+					SortByFieldValue:  "value",
+					SortByFieldPrefix: "",
+					KeyFieldName:      "KeyField",
+					KeyFieldValue:     1111,
+					KeyFieldPrefix:    "",
+					IsDesc:            false,
+					IsLastSeen:        true,
+				},
+			},
+			wantSQL:  "SELECT * FROM MyTable WHERE (SortField > ? OR (SortField = ? AND KeyField > ?)) ORDER BY SortField ASC, KeyField ASC LIMIT 124",
+			wantArgs: []interface{}{"value", "value", 1111},
+		},
 		{
 			in: &Options{
 				PageSize: 123,
@@ -1055,3 +1096,188 @@ func TestAddStatusFilterToSelectWithRunModel(t *testing.T) {
 	assert.Contains(t, sql, "WHERE Conditions <> ?") // filtering on status, aka Conditions in db
 	assert.Contains(t, args, "somevalue")
 }
+
+func TestAddLabelFilterToSelectWithPipelineModel(t *testing.T) {
+	protoFilter := &api.Filter{
+		Predicates: []*api.Predicate{
+			{
+				Key:   "labels.team",
+				Op:    api.Predicate_EQUALS,
+				Value: &api.Predicate_StringValue{StringValue: "ml"},
+			},
+			{
+				Key:   "labels.env",
+				Op:    api.Predicate_EQUALS,
+				Value: &api.Predicate_StringValue{StringValue: "prod"},
+			},
+		},
+	}
+	listableOptions, err := NewOptions(&model.Pipeline{}, 10, "name", protoFilter)
+	assert.Nil(t, err)
+	sqlBuilder := sq.Select("*").From("pipelines")
+	sql, args, err := listableOptions.AddLabelFilterToSelect(sqlBuilder, "pipeline").ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT * FROM pipelines "+
+		"WHERE pipelines.UUID IN (SELECT ResourceUUID FROM labels WHERE ResourceType = ? AND LabelKey = ? AND LabelValue = ?) "+
+		"AND pipelines.UUID IN (SELECT ResourceUUID FROM labels WHERE ResourceType = ? AND LabelKey = ? AND LabelValue = ?)", sql)
+	assert.Equal(t, []interface{}{model.ResourceType("pipeline"), "env", "prod", model.ResourceType("pipeline"), "team", "ml"}, args)
+
+	// Label predicates don't add any other condition.
+	sql, _, err = listableOptions.AddFilterToSelect(sqlBuilder).ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT * FROM pipelines", sql)
+}
+
+func TestNewOptions_JobFilter(t *testing.T) {
+	newJobOptions := func(key string, op api.Predicate_Op, value string) (*Options, error) {
+		return NewOptions(&model.Job{}, 10, "name", &api.Filter{
+			Predicates: []*api.Predicate{{Key: key, Op: op, Value: &api.Predicate_StringValue{StringValue: value}}},
+		})
+	}
+	for _, test := range []struct {
+		key   string
+		op    api.Predicate_Op
+		value string
+		sql   string
+		args  []interface{}
+	}{
+		{"enabled", api.Predicate_EQUALS, "true", "WHERE jobs.Enabled = ?", []interface{}{int64(1)}},
+		{"enabled", api.Predicate_NOT_EQUALS, "False", "WHERE jobs.Enabled <> ?", []interface{}{int64(0)}},
+		{"schedule_type", api.Predicate_EQUALS, "CRON", "WHERE jobs.Schedule <> ?", []interface{}{""}},
+		{"schedule_type", api.Predicate_EQUALS, "periodic", "WHERE jobs.IntervalSecond > ?", []interface{}{int64(0)}},
+		{"schedule_type", api.Predicate_NOT_EQUALS, "PERIODIC", "WHERE jobs.Schedule <> ?", []interface{}{""}},
+	} {
+		opts, err := newJobOptions(test.key, test.op, test.value)
+		assert.Nil(t, err)
+		sql, args, err := opts.AddFilterToSelect(sq.Select("*").From("jobs")).ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, "SELECT * FROM jobs "+test.sql, sql)
+		assert.Equal(t, test.args, args)
+	}
+
+	_, err := newJobOptions("enabled", api.Predicate_EQUALS, "yes")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	_, err = newJobOptions("schedule_type", api.Predicate_EQUALS, "HOURLY")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	_, err = newJobOptions("schedule_type", api.Predicate_IS_SUBSTRING, "CRON")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+}
+
+func TestNewOptions_PipelineHasVersionsFilter(t *testing.T) {
+	newPipelineOptions := func(op api.Predicate_Op, value string) (*Options, error) {
+		return NewOptions(&model.Pipeline{}, 10, "name", &api.Filter{
+			Predicates: []*api.Predicate{{Key: "has_versions", Op: op, Value: &api.Predicate_StringValue{StringValue: value}}},
+		})
+	}
+	for _, test := range []struct {
+		op    api.Predicate_Op
+		value string
+		sql   string
+	}{
+		{api.Predicate_EQUALS, "true", "WHERE pipelines.VersionCount > ?"},
+		{api.Predicate_EQUALS, "False", "WHERE pipelines.VersionCount = ?"},
+		{api.Predicate_NOT_EQUALS, "true", "WHERE pipelines.VersionCount = ?"},
+		{api.Predicate_NOT_EQUALS, "false", "WHERE pipelines.VersionCount > ?"},
+	} {
+		opts, err := newPipelineOptions(test.op, test.value)
+		assert.Nil(t, err)
+		sql, args, err := opts.AddFilterToSelect(sq.Select("*").From("pipelines")).ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, "SELECT * FROM pipelines "+test.sql, sql)
+		assert.Equal(t, []interface{}{int64(0)}, args)
+	}
+
+	_, err := newPipelineOptions(api.Predicate_EQUALS, "yes")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	_, err = newPipelineOptions(api.Predicate_GREATER_THAN, "true")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+}
+
+func TestNewOptions_RunCreatedByFilter(t *testing.T) {
+	newRunOptions := func() (*Options, error) {
+		return NewOptions(&model.Run{}, 10, "name", &api.Filter{
+			Predicates: []*api.Predicate{
+				{Key: "created_by", Op: api.Predicate_EQUALS, Value: &api.Predicate_StringValue{StringValue: "alice@corp.com"}},
+				{Key: "name", Op: api.Predicate_EQUALS, Value: &api.Predicate_StringValue{StringValue: "run1"}},
+			},
+		})
+	}
+
+	// The creator of runs isn't known in single-user mode.
+	opts, err := newRunOptions()
+	assert.Nil(t, err)
+	sql, args, err := opts.AddFilterToSelect(sq.Select("*").From("run_details")).ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT * FROM run_details WHERE DisplayName = ?", sql)
+	assert.Equal(t, []interface{}{"run1"}, args)
+
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+	opts, err = newRunOptions()
+	assert.Nil(t, err)
+	sql, args, err = opts.AddFilterToSelect(sq.Select("*").From("run_details")).ToSql()
+	assert.Nil(t, err)
+	// The predicates are in no particular order.
+	assert.Contains(t, []string{
+		"SELECT * FROM run_details WHERE CreatedBy = ? AND DisplayName = ?",
+		"SELECT * FROM run_details WHERE DisplayName = ? AND CreatedBy = ?",
+	}, sql)
+	assert.ElementsMatch(t, []interface{}{"alice@corp.com", "run1"}, args)
+}
+
+func TestAddMetricFilterToSelectWithRunModel(t *testing.T) {
+	protoFilter := &api.Filter{
+		Predicates: []*api.Predicate{
+			{
+				Key:   "metrics.accuracy",
+				Op:    api.Predicate_GREATER_THAN,
+				Value: &api.Predicate_StringValue{StringValue: "0.9"},
+			},
+			{
+				Key:   "metrics.loss",
+				Op:    api.Predicate_LESS_THAN_EQUALS,
+				Value: &api.Predicate_StringValue{StringValue: "0.1"},
+			},
+		},
+	}
+	listableOptions, err := NewOptions(&model.Run{}, 10, "name", protoFilter)
+	assert.Nil(t, err)
+	sqlBuilder := sq.Select("*").From("run_details")
+	sql, args, err := listableOptions.AddMetricFilterToSelect(sqlBuilder).ToSql()
+	assert.Nil(t, err)
+	latest := " AND NOT EXISTS (SELECT 1 FROM run_metrics AS newer WHERE newer.RunUUID = rm.RunUUID AND newer.Name = rm.Name" +
+		" AND (newer.ReportedAtInSec > rm.ReportedAtInSec OR (newer.ReportedAtInSec = rm.ReportedAtInSec AND newer.NodeID > rm.NodeID))))"
+	assert.Equal(t, "SELECT * FROM run_details "+
+		"WHERE UUID IN (SELECT rm.RunUUID FROM run_metrics AS rm WHERE rm.Name = ? AND rm.NumberValue > ?"+latest+" "+
+		"AND UUID IN (SELECT rm.RunUUID FROM run_metrics AS rm WHERE rm.Name = ? AND rm.NumberValue <= ?"+latest, sql)
+	assert.Equal(t, []interface{}{"accuracy", 0.9, "loss", 0.1}, args)
+
+	// Metric predicates don't add any other condition, and survive the page token.
+	sql, _, err = listableOptions.AddFilterToSelect(sqlBuilder).ToSql()
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT * FROM run_details", sql)
+	nextPageToken, err := listableOptions.NextPageToken(&model.Run{UUID: "run1", DisplayName: "run1"})
+	assert.Nil(t, err)
+	nextOptions, err := NewOptionsFromToken(nextPageToken, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, listableOptions.Filter.Metrics(), nextOptions.Filter.Metrics())
+
+	_, err = NewOptions(&model.Job{}, 10, "name", protoFilter)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "no support for filtering on metrics")
+}
+
+func TestNewOptions_LabelFilterOnUnlabeledModel(t *testing.T) {
+	protoFilter := &api.Filter{
+		Predicates: []*api.Predicate{
+			{
+				Key:   "labels.team",
+				Op:    api.Predicate_EQUALS,
+				Value: &api.Predicate_StringValue{StringValue: "ml"},
+			},
+		},
+	}
+	_, err := NewOptions(&model.Job{}, 10, "name", protoFilter)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "no support for filtering on labels")
+}
@@ -25,8 +25,10 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
@@ -60,6 +62,9 @@ func main() {
 	flag.Parse()
 
 	initConfig()
+	if err := common.ValidateNamespacePipelineRoots(); err != nil {
+		glog.Fatalf("Invalid pipeline roots configuration. Err: %v", err)
+	}
 	clientManager := newClientManager()
 	resourceManager := resource.NewResourceManager(&clientManager)
 	err := loadSamples(resourceManager)
@@ -72,28 +77,133 @@ func main() {
 		glog.Fatalf("Failed to create default experiment. Err: %v", err)
 	}
 
-	go startRpcServer(resourceManager)
-	startHttpProxy(resourceManager)
+	if err := resourceManager.ResumeCompletionWebhooks(); err != nil {
+		glog.Errorf("Failed to resume the pending completion webhooks. Err: %v", err)
+	}
+
+	if common.IsExperimentSoftDeleteEnabled() {
+		go startExperimentReaper(resourceManager)
+	}
+	if common.IsRunReaperEnabled() {
+		go startRunReaper(resourceManager)
+	}
+	if common.GetManifestCompressionBatchSize() > 0 {
+		go compressStoredManifests(resourceManager)
+	}
+	rpcServer := grpc.NewServer(grpc.UnaryInterceptor(apiServerInterceptor), grpc.StreamInterceptor(apiServerStreamInterceptor), grpc.MaxRecvMsgSize(math.MaxInt32))
+	httpServer := &http.Server{Addr: *httpPortFlag}
+	go startRpcServer(resourceManager, rpcServer)
+	go startHttpProxy(resourceManager, httpServer)
+	shutDownOnSignal(resourceManager, rpcServer, httpServer)
 
 	clientManager.Close()
 }
 
+// shutDownOnSignal waits for the API server to be told to stop, then shuts it down gracefully. Requests that
+// would change anything are rejected from then on, while those already being served get until the drain
+// timeout to finish, so that e.g. a CreateRun call that submitted its workflow gets to store its run. So do the
+// completion webhooks being delivered; those that aren't delivered in time are resumed on the next start.
+func shutDownOnSignal(resourceManager *resource.ResourceManager, rpcServer *grpc.Server, httpServer *http.Server) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+	received := <-signals
+	timeout := common.GetShutdownDrainTimeout()
+	glog.Infof("Received %v, shutting down within %v", received, timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if !server.Drain(timeout) {
+		glog.Warningf("Requests were still being served after %v, shutting down anyway", timeout)
+	}
+	if deadline, _ := ctx.Deadline(); !resourceManager.WaitForCompletionWebhooks(time.Until(deadline)) {
+		glog.Warningf("Completion webhooks were still being delivered after %v, they will be resumed on the next start", timeout)
+	}
+	// The HTTP proxy forwards its requests to the RPC server, so it is shut down first.
+	if err := httpServer.Shutdown(ctx); err != nil {
+		glog.Warningf("Failed to shut down the Http Proxy gracefully: %v", err)
+	}
+	stopped := make(chan struct{})
+	go func() {
+		rpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		rpcServer.Stop()
+	}
+	glog.Info("API server shut down")
+}
+
+// startExperimentReaper periodically deletes the experiments that were soft deleted
+// longer than the retention window ago.
+func startExperimentReaper(resourceManager *resource.ResourceManager) {
+	retention := common.GetExperimentSoftDeleteRetention()
+	interval := common.GetExperimentReaperInterval()
+	glog.Infof("Starting experiment reaper with retention %v and interval %v", retention, interval)
+	for range time.Tick(interval) {
+		reaped, err := resourceManager.ReapDeletedExperiments(retention)
+		if err != nil {
+			glog.Errorf("Failed to reap deleted experiments. Err: %v", err)
+		}
+		if reaped > 0 {
+			glog.Infof("Reaped %v deleted experiments", reaped)
+		}
+	}
+}
+
+// startRunReaper periodically deletes, or archives, the runs that finished longer than their retention ago.
+func startRunReaper(resourceManager *resource.ResourceManager) {
+	opts := resource.RunReaperOptions{
+		Retention:           common.GetRunRetention(),
+		NamespaceRetention:  common.GetRunRetentionOverrides(),
+		Archive:             common.GetBoolConfigWithDefault(common.RunReaperArchive, false),
+		KeepMetrics:         common.GetBoolConfigWithDefault(common.RunReaperKeepMetrics, false),
+		KeepStatusSnapshots: common.GetBoolConfigWithDefault(common.RunReaperKeepStatusSnapshots, false),
+		BatchSize:           common.GetRunReaperBatchSize(),
+	}
+	interval := common.GetRunReaperInterval()
+	glog.Infof("Starting run reaper with retention %v, namespace overrides %v and interval %v", opts.Retention, opts.NamespaceRetention, interval)
+	for range time.Tick(interval) {
+		reaped, err := resourceManager.ReapRuns(opts)
+		if err != nil {
+			glog.Errorf("Failed to reap runs. Err: %v", err)
+		}
+		if reaped > 0 {
+			glog.Infof("Reaped %v runs", reaped)
+		}
+	}
+}
+
+// compressStoredManifests compresses, in the background, the manifests stored before manifests were compressed.
+func compressStoredManifests(resourceManager *resource.ResourceManager) {
+	batchSize := common.GetManifestCompressionBatchSize()
+	interval := common.GetManifestCompressionBatchInterval()
+	glog.Infof("Compressing stored manifests in batches of %v every %v", batchSize, interval)
+	compressed, err := resourceManager.CompressStoredManifests(batchSize, interval)
+	if err != nil {
+		glog.Errorf("Failed to compress stored manifests. Err: %v", err)
+	}
+	glog.Infof("Compressed the manifests of %v rows", compressed)
+}
+
 // A custom http request header matcher to pass on the user identity
 // Reference: https://github.com/grpc-ecosystem/grpc-gateway/blob/master/docs/_docs/customizingyourgateway.md#mapping-from-http-request-headers-to-grpc-client-metadata
 func grpcCustomMatcher(key string) (string, bool) {
 	if strings.EqualFold(key, common.GetKubeflowUserIDHeader()) {
 		return strings.ToLower(key), true
 	}
+	if strings.EqualFold(key, common.ImpersonateNamespaceHeader) {
+		return strings.ToLower(key), true
+	}
 	return strings.ToLower(key), false
 }
 
-func startRpcServer(resourceManager *resource.ResourceManager) {
+func startRpcServer(resourceManager *resource.ResourceManager, s *grpc.Server) {
 	glog.Info("Starting RPC server")
 	listener, err := net.Listen("tcp", *rpcPortFlag)
 	if err != nil {
 		glog.Fatalf("Failed to start RPC server: %v", err)
 	}
-	s := grpc.NewServer(grpc.UnaryInterceptor(apiServerInterceptor), grpc.MaxRecvMsgSize(math.MaxInt32))
 
 	sharedExperimentServer := server.NewExperimentServer(resourceManager, &server.ExperimentServerOptions{CollectMetrics: *collectMetricsFlag})
 	sharedJobServer := server.NewJobServer(resourceManager, &server.JobServerOptions{CollectMetrics: *collectMetricsFlag})
@@ -113,6 +223,7 @@ func startRpcServer(resourceManager *resource.ResourceManager) {
 			common.GetStringConfig(visualizationServicePort),
 		))
 	apiV1beta1.RegisterAuthServiceServer(s, server.NewAuthServer(resourceManager))
+	apiV1beta1.RegisterMaintenanceServiceServer(s, server.NewMaintenanceServer(resourceManager))
 
 	apiV2beta1.RegisterExperimentServiceServer(s, sharedExperimentServer)
 	apiV2beta1.RegisterRecurringRunServiceServer(s, sharedJobServer)
@@ -126,7 +237,7 @@ func startRpcServer(resourceManager *resource.ResourceManager) {
 	glog.Info("RPC server started")
 }
 
-func startHttpProxy(resourceManager *resource.ResourceManager) {
+func startHttpProxy(resourceManager *resource.ResourceManager, httpServer *http.Server) {
 	glog.Info("Starting Http Proxy")
 
 	ctx := context.Background()
@@ -143,6 +254,7 @@ func startHttpProxy(resourceManager *resource.ResourceManager) {
 	registerHttpHandlerFromEndpoint(apiV1beta1.RegisterReportServiceHandlerFromEndpoint, "ReportService", ctx, runtimeMux)
 	registerHttpHandlerFromEndpoint(apiV1beta1.RegisterVisualizationServiceHandlerFromEndpoint, "Visualization", ctx, runtimeMux)
 	registerHttpHandlerFromEndpoint(apiV1beta1.RegisterAuthServiceHandlerFromEndpoint, "AuthService", ctx, runtimeMux)
+	registerHttpHandlerFromEndpoint(apiV1beta1.RegisterMaintenanceServiceHandlerFromEndpoint, "MaintenanceService", ctx, runtimeMux)
 
 	// Create gRPC HTTP MUX and register services for v2beta1 api.
 	registerHttpHandlerFromEndpoint(apiV2beta1.RegisterExperimentServiceHandlerFromEndpoint, "ExperimentService", ctx, runtimeMux)
@@ -156,13 +268,19 @@ func startHttpProxy(resourceManager *resource.ResourceManager) {
 	// accept pipeline url for importing.
 	// https://github.com/grpc-ecosystem/grpc-gateway/issues/410
 	pipelineUploadServer := server.NewPipelineUploadServer(resourceManager, &server.PipelineUploadServerOptions{CollectMetrics: *collectMetricsFlag})
-	topMux.HandleFunc("/apis/v1beta1/pipelines/upload", pipelineUploadServer.UploadPipeline)
-	topMux.HandleFunc("/apis/v1beta1/pipelines/upload_version", pipelineUploadServer.UploadPipelineVersion)
+	topMux.HandleFunc("/apis/v1beta1/pipelines/upload", server.RejectInReadOnlyMode(pipelineUploadServer.UploadPipeline))
+	topMux.HandleFunc("/apis/v1beta1/pipelines/upload_version", server.RejectInReadOnlyMode(pipelineUploadServer.UploadPipelineVersion))
+	topMux.HandleFunc("/apis/v1beta1/pipelines/upload_versions", server.RejectInReadOnlyMode(pipelineUploadServer.UploadPipelineVersions))
+	topMux.HandleFunc("/apis/v1beta1/pipelines/validate", pipelineUploadServer.ValidatePipeline)
 	topMux.HandleFunc("/apis/v1beta1/healthz", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		io.WriteString(w, `{"commit_sha":"`+common.GetStringConfigWithDefault("COMMIT_SHA", "unknown")+`", "tag_name":"`+common.GetStringConfigWithDefault("TAG_NAME", "unknown")+`", "multi_user":`+strconv.FormatBool(common.IsMultiUserMode())+`}`)
 	})
 
+	healthServer := server.NewHealthServer(resourceManager)
+	topMux.HandleFunc("/apis/v1beta1/readyz", healthServer.Readiness)
+	topMux.HandleFunc("/apis/v1beta1/server_configuration", healthServer.ServerConfiguration)
+
 	// log streaming is provided via HTTP.
 	runLogServer := server.NewRunLogServer(resourceManager)
 	topMux.HandleFunc("/apis/v1alpha1/runs/{run_id}/nodes/{node_id}/log", runLogServer.ReadRunLogV1)
@@ -172,8 +290,11 @@ func startHttpProxy(resourceManager *resource.ResourceManager) {
 	// Register a handler for Prometheus to poll.
 	topMux.Handle("/metrics", promhttp.Handler())
 
-	http.ListenAndServe(*httpPortFlag, topMux)
-	glog.Info("Http Proxy started")
+	httpServer.Handler = topMux
+	if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		glog.Fatalf("Failed to serve the Http Proxy: %v", err)
+	}
+	glog.Info("Http Proxy stopped")
 }
 
 func registerHttpHandlerFromEndpoint(handler RegisterHttpHandlerFromEndpoint, serviceName string, ctx context.Context, mux *runtime.ServeMux) {
@@ -221,7 +342,7 @@ func loadSamples(resourceManager *resource.ResourceManager) error {
 		if configErr != nil {
 			return fmt.Errorf("Failed to decompress the file %s. Error: %v", config.Name, configErr)
 		}
-		_, configErr = resourceManager.CreatePipeline(config.Name, config.Description, "", pipelineFile)
+		_, configErr = resourceManager.CreatePipeline(config.Name, config.Description, "", nil, pipelineFile)
 		if configErr != nil {
 			// Log the error but not fail. The API Server pod can restart and it could potentially cause name collision.
 			// In the future, we might consider loading samples during deployment, instead of when API server starts.
@@ -17,3 +17,10 @@ package model
 type DefaultExperiment struct {
 	DefaultExperimentId string `gorm:"column:DefaultExperimentId; not null; primary_key"`
 }
+
+// NamespaceDefaultExperiment records the experiment that runs and jobs created in a namespace without an
+// experiment are grouped into. It is created the first time such a run or job is created.
+type NamespaceDefaultExperiment struct {
+	Namespace           string `gorm:"column:Namespace; not null; primary_key; size:63"`
+	DefaultExperimentId string `gorm:"column:DefaultExperimentId; not null; unique"`
+}
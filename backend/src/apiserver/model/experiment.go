@@ -1,14 +1,39 @@
 package model
 
 type Experiment struct {
-	UUID           string `gorm:"column:UUID; not null; primary_key"`
-	Name           string `gorm:"column:Name; not null; unique_index:idx_name_namespace"`
-	Description    string `gorm:"column:Description; not null"`
-	CreatedAtInSec int64  `gorm:"column:CreatedAtInSec; not null"`
-	Namespace      string `gorm:"column:Namespace; not null; unique_index:idx_name_namespace"`
-	StorageState   string `gorm:"column:StorageState; not null;"`
+	UUID                     string `gorm:"column:UUID; not null; primary_key"`
+	Name                     string `gorm:"column:Name; not null; unique_index:idx_name_namespace"`
+	Description              string `gorm:"column:Description; not null"`
+	CreatedAtInSec           int64  `gorm:"column:CreatedAtInSec; not null"`
+	Namespace                string `gorm:"column:Namespace; not null; unique_index:idx_name_namespace"`
+	StorageState             string `gorm:"column:StorageState; not null;"`
+	DeletedAtInSec           int64  `gorm:"column:DeletedAtInSec; default:0;"`
+	DefaultPipelineVersionId string `gorm:"column:DefaultPipelineVersionId; default:'';"`
+	DefaultParameters        string `gorm:"column:DefaultParameters; size:65535;"`
+	ResourceVersion          int64  `gorm:"column:ResourceVersion; not null; default:1;"`
+	LastRunCreatedAtInSec    int64  `gorm:"column:LastRunCreatedAtInSec; not null; default:0;"`
+	WorkflowNamePrefix       string `gorm:"column:WorkflowNamePrefix; default:'';"`
+	CompletionWebhook        string `gorm:"column:CompletionWebhook; default:''; size:2048;"`
+	// Labels classify the experiment, e.g. data-classification=pii. They are stored in the labels table.
+	Labels map[string]string `gorm:"-"`
 }
 // Note: Experiment.StorageState can have values: "STORAGESTATE_UNSPECIFIED", "AVAILABLE" or "ARCHIVED"
+// Note: Experiment.DeletedAtInSec is non zero when the experiment is soft deleted. Soft deleted experiments,
+// together with their runs and jobs, are hidden from list results until they are restored or reaped.
+// Note: Experiment.DefaultPipelineVersionId is the pipeline version that runs created in the experiment without
+// a pipeline use. It is empty if the experiment has no default version.
+// Note: Experiment.DefaultParameters is the serialized v1 parameters that v1 runs created in the experiment
+// get for the parameters they don't set. It is empty if the experiment has no presets.
+// Note: Experiment.ResourceVersion is bumped by every update of the experiment. Updates that pass the version
+// they read fail with a Conflict error if the experiment was updated since.
+// Note: Experiment.LastRunCreatedAtInSec is when the latest run of the experiment was created, or 0 if it has no
+// runs. It is kept when runs are deleted or the experiment is archived, and creating runs doesn't bump the
+// resource version.
+// Note: Experiment.WorkflowNamePrefix is prepended to the generated names of the workflows of runs created in the
+// experiment. It is empty if the workflows keep the names the pipeline gives them.
+// Note: Experiment.CompletionWebhook is the URL notified when a run of the experiment that doesn't set its own
+// webhook completes. It is empty if the experiment has no default webhook.
+// Note: Experiment.Labels are inherited by the workflows of runs created in the experiment, and by their pods.
 
 func (e Experiment) GetValueOfPrimaryKey() string {
 	return e.UUID
@@ -35,6 +60,8 @@ var experimentAPIToModelFieldMap = map[string]string{
 	"description":   "Description",
 	"namespace":     "Namespace",
 	"storage_state": "StorageState",
+	"deleted_at":    "DeletedAtInSec",
+	"last_run_at":   "LastRunCreatedAtInSec",
 }
 
 // APIToModelFieldMap returns a map from API names to field names for model
@@ -69,11 +96,26 @@ func (e *Experiment) GetFieldValue(name string) interface{} {
 		return e.Namespace
 	case "StorageState":
 		return e.StorageState
+	case "DeletedAtInSec":
+		return e.DeletedAtInSec
+	case "DefaultPipelineVersionId":
+		return e.DefaultPipelineVersionId
+	case "DefaultParameters":
+		return e.DefaultParameters
+	case "ResourceVersion":
+		return e.ResourceVersion
+	case "LastRunCreatedAtInSec":
+		return e.LastRunCreatedAtInSec
 	default:
 		return nil
 	}
 }
 
+// GetLabels returns the labels of the experiment. Experiments can be filtered by label.
+func (e *Experiment) GetLabels() map[string]string {
+	return e.Labels
+}
+
 func (e *Experiment) GetSortByFieldPrefix(name string) string {
 	return "experiments."
 }
@@ -0,0 +1,25 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// IdempotencyKey maps a caller supplied idempotency key to the run created for it.
+type IdempotencyKey struct {
+	// Namespace the key is scoped to. Empty in single-user mode.
+	Namespace      string `gorm:"column:Namespace; not null; primary_key; size:63"`
+	IdempotencyKey string `gorm:"column:IdempotencyKey; not null; primary_key; size:255"`
+	RunUUID        string `gorm:"column:RunUUID; not null"`
+	CreatedAtInSec int64  `gorm:"column:CreatedAtInSec; not null"`
+	ExpiresAtInSec int64  `gorm:"column:ExpiresAtInSec; not null; index"`
+}
@@ -32,8 +32,36 @@ type Job struct {
 	Trigger
 	PipelineSpec
 	Conditions string `gorm:"column:Conditions; not null"`
+	// ParameterResolution decides whether the runs of this job snapshot its
+	// parameters or inherit them when their workflows are submitted.
+	// Empty means ParameterResolutionSnapshot.
+	ParameterResolution ParameterResolution `gorm:"column:ParameterResolution; not null"`
+	// NextRunAtInSec is when the ScheduledWorkflow controller is expected to
+	// create the next run of the job, or 0 if it won't create any, in which
+	// case NextRunReason says why. They aren't stored, and are only set by the
+	// calls that compute them.
+	NextRunAtInSec int64  `gorm:"-"`
+	NextRunReason  string `gorm:"-"`
+	// ResourceVersion is bumped by every update of the job made through the
+	// API. The status reported by the persistence agent doesn't bump it.
+	ResourceVersion int64 `gorm:"column:ResourceVersion; not null; default:1;"`
 }
 
+// ParameterResolution specifies how the runs of a job obtain their parameters.
+type ParameterResolution string
+
+const (
+	// ParameterResolutionSnapshot copies the job's parameters into the
+	// ScheduledWorkflow when the job is created, and they cannot change
+	// afterwards. This is the default.
+	ParameterResolutionSnapshot ParameterResolution = "Snapshot"
+	// ParameterResolutionInherit makes every run resolve the job's current
+	// parameters when the ScheduledWorkflow controller submits its workflow.
+	// Workflows that were already submitted keep the values they resolved, so
+	// deleting the job falls back to that last snapshot.
+	ParameterResolutionInherit ParameterResolution = "Inherit"
+)
+
 // Trigger specifies when to create a new workflow.
 type Trigger struct {
 	// Create workflows according to a cron schedule.
@@ -70,6 +98,12 @@ type PeriodicSchedule struct {
 	IntervalSecond *int64 `gorm:"column:IntervalSecond;"`
 }
 
+// CatchUp reports whether the job backfills the runs it missed, one for every tick of its schedule that
+// passed while it was disabled or couldn't run. It is stored inverted, as NoCatchup.
+func (j *Job) CatchUp() bool {
+	return !j.NoCatchup
+}
+
 func (j Job) GetValueOfPrimaryKey() string {
 	return fmt.Sprint(j.UUID)
 }
@@ -96,9 +130,23 @@ var jobAPIToModelFieldMap = map[string]string{
 	"description": "Description",
 }
 
+// jobFilterFieldMap holds the fields of jobs that can be filtered on, which are those that can be sorted by
+// along with the columns of the enabled state and the trigger. The list package rewrites the "enabled" and
+// "schedule_type" predicates into predicates on these columns.
+var jobFilterFieldMap = map[string]string{
+	"id":                                "UUID",
+	"name":                              "DisplayName",
+	"created_at":                        "CreatedAtInSec",
+	"updated_at":                        "UpdatedAtInSec",
+	"description":                       "Description",
+	"enabled":                           "Enabled",
+	"cron_schedule.cron":                "Schedule",
+	"periodic_schedule.interval_second": "IntervalSecond",
+}
+
 // APIToModelFieldMap returns a map from API names to field names for model Job.
 func (k *Job) APIToModelFieldMap() map[string]string {
-	return jobAPIToModelFieldMap
+	return jobFilterFieldMap
 }
 
 // GetModelName returns table name used as sort field prefix
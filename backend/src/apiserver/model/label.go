@@ -0,0 +1,27 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// Label is a key/value pair attached to a resource to categorize it, e.g. team=ml.
+type Label struct {
+	// ID of the labeled resource.
+	ResourceUUID string `gorm:"column:ResourceUUID; not null; primary_key; size:64"`
+
+	// The type of the labeled resource.
+	ResourceType ResourceType `gorm:"column:ResourceType; not null; primary_key; size:64; index:labelfilter"`
+
+	LabelKey   string `gorm:"column:LabelKey; not null; primary_key; size:317; index:labelfilter"`
+	LabelValue string `gorm:"column:LabelValue; not null; size:63; index:labelfilter"`
+}
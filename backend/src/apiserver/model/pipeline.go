@@ -42,6 +42,13 @@ type Pipeline struct {
 	DefaultVersionId string           `gorm:"column:DefaultVersionId;"`
 	DefaultVersion   *PipelineVersion `gorm:"-"`
 	Namespace        string           `gorm:"column:Namespace; size:63; default:''"`
+	// Labels categorize the pipeline, e.g. team=ml. They are stored in the labels table.
+	Labels map[string]string `gorm:"-"`
+	// ResourceVersion is bumped by every update of the pipeline, not counting its versions.
+	ResourceVersion int64 `gorm:"column:ResourceVersion; not null; default:1;"`
+	// VersionCount is the number of ready versions of the pipeline. It is counted when the pipeline is read
+	// rather than stored, so it can't go stale as versions are created and deleted.
+	VersionCount int64 `gorm:"-"`
 }
 
 func (p Pipeline) GetValueOfPrimaryKey() string {
@@ -72,10 +79,22 @@ var pipelineAPIToModelFieldMap = map[string]string{
 	// "default_version_id": "DefaultVersionId",
 }
 
+// pipelineFilterFieldMap holds the fields of pipelines that can be filtered on, which are those that can be
+// sorted by along with the number of ready versions. The list package rewrites the "has_versions" predicates
+// into predicates on that number.
+var pipelineFilterFieldMap = map[string]string{
+	"id":            "UUID",
+	"name":          "Name",
+	"created_at":    "CreatedAtInSec",
+	"description":   "Description",
+	"namespace":     "Namespace",
+	"version_count": "VersionCount",
+}
+
 // APIToModelFieldMap returns a map from API names to field names for model
 // Pipeline.
 func (p *Pipeline) APIToModelFieldMap() map[string]string {
-	return pipelineAPIToModelFieldMap
+	return pipelineFilterFieldMap
 }
 
 // GetModelName returns table name used as sort field prefix
@@ -111,6 +130,11 @@ func (p *Pipeline) GetSortByFieldPrefix(name string) string {
 	return "pipelines."
 }
 
+// GetLabels returns the labels of the pipeline. Pipelines can be filtered by label.
+func (p *Pipeline) GetLabels() map[string]string {
+	return p.Labels
+}
+
 func (p *Pipeline) GetKeyFieldPrefix() string {
 	return "pipelines."
 }
@@ -28,6 +28,17 @@ const (
 	PipelineVersionDeleting PipelineVersionStatus = "DELETING"
 )
 
+// PipelineVersionPackageFormatArchive is the package format of a pipeline version whose manifest was
+// assembled from an uploaded zip or tarball. Versions uploaded as a single file have no package format.
+const PipelineVersionPackageFormatArchive = "archive"
+
+// Sorting by the "semver" key orders pipeline versions by the semantic versions in their names. It isn't a
+// column, so the pipeline store sorts the versions itself when it is used.
+const (
+	PipelineVersionSemVerSortKey   = "semver"
+	PipelineVersionSemVerSortField = "SemVer"
+)
+
 type PipelineVersion struct {
 	UUID           string `gorm:"column:UUID; not null; primary_key"`
 	CreatedAtInSec int64  `gorm:"column:CreatedAtInSec; not null; index"`
@@ -35,6 +46,9 @@ type PipelineVersion struct {
 	// Set size to 65535 so it will be stored as longtext.
 	// https://dev.mysql.com/doc/refman/8.0/en/column-count-limit.html
 	Parameters string `gorm:"column:Parameters; not null; size:65535"`
+	// Declared type of each input parameter in JSON, e.g. {"epochs":"int"}. Empty if the pipeline
+	// doesn't declare parameter types.
+	ParameterSchema string `gorm:"column:ParameterSchema; size:65535"`
 	// PipelineVersion belongs to Pipeline. If a pipeline with a specific UUID
 	// is deleted from Pipeline table, all this pipeline's versions will be
 	// deleted from PipelineVersion table.
@@ -42,7 +56,13 @@ type PipelineVersion struct {
 	Status     PipelineVersionStatus `gorm:"column:Status; not null"`
 	// Code source url links to the pipeline version's definition in repo.
 	CodeSourceUrl string `gorm:"column:CodeSourceUrl;"`
+	// Package url is the url the pipeline version's manifest was downloaded from, if any.
+	PackageUrl string `gorm:"column:PackageUrl;"`
+	// Package format tells how the pipeline version's manifest was uploaded, e.g. archive.
+	PackageFormat string `gorm:"column:PackageFormat;"`
 	Description   string `gorm:"column:Description; not null; size:65535"` // Set size to large number so it will be stored as longtext
+	// Time the description was last changed, 0 if it never was. The rest of a version can't change.
+	UpdatedAtInSec int64 `gorm:"column:UpdatedAtInSec; default:0;"`
 }
 
 func (p PipelineVersion) GetValueOfPrimaryKey() string {
@@ -67,6 +87,7 @@ func (p *PipelineVersion) APIToModelFieldMap() map[string]string {
 		"name":       "Name",
 		"created_at": "CreatedAtInSec",
 		"status":     "Status",
+		"updated_at": "UpdatedAtInSec",
 	}
 }
 
@@ -79,6 +100,9 @@ func (p *PipelineVersion) GetField(name string) (string, bool) {
 	if field, ok := p.APIToModelFieldMap()[name]; ok {
 		return field, true
 	}
+	if name == PipelineVersionSemVerSortKey {
+		return PipelineVersionSemVerSortField, true
+	}
 	return "", false
 }
 
@@ -86,12 +110,14 @@ func (p *PipelineVersion) GetFieldValue(name string) interface{} {
 	switch name {
 	case "UUID":
 		return p.UUID
-	case "Name":
+	case "Name", PipelineVersionSemVerSortField:
 		return p.Name
 	case "CreatedAtInSec":
 		return p.CreatedAtInSec
 	case "Status":
 		return p.Status
+	case "UpdatedAtInSec":
+		return p.UpdatedAtInSec
 	default:
 		return nil
 	}
@@ -20,6 +20,7 @@ import (
 
 const (
 	RunTerminatingConditions string = "Terminating"
+	RunTerminatedConditions  string = "Terminated"
 )
 
 type Run struct {
@@ -34,18 +35,136 @@ type Run struct {
 	CreatedAtInSec     int64  `gorm:"column:CreatedAtInSec; not null;"`
 	ScheduledAtInSec   int64  `gorm:"column:ScheduledAtInSec; default:0;"`
 	FinishedAtInSec    int64  `gorm:"column:FinishedAtInSec; default:0;"`
+	StartedAtInSec     int64  `gorm:"column:StartedAtInSec; default:0;"`
+	PodStartedAtInSec  int64  `gorm:"column:PodStartedAtInSec; default:0;"`
 	Conditions         string `gorm:"column:Conditions; not null"`
+	State              string `gorm:"column:State; not null;"`       /* Upper-cased workflow phase derived from Conditions, e.g. RUNNING. */
+	WorkflowUID        string `gorm:"column:WorkflowUID; not null;"` /* metadata.uid of the run's workflow. Kept after the workflow is garbage collected. */
+	TTLSeconds         *int64 `gorm:"column:TTLSeconds;"`            /* Seconds the workflow is kept after it finishes. Nil if the workflow has no TTL. */
+	ResourceOverrides  string `gorm:"column:ResourceOverrides; size:65535"`
+	PodMetadata        string `gorm:"column:PodMetadata; size:65535"`   /* JSON of the labels and annotations the run set on its pods. */
+	Scheduling         string `gorm:"column:Scheduling; size:65535"`    /* JSON of the node selector and tolerations of the run's workflow, if the run added any. */
+	Env                string `gorm:"column:Env; size:65535"`           /* JSON of the environment variables the run added to its containers. */
+	RetryStrategy      string `gorm:"column:RetryStrategy; size:65535"` /* JSON of the retry strategy the run set on its templates, if any. */
+	TerminatedBy       string `gorm:"column:TerminatedBy; default:'';"`
+	CreatedBy          string `gorm:"column:CreatedBy; not null; default:''; size:255; index;"` /* Identity of the user that created the run, if the request was authenticated. */
+	PriorityClassName  string `gorm:"column:PriorityClassName; default:''; size:255;"`          /* Priority class of the pods of the run's workflow, if any. */
+	CompletionWebhook  string `gorm:"column:CompletionWebhook; default:''; size:2048;"`         /* URL POSTed to when the run reaches a terminal state. Empty for the experiment default, if any. */
 	Metrics            []*RunMetric
 	ResourceReferences []*ResourceReference
 	PipelineSpec
+	// Labels of the run's workflow, e.g. model-name=resnet. They are stored in the labels table.
+	Labels map[string]string `gorm:"-"`
+
+	// Outcome of the last delivery of the completion webhook, DELIVERED or FAILED, why it failed, and when it was
+	// attempted. Empty until a webhook of the run is delivered.
+	CompletionWebhookStatus           string `gorm:"column:CompletionWebhookStatus; default:''; size:32;"`
+	CompletionWebhookMessage          string `gorm:"column:CompletionWebhookMessage; default:''; size:1024;"`
+	CompletionWebhookAttemptedAtInSec int64  `gorm:"column:CompletionWebhookAttemptedAtInSec; default:0;"`
+}
+
+// Run states that can be used to filter runs. They mirror the phases of the underlying workflow.
+const (
+	RunStatePending     string = "PENDING"
+	RunStateRunning     string = "RUNNING"
+	RunStateSucceeded   string = "SUCCEEDED"
+	RunStateFailed      string = "FAILED"
+	RunStateError       string = "ERROR"
+	RunStateTerminating string = "TERMINATING"
+	RunStateTerminated  string = "TERMINATED"
+)
+
+// Outcomes of the delivery of the completion webhook of a run.
+const (
+	CompletionWebhookPending   string = "PENDING"
+	CompletionWebhookDelivered string = "DELIVERED"
+	CompletionWebhookFailed    string = "FAILED"
+)
+
+// ActiveRunStates are the run states that haven't reached a terminal state yet.
+var ActiveRunStates = []string{RunStatePending, RunStateRunning, RunStateTerminating}
+
+// IsActiveRunState returns whether the run state is one of ActiveRunStates.
+func IsActiveRunState(state string) bool {
+	for _, active := range ActiveRunStates {
+		if state == active {
+			return true
+		}
+	}
+	return false
+}
+
+// RunStateFromConditions maps the workflow phase stored in Conditions to the run state.
+// A workflow that hasn't been picked up by the controller yet has no phase and is pending.
+func RunStateFromConditions(conditions string) string {
+	if conditions == "" {
+		return RunStatePending
+	}
+	return strings.ToUpper(conditions)
+}
+
+// QueueWaitSeconds returns how long the run waited from its creation until its first pod started. If no
+// pod has started, the wait lasts until the run finished, or until now for an active run. Negative
+// waits caused by clock skew are reported as zero.
+func (r *Run) QueueWaitSeconds(nowInSec int64) int64 {
+	end := r.PodStartedAtInSec
+	if end == 0 {
+		end = r.FinishedAtInSec
+	}
+	if end == 0 {
+		end = nowInSec
+	}
+	return nonNegativeSeconds(end - r.CreatedAtInSec)
+}
+
+// DurationSeconds returns how long the run's workflow executed, or has been executing until now for an
+// active run. A run whose workflow hasn't started has no duration. Negative durations caused by clock
+// skew are reported as zero.
+func (r *Run) DurationSeconds(nowInSec int64) int64 {
+	if r.StartedAtInSec == 0 {
+		return 0
+	}
+	end := r.FinishedAtInSec
+	if end == 0 {
+		end = nowInSec
+	}
+	return nonNegativeSeconds(end - r.StartedAtInSec)
+}
+
+// StatusSource returns where the status of the run is read from: the snapshot once the TTL of its finished
+// workflow has passed and the workflow was garbage collected, the workflow itself otherwise.
+func (r *Run) StatusSource(nowInSec int64) string {
+	if r.FinishedAtInSec != 0 && r.TTLSeconds != nil && nowInSec >= r.FinishedAtInSec+*r.TTLSeconds {
+		return RunStatusSourceArchived
+	}
+	return RunStatusSourceLive
+}
+
+func nonNegativeSeconds(seconds int64) int64 {
+	if seconds < 0 {
+		return 0
+	}
+	return seconds
 }
 
 type PipelineRuntime struct {
 	PipelineRuntimeManifest string `gorm:"column:PipelineRuntimeManifest; not null; size:65535"`
 	/* Argo CRD. Set size to 65535 so it will be stored as longtext. https://dev.mysql.com/doc/refman/8.0/en/column-count-limit.html */
 	WorkflowRuntimeManifest string `gorm:"column:WorkflowRuntimeManifest; not null; size:65535"`
+	/* Gzip compressed workflow status captured when the run finished. It can be megabytes for large DAGs, so it
+	isn't loaded with the run. See RunStore.GetRunStatusSnapshot. */
+	StatusSnapshot []byte `gorm:"column:StatusSnapshot; size:16777215"`
 }
 
+// Sources that the status of a run is read from.
+const (
+	// RunStatusSourceLive is the workflow the run is executed by.
+	RunStatusSourceLive string = "live"
+	// RunStatusSourceArchived is the snapshot of the status that was persisted before the workflow was
+	// garbage collected.
+	RunStatusSourceArchived string = "archived"
+)
+
 type RunDetail struct {
 	Run
 	PipelineRuntime
@@ -58,6 +177,8 @@ type RunMetric struct {
 	NumberValue float64 `gorm:"column:NumberValue"`
 	Format      string  `gorm:"column:Format"`
 	Payload     string  `gorm:"column:Payload; not null; size:65535"`
+	// ReportedAtInSec is when the metric was last reported. It isn't part of the payload.
+	ReportedAtInSec int64 `gorm:"column:ReportedAtInSec; not null; default:0;" json:"-"`
 }
 
 func (r Run) GetValueOfPrimaryKey() string {
@@ -86,7 +207,9 @@ var runAPIToModelFieldMap = map[string]string{
 	"scheduled_at":  "ScheduledAtInSec",
 	"storage_state": "StorageState",
 	"status":        "Conditions",
+	"state":         "State",
 	"finished_at":   "FinishedAtInSec",
+	"created_by":    "CreatedBy",
 }
 
 // APIToModelFieldMap returns a map from API names to field names for model Run.
@@ -130,6 +253,10 @@ func (r *Run) GetFieldValue(name string) interface{} {
 		return r.StorageState
 	case "Conditions":
 		return r.Conditions
+	case "State":
+		return r.State
+	case "CreatedBy":
+		return r.CreatedBy
 	}
 	// Second, try to find the match of "name" inside an array typed field
 	for _, metric := range r.Metrics {
@@ -160,6 +287,16 @@ func (r *Run) GetSortByFieldPrefix(name string) string {
 	}
 }
 
+// GetLabels returns the labels of the run. Runs can be filtered by label.
+func (r *Run) GetLabels() map[string]string {
+	return r.Labels
+}
+
+// GetMetrics returns the metrics of the run. Runs can be filtered by metric.
+func (r *Run) GetMetrics() []*RunMetric {
+	return r.Metrics
+}
+
 func (r *Run) GetKeyFieldPrefix() string {
 	return r.GetModelName()
 }
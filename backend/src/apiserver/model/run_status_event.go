@@ -0,0 +1,24 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// RunStatusEvent records that a run changed to a state, e.g. RUNNING, at some time.
+type RunStatusEvent struct {
+	RunUUID string `gorm:"column:RunUUID; not null; primary_key; size:64"`
+	// Sequence orders the events of a run. It starts at 1 and keeps growing when old events are pruned.
+	Sequence       int64  `gorm:"column:Sequence; not null; primary_key; AUTO_INCREMENT:false"`
+	State          string `gorm:"column:State; not null"`
+	CreatedAtInSec int64  `gorm:"column:CreatedAtInSec; not null"`
+}
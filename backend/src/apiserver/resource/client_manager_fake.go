@@ -39,6 +39,7 @@ type FakeClientManager struct {
 	resourceReferenceStore        storage.ResourceReferenceStoreInterface
 	dBStatusStore                 storage.DBStatusStoreInterface
 	defaultExperimentStore        storage.DefaultExperimentStoreInterface
+	idempotencyKeyStore           storage.IdempotencyKeyStoreInterface
 	objectStore                   storage.ObjectStoreInterface
 	ExecClientFake                *client.FakeExecClient
 	swfClientFake                 *client.FakeSwfClient
@@ -80,6 +81,7 @@ func NewFakeClientManager(time util.TimeInterface, uuid util.UUIDGeneratorInterf
 		resourceReferenceStore:        storage.NewResourceReferenceStore(db),
 		dBStatusStore:                 storage.NewDBStatusStore(db),
 		defaultExperimentStore:        storage.NewDefaultExperimentStore(db),
+		idempotencyKeyStore:           storage.NewIdempotencyKeyStore(db, time),
 		objectStore:                   storage.NewFakeObjectStore(),
 		swfClientFake:                 client.NewFakeSwfClient(),
 		k8sCoreClientFake:             client.NewFakeKuberneteCoresClient(),
@@ -167,6 +169,10 @@ func (f *FakeClientManager) DBStatusStore() storage.DBStatusStoreInterface {
 	return f.dBStatusStore
 }
 
+func (f *FakeClientManager) IdempotencyKeyStore() storage.IdempotencyKeyStoreInterface {
+	return f.idempotencyKeyStore
+}
+
 func (f *FakeClientManager) DefaultExperimentStore() storage.DefaultExperimentStoreInterface {
 	return f.defaultExperimentStore
 }
@@ -0,0 +1,270 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+// maxCompletionWebhookLength is the size of the CompletionWebhook columns of runs and experiments.
+const maxCompletionWebhookLength = 2048
+
+// completionWebhookPayload is the JSON body POSTed to the completion webhook of a run. Times are RFC 3339 and
+// omitted when the run doesn't have them.
+type completionWebhookPayload struct {
+	RunId         string `json:"run_id"`
+	DisplayName   string `json:"display_name"`
+	Namespace     string `json:"namespace,omitempty"`
+	ExperimentId  string `json:"experiment_id,omitempty"`
+	State         string `json:"state"`
+	CreatedAt     string `json:"created_at,omitempty"`
+	StartedAt     string `json:"started_at,omitempty"`
+	FinishedAt    string `json:"finished_at,omitempty"`
+	DurationInSec int64  `json:"duration_in_sec,omitempty"`
+}
+
+// validateCompletionWebhook checks that a completion webhook is an HTTP(S) URL. In multi-user mode, its host
+// must also be one of the allowed hosts, so that users can't make the API server send requests to services of
+// the cluster. An empty webhook is valid.
+func validateCompletionWebhook(webhook string) error {
+	if webhook == "" {
+		return nil
+	}
+	if len(webhook) > maxCompletionWebhookLength {
+		return util.NewInvalidInputError("Invalid completion webhook: must be no more than %v characters", maxCompletionWebhookLength)
+	}
+	parsedURL, err := url.ParseRequestURI(webhook)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return util.NewInvalidInputError("Invalid completion webhook %v. Please specify a valid HTTP(S) URL", webhook)
+	}
+	if parsedURL.User != nil {
+		return util.NewInvalidInputError("Invalid completion webhook %v: the URL can't have credentials", webhook)
+	}
+	if common.IsMultiUserMode() && !isCompletionWebhookHostAllowed(parsedURL) {
+		return util.NewPermissionDeniedError(errors.Errorf("host %q is not allowed", parsedURL.Host),
+			"Completion webhooks can't be sent to host %v", parsedURL.Host)
+	}
+	return nil
+}
+
+// isCompletionWebhookHostAllowed returns whether the host of a webhook, with or without its port, is one of the
+// allowed hosts.
+func isCompletionWebhookHostAllowed(webhook *url.URL) bool {
+	for _, host := range common.GetCompletionWebhookAllowedHosts() {
+		if host == webhook.Host || host == webhook.Hostname() {
+			return true
+		}
+	}
+	return false
+}
+
+// notifyRunCompletion delivers the completion webhook of a completed run, if it has one. The webhook is
+// resolved and the delivery recorded as pending right away, then it's delivered in the background, so that slow
+// or failing webhooks don't hold up the reports of the persistence agent. Pending deliveries interrupted by a
+// restart are resumed by ResumeCompletionWebhooks.
+func (r *ResourceManager) notifyRunCompletion(runId string) {
+	run, err := r.runStore.GetRun(runId)
+	if err != nil {
+		glog.Errorf("Failed to get run %v to deliver its completion webhook: %v", runId, err)
+		return
+	}
+	webhook, err := r.getRunCompletionWebhook(run)
+	if err != nil {
+		glog.Errorf("Failed to get the completion webhook of run %v: %v", runId, err)
+		return
+	}
+	if webhook == "" {
+		return
+	}
+	if err := r.runStore.UpdateRunCompletionWebhookStatus(runId, model.CompletionWebhookPending, "", r.time.Now().Unix()); err != nil {
+		glog.Errorf("Failed to record the completion webhook of run %v as pending: %v", runId, err)
+	}
+	r.deliverCompletionWebhookInBackground(run, webhook)
+}
+
+// ResumeCompletionWebhooks delivers, in the background, the completion webhooks that were still pending when the
+// API server last stopped.
+func (r *ResourceManager) ResumeCompletionWebhooks() error {
+	runIds, err := r.runStore.ListRunsWithCompletionWebhookStatus(model.CompletionWebhookPending)
+	if err != nil {
+		return util.Wrap(err, "Failed to list the runs with a pending completion webhook")
+	}
+	for _, runId := range runIds {
+		run, err := r.runStore.GetRun(runId)
+		if err != nil {
+			glog.Errorf("Failed to get run %v to deliver its completion webhook: %v", runId, err)
+			continue
+		}
+		webhook, err := r.getRunCompletionWebhook(run)
+		if err != nil {
+			glog.Errorf("Failed to get the completion webhook of run %v: %v", runId, err)
+			continue
+		}
+		if webhook == "" {
+			continue
+		}
+		r.deliverCompletionWebhookInBackground(run, webhook)
+	}
+	if len(runIds) > 0 {
+		glog.Infof("Resumed the delivery of %v pending completion webhooks", len(runIds))
+	}
+	return nil
+}
+
+// WaitForCompletionWebhooks waits up to the timeout for the completion webhooks being delivered. It returns
+// whether they were all delivered in time. Those that weren't stay pending and are resumed on the next start.
+func (r *ResourceManager) WaitForCompletionWebhooks(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		r.completionWebhooks.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// getRunCompletionWebhook returns the completion webhook of a run, or the default webhook of its experiment if
+// the run doesn't set one. It returns "" if there is neither.
+func (r *ResourceManager) getRunCompletionWebhook(run *model.RunDetail) (string, error) {
+	if run.CompletionWebhook != "" || run.ExperimentUUID == "" {
+		return run.CompletionWebhook, nil
+	}
+	// Runs can complete after their experiment was soft deleted.
+	experiment, err := r.experimentStore.GetExperimentIncludingDeleted(run.ExperimentUUID)
+	if err != nil {
+		return "", err
+	}
+	return experiment.CompletionWebhook, nil
+}
+
+func (r *ResourceManager) deliverCompletionWebhookInBackground(run *model.RunDetail, webhook string) {
+	r.completionWebhooks.Add(1)
+	go func() {
+		defer r.completionWebhooks.Done()
+		r.deliverCompletionWebhook(run, webhook)
+	}()
+}
+
+// deliverCompletionWebhook POSTs a completed run to a webhook and records whether the webhook was delivered.
+func (r *ResourceManager) deliverCompletionWebhook(run *model.RunDetail, webhook string) {
+	status, message := model.CompletionWebhookDelivered, ""
+	if err := postCompletionWebhook(webhook, run); err != nil {
+		glog.Warningf("Failed to deliver the completion webhook of run %v: %v", run.UUID, err)
+		status, message = model.CompletionWebhookFailed, err.Error()
+	}
+	if err := r.runStore.UpdateRunCompletionWebhookStatus(run.UUID, status, message, r.time.Now().Unix()); err != nil {
+		glog.Errorf("Failed to record the completion webhook status of run %v: %v", run.UUID, err)
+	}
+}
+
+// postCompletionWebhook POSTs a run to a webhook, retrying with an exponential backoff while the webhook can't
+// be reached, is throttling or fails. Redirects aren't followed.
+func postCompletionWebhook(webhook string, run *model.RunDetail) error {
+	// The allowed hosts may have changed since the webhook was set.
+	if err := validateCompletionWebhook(webhook); err != nil {
+		return err
+	}
+	body, err := json.Marshal(newCompletionWebhookPayload(run))
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the run")
+	}
+	httpClient := &http.Client{
+		Timeout: common.GetCompletionWebhookTimeout(),
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = common.GetCompletionWebhookRetryInitialInterval()
+	b.MaxInterval = common.GetCompletionWebhookRetryMaxInterval()
+	b.MaxElapsedTime = 0
+	b.Reset()
+
+	maxAttempts := common.GetCompletionWebhookMaxAttempts()
+	for attempt := 1; ; attempt++ {
+		retryable, err := sendCompletionWebhook(httpClient, webhook, body)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt >= maxAttempts {
+			return errors.Wrapf(err, "failed after %d attempts", attempt)
+		}
+		time.Sleep(b.NextBackOff())
+	}
+}
+
+// sendCompletionWebhook makes one delivery attempt of a webhook, returning whether a failure is worth retrying.
+func sendCompletionWebhook(httpClient *http.Client, webhook string, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+	return retryable, errors.Errorf("the webhook responded with %v", resp.Status)
+}
+
+func newCompletionWebhookPayload(run *model.RunDetail) *completionWebhookPayload {
+	formatTime := func(sec int64) string {
+		if sec <= 0 {
+			return ""
+		}
+		return time.Unix(sec, 0).UTC().Format(time.RFC3339)
+	}
+	state := run.State
+	if state == "" {
+		state = model.RunStateFromConditions(run.Conditions)
+	}
+	payload := &completionWebhookPayload{
+		RunId:        run.UUID,
+		DisplayName:  run.DisplayName,
+		Namespace:    run.Namespace,
+		ExperimentId: run.ExperimentUUID,
+		State:        state,
+		CreatedAt:    formatTime(run.CreatedAtInSec),
+		StartedAt:    formatTime(run.StartedAtInSec),
+		FinishedAt:   formatTime(run.FinishedAtInSec),
+	}
+	if run.StartedAtInSec > 0 && run.FinishedAtInSec >= run.StartedAtInSec {
+		payload.DurationInSec = run.FinishedAtInSec - run.StartedAtInSec
+	}
+	return payload
+}
@@ -0,0 +1,147 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	k8errors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+)
+
+// executionRetryPolicy decides how often, and how far apart, retryable execution client calls are attempted.
+type executionRetryPolicy struct {
+	maxAttempts     int
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	multiplier      float64
+}
+
+func executionRetryPolicyFromConfig() executionRetryPolicy {
+	return executionRetryPolicy{
+		maxAttempts:     common.GetArgoClientRetryMaxAttempts(),
+		initialInterval: common.GetArgoClientRetryInitialInterval(),
+		maxInterval:     common.GetArgoClientRetryMaxInterval(),
+		multiplier:      common.GetArgoClientRetryMultiplier(),
+	}
+}
+
+// retry calls operation until it succeeds, fails with an error that isn't retryable, or runs out of attempts.
+// An error returned after the attempts ran out carries the number of attempts.
+func (p executionRetryPolicy) retry(ctx context.Context, name string, operation func() error) error {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = p.initialInterval
+	b.MaxInterval = p.maxInterval
+	b.Multiplier = p.multiplier
+	b.MaxElapsedTime = 0
+	b.Reset()
+
+	for attempt := 1; ; attempt++ {
+		err := operation()
+		if err == nil || !isRetryableExecutionError(err) {
+			return err
+		}
+		if attempt >= p.maxAttempts {
+			return util.Wrapf(err, "Failed to %v after %d attempts", name, attempt)
+		}
+		wait := b.NextBackOff()
+		glog.Warningf("Attempt %d to %v failed, retrying in %v: %v", attempt, name, wait, err)
+		select {
+		case <-ctx.Done():
+			return util.Wrapf(err, "Failed to %v after %d attempts", name, attempt)
+		case <-time.After(wait):
+		}
+	}
+}
+
+// isRetryableExecutionError returns whether the error is transient: the API server was throttling, failing
+// or unavailable, or the connection was reset. Other errors, such as invalid or forbidden requests, are final.
+func isRetryableExecutionError(err error) bool {
+	if utilnet.IsConnectionReset(err) {
+		return true
+	}
+	status, ok := err.(k8errors.APIStatus)
+	if !ok {
+		return false
+	}
+	code := status.Status().Code
+	return code == http.StatusTooManyRequests || (code >= http.StatusInternalServerError && code != http.StatusNotImplemented)
+}
+
+// retryingExecutionInterface retries the idempotent calls of an execution client on transient errors. Creating
+// an execution is only retried when its name is set, since a generated name could create it twice.
+type retryingExecutionInterface struct {
+	util.ExecutionInterface
+	policy executionRetryPolicy
+}
+
+func newRetryingExecutionInterface(execution util.ExecutionInterface, policy executionRetryPolicy) util.ExecutionInterface {
+	return &retryingExecutionInterface{ExecutionInterface: execution, policy: policy}
+}
+
+func (r *retryingExecutionInterface) Create(ctx context.Context, execution util.ExecutionSpec, opts v1.CreateOptions) (util.ExecutionSpec, error) {
+	if execution.ExecutionName() == "" {
+		return r.ExecutionInterface.Create(ctx, execution, opts)
+	}
+	var created util.ExecutionSpec
+	retried := false
+	err := r.policy.retry(ctx, "create execution "+execution.ExecutionName(), func() error {
+		var err error
+		created, err = r.ExecutionInterface.Create(ctx, execution, opts)
+		if k8errors.IsAlreadyExists(err) && retried {
+			// An earlier attempt created the execution, even though it reported an error.
+			created, err = r.ExecutionInterface.Get(ctx, execution.ExecutionName(), v1.GetOptions{})
+		}
+		retried = true
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (r *retryingExecutionInterface) Get(ctx context.Context, name string, opts v1.GetOptions) (util.ExecutionSpec, error) {
+	var execution util.ExecutionSpec
+	err := r.policy.retry(ctx, "get execution "+name, func() error {
+		var err error
+		execution, err = r.ExecutionInterface.Get(ctx, name, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return execution, nil
+}
+
+func (r *retryingExecutionInterface) List(ctx context.Context, opts v1.ListOptions) (*util.ExecutionSpecList, error) {
+	var executions *util.ExecutionSpecList
+	err := r.policy.retry(ctx, "list executions", func() error {
+		var err error
+		executions, err = r.ExecutionInterface.List(ctx, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return executions, nil
+}
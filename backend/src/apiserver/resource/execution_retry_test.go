@@ -0,0 +1,152 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	k8errors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var testRetryPolicy = executionRetryPolicy{
+	maxAttempts:     3,
+	initialInterval: time.Millisecond,
+	maxInterval:     time.Millisecond,
+	multiplier:      2,
+}
+
+var workflowsResource = schema.GroupResource{Group: "argoproj.io", Resource: "workflows"}
+
+// flakyExecutionInterface fails the first calls with the given errors.
+type flakyExecutionInterface struct {
+	util.ExecutionInterface
+	errs  []error
+	calls int
+}
+
+func (f *flakyExecutionInterface) nextError() error {
+	f.calls++
+	if f.calls <= len(f.errs) {
+		return f.errs[f.calls-1]
+	}
+	return nil
+}
+
+func (f *flakyExecutionInterface) Create(ctx context.Context, execution util.ExecutionSpec, opts v1.CreateOptions) (util.ExecutionSpec, error) {
+	if err := f.nextError(); err != nil {
+		return nil, err
+	}
+	return f.ExecutionInterface.Create(ctx, execution, opts)
+}
+
+func (f *flakyExecutionInterface) Get(ctx context.Context, name string, opts v1.GetOptions) (util.ExecutionSpec, error) {
+	if err := f.nextError(); err != nil {
+		return nil, err
+	}
+	return f.ExecutionInterface.Get(ctx, name, opts)
+}
+
+func TestIsRetryableExecutionError(t *testing.T) {
+	assert.True(t, isRetryableExecutionError(k8errors.NewTooManyRequests("throttled", 1)))
+	assert.True(t, isRetryableExecutionError(k8errors.NewInternalError(errors.New("boom"))))
+	assert.True(t, isRetryableExecutionError(k8errors.NewServiceUnavailable("unavailable")))
+	assert.True(t, isRetryableExecutionError(syscall.ECONNRESET))
+	assert.False(t, isRetryableExecutionError(k8errors.NewBadRequest("bad")))
+	assert.False(t, isRetryableExecutionError(k8errors.NewForbidden(workflowsResource, "wf", errors.New("denied"))))
+	assert.False(t, isRetryableExecutionError(k8errors.NewNotFound(workflowsResource, "wf")))
+	assert.False(t, isRetryableExecutionError(errors.New("unknown")))
+}
+
+func TestRetryingExecutionInterface_GetRetriesTransientErrors(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	workflow := util.NewWorkflow(testWorkflow.DeepCopy())
+	workflow.SetExecutionName("wf")
+	_, err := store.ExecClientFake.Execution("ns1").Create(context.Background(), workflow, v1.CreateOptions{})
+	assert.Nil(t, err)
+
+	flaky := &flakyExecutionInterface{
+		ExecutionInterface: store.ExecClientFake.Execution("ns1"),
+		errs:               []error{k8errors.NewInternalError(errors.New("boom")), k8errors.NewTooManyRequests("throttled", 1)},
+	}
+	execution, err := newRetryingExecutionInterface(flaky, testRetryPolicy).Get(context.Background(), "wf", v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "wf", execution.ExecutionName())
+	assert.Equal(t, 3, flaky.calls)
+}
+
+func TestRetryingExecutionInterface_GetStopsOnTerminalError(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	flaky := &flakyExecutionInterface{
+		ExecutionInterface: store.ExecClientFake.Execution("ns1"),
+		errs:               []error{k8errors.NewForbidden(workflowsResource, "wf", errors.New("denied"))},
+	}
+	_, err := newRetryingExecutionInterface(flaky, testRetryPolicy).Get(context.Background(), "wf", v1.GetOptions{})
+	assert.True(t, k8errors.IsForbidden(err))
+	assert.Equal(t, 1, flaky.calls)
+}
+
+func TestRetryingExecutionInterface_GetRunsOutOfAttempts(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	unavailable := k8errors.NewServiceUnavailable("unavailable")
+	flaky := &flakyExecutionInterface{
+		ExecutionInterface: store.ExecClientFake.Execution("ns1"),
+		errs:               []error{unavailable, unavailable, unavailable, unavailable},
+	}
+	_, err := newRetryingExecutionInterface(flaky, testRetryPolicy).Get(context.Background(), "wf", v1.GetOptions{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "after 3 attempts")
+	assert.Equal(t, 3, flaky.calls)
+}
+
+func TestRetryingExecutionInterface_CreateWithGeneratedNameIsNotRetried(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	flaky := &flakyExecutionInterface{
+		ExecutionInterface: store.ExecClientFake.Execution("ns1"),
+		errs:               []error{k8errors.NewInternalError(errors.New("boom"))},
+	}
+	workflow := util.NewWorkflow(testWorkflow.DeepCopy())
+	workflow.SetExecutionName("")
+	workflow.GenerateName = "wf-"
+	_, err := newRetryingExecutionInterface(flaky, testRetryPolicy).Create(context.Background(), workflow, v1.CreateOptions{})
+	assert.True(t, k8errors.IsInternalError(err))
+	assert.Equal(t, 1, flaky.calls)
+}
+
+func TestRetryingExecutionInterface_CreateWithNameIsRetried(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	flaky := &flakyExecutionInterface{
+		ExecutionInterface: store.ExecClientFake.Execution("ns1"),
+		errs:               []error{syscall.ECONNRESET},
+	}
+	workflow := util.NewWorkflow(testWorkflow.DeepCopy())
+	workflow.SetExecutionName("wf")
+	created, err := newRetryingExecutionInterface(flaky, testRetryPolicy).Create(context.Background(), workflow, v1.CreateOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "wf", created.ExecutionName())
+	assert.Equal(t, 2, flaky.calls)
+}
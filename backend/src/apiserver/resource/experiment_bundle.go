@@ -0,0 +1,359 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// experimentBundleVersion is the version of the layout of experiment bundles. Bundles of other versions are
+	// rejected on import.
+	experimentBundleVersion = 1
+	experimentBundleFile    = "experiment.json"
+	experimentBundleRunsDir = "runs"
+	// maxExperimentNameSuffix bounds the suffixes tried for the name of an imported experiment.
+	maxExperimentNameSuffix = 100
+)
+
+// bundledExperiment is the experiment metadata in an experiment bundle.
+type bundledExperiment struct {
+	Version           int    `json:"version"`
+	Name              string `json:"name"`
+	Description       string `json:"description,omitempty"`
+	StorageState      string `json:"storage_state,omitempty"`
+	DefaultParameters string `json:"default_parameters,omitempty"`
+}
+
+// bundledRun is a run in an experiment bundle. IDs that only make sense on the source instance, like the
+// pipeline and the job of the run, are left out.
+type bundledRun struct {
+	Name                    string            `json:"name"`
+	DisplayName             string            `json:"display_name"`
+	Description             string            `json:"description,omitempty"`
+	StorageState            string            `json:"storage_state,omitempty"`
+	ServiceAccount          string            `json:"service_account,omitempty"`
+	CreatedAtInSec          int64             `json:"created_at_in_sec"`
+	ScheduledAtInSec        int64             `json:"scheduled_at_in_sec,omitempty"`
+	StartedAtInSec          int64             `json:"started_at_in_sec,omitempty"`
+	FinishedAtInSec         int64             `json:"finished_at_in_sec,omitempty"`
+	Conditions              string            `json:"conditions"`
+	State                   string            `json:"state"`
+	Labels                  map[string]string `json:"labels,omitempty"`
+	PipelineName            string            `json:"pipeline_name,omitempty"`
+	PipelineSpecManifest    string            `json:"pipeline_spec_manifest,omitempty"`
+	WorkflowSpecManifest    string            `json:"workflow_spec_manifest,omitempty"`
+	Parameters              string            `json:"parameters,omitempty"`
+	RuntimeParameters       string            `json:"runtime_parameters,omitempty"`
+	PipelineRoot            string            `json:"pipeline_root,omitempty"`
+	Metrics                 []bundledMetric   `json:"metrics,omitempty"`
+	WorkflowRuntimeManifest string            `json:"workflow_runtime_manifest,omitempty"`
+	PipelineRuntimeManifest string            `json:"pipeline_runtime_manifest,omitempty"`
+}
+
+type bundledMetric struct {
+	NodeID      string  `json:"node_id"`
+	Name        string  `json:"name"`
+	NumberValue float64 `json:"number_value"`
+	Format      string  `json:"format,omitempty"`
+}
+
+// ExportExperiment returns a tar archive holding an experiment and its runs, archived or not, as JSON: the
+// experiment in experiment.json and each run, with its pipeline spec, parameters and metrics, in runs/. The
+// runtime manifests of the runs, which hold the status of their workflows and can be large, are only included
+// if includeWorkflowStatus is set.
+func (r *ResourceManager) ExportExperiment(experimentId string, includeWorkflowStatus bool) ([]byte, error) {
+	experiment, err := r.experimentStore.GetExperiment(experimentId)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to export experiment %v", experimentId)
+	}
+	runIds, err := r.runStore.ListExperimentRunIds(experimentId)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to export experiment %v", experimentId)
+	}
+
+	var buffer bytes.Buffer
+	writer := tar.NewWriter(&buffer)
+	err = writeBundleFile(writer, experimentBundleFile, &bundledExperiment{
+		Version:           experimentBundleVersion,
+		Name:              experiment.Name,
+		Description:       experiment.Description,
+		StorageState:      experiment.StorageState,
+		DefaultParameters: experiment.DefaultParameters,
+	})
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to export experiment %v", experimentId)
+	}
+	for i, runId := range runIds {
+		run, err := r.runStore.GetRun(runId)
+		if util.IsUserErrorCodeMatch(err, codes.NotFound) {
+			// The run was deleted after it was listed, or its workflow was never reported.
+			continue
+		}
+		if err != nil {
+			return nil, util.Wrapf(err, "Failed to export run %v of experiment %v", runId, experimentId)
+		}
+		bundled := toBundledRun(run, includeWorkflowStatus)
+		// Runs are numbered so that they are imported in order of creation.
+		name := path.Join(experimentBundleRunsDir, fmt.Sprintf("%05d-%v.json", i, runId))
+		if err := writeBundleFile(writer, name, bundled); err != nil {
+			return nil, util.Wrapf(err, "Failed to export run %v of experiment %v", runId, experimentId)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to export experiment %v", experimentId)
+	}
+	return buffer.Bytes(), nil
+}
+
+// ImportExperiment creates an experiment, and its runs, from a bundle made by ExportExperiment. The runs are
+// recorded as they were when exported and aren't executed again. Runs that hadn't finished are recorded as
+// terminated. If the namespace already has an experiment of the same name, the name gets a " (N)" suffix.
+func (r *ResourceManager) ImportExperiment(namespace string, bundle []byte) (*model.Experiment, error) {
+	if !common.IsMultiUserMode() {
+		namespace = ""
+	}
+	bundledExp, bundledRuns, err := readExperimentBundle(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	var experiment *model.Experiment
+	for suffix := 0; experiment == nil; suffix++ {
+		if suffix > maxExperimentNameSuffix {
+			return nil, util.NewAlreadyExistError(
+				"Failed to import experiment %v. Too many experiments of that name already exist", bundledExp.Name)
+		}
+		name := bundledExp.Name
+		if suffix > 0 {
+			name = fmt.Sprintf("%v (%d)", bundledExp.Name, suffix)
+		}
+		experiment, err = r.experimentStore.CreateExperiment(&model.Experiment{
+			Name:              name,
+			Description:       bundledExp.Description,
+			Namespace:         namespace,
+			StorageState:      bundledExp.StorageState,
+			DefaultParameters: bundledExp.DefaultParameters,
+		})
+		if util.IsUserErrorCodeMatch(err, codes.AlreadyExists) {
+			continue
+		}
+		if err != nil {
+			return nil, util.Wrapf(err, "Failed to import experiment %v", bundledExp.Name)
+		}
+	}
+
+	// Like the runs created in single-user mode, imported runs belong to the namespace of the API server then.
+	runNamespace := experiment.Namespace
+	if runNamespace == "" {
+		runNamespace = common.GetPodNamespace()
+	}
+	now := r.time.Now().Unix()
+	for _, bundled := range bundledRuns {
+		id, err := r.uuid.NewRandom()
+		if err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to generate a run ID")
+		}
+		run := fromBundledRun(bundled, id.String(), experiment, runNamespace, now)
+		if _, err := r.runStore.CreateRun(run); err != nil {
+			return nil, util.Wrapf(err, "Failed to import run %v into experiment %v", bundled.DisplayName, experiment.UUID)
+		}
+		for _, metric := range bundled.Metrics {
+			err := r.runStore.ReportMetric(&model.RunMetric{
+				RunUUID:     run.UUID,
+				NodeID:      metric.NodeID,
+				Name:        metric.Name,
+				NumberValue: metric.NumberValue,
+				Format:      metric.Format,
+			})
+			if err != nil {
+				return nil, util.Wrapf(err, "Failed to import the metrics of run %v into experiment %v", bundled.DisplayName, experiment.UUID)
+			}
+		}
+	}
+	return r.experimentStore.GetExperiment(experiment.UUID)
+}
+
+func toBundledRun(run *model.RunDetail, includeWorkflowStatus bool) *bundledRun {
+	bundled := &bundledRun{
+		Name:                 run.Name,
+		DisplayName:          run.DisplayName,
+		Description:          run.Description,
+		StorageState:         run.StorageState,
+		ServiceAccount:       run.ServiceAccount,
+		CreatedAtInSec:       run.CreatedAtInSec,
+		ScheduledAtInSec:     run.ScheduledAtInSec,
+		StartedAtInSec:       run.StartedAtInSec,
+		FinishedAtInSec:      run.FinishedAtInSec,
+		Conditions:           run.Conditions,
+		State:                run.State,
+		Labels:               run.Labels,
+		PipelineName:         run.PipelineName,
+		PipelineSpecManifest: run.PipelineSpecManifest,
+		WorkflowSpecManifest: run.WorkflowSpecManifest,
+		Parameters:           run.Parameters,
+		RuntimeParameters:    run.PipelineSpec.RuntimeConfig.Parameters,
+		PipelineRoot:         run.PipelineSpec.RuntimeConfig.PipelineRoot,
+	}
+	for _, metric := range run.Metrics {
+		bundled.Metrics = append(bundled.Metrics, bundledMetric{
+			NodeID:      metric.NodeID,
+			Name:        metric.Name,
+			NumberValue: metric.NumberValue,
+			Format:      metric.Format,
+		})
+	}
+	if includeWorkflowStatus {
+		bundled.WorkflowRuntimeManifest = run.WorkflowRuntimeManifest
+		bundled.PipelineRuntimeManifest = run.PipelineRuntimeManifest
+	}
+	return bundled
+}
+
+func fromBundledRun(bundled *bundledRun, runId string, experiment *model.Experiment, namespace string, now int64) *model.RunDetail {
+	run := &model.RunDetail{
+		Run: model.Run{
+			UUID:             runId,
+			ExperimentUUID:   experiment.UUID,
+			Name:             bundled.Name,
+			DisplayName:      bundled.DisplayName,
+			Description:      bundled.Description,
+			StorageState:     bundled.StorageState,
+			Namespace:        namespace,
+			ServiceAccount:   bundled.ServiceAccount,
+			CreatedAtInSec:   bundled.CreatedAtInSec,
+			ScheduledAtInSec: bundled.ScheduledAtInSec,
+			StartedAtInSec:   bundled.StartedAtInSec,
+			FinishedAtInSec:  bundled.FinishedAtInSec,
+			Conditions:       bundled.Conditions,
+			State:            bundled.State,
+			Labels:           bundled.Labels,
+			ResourceReferences: []*model.ResourceReference{{
+				ResourceUUID:  runId,
+				ResourceType:  common.Run,
+				ReferenceUUID: experiment.UUID,
+				ReferenceName: experiment.Name,
+				ReferenceType: common.Experiment,
+				Relationship:  common.Owner,
+			}},
+			PipelineSpec: model.PipelineSpec{
+				PipelineName:         bundled.PipelineName,
+				PipelineSpecManifest: bundled.PipelineSpecManifest,
+				WorkflowSpecManifest: bundled.WorkflowSpecManifest,
+				Parameters:           bundled.Parameters,
+				RuntimeConfig: model.RuntimeConfig{
+					Parameters:   bundled.RuntimeParameters,
+					PipelineRoot: bundled.PipelineRoot,
+				},
+			},
+		},
+		PipelineRuntime: model.PipelineRuntime{
+			WorkflowRuntimeManifest: bundled.WorkflowRuntimeManifest,
+			PipelineRuntimeManifest: bundled.PipelineRuntimeManifest,
+		},
+	}
+	// No workflow is created for an imported run, so nothing would ever report the end of a run that hadn't
+	// finished.
+	if run.State == "" {
+		run.State = model.RunStateFromConditions(run.Conditions)
+	}
+	if run.State == "" || model.IsActiveRunState(run.State) {
+		run.State = model.RunStateTerminated
+		run.Conditions = "Terminated"
+		run.FinishedAtInSec = now
+	}
+	// Runs whose workflow was never reported can't be read, so a bundle without the status of the workflows
+	// records the workflow spec in its place.
+	if run.WorkflowRuntimeManifest == "" {
+		run.WorkflowRuntimeManifest = run.WorkflowSpecManifest
+	}
+	return run
+}
+
+func writeBundleFile(writer *tar.Writer, name string, content interface{}) error {
+	data, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to marshal %v", name)
+	}
+	err = writer.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), Typeflag: tar.TypeReg})
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to write %v", name)
+	}
+	if _, err := writer.Write(data); err != nil {
+		return util.NewInternalServerError(err, "Failed to write %v", name)
+	}
+	return nil
+}
+
+// readExperimentBundle reads the experiment, and its runs in the order they are stored, from an experiment
+// bundle.
+func readExperimentBundle(bundle []byte) (*bundledExperiment, []*bundledRun, error) {
+	var experiment *bundledExperiment
+	var runs []*bundledRun
+	reader := tar.NewReader(bytes.NewReader(bundle))
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, util.NewInvalidInputErrorWithDetails(err, "Failed to read the experiment bundle")
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return nil, nil, util.NewInvalidInputErrorWithDetails(err, fmt.Sprintf("Failed to read %v of the experiment bundle", header.Name))
+		}
+		name := path.Clean(header.Name)
+		switch {
+		case name == experimentBundleFile:
+			experiment = &bundledExperiment{}
+			if err := json.Unmarshal(data, experiment); err != nil {
+				return nil, nil, util.NewInvalidInputErrorWithDetails(err, fmt.Sprintf("Failed to parse %v of the experiment bundle", header.Name))
+			}
+		case path.Dir(name) == experimentBundleRunsDir && strings.HasSuffix(name, ".json"):
+			run := &bundledRun{}
+			if err := json.Unmarshal(data, run); err != nil {
+				return nil, nil, util.NewInvalidInputErrorWithDetails(err, fmt.Sprintf("Failed to parse %v of the experiment bundle", header.Name))
+			}
+			runs = append(runs, run)
+		}
+	}
+	if experiment == nil {
+		return nil, nil, util.NewInvalidInputError("The experiment bundle has no %v", experimentBundleFile)
+	}
+	if experiment.Version != experimentBundleVersion {
+		return nil, nil, util.NewInvalidInputError("Unsupported experiment bundle version %v. Supported version is %v",
+			experiment.Version, experimentBundleVersion)
+	}
+	if experiment.Name == "" {
+		return nil, nil, util.NewInvalidInputError("The experiment of the bundle has no name")
+	}
+	return experiment, runs, nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+// readBundleFiles returns the content of the files of a tar archive by name.
+func readBundleFiles(t *testing.T, bundle []byte) map[string][]byte {
+	files := map[string][]byte{}
+	reader := tar.NewReader(bytes.NewReader(bundle))
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.Nil(t, err)
+		data, err := ioutil.ReadAll(reader)
+		require.Nil(t, err)
+		files[header.Name] = data
+	}
+	return files
+}
+
+func TestExportExperiment(t *testing.T) {
+	store, manager, runDetail := initWithOneTimeRun(t)
+	err := store.RunStore().UpdateRun(runDetail.UUID, "Succeeded", 10, runDetail.WorkflowRuntimeManifest)
+	require.Nil(t, err)
+	err = store.RunStore().ReportMetric(&model.RunMetric{
+		RunUUID: runDetail.UUID, NodeID: "node1", Name: "accuracy", NumberValue: 0.9, Format: "RAW"})
+	require.Nil(t, err)
+
+	bundle, err := manager.ExportExperiment(DefaultFakeUUID, false)
+	require.Nil(t, err)
+	files := readBundleFiles(t, bundle)
+	assert.Len(t, files, 2)
+	var experiment bundledExperiment
+	require.Nil(t, json.Unmarshal(files["experiment.json"], &experiment))
+	assert.Equal(t, bundledExperiment{Version: 1, Name: "e1", StorageState: "AVAILABLE"}, experiment)
+	var run bundledRun
+	require.Nil(t, json.Unmarshal(files["runs/00000-"+runDetail.UUID+".json"], &run))
+	assert.Equal(t, "run1", run.DisplayName)
+	assert.Equal(t, model.RunStateSucceeded, run.State)
+	assert.Equal(t, runDetail.WorkflowSpecManifest, run.WorkflowSpecManifest)
+	assert.Equal(t, runDetail.Parameters, run.Parameters)
+	assert.Equal(t, []bundledMetric{{NodeID: "node1", Name: "accuracy", NumberValue: 0.9, Format: "RAW"}}, run.Metrics)
+	assert.Empty(t, run.WorkflowRuntimeManifest)
+
+	bundle, err = manager.ExportExperiment(DefaultFakeUUID, true)
+	require.Nil(t, err)
+	files = readBundleFiles(t, bundle)
+	require.Nil(t, json.Unmarshal(files["runs/00000-"+runDetail.UUID+".json"], &run))
+	assert.Equal(t, runDetail.WorkflowRuntimeManifest, run.WorkflowRuntimeManifest)
+
+	_, err = manager.ExportExperiment("unknown", false)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
+func TestImportExperiment(t *testing.T) {
+	store, manager, runDetail := initWithOneTimeRun(t)
+	err := store.RunStore().UpdateRun(runDetail.UUID, "Succeeded", 10, runDetail.WorkflowRuntimeManifest)
+	require.Nil(t, err)
+	err = store.RunStore().ReportMetric(&model.RunMetric{
+		RunUUID: runDetail.UUID, NodeID: "node1", Name: "accuracy", NumberValue: 0.9, Format: "RAW"})
+	require.Nil(t, err)
+	bundle, err := manager.ExportExperiment(DefaultFakeUUID, false)
+	require.Nil(t, err)
+
+	store.UpdateUUID(util.NewFakeUUIDGeneratorOrFatal(FakeUUIDOne, nil))
+	manager = NewResourceManager(store)
+	experiment, err := manager.ImportExperiment("", bundle)
+	require.Nil(t, err)
+	// The name of the exported experiment is taken.
+	assert.Equal(t, FakeUUIDOne, experiment.UUID)
+	assert.Equal(t, "e1 (1)", experiment.Name)
+	assert.Equal(t, runDetail.CreatedAtInSec, experiment.LastRunCreatedAtInSec)
+
+	runIds, err := store.RunStore().ListExperimentRunIds(FakeUUIDOne)
+	require.Nil(t, err)
+	require.Equal(t, []string{FakeUUIDOne}, runIds)
+	run, err := manager.GetRun(FakeUUIDOne)
+	require.Nil(t, err)
+	assert.Equal(t, "run1", run.DisplayName)
+	assert.Equal(t, model.RunStateSucceeded, run.State)
+	assert.Equal(t, int64(10), run.FinishedAtInSec)
+	assert.Equal(t, runDetail.CreatedAtInSec, run.CreatedAtInSec)
+	assert.Equal(t, runDetail.Parameters, run.Parameters)
+	assert.Equal(t, runDetail.WorkflowSpecManifest, run.WorkflowSpecManifest)
+	require.Len(t, run.Metrics, 1)
+	assert.Equal(t, 0.9, run.Metrics[0].NumberValue)
+	// The run isn't executed again.
+	assert.Empty(t, run.WorkflowUID)
+
+	_, err = manager.ImportExperiment("", []byte("not a bundle"))
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+}
+
+func TestImportExperiment_UnfinishedRun(t *testing.T) {
+	store, manager, runDetail := initWithOneTimeRun(t)
+	bundle, err := manager.ExportExperiment(DefaultFakeUUID, true)
+	require.Nil(t, err)
+
+	store.UpdateUUID(util.NewFakeUUIDGeneratorOrFatal(FakeUUIDOne, nil))
+	manager = NewResourceManager(store)
+	_, err = manager.ImportExperiment("", bundle)
+	require.Nil(t, err)
+	run, err := manager.GetRun(FakeUUIDOne)
+	require.Nil(t, err)
+	assert.Equal(t, runDetail.DisplayName, run.DisplayName)
+	assert.Equal(t, model.RunStateTerminated, run.State)
+	assert.NotZero(t, run.FinishedAtInSec)
+}
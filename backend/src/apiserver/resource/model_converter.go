@@ -111,13 +111,19 @@ func (r *ResourceManager) ToModelRunDetailV1(run *apiv1beta1.Run, runId string,
 		}
 	}
 
-	// Add a reference to the default experiment if run does not already have a containing experiment
-	ref, err := r.getDefaultExperimentIfNoExperiment(run.GetResourceReferences())
-	if err != nil {
-		return nil, err
-	}
-	if ref != nil {
-		run.ResourceReferences = append(run.GetResourceReferences(), ref)
+	// Add a reference to the default experiment if run does not already have a containing experiment. A dry run
+	// in a namespace that doesn't have a default experiment yet is converted without an experiment.
+	namespace := common.GetNamespaceFromAPIResourceReferences(run.GetResourceReferences())
+	withoutExperiment := common.IsMultiUserMode() && namespace != "" &&
+		common.GetExperimentIDFromAPIResourceReferences(run.GetResourceReferences()) == ""
+	if !withoutExperiment {
+		ref, err := r.getDefaultExperimentIfNoExperiment(run.GetResourceReferences())
+		if err != nil {
+			return nil, err
+		}
+		if ref != nil {
+			run.ResourceReferences = append(run.GetResourceReferences(), ref)
+		}
 	}
 
 	// Convert api ResourceReferences to Model ResourceReferences.
@@ -126,14 +132,16 @@ func (r *ResourceManager) ToModelRunDetailV1(run *apiv1beta1.Run, runId string,
 		return nil, util.Wrap(err, "Unable to convert resource references.")
 	}
 
-	experimentUUID, err := r.getOwningExperimentUUID(run.ResourceReferences)
-	if err != nil {
-		return nil, util.Wrap(err, "Error getting the experiment UUID")
-	}
-
-	namespace, err := r.getNamespaceFromExperiment(run.GetResourceReferences())
-	if err != nil {
-		return nil, err
+	var experimentUUID string
+	if !withoutExperiment {
+		experimentUUID, err = r.getOwningExperimentUUID(run.ResourceReferences)
+		if err != nil {
+			return nil, util.Wrap(err, "Error getting the experiment UUID")
+		}
+		namespace, err = r.getNamespaceFromExperiment(run.GetResourceReferences())
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	runDetail := &model.RunDetail{
@@ -169,6 +177,7 @@ func (r *ResourceManager) ToModelRunDetailV1(run *apiv1beta1.Run, runId string,
 		}
 		runDetail.Parameters = params
 		runDetail.WorkflowSpecManifest = manifest
+		runDetail.PipelineSpec.RuntimeConfig.PipelineRoot = pipelineRootOfV1(run)
 		return runDetail, nil
 
 	} else if templateType == template.V2 {
@@ -179,7 +188,7 @@ func (r *ResourceManager) ToModelRunDetailV1(run *apiv1beta1.Run, runId string,
 		}
 		runDetail.PipelineSpecManifest = manifest
 		runDetail.PipelineSpec.RuntimeConfig.Parameters = params
-		runDetail.PipelineSpec.RuntimeConfig.PipelineRoot = run.GetPipelineSpec().GetRuntimeConfig().GetPipelineRoot()
+		runDetail.PipelineSpec.RuntimeConfig.PipelineRoot = pipelineRootOfV1(run)
 		return runDetail, nil
 
 	} else {
@@ -187,6 +196,15 @@ func (r *ResourceManager) ToModelRunDetailV1(run *apiv1beta1.Run, runId string,
 	}
 }
 
+// pipelineRootOfV1 returns the pipeline root set by a v1 run, in its pipeline_root field or else in the runtime
+// config of its pipeline spec.
+func pipelineRootOfV1(run *apiv1beta1.Run) string {
+	if run.GetPipelineRoot() != "" {
+		return run.GetPipelineRoot()
+	}
+	return run.GetPipelineSpec().GetRuntimeConfig().GetPipelineRoot()
+}
+
 // The input run might not contain workflowSpecManifest and pipelineSpecManifest, but instead a pipeline ID.
 // The caller would retrieve manifest and pass in.
 func (r *ResourceManager) ToModelRunDetailV2(run *apiv2beta1.Run, runId string, runAt int64, manifest string, templateType template.TemplateType) (*model.RunDetail, error) {
@@ -240,6 +258,9 @@ func (r *ResourceManager) ToModelRunDetailV2(run *apiv2beta1.Run, runId string,
 func (r *ResourceManager) updateModelRunWithNewScheduledWorkflow(modelRunDetail *model.RunDetail, workflow util.ExecutionSpec, templateType template.TemplateType) {
 	modelRunDetail.Name = workflow.ExecutionName()
 	modelRunDetail.Namespace = workflow.ExecutionNamespace()
+	modelRunDetail.WorkflowUID = workflow.ExecutionUID()
+	modelRunDetail.TTLSeconds = ttlSecondsOf(workflow)
+	modelRunDetail.Labels = runLabelsOf(workflow)
 	modelRunDetail.ServiceAccount = workflow.ServiceAccount()
 	modelRunDetail.Conditions = string(workflow.ExecutionStatus().Condition())
 	if templateType == template.V1 {
@@ -580,7 +601,7 @@ func (r *ResourceManager) getResourceName(resourceType model.ResourceType, resou
 		}
 		return job.DisplayName, nil
 	case common.Run:
-		run, err := r.GetRun(resourceId)
+		run, err := r.runStore.GetRun(resourceId)
 		if err != nil {
 			return "", util.Wrap(err, "Referred run not found.")
 		}
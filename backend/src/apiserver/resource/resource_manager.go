@@ -15,13 +15,23 @@
 package resource
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	workflowapi "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/cenkalti/backoff"
+	"github.com/ghodss/yaml"
 	"github.com/golang/glog"
 	apiv1beta1 "github.com/kubeflow/pipelines/backend/api/v1beta1/go_client"
 	apiv2beta1 "github.com/kubeflow/pipelines/backend/api/v2beta1/go_client"
@@ -35,11 +45,13 @@ import (
 	"github.com/kubeflow/pipelines/backend/src/apiserver/template"
 	exec "github.com/kubeflow/pipelines/backend/src/common"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
+	swfapi "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow/v1beta1"
 	scheduledworkflowclient "github.com/kubeflow/pipelines/backend/src/crd/pkg/client/clientset/versioned/typed/scheduledworkflow/v1beta1"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -55,8 +67,17 @@ var (
 		Name: "resource_manager_workflow_gc",
 		Help: "The number of gabarage-collected workflows",
 	})
+
+	// Count the runs deleted or archived by the run reaper, by action.
+	reapedRunsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "resource_manager_reaped_runs",
+		Help: "The number of runs deleted or archived because they finished longer than their retention ago",
+	}, []string{"action"})
 )
 
+// defaultExperimentName is the name of the experiments runs are grouped into when created without one.
+const defaultExperimentName = "Default"
+
 type ClientManagerInterface interface {
 	ExperimentStore() storage.ExperimentStoreInterface
 	PipelineStore() storage.PipelineStoreInterface
@@ -66,6 +87,7 @@ type ClientManagerInterface interface {
 	ResourceReferenceStore() storage.ResourceReferenceStoreInterface
 	DBStatusStore() storage.DBStatusStoreInterface
 	DefaultExperimentStore() storage.DefaultExperimentStoreInterface
+	IdempotencyKeyStore() storage.IdempotencyKeyStoreInterface
 	ObjectStore() storage.ObjectStoreInterface
 	ExecClient() util.ExecutionClient
 	SwfClient() client.SwfClientInterface
@@ -87,6 +109,7 @@ type ResourceManager struct {
 	resourceReferenceStore    storage.ResourceReferenceStoreInterface
 	dBStatusStore             storage.DBStatusStoreInterface
 	defaultExperimentStore    storage.DefaultExperimentStoreInterface
+	idempotencyKeyStore       storage.IdempotencyKeyStoreInterface
 	objectStore               storage.ObjectStoreInterface
 	execClient                util.ExecutionClient
 	swfClient                 client.SwfClientInterface
@@ -97,6 +120,8 @@ type ResourceManager struct {
 	time                      util.TimeInterface
 	uuid                      util.UUIDGeneratorInterface
 	authenticators            []kfpauth.Authenticator
+	// Completion webhooks being delivered in the background.
+	completionWebhooks sync.WaitGroup
 }
 
 func NewResourceManager(clientManager ClientManagerInterface) *ResourceManager {
@@ -109,6 +134,7 @@ func NewResourceManager(clientManager ClientManagerInterface) *ResourceManager {
 		resourceReferenceStore:    clientManager.ResourceReferenceStore(),
 		dBStatusStore:             clientManager.DBStatusStore(),
 		defaultExperimentStore:    clientManager.DefaultExperimentStore(),
+		idempotencyKeyStore:       clientManager.IdempotencyKeyStore(),
 		objectStore:               clientManager.ObjectStore(),
 		execClient:                clientManager.ExecClient(),
 		swfClient:                 clientManager.SwfClient(),
@@ -123,7 +149,7 @@ func NewResourceManager(clientManager ClientManagerInterface) *ResourceManager {
 }
 
 func (r *ResourceManager) getWorkflowClient(namespace string) util.ExecutionInterface {
-	return r.execClient.Execution(namespace)
+	return newRetryingExecutionInterface(r.execClient.Execution(namespace), executionRetryPolicyFromConfig())
 }
 
 func (r *ResourceManager) getScheduledWorkflowClient(namespace string) scheduledworkflowclient.ScheduledWorkflowInterface {
@@ -146,17 +172,411 @@ func (r *ResourceManager) GetExperiment(experimentId string) (*model.Experiment,
 	return r.experimentStore.GetExperiment(experimentId)
 }
 
+// GetExperimentRunCounts returns the number of runs of an experiment in each state. Archived runs are only
+// counted if includeArchived is set.
+func (r *ResourceManager) GetExperimentRunCounts(ctx context.Context, experimentId string, includeArchived bool) (map[string]int, error) {
+	if _, err := r.GetExperiment(experimentId); err != nil {
+		return nil, util.Wrapf(err, "Failed to count the runs of experiment %v", experimentId)
+	}
+	return r.runStore.CountExperimentRunsByState(experimentId, includeArchived)
+}
+
 func (r *ResourceManager) ListExperiments(filterContext *common.FilterContext, opts *list.Options) (
 	experiments []*model.Experiment, total_size int, nextPageToken string, err error) {
 	return r.experimentStore.ListExperiments(filterContext, opts)
 }
 
-func (r *ResourceManager) DeleteExperiment(experimentID string) error {
-	_, err := r.experimentStore.GetExperiment(experimentID)
+// ListExperimentsByNamespace lists the experiments of the given namespace, rather than of one inferred from
+// the caller, e.g. for admin views across namespaces. In multi-user mode the caller must be an admin or be
+// allowed to list the experiments of the namespace. In single-user mode the namespace is ignored and all
+// experiments are listed.
+func (r *ResourceManager) ListExperimentsByNamespace(ctx context.Context, namespace string, opts *list.Options) (
+	experiments []*model.Experiment, total_size int, nextPageToken string, err error) {
+	if !common.IsMultiUserMode() {
+		return r.experimentStore.ListExperiments(&common.FilterContext{}, opts)
+	}
+	if namespace == "" {
+		return nil, 0, "", util.NewInvalidInputError("Listing experiments requires a namespace in multi-user mode")
+	}
+	userIdentity, err := r.AuthenticateRequest(ctx)
+	if err != nil {
+		return nil, 0, "", util.Wrapf(err, "Failed to list the experiments of namespace %v", namespace)
+	}
+	if !common.IsImpersonationAdmin(userIdentity) {
+		err = r.IsRequestAuthorized(ctx, userIdentity, &authorizationv1.ResourceAttributes{
+			Namespace: namespace,
+			Verb:      common.RbacResourceVerbList,
+			Group:     common.RbacPipelinesGroup,
+			Version:   common.RbacPipelinesVersion,
+			Resource:  common.RbacResourceTypeExperiments,
+		})
+		if err != nil {
+			return nil, 0, "", util.Wrapf(err, "Failed to list the experiments of namespace %v", namespace)
+		}
+	}
+	return r.experimentStore.ListExperiments(&common.FilterContext{
+		ReferenceKey: &common.ReferenceKey{Type: common.Namespace, ID: namespace}}, opts)
+}
+
+// DeleteExperiment deletes an experiment. If softDelete is set, the experiment is only marked as deleted,
+// and its jobs are disabled. It can then be brought back with RestoreExperiment until it is reaped.
+func (r *ResourceManager) DeleteExperiment(ctx context.Context, experimentID string, softDelete bool) error {
+	// Soft deleted experiments can still be deleted for good.
+	experiment, err := r.experimentStore.GetExperimentIncludingDeleted(experimentID)
+	if err != nil {
+		return util.Wrap(err, "Delete experiment failed")
+	}
+	defaultOf, err := r.defaultExperimentStore.GetDefaultExperimentNamespace(experimentID)
 	if err != nil {
 		return util.Wrap(err, "Delete experiment failed")
 	}
-	return r.experimentStore.DeleteExperiment(experimentID)
+	if defaultOf != "" {
+		return util.NewFailedPreconditionError(errors.New("experiment is a namespace default"),
+			"Experiment %v is the default experiment of namespace %v and can't be deleted", experimentID, defaultOf)
+	}
+	if !softDelete {
+		return r.experimentStore.DeleteExperiment(experimentID)
+	}
+	if experiment.DeletedAtInSec > 0 {
+		return util.NewResourceNotFoundError("Experiment", experimentID)
+	}
+	if err := r.disableExperimentJobs(ctx, experimentID); err != nil {
+		return util.Wrap(err, "Delete experiment failed")
+	}
+	return r.experimentStore.SoftDeleteExperiment(experimentID)
+}
+
+// RestoreExperiment restores a soft deleted experiment, together with the visibility of its runs and jobs.
+// Jobs disabled by the deletion stay disabled.
+func (r *ResourceManager) RestoreExperiment(ctx context.Context, experimentID string) error {
+	if _, err := r.experimentStore.GetExperimentIncludingDeleted(experimentID); err != nil {
+		return util.Wrap(err, "Restore experiment failed")
+	}
+	return r.experimentStore.RestoreExperiment(experimentID)
+}
+
+// UpdateExperiment changes the name and description of an experiment. If expectedVersion isn't 0, the
+// update fails with a Conflict error unless the experiment is still at that resource version.
+func (r *ResourceManager) UpdateExperiment(experimentID string, name string, description string, expectedVersion int64) (*model.Experiment, error) {
+	if name == "" {
+		return nil, util.NewInvalidInputError("Experiment name cannot be empty")
+	}
+	if err := r.experimentStore.UpdateExperiment(experimentID, name, description, expectedVersion); err != nil {
+		return nil, util.Wrap(err, "Update experiment failed")
+	}
+	return r.experimentStore.GetExperiment(experimentID)
+}
+
+// SetExperimentDefaultVersion pins the pipeline version that runs created in the experiment without a pipeline
+// use. An empty version ID clears the pin.
+func (r *ResourceManager) SetExperimentDefaultVersion(experimentID string, pipelineVersionID string) error {
+	if _, err := r.experimentStore.GetExperiment(experimentID); err != nil {
+		return util.Wrap(err, "Set experiment default version failed")
+	}
+	if pipelineVersionID != "" {
+		if _, err := r.pipelineStore.GetPipelineVersion(pipelineVersionID); err != nil {
+			return util.Wrap(err, "Set experiment default version failed")
+		}
+	}
+	return r.experimentStore.SetExperimentDefaultPipelineVersion(experimentID, pipelineVersionID)
+}
+
+// SetExperimentDefaultParameters sets the parameter presets of an experiment. v1 runs created in the
+// experiment get the presets for the parameters they don't set. Empty parameters clear the presets.
+func (r *ResourceManager) SetExperimentDefaultParameters(experimentID string, parameters []*apiv1beta1.Parameter) error {
+	if _, err := r.experimentStore.GetExperiment(experimentID); err != nil {
+		return util.Wrap(err, "Set experiment default parameters failed")
+	}
+	names := make(map[string]bool, len(parameters))
+	for _, param := range parameters {
+		if param.GetName() == "" {
+			return util.NewInvalidInputError("Set experiment default parameters failed: a parameter has no name")
+		}
+		if names[param.GetName()] {
+			return util.NewInvalidInputError("Set experiment default parameters failed: parameter %q is set more than once", param.GetName())
+		}
+		names[param.GetName()] = true
+	}
+	paramsJSON, err := apiParametersToModelParameters(parameters)
+	if err != nil {
+		return util.Wrap(err, "Set experiment default parameters failed")
+	}
+	return r.experimentStore.SetExperimentDefaultParameters(experimentID, paramsJSON)
+}
+
+// SetExperimentWorkflowNamePrefix sets the prefix of the generated names of the workflows of the runs created
+// in an experiment from now on, e.g. to tell the workflows of several systems sharing Argo apart. The prefix
+// must be a DNS label. An empty prefix clears it.
+func (r *ResourceManager) SetExperimentWorkflowNamePrefix(experimentID string, prefix string) error {
+	if _, err := r.experimentStore.GetExperiment(experimentID); err != nil {
+		return util.Wrap(err, "Set experiment workflow name prefix failed")
+	}
+	if err := validateWorkflowNamePrefix(prefix); err != nil {
+		return err
+	}
+	return r.experimentStore.SetExperimentWorkflowNamePrefix(experimentID, prefix)
+}
+
+// SetExperimentCompletionWebhook sets the URL notified when a run of an experiment completes, unless the run
+// sets its own webhook. In multi-user mode, the host of the URL must be allowed. An empty URL clears it.
+func (r *ResourceManager) SetExperimentCompletionWebhook(experimentID string, webhook string) error {
+	if _, err := r.experimentStore.GetExperiment(experimentID); err != nil {
+		return util.Wrap(err, "Set experiment completion webhook failed")
+	}
+	if err := validateCompletionWebhook(webhook); err != nil {
+		return err
+	}
+	return r.experimentStore.SetExperimentCompletionWebhook(experimentID, webhook)
+}
+
+// SetExperimentLabels replaces the labels of an experiment, which the workflows of the runs created in the
+// experiment from now on inherit, along with their pods. Keys prefixed with one of the domains Kubeflow and Argo
+// label workflows and pods with are rejected. Empty labels clear them.
+func (r *ResourceManager) SetExperimentLabels(experimentID string, labels map[string]string) error {
+	if _, err := r.experimentStore.GetExperiment(experimentID); err != nil {
+		return util.Wrap(err, "Set experiment labels failed")
+	}
+	if err := validateExperimentLabels(labels); err != nil {
+		return util.Wrap(err, "Set experiment labels failed")
+	}
+	return r.experimentStore.SetExperimentLabels(experimentID, labels)
+}
+
+// CloneExperimentOptions are the options of CloneExperimentWithOptions.
+type CloneExperimentOptions struct {
+	// Namespace the clone is created in, in multi-user mode. Defaults to the namespace of the source experiment.
+	Namespace string
+}
+
+// CloneExperiment creates an experiment with the description, default pipeline version, parameter presets,
+// workflow name prefix and labels of another one, but none of its runs or jobs, in the same namespace.
+func (r *ResourceManager) CloneExperiment(ctx context.Context, sourceID string, newName string) (*model.Experiment, error) {
+	return r.CloneExperimentWithOptions(ctx, sourceID, newName, CloneExperimentOptions{})
+}
+
+// CloneExperimentWithOptions is CloneExperiment with options. The clone has a ClonedFrom reference to the
+// source experiment, and is created available even if the source is archived. A clone named like another
+// experiment of its namespace fails with an AlreadyExists error. In multi-user mode the caller must be allowed
+// to get the source experiment and to create experiments in the namespace of the clone.
+func (r *ResourceManager) CloneExperimentWithOptions(ctx context.Context, sourceID string, newName string, opts CloneExperimentOptions) (*model.Experiment, error) {
+	if newName == "" {
+		return nil, util.NewInvalidInputError("Failed to clone experiment %v: the clone must have a name", sourceID)
+	}
+	source, err := r.experimentStore.GetExperiment(sourceID)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to clone experiment %v", sourceID)
+	}
+	namespace := ""
+	if common.IsMultiUserMode() {
+		namespace = opts.Namespace
+		if namespace == "" {
+			namespace = source.Namespace
+		}
+		userIdentity, err := r.AuthenticateRequest(ctx)
+		if err != nil {
+			return nil, util.Wrapf(err, "Failed to clone experiment %v", sourceID)
+		}
+		if !common.IsImpersonationAdmin(userIdentity) {
+			for _, attributes := range []*authorizationv1.ResourceAttributes{
+				{Namespace: source.Namespace, Name: source.Name, Verb: common.RbacResourceVerbGet},
+				{Namespace: namespace, Name: newName, Verb: common.RbacResourceVerbCreate},
+			} {
+				attributes.Group = common.RbacPipelinesGroup
+				attributes.Version = common.RbacPipelinesVersion
+				attributes.Resource = common.RbacResourceTypeExperiments
+				if err := r.IsRequestAuthorized(ctx, userIdentity, attributes); err != nil {
+					return nil, util.Wrapf(err, "Failed to clone experiment %v", sourceID)
+				}
+			}
+		}
+	}
+	clone, err := r.experimentStore.CreateClonedExperiment(&model.Experiment{
+		Name:                     newName,
+		Description:              source.Description,
+		Namespace:                namespace,
+		DefaultPipelineVersionId: source.DefaultPipelineVersionId,
+		DefaultParameters:        source.DefaultParameters,
+		WorkflowNamePrefix:       source.WorkflowNamePrefix,
+		CompletionWebhook:        source.CompletionWebhook,
+		Labels:                   source.Labels,
+	}, source)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to clone experiment %v", sourceID)
+	}
+	return clone, nil
+}
+
+// applyExperimentToWorkflow prepends the workflow name prefix of the run's experiment, if any, to the generated
+// name of the run's workflow, or to its name if the pipeline gives its workflows a fixed name. The labels of the
+// experiment are set on the workflow and its pods, and returned.
+func (r *ResourceManager) applyExperimentToWorkflow(executionSpec util.ExecutionSpec, modelRunDetail *model.RunDetail) (map[string]string, error) {
+	if modelRunDetail.ExperimentUUID == "" {
+		return nil, nil
+	}
+	experiment, err := r.experimentStore.GetExperiment(modelRunDetail.ExperimentUUID)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the workflow settings of the experiment")
+	}
+	if experiment.WorkflowNamePrefix != "" {
+		meta := executionSpec.ExecutionObjectMeta()
+		if meta.GenerateName != "" {
+			meta.GenerateName = experiment.WorkflowNamePrefix + "-" + meta.GenerateName
+		} else if meta.Name != "" {
+			meta.Name = experiment.WorkflowNamePrefix + "-" + meta.Name
+		}
+	}
+	for key, value := range experiment.Labels {
+		executionSpec.SetLabels(key, value)
+		executionSpec.SetPodMetadataLabels(key, value)
+	}
+	return experiment.Labels, nil
+}
+
+// AddExperimentDefaultVersion adds a reference to the default pipeline version of the run's experiment when
+// the run doesn't specify a pipeline. The references are returned unchanged otherwise.
+func (r *ResourceManager) AddExperimentDefaultVersion(spec *apiv1beta1.PipelineSpec, references []*apiv1beta1.ResourceReference) ([]*apiv1beta1.ResourceReference, error) {
+	if spec.GetWorkflowManifest() != "" || spec.GetPipelineManifest() != "" || spec.GetPipelineId() != "" {
+		return references, nil
+	}
+	experimentID := common.GetExperimentIDFromAPIResourceReferences(references)
+	if experimentID == "" {
+		return references, nil
+	}
+	for _, reference := range references {
+		if reference.GetKey().GetType() == apiv1beta1.ResourceType_PIPELINE_VERSION {
+			return references, nil
+		}
+	}
+	experiment, err := r.experimentStore.GetExperiment(experimentID)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the default pipeline version of the experiment")
+	}
+	if experiment.DefaultPipelineVersionId == "" {
+		return references, nil
+	}
+	return append(references, &apiv1beta1.ResourceReference{
+		Key: &apiv1beta1.ResourceKey{
+			Id:   experiment.DefaultPipelineVersionId,
+			Type: apiv1beta1.ResourceType_PIPELINE_VERSION,
+		},
+		Relationship: apiv1beta1.Relationship_CREATOR,
+	}), nil
+}
+
+// ReapDeletedExperiments permanently deletes the experiments soft deleted longer than the retention
+// window ago. It returns the number of deleted experiments.
+func (r *ResourceManager) ReapDeletedExperiments(retention time.Duration) (int, error) {
+	deletedBefore := r.time.Now().Add(-retention).Unix()
+	experimentIDs, err := r.experimentStore.ListExperimentsDeletedBefore(deletedBefore)
+	if err != nil {
+		return 0, util.Wrap(err, "Failed to list the experiments to reap")
+	}
+	var errs []error
+	reaped := 0
+	for _, id := range experimentIDs {
+		if err := r.experimentStore.DeleteExperiment(id); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		reaped++
+	}
+	if len(errs) > 0 {
+		return reaped, util.NewInternalServerError(utilerrors.NewAggregate(errs), "Failed to reap %v deleted experiments", len(errs))
+	}
+	return reaped, nil
+}
+
+// RunReaperOptions tells ReapRuns which finished runs to reap and how.
+type RunReaperOptions struct {
+	// Retention is how long runs are kept after they finish. Zero keeps them forever.
+	Retention time.Duration
+	// NamespaceRetention overrides Retention for the runs of some namespaces.
+	NamespaceRetention map[string]time.Duration
+	// Archive archives the runs instead of deleting them.
+	Archive bool
+	// KeepMetrics keeps the metrics of the reaped runs.
+	KeepMetrics bool
+	// KeepStatusSnapshots keeps the status snapshots of archived runs. Deleted runs lose them.
+	KeepStatusSnapshots bool
+	// BatchSize is the number of runs reaped in each transaction.
+	BatchSize int
+}
+
+// ReapRuns deletes, or archives, the runs that finished longer than their namespace's retention ago, BatchSize
+// runs at a time. It returns how many runs were reaped.
+func (r *ResourceManager) ReapRuns(opts RunReaperOptions) (int, error) {
+	if opts.BatchSize <= 0 {
+		return 0, util.NewInvalidInputError("Runs must be reaped in batches of at least one run, got %v", opts.BatchSize)
+	}
+	namespaces := make([]string, 0, len(opts.NamespaceRetention))
+	for namespace := range opts.NamespaceRetention {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	reaped := 0
+	for _, namespace := range namespaces {
+		if retention := opts.NamespaceRetention[namespace]; retention > 0 {
+			count, err := r.reapRunsFinishedBefore(namespace, nil, retention, opts)
+			reaped += count
+			if err != nil {
+				return reaped, util.Wrapf(err, "Failed to reap the runs of namespace %v", namespace)
+			}
+		}
+	}
+	if opts.Retention > 0 {
+		count, err := r.reapRunsFinishedBefore("", namespaces, opts.Retention, opts)
+		reaped += count
+		if err != nil {
+			return reaped, util.Wrap(err, "Failed to reap runs")
+		}
+	}
+	return reaped, nil
+}
+
+func (r *ResourceManager) reapRunsFinishedBefore(namespace string, excludedNamespaces []string, retention time.Duration, opts RunReaperOptions) (int, error) {
+	finishedBefore := r.time.Now().Add(-retention).Unix()
+	action := "deleted"
+	if opts.Archive {
+		action = "archived"
+	}
+	reaped := 0
+	for {
+		runIds, err := r.runStore.ListRunIdsFinishedBefore(namespace, excludedNamespaces, finishedBefore, opts.Archive, opts.BatchSize)
+		if err != nil {
+			return reaped, err
+		}
+		if err := r.runStore.ReapRuns(runIds, opts.Archive, opts.KeepMetrics, opts.KeepStatusSnapshots); err != nil {
+			return reaped, err
+		}
+		reaped += len(runIds)
+		reapedRunsCounter.WithLabelValues(action).Add(float64(len(runIds)))
+		if len(runIds) < opts.BatchSize {
+			return reaped, nil
+		}
+	}
+}
+
+// CompressStoredManifests compresses the manifests of the runs and jobs stored before manifests were
+// compressed. The rows are compressed batchSize at a time, waiting interval between batches so that the tables
+// are never locked for long. It returns how many rows were compressed.
+func (r *ResourceManager) CompressStoredManifests(batchSize int, interval time.Duration) (int, error) {
+	compressed := 0
+	for _, store := range []storage.ManifestCompressor{r.runStore, r.jobStore} {
+		afterUUID := ""
+		for {
+			lastUUID, changed, err := store.CompressManifests(afterUUID, batchSize)
+			compressed += changed
+			if err != nil {
+				return compressed, util.Wrap(err, "Failed to compress the stored manifests")
+			}
+			if lastUUID == "" {
+				break
+			}
+			afterUUID = lastUUID
+			time.Sleep(interval)
+		}
+	}
+	return compressed, nil
 }
 
 func (r *ResourceManager) ArchiveExperiment(ctx context.Context, experimentId string) error {
@@ -166,17 +586,25 @@ func (r *ResourceManager) ArchiveExperiment(ctx context.Context, experimentId st
 	// (2.1) archive experiemnts
 	// (2.2) archive runs
 	// (2.3) disable jobs
+	if err := r.disableExperimentJobs(ctx, experimentId); err != nil {
+		return err
+	}
+	return r.experimentStore.ArchiveExperiment(experimentId)
+}
+
+// disableExperimentJobs disables the ScheduledWorkflow CRs of all the jobs in an experiment.
+func (r *ResourceManager) disableExperimentJobs(ctx context.Context, experimentId string) error {
 	opts, err := list.NewOptions(&model.Job{}, 50, "name", nil)
 	if err != nil {
 		return util.NewInternalServerError(err,
-			"Failed to create list jobs options when archiving experiment. ")
+			"Failed to create list jobs options when disabling the jobs of an experiment. ")
 	}
 	for {
 		jobs, _, newToken, err := r.jobStore.ListJobs(&common.FilterContext{
 			ReferenceKey: &common.ReferenceKey{Type: common.Experiment, ID: experimentId}}, opts)
 		if err != nil {
 			return util.NewInternalServerError(err,
-				"Failed to list jobs of to-be-archived experiment. expID: %v", experimentId)
+				"Failed to list jobs of experiment. expID: %v", experimentId)
 		}
 		for _, job := range jobs {
 			_, err = r.getScheduledWorkflowClient(job.Namespace).Patch(
@@ -195,11 +623,11 @@ func (r *ResourceManager) ArchiveExperiment(ctx context.Context, experimentId st
 			opts, err = list.NewOptionsFromToken(newToken, 50)
 			if err != nil {
 				return util.NewInternalServerError(err,
-					"Failed to create list jobs options from page token when archiving experiment. ")
+					"Failed to create list jobs options from page token when disabling the jobs of an experiment. ")
 			}
 		}
 	}
-	return r.experimentStore.ArchiveExperiment(experimentId)
+	return nil
 }
 
 func (r *ResourceManager) UnarchiveExperiment(experimentId string) error {
@@ -219,6 +647,18 @@ func (r *ResourceManager) GetPipelineByNameAndNamespace(name string, namespace s
 	return r.pipelineStore.GetPipelineByNameAndNamespace(name, namespace)
 }
 
+// GetPipelineByName returns the pipeline with the given name in a namespace, which is unique in multi-user
+// mode. In single-user mode the namespace is ignored.
+func (r *ResourceManager) GetPipelineByName(ctx context.Context, namespace string, name string) (*model.Pipeline, error) {
+	if !common.IsMultiUserMode() {
+		return r.pipelineStore.GetPipelineByName(name)
+	}
+	if namespace == model.NoNamespace {
+		namespace = ""
+	}
+	return r.pipelineStore.GetPipelineByNameAndNamespace(name, namespace)
+}
+
 func (r *ResourceManager) DeletePipeline(pipelineId string) error {
 	_, err := r.pipelineStore.GetPipeline(pipelineId)
 	if err != nil {
@@ -252,11 +692,32 @@ func (r *ResourceManager) DeletePipeline(pipelineId string) error {
 	return nil
 }
 
-func (r *ResourceManager) UpdatePipelineDefaultVersion(pipelineId string, versionId string) error {
-	return r.pipelineStore.UpdatePipelineDefaultVersion(pipelineId, versionId)
+// UpdatePipelineDefaultVersion overrides the default version of a pipeline, which
+// is otherwise the latest version uploaded. Runs and jobs that only reference the
+// pipeline use its default version. If expectedVersion isn't 0, the update fails
+// with a Conflict error unless the pipeline is still at that resource version.
+func (r *ResourceManager) UpdatePipelineDefaultVersion(pipelineId string, versionId string, expectedVersion int64) error {
+	if _, err := r.pipelineStore.GetPipelineWithStatus(pipelineId, model.PipelineReady); err != nil {
+		return util.Wrapf(err, "Failed to update the default version of pipeline %v", pipelineId)
+	}
+	version, err := r.pipelineStore.GetPipelineVersionWithStatus(versionId, model.PipelineVersionReady)
+	if err != nil {
+		return util.Wrapf(err, "Failed to update the default version of pipeline %v", pipelineId)
+	}
+	if version.PipelineId != pipelineId {
+		return util.NewInvalidInputError("Pipeline version %v belongs to pipeline %v, not to pipeline %v",
+			versionId, version.PipelineId, pipelineId)
+	}
+	return r.pipelineStore.UpdatePipelineDefaultVersion(pipelineId, versionId, expectedVersion)
 }
 
-func (r *ResourceManager) CreatePipeline(name string, description string, namespace string, pipelineFile []byte) (*model.Pipeline, error) {
+func (r *ResourceManager) CreatePipeline(name string, description string, namespace string, labels map[string]string, pipelineFile []byte) (*model.Pipeline, error) {
+	if err := common.ValidateLabels(labels); err != nil {
+		return nil, util.Wrap(err, "Create pipeline failed")
+	}
+	if err := validateManifestSize("pipeline file", len(pipelineFile)); err != nil {
+		return nil, util.Wrap(err, "Create pipeline failed")
+	}
 	tmpl, err := template.New(pipelineFile)
 	if err != nil {
 		return nil, util.Wrap(err, "Create pipeline failed")
@@ -268,6 +729,10 @@ func (r *ResourceManager) CreatePipeline(name string, description string, namesp
 	if err != nil {
 		return nil, util.Wrap(err, "Create pipeline failed")
 	}
+	schemaJSON, err := tmpl.ParameterSchema().JSON()
+	if err != nil {
+		return nil, util.Wrap(err, "Create pipeline failed")
+	}
 	// Create an entry with status of creating the pipeline
 	pipeline := &model.Pipeline{
 		Name:        name,
@@ -275,10 +740,12 @@ func (r *ResourceManager) CreatePipeline(name string, description string, namesp
 		Parameters:  paramsJSON,
 		Status:      model.PipelineCreating,
 		Namespace:   namespace,
+		Labels:      labels,
 		DefaultVersion: &model.PipelineVersion{
-			Name:       name,
-			Parameters: paramsJSON,
-			Status:     model.PipelineVersionCreating,
+			Name:            name,
+			Parameters:      paramsJSON,
+			ParameterSchema: schemaJSON,
+			Status:          model.PipelineVersionCreating,
 		}}
 	newPipeline, err := r.pipelineStore.CreatePipeline(pipeline)
 	if err != nil {
@@ -296,6 +763,7 @@ func (r *ResourceManager) CreatePipeline(name string, description string, namesp
 
 	newPipeline.Status = model.PipelineReady
 	newPipeline.DefaultVersion.Status = model.PipelineVersionReady
+	newPipeline.VersionCount = 1
 	err = r.pipelineStore.UpdatePipelineAndVersionsStatus(
 		newPipeline.UUID,
 		newPipeline.Status,
@@ -307,6 +775,16 @@ func (r *ResourceManager) CreatePipeline(name string, description string, namesp
 	return newPipeline, nil
 }
 
+// UpdatePipelineLabels replaces the labels of a pipeline without creating a new pipeline version. If
+// expectedVersion isn't 0, the update fails with a Conflict error unless the pipeline is still at that
+// resource version.
+func (r *ResourceManager) UpdatePipelineLabels(pipelineId string, labels map[string]string, expectedVersion int64) error {
+	if err := common.ValidateLabels(labels); err != nil {
+		return util.Wrapf(err, "Failed to update labels of pipeline %v", pipelineId)
+	}
+	return r.pipelineStore.UpdatePipelineLabels(pipelineId, labels, expectedVersion)
+}
+
 func (r *ResourceManager) UpdatePipelineStatus(pipelineId string, status model.PipelineStatus) error {
 	return r.pipelineStore.UpdatePipelineStatus(pipelineId, status)
 }
@@ -323,8 +801,8 @@ func (r *ResourceManager) GetPipelineTemplate(pipelineId string) ([]byte, error)
 	}
 
 	if pipeline.DefaultVersion == nil {
-		return nil, util.Wrap(err,
-			"Get pipeline template failed since no default version is defined")
+		return nil, util.NewFailedPreconditionError(errors.New("no default version"),
+			"Get pipeline template failed since pipeline %v has no default version", pipelineId)
 	}
 	template, err := r.objectStore.GetFile(r.objectStore.GetPipelineKey(fmt.Sprint(pipeline.DefaultVersion.UUID)))
 	if err != nil {
@@ -334,112 +812,1201 @@ func (r *ResourceManager) GetPipelineTemplate(pipelineId string) ([]byte, error)
 	return template, nil
 }
 
+// CreateRun creates a run. If the request carries an idempotency key, a repeated request with the same
+// key within the key's TTL returns the run created by the first request instead of creating another one.
+// If the request carries a TTL, container resources or a priority class, they replace the ones declared in the
+// run's manifest.
 func (r *ResourceManager) CreateRun(ctx context.Context, apiRunInterface interface{}) (*model.RunDetail, error) {
-	// For apiv1beta1:
-	// Get manifest from either of the two places:
-	// (1) raw manifest in pipeline_spec
-	// (2) pipeline version in resource_references
-	// And the latter takes priority over the former when the manifest is from pipeline_spec.pipeline_id
-	// workflow/pipeline manifest and pipeline id/version will not exist at the same time, guaranteed by the validation phase
-	// For apiv2beta1:
-	// Get pipeline manifest from either of the two places:
-	// (1) raw pipeline manifest in pipeline_spec
-	// (2) pipeline id
-	// 	And the latter takes priority over the former when the pipeline manifest is from pipeline_spec.pipeline_id
-	// TODO(lingqinggan): Add get pipeline from pipeline version.
-	manifestBytes, err := getManifestBytesfromAPIRunInterface(apiRunInterface, r)
+	prepared, err := r.prepareRunToCreate(ctx, apiRunInterface)
 	if err != nil {
 		return nil, err
 	}
-
-	// Generate Run Id and timestamps.
-	uuid, err := r.uuid.NewRandom()
-	if err != nil {
-		return nil, util.NewInternalServerError(err, "Failed to generate run ID.")
+	key := idempotencyKeyFromContext(ctx)
+	if key == "" {
+		return r.submitRun(ctx, prepared)
 	}
-	runId := uuid.String()
-	runAt := r.time.Now().Unix()
+	return r.submitRunWithIdempotencyKey(ctx, prepared, key)
+}
 
-	// Create template for this run. Template can be argo template or IR. New templates may be added in the future.
-	tmpl, err := template.New(manifestBytes)
+// prepareRunToCreate prepares a run for submission without creating its workflow or storing it, the way
+// CreateRun does. The execution spec is validated.
+func (r *ResourceManager) prepareRunToCreate(ctx context.Context, apiRunInterface interface{}) (*preparedRun, error) {
+	prepared, err := r.compileRunToCreate(ctx, apiRunInterface, false)
 	if err != nil {
 		return nil, err
 	}
-	runWorkflowOptions := template.RunWorkflowOptions{
-		RunId: runId,
-		RunAt: runAt,
+	if err := prepared.executionSpec.Validate(false, false); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to validate workflow for (%+v)", prepared.executionSpec.ExecutionName())
 	}
+	return prepared, nil
+}
 
-	// Convert apiRun to model Run.
-	modelRunDetail, err := r.ToModelRunDetail(apiRunInterface, runId, runAt, string(manifestBytes), tmpl.GetTemplateType())
+// compileRunToCreate builds the execution spec of a run the way CreateRun submits it. v1 runs without an
+// experiment land in the default experiment of their namespace, and runs without a pipeline use the default
+// version of their experiment. The overrides and the priority class of the request, the pipeline root and the
+// secret parameters are applied to the execution spec, which is not validated. A dry run only uses an existing
+// default experiment: if the namespace has none yet, the run is compiled in the namespace without an experiment.
+func (r *ResourceManager) compileRunToCreate(ctx context.Context, apiRunInterface interface{}, dryRun bool) (*preparedRun, error) {
+	if apiRun, ok := apiRunInterface.(*apiv1beta1.Run); ok {
+		references, err := r.addNamespaceDefaultExperiment(ctx, apiRun.GetResourceReferences(), dryRun)
+		if err != nil {
+			return nil, err
+		}
+		references, err = r.AddExperimentDefaultVersion(apiRun.GetPipelineSpec(), references)
+		if err != nil {
+			return nil, err
+		}
+		apiRun.ResourceReferences = references
+	}
+	prepared, err := r.compileRun(apiRunInterface)
 	if err != nil {
-		return nil, util.Wrap(err, "Error creating model RunDetail")
+		return nil, err
 	}
-
-	// Convert modelRun into execution spec.
-	executionSpec, err := tmpl.RunWorkflow(&modelRunDetail.Run, runWorkflowOptions)
+	if err := r.canUseServiceAccount(ctx, prepared.modelRunDetail.Namespace, serviceAccountOf(apiRunInterface)); err != nil {
+		return nil, err
+	}
+	if err := applyRunOverrides(ctx, apiRunInterface, prepared); err != nil {
+		return nil, err
+	}
+	if err := r.applyPriorityClass(ctx, apiRunInterface, prepared); err != nil {
+		return nil, err
+	}
+	completionWebhook, err := CompletionWebhookOf(ctx, apiRunInterface)
 	if err != nil {
-		return nil, util.Wrap(err, "failed to generate the ExecutionSpec")
+		return nil, err
+	}
+	prepared.modelRunDetail.CompletionWebhook = completionWebhook
+	if err := applyPipelineRoot(prepared); err != nil {
+		return nil, err
+	}
+	if err := r.resolveSecretParameters(ctx, prepared); err != nil {
+		return nil, err
 	}
+	return prepared, nil
+}
 
-	// Validate executionSpec.
-	err = executionSpec.Validate(false, false)
+// NewRunFromPipelineVersion returns a run of a pipeline version in an experiment, named after the version.
+// The parameters are passed the way the stored manifest of the version expects them: as the parameters of an
+// Argo workflow, or as the runtime config of a pipeline spec, typed as the pipeline declares them.
+func (r *ResourceManager) NewRunFromPipelineVersion(versionId string, experimentId string, params []*apiv1beta1.Parameter) (*apiv1beta1.Run, error) {
+	if experimentId == "" {
+		return nil, util.NewInvalidInputError("A run of pipeline version %v requires an experiment", versionId)
+	}
+	version, err := r.pipelineStore.GetPipelineVersion(versionId)
 	if err != nil {
-		return nil, util.NewInternalServerError(err, "Failed to validate workflow for (%+v)", executionSpec.ExecutionName())
+		if util.IsUserErrorCodeMatch(err, codes.NotFound) {
+			return nil, util.Wrapf(err, "Pipeline version %v doesn't exist or was deleted", versionId)
+		}
+		return nil, util.Wrapf(err, "Failed to get pipeline version %v", versionId)
 	}
-
-	// Create argo workflow CR resource.
-	newExecSpec, err := r.getWorkflowClient(modelRunDetail.Namespace).Create(ctx, executionSpec, v1.CreateOptions{})
+	manifest, err := r.objectStore.GetFile(r.objectStore.GetPipelineKey(versionId))
 	if err != nil {
-		return nil, util.NewInternalServerError(err, "Failed to create a workflow for (%s)", executionSpec.ExecutionName())
+		return nil, util.Wrapf(err, "Failed to get the manifest of pipeline version %v", versionId)
 	}
-
-	// Patch the default value to apiRun.
-	if common.GetBoolConfigWithDefault(common.HasDefaultBucketEnvVar, false) {
-		var err error
-		modelRunDetail.PipelineSpec.Parameters, err = common.PatchPipelineDefaultParameter(modelRunDetail.PipelineSpec.Parameters)
-		if err != nil {
-			return nil, fmt.Errorf("failed to patch default value to pipeline. Error: %v", err)
+	tmpl, err := template.New(manifest)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to parse the manifest of pipeline version %v", versionId)
+	}
+	pipelineSpec := &apiv1beta1.PipelineSpec{}
+	if tmpl.GetTemplateType() == template.V2 {
+		schema := tmpl.ParameterSchema()
+		runtimeParams := map[string]*structpb.Value{}
+		for _, param := range params {
+			value, err := schema.RuntimeValue(param.GetName(), param.GetValue())
+			if err != nil {
+				return nil, err
+			}
+			runtimeParams[param.GetName()] = value
 		}
-	}
-
-	// Update modelRunDetail with information from workflow
-	r.updateModelRunWithNewScheduledWorkflow(modelRunDetail, newExecSpec, tmpl.GetTemplateType())
-
-	// Assign the create at time.
-	modelRunDetail.CreatedAtInSec = runAt
-
-	return r.runStore.CreateRun(modelRunDetail)
-}
-
-func (r *ResourceManager) GetRun(runId string) (*model.RunDetail, error) {
-	return r.runStore.GetRun(runId)
-}
-
-func (r *ResourceManager) ListRuns(filterContext *common.FilterContext,
-	opts *list.Options) (runs []*model.Run, total_size int, nextPageToken string, err error) {
-	return r.runStore.ListRuns(filterContext, opts)
+		pipelineSpec.RuntimeConfig = &apiv1beta1.PipelineSpec_RuntimeConfig{Parameters: runtimeParams}
+	} else {
+		pipelineSpec.Parameters = params
+	}
+	return &apiv1beta1.Run{
+		Name:         version.Name,
+		PipelineSpec: pipelineSpec,
+		ResourceReferences: []*apiv1beta1.ResourceReference{
+			{
+				Key:          &apiv1beta1.ResourceKey{Type: apiv1beta1.ResourceType_EXPERIMENT, Id: experimentId},
+				Relationship: apiv1beta1.Relationship_OWNER,
+			},
+			{
+				Key:          &apiv1beta1.ResourceKey{Type: apiv1beta1.ResourceType_PIPELINE_VERSION, Id: versionId},
+				Relationship: apiv1beta1.Relationship_CREATOR,
+			},
+		},
+	}, nil
 }
 
-func (r *ResourceManager) ArchiveRun(runId string) error {
-	return r.runStore.ArchiveRun(runId)
+// CreateRunFromPipelineVersion creates a run of a pipeline version in an experiment, without the caller
+// carrying the manifest of the version.
+func (r *ResourceManager) CreateRunFromPipelineVersion(ctx context.Context, versionId string, experimentId string, params []*apiv1beta1.Parameter) (*model.RunDetail, error) {
+	apiRun, err := r.NewRunFromPipelineVersion(versionId, experimentId, params)
+	if err != nil {
+		return nil, err
+	}
+	return r.CreateRun(ctx, apiRun)
 }
 
-func (r *ResourceManager) UnarchiveRun(runId string) error {
-	experimentRef, err := r.resourceReferenceStore.GetResourceReference(runId, common.Run, common.Experiment)
+// applyRunOverrides applies the workflow TTL, the container resources, the pod metadata, the scheduling
+// constraints, the environment variables and the retry strategy carried by a CreateRun request to a prepared
+// run, and records all but the TTL on the run.
+func applyRunOverrides(ctx context.Context, apiRunInterface interface{}, prepared *preparedRun) error {
+	ttlSeconds, err := ttlSecondsAfterFinishedOf(ctx, apiRunInterface)
 	if err != nil {
-		return util.Wrap(err, "Failed to retrieve resource reference")
+		return err
 	}
-
-	experiment, err := r.GetExperiment(experimentRef.ReferenceUUID)
+	if ttlSeconds != nil {
+		prepared.executionSpec.SetTTLSecondsAfterFinished(*ttlSeconds)
+	}
+	resources, err := resourceOverridesOf(ctx, apiRunInterface)
 	if err != nil {
-		return errors.Wrap(err, "Failed to retrieve experiment")
+		return err
+	}
+	if len(resources) > 0 {
+		if err := prepared.executionSpec.SetContainerResources(resources); err != nil {
+			return util.Wrap(err, "Failed to override container resources")
+		}
+		resourcesJSON, err := json.Marshal(resources)
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to marshal the container resource overrides")
+		}
+		prepared.modelRunDetail.ResourceOverrides = string(resourcesJSON)
+	}
+	podMetadata, err := podMetadataOf(ctx, apiRunInterface)
+	if err != nil {
+		return err
+	}
+	if podMetadata != nil {
+		for key, value := range podMetadata.Labels {
+			if experimentValue, ok := prepared.experimentLabels[key]; ok && experimentValue != value {
+				return util.NewInvalidInputError("Pod label %q is set to %q by the experiment of the run, so it can't be set to %q", key, experimentValue, value)
+			}
+			prepared.executionSpec.SetPodMetadataLabels(key, value)
+		}
+		for key, value := range podMetadata.Annotations {
+			prepared.executionSpec.SetPodMetadataAnnotations(key, value)
+		}
+		podMetadataJSON, err := json.Marshal(podMetadata)
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to marshal the pod metadata")
+		}
+		prepared.modelRunDetail.PodMetadata = string(podMetadataJSON)
+	}
+	scheduling, err := schedulingOf(ctx, apiRunInterface)
+	if err != nil {
+		return err
+	}
+	if scheduling != nil {
+		prepared.executionSpec.AddScheduling(scheduling.NodeSelector, scheduling.Tolerations)
+		// The run records the scheduling constraints its workflow ends up with, including those of the manifest.
+		effective := runScheduling{}
+		effective.NodeSelector, effective.Tolerations = prepared.executionSpec.Scheduling()
+		schedulingJSON, err := json.Marshal(effective)
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to marshal the scheduling constraints")
+		}
+		prepared.modelRunDetail.Scheduling = string(schedulingJSON)
+	}
+	runEnv, err := RunEnvOf(ctx, apiRunInterface)
+	if err != nil {
+		return err
+	}
+	if runEnv != nil {
+		if err := prepared.executionSpec.AddEnv(runEnv.Env, runEnv.Templates); err != nil {
+			return err
+		}
+		// Secrets are only referenced, so the run never records their values.
+		envJSON, err := json.Marshal(runEnv)
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to marshal the environment variables")
+		}
+		prepared.modelRunDetail.Env = string(envJSON)
+	}
+	retryStrategy, err := RetryStrategyOf(ctx, apiRunInterface)
+	if err != nil {
+		return err
+	}
+	if retryStrategy != nil {
+		if err := prepared.executionSpec.SetRetryStrategy(&retryStrategy.RetryStrategy, retryStrategy.Templates, retryStrategy.Force); err != nil {
+			return err
+		}
+		retryStrategyJSON, err := json.Marshal(retryStrategy)
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to marshal the retry strategy")
+		}
+		prepared.modelRunDetail.RetryStrategy = string(retryStrategyJSON)
+	}
+	return nil
+}
+
+// applyPriorityClass sets the priority class carried by a CreateRun request on every pod of the workflow of a
+// prepared run. The priority class must exist. The run records the priority class its workflow ends up with,
+// including one set by the manifest.
+func (r *ResourceManager) applyPriorityClass(ctx context.Context, apiRunInterface interface{}, prepared *preparedRun) error {
+	name, err := PriorityClassNameOf(ctx, apiRunInterface)
+	if err != nil {
+		return err
+	}
+	if name != "" {
+		_, err = r.k8sCoreClient.PriorityClassClient().Get(ctx, name, v1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return util.NewInvalidInputError("Priority class %v doesn't exist", name)
+		}
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to get priority class %v", name)
+		}
+		prepared.executionSpec.SetPriorityClassName(name)
+	}
+	prepared.modelRunDetail.PriorityClassName = prepared.executionSpec.PriorityClassName()
+	return nil
+}
+
+// applyPipelineRoot validates the pipeline root set by a run, or the one of its namespace, and stores the output
+// artifacts of the run's Argo workflow under it; pipeline spec runs already got it through their runtime config.
+// In multi-user mode, the root must be in a bucket the run's namespace may write to. The run records the
+// normalized root.
+func applyPipelineRoot(prepared *preparedRun) error {
+	pipelineRoot := prepared.modelRunDetail.PipelineSpec.RuntimeConfig.PipelineRoot
+	if pipelineRoot == "" {
+		return nil
+	}
+	root, err := util.ParseArtifactRoot(pipelineRoot)
+	if err != nil {
+		return err
+	}
+	namespace := prepared.modelRunDetail.Namespace
+	if common.IsMultiUserMode() && !common.IsPipelineRootBucketAllowed(namespace, root.Bucket) {
+		return util.NewPermissionDeniedError(errors.New("bucket not allowed"),
+			"Runs in namespace %v are not allowed to write to bucket %v", namespace, root.Bucket)
+	}
+	prepared.modelRunDetail.PipelineSpec.RuntimeConfig.PipelineRoot = root.URI()
+	if prepared.templateType == template.V1 {
+		if root.Scheme != util.ArtifactRootSchemeGCS {
+			root.Endpoint, root.Insecure = common.GetPipelineRootS3Endpoint()
+			root.CredentialsSecret = common.GetPipelineRootCredentialsSecret()
+		}
+		prepared.executionSpec.SetOutputArtifactRoot(root)
+	}
+	return nil
+}
+
+// resolveSecretParameters makes the workflow of a prepared run read the parameters whose values are secret
+// references, of the form secretKeyRef://<secretName>/<key>, from the secrets of the run's namespace. The run
+// keeps the references, never the secret values. Every referenced key must exist, and in multi-user mode the
+// user must be allowed to read the secrets. Every way of submitting a run goes through it, so that no workflow
+// is created with the references themselves as parameter values.
+func (r *ResourceManager) resolveSecretParameters(ctx context.Context, prepared *preparedRun) error {
+	refs, err := prepared.executionSpec.ResolveSecretParameters()
+	if err != nil {
+		return err
+	}
+	namespace := prepared.modelRunDetail.Namespace
+	if err := r.canReadParameterSecrets(ctx, namespace, refs); err != nil {
+		return err
+	}
+	for paramName, ref := range refs {
+		secret, err := r.k8sCoreClient.SecretClient(namespace).Get(ctx, ref.Name, v1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return util.NewInvalidInputError("Secret %v referenced by parameter %v doesn't exist in namespace %v", ref.Name, paramName, namespace)
+		}
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to get secret %v referenced by parameter %v", ref.Name, paramName)
+		}
+		if _, ok := secret.Data[ref.Key]; !ok {
+			return util.NewInvalidInputError("Secret %v referenced by parameter %v has no key %v", ref.Name, paramName, ref.Key)
+		}
+	}
+	return nil
+}
+
+// canUseServiceAccount verifies, in multi-user mode, that the user may run workloads as a service account of
+// the namespace of a run, i.e. is allowed to "use" the serviceaccounts resource with that name. The default
+// pipeline runner service account needs no permission.
+func (r *ResourceManager) canUseServiceAccount(ctx context.Context, namespace string, serviceAccount string) error {
+	defaultServiceAccount := common.GetStringConfigWithDefault(common.DefaultPipelineRunnerServiceAccountFlag, common.DefaultPipelineRunnerServiceAccount)
+	if !common.IsMultiUserMode() || serviceAccount == "" || serviceAccount == defaultServiceAccount {
+		return nil
+	}
+	userIdentity, err := r.AuthenticateRequest(ctx)
+	if err != nil {
+		return util.Wrapf(err, "Failed to authorize the service account %v", serviceAccount)
+	}
+	err = r.IsRequestAuthorized(ctx, userIdentity, &authorizationv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      common.RbacResourceVerbUse,
+		Version:   "v1",
+		Resource:  common.RbacResourceTypeServiceAccounts,
+		Name:      serviceAccount,
+	})
+	if util.IsUserErrorCodeMatch(err, codes.PermissionDenied) {
+		return util.NewPermissionDeniedError(err,
+			"Not authorized to run as service account %v in namespace %v", serviceAccount, namespace)
+	}
+	if err != nil {
+		return util.Wrapf(err, "Failed to authorize the service account %v", serviceAccount)
+	}
+	return nil
+}
+
+// canReadParameterSecrets verifies, in multi-user mode, that the user may read the secrets of a namespace that
+// the parameters of a run refer to.
+func (r *ResourceManager) canReadParameterSecrets(ctx context.Context, namespace string, refs map[string]util.SecretKeyRef) error {
+	if !common.IsMultiUserMode() || len(refs) == 0 {
+		return nil
+	}
+	userIdentity, err := r.AuthenticateRequest(ctx)
+	if err != nil {
+		return util.Wrap(err, "Failed to authorize the secrets referenced by the run parameters")
+	}
+	for paramName, ref := range refs {
+		err = r.IsRequestAuthorized(ctx, userIdentity, &authorizationv1.ResourceAttributes{
+			Namespace: namespace,
+			Verb:      common.RbacResourceVerbGet,
+			Version:   "v1",
+			Resource:  common.RbacResourceTypeSecrets,
+			Name:      ref.Name,
+		})
+		if util.IsUserErrorCodeMatch(err, codes.PermissionDenied) {
+			return util.NewPermissionDeniedError(err,
+				"Not authorized to read secret %v in namespace %v, referenced by parameter %v", ref.Name, namespace, paramName)
+		}
+		if err != nil {
+			return util.Wrapf(err, "Failed to authorize the secret %v", ref.Name)
+		}
+	}
+	return nil
+}
+
+// submitRunWithIdempotencyKey submits a prepared run unless a run was already created for the key. Keys are
+// scoped to the run's namespace in multi-user mode. Requests with the same key are serialized within this
+// server, and the key's database record makes sure only one of them submits a workflow across servers.
+func (r *ResourceManager) submitRunWithIdempotencyKey(ctx context.Context, prepared *preparedRun, key string) (*model.RunDetail, error) {
+	namespace := ""
+	if common.IsMultiUserMode() {
+		namespace = prepared.modelRunDetail.Namespace
+	}
+	unlock := idempotencyKeyLocks.lock(namespace + "/" + key)
+	defer unlock()
+
+	runId := prepared.modelRunDetail.UUID
+	record, claimed, err := r.idempotencyKeyStore.ClaimIdempotencyKey(namespace, key, runId, common.GetIdempotencyKeyTTL())
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to create a run with an idempotency key")
+	}
+	if !claimed {
+		runDetail, err := r.runStore.GetRun(record.RunUUID)
+		if util.IsUserErrorCodeMatch(err, codes.NotFound) {
+			return nil, util.NewBadRequestError(err,
+				"A run with idempotency key %q is being created by another request. Please retry later", key)
+		}
+		if err != nil {
+			return nil, util.Wrapf(err, "Failed to get the run created with idempotency key %q", key)
+		}
+		return runDetail, nil
+	}
+	runDetail, err := r.submitRun(ctx, prepared)
+	if err != nil {
+		// Allow the request to be retried with the same key.
+		if releaseErr := r.idempotencyKeyStore.ReleaseIdempotencyKey(namespace, key, runId); releaseErr != nil {
+			glog.Errorf("Failed to release idempotency key %q: %v", key, releaseErr)
+		}
+		return nil, err
+	}
+	return runDetail, nil
+}
+
+// preparedRun holds a run that has been converted and validated but not yet submitted.
+type preparedRun struct {
+	modelRunDetail *model.RunDetail
+	executionSpec  util.ExecutionSpec
+	templateType   template.TemplateType
+	// Labels the workflow and its pods inherit from the experiment of the run.
+	experimentLabels map[string]string
+}
+
+// prepareRun resolves the manifest of a run and builds and validates its execution spec, without
+// creating any workflow or database row.
+func (r *ResourceManager) prepareRun(apiRunInterface interface{}) (*preparedRun, error) {
+	prepared, err := r.compileRun(apiRunInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate executionSpec.
+	err = prepared.executionSpec.Validate(false, false)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to validate workflow for (%+v)", prepared.executionSpec.ExecutionName())
+	}
+	return prepared, nil
+}
+
+// compileRun resolves the manifest of a run and builds its execution spec with the run's
+// parameters substituted. The execution spec is not validated.
+func (r *ResourceManager) compileRun(apiRunInterface interface{}) (*preparedRun, error) {
+	// For apiv1beta1:
+	// Get manifest from either of the two places:
+	// (1) raw manifest in pipeline_spec
+	// (2) pipeline version in resource_references
+	// And the latter takes priority over the former when the manifest is from pipeline_spec.pipeline_id
+	// workflow/pipeline manifest and pipeline id/version will not exist at the same time, guaranteed by the validation phase
+	// For apiv2beta1:
+	// Get pipeline manifest from either of the two places:
+	// (1) raw pipeline manifest in pipeline_spec
+	// (2) pipeline id
+	// 	And the latter takes priority over the former when the pipeline manifest is from pipeline_spec.pipeline_id
+	// TODO(lingqinggan): Add get pipeline from pipeline version.
+	manifestBytes, err := getManifestBytesfromAPIRunInterface(apiRunInterface, r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate Run Id and timestamps.
+	uuid, err := r.uuid.NewRandom()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to generate run ID.")
+	}
+	runId := uuid.String()
+	runAt := r.time.Now().Unix()
+
+	// Create template for this run. Template can be argo template or IR. New templates may be added in the future.
+	tmpl, err := template.New(manifestBytes)
+	if err != nil {
+		return nil, err
+	}
+	runWorkflowOptions := template.RunWorkflowOptions{
+		RunId: runId,
+		RunAt: runAt,
+	}
+
+	// Convert apiRun to model Run.
+	modelRunDetail, err := r.ToModelRunDetail(apiRunInterface, runId, runAt, string(manifestBytes), tmpl.GetTemplateType())
+	if err != nil {
+		return nil, util.Wrap(err, "Error creating model RunDetail")
+	}
+	// Runs that don't set a pipeline root write to the bucket of their namespace, if it has one.
+	if modelRunDetail.PipelineSpec.RuntimeConfig.PipelineRoot == "" {
+		modelRunDetail.PipelineSpec.RuntimeConfig.PipelineRoot = common.GetNamespacePipelineRoot(modelRunDetail.Namespace)
+	}
+
+	if err := r.applyExperimentDefaultParameters(tmpl, modelRunDetail); err != nil {
+		return nil, err
+	}
+	if err := r.expandRunParameters(tmpl, modelRunDetail, runAt); err != nil {
+		return nil, err
+	}
+
+	// Pipelines that declare parameter types get the supplied values checked against them.
+	if err := tmpl.ParameterSchema().ValidateRunParameters(&modelRunDetail.Run); err != nil {
+		return nil, err
+	}
+
+	// Convert modelRun into execution spec.
+	executionSpec, err := tmpl.RunWorkflow(&modelRunDetail.Run, runWorkflowOptions)
+	if err != nil {
+		return nil, util.Wrap(err, "failed to generate the ExecutionSpec")
+	}
+	experimentLabels, err := r.applyExperimentToWorkflow(executionSpec, modelRunDetail)
+	if err != nil {
+		return nil, err
+	}
+
+	// Assign the create at time.
+	modelRunDetail.CreatedAtInSec = runAt
+
+	return &preparedRun{
+		modelRunDetail:   modelRunDetail,
+		executionSpec:    executionSpec,
+		templateType:     tmpl.GetTemplateType(),
+		experimentLabels: experimentLabels,
+	}, nil
+}
+
+// applyExperimentDefaultParameters adds the parameter presets of the run's experiment to the parameters of a
+// v1 run, so the run stores the parameters it ran with. Parameters set by the run win, and presets for
+// parameters the workflow doesn't declare are ignored.
+func (r *ResourceManager) applyExperimentDefaultParameters(tmpl template.Template, modelRunDetail *model.RunDetail) error {
+	if tmpl.GetTemplateType() != template.V1 || modelRunDetail.ExperimentUUID == "" {
+		return nil
+	}
+	experiment, err := r.experimentStore.GetExperiment(modelRunDetail.ExperimentUUID)
+	if err != nil {
+		return util.Wrap(err, "Failed to get the default parameters of the experiment")
+	}
+	if experiment.DefaultParameters == "" {
+		return nil
+	}
+	presets, err := util.UnmarshalParameters(util.ArgoWorkflow, experiment.DefaultParameters)
+	if err != nil {
+		return util.Wrapf(err, "Failed to read the default parameters of experiment %v", experiment.UUID)
+	}
+	declaredJSON, err := tmpl.ParametersJSON()
+	if err != nil {
+		return util.Wrap(err, "Failed to read the workflow parameters")
+	}
+	declared, err := util.UnmarshalParameters(util.ArgoWorkflow, declaredJSON)
+	if err != nil {
+		return err
+	}
+	declaredNames := make(map[string]bool, len(declared))
+	for _, param := range declared {
+		declaredNames[param.Name] = true
+	}
+	params, err := util.UnmarshalParameters(util.ArgoWorkflow, modelRunDetail.Parameters)
+	if err != nil {
+		return err
+	}
+	setNames := make(map[string]bool, len(params))
+	for _, param := range params {
+		setNames[param.Name] = true
+	}
+	added := false
+	for _, preset := range presets {
+		if !declaredNames[preset.Name] || setNames[preset.Name] {
+			continue
+		}
+		params = append(params, preset)
+		added = true
+	}
+	if !added {
+		return nil
+	}
+	modelRunDetail.Parameters, err = util.MarshalParameters(util.ArgoWorkflow, params)
+	return err
+}
+
+// expandRunParameters expands the {run_name}, {run_id}, {experiment_name} and {timestamp} tokens in the
+// parameter values of a run. The string defaults of the pipeline are expanded too, and the run sets the
+// parameters whose default holds a token to the expanded value.
+func (r *ResourceManager) expandRunParameters(tmpl template.Template, modelRunDetail *model.RunDetail, runAt int64) error {
+	var tokens map[string]string
+	expand := func(name string, value string) (string, bool, error) {
+		if !parameterTokenPattern.MatchString(value) {
+			return value, false, nil
+		}
+		if tokens == nil {
+			experimentName := ""
+			if modelRunDetail.ExperimentUUID != "" {
+				experiment, err := r.experimentStore.GetExperiment(modelRunDetail.ExperimentUUID)
+				if err != nil {
+					return "", false, util.Wrap(err, "Failed to get the experiment to expand the run parameters")
+				}
+				experimentName = experiment.Name
+			}
+			tokens = map[string]string{
+				"run_name":        modelRunDetail.DisplayName,
+				"run_id":          modelRunDetail.UUID,
+				"experiment_name": experimentName,
+				"timestamp":       time.Unix(runAt, 0).UTC().Format(parameterTimestampLayout),
+			}
+		}
+		expanded, err := expandParameterTokens(name, value, tokens)
+		return expanded, true, err
+	}
+	defaults := tmpl.StringParameterDefaults()
+	defaultNames := make([]string, 0, len(defaults))
+	for name := range defaults {
+		defaultNames = append(defaultNames, name)
+	}
+	sort.Strings(defaultNames)
+
+	if tmpl.GetTemplateType() == template.V1 {
+		var params util.SpecParameters
+		if modelRunDetail.Parameters != "" {
+			var err error
+			params, err = util.UnmarshalParameters(util.ArgoWorkflow, modelRunDetail.Parameters)
+			if err != nil {
+				return err
+			}
+		}
+		changed := false
+		setNames := make(map[string]bool, len(params))
+		for i := range params {
+			setNames[params[i].Name] = true
+			if params[i].Value == nil {
+				continue
+			}
+			value, ok, err := expand(params[i].Name, *params[i].Value)
+			if err != nil {
+				return err
+			}
+			if ok {
+				params[i].Value = &value
+				changed = true
+			}
+		}
+		for _, name := range defaultNames {
+			if setNames[name] {
+				continue
+			}
+			value, ok, err := expand(name, defaults[name])
+			if err != nil {
+				return err
+			}
+			if ok {
+				params = append(params, util.SpecParameter{Name: name, Value: &value})
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+		var err error
+		modelRunDetail.Parameters, err = util.MarshalParameters(util.ArgoWorkflow, params)
+		return err
+	}
+
+	runtimeParams := map[string]json.RawMessage{}
+	if modelRunDetail.RuntimeConfig.Parameters != "" {
+		if err := json.Unmarshal([]byte(modelRunDetail.RuntimeConfig.Parameters), &runtimeParams); err != nil {
+			return util.NewInvalidInputError("Failed to parse the run runtime parameters: %v", err)
+		}
+	}
+	changed := false
+	setExpanded := func(name string, value string) error {
+		expanded, ok, err := expand(name, value)
+		if err != nil || !ok {
+			return err
+		}
+		runtimeParams[name], err = json.Marshal(expanded)
+		changed = true
+		return err
+	}
+	for name, raw := range runtimeParams {
+		var value string
+		// Only string values can hold tokens.
+		if json.Unmarshal(raw, &value) != nil {
+			continue
+		}
+		if err := setExpanded(name, value); err != nil {
+			return err
+		}
+	}
+	for _, name := range defaultNames {
+		if _, ok := runtimeParams[name]; ok {
+			continue
+		}
+		if err := setExpanded(name, defaults[name]); err != nil {
+			return err
+		}
+	}
+	if !changed {
+		return nil
+	}
+	runtimeParamsJSON, err := json.Marshal(runtimeParams)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to marshal the run runtime parameters")
+	}
+	modelRunDetail.RuntimeConfig.Parameters = string(runtimeParamsJSON)
+	return nil
+}
+
+// DryRunResult is the outcome of compiling a run without submitting it.
+type DryRunResult struct {
+	// WorkflowManifest is the rendered execution spec in YAML.
+	WorkflowManifest string
+	// Diagnostics lists the problems found when validating the rendered execution spec.
+	Diagnostics []string
+}
+
+// DryRunRun prepares a run the same way CreateRun does, including parameter substitution, the default
+// experiment, the overrides of the request and the secret parameters, but neither submits the workflow nor
+// stores a run. Errors in the run itself, such as unknown parameters, are returned as errors, while validation
+// problems of the rendered execution spec are returned as diagnostics. The default experiment of the namespace
+// is used if it exists, but is never created. Callers are responsible for authorizing the request like CreateRun.
+func (r *ResourceManager) DryRunRun(ctx context.Context, apiRunInterface interface{}) (*DryRunResult, error) {
+	prepared, err := r.compileRunToCreate(ctx, apiRunInterface, true)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to compile the run")
+	}
+	manifest, err := yaml.JSONToYAML([]byte(prepared.executionSpec.ToStringForStore()))
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to render the workflow for (%+v)", prepared.executionSpec.ExecutionName())
+	}
+	result := &DryRunResult{WorkflowManifest: string(manifest)}
+	if err := prepared.executionSpec.Validate(false, false); err != nil {
+		result.Diagnostics = append(result.Diagnostics, err.Error())
+	}
+	return result, nil
+}
+
+// submitRun creates the workflow of a prepared run and stores the run. If storing the run fails, the workflow
+// is deleted so that it doesn't run without a run.
+func (r *ResourceManager) submitRun(ctx context.Context, prepared *preparedRun) (*model.RunDetail, error) {
+	modelRunDetail := prepared.modelRunDetail
+	executionSpec := prepared.executionSpec
+
+	unlock, err := r.checkNamespaceRunQuota(modelRunDetail.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Create argo workflow CR resource.
+	newExecSpec, err := r.getWorkflowClient(modelRunDetail.Namespace).Create(ctx, executionSpec, v1.CreateOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create a workflow for (%s)", executionSpec.ExecutionName())
+	}
+	workflowName := newExecSpec.ExecutionName()
+
+	// Patch the default value to apiRun.
+	if common.GetBoolConfigWithDefault(common.HasDefaultBucketEnvVar, false) {
+		var err error
+		modelRunDetail.PipelineSpec.Parameters, err = common.PatchPipelineDefaultParameter(modelRunDetail.PipelineSpec.Parameters)
+		if err != nil {
+			r.deleteOrphanedWorkflow(modelRunDetail.Namespace, workflowName)
+			return nil, fmt.Errorf("failed to patch default value to pipeline. Error: %v", err)
+		}
+	}
+
+	// Update modelRunDetail with information from workflow
+	r.updateModelRunWithNewScheduledWorkflow(modelRunDetail, newExecSpec, prepared.templateType)
+	// The identity is only known when the request was authenticated, e.g. in multi-user mode.
+	modelRunDetail.CreatedBy, _ = r.AuthenticateRequest(ctx)
+
+	runDetail, err := r.runStore.CreateRun(modelRunDetail)
+	if err != nil {
+		r.deleteOrphanedWorkflow(modelRunDetail.Namespace, workflowName)
+		return nil, err
+	}
+	return runDetail, nil
+}
+
+// deleteOrphanedWorkflow deletes a workflow whose run couldn't be stored, e.g. because the database went away
+// while the API server was shutting down. The request may have been cancelled by then, so the deletion doesn't
+// use its context. Failures are only logged since the original error is what gets reported.
+func (r *ResourceManager) deleteOrphanedWorkflow(namespace string, workflowName string) {
+	err := r.getWorkflowClient(namespace).Delete(context.Background(), workflowName, v1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		glog.Errorf("Failed to delete workflow %v, whose run couldn't be stored. Error: %v", workflowName, err.Error())
+	}
+}
+
+// checkNamespaceRunQuota returns a ResourceExhausted error if the namespace already has as many active
+// runs as it is allowed to, as configured by MAX_ACTIVE_RUNS_PER_NAMESPACE and its per-namespace overrides.
+// Otherwise it holds the quota lock of the namespace until the returned function is called, so that
+// concurrent creations can't exceed the quota between the check and the insert of the run.
+func (r *ResourceManager) checkNamespaceRunQuota(namespace string) (func(), error) {
+	limit := common.GetMaxActiveRunsPerNamespace(namespace)
+	if limit <= 0 {
+		return func() {}, nil
+	}
+	unlock := runQuotaLocks.lock(namespace)
+	count, err := r.runStore.CountRuns(namespace, model.ActiveRunStates)
+	if err != nil {
+		unlock()
+		return nil, util.Wrapf(err, "Failed to check the run quota of namespace %v", namespace)
+	}
+	if count >= limit {
+		unlock()
+		return nil, util.NewResourceExhaustedError(errors.New("run quota exceeded"),
+			"Namespace %q already has %d active runs, which is its limit", namespace, count)
+	}
+	return unlock, nil
+}
+
+// BulkCreateRuns creates several runs at once. Every run is prepared the way CreateRun prepares it, and all of
+// them are prepared before any workflow is created. By default the operation is atomic: if any run fails, the
+// workflows and run rows created so far are removed and the error is returned. When allowPartialFailure is set,
+// every run is attempted independently, and the result and error slices are aligned with apiRuns.
+func (r *ResourceManager) BulkCreateRuns(ctx context.Context, apiRuns []interface{}, allowPartialFailure bool) (
+	[]*model.RunDetail, []error, error) {
+	if len(apiRuns) == 0 {
+		return nil, nil, util.NewInvalidInputError("Bulk create runs requires at least one run")
+	}
+	runDetails := make([]*model.RunDetail, len(apiRuns))
+	runErrors := make([]error, len(apiRuns))
+
+	// Prepare every run up front so that a bad run doesn't leave workflows behind.
+	prepared := make([]*preparedRun, len(apiRuns))
+	for i, apiRun := range apiRuns {
+		p, err := r.prepareRunToCreate(ctx, apiRun)
+		if err != nil {
+			err = util.Wrapf(err, "Failed to validate run at index %v", i)
+			if !allowPartialFailure {
+				return nil, nil, err
+			}
+			runErrors[i] = err
+			continue
+		}
+		prepared[i] = p
+	}
+
+	var submitted []*model.RunDetail
+	for i, p := range prepared {
+		if p == nil {
+			continue
+		}
+		// A run that fails to be submitted leaves neither a workflow nor a row behind, so only the runs
+		// submitted before it are rolled back.
+		runDetail, err := r.submitRun(ctx, p)
+		if err != nil {
+			err = util.Wrapf(err, "Failed to create run at index %v", i)
+			if !allowPartialFailure {
+				r.rollbackSubmittedRuns(ctx, submitted)
+				return nil, nil, err
+			}
+			runErrors[i] = err
+			continue
+		}
+		submitted = append(submitted, runDetail)
+		runDetails[i] = runDetail
+	}
+	if !allowPartialFailure {
+		return runDetails, nil, nil
+	}
+	return runDetails, runErrors, nil
+}
+
+// rollbackSubmittedRuns removes the workflows and run rows created by an unsuccessful
+// BulkCreateRuns call. Failures are only logged since the original error is what gets reported.
+func (r *ResourceManager) rollbackSubmittedRuns(ctx context.Context, submitted []*model.RunDetail) {
+	for _, runDetail := range submitted {
+		err := r.getWorkflowClient(runDetail.Namespace).Delete(ctx, runDetail.Name, v1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			glog.Errorf("Failed to delete workflow %v while rolling back bulk run creation. Error: %v", runDetail.Name, err.Error())
+		}
+		err = r.runStore.DeleteRun(runDetail.UUID)
+		if err != nil && !util.IsNotFound(err) {
+			glog.Errorf("Failed to delete run %v while rolling back bulk run creation. Error: %v", runDetail.UUID, err.Error())
+		}
+	}
+}
+
+func (r *ResourceManager) GetRun(runId string) (*model.RunDetail, error) {
+	return r.runStore.GetRun(runId)
+}
+
+// GetRunByWorkflowName returns the run of the workflow with the given name in a namespace, so that callers
+// that only know the workflow don't need to list the runs. It returns a NotFound error if no run has such a
+// workflow, e.g. because the workflow wasn't created by KFP.
+func (r *ResourceManager) GetRunByWorkflowName(ctx context.Context, namespace string, workflowName string) (*model.RunDetail, error) {
+	runDetail, err := r.runStore.GetRunByWorkflowName(namespace, workflowName)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to get the run of workflow %v/%v", namespace, workflowName)
+	}
+	return runDetail, nil
+}
+
+// GetRunByWorkflowUID returns the run of the workflow with the given UID, or a NotFound error if no run has it.
+func (r *ResourceManager) GetRunByWorkflowUID(ctx context.Context, workflowUID string) (*model.RunDetail, error) {
+	runDetail, err := r.runStore.GetRunByWorkflowUID(workflowUID)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to get the run of workflow with UID %v", workflowUID)
+	}
+	return runDetail, nil
+}
+
+// GetRunStatusHistory returns the states a run went through, oldest first, with the times they were reported.
+// Repeated reports of the same state are recorded once.
+func (r *ResourceManager) GetRunStatusHistory(ctx context.Context, runId string) ([]*model.RunStatusEvent, error) {
+	if _, err := r.runStore.GetRun(runId); err != nil {
+		return nil, util.Wrapf(err, "Failed to get the status history of run %v", runId)
+	}
+	return r.runStore.ListRunStatusEvents(runId)
+}
+
+func (r *ResourceManager) ListRuns(filterContext *common.FilterContext,
+	opts *list.Options) (runs []*model.Run, total_size int, nextPageToken string, err error) {
+	return r.runStore.ListRuns(filterContext, opts)
+}
+
+// maxRecentRuns is the most runs ListRecentRuns lists, the same as the largest page of a list.
+const maxRecentRuns = 200
+
+// RecentRunsOptions tells ListRecentRunsWithOptions which runs to list.
+type RecentRunsOptions struct {
+	// IncludeArchived lists archived runs too.
+	IncludeArchived bool
+}
+
+// ListRecentRuns lists the limit most recently created runs of a namespace across all its experiments, newest
+// first, leaving out archived runs.
+func (r *ResourceManager) ListRecentRuns(ctx context.Context, namespace string, limit int) ([]*model.Run, error) {
+	return r.ListRecentRunsWithOptions(ctx, namespace, limit, RecentRunsOptions{})
+}
+
+// ListRecentRunsWithOptions is ListRecentRuns with options. The limit is capped like page sizes are. In
+// multi-user mode the caller must be an admin or be allowed to list the runs of the namespace. In single-user
+// mode the namespace is ignored and the runs of all namespaces are listed.
+func (r *ResourceManager) ListRecentRunsWithOptions(ctx context.Context, namespace string, limit int, opts RecentRunsOptions) ([]*model.Run, error) {
+	if limit <= 0 {
+		return nil, util.NewInvalidInputError("Listing recent runs requires a limit of at least one run, got %v", limit)
+	}
+	if limit > maxRecentRuns {
+		limit = maxRecentRuns
+	}
+	if !common.IsMultiUserMode() {
+		namespace = ""
+	} else {
+		if namespace == "" {
+			return nil, util.NewInvalidInputError("Listing recent runs requires a namespace in multi-user mode")
+		}
+		userIdentity, err := r.AuthenticateRequest(ctx)
+		if err != nil {
+			return nil, util.Wrapf(err, "Failed to list the recent runs of namespace %v", namespace)
+		}
+		if !common.IsImpersonationAdmin(userIdentity) {
+			err = r.IsRequestAuthorized(ctx, userIdentity, &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      common.RbacResourceVerbList,
+				Group:     common.RbacPipelinesGroup,
+				Version:   common.RbacPipelinesVersion,
+				Resource:  common.RbacResourceTypeRuns,
+			})
+			if err != nil {
+				return nil, util.Wrapf(err, "Failed to list the recent runs of namespace %v", namespace)
+			}
+		}
+	}
+	runs, err := r.runStore.ListRecentRuns(namespace, opts.IncludeArchived, limit)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to list the recent runs of namespace %v", namespace)
+	}
+	return runs, nil
+}
+
+// ResourceCounts are the numbers of pipelines, experiments, runs and jobs of a namespace.
+type ResourceCounts struct {
+	Pipelines   int `json:"pipelines"`
+	Experiments int `json:"experiments"`
+	Runs        int `json:"runs"`
+	Jobs        int `json:"jobs"`
+}
+
+// CountResources counts, concurrently, the ready pipelines, the experiments and runs that are neither archived
+// nor soft deleted, and the jobs of a namespace. In multi-user mode the caller must be an admin or be allowed to
+// list each type of resource in the namespace. In single-user mode the namespace is ignored and the resources
+// of all namespaces are counted.
+func (r *ResourceManager) CountResources(ctx context.Context, namespace string) (*ResourceCounts, error) {
+	if !common.IsMultiUserMode() {
+		namespace = ""
+	} else {
+		if namespace == "" {
+			return nil, util.NewInvalidInputError("Counting resources requires a namespace in multi-user mode")
+		}
+		userIdentity, err := r.AuthenticateRequest(ctx)
+		if err != nil {
+			return nil, util.Wrapf(err, "Failed to count the resources of namespace %v", namespace)
+		}
+		if !common.IsImpersonationAdmin(userIdentity) {
+			for _, resourceType := range []string{common.RbacResourceTypePipelines, common.RbacResourceTypeExperiments,
+				common.RbacResourceTypeRuns, common.RbacResourceTypeJobs} {
+				err = r.IsRequestAuthorized(ctx, userIdentity, &authorizationv1.ResourceAttributes{
+					Namespace: namespace,
+					Verb:      common.RbacResourceVerbList,
+					Group:     common.RbacPipelinesGroup,
+					Version:   common.RbacPipelinesVersion,
+					Resource:  resourceType,
+				})
+				if err != nil {
+					return nil, util.Wrapf(err, "Failed to count the resources of namespace %v", namespace)
+				}
+			}
+		}
+	}
+	counts := &ResourceCounts{}
+	counters := []struct {
+		count   *int
+		counter func(namespace string) (int, error)
+	}{
+		{&counts.Pipelines, r.pipelineStore.CountPipelines},
+		{&counts.Experiments, r.experimentStore.CountExperiments},
+		{&counts.Runs, r.runStore.CountAvailableRuns},
+		{&counts.Jobs, r.jobStore.CountJobs},
+	}
+	errs := make([]error, len(counters))
+	var wg sync.WaitGroup
+	for i := range counters {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			*counters[i].count, errs[i] = counters[i].counter(namespace)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, util.Wrapf(err, "Failed to count the resources of namespace %v", namespace)
+		}
+	}
+	return counts, nil
+}
+
+// ListRunsForPipelineVersion lists the runs created from a pipeline version. In multi-user mode, only the runs
+// in namespaces the caller is allowed to list runs in are listed and counted.
+func (r *ResourceManager) ListRunsForPipelineVersion(ctx context.Context, versionId string,
+	opts *list.Options) (runs []*model.Run, total_size int, nextPageToken string, err error) {
+	if _, err := r.pipelineStore.GetPipelineVersion(versionId); err != nil {
+		return nil, 0, "", util.Wrapf(err, "Failed to list the runs of pipeline version %v", versionId)
+	}
+	filterContext := &common.FilterContext{
+		ReferenceKey: &common.ReferenceKey{Type: common.PipelineVersion, ID: versionId}}
+	if common.IsMultiUserMode() && !common.IsMultiUserSharedReadMode() {
+		filterContext.Namespaces, err = r.allowedPipelineVersionRunNamespaces(ctx, versionId)
+		if err != nil {
+			return nil, 0, "", util.Wrapf(err, "Failed to list the runs of pipeline version %v", versionId)
+		}
+	}
+	runs, total_size, nextPageToken, err = r.runStore.ListRuns(filterContext, opts)
+	if err != nil {
+		return nil, 0, "", util.Wrapf(err, "Failed to list the runs of pipeline version %v", versionId)
+	}
+	return runs, total_size, nextPageToken, nil
+}
+
+// allowedPipelineVersionRunNamespaces returns the namespaces that have runs of a pipeline version and that the
+// caller is allowed to list runs in.
+func (r *ResourceManager) allowedPipelineVersionRunNamespaces(ctx context.Context, versionId string) ([]string, error) {
+	runUsage, err := r.runStore.GetPipelineVersionRunUsage(versionId, 0)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := []string{}
+	for namespace := range runUsage {
+		allowed, err := r.canListInNamespace(ctx, namespace, common.RbacResourceTypeRuns)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// pipelineVersionUsageWindow is how far back the recent runs of the usage stats of pipeline versions go.
+const pipelineVersionUsageWindow = 30 * 24 * time.Hour
+
+// PipelineVersionUsageStats sums up how much a pipeline version is used.
+type PipelineVersionUsageStats struct {
+	TotalRuns int `json:"total_runs"`
+	// RecentRuns are the runs created in the last 30 days.
+	RecentRuns int `json:"recent_runs"`
+	Jobs       int `json:"jobs"`
+	// LastUsedAtInSec is when the latest run of the version was created, or 0 if it was never run.
+	LastUsedAtInSec int64 `json:"last_used_at_in_sec"`
+}
+
+// GetPipelineVersionUsageStats sums up the runs, archived or not, and the jobs created from a pipeline version,
+// e.g. before deprecating it. In multi-user mode, the runs and jobs of the namespaces the caller isn't allowed
+// to list them in are left out.
+func (r *ResourceManager) GetPipelineVersionUsageStats(ctx context.Context, versionId string) (*PipelineVersionUsageStats, error) {
+	if _, err := r.pipelineStore.GetPipelineVersion(versionId); err != nil {
+		return nil, util.Wrapf(err, "Failed to get the usage stats of pipeline version %v", versionId)
+	}
+	since := r.time.Now().Add(-pipelineVersionUsageWindow).Unix()
+	runUsage, err := r.runStore.GetPipelineVersionRunUsage(versionId, since)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to get the usage stats of pipeline version %v", versionId)
+	}
+	jobCounts, err := r.jobStore.CountPipelineVersionJobs(versionId)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to get the usage stats of pipeline version %v", versionId)
+	}
+	checkAccess := common.IsMultiUserMode() && !common.IsMultiUserSharedReadMode()
+	stats := &PipelineVersionUsageStats{}
+	for namespace, usage := range runUsage {
+		if checkAccess {
+			allowed, err := r.canListInNamespace(ctx, namespace, common.RbacResourceTypeRuns)
+			if err != nil {
+				return nil, util.Wrapf(err, "Failed to get the usage stats of pipeline version %v", versionId)
+			}
+			if !allowed {
+				continue
+			}
+		}
+		stats.TotalRuns += usage.Runs
+		stats.RecentRuns += usage.RecentRuns
+		if usage.LastRunCreatedAtInSec > stats.LastUsedAtInSec {
+			stats.LastUsedAtInSec = usage.LastRunCreatedAtInSec
+		}
+	}
+	for namespace, count := range jobCounts {
+		if checkAccess {
+			allowed, err := r.canListInNamespace(ctx, namespace, common.RbacResourceTypeJobs)
+			if err != nil {
+				return nil, util.Wrapf(err, "Failed to get the usage stats of pipeline version %v", versionId)
+			}
+			if !allowed {
+				continue
+			}
+		}
+		stats.Jobs += count
+	}
+	return stats, nil
+}
+
+// canListInNamespace returns whether the user of a request is allowed to list a type of resources, e.g. runs,
+// of a namespace.
+func (r *ResourceManager) canListInNamespace(ctx context.Context, namespace string, resource string) (bool, error) {
+	userIdentity, err := r.AuthenticateRequest(ctx)
+	if err != nil {
+		return false, err
+	}
+	err = r.IsRequestAuthorized(ctx, userIdentity, &authorizationv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      common.RbacResourceVerbList,
+		Group:     common.RbacPipelinesGroup,
+		Version:   common.RbacPipelinesVersion,
+		Resource:  resource,
+	})
+	if util.IsUserErrorCodeMatch(err, codes.PermissionDenied) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ArchiveRun hides a run from run lists without deleting it. The run's workflow is left alone, so it is
+// still garbage collected once its TTL expires. Archiving an archived run is a no-op.
+func (r *ResourceManager) ArchiveRun(ctx context.Context, runId string) error {
+	runDetail, err := r.checkRunExist(runId)
+	if err != nil {
+		return util.Wrap(err, "Archive run failed")
+	}
+	if runDetail.StorageState == apiv1beta1.Run_STORAGESTATE_ARCHIVED.String() {
+		return nil
+	}
+	return r.runStore.ArchiveRun(runId)
+}
+
+// UpdateRunName changes the display name of a run, which ListRuns filters on with the "name" key. The name of
+// the workflow of the run stays as it was generated.
+func (r *ResourceManager) UpdateRunName(ctx context.Context, runId string, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return util.NewInvalidInputError("The run name is empty. Please specify a valid name.")
+	}
+	if err := r.runStore.UpdateRunDisplayName(runId, name); err != nil {
+		return util.Wrapf(err, "Failed to rename run %v", runId)
+	}
+	return nil
+}
+
+// UnarchiveRun restores an archived run. The run's experiment must not be archived. Unarchiving an
+// available run is a no-op.
+func (r *ResourceManager) UnarchiveRun(ctx context.Context, runId string) error {
+	experimentRef, err := r.resourceReferenceStore.GetResourceReference(runId, common.Run, common.Experiment)
+	if err != nil {
+		return util.Wrap(err, "Failed to retrieve resource reference")
+	}
+
+	experiment, err := r.GetExperiment(experimentRef.ReferenceUUID)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve experiment")
 	}
 
 	if experiment.StorageState == "ARCHIVED" {
 		return util.NewFailedPreconditionError(errors.New("Unarchive the experiment first to allow the run to be restored"),
 			fmt.Sprintf("Unarchive experiment with name `%s` first to allow run `%s` to be restored", experimentRef.ReferenceName, runId))
 	}
+	runDetail, err := r.checkRunExist(runId)
+	if err != nil {
+		return util.Wrap(err, "Unarchive run failed")
+	}
+	if runDetail.StorageState == apiv1beta1.Run_STORAGESTATE_AVAILABLE.String() {
+		return nil
+	}
 	return r.runStore.UnarchiveRun(runId)
 }
 
@@ -465,6 +2032,29 @@ func (r *ResourceManager) DeleteRun(ctx context.Context, runID string) error {
 	return nil
 }
 
+// DeleteRuns deletes several runs along with their workflows. A run that fails to be deleted doesn't stop
+// the others from being deleted, and runs that don't exist are skipped. The IDs of the deleted runs are
+// returned, along with the errors of the runs that couldn't be deleted, keyed by run ID.
+func (r *ResourceManager) DeleteRuns(ctx context.Context, runIds []string) ([]string, map[string]error, error) {
+	if maxBatchSize := common.GetMaxDeleteRunsBatchSize(); len(runIds) > maxBatchSize {
+		return nil, nil, util.NewInvalidInputError("Cannot delete %d runs at once, the maximum is %d", len(runIds), maxBatchSize)
+	}
+	var deleted []string
+	failures := make(map[string]error)
+	for _, runId := range runIds {
+		err := r.DeleteRun(ctx, runId)
+		if util.IsUserErrorCodeMatch(err, codes.NotFound) {
+			continue
+		}
+		if err != nil {
+			failures[runId] = err
+			continue
+		}
+		deleted = append(deleted, runId)
+	}
+	return deleted, failures, nil
+}
+
 func (r *ResourceManager) CreateTask(ctx context.Context, apiTask *apiv1beta1.Task) (*model.Task, error) {
 	uuid, err := r.uuid.NewRandom()
 	if err != nil {
@@ -499,6 +2089,7 @@ func TerminateWorkflow(ctx context.Context, wfClient util.ExecutionInterface, na
 	patchObj := map[string]interface{}{
 		"spec": map[string]interface{}{
 			"activeDeadlineSeconds": 0,
+			"shutdown":              workflowapi.ShutdownStrategyTerminate,
 		},
 	}
 
@@ -516,18 +2107,26 @@ func TerminateWorkflow(ctx context.Context, wfClient util.ExecutionInterface, na
 	return err
 }
 
+// TerminateRun stops the workflow of an active run, keeping the run, its metrics and its logs. The run is
+// marked as terminating with the identity of the caller, and as terminated once the workflow finishes.
 func (r *ResourceManager) TerminateRun(ctx context.Context, runId string) error {
 	runDetail, err := r.checkRunExist(runId)
 	if err != nil {
 		return util.Wrap(err, "Terminate run failed")
 	}
+	if state := model.RunStateFromConditions(runDetail.Conditions); !model.IsActiveRunState(state) {
+		return util.NewFailedPreconditionError(errors.New("run has already finished"),
+			"Run %v can't be terminated because it has already finished with state %v", runId, state)
+	}
 
 	namespace, err := r.GetNamespaceFromRunID(runId)
 	if err != nil {
 		return util.Wrap(err, "Terminate run failed")
 	}
 
-	err = r.runStore.TerminateRun(runId)
+	// The identity is only known when the request was authenticated, e.g. in multi-user mode.
+	terminatedBy, _ := r.AuthenticateRequest(ctx)
+	err = r.runStore.TerminateRun(runId, terminatedBy)
 	if err != nil {
 		return util.Wrap(err, "Terminate run failed")
 	}
@@ -539,11 +2138,140 @@ func (r *ResourceManager) TerminateRun(ctx context.Context, runId string) error
 	return nil
 }
 
-func (r *ResourceManager) RetryRun(ctx context.Context, runId string) error {
+// GetActiveJobRunIds returns the IDs of the runs of a job that haven't finished yet.
+func (r *ResourceManager) GetActiveJobRunIds(jobId string) ([]string, error) {
+	if _, err := r.GetJob(jobId); err != nil {
+		return nil, util.Wrap(err, "Failed to list the active runs of the job")
+	}
+	runIds, err := r.runStore.ListJobRunIds(jobId, model.ActiveRunStates)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to list the active runs of the job")
+	}
+	return runIds, nil
+}
+
+// TerminateRuns terminates several runs. A run that fails to be terminated doesn't stop the others from being
+// terminated, and runs that don't exist or have already finished are skipped. The result of each of the other
+// runs is returned, keyed by run ID, with a nil error for the runs that were terminated.
+func (r *ResourceManager) TerminateRuns(ctx context.Context, runIds []string) map[string]error {
+	results := make(map[string]error)
+	for _, runId := range runIds {
+		err := r.TerminateRun(ctx, runId)
+		if util.IsUserErrorCodeMatch(err, codes.NotFound) || util.IsUserErrorCodeMatch(err, codes.FailedPrecondition) {
+			continue
+		}
+		results[runId] = err
+	}
+	return results
+}
+
+// TerminateJobRuns terminates the runs of a job that haven't finished yet. The job itself is left as it is,
+// so it keeps scheduling runs unless it is disabled. The results are those of TerminateRuns.
+func (r *ResourceManager) TerminateJobRuns(ctx context.Context, jobId string) (map[string]error, error) {
+	runIds, err := r.GetActiveJobRunIds(jobId)
+	if err != nil {
+		return nil, err
+	}
+	return r.TerminateRuns(ctx, runIds), nil
+}
+
+// JobRerunSummary is the outcome of rerunning the failed runs of a job.
+type JobRerunSummary struct {
+	// Resubmitted maps the IDs of the failed runs that were rerun to the IDs of their new runs.
+	Resubmitted map[string]string
+	// Skipped are the IDs of the failed runs that already have a run retried from them.
+	Skipped []string
+	// Errors maps the IDs of the failed runs that couldn't be rerun to why.
+	Errors map[string]error
+}
+
+// RerunFailedRunsForJob creates a fresh run for each run of a job that failed, and was created at or after
+// since. The new runs have the same pipeline spec and parameters as the failed runs, and a RetriedFrom
+// reference back to them, the way RetryRun restarts a run. Failed runs that were already retried are
+// skipped, and a run that fails to be resubmitted doesn't stop the others from being resubmitted. In
+// multi-user mode the caller must be an admin or be allowed to retry the job.
+func (r *ResourceManager) RerunFailedRunsForJob(ctx context.Context, jobId string, since time.Time) (*JobRerunSummary, error) {
+	job, err := r.jobStore.GetJob(jobId)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to rerun the failed runs of job %v", jobId)
+	}
+	if common.IsMultiUserMode() {
+		userIdentity, err := r.AuthenticateRequest(ctx)
+		if err != nil {
+			return nil, util.Wrapf(err, "Failed to rerun the failed runs of job %v", jobId)
+		}
+		if !common.IsImpersonationAdmin(userIdentity) {
+			err = r.IsRequestAuthorized(ctx, userIdentity, &authorizationv1.ResourceAttributes{
+				Namespace: job.Namespace,
+				Name:      job.Name,
+				Verb:      common.RbacResourceVerbRetry,
+				Group:     common.RbacPipelinesGroup,
+				Version:   common.RbacPipelinesVersion,
+				Resource:  common.RbacResourceTypeJobs,
+			})
+			if err != nil {
+				return nil, util.Wrapf(err, "Failed to rerun the failed runs of job %v", jobId)
+			}
+		}
+	}
+
+	runIds, err := r.runStore.ListJobRunIds(jobId, []string{model.RunStateFailed, model.RunStateError})
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to rerun the failed runs of job %v", jobId)
+	}
+	retriedRunIds, err := r.runStore.ListRetriedRunIds(runIds)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to rerun the failed runs of job %v", jobId)
+	}
+	retried := make(map[string]bool, len(retriedRunIds))
+	for _, runId := range retriedRunIds {
+		retried[runId] = true
+	}
+	summary := &JobRerunSummary{Resubmitted: map[string]string{}, Skipped: []string{}, Errors: map[string]error{}}
+	for _, runId := range runIds {
+		runDetail, err := r.runStore.GetRun(runId)
+		if err != nil {
+			summary.Errors[runId] = err
+			continue
+		}
+		if runDetail.CreatedAtInSec < since.Unix() {
+			continue
+		}
+		if retried[runId] {
+			summary.Skipped = append(summary.Skipped, runId)
+			continue
+		}
+		newRunDetail, err := r.restartRun(ctx, runDetail)
+		if err != nil {
+			summary.Errors[runId] = err
+			continue
+		}
+		summary.Resubmitted[runId] = newRunDetail.UUID
+	}
+	return summary, nil
+}
+
+// RetryRun retries a failed run. If retryFailedNodes is set, the existing workflow is retried in place
+// with Argo's retry semantics, so that only the failed nodes are rerun. Otherwise a new run is created
+// with the same pipeline spec, parameters and resource references, and a RetriedFrom reference back to
+// the original run. The retried run is returned.
+func (r *ResourceManager) RetryRun(ctx context.Context, runId string, retryFailedNodes bool) (*model.RunDetail, error) {
 	runDetail, err := r.checkRunExist(runId)
 	if err != nil {
-		return util.Wrap(err, "Retry run failed")
+		return nil, util.Wrap(err, "Retry run failed")
+	}
+	if !retryFailedNodes {
+		return r.restartRun(ctx, runDetail)
+	}
+	if err := r.retryFailedNodes(ctx, runDetail); err != nil {
+		return nil, err
 	}
+	return r.runStore.GetRun(runId)
+}
+
+// retryFailedNodes retries the workflow of a run in place, rerunning only the nodes that failed.
+func (r *ResourceManager) retryFailedNodes(ctx context.Context, runDetail *model.RunDetail) error {
+	runId := runDetail.UUID
 	namespace, err := r.GetNamespaceFromRunID(runId)
 	if err != nil {
 		return util.Wrap(err, "Retry run failed")
@@ -590,11 +2318,393 @@ func (r *ResourceManager) RetryRun(ctx context.Context, runId string) error {
 		}
 		newExecSpec = newCreatedWorkflow
 	}
-	err = r.runStore.UpdateRun(runId, string(newExecSpec.ExecutionStatus().Condition()), 0, newExecSpec.ToStringForStore())
+	err = r.runStore.UpdateRun(runId, string(newExecSpec.ExecutionStatus().Condition()), 0, newExecSpec.ToStringForStore())
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to update the database entry.")
+	}
+	return nil
+}
+
+// restartRun submits a fresh workflow for a stored run. The new run reuses the pipeline spec,
+// parameters and resource references of the original run, except for the job that created it.
+func (r *ResourceManager) restartRun(ctx context.Context, runDetail *model.RunDetail) (*model.RunDetail, error) {
+	state := model.RunStateFromConditions(runDetail.Conditions)
+	if state != model.RunStateFailed && state != model.RunStateError {
+		return nil, util.NewFailedPreconditionError(errors.New("run cannot be restarted"),
+			"Run %s must be Failed/Error to be restarted, but it is %s", runDetail.UUID, state)
+	}
+	tmpl, err := runTemplate(runDetail)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to restart run: invalid manifest")
+	}
+	newRunDetail, err := r.resubmitRun(ctx, runDetail, tmpl, runDetail.PipelineSpec, nil, common.RetriedFrom)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to restart run")
+	}
+	return newRunDetail, nil
+}
+
+// CloneRun creates a new run from the pipeline spec and resource references of an existing run, with
+// paramOverrides applied on top of its parameters and a ClonedFrom reference back to it. The clone lands in
+// the experiment of the original run, unless experimentId is set. Overrides are only supported for v1 runs,
+// and must name parameters declared by the workflow.
+func (r *ResourceManager) CloneRun(ctx context.Context, runId string, paramOverrides []*apiv1beta1.Parameter, experimentId string) (*model.RunDetail, error) {
+	runDetail, err := r.checkRunExist(runId)
+	if err != nil {
+		return nil, util.Wrap(err, "Clone run failed")
+	}
+	tmpl, err := runTemplate(runDetail)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to clone run: invalid manifest")
+	}
+	pipelineSpec := runDetail.PipelineSpec
+	if len(paramOverrides) > 0 {
+		if tmpl.GetTemplateType() != template.V1 {
+			return nil, util.NewInvalidInputError("Failed to clone run %s: parameter overrides are only supported for v1 runs", runId)
+		}
+		params, err := overrideParameters(tmpl, pipelineSpec.Parameters, paramOverrides)
+		if err != nil {
+			return nil, util.Wrapf(err, "Failed to clone run %s", runId)
+		}
+		pipelineSpec.Parameters = params
+	}
+	var experiment *model.Experiment
+	if experimentId != "" {
+		experiment, err = r.GetExperiment(experimentId)
+		if err != nil {
+			return nil, util.Wrapf(err, "Failed to clone run %s", runId)
+		}
+		if common.IsMultiUserMode() && experiment.Namespace != runDetail.Namespace {
+			return nil, util.NewInvalidInputError("Failed to clone run %s: experiment %s is in namespace %s, not %s",
+				runId, experimentId, experiment.Namespace, runDetail.Namespace)
+		}
+	}
+	newRunDetail, err := r.resubmitRun(ctx, runDetail, tmpl, pipelineSpec, experiment, common.ClonedFrom)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to clone run %s", runId)
+	}
+	return newRunDetail, nil
+}
+
+// runTemplate parses the workflow manifest of a stored run, or its pipeline spec manifest for v2 runs.
+func runTemplate(runDetail *model.RunDetail) (template.Template, error) {
+	manifest := runDetail.WorkflowSpecManifest
+	if manifest == "" {
+		manifest = runDetail.PipelineSpecManifest
+	}
+	return template.New([]byte(manifest))
+}
+
+// overrideParameters applies overrides on top of the serialized v1 parameters of a run. Overrides for
+// parameters that the workflow doesn't declare are rejected.
+func overrideParameters(tmpl template.Template, parameters string, overrides []*apiv1beta1.Parameter) (string, error) {
+	declaredJSON, err := tmpl.ParametersJSON()
+	if err != nil {
+		return "", util.Wrap(err, "Failed to read the workflow parameters")
+	}
+	declared, err := util.UnmarshalParameters(util.ArgoWorkflow, declaredJSON)
+	if err != nil {
+		return "", err
+	}
+	declaredNames := make(map[string]bool, len(declared))
+	for _, param := range declared {
+		declaredNames[param.Name] = true
+	}
+	params, err := util.UnmarshalParameters(util.ArgoWorkflow, parameters)
+	if err != nil {
+		return "", err
+	}
+	for _, override := range overrides {
+		if !declaredNames[override.GetName()] {
+			return "", util.NewInvalidInputError("Parameter %q is not declared by the workflow", override.GetName())
+		}
+		found := false
+		for i := range params {
+			if params[i].Name == override.GetName() {
+				params[i].Value = util.StringPointer(override.GetValue())
+				found = true
+			}
+		}
+		if !found {
+			params = append(params, util.SpecParameter{Name: override.GetName(), Value: util.StringPointer(override.GetValue())})
+		}
+	}
+	return util.MarshalParameters(util.ArgoWorkflow, params)
+}
+
+// resubmitRun submits a fresh workflow for a stored run with the given pipeline spec. The new run reuses the
+// resource references of the original run, except for the job that created it and its own lineage, and gets
+// a reference of the given relationship back to the original run. If experiment is set, the new run is
+// created in that experiment instead of the experiment of the original run.
+func (r *ResourceManager) resubmitRun(ctx context.Context, runDetail *model.RunDetail, tmpl template.Template,
+	pipelineSpec model.PipelineSpec, experiment *model.Experiment, lineage model.Relationship) (*model.RunDetail, error) {
+	uuid, err := r.uuid.NewRandom()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to generate run ID.")
+	}
+	newRunId := uuid.String()
+	runAt := r.time.Now().Unix()
+
+	experimentUUID := runDetail.ExperimentUUID
+	var references []*model.ResourceReference
+	for _, ref := range runDetail.ResourceReferences {
+		if ref.ReferenceType == common.Job || ref.Relationship == common.RetriedFrom || ref.Relationship == common.ClonedFrom {
+			continue
+		}
+		if experiment != nil && ref.ReferenceType == common.Experiment {
+			continue
+		}
+		newRef := *ref
+		newRef.ResourceUUID = newRunId
+		references = append(references, &newRef)
+	}
+	if experiment != nil {
+		experimentUUID = experiment.UUID
+		references = append(references, &model.ResourceReference{
+			ResourceUUID:  newRunId,
+			ResourceType:  common.Run,
+			ReferenceUUID: experiment.UUID,
+			ReferenceName: experiment.Name,
+			ReferenceType: common.Experiment,
+			Relationship:  common.Owner,
+		})
+	}
+	references = append(references, &model.ResourceReference{
+		ResourceUUID:  newRunId,
+		ResourceType:  common.Run,
+		ReferenceUUID: runDetail.UUID,
+		ReferenceName: runDetail.DisplayName,
+		ReferenceType: common.Run,
+		Relationship:  lineage,
+	})
+
+	modelRunDetail := &model.RunDetail{
+		Run: model.Run{
+			UUID:               newRunId,
+			ExperimentUUID:     experimentUUID,
+			DisplayName:        runDetail.DisplayName,
+			Name:               runDetail.DisplayName,
+			Namespace:          runDetail.Namespace,
+			ServiceAccount:     runDetail.ServiceAccount,
+			Description:        runDetail.Description,
+			CreatedAtInSec:     runAt,
+			ScheduledAtInSec:   runAt,
+			ResourceReferences: references,
+			PipelineSpec:       pipelineSpec,
+		},
+	}
+	executionSpec, err := tmpl.RunWorkflow(&modelRunDetail.Run, template.RunWorkflowOptions{RunId: newRunId, RunAt: runAt})
+	if err != nil {
+		return nil, util.Wrap(err, "failed to generate the ExecutionSpec")
+	}
+	if err = executionSpec.Validate(false, false); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to validate workflow for (%+v)", executionSpec.ExecutionName())
+	}
+	// The new run keeps the service account of the original run, which the user must be allowed to use.
+	if err := r.canUseServiceAccount(ctx, modelRunDetail.Namespace, modelRunDetail.ServiceAccount); err != nil {
+		return nil, err
+	}
+	prepared := &preparedRun{
+		modelRunDetail: modelRunDetail,
+		executionSpec:  executionSpec,
+		templateType:   tmpl.GetTemplateType(),
+	}
+	// The parameters of the original run keep their secret references, which are resolved again.
+	if err := r.resolveSecretParameters(ctx, prepared); err != nil {
+		return nil, err
+	}
+	return r.submitRun(ctx, prepared)
+}
+
+// GetRunManifest returns the workflow that was submitted for a run as YAML, with the parameters of the run
+// substituted. The workflow status is left out, and parameters that are read from another source keep the
+// reference to the source instead of the resolved value. Runs stored without a runtime manifest have their
+// workflow rendered again from the pipeline spec and parameters of the run.
+func (r *ResourceManager) GetRunManifest(ctx context.Context, runId string) (string, error) {
+	runDetail, err := r.checkRunExist(runId)
+	if err != nil {
+		return "", util.Wrap(err, "Failed to get the manifest of the run")
+	}
+	manifest := runDetail.WorkflowRuntimeManifest
+	if manifest == "" {
+		manifest, err = renderRunManifest(runDetail)
+		if err != nil {
+			return "", util.Wrapf(err, "Failed to render the manifest of run %v", runId)
+		}
+	}
+	workflow, err := util.NewWorkflowFromBytesJSON([]byte(manifest))
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Failed to parse the manifest of run %v", runId)
+	}
+	workflow.Status = workflowapi.WorkflowStatus{}
+	workflow.RedactParameterValuesFrom()
+	bytes, err := yaml.Marshal(workflow.Workflow)
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Failed to marshal the manifest of run %v", runId)
+	}
+	return string(bytes), nil
+}
+
+// GetRunDAG returns the nodes of the workflow of a run, with the edges between them. The status is read from
+// the workflow while it exists, and from the status last persisted for the run once it is garbage collected.
+func (r *ResourceManager) GetRunDAG(ctx context.Context, runId string) ([]util.ExecutionNode, error) {
+	runDetail, err := r.checkRunExist(runId)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the DAG of the run")
+	}
+	execSpec, err := r.getWorkflowClient(runDetail.Namespace).Get(ctx, runDetail.Name, v1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		execSpec, err = r.persistedRunWorkflow(runDetail)
+		if err != nil {
+			return nil, util.Wrapf(err, "Failed to get the DAG of run %v", runId)
+		}
+	} else if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get the workflow of run %v", runId)
+	}
+	if err := execSpec.Decompress(); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to decompress the workflow status of run %v", runId)
+	}
+	return execSpec.ExecutionStatus().Nodes(), nil
+}
+
+// GetRunArtifacts returns the output artifacts of the nodes of a run, leaving out archived logs. The artifacts
+// of archived runs, and of runs whose workflow was garbage collected, are read from the persisted workflow
+// status. Callers are responsible for authorizing the request like GetRun.
+func (r *ResourceManager) GetRunArtifacts(ctx context.Context, runId string) ([]util.ExecutionArtifact, error) {
+	runDetail, err := r.checkRunExist(runId)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the artifacts of the run")
+	}
+	execSpec, err := r.runWorkflowStatus(ctx, runDetail)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to get the artifacts of run %v", runId)
+	}
+	return execSpec.ExecutionStatus().OutputArtifacts(), nil
+}
+
+// runWorkflowStatus returns the decompressed workflow of a run: the live one while it exists and the run isn't
+// archived, the persisted one otherwise.
+func (r *ResourceManager) runWorkflowStatus(ctx context.Context, runDetail *model.RunDetail) (util.ExecutionSpec, error) {
+	var execSpec util.ExecutionSpec
+	var err error
+	persisted := runDetail.StorageState == apiv1beta1.Run_STORAGESTATE_ARCHIVED.String()
+	if !persisted {
+		execSpec, err = r.getWorkflowClient(runDetail.Namespace).Get(ctx, runDetail.Name, v1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			persisted = true
+		} else if err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to get the workflow of run %v", runDetail.UUID)
+		}
+	}
+	if persisted {
+		execSpec, err = r.persistedRunWorkflow(runDetail)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := execSpec.Decompress(); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to decompress the workflow status of run %v", runDetail.UUID)
+	}
+	return execSpec, nil
+}
+
+// persistedRunWorkflow returns the workflow status persisted for a run: the snapshot taken when it finished,
+// or the runtime manifest for runs finished before snapshots were taken.
+func (r *ResourceManager) persistedRunWorkflow(runDetail *model.RunDetail) (util.ExecutionSpec, error) {
+	snapshot, err := r.runStore.GetRunStatusSnapshot(runDetail.UUID)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot != nil {
+		workflow, err := util.NewWorkflowFromStatusSnapshot(snapshot)
+		if err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to read the status snapshot of run %v", runDetail.UUID)
+		}
+		return workflow, nil
+	}
+	if runDetail.WorkflowRuntimeManifest == "" {
+		return nil, util.NewNotFoundError(errors.New("no persisted workflow status"),
+			"Run %v has no workflow status", runDetail.UUID)
+	}
+	execSpec, err := util.NewExecutionSpecJSON(util.ArgoWorkflow, []byte(runDetail.WorkflowRuntimeManifest))
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to parse the persisted workflow of run %v", runDetail.UUID)
+	}
+	return execSpec, nil
+}
+
+// renderRunManifest renders the workflow of a stored run from its pipeline spec and parameters.
+func renderRunManifest(runDetail *model.RunDetail) (string, error) {
+	tmpl, err := runTemplate(runDetail)
+	if err != nil {
+		return "", util.Wrap(err, "Invalid manifest")
+	}
+	executionSpec, err := tmpl.RunWorkflow(&runDetail.Run, template.RunWorkflowOptions{
+		RunId: runDetail.UUID,
+		RunAt: runDetail.ScheduledAtInSec,
+	})
+	if err != nil {
+		return "", util.Wrap(err, "Failed to generate the ExecutionSpec")
+	}
+	if runDetail.Name != "" {
+		executionSpec.SetExecutionName(runDetail.Name)
+	}
+	if runDetail.Namespace != "" {
+		executionSpec.SetExecutionNamespace(runDetail.Namespace)
+	}
+	return executionSpec.ToStringForStore(), nil
+}
+
+// RunLogLocation tells where the logs of a node of a run are.
+type RunLogLocation struct {
+	// Namespace and PodName identify the pod that ran the node.
+	Namespace string
+	PodName   string
+	// ObjectKey is the key of the archived logs in the object store, or empty if the logs weren't archived.
+	ObjectKey string
+}
+
+// GetRunLogs resolves where the logs of a node of a run are. A retried node resolves to the pod of its latest
+// attempt. The returned bool tells whether the logs can still be read from the pod. Otherwise they can only
+// be read from the object store if they were archived, and have expired if they were not.
+func (r *ResourceManager) GetRunLogs(ctx context.Context, runId string, nodeId string) (*RunLogLocation, bool, error) {
+	runDetail, err := r.checkRunExist(runId)
+	if err != nil {
+		return nil, false, util.Wrap(err, "Failed to get the logs of the run")
+	}
+	live := true
+	execSpec, err := r.getWorkflowClient(runDetail.Namespace).Get(ctx, runDetail.Name, v1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		live = false
+		execSpec, err = r.persistedRunWorkflow(runDetail)
+		if err != nil {
+			return nil, false, util.Wrapf(err, "Failed to get the logs of run %v", runId)
+		}
+	} else if err != nil {
+		return nil, false, util.NewInternalServerError(err, "Failed to get the workflow of run %v", runId)
+	}
+	if err := execSpec.Decompress(); err != nil {
+		return nil, false, util.NewInternalServerError(err, "Failed to decompress the workflow status of run %v", runId)
+	}
+	podNodeId, podName, err := execSpec.ExecutionStatus().NodePod(nodeId)
 	if err != nil {
-		return util.NewInternalServerError(err, "Failed to update the database entry.")
+		return nil, false, util.Wrapf(err, "Failed to get the logs of node %v of run %v", nodeId, runId)
+	}
+	// Argo records the logs it archives as the main-logs artifact of the node.
+	location := &RunLogLocation{
+		Namespace: runDetail.Namespace,
+		PodName:   podName,
+		ObjectKey: execSpec.ExecutionStatus().FindObjectStoreArtifactKeyOrEmpty(podNodeId, "main-logs"),
+	}
+	if live {
+		_, err := r.k8sCoreClient.PodClient(runDetail.Namespace).Get(ctx, podName, v1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			live = false
+		} else if err != nil {
+			return nil, false, util.NewInternalServerError(err, "Failed to get pod %v of run %v", podName, runId)
+		}
 	}
-	return nil
+	return location, live, nil
 }
 
 func (r *ResourceManager) ReadLog(ctx context.Context, runId string, nodeId string, follow bool, dst io.Writer) error {
@@ -681,6 +2791,123 @@ func (r *ResourceManager) GetJob(id string) (*model.Job, error) {
 	return r.jobStore.GetJob(id)
 }
 
+// GetJobWithNextRun returns a job along with when it is expected to create its
+// next run, or why it won't create any.
+func (r *ResourceManager) GetJobWithNextRun(ctx context.Context, id string) (*model.Job, error) {
+	job, err := r.jobStore.GetJob(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadJobNextRun(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// ListJobsWithNextRun lists jobs along with when each is expected to create its
+// next run, or why it won't create any.
+func (r *ResourceManager) ListJobsWithNextRun(ctx context.Context, filterContext *common.FilterContext,
+	opts *list.Options) (jobs []*model.Job, total_size int, nextPageToken string, err error) {
+	jobs, total_size, nextPageToken, err = r.jobStore.ListJobs(filterContext, opts)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	for _, job := range jobs {
+		if err := r.loadJobNextRun(ctx, job); err != nil {
+			return nil, 0, "", err
+		}
+	}
+	return jobs, total_size, nextPageToken, nil
+}
+
+// loadJobNextRun sets when a job is expected to create its next run from its
+// scheduled workflow, or why it won't create any.
+func (r *ResourceManager) loadJobNextRun(ctx context.Context, job *model.Job) error {
+	scheduledWorkflow, err := r.getScheduledWorkflowClient(job.Namespace).Get(ctx, job.Name, v1.GetOptions{})
+	if apierrors.IsNotFound(err) || (err == nil && string(scheduledWorkflow.UID) != job.UUID) {
+		job.NextRunReason = "The scheduled workflow of the job doesn't exist."
+		return nil
+	}
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to get the scheduled workflow of job %v", job.UUID)
+	}
+	if err := setJobNextRun(job, scheduledWorkflow, r.time.Now().Unix()); err != nil {
+		return util.Wrapf(err, "Failed to compute the next run of job %v", job.UUID)
+	}
+	return nil
+}
+
+// JobRunHistoryEntry is a run created by a job, along with its outcome.
+type JobRunHistoryEntry struct {
+	Run *model.Run
+	// State of the run, e.g. SUCCEEDED, or PENDING and RUNNING until it finishes.
+	State string
+	// DurationSeconds is how long the run ran, or has been running if it hasn't finished. It is 0 until the
+	// run starts.
+	DurationSeconds int64
+}
+
+// GetJobRunHistory lists the runs created by a job, archived ones included, the most recently scheduled
+// first. Runs scheduled without a time are ordered by when they were created. Schedule ticks that failed to
+// create a run aren't recorded by the API server, so they aren't part of the history. An empty page token
+// lists the first page. In multi-user mode the caller must be an admin or be allowed to get the job.
+func (r *ResourceManager) GetJobRunHistory(ctx context.Context, jobId string, pageToken string, pageSize int) ([]*JobRunHistoryEntry, int, string, error) {
+	job, err := r.jobStore.GetJob(jobId)
+	if err != nil {
+		return nil, 0, "", util.Wrapf(err, "Failed to get the run history of job %v", jobId)
+	}
+	if common.IsMultiUserMode() {
+		userIdentity, err := r.AuthenticateRequest(ctx)
+		if err != nil {
+			return nil, 0, "", util.Wrapf(err, "Failed to get the run history of job %v", jobId)
+		}
+		if !common.IsImpersonationAdmin(userIdentity) {
+			err = r.IsRequestAuthorized(ctx, userIdentity, &authorizationv1.ResourceAttributes{
+				Namespace: job.Namespace,
+				Name:      job.Name,
+				Verb:      common.RbacResourceVerbGet,
+				Group:     common.RbacPipelinesGroup,
+				Version:   common.RbacPipelinesVersion,
+				Resource:  common.RbacResourceTypeJobs,
+			})
+			if err != nil {
+				return nil, 0, "", util.Wrapf(err, "Failed to get the run history of job %v", jobId)
+			}
+		}
+	}
+
+	var opts *list.Options
+	if pageToken == "" {
+		storageStates := &apiv1beta1.Filter{Predicates: []*apiv1beta1.Predicate{{
+			Key: "storage_state",
+			Op:  apiv1beta1.Predicate_IN,
+			Value: &apiv1beta1.Predicate_StringValues{StringValues: &apiv1beta1.StringValues{Values: []string{
+				apiv1beta1.Run_STORAGESTATE_AVAILABLE.String(), apiv1beta1.Run_STORAGESTATE_ARCHIVED.String()}}},
+		}}}
+		opts, err = list.NewOptions(&model.Run{}, pageSize, "scheduled_at desc", storageStates)
+	} else {
+		opts, err = list.NewOptionsFromToken(pageToken, pageSize)
+	}
+	if err != nil {
+		return nil, 0, "", util.Wrapf(err, "Failed to get the run history of job %v", jobId)
+	}
+	runs, totalSize, nextPageToken, err := r.runStore.ListRuns(&common.FilterContext{
+		ReferenceKey: &common.ReferenceKey{Type: common.Job, ID: jobId}}, opts)
+	if err != nil {
+		return nil, 0, "", util.Wrapf(err, "Failed to get the run history of job %v", jobId)
+	}
+	now := r.time.Now().Unix()
+	history := make([]*JobRunHistoryEntry, 0, len(runs))
+	for _, run := range runs {
+		history = append(history, &JobRunHistoryEntry{
+			Run:             run,
+			State:           model.RunStateFromConditions(run.Conditions),
+			DurationSeconds: run.DurationSeconds(now),
+		})
+	}
+	return history, totalSize, nextPageToken, nil
+}
+
 func (r *ResourceManager) CreateJob(ctx context.Context, apiJobInterface interface{}) (*model.Job, error) {
 	// For apiv1beta1:
 	// Get manifest from either of the two places:
@@ -694,6 +2921,13 @@ func (r *ResourceManager) CreateJob(ctx context.Context, apiJobInterface interfa
 	// (2) pipeline id
 	// 	And the latter takes priority over the former when the pipeline manifest is from pipeline_spec.pipeline_id
 	// TODO(lingqinggan): Add get pipeline from pipeline version.
+	if apiJob, ok := apiJobInterface.(*apiv1beta1.Job); ok {
+		references, err := r.addNamespaceDefaultExperiment(ctx, apiJob.GetResourceReferences(), false)
+		if err != nil {
+			return nil, err
+		}
+		apiJob.ResourceReferences = references
+	}
 	manifestBytes, err := getManifestBytesfromAPIJobInterface(apiJobInterface, r)
 	if err != nil {
 		return nil, util.Wrap(err, "Error getting manifest Bytes from api job")
@@ -711,6 +2945,18 @@ func (r *ResourceManager) CreateJob(ctx context.Context, apiJobInterface interfa
 		return nil, util.Wrap(err, "Error creating model job")
 	}
 
+	// Validate the schedule before anything is created.
+	if err := validateTrigger(modelJob.Trigger); err != nil {
+		return nil, util.Wrap(err, "Invalid job schedule")
+	}
+	// New jobs don't backfill the runs they miss unless the request asks for it. A job created with
+	// no_catchup never catches up.
+	catchUp, err := catchUpFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	modelJob.NoCatchup = modelJob.NoCatchup || !catchUp
+
 	// Convert modelJob into scheduledWorkflow.
 	scheduledWorkflow, err := tmpl.ScheduledWorkflow(modelJob)
 	if err != nil {
@@ -734,8 +2980,43 @@ func (r *ResourceManager) CreateJob(ctx context.Context, apiJobInterface interfa
 	modelJob.CreatedAtInSec = now
 	modelJob.UpdatedAtInSec = now
 
-	// Store modelJob to database and return.
-	return r.jobStore.CreateJob(modelJob)
+	// Store modelJob to database and return. The scheduled workflow is deleted if the job can't be stored.
+	job, err := r.jobStore.CreateJob(modelJob)
+	if err != nil {
+		if deleteErr := r.getScheduledWorkflowClient(modelJob.Namespace).Delete(context.Background(), newScheduledWorkflow.Name, &v1.DeleteOptions{}); deleteErr != nil && !apierrors.IsNotFound(deleteErr) {
+			glog.Errorf("Failed to delete scheduled workflow %v, whose job couldn't be stored. Error: %v", newScheduledWorkflow.Name, deleteErr.Error())
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+// RestartJobSchedule makes a recurring job schedule its next run from now, as if it had last run now, so that
+// it doesn't create runs for the ticks of its schedule that already passed. It is meant to be called before
+// resuming a job that was paused for long, and can be called whether the job is enabled or not. It returns the
+// job with the time of its next run.
+func (r *ResourceManager) RestartJobSchedule(ctx context.Context, jobID string) (*model.Job, error) {
+	job, err := r.checkJobExist(ctx, jobID)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to restart the schedule of job")
+	}
+	if isOneOffJob(job) {
+		return nil, util.NewFailedPreconditionError(errors.New("job has no schedule"),
+			"Job %v runs once and has no schedule to restart", jobID)
+	}
+	now := r.time.Now().UTC()
+	scheduledWorkflow, err := r.getScheduledWorkflowClient(job.Namespace).Patch(
+		ctx,
+		job.Name,
+		types.MergePatchType,
+		[]byte(fmt.Sprintf(`{"status":{"trigger":{"lastTriggeredTime":%q}}}`, now.Format(time.RFC3339))))
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to restart the schedule of job %v", jobID)
+	}
+	if err := setJobNextRun(job, scheduledWorkflow, now.Unix()); err != nil {
+		return nil, util.Wrapf(err, "Failed to compute the next run of job %v", jobID)
+	}
+	return job, nil
 }
 
 func (r *ResourceManager) updateJobResourceReferences(resourceId string, modelJob *model.Job) error {
@@ -746,6 +3027,18 @@ func (r *ResourceManager) updateJobResourceReferences(resourceId string, modelJo
 }
 
 func (r *ResourceManager) EnableJob(ctx context.Context, jobID string, enabled bool) error {
+	_, err := r.UpdateJobEnabled(ctx, jobID, enabled)
+	return err
+}
+
+// UpdateJobEnabled pauses or resumes a job, and returns the job with its new
+// status and the time of its next run. The ScheduledWorkflow controller stops
+// or starts creating runs accordingly. Runs the job already created are left
+// running. A resumed job that catches up creates a run for every tick of its
+// schedule that passed while it was paused, and one that doesn't creates a
+// run for the latest of them. Call RestartJobSchedule first for it to create
+// none.
+func (r *ResourceManager) UpdateJobEnabled(ctx context.Context, jobID string, enabled bool) (*model.Job, error) {
 	var job *model.Job
 	var err error
 	if enabled {
@@ -756,27 +3049,115 @@ func (r *ResourceManager) EnableJob(ctx context.Context, jobID string, enabled b
 		job, err = r.jobStore.GetJob(jobID)
 	}
 	if err != nil {
-		return util.Wrap(err, "Enable/Disable job failed")
+		return nil, util.Wrap(err, "Enable/Disable job failed")
 	}
 
-	_, err = r.getScheduledWorkflowClient(job.Namespace).Patch(
+	scheduledWorkflow, err := r.getScheduledWorkflowClient(job.Namespace).Patch(
 		ctx,
 		job.Name,
 		types.MergePatchType,
 		[]byte(fmt.Sprintf(`{"spec":{"enabled":%s}}`, strconv.FormatBool(enabled))))
 	if err != nil {
-		return util.NewInternalServerError(err,
+		return nil, util.NewInternalServerError(err,
 			"Failed to enable/disable job CR. Enabled: %v, jobID: %v",
 			enabled, jobID)
 	}
 
 	err = r.jobStore.EnableJob(jobID, enabled)
 	if err != nil {
-		return util.Wrapf(err, "Failed to enable/disable job. Enabled: %v, jobID: %v",
+		return nil, util.Wrapf(err, "Failed to enable/disable job. Enabled: %v, jobID: %v",
 			enabled, jobID)
 	}
+	// One-off jobs keep their Running/Succeeded status. Recurring jobs get the
+	// status the controller records for their new mode.
+	if !isOneOffJob(job) {
+		conditions := string(swfapi.ScheduledWorkflowDisabled)
+		if enabled {
+			conditions = string(swfapi.ScheduledWorkflowEnabled)
+		}
+		if err := r.jobStore.UpdateJobConditions(jobID, conditions); err != nil {
+			return nil, util.Wrapf(err, "Failed to update the status of job %v", jobID)
+		}
+	}
 
-	return nil
+	job, err = r.jobStore.GetJob(jobID)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to get job %v after enabling/disabling it", jobID)
+	}
+	if err := setJobNextRun(job, scheduledWorkflow, r.time.Now().Unix()); err != nil {
+		return nil, util.Wrapf(err, "Failed to compute the next run of job %v", jobID)
+	}
+	return job, nil
+}
+
+// SetJobParameterResolution changes whether the runs of a job snapshot its
+// parameters or inherit them when their workflows are submitted.
+func (r *ResourceManager) SetJobParameterResolution(ctx context.Context, jobID string, resolution model.ParameterResolution) error {
+	if resolution != model.ParameterResolutionSnapshot && resolution != model.ParameterResolutionInherit {
+		return util.NewInvalidInputError("Unsupported parameter resolution %q. Expected %q or %q",
+			resolution, model.ParameterResolutionSnapshot, model.ParameterResolutionInherit)
+	}
+	if _, err := r.checkJobExist(ctx, jobID); err != nil {
+		return util.Wrapf(err, "Failed to set parameter resolution of job %v", jobID)
+	}
+	return r.jobStore.UpdateJobParameterResolution(jobID, resolution)
+}
+
+// UpdateJobParameters replaces the parameters of a job whose runs inherit them.
+// The new values are pushed to the ScheduledWorkflow, so every workflow it
+// submits from now on resolves them. Workflows that were already submitted keep
+// the values they resolved at submission time. If expectedVersion isn't 0, the
+// update fails with a Conflict error unless the job is still at that resource
+// version.
+func (r *ResourceManager) UpdateJobParameters(ctx context.Context, jobID string, parameters string, expectedVersion int64) error {
+	job, err := r.checkJobExist(ctx, jobID)
+	if err != nil {
+		return util.Wrapf(err, "Failed to update parameters of job %v", jobID)
+	}
+	// Check the version before patching the ScheduledWorkflow, so a stale update changes nothing.
+	if expectedVersion > 0 && job.ResourceVersion != expectedVersion {
+		return util.NewConflictError("Job %v was modified since it was read: its resource version is %d, not %d",
+			jobID, job.ResourceVersion, expectedVersion)
+	}
+	if job.ParameterResolution != model.ParameterResolutionInherit {
+		return util.NewFailedPreconditionError(
+			errors.New("job parameters are snapshotted"),
+			"Job %v snapshots its parameters when scheduled. Set its parameter resolution to %v to update them",
+			jobID, model.ParameterResolutionInherit)
+	}
+	if job.WorkflowSpecManifest == "" {
+		return util.NewInvalidInputError("Updating parameters is only supported for jobs created from a workflow manifest. Job %v has none", jobID)
+	}
+	tmpl, err := template.New([]byte(job.WorkflowSpecManifest))
+	if err != nil {
+		return util.Wrapf(err, "Failed to update parameters of job %v", jobID)
+	}
+	// Generating the ScheduledWorkflow verifies the parameters against the manifest.
+	job.Parameters = parameters
+	if _, err := tmpl.ScheduledWorkflow(job); err != nil {
+		return util.NewInvalidInputError("Invalid parameters for job %v: %v", jobID, err)
+	}
+	swfParameters := []swfapi.Parameter{}
+	if parameters != "" {
+		if err := json.Unmarshal([]byte(parameters), &swfParameters); err != nil {
+			return util.NewInvalidInputError("Invalid parameters for job %v: %v", jobID, err)
+		}
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"workflow": map[string]interface{}{
+				"parameters": swfParameters,
+			},
+		},
+	})
+	if err != nil {
+		return util.NewInternalServerError(err, "Unexpected error while marshalling a patch object.")
+	}
+	_, err = r.getScheduledWorkflowClient(job.Namespace).Patch(ctx, job.Name, types.MergePatchType, patch)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to update parameters of job CR. jobID: %v", jobID)
+	}
+	return r.jobStore.UpdateJobParameters(jobID, parameters, expectedVersion)
 }
 
 func (r *ResourceManager) DeleteJob(ctx context.Context, jobID string) error {
@@ -806,16 +3187,113 @@ func (r *ResourceManager) DeleteJob(ctx context.Context, jobID string) error {
 }
 
 func (r *ResourceManager) ReportWorkflowResource(ctx context.Context, execSpec util.ExecutionSpec) error {
+	report, err := r.prepareWorkflowReport(ctx, execSpec)
+	if err != nil {
+		return err
+	}
+	return r.finishWorkflowReport(ctx, report, r.storeWorkflowReport(report))
+}
+
+// WorkflowStatusUpdate is a status change of a workflow reported by the persistence agent.
+type WorkflowStatusUpdate struct {
+	Execution util.ExecutionSpec
+}
+
+// ReportWorkflows reports several workflows at once, as ReportWorkflowResource does for each of them. When a
+// run is reported more than once only its update with the highest workflow resource version is stored, and the
+// other ones get its result. The runs are stored in chunks of GetReportWorkflowsBatchSize, each in a single
+// transaction. The returned errors are aligned with updates, so that the caller knows which ones to retry.
+func (r *ResourceManager) ReportWorkflows(ctx context.Context, updates []*WorkflowStatusUpdate) []error {
+	errs := make([]error, len(updates))
+	// latest holds the index of the newest update of each run.
+	latest := map[string]int{}
+	runIds := make([]string, len(updates))
+	for i, update := range updates {
+		if update == nil || update.Execution == nil {
+			errs[i] = util.NewInvalidInputError("Workflow status update at index %v has no workflow", i)
+			continue
+		}
+		runIds[i] = update.Execution.ExecutionObjectMeta().Labels[util.LabelKeyWorkflowRunId]
+		if runIds[i] == "" {
+			continue
+		}
+		if k, ok := latest[runIds[i]]; !ok || !isOlderResourceVersion(update.Execution, updates[k].Execution) {
+			latest[runIds[i]] = i
+		}
+	}
+
+	var reports []*workflowReport
+	var indexes []int
+	for i, update := range updates {
+		if errs[i] != nil || (runIds[i] != "" && latest[runIds[i]] != i) {
+			continue
+		}
+		report, err := r.prepareWorkflowReport(ctx, update.Execution)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		reports = append(reports, report)
+		indexes = append(indexes, i)
+	}
+
+	batchSize := common.GetReportWorkflowsBatchSize()
+	for start := 0; start < len(reports); start += batchSize {
+		end := start + batchSize
+		if end > len(reports) {
+			end = len(reports)
+		}
+		statusUpdates := make([]*storage.RunStatusUpdate, 0, end-start)
+		for _, report := range reports[start:end] {
+			statusUpdates = append(statusUpdates, report.update)
+		}
+		storeErrs := r.runStore.UpdateRunStatuses(statusUpdates)
+		for k, report := range reports[start:end] {
+			errs[indexes[start+k]] = r.finishWorkflowReport(ctx, report, storeErrs[k])
+		}
+	}
+
+	for i, runId := range runIds {
+		if runId != "" && latest[runId] != i {
+			errs[i] = errs[latest[runId]]
+		}
+	}
+	return errs
+}
+
+// isOlderResourceVersion returns whether a workflow has a lower resource version than another one. Resource
+// versions that aren't numbers can't be compared, so neither workflow is older and the later report wins.
+func isOlderResourceVersion(workflow util.ExecutionSpec, other util.ExecutionSpec) bool {
+	version, err := strconv.ParseUint(workflow.ExecutionObjectMeta().ResourceVersion, 10, 64)
+	if err != nil {
+		return false
+	}
+	otherVersion, err := strconv.ParseUint(other.ExecutionObjectMeta().ResourceVersion, 10, 64)
+	if err != nil {
+		return false
+	}
+	return version < otherVersion
+}
+
+// workflowReport is a reported workflow whose run status is ready to be stored.
+type workflowReport struct {
+	execSpec util.ExecutionSpec
+	update   *storage.RunStatusUpdate
+}
+
+// prepareWorkflowReport validates a reported workflow, garbage collects it if its final state was persisted,
+// and builds the status of its run.
+func (r *ResourceManager) prepareWorkflowReport(ctx context.Context, execSpec util.ExecutionSpec) (*workflowReport, error) {
 	objMeta := execSpec.ExecutionObjectMeta()
 	execStatus := execSpec.ExecutionStatus()
 	if _, ok := objMeta.Labels[util.LabelKeyWorkflowRunId]; !ok {
 		// Skip reporting if the workflow doesn't have the run id label
-		return util.NewInvalidInputError("Workflow[%s] missing the Run ID label", execSpec.ExecutionName())
+		return nil, util.NewInvalidInputError("Workflow[%s] missing the Run ID label", execSpec.ExecutionName())
 	}
 	runId := objMeta.Labels[util.LabelKeyWorkflowRunId]
 	jobId := execSpec.ScheduledWorkflowUUIDAsStringOrEmpty()
 	if len(execSpec.ExecutionNamespace()) == 0 {
-		return util.NewInvalidInputError("Workflow missing namespace")
+		return nil, util.NewInvalidInputError("Workflow missing namespace")
 	}
 
 	if execSpec.PersistedFinalState() {
@@ -826,9 +3304,9 @@ func (r *ResourceManager) ReportWorkflowResource(ctx context.Context, execSpec u
 			// report workflows that no longer exist. It's important to return a not found error, so that persistence
 			// agent won't retry again.
 			if util.IsNotFound(err) {
-				return util.NewNotFoundError(err, "Failed to delete the completed workflow for run %s", runId)
+				return nil, util.NewNotFoundError(err, "Failed to delete the completed workflow for run %s", runId)
 			} else {
-				return util.NewInternalServerError(err, "Failed to delete the completed workflow for run %s", runId)
+				return nil, util.NewInternalServerError(err, "Failed to delete the completed workflow for run %s", runId)
 			}
 		}
 		// TODO(jingzhang36): find a proper way to pass collectMetricsFlag here.
@@ -839,94 +3317,169 @@ func (r *ResourceManager) ReportWorkflowResource(ctx context.Context, execSpec u
 	condition := execStatus.Condition()
 	if execSpec.IsTerminating() {
 		condition = exec.ExecutionPhase(model.RunTerminatingConditions)
+	} else if execSpec.IsTerminated() {
+		condition = exec.ExecutionPhase(model.RunTerminatedConditions)
 	}
 	if jobId == "" {
 		// If a run doesn't have job ID, it's a one-time run created by Pipeline API server.
 		// In this case the DB entry should already been created when argo workflow CR is created.
-		if updateError := r.runStore.UpdateRun(runId, string(condition), execStatus.FinishedAt(), execSpec.ToStringForStore()); updateError != nil {
-			if !util.IsUserErrorCodeMatch(updateError, codes.NotFound) {
-				return util.Wrap(updateError, "Failed to update the run.")
-			}
-			// Handle run not found in run store error.
-			// To avoid letting the workflow leak for ever, we need to GC it when its record does not exist in KFP DB.
-			glog.Errorf("Cannot find reported workflow name=%q namespace=%q runId=%q in run store. "+
-				"Deleting the workflow to avoid resource leaking. "+
-				"This can be caused by installing two KFP instances that try to manage the same workflows "+
-				"or an unknown bug. If you encounter this, recommend reporting more details in https://github.com/kubeflow/pipelines/issues/6189.",
-				execSpec.ExecutionName(), execSpec.ExecutionNamespace(), runId)
-			if err := r.getWorkflowClient(execSpec.ExecutionNamespace()).Delete(ctx, execSpec.ExecutionName(), v1.DeleteOptions{}); err != nil {
-				if util.IsNotFound(err) {
-					return util.NewNotFoundError(err, "Failed to delete the obsolete workflow for run %s", runId)
-				}
-				return util.NewInternalServerError(err, "Failed to delete the obsolete workflow for run %s", runId)
-			}
-			// TODO(jingzhang36): find a proper way to pass collectMetricsFlag here.
-			workflowGCCounter.Inc()
-			// Note, persistence agent will not retry reporting this workflow again, because updateError is a not found error.
-			return util.Wrapf(updateError, "Failed to report workflow name=%q namespace=%q runId=%q", execSpec.ExecutionName(), execSpec.ExecutionNamespace(), runId)
-		}
-	} else {
-		// Get the experiment resource reference for job.
-		experimentRef, err := r.resourceReferenceStore.GetResourceReference(jobId, common.Job, common.Experiment)
-		if err != nil {
-			return util.Wrap(err, "Failed to retrieve the experiment ID for the job that created the run.")
-		}
-		jobName, err := r.getResourceName(common.Job, jobId)
-		if err != nil {
-			return util.Wrap(err, "Failed to retrieve the job name for the job that created the run.")
-		}
-		// Scheduled time equals created time if it is not specified
-		var scheduledTimeInSec int64
-		if execSpec.ScheduledAtInSecOr0() == 0 {
-			scheduledTimeInSec = objMeta.CreationTimestamp.Unix()
-		} else {
-			scheduledTimeInSec = execSpec.ScheduledAtInSecOr0()
-		}
-		runDetail := &model.RunDetail{
-			Run: model.Run{
-				UUID:             runId,
-				ExperimentUUID:   experimentRef.ReferenceUUID,
-				DisplayName:      execSpec.ExecutionName(),
-				Name:             execSpec.ExecutionName(),
-				StorageState:     apiv1beta1.Run_STORAGESTATE_AVAILABLE.String(),
-				Namespace:        execSpec.ExecutionNamespace(),
-				CreatedAtInSec:   objMeta.CreationTimestamp.Unix(),
-				ScheduledAtInSec: scheduledTimeInSec,
-				FinishedAtInSec:  execStatus.FinishedAt(),
-				Conditions:       string(condition),
-				PipelineSpec: model.PipelineSpec{
-					WorkflowSpecManifest: execSpec.GetExecutionSpec().ToStringForStore(),
-				},
-				ResourceReferences: []*model.ResourceReference{
-					{
-						ResourceUUID:  runId,
-						ResourceType:  common.Run,
-						ReferenceUUID: jobId,
-						ReferenceName: jobName,
-						ReferenceType: common.Job,
-						Relationship:  common.Creator,
+		return &workflowReport{
+			execSpec: execSpec,
+			update: &storage.RunStatusUpdate{
+				Run: &model.RunDetail{
+					Run: model.Run{
+						UUID:              runId,
+						Labels:            runLabelsOf(execSpec),
+						FinishedAtInSec:   execStatus.FinishedAt(),
+						StartedAtInSec:    execStatus.StartedAt(),
+						PodStartedAtInSec: execStatus.FirstPodStartedAt(),
+						Conditions:        string(condition),
 					},
-					{
-						ResourceUUID:  runId,
-						ResourceType:  common.Run,
-						ReferenceUUID: experimentRef.ReferenceUUID,
-						ReferenceName: experimentRef.ReferenceName,
-						ReferenceType: common.Experiment,
-						Relationship:  common.Owner,
+					PipelineRuntime: model.PipelineRuntime{
+						WorkflowRuntimeManifest: execSpec.ToStringForStore(),
 					},
 				},
 			},
-			PipelineRuntime: model.PipelineRuntime{
-				WorkflowRuntimeManifest: execSpec.ToStringForStore(),
+		}, nil
+	}
+	// Get the experiment resource reference for job.
+	experimentRef, err := r.resourceReferenceStore.GetResourceReference(jobId, common.Job, common.Experiment)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to retrieve the experiment ID for the job that created the run.")
+	}
+	jobName, err := r.getResourceName(common.Job, jobId)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to retrieve the job name for the job that created the run.")
+	}
+	// Scheduled time equals created time if it is not specified
+	var scheduledTimeInSec int64
+	if execSpec.ScheduledAtInSecOr0() == 0 {
+		scheduledTimeInSec = objMeta.CreationTimestamp.Unix()
+	} else {
+		scheduledTimeInSec = execSpec.ScheduledAtInSecOr0()
+	}
+	runDetail := &model.RunDetail{
+		Run: model.Run{
+			UUID:             runId,
+			ExperimentUUID:   experimentRef.ReferenceUUID,
+			DisplayName:      execSpec.ExecutionName(),
+			Name:             execSpec.ExecutionName(),
+			StorageState:     apiv1beta1.Run_STORAGESTATE_AVAILABLE.String(),
+			Namespace:        execSpec.ExecutionNamespace(),
+			WorkflowUID:      execSpec.ExecutionUID(),
+			TTLSeconds:       ttlSecondsOf(execSpec),
+			Labels:           runLabelsOf(execSpec),
+			CreatedAtInSec:   objMeta.CreationTimestamp.Unix(),
+			ScheduledAtInSec: scheduledTimeInSec,
+			FinishedAtInSec:  execStatus.FinishedAt(),
+			Conditions:       string(condition),
+			PipelineSpec: model.PipelineSpec{
+				WorkflowSpecManifest: execSpec.GetExecutionSpec().ToStringForStore(),
+			},
+			ResourceReferences: []*model.ResourceReference{
+				{
+					ResourceUUID:  runId,
+					ResourceType:  common.Run,
+					ReferenceUUID: jobId,
+					ReferenceName: jobName,
+					ReferenceType: common.Job,
+					Relationship:  common.Creator,
+				},
+				{
+					ResourceUUID:  runId,
+					ResourceType:  common.Run,
+					ReferenceUUID: experimentRef.ReferenceUUID,
+					ReferenceName: experimentRef.ReferenceName,
+					ReferenceType: common.Experiment,
+					Relationship:  common.Owner,
+				},
 			},
-		}
-		err = r.runStore.CreateOrUpdateRun(runDetail)
-		if err != nil {
+		},
+		PipelineRuntime: model.PipelineRuntime{
+			WorkflowRuntimeManifest: execSpec.ToStringForStore(),
+		},
+	}
+	runDetail.StartedAtInSec = execStatus.StartedAt()
+	runDetail.PodStartedAtInSec = execStatus.FirstPodStartedAt()
+	return &workflowReport{
+		execSpec: execSpec,
+		update:   &storage.RunStatusUpdate{Run: runDetail, CreateIfMissing: true},
+	}, nil
+}
+
+// storeWorkflowReport stores the status of the run of a single reported workflow.
+func (r *ResourceManager) storeWorkflowReport(report *workflowReport) error {
+	run := report.update.Run
+	if report.update.CreateIfMissing {
+		if err := r.runStore.CreateOrUpdateRun(run); err != nil {
 			return util.Wrap(err, "Failed to create or update the run.")
 		}
+		return nil
+	}
+	if err := r.runStore.UpdateRun(run.UUID, run.Conditions, run.FinishedAtInSec, run.WorkflowRuntimeManifest); err != nil {
+		if util.IsUserErrorCodeMatch(err, codes.NotFound) {
+			return err
+		}
+		return util.Wrap(err, "Failed to update the run.")
+	}
+	if err := r.runStore.UpdateRunStartTimes(run.UUID, run.StartedAtInSec, run.PodStartedAtInSec); err != nil {
+		return util.Wrap(err, "Failed to update the start times of the run.")
+	}
+	if err := r.runStore.UpdateRunLabels(run.UUID, run.Labels); err != nil {
+		return util.Wrap(err, "Failed to update the labels of the run.")
+	}
+	return nil
+}
+
+// finishWorkflowReport handles the result of storing the status of the run of a reported workflow. Workflows
+// of one-time runs that aren't in the run store are deleted, and workflows in their final state are labeled as
+// persisted.
+func (r *ResourceManager) finishWorkflowReport(ctx context.Context, report *workflowReport, storeErr error) error {
+	execSpec := report.execSpec
+	runId := report.update.Run.UUID
+	if storeErr != nil {
+		if report.update.CreateIfMissing || !util.IsUserErrorCodeMatch(storeErr, codes.NotFound) {
+			return storeErr
+		}
+		// Handle run not found in run store error.
+		// To avoid letting the workflow leak for ever, we need to GC it when its record does not exist in KFP DB.
+		glog.Errorf("Cannot find reported workflow name=%q namespace=%q runId=%q in run store. "+
+			"Deleting the workflow to avoid resource leaking. "+
+			"This can be caused by installing two KFP instances that try to manage the same workflows "+
+			"or an unknown bug. If you encounter this, recommend reporting more details in https://github.com/kubeflow/pipelines/issues/6189.",
+			execSpec.ExecutionName(), execSpec.ExecutionNamespace(), runId)
+		if err := r.getWorkflowClient(execSpec.ExecutionNamespace()).Delete(ctx, execSpec.ExecutionName(), v1.DeleteOptions{}); err != nil {
+			if util.IsNotFound(err) {
+				return util.NewNotFoundError(err, "Failed to delete the obsolete workflow for run %s", runId)
+			}
+			return util.NewInternalServerError(err, "Failed to delete the obsolete workflow for run %s", runId)
+		}
+		// TODO(jingzhang36): find a proper way to pass collectMetricsFlag here.
+		workflowGCCounter.Inc()
+		// Note, persistence agent will not retry reporting this workflow again, because updateError is a not found error.
+		return util.Wrapf(storeErr, "Failed to report workflow name=%q namespace=%q runId=%q", execSpec.ExecutionName(), execSpec.ExecutionNamespace(), runId)
+	}
+
+	// The status history is recorded before the workflow can be labeled as persisted, so that a failure is
+	// retried by the persistence agent.
+	added, err := r.recordRunStatusEvent(report.update.Run)
+	if err != nil {
+		return err
+	}
+	// The terminal state is only added to the history once, so the webhook isn't delivered again when the
+	// workflow is reported again. Delivery failures are recorded on the run rather than failing the report.
+	if added && execSpec.ExecutionStatus().IsInFinalState() {
+		r.notifyRunCompletion(runId)
 	}
 
-	if execStatus.IsInFinalState() {
+	if execSpec.ExecutionStatus().IsInFinalState() {
+		// The snapshot is stored before the workflow is labeled as persisted, since it can be garbage collected
+		// at any time afterwards.
+		if !execSpec.PersistedFinalState() {
+			if err := r.storeRunStatusSnapshot(runId, execSpec); err != nil {
+				return err
+			}
+		}
 		err := AddWorkflowLabel(ctx, r.getWorkflowClient(execSpec.ExecutionNamespace()), execSpec.ExecutionName(), util.LabelKeyWorkflowPersistedFinalState, "true")
 		if err != nil {
 			message := fmt.Sprintf("Failed to add PersistedFinalState label to workflow %s", execSpec.ExecutionName())
@@ -944,6 +3497,32 @@ func (r *ResourceManager) ReportWorkflowResource(ctx context.Context, execSpec u
 	return nil
 }
 
+// recordRunStatusEvent adds the state of a reported run to the status history of the run, unless it is the
+// state the run was last in, and returns whether it was added.
+func (r *ResourceManager) recordRunStatusEvent(run *model.RunDetail) (bool, error) {
+	state := model.RunStateFromConditions(run.Conditions)
+	added, err := r.runStore.AppendRunStatusEvent(run.UUID, state, r.time.Now().Unix(), common.GetMaxRunStatusEvents())
+	if err != nil {
+		return false, util.Wrapf(err, "Failed to record the status history of run %v", run.UUID)
+	}
+	return added, nil
+}
+
+// storeRunStatusSnapshot persists the compressed final status of the workflow of a run.
+func (r *ResourceManager) storeRunStatusSnapshot(runId string, execSpec util.ExecutionSpec) error {
+	if err := execSpec.Decompress(); err != nil {
+		return util.NewInternalServerError(err, "Failed to decompress the status of workflow %v", execSpec.ExecutionName())
+	}
+	snapshot, err := execSpec.ExecutionStatus().StatusSnapshot()
+	if err != nil {
+		return util.Wrap(err, "Failed to snapshot the status of the run.")
+	}
+	if err := r.runStore.UpdateRunStatusSnapshot(runId, snapshot); err != nil {
+		return util.Wrap(err, "Failed to store the status snapshot of the run.")
+	}
+	return nil
+}
+
 // AddWorkflowLabel add label for a workflow
 func AddWorkflowLabel(ctx context.Context, wfClient util.ExecutionInterface, name string, labelKey string, labelValue string) error {
 	patchObj := map[string]interface{}{
@@ -1052,6 +3631,12 @@ func getManifestBytesfromAPIRunInterface(apiRunInterface interface{}, r *Resourc
 	switch apiRunInterface.(type) {
 	case *apiv1beta1.Run:
 		apiRun := apiRunInterface.(*apiv1beta1.Run)
+		if err := validateManifestSize("workflow manifest", len(apiRun.GetPipelineSpec().GetWorkflowManifest())); err != nil {
+			return nil, err
+		}
+		if err := validateManifestSize("pipeline manifest", len(apiRun.GetPipelineSpec().GetPipelineManifest())); err != nil {
+			return nil, err
+		}
 		manifestBytes, err = getManifestBytesV1(apiRun.PipelineSpec, &apiRun.ResourceReferences, r)
 		if err != nil {
 			return nil, util.Wrap(err, "Cannot get manifest bytes.")
@@ -1068,6 +3653,9 @@ func getManifestBytesfromAPIRunInterface(apiRunInterface interface{}, r *Resourc
 			if err != nil {
 				return nil, util.Wrap(err, "Cannot marshal PipelineSpec.")
 			}
+			if err := validateManifestSize("pipeline spec", len(manifestBytes)); err != nil {
+				return nil, err
+			}
 		}
 	default:
 		return nil, util.Wrap(err, "Wrong api run interface type")
@@ -1119,7 +3707,7 @@ func (r *ResourceManager) CreateDefaultExperiment() (string, error) {
 
 	// Create default experiment
 	defaultExperiment := &apiv1beta1.Experiment{
-		Name:        "Default",
+		Name:        defaultExperimentName,
 		Description: "All runs created without specifying an experiment will be grouped here.",
 	}
 	experiment, err := r.CreateExperiment(defaultExperiment)
@@ -1127,29 +3715,110 @@ func (r *ResourceManager) CreateDefaultExperiment() (string, error) {
 		return "", fmt.Errorf("Failed to create default experiment. Err: %v", err)
 	}
 
-	// Set default experiment ID in the DB
-	err = r.SetDefaultExperimentId(experiment.UUID)
+	// Set default experiment ID in the DB
+	err = r.SetDefaultExperimentId(experiment.UUID)
+	if err != nil {
+		return "", fmt.Errorf("Failed to set default experiment ID. Err: %v", err)
+	}
+
+	glog.Infof("Default experiment is set. ID is: %v", experiment.UUID)
+	return experiment.UUID, nil
+}
+
+// getDefaultExperimentIfNoExperiment If the provided run does not include a reference to a containing
+// experiment, then we fetch the default experiment's ID and create a reference to that.
+func (r *ResourceManager) getDefaultExperimentIfNoExperiment(references []*apiv1beta1.ResourceReference) (*apiv1beta1.ResourceReference, error) {
+	// First check if there is already a referenced experiment
+	for _, ref := range references {
+		if ref.Key.Type == apiv1beta1.ResourceType_EXPERIMENT && ref.Relationship == apiv1beta1.Relationship_OWNER {
+			return nil, nil
+		}
+	}
+	if common.IsMultiUserMode() {
+		return nil, util.NewInvalidInputError("Experiment is required in resource references.")
+	}
+	return r.getDefaultExperimentResourceReference(references)
+}
+
+// addNamespaceDefaultExperiment makes a run or job created in multi-user mode without an experiment, but
+// with a namespace reference, belong to the default experiment of that namespace. A dry run only looks up the
+// default experiment and leaves the references unchanged if the namespace doesn't have one.
+func (r *ResourceManager) addNamespaceDefaultExperiment(ctx context.Context, references []*apiv1beta1.ResourceReference, dryRun bool) ([]*apiv1beta1.ResourceReference, error) {
+	if !common.IsMultiUserMode() || common.GetExperimentIDFromAPIResourceReferences(references) != "" {
+		return references, nil
+	}
+	namespace := common.GetNamespaceFromAPIResourceReferences(references)
+	if namespace == "" {
+		return references, nil
+	}
+	var experimentId string
+	var err error
+	if dryRun {
+		experimentId, err = r.defaultExperimentStore.GetNamespaceDefaultExperimentId(namespace)
+		if err != nil {
+			return nil, util.Wrapf(err, "Failed to get the default experiment of namespace %v", namespace)
+		}
+		if experimentId == "" {
+			return references, nil
+		}
+	} else {
+		experimentId, err = r.GetOrCreateDefaultExperiment(ctx, namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return append(references, &apiv1beta1.ResourceReference{
+		Key: &apiv1beta1.ResourceKey{
+			Id:   experimentId,
+			Type: apiv1beta1.ResourceType_EXPERIMENT,
+		},
+		Relationship: apiv1beta1.Relationship_OWNER,
+	}), nil
+}
+
+// GetOrCreateDefaultExperiment returns the ID of the default experiment of the namespace. The experiment is
+// created the first time it is asked for. Concurrent calls for the same namespace get the same experiment.
+func (r *ResourceManager) GetOrCreateDefaultExperiment(ctx context.Context, namespace string) (string, error) {
+	unlock := defaultExperimentLocks.lock(namespace)
+	defer unlock()
+
+	experimentId, err := r.defaultExperimentStore.GetNamespaceDefaultExperimentId(namespace)
+	if err != nil {
+		return "", util.Wrapf(err, "Failed to get the default experiment of namespace %v", namespace)
+	}
+	if experimentId != "" {
+		return experimentId, nil
+	}
+
+	glog.Infof("No default experiment was found in namespace %v. Creating a new default experiment", namespace)
+	experiment, err := r.CreateExperiment(&apiv1beta1.Experiment{
+		Name:        defaultExperimentName,
+		Description: "All runs created without specifying an experiment will be grouped here.",
+		ResourceReferences: []*apiv1beta1.ResourceReference{
+			{
+				Key:          &apiv1beta1.ResourceKey{Type: apiv1beta1.ResourceType_NAMESPACE, Id: namespace},
+				Relationship: apiv1beta1.Relationship_OWNER,
+			},
+		},
+	})
+	if util.IsUserErrorCodeMatch(err, codes.AlreadyExists) {
+		// Experiment names are unique in a namespace. The experiment was either created by another API server
+		// replica racing with this one, or by a user, and becomes the default in both cases.
+		experiment, err = r.experimentStore.GetExperimentByNameAndNamespace(defaultExperimentName, namespace)
+	}
 	if err != nil {
-		return "", fmt.Errorf("Failed to set default experiment ID. Err: %v", err)
+		return "", util.Wrapf(err, "Failed to create the default experiment of namespace %v", namespace)
 	}
 
-	glog.Infof("Default experiment is set. ID is: %v", experiment.UUID)
-	return experiment.UUID, nil
-}
-
-// getDefaultExperimentIfNoExperiment If the provided run does not include a reference to a containing
-// experiment, then we fetch the default experiment's ID and create a reference to that.
-func (r *ResourceManager) getDefaultExperimentIfNoExperiment(references []*apiv1beta1.ResourceReference) (*apiv1beta1.ResourceReference, error) {
-	// First check if there is already a referenced experiment
-	for _, ref := range references {
-		if ref.Key.Type == apiv1beta1.ResourceType_EXPERIMENT && ref.Relationship == apiv1beta1.Relationship_OWNER {
-			return nil, nil
-		}
+	err = r.defaultExperimentStore.SetNamespaceDefaultExperimentId(namespace, experiment.UUID)
+	if util.IsUserErrorCodeMatch(err, codes.AlreadyExists) {
+		return r.defaultExperimentStore.GetNamespaceDefaultExperimentId(namespace)
 	}
-	if common.IsMultiUserMode() {
-		return nil, util.NewInvalidInputError("Experiment is required in resource references.")
+	if err != nil {
+		return "", util.Wrapf(err, "Failed to set the default experiment of namespace %v", namespace)
 	}
-	return r.getDefaultExperimentResourceReference(references)
+	glog.Infof("Default experiment of namespace %v is set. ID is: %v", namespace, experiment.UUID)
+	return experiment.UUID, nil
 }
 
 func (r *ResourceManager) getDefaultExperimentResourceReference(references []*apiv1beta1.ResourceReference) (*apiv1beta1.ResourceReference, error) {
@@ -1184,6 +3853,158 @@ func (r *ResourceManager) ReportMetric(metric interface{}, runUUID string) error
 	return r.runStore.ReportMetric(modelRunMetrics)
 }
 
+// runMetricNamePattern matches the metric names the report server accepts.
+var runMetricNamePattern = regexp.MustCompile("^[a-zA-Z]([-_a-zA-Z0-9]{0,62}[a-zA-Z0-9])?$")
+
+// RunMetricsReprocessing is the result of ReprocessRunMetrics.
+type RunMetricsReprocessing struct {
+	// Ingested is the number of metrics stored, or updated because their value changed.
+	Ingested int
+	// Skipped is the number of metrics already stored with the same value, or invalid.
+	Skipped int
+	// Errors are the failures to read or parse the metrics artifacts of some nodes.
+	Errors []error
+}
+
+// ReprocessRunMetrics reads the metrics artifacts of the nodes of a finished run's workflow, live or persisted,
+// and stores the metrics they hold, e.g. for runs that finished before their metrics were reported. Metrics
+// already stored are updated, so it can be called again. Runs that haven't finished return a
+// FailedPrecondition error.
+func (r *ResourceManager) ReprocessRunMetrics(ctx context.Context, runId string) (*RunMetricsReprocessing, error) {
+	runDetail, err := r.checkRunExist(runId)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to reprocess the metrics of run %v", runId)
+	}
+	state := model.RunStateFromConditions(runDetail.Conditions)
+	if model.IsActiveRunState(state) {
+		return nil, util.NewFailedPreconditionError(errors.New("run is not finished"),
+			"Metrics of run %v can only be reprocessed once it finished, it is %v", runId, state)
+	}
+	execSpec, err := r.runWorkflowStatus(ctx, runDetail)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to reprocess the metrics of run %v", runId)
+	}
+	retrieveArtifact := func(request *apiv1beta1.ReadArtifactRequest, user string) (*apiv1beta1.ReadArtifactResponse, error) {
+		key := execSpec.ExecutionStatus().FindObjectStoreArtifactKeyOrEmpty(request.GetNodeId(), request.GetArtifactName())
+		if key == "" {
+			return nil, nil
+		}
+		data, err := r.objectStore.GetFile(key)
+		if err != nil {
+			return nil, err
+		}
+		return &apiv1beta1.ReadArtifactResponse{Data: data}, nil
+	}
+	metrics, errs := execSpec.ExecutionStatus().CollectionMetrics(retrieveArtifact, "")
+
+	stored := map[string]*model.RunMetric{}
+	for _, metric := range runDetail.Metrics {
+		stored[metric.NodeID+"/"+metric.Name] = metric
+	}
+	result := &RunMetricsReprocessing{Errors: errs}
+	for _, metric := range metrics {
+		if !runMetricNamePattern.MatchString(metric.GetName()) || metric.GetNodeId() == "" ||
+			math.IsNaN(metric.GetNumberValue()) || math.IsInf(metric.GetNumberValue(), 0) {
+			result.Skipped++
+			continue
+		}
+		existing, ok := stored[metric.GetNodeId()+"/"+metric.GetName()]
+		if ok && existing.NumberValue == metric.GetNumberValue() && existing.Format == metric.GetFormat().String() {
+			result.Skipped++
+			continue
+		}
+		if err := r.ReportMetric(metric, runId); err != nil {
+			return nil, util.Wrapf(err, "Failed to store metric %v of node %v of run %v", metric.GetName(), metric.GetNodeId(), runId)
+		}
+		result.Ingested++
+	}
+	return result, nil
+}
+
+// ExperimentMetricsSummary aggregates the values of a metric reported by the runs of an experiment.
+type ExperimentMetricsSummary struct {
+	MetricName string
+	// Count is the number of reported values. A run reports one value per node.
+	Count int
+	Min   float64
+	Max   float64
+	Mean  float64
+	// BestRunId is the run that reported the best value: the lowest one if lower values are better,
+	// otherwise the highest one.
+	BestRunId string
+}
+
+// ExperimentMetricsSummaryOptions controls which values are aggregated by GetExperimentMetricsSummary.
+type ExperimentMetricsSummaryOptions struct {
+	// TerminalRunsOnly restricts the summary to runs that have succeeded, failed or errored.
+	TerminalRunsOnly bool
+	// LowerIsBetter picks the run with the lowest value as the best run, e.g. for a loss.
+	LowerIsBetter bool
+}
+
+// terminalRunStates are the states of runs that will not report any more metrics.
+var terminalRunStates = []string{model.RunStateSucceeded, model.RunStateFailed, model.RunStateError}
+
+// GetExperimentMetricsSummary returns the min, max, mean and count of a metric reported by the runs of an
+// experiment, and the run that reported the best value. It fails with a not found error if no run of the
+// experiment ever reported the metric.
+func (r *ResourceManager) GetExperimentMetricsSummary(ctx context.Context, experimentId string, metricName string, opts ExperimentMetricsSummaryOptions) (*ExperimentMetricsSummary, error) {
+	if metricName == "" {
+		return nil, util.NewInvalidInputError("Metric name is required to summarize the metrics of experiment %v", experimentId)
+	}
+	if _, err := r.experimentStore.GetExperiment(experimentId); err != nil {
+		return nil, util.Wrapf(err, "Failed to summarize metric %v of experiment %v", metricName, experimentId)
+	}
+	var states []string
+	if opts.TerminalRunsOnly {
+		states = terminalRunStates
+	}
+	metrics, err := r.runStore.ListExperimentRunMetrics(experimentId, metricName, states)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to summarize metric %v of experiment %v", metricName, experimentId)
+	}
+	if len(metrics) == 0 && len(states) > 0 {
+		// Only report the metric as missing if no run reported it, regardless of the run states.
+		metrics, err = r.runStore.ListExperimentRunMetrics(experimentId, metricName, nil)
+		if err != nil {
+			return nil, util.Wrapf(err, "Failed to summarize metric %v of experiment %v", metricName, experimentId)
+		}
+		if len(metrics) > 0 {
+			return &ExperimentMetricsSummary{MetricName: metricName}, nil
+		}
+	}
+	if len(metrics) == 0 {
+		return nil, util.NewResourceNotFoundError("Metric", fmt.Sprintf("%v in experiment %v", metricName, experimentId))
+	}
+
+	summary := &ExperimentMetricsSummary{
+		MetricName: metricName,
+		Count:      len(metrics),
+		Min:        metrics[0].NumberValue,
+		Max:        metrics[0].NumberValue,
+		BestRunId:  metrics[0].RunUUID,
+	}
+	sum := 0.0
+	for _, metric := range metrics {
+		value := metric.NumberValue
+		sum += value
+		if value < summary.Min {
+			summary.Min = value
+			if opts.LowerIsBetter {
+				summary.BestRunId = metric.RunUUID
+			}
+		}
+		if value > summary.Max {
+			summary.Max = value
+			if !opts.LowerIsBetter {
+				summary.BestRunId = metric.RunUUID
+			}
+		}
+	}
+	summary.Mean = sum / float64(len(metrics))
+	return summary, nil
+}
+
 // ReadArtifact parses run's workflow to find artifact file path and reads the content of the file
 // from object store.
 func (r *ResourceManager) ReadArtifact(runID string, nodeID string, artifactName string) ([]byte, error) {
@@ -1224,6 +4045,128 @@ func (r *ResourceManager) MarkSampleLoaded() error {
 	return r.dBStatusStore.MarkSampleLoaded()
 }
 
+// healthCheckTimeout bounds how long HealthCheck waits for each dependency. It is shorter than the timeout of
+// the readiness probe of the API server.
+const healthCheckTimeout = time.Second
+
+// DependencyStatus is the result of checking that the API server can reach one of its dependencies.
+type DependencyStatus struct {
+	Name string `json:"name"`
+	// Required is set for the dependencies the API server can't serve traffic without.
+	Required  bool   `json:"required"`
+	Healthy   bool   `json:"healthy"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+// HealthStatus is the result of checking the dependencies of the API server.
+type HealthStatus struct {
+	// Ready is set when all the required dependencies are healthy and the server isn't shutting down.
+	Ready bool `json:"ready"`
+	// Draining is set once the server is shutting down, so that it stops receiving traffic.
+	Draining     bool                `json:"draining"`
+	Dependencies []*DependencyStatus `json:"dependencies"`
+}
+
+// HealthCheck checks, concurrently, that the API server can reach the database, the bucket of the object
+// store and the Kubernetes API. The object store only holds pipeline files and archived logs, so the API
+// server can still serve most requests without it, and it isn't required to be ready. A server that is
+// shutting down isn't ready either.
+func (r *ResourceManager) HealthCheck(ctx context.Context) *HealthStatus {
+	checks := []struct {
+		name     string
+		required bool
+		check    func(ctx context.Context) error
+	}{
+		{"database", true, r.dBStatusStore.CheckConnection},
+		{"object_store", false, r.objectStore.CheckBucket},
+		{"kubernetes", true, func(ctx context.Context) error {
+			_, err := r.k8sCoreClient.PodClient(common.GetPodNamespace()).List(ctx, v1.ListOptions{Limit: 1})
+			return err
+		}},
+	}
+	status := &HealthStatus{Ready: true, Dependencies: make([]*DependencyStatus, len(checks))}
+	var wg sync.WaitGroup
+	for i := range checks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+			start := time.Now()
+			err := checks[i].check(checkCtx)
+			dependency := &DependencyStatus{
+				Name:      checks[i].name,
+				Required:  checks[i].required,
+				Healthy:   err == nil,
+				LatencyMs: time.Since(start).Milliseconds(),
+			}
+			if err != nil {
+				dependency.Error = err.Error()
+			}
+			status.Dependencies[i] = dependency
+		}(i)
+	}
+	wg.Wait()
+	for _, dependency := range status.Dependencies {
+		if dependency.Required && !dependency.Healthy {
+			glog.Warningf("Required dependency %v is unhealthy: %v", dependency.Name, dependency.Error)
+			status.Ready = false
+		}
+	}
+	if common.IsDraining() {
+		status.Ready = false
+		status.Draining = true
+	}
+	return status
+}
+
+// ServerConfiguration is the configuration of the API server that clients may adapt to. It only holds values
+// that are safe to expose to any user: no credentials, and no hosts of the services the server itself uses.
+type ServerConfiguration struct {
+	MultiUser           bool `json:"multi_user"`
+	MultiUserSharedRead bool `json:"multi_user_shared_read"`
+	ReadOnly            bool `json:"read_only"`
+	CacheEnabled        bool `json:"cache_enabled"`
+	// ExperimentSoftDelete is set when deleted experiments can be restored until they are reaped.
+	ExperimentSoftDelete bool   `json:"experiment_soft_delete"`
+	DefaultPipelineRoot  string `json:"default_pipeline_root"`
+	// MaxUploadBytes is the maximum size of an uploaded pipeline file, or 0 if there is no limit.
+	// MaxPipelineURLBytes is that of a pipeline file downloaded from a URL.
+	MaxUploadBytes      int `json:"max_upload_bytes"`
+	MaxPipelineURLBytes int `json:"max_pipeline_url_bytes"`
+	// PipelineSpecFormats are the formats of the pipelines the server can run, and PipelinePackageFormats those
+	// of the files pipelines can be uploaded as.
+	PipelineSpecFormats    []string `json:"pipeline_spec_formats"`
+	PipelinePackageFormats []string `json:"pipeline_package_formats"`
+}
+
+// GetServerConfiguration returns the effective configuration of the API server, for clients to discover what it
+// supports rather than hardcode it.
+func (r *ResourceManager) GetServerConfiguration(ctx context.Context) *ServerConfiguration {
+	defaultPipelineRoot := ""
+	// The root is only exposed in its normalized form, which can't carry credentials.
+	if root, err := util.ParseArtifactRoot(common.GetDefaultPipelineRoot()); err == nil {
+		defaultPipelineRoot = root.URI()
+	}
+	maxUploadBytes := common.GetMaxManifestBytes()
+	if maxUploadBytes < 0 {
+		maxUploadBytes = 0
+	}
+	return &ServerConfiguration{
+		MultiUser:              common.IsMultiUserMode(),
+		MultiUserSharedRead:    common.IsMultiUserMode() && common.IsMultiUserSharedReadMode(),
+		ReadOnly:               common.IsReadOnlyMode(),
+		CacheEnabled:           common.GetBoolFromStringWithDefault(common.IsCacheEnabled(), true),
+		ExperimentSoftDelete:   common.IsExperimentSoftDeleteEnabled(),
+		DefaultPipelineRoot:    defaultPipelineRoot,
+		MaxUploadBytes:         maxUploadBytes,
+		MaxPipelineURLBytes:    common.GetPipelineURLMaxBytes(),
+		PipelineSpecFormats:    []string{string(template.V1), string(template.V2)},
+		PipelinePackageFormats: []string{"yaml", "json", "zip", "tar.gz", "tar"},
+	}
+}
+
 func (r *ResourceManager) CreatePipelineVersion(apiVersion *apiv1beta1.PipelineVersion, pipelineFile []byte, updateDefaultVersion bool) (*model.PipelineVersion, error) {
 	// Extract pipeline id
 	var pipelineId = ""
@@ -1235,30 +4178,14 @@ func (r *ResourceManager) CreatePipelineVersion(apiVersion *apiv1beta1.PipelineV
 	if len(pipelineId) == 0 {
 		return nil, util.NewInvalidInputError("Create pipeline version failed due to missing pipeline id")
 	}
-	tmpl, err := template.New(pipelineFile)
-	if err != nil {
-		return nil, util.Wrap(err, "Create pipeline version failed")
-	}
-	if tmpl.IsV2() {
-		pipeline, err := r.GetPipeline(pipelineId)
-		if err != nil {
-			return nil, util.Wrap(err, "Create pipeline version failed")
-		}
-		tmpl.OverrideV2PipelineName(pipeline.Name, pipeline.Namespace)
-	}
-	paramsJSON, err := tmpl.ParametersJSON()
+	version, tmpl, err := r.newModelPipelineVersion(pipelineId, pipelineFile)
 	if err != nil {
 		return nil, util.Wrap(err, "Create pipeline version failed")
 	}
-	// Construct model.PipelineVersion
-	version := &model.PipelineVersion{
-		Name:          apiVersion.Name,
-		PipelineId:    pipelineId,
-		Status:        model.PipelineVersionCreating,
-		Parameters:    paramsJSON,
-		CodeSourceUrl: apiVersion.CodeSourceUrl,
-		Description:   apiVersion.Description,
-	}
+	version.Name = apiVersion.Name
+	version.CodeSourceUrl = apiVersion.CodeSourceUrl
+	version.PackageUrl = apiVersion.GetPackageUrl().GetPipelineUrl()
+	version.Description = apiVersion.Description
 	version, err = r.pipelineStore.CreatePipelineVersion(version, updateDefaultVersion)
 	if err != nil {
 		return nil, util.Wrap(err, "Create pipeline version failed")
@@ -1281,53 +4208,471 @@ func (r *ResourceManager) CreatePipelineVersion(apiVersion *apiv1beta1.PipelineV
 	return version, nil
 }
 
+// newModelPipelineVersion validates a pipeline file and returns a version of the pipeline, in the creating
+// status, holding its parameters, along with the template of the file. A zip file or a tarball is assembled
+// into the manifest of the version first.
+func (r *ResourceManager) newModelPipelineVersion(pipelineId string, pipelineFile []byte) (*model.PipelineVersion, template.Template, error) {
+	packageFormat := ""
+	if template.IsArchive(pipelineFile) {
+		manifest, err := template.AssembleArchive(pipelineFile, common.GetMaxManifestBytes())
+		if err != nil {
+			return nil, nil, err
+		}
+		pipelineFile = manifest
+		packageFormat = model.PipelineVersionPackageFormatArchive
+	}
+	if err := validateManifestSize("pipeline file", len(pipelineFile)); err != nil {
+		return nil, nil, err
+	}
+	tmpl, err := template.New(pipelineFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tmpl.IsV2() {
+		pipeline, err := r.GetPipeline(pipelineId)
+		if err != nil {
+			return nil, nil, err
+		}
+		tmpl.OverrideV2PipelineName(pipeline.Name, pipeline.Namespace)
+	}
+	paramsJSON, err := tmpl.ParametersJSON()
+	if err != nil {
+		return nil, nil, err
+	}
+	schemaJSON, err := tmpl.ParameterSchema().JSON()
+	if err != nil {
+		return nil, nil, err
+	}
+	version := &model.PipelineVersion{
+		PipelineId:      pipelineId,
+		Status:          model.PipelineVersionCreating,
+		Parameters:      paramsJSON,
+		ParameterSchema: schemaJSON,
+		PackageFormat:   packageFormat,
+	}
+	return version, tmpl, nil
+}
+
+// ValidatePipelineSpec checks a pipeline file the way creating a pipeline version does, without storing
+// anything, and returns every problem found. The file would be accepted if none of the diagnostics is an
+// error. In multi-user mode, the request must be authenticated.
+func (r *ResourceManager) ValidatePipelineSpec(ctx context.Context, pipelineFile []byte) ([]*template.Diagnostic, error) {
+	if common.IsMultiUserMode() {
+		if _, err := r.AuthenticateRequest(ctx); err != nil {
+			return nil, util.Wrap(err, "Failed to validate the pipeline spec")
+		}
+	}
+	if err := validateManifestSize("pipeline file", len(pipelineFile)); err != nil {
+		message := err.Error()
+		if userErr, ok := err.(*util.UserError); ok {
+			message = userErr.ExternalMessage()
+		}
+		return []*template.Diagnostic{{Severity: template.DiagnosticError, Message: message}}, nil
+	}
+	return template.Diagnose(pipelineFile), nil
+}
+
+// PipelineVersionUpload is a version to create from an uploaded pipeline file.
+type PipelineVersionUpload struct {
+	Name         string
+	Description  string
+	PipelineFile []byte
+}
+
+// CreatePipelineVersions creates versions of a pipeline from uploaded files, all of them or none. Every file
+// is validated, and the names must be unique within the batch, before any version is created. The created
+// versions are returned in the order of the uploads, and the last one becomes the default version of the
+// pipeline if updateDefaultVersion is set.
+func (r *ResourceManager) CreatePipelineVersions(pipelineId string, uploads []*PipelineVersionUpload, updateDefaultVersion bool) ([]*model.PipelineVersion, error) {
+	if len(uploads) == 0 {
+		return nil, util.NewInvalidInputError("Create pipeline versions failed: no pipeline file is uploaded")
+	}
+	if _, err := r.GetPipeline(pipelineId); err != nil {
+		return nil, util.Wrap(err, "Create pipeline versions failed")
+	}
+	names := make(map[string]bool, len(uploads))
+	for _, upload := range uploads {
+		if names[upload.Name] {
+			return nil, util.NewInvalidInputError("Create pipeline versions failed: version name %v is given more than once", upload.Name)
+		}
+		names[upload.Name] = true
+	}
+	versions := make([]*model.PipelineVersion, 0, len(uploads))
+	templates := make([]template.Template, 0, len(uploads))
+	for _, upload := range uploads {
+		version, tmpl, err := r.newModelPipelineVersion(pipelineId, upload.PipelineFile)
+		if err != nil {
+			return nil, util.Wrapf(err, "Create pipeline versions failed: version %v is invalid", upload.Name)
+		}
+		version.Name = upload.Name
+		version.Description = upload.Description
+		versions = append(versions, version)
+		templates = append(templates, tmpl)
+	}
+	versions, err := r.pipelineStore.CreatePipelineVersions(versions, updateDefaultVersion)
+	if err != nil {
+		return nil, util.Wrap(err, "Create pipeline versions failed")
+	}
+
+	for i, version := range versions {
+		err = r.objectStore.AddFile(templates[i].Bytes(), r.objectStore.GetPipelineKey(fmt.Sprint(version.UUID)))
+		if err != nil {
+			// Don't leave part of the batch behind.
+			for _, created := range versions {
+				if deleteErr := r.pipelineStore.DeletePipelineVersion(created.UUID); deleteErr != nil {
+					glog.Errorf("Failed to delete pipeline version %v after a failed batch upload: %v", created.UUID, deleteErr)
+				}
+				r.objectStore.DeleteFile(r.objectStore.GetPipelineKey(fmt.Sprint(created.UUID)))
+			}
+			return nil, util.Wrap(err, "Create pipeline versions failed")
+		}
+	}
+	for _, version := range versions {
+		version.Status = model.PipelineVersionReady
+		err = r.pipelineStore.UpdatePipelineVersionStatus(version.UUID, version.Status)
+		if err != nil {
+			return nil, util.Wrap(err, "Create pipeline versions failed")
+		}
+	}
+	return versions, nil
+}
+
+// CreatePipelineVersionFromURL downloads the manifest at pipelineURL and creates a version of the given
+// pipeline from it, the same way CreatePipelineVersion does. The URL is recorded on the version.
+func (r *ResourceManager) CreatePipelineVersionFromURL(ctx context.Context, name string, pipelineId string, pipelineURL string) (*model.PipelineVersion, error) {
+	pipelineFile, err := fetchPipelineFile(ctx, pipelineURL)
+	if err != nil {
+		return nil, util.Wrap(err, "Create pipeline version from URL failed")
+	}
+	apiVersion := &apiv1beta1.PipelineVersion{
+		Name:       name,
+		PackageUrl: &apiv1beta1.Url{PipelineUrl: pipelineURL},
+		ResourceReferences: []*apiv1beta1.ResourceReference{
+			{
+				Key:          &apiv1beta1.ResourceKey{Id: pipelineId, Type: apiv1beta1.ResourceType_PIPELINE},
+				Relationship: apiv1beta1.Relationship_OWNER,
+			},
+		},
+	}
+	return r.CreatePipelineVersion(apiVersion, pipelineFile, common.IsPipelineVersionUpdatedByDefault())
+}
+
 func (r *ResourceManager) GetPipelineVersion(versionId string) (*model.PipelineVersion, error) {
 	return r.pipelineStore.GetPipelineVersion(versionId)
 }
 
+// UpdatePipelineVersionDescription changes the description of a pipeline version, e.g. to add release notes
+// after it was uploaded, and returns the updated version. Nothing else of the version changes. In multi-user
+// mode the caller must be allowed to update the pipelines of the namespace the version's pipeline is in,
+// unless the pipeline is shared.
+func (r *ResourceManager) UpdatePipelineVersionDescription(ctx context.Context, versionId string, description string) (*model.PipelineVersion, error) {
+	version, err := r.pipelineStore.GetPipelineVersion(versionId)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to update the description of pipeline version %v", versionId)
+	}
+	if common.IsMultiUserMode() {
+		pipeline, err := r.GetPipeline(version.PipelineId)
+		if err != nil {
+			return nil, util.Wrapf(err, "Failed to update the description of pipeline version %v", versionId)
+		}
+		if pipeline.Namespace != "" {
+			userIdentity, err := r.AuthenticateRequest(ctx)
+			if err != nil {
+				return nil, util.Wrapf(err, "Failed to update the description of pipeline version %v", versionId)
+			}
+			if !common.IsImpersonationAdmin(userIdentity) {
+				err = r.IsRequestAuthorized(ctx, userIdentity, &authorizationv1.ResourceAttributes{
+					Namespace: pipeline.Namespace,
+					Name:      pipeline.Name,
+					Verb:      common.RbacResourceVerbUpdate,
+					Group:     common.RbacPipelinesGroup,
+					Version:   common.RbacPipelinesVersion,
+					Resource:  common.RbacResourceTypePipelines,
+				})
+				if err != nil {
+					return nil, util.Wrapf(err, "Failed to update the description of pipeline version %v", versionId)
+				}
+			}
+		}
+	}
+	if err := r.pipelineStore.UpdatePipelineVersionDescription(versionId, description); err != nil {
+		return nil, util.Wrapf(err, "Failed to update the description of pipeline version %v", versionId)
+	}
+	return r.pipelineStore.GetPipelineVersion(versionId)
+}
+
 func (r *ResourceManager) ListPipelineVersions(pipelineId string, opts *list.Options) (pipelines []*model.PipelineVersion, total_size int, nextPageToken string, err error) {
 	return r.pipelineStore.ListPipelineVersions(pipelineId, opts)
 }
 
+// DeletePipelineVersion deletes a pipeline version, unless runs created from it are still active or jobs
+// were created from it. Finished runs, archived or not, don't block the deletion, and no longer reference
+// the version.
 func (r *ResourceManager) DeletePipelineVersion(pipelineVersionId string) error {
+	_, err := r.DeletePipelineVersionWithOptions(context.Background(), pipelineVersionId, DeletePipelineVersionOptions{})
+	return err
+}
+
+// DeletePipelineVersionOptions are the options of DeletePipelineVersionWithOptions.
+type DeletePipelineVersionOptions struct {
+	// Cascade deletes the version even though active runs or jobs were created from it. The runs are kept,
+	// and the jobs are disabled, but none of them references the version anymore.
+	Cascade bool
+}
+
+// PipelineVersionDeletion counts the runs and jobs that referenced a deleted pipeline version.
+type PipelineVersionDeletion struct {
+	AffectedRuns int
+	AffectedJobs int
+}
+
+// DeletePipelineVersionWithOptions deletes a pipeline version like DeletePipelineVersion does, or, with
+// the Cascade option, whatever runs and jobs were created from it. It returns how many runs and jobs no
+// longer reference the version.
+func (r *ResourceManager) DeletePipelineVersionWithOptions(ctx context.Context, pipelineVersionId string, options DeletePipelineVersionOptions) (*PipelineVersionDeletion, error) {
 	_, err := r.pipelineStore.GetPipelineVersion(pipelineVersionId)
 	if err != nil {
-		return util.Wrap(err, "Delete pipeline version failed")
+		return nil, util.Wrap(err, "Delete pipeline version failed")
+	}
+	activeRunIds, finishedRunCount, err := r.getPipelineVersionRuns(pipelineVersionId)
+	if err != nil {
+		return nil, util.Wrap(err, "Delete pipeline version failed")
+	}
+	jobs, err := r.getPipelineVersionJobs(pipelineVersionId)
+	if err != nil {
+		return nil, util.Wrap(err, "Delete pipeline version failed")
+	}
+	if !options.Cascade && len(activeRunIds) > 0 {
+		reported := activeRunIds
+		if len(reported) > maxReportedActiveRuns {
+			reported = reported[:maxReportedActiveRuns]
+		}
+		return nil, util.NewFailedPreconditionError(errors.New("pipeline version has active runs"),
+			"Pipeline version %v can't be deleted because %v of its runs are still active, including %v. "+
+				"Wait for them to finish or terminate them first", pipelineVersionId, len(activeRunIds),
+			strings.Join(reported, ", "))
+	}
+	if !options.Cascade && len(jobs) > 0 {
+		reported := []string{}
+		for _, job := range jobs {
+			if len(reported) == maxReportedActiveRuns {
+				break
+			}
+			reported = append(reported, job.UUID)
+		}
+		return nil, util.NewFailedPreconditionError(errors.New("pipeline version has jobs"),
+			"Pipeline version %v can't be deleted because %v jobs were created from it, including %v. "+
+				"Delete them first, or delete the version with cascade", pipelineVersionId, len(jobs),
+			strings.Join(reported, ", "))
+	}
+	if err := r.disablePipelineVersionJobs(ctx, jobs); err != nil {
+		return nil, util.Wrap(err, "Delete pipeline version failed")
+	}
+	deletion := &PipelineVersionDeletion{AffectedRuns: len(activeRunIds) + finishedRunCount, AffectedJobs: len(jobs)}
+	if deletion.AffectedRuns > 0 || deletion.AffectedJobs > 0 {
+		glog.Infof("Deleting pipeline version %v, which %v runs and %v jobs were created from",
+			pipelineVersionId, deletion.AffectedRuns, deletion.AffectedJobs)
 	}
 
 	// Mark pipeline as deleting so it's not visible to user.
 	err = r.pipelineStore.UpdatePipelineVersionStatus(pipelineVersionId, model.PipelineVersionDeleting)
 	if err != nil {
-		return util.Wrap(err, "Delete pipeline version failed")
+		return nil, util.Wrap(err, "Delete pipeline version failed")
 	}
 
 	err = r.objectStore.DeleteFile(r.objectStore.GetPipelineKey(fmt.Sprint(pipelineVersionId)))
 	if err != nil {
 		glog.Errorf("%v", errors.Wrapf(err, "Failed to delete pipeline file for pipeline version %v", pipelineVersionId))
-		return util.Wrap(err, "Delete pipeline version failed")
+		return nil, util.Wrap(err, "Delete pipeline version failed")
 	}
 	err = r.pipelineStore.DeletePipelineVersion(pipelineVersionId)
 	if err != nil {
 		glog.Errorf("%v", errors.Wrapf(err, "Failed to delete pipeline DB entry for pipeline %v", pipelineVersionId))
-		return util.Wrap(err, "Delete pipeline version failed")
+		return nil, util.Wrap(err, "Delete pipeline version failed")
+	}
+
+	return deletion, nil
+}
+
+// maxReportedActiveRuns bounds how many of the active runs blocking the deletion of a pipeline version are
+// named in the error.
+const maxReportedActiveRuns = 5
+
+// getPipelineVersionRuns returns the IDs of the active runs created from a pipeline version, and how many of
+// its runs have finished. Archived runs are included.
+func (r *ResourceManager) getPipelineVersionRuns(versionId string) ([]string, int, error) {
+	storageStates := &apiv1beta1.Filter{Predicates: []*apiv1beta1.Predicate{{
+		Key: "storage_state",
+		Op:  apiv1beta1.Predicate_IN,
+		Value: &apiv1beta1.Predicate_StringValues{StringValues: &apiv1beta1.StringValues{Values: []string{
+			apiv1beta1.Run_STORAGESTATE_AVAILABLE.String(), apiv1beta1.Run_STORAGESTATE_ARCHIVED.String()}}},
+	}}}
+	opts, err := list.NewOptions(&model.Run{}, 50, "", storageStates)
+	if err != nil {
+		return nil, 0, util.NewInternalServerError(err,
+			"Failed to create list runs options when listing the runs of a pipeline version")
+	}
+	activeRunIds := []string{}
+	finishedRunCount := 0
+	for {
+		runs, _, newToken, err := r.runStore.ListRuns(&common.FilterContext{
+			ReferenceKey: &common.ReferenceKey{Type: common.PipelineVersion, ID: versionId}}, opts)
+		if err != nil {
+			return nil, 0, util.Wrapf(err, "Failed to list the runs of pipeline version %v", versionId)
+		}
+		for _, run := range runs {
+			if model.IsActiveRunState(model.RunStateFromConditions(run.Conditions)) {
+				activeRunIds = append(activeRunIds, run.UUID)
+			} else {
+				finishedRunCount++
+			}
+		}
+		if newToken == "" {
+			return activeRunIds, finishedRunCount, nil
+		}
+		opts, err = list.NewOptionsFromToken(newToken, 50)
+		if err != nil {
+			return nil, 0, util.NewInternalServerError(err,
+				"Failed to create list runs options from page token when listing the runs of a pipeline version")
+		}
+	}
+}
+
+// getPipelineVersionJobs returns the jobs created from a pipeline version.
+func (r *ResourceManager) getPipelineVersionJobs(versionId string) ([]*model.Job, error) {
+	opts, err := list.NewOptions(&model.Job{}, 50, "", nil)
+	if err != nil {
+		return nil, util.NewInternalServerError(err,
+			"Failed to create list jobs options when listing the jobs of a pipeline version")
+	}
+	jobs := []*model.Job{}
+	for {
+		page, _, newToken, err := r.jobStore.ListJobs(&common.FilterContext{
+			ReferenceKey: &common.ReferenceKey{Type: common.PipelineVersion, ID: versionId}}, opts)
+		if err != nil {
+			return nil, util.Wrapf(err, "Failed to list the jobs of pipeline version %v", versionId)
+		}
+		jobs = append(jobs, page...)
+		if newToken == "" {
+			return jobs, nil
+		}
+		opts, err = list.NewOptionsFromToken(newToken, 50)
+		if err != nil {
+			return nil, util.NewInternalServerError(err,
+				"Failed to create list jobs options from page token when listing the jobs of a pipeline version")
+		}
 	}
+}
 
+// disablePipelineVersionJobs disables the enabled jobs of a pipeline version that is deleted. In multi-user
+// mode, the user must be allowed to disable the jobs of every namespace they are in, or none is disabled.
+func (r *ResourceManager) disablePipelineVersionJobs(ctx context.Context, jobs []*model.Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	if common.IsMultiUserMode() {
+		userIdentity, err := r.AuthenticateRequest(ctx)
+		if err != nil {
+			return err
+		}
+		authorized := map[string]bool{}
+		for _, job := range jobs {
+			if authorized[job.Namespace] {
+				continue
+			}
+			err = r.IsRequestAuthorized(ctx, userIdentity, &authorizationv1.ResourceAttributes{
+				Namespace: job.Namespace,
+				Verb:      common.RbacResourceVerbDisable,
+				Group:     common.RbacPipelinesGroup,
+				Version:   common.RbacPipelinesVersion,
+				Resource:  common.RbacResourceTypeJobs,
+			})
+			if err != nil {
+				return util.Wrapf(err, "Failed to disable the jobs of namespace %v", job.Namespace)
+			}
+			authorized[job.Namespace] = true
+		}
+	}
+	for _, job := range jobs {
+		if !job.Enabled {
+			continue
+		}
+		if _, err := r.UpdateJobEnabled(ctx, job.UUID, false); err != nil {
+			return util.Wrapf(err, "Failed to disable job %v", job.UUID)
+		}
+	}
 	return nil
 }
 
-func (r *ResourceManager) GetPipelineVersionTemplate(versionId string) ([]byte, error) {
+// GetPipelineVersionTemplate returns the template of a pipeline version in the given format. See
+// template.ConvertTemplate for the supported conversions.
+func (r *ResourceManager) GetPipelineVersionTemplate(versionId string, format template.TemplateFormat) ([]byte, error) {
 	// Verify pipeline version exist
 	_, err := r.pipelineStore.GetPipelineVersion(versionId)
 	if err != nil {
 		return nil, util.Wrap(err, "Get pipeline version template failed: cannot get pipeline version")
 	}
 
-	template, err := r.objectStore.GetFile(r.objectStore.GetPipelineKey(fmt.Sprint(versionId)))
+	stored, err := r.objectStore.GetFile(r.objectStore.GetPipelineKey(fmt.Sprint(versionId)))
 	if err != nil {
 		return nil, util.Wrap(err, "Get pipeline version template failed: cannot get file")
 	}
 
-	return template, nil
+	converted, err := template.ConvertTemplate(stored, format)
+	if err != nil {
+		return nil, util.Wrap(err, "Get pipeline version template failed")
+	}
+	return converted, nil
+}
+
+// GetPipelineVersionTemplateReader is GetPipelineVersionTemplate for manifests too large to hold in memory. The
+// raw manifest is read from the object store as the returned reader is read. Converting a manifest to another
+// format takes the whole of it, so converted manifests are read all at once. The caller closes the reader.
+func (r *ResourceManager) GetPipelineVersionTemplateReader(versionId string, format template.TemplateFormat) (io.ReadCloser, error) {
+	if format != "" && format != template.TemplateFormatRaw {
+		converted, err := r.GetPipelineVersionTemplate(versionId, format)
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.NopCloser(bytes.NewReader(converted)), nil
+	}
+	_, err := r.pipelineStore.GetPipelineVersion(versionId)
+	if err != nil {
+		return nil, util.Wrap(err, "Get pipeline version template failed: cannot get pipeline version")
+	}
+	reader, err := r.objectStore.GetFileReader(r.objectStore.GetPipelineKey(fmt.Sprint(versionId)))
+	if err != nil {
+		return nil, util.Wrap(err, "Get pipeline version template failed: cannot get file")
+	}
+	return reader, nil
+}
+
+// ComparePipelineVersions returns what changed in the manifest of pipeline
+// version versionB compared to the one of pipeline version versionA.
+func (r *ResourceManager) ComparePipelineVersions(ctx context.Context, versionA string, versionB string) (*template.ManifestDiff, error) {
+	manifestA, err := r.GetPipelineVersionTemplate(versionA, template.TemplateFormatRaw)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to compare pipeline versions %v and %v", versionA, versionB)
+	}
+	manifestB, err := r.GetPipelineVersionTemplate(versionB, template.TemplateFormatRaw)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to compare pipeline versions %v and %v", versionA, versionB)
+	}
+	return template.CompareManifests(manifestA, manifestB), nil
+}
+
+// GetPipelineVersionDependencies returns the container images, secrets, config maps and volume claims that the
+// manifest of a pipeline version pulls in, with warnings about images referenced by floating tags.
+func (r *ResourceManager) GetPipelineVersionDependencies(ctx context.Context, versionId string) (*template.Dependencies, error) {
+	manifest, err := r.GetPipelineVersionTemplate(versionId, template.TemplateFormatRaw)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to get the dependencies of pipeline version %v", versionId)
+	}
+	dependencies, err := template.ExtractDependencies(manifest)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to get the dependencies of pipeline version %v", versionId)
+	}
+	return dependencies, nil
 }
 
 func (r *ResourceManager) AuthenticateRequest(ctx context.Context) (string, error) {
@@ -1397,7 +4742,7 @@ func (r *ResourceManager) GetNamespaceFromExperimentID(experimentID string) (str
 }
 
 func (r *ResourceManager) GetNamespaceFromRunID(runId string) (string, error) {
-	runDetail, err := r.GetRun(runId)
+	runDetail, err := r.runStore.GetRun(runId)
 	if err != nil {
 		return "", util.Wrap(err, "Failed to get namespace from run id.")
 	}
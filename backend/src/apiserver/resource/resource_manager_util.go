@@ -16,13 +16,36 @@ package resource
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	api "github.com/kubeflow/pipelines/backend/api/v1beta1/go_client"
+	apiv2beta1 "github.com/kubeflow/pipelines/backend/api/v2beta1/go_client"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
+	swfutil "github.com/kubeflow/pipelines/backend/src/crd/controller/scheduledworkflow/util"
+	swfregister "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow"
+	swfapi "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow/v1beta1"
+	"github.com/pkg/errors"
+	"github.com/robfig/cron"
+	"google.golang.org/grpc/metadata"
+	corev1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 func deletePods(ctx context.Context, k8sCoreClient client.KubernetesCoreInterface, podsToDelete []string, namespace string) error {
@@ -52,6 +75,11 @@ func convertPipelineIdToDefaultPipelineVersion(pipelineSpec *api.PipelineSpec, r
 	if err != nil {
 		return util.Wrap(err, "Failed to find the specified pipeline")
 	}
+	// The default version is cleared when the last version of the pipeline is deleted.
+	if pipeline.DefaultVersionId == "" {
+		return util.NewFailedPreconditionError(errors.New("no default version"),
+			"Pipeline %v has no default version. Specify a pipeline version instead", pipeline.UUID)
+	}
 	// Add default pipeline version to resource references
 	*resourceReferences = append(*resourceReferences, &api.ResourceReference{
 		Key:          &api.ResourceKey{Type: api.ResourceType_PIPELINE_VERSION, Id: pipeline.DefaultVersionId},
@@ -59,3 +87,1001 @@ func convertPipelineIdToDefaultPipelineVersion(pipelineSpec *api.PipelineSpec, r
 	})
 	return nil
 }
+
+// Names of the cron fields, in the order expected by the scheduled workflow controller.
+// The controller parses schedules with cron.Parse, which requires the seconds field and
+// makes the day of week field optional, so both 5-field and 6-field schedules are accepted.
+var cronFieldNames = []string{"second", "minute", "hour", "day of month", "month", "day of week"}
+
+// validateCronSchedule parses the cron schedule the same way as the scheduled workflow
+// controller. If the schedule is malformed, the returned error names the offending field.
+func validateCronSchedule(schedule string) error {
+	if _, err := cron.Parse(schedule); err != nil {
+		fields := strings.Fields(schedule)
+		if strings.HasPrefix(schedule, "@") || len(fields) < 5 || len(fields) > len(cronFieldNames) {
+			return util.NewInvalidInputError(
+				"Schedule cron %q is not a supported format(https://godoc.org/github.com/robfig/cron). Error: %v", schedule, err)
+		}
+		// Parse the fields one at a time to find the position of the malformed one.
+		for i := range fields {
+			probe := make([]string, len(fields))
+			for j := range probe {
+				probe[j] = "*"
+			}
+			probe[i] = fields[i]
+			if _, fieldErr := cron.Parse(strings.Join(probe, " ")); fieldErr != nil {
+				return util.NewInvalidInputError(
+					"Schedule cron %q has an invalid %s field %q at position %v. Error: %v",
+					schedule, cronFieldNames[i], fields[i], i+1, fieldErr)
+			}
+		}
+		return util.NewInvalidInputError(
+			"Schedule cron %q is not a supported format(https://godoc.org/github.com/robfig/cron). Error: %v", schedule, err)
+	}
+	return nil
+}
+
+// validateTrigger checks the schedule of a job before it is persisted, so that a broken
+// schedule doesn't surface only later in the scheduled workflow controller.
+func validateTrigger(trigger model.Trigger) error {
+	if trigger.Cron != nil {
+		if err := validateCronSchedule(*trigger.Cron); err != nil {
+			return err
+		}
+		if err := validateScheduleTimeRange(trigger.CronScheduleStartTimeInSec, trigger.CronScheduleEndTimeInSec); err != nil {
+			return err
+		}
+	}
+	if trigger.IntervalSecond != nil {
+		if *trigger.IntervalSecond < 1 {
+			return util.NewInvalidInputError(
+				"Found invalid period schedule interval %v. Set at interval to least 1 second.", *trigger.IntervalSecond)
+		}
+		if err := validateScheduleTimeRange(trigger.PeriodicScheduleStartTimeInSec, trigger.PeriodicScheduleEndTimeInSec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateScheduleTimeRange(startTimeInSec *int64, endTimeInSec *int64) error {
+	if startTimeInSec != nil && endTimeInSec != nil && *startTimeInSec > *endTimeInSec {
+		return util.NewInvalidInputError(
+			"The start time of the schedule (%v) is after its end time (%v).", *startTimeInSec, *endTimeInSec)
+	}
+	return nil
+}
+
+// redirectNotAllowedError is returned when a pipeline URL download is redirected to a host that
+// is not allowed.
+type redirectNotAllowedError struct {
+	host string
+}
+
+func (e *redirectNotAllowedError) Error() string {
+	return fmt.Sprintf("redirect to host %q is not allowed", e.host)
+}
+
+// fetchPipelineFile downloads a pipeline manifest over HTTP(S). Redirects are only followed to the
+// host of pipelineURL or to hosts in the redirect allowlist, and the body must not exceed the
+// configured size limit.
+func fetchPipelineFile(ctx context.Context, pipelineURL string) ([]byte, error) {
+	parsedURL, err := url.ParseRequestURI(pipelineURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+		return nil, util.NewInvalidInputError("Invalid pipeline URL %v. Please specify a valid HTTP(S) URL", pipelineURL)
+	}
+	allowedHosts := map[string]bool{parsedURL.Host: true}
+	for _, host := range common.GetPipelineURLRedirectAllowlist() {
+		allowedHosts[host] = true
+	}
+	httpClient := &http.Client{
+		Timeout: common.GetPipelineURLFetchTimeout(),
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if !allowedHosts[req.URL.Host] {
+				return &redirectNotAllowedError{host: req.URL.Host}
+			}
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return nil
+		},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pipelineURL, nil)
+	if err != nil {
+		return nil, util.NewInvalidInputError("Invalid pipeline URL %v: %v", pipelineURL, err.Error())
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		var redirectErr *redirectNotAllowedError
+		if errors.As(err, &redirectErr) {
+			return nil, util.NewPermissionDeniedError(err, "Failed to download the pipeline from %v: redirect to host %v is not allowed", pipelineURL, redirectErr.host)
+		}
+		return nil, util.NewInternalServerError(err, "Failed to download the pipeline from %v", pipelineURL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, util.NewFailedPreconditionError(errors.Errorf("unexpected status code %v", resp.StatusCode),
+			"Failed to download the pipeline from %v: server responded with %v", pipelineURL, resp.Status)
+	}
+	maxBytes := common.GetPipelineURLMaxBytes()
+	if resp.ContentLength > int64(maxBytes) {
+		return nil, util.NewResourceExhaustedError(errors.Errorf("content length %v", resp.ContentLength),
+			"The pipeline at %v exceeds the maximum size of %v bytes", pipelineURL, maxBytes)
+	}
+	// Read one byte more than the limit to detect bodies without a content length that are too large.
+	pipelineFile, err := ioutil.ReadAll(io.LimitReader(resp.Body, int64(maxBytes)+1))
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to read the pipeline from %v", pipelineURL)
+	}
+	if len(pipelineFile) > maxBytes {
+		return nil, util.NewResourceExhaustedError(errors.New("body too large"),
+			"The pipeline at %v exceeds the maximum size of %v bytes", pipelineURL, maxBytes)
+	}
+	return pipelineFile, nil
+}
+
+// validateManifestSize returns an InvalidInputError if a manifest is larger than the configured limit, so that
+// it is rejected before anything is stored. The limit applies to the raw size, which bounds the compressed size
+// that is stored for runs.
+func validateManifestSize(kind string, size int) error {
+	maxBytes := common.GetMaxManifestBytes()
+	if maxBytes > 0 && size > maxBytes {
+		return util.NewInvalidInputError("The %v is %v bytes, which exceeds the maximum allowed size of %v bytes", kind, size, maxBytes)
+	}
+	return nil
+}
+
+// idempotencyKeyFromContext returns the idempotency key in the incoming gRPC metadata, if any.
+func idempotencyKeyFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(common.IdempotencyKeyHeader)
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(values[0])
+}
+
+// catchUpFromContext returns whether the incoming gRPC metadata asks for a new job to catch up, false if it
+// doesn't say.
+func catchUpFromContext(ctx context.Context) (bool, error) {
+	if ctx == nil {
+		return false, nil
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false, nil
+	}
+	values := md.Get(common.CatchUpHeader)
+	if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+		return false, nil
+	}
+	catchUp, err := strconv.ParseBool(strings.TrimSpace(values[0]))
+	if err != nil {
+		return false, util.NewInvalidInputError("Invalid catch-up %q: must be true or false", values[0])
+	}
+	return catchUp, nil
+}
+
+// serviceAccountOf returns the service account a v1 or v2 run asks to run as, or "" if it uses the default one.
+func serviceAccountOf(apiRunInterface interface{}) string {
+	switch apiRun := apiRunInterface.(type) {
+	case *api.Run:
+		return apiRun.GetServiceAccount()
+	case *apiv2beta1.Run:
+		return apiRun.GetServiceAccount()
+	}
+	return ""
+}
+
+// ttlSecondsAfterFinishedOf returns the workflow TTL set by the ttl_seconds_after_finished field of a v1 run,
+// or else by the incoming gRPC metadata, or nil if the request doesn't override the TTL.
+func ttlSecondsAfterFinishedOf(ctx context.Context, apiRunInterface interface{}) (*int32, error) {
+	apiRun, ok := apiRunInterface.(*api.Run)
+	if !ok || apiRun.GetTtlSecondsAfterFinished() == nil {
+		return ttlSecondsAfterFinishedFromContext(ctx)
+	}
+	ttl := apiRun.GetTtlSecondsAfterFinished().GetValue()
+	if ttl < 0 {
+		return nil, util.NewInvalidInputError("Invalid TTL seconds after finished %v: must not be negative", ttl)
+	}
+	return &ttl, nil
+}
+
+// ttlSecondsAfterFinishedFromContext returns the workflow TTL in the incoming gRPC metadata, or nil if the
+// request doesn't override the TTL.
+func ttlSecondsAfterFinishedFromContext(ctx context.Context) (*int32, error) {
+	if ctx == nil {
+		return nil, nil
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	values := md.Get(common.TTLSecondsAfterFinishedHeader)
+	if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+		return nil, nil
+	}
+	seconds, err := strconv.ParseInt(strings.TrimSpace(values[0]), 10, 32)
+	if err != nil {
+		return nil, util.NewInvalidInputError("Invalid TTL seconds after finished %q: must be an integer", values[0])
+	}
+	if seconds < 0 {
+		return nil, util.NewInvalidInputError("Invalid TTL seconds after finished %v: must not be negative", seconds)
+	}
+	ttl := int32(seconds)
+	return &ttl, nil
+}
+
+// resourceOverridesOf returns the container resources set by the resource_overrides field of a v1 run, or else
+// by the incoming gRPC metadata, keyed by template name, or nil if the request doesn't override any.
+func resourceOverridesOf(ctx context.Context, apiRunInterface interface{}) (map[string]corev1.ResourceRequirements, error) {
+	apiRun, ok := apiRunInterface.(*api.Run)
+	if !ok || len(apiRun.GetResourceOverrides()) == 0 {
+		return resourceOverridesFromContext(ctx)
+	}
+	resources := make(map[string]corev1.ResourceRequirements, len(apiRun.GetResourceOverrides()))
+	for templateName, requirements := range apiRun.GetResourceOverrides() {
+		limits, err := toResourceList(templateName, requirements.GetLimits())
+		if err != nil {
+			return nil, err
+		}
+		requests, err := toResourceList(templateName, requirements.GetRequests())
+		if err != nil {
+			return nil, err
+		}
+		resources[templateName] = corev1.ResourceRequirements{Limits: limits, Requests: requests}
+	}
+	return resources, nil
+}
+
+// toResourceList parses the resource quantities a run sets on the containers of a template.
+func toResourceList(templateName string, quantities map[string]string) (corev1.ResourceList, error) {
+	if len(quantities) == 0 {
+		return nil, nil
+	}
+	list := make(corev1.ResourceList, len(quantities))
+	for name, value := range quantities {
+		quantity, err := k8sresource.ParseQuantity(value)
+		if err != nil {
+			return nil, util.NewInvalidInputError("Invalid quantity %q of resource %v of template %v: %v", value, name, templateName, err)
+		}
+		list[corev1.ResourceName(name)] = quantity
+	}
+	return list, nil
+}
+
+// resourceOverridesFromContext returns the container resources in the incoming gRPC metadata, keyed by
+// template name, or nil if the request doesn't override any.
+func resourceOverridesFromContext(ctx context.Context) (map[string]corev1.ResourceRequirements, error) {
+	if ctx == nil {
+		return nil, nil
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	values := md.Get(common.ResourceOverridesHeader)
+	if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+		return nil, nil
+	}
+	var resources map[string]corev1.ResourceRequirements
+	if err := json.Unmarshal([]byte(values[0]), &resources); err != nil {
+		return nil, util.NewInvalidInputErrorWithDetails(err, "Invalid resource overrides: must map template names to resource requirements")
+	}
+	return resources, nil
+}
+
+// systemWorkflowLabelPrefixes are the prefixes of the workflow labels that Pipelines and Argo manage.
+// They aren't copied to runs.
+var systemWorkflowLabelPrefixes = []string{"pipeline/", "pipelines.kubeflow.org/", swfregister.FullName + "/", "workflows.argoproj.io/"}
+
+// runLabelsOf returns the labels of a workflow that are stored as labels of its run, or nil if there are none.
+func runLabelsOf(execSpec util.ExecutionSpec) map[string]string {
+	var labels map[string]string
+	for key, value := range execSpec.ExecutionObjectMeta().Labels {
+		if isSystemWorkflowLabel(key) {
+			continue
+		}
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[key] = value
+	}
+	return labels
+}
+
+func isSystemWorkflowLabel(key string) bool {
+	for _, prefix := range systemWorkflowLabelPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateExperimentLabels checks that experiment labels are valid Kubernetes labels that can be set on workflows
+// and pods, rather than reserved for Kubeflow or Argo.
+func validateExperimentLabels(labels map[string]string) error {
+	if err := common.ValidateLabels(labels); err != nil {
+		return err
+	}
+	for key := range labels {
+		reserved := isSystemWorkflowLabel(key)
+		if i := strings.Index(key, "/"); i >= 0 {
+			domain := key[:i]
+			for _, reservedDomain := range reservedPodMetadataDomains {
+				reserved = reserved || domain == reservedDomain || strings.HasSuffix(domain, "."+reservedDomain)
+			}
+		}
+		if reserved {
+			return util.NewInvalidInputError("Label key %q is reserved: keys with its prefix are set by Kubeflow Pipelines or Argo", key)
+		}
+	}
+	return nil
+}
+
+// reservedPodMetadataDomains are the prefixes of the label and annotation keys that Kubeflow and Argo set on
+// pods. "pipeline" is the prefix of the run ID label.
+var reservedPodMetadataDomains = []string{"kubeflow.org", "workflows.argoproj.io", "pipeline"}
+
+// runPodMetadata is the metadata a run sets on every pod of its workflow.
+type runPodMetadata struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// podMetadataOf returns the pod labels and annotations set by the pod_metadata field of a v1 run, or else by
+// the incoming gRPC metadata, or nil if the request doesn't set any.
+func podMetadataOf(ctx context.Context, apiRunInterface interface{}) (*runPodMetadata, error) {
+	apiRun, ok := apiRunInterface.(*api.Run)
+	if !ok || apiRun.GetPodMetadata() == nil {
+		return podMetadataFromContext(ctx)
+	}
+	return validatePodMetadata(&runPodMetadata{
+		Labels:      apiRun.GetPodMetadata().GetLabels(),
+		Annotations: apiRun.GetPodMetadata().GetAnnotations(),
+	})
+}
+
+// podMetadataFromContext returns the pod labels and annotations in the incoming gRPC metadata, or nil if the
+// request doesn't set any.
+func podMetadataFromContext(ctx context.Context) (*runPodMetadata, error) {
+	if ctx == nil {
+		return nil, nil
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	values := md.Get(common.PodMetadataHeader)
+	if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+		return nil, nil
+	}
+	var podMetadata runPodMetadata
+	if err := json.Unmarshal([]byte(values[0]), &podMetadata); err != nil {
+		return nil, util.NewInvalidInputErrorWithDetails(err, "Invalid pod metadata: must be an object with labels and annotations")
+	}
+	return validatePodMetadata(&podMetadata)
+}
+
+// validatePodMetadata checks the pod labels and annotations a run sets, or returns nil if it sets none.
+func validatePodMetadata(podMetadata *runPodMetadata) (*runPodMetadata, error) {
+	if len(podMetadata.Labels) == 0 && len(podMetadata.Annotations) == 0 {
+		return nil, nil
+	}
+	for key, value := range podMetadata.Labels {
+		if err := validatePodMetadataKey("label", key); err != nil {
+			return nil, err
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return nil, util.NewInvalidInputError("Invalid value %q of pod label %v: %v", value, key, strings.Join(errs, "; "))
+		}
+	}
+	for key := range podMetadata.Annotations {
+		if err := validatePodMetadataKey("annotation", key); err != nil {
+			return nil, err
+		}
+	}
+	return podMetadata, nil
+}
+
+// validatePodMetadataKey checks that a pod label or annotation key is a valid Kubernetes key that isn't
+// reserved for Kubeflow or Argo.
+func validatePodMetadataKey(kind string, key string) error {
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return util.NewInvalidInputError("Invalid pod %v key %q: %v", kind, key, strings.Join(errs, "; "))
+	}
+	i := strings.Index(key, "/")
+	if i < 0 {
+		return nil
+	}
+	domain := key[:i]
+	for _, reserved := range reservedPodMetadataDomains {
+		if domain == reserved || strings.HasSuffix(domain, "."+reserved) {
+			return util.NewInvalidInputError("Pod %v key %q is reserved: keys prefixed with %v can't be set by a run", kind, key, reserved)
+		}
+	}
+	return nil
+}
+
+// PriorityClassNameOf returns the priority class set by the priority_class_name field of a v1 run, or else by the
+// incoming gRPC metadata, or "" if the request doesn't set one.
+func PriorityClassNameOf(ctx context.Context, apiRunInterface interface{}) (string, error) {
+	if apiRun, ok := apiRunInterface.(*api.Run); ok && strings.TrimSpace(apiRun.GetPriorityClassName()) != "" {
+		return validatePriorityClassName(strings.TrimSpace(apiRun.GetPriorityClassName()))
+	}
+	return PriorityClassNameFromContext(ctx)
+}
+
+// PriorityClassNameFromContext returns the priority class in the incoming gRPC metadata, or "" if the request
+// doesn't set one.
+func PriorityClassNameFromContext(ctx context.Context) (string, error) {
+	if ctx == nil {
+		return "", nil
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+	values := md.Get(common.PriorityClassNameHeader)
+	if len(values) == 0 {
+		return "", nil
+	}
+	name := strings.TrimSpace(values[0])
+	if name == "" {
+		return "", nil
+	}
+	return validatePriorityClassName(name)
+}
+
+// validatePriorityClassName checks that the name of a priority class is a valid DNS subdomain.
+func validatePriorityClassName(name string) (string, error) {
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", util.NewInvalidInputError("Invalid priority class name %q: %v", name, strings.Join(errs, "; "))
+	}
+	return name, nil
+}
+
+// CompletionWebhookOf returns the completion webhook URL set by the completion_webhook field of a v1 run, or else
+// by the incoming gRPC metadata, or "" if the request doesn't set one.
+func CompletionWebhookOf(ctx context.Context, apiRunInterface interface{}) (string, error) {
+	if apiRun, ok := apiRunInterface.(*api.Run); ok && strings.TrimSpace(apiRun.GetCompletionWebhook()) != "" {
+		webhook := strings.TrimSpace(apiRun.GetCompletionWebhook())
+		if err := validateCompletionWebhook(webhook); err != nil {
+			return "", err
+		}
+		return webhook, nil
+	}
+	return CompletionWebhookFromContext(ctx)
+}
+
+// CompletionWebhookFromContext returns the completion webhook URL in the incoming gRPC metadata, or "" if the
+// request doesn't set one.
+func CompletionWebhookFromContext(ctx context.Context) (string, error) {
+	if ctx == nil {
+		return "", nil
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+	values := md.Get(common.CompletionWebhookHeader)
+	if len(values) == 0 {
+		return "", nil
+	}
+	webhook := strings.TrimSpace(values[0])
+	if err := validateCompletionWebhook(webhook); err != nil {
+		return "", err
+	}
+	return webhook, nil
+}
+
+// maxWorkflowNamePrefixLength leaves room for the name the pipeline gives its workflows in the 58 characters
+// Kubernetes keeps of a generated name.
+const maxWorkflowNamePrefixLength = 40
+
+// validateWorkflowNamePrefix checks that a workflow name prefix is a short DNS label. An empty prefix is valid.
+func validateWorkflowNamePrefix(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+	if errs := validation.IsDNS1123Label(prefix); len(errs) > 0 {
+		return util.NewInvalidInputError("Invalid workflow name prefix %q: %v", prefix, strings.Join(errs, "; "))
+	}
+	if len(prefix) > maxWorkflowNamePrefixLength {
+		return util.NewInvalidInputError("Invalid workflow name prefix %q: must be no more than %v characters", prefix, maxWorkflowNamePrefixLength)
+	}
+	return nil
+}
+
+// reservedEnvPrefixes are the prefixes of the names of the environment variables that KFP and Argo set on the
+// containers of a run, which a run can't set itself.
+var reservedEnvPrefixes = []string{"KFP_", "ARGO_"}
+
+// RunEnv is the environment variables a run adds to the containers of the named templates, or of all the
+// templates if none is named.
+type RunEnv struct {
+	Env       []corev1.EnvVar `json:"env"`
+	Templates []string        `json:"templates,omitempty"`
+}
+
+// RunEnvOf returns the environment variables set by the env and env_templates fields of a v1 run, or else by the
+// incoming gRPC metadata, or nil if the request doesn't set any.
+func RunEnvOf(ctx context.Context, apiRunInterface interface{}) (*RunEnv, error) {
+	apiRun, ok := apiRunInterface.(*api.Run)
+	if !ok || len(apiRun.GetEnv()) == 0 {
+		return RunEnvFromContext(ctx)
+	}
+	runEnv := &RunEnv{Templates: apiRun.GetEnvTemplates()}
+	for _, apiEnvVar := range apiRun.GetEnv() {
+		envVar, err := toEnvVar(apiEnvVar)
+		if err != nil {
+			return nil, err
+		}
+		runEnv.Env = append(runEnv.Env, envVar)
+	}
+	return validateRunEnv(runEnv)
+}
+
+// RunEnvFromContext returns the environment variables in the incoming gRPC metadata, or nil if the request
+// doesn't set any.
+func RunEnvFromContext(ctx context.Context) (*RunEnv, error) {
+	if ctx == nil {
+		return nil, nil
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	values := md.Get(common.RunEnvHeader)
+	if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+		return nil, nil
+	}
+	var runEnv RunEnv
+	if err := json.Unmarshal([]byte(values[0]), &runEnv); err != nil {
+		return nil, util.NewInvalidInputErrorWithDetails(err, "Invalid run env: must be an object with a list of environment variables")
+	}
+	return validateRunEnv(&runEnv)
+}
+
+// validateRunEnv checks the environment variables a run adds, or returns nil if it adds none.
+func validateRunEnv(runEnv *RunEnv) (*RunEnv, error) {
+	if len(runEnv.Env) == 0 {
+		return nil, nil
+	}
+	names := make(map[string]bool, len(runEnv.Env))
+	for i := range runEnv.Env {
+		envVar := &runEnv.Env[i]
+		if err := validateEnvVar(envVar); err != nil {
+			return nil, err
+		}
+		if names[envVar.Name] {
+			return nil, util.NewInvalidInputError("Environment variable %v is set more than once", envVar.Name)
+		}
+		names[envVar.Name] = true
+	}
+	for _, name := range runEnv.Templates {
+		if name == "" {
+			return nil, util.NewInvalidInputError("Invalid run env: template names can't be empty")
+		}
+	}
+	return runEnv, nil
+}
+
+// toEnvVar converts an environment variable of a v1 run.
+func toEnvVar(apiEnvVar *api.EnvVar) (corev1.EnvVar, error) {
+	envVar := corev1.EnvVar{Name: apiEnvVar.GetName(), Value: apiEnvVar.GetValue()}
+	source := apiEnvVar.GetValueFrom()
+	if source == nil {
+		return envVar, nil
+	}
+	envVar.ValueFrom = &corev1.EnvVarSource{}
+	if ref := source.GetSecretKeyRef(); ref != nil {
+		envVar.ValueFrom.SecretKeyRef = &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: ref.GetName()},
+			Key:                  ref.GetKey(),
+		}
+		if ref.GetOptional() {
+			optional := true
+			envVar.ValueFrom.SecretKeyRef.Optional = &optional
+		}
+	}
+	if ref := source.GetConfigMapKeyRef(); ref != nil {
+		envVar.ValueFrom.ConfigMapKeyRef = &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: ref.GetName()},
+			Key:                  ref.GetKey(),
+		}
+		if ref.GetOptional() {
+			optional := true
+			envVar.ValueFrom.ConfigMapKeyRef.Optional = &optional
+		}
+	}
+	if ref := source.GetFieldRef(); ref != nil {
+		envVar.ValueFrom.FieldRef = &corev1.ObjectFieldSelector{APIVersion: ref.GetApiVersion(), FieldPath: ref.GetFieldPath()}
+	}
+	if ref := source.GetResourceFieldRef(); ref != nil {
+		envVar.ValueFrom.ResourceFieldRef = &corev1.ResourceFieldSelector{ContainerName: ref.GetContainerName(), Resource: ref.GetResource()}
+		if ref.GetDivisor() != "" {
+			divisor, err := k8sresource.ParseQuantity(ref.GetDivisor())
+			if err != nil {
+				return envVar, util.NewInvalidInputError("Invalid divisor %q of environment variable %v: %v", ref.GetDivisor(), envVar.Name, err)
+			}
+			envVar.ValueFrom.ResourceFieldRef.Divisor = divisor
+		}
+	}
+	return envVar, nil
+}
+
+// RunRetryStrategy is the retry strategy a run sets on the named templates, or on all the templates that run a
+// pod if none is named.
+type RunRetryStrategy struct {
+	util.RetryStrategy
+	Templates []string `json:"templates,omitempty"`
+	// Force replaces the retry strategies the templates have.
+	Force bool `json:"force,omitempty"`
+}
+
+// RetryStrategyOf returns the retry strategy set by the retry_strategy field of a v1 run, or else by the incoming
+// gRPC metadata, or nil if the request doesn't set one.
+func RetryStrategyOf(ctx context.Context, apiRunInterface interface{}) (*RunRetryStrategy, error) {
+	apiRun, ok := apiRunInterface.(*api.Run)
+	if !ok || apiRun.GetRetryStrategy() == nil {
+		return RetryStrategyFromContext(ctx)
+	}
+	apiStrategy := apiRun.GetRetryStrategy()
+	strategy := &RunRetryStrategy{
+		RetryStrategy: util.RetryStrategy{Limit: apiStrategy.GetLimit(), RetryPolicy: apiStrategy.GetRetryPolicy()},
+		Templates:     apiStrategy.GetTemplates(),
+		Force:         apiStrategy.GetForce(),
+	}
+	if backoff := apiStrategy.GetBackoff(); backoff != nil {
+		strategy.Backoff = &util.RetryBackoff{
+			Duration:    backoff.GetDuration(),
+			Factor:      backoff.GetFactor(),
+			MaxDuration: backoff.GetMaxDuration(),
+		}
+	}
+	return validateRetryStrategy(strategy)
+}
+
+// RetryStrategyFromContext returns the retry strategy in the incoming gRPC metadata, or nil if the request
+// doesn't set one.
+func RetryStrategyFromContext(ctx context.Context) (*RunRetryStrategy, error) {
+	if ctx == nil {
+		return nil, nil
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	values := md.Get(common.RetryStrategyHeader)
+	if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+		return nil, nil
+	}
+	var strategy RunRetryStrategy
+	if err := json.Unmarshal([]byte(values[0]), &strategy); err != nil {
+		return nil, util.NewInvalidInputErrorWithDetails(err, "Invalid retry strategy: must be an object with a limit, a retry policy and a backoff")
+	}
+	return validateRetryStrategy(&strategy)
+}
+
+// validateRetryStrategy checks the retry strategy a run sets.
+func validateRetryStrategy(strategy *RunRetryStrategy) (*RunRetryStrategy, error) {
+	if err := strategy.Validate(); err != nil {
+		return nil, err
+	}
+	for _, name := range strategy.Templates {
+		if name == "" {
+			return nil, util.NewInvalidInputError("Invalid retry strategy: template names can't be empty")
+		}
+	}
+	return strategy, nil
+}
+
+// validateEnvVar checks an environment variable a run adds. Its value is either given or read from a single
+// source, e.g. a key of a secret of the run's namespace.
+func validateEnvVar(envVar *corev1.EnvVar) error {
+	if errs := validation.IsEnvVarName(envVar.Name); len(errs) > 0 {
+		return util.NewInvalidInputError("Invalid environment variable name %q: %v", envVar.Name, strings.Join(errs, "; "))
+	}
+	for _, prefix := range reservedEnvPrefixes {
+		if strings.HasPrefix(envVar.Name, prefix) {
+			return util.NewInvalidInputError("Environment variable %v is reserved: names starting with %v are set by Kubeflow Pipelines or Argo", envVar.Name, prefix)
+		}
+	}
+	source := envVar.ValueFrom
+	if source == nil {
+		return nil
+	}
+	if envVar.Value != "" {
+		return util.NewInvalidInputError("Environment variable %v can't have both a value and a value source", envVar.Name)
+	}
+	sources := 0
+	for _, set := range []bool{source.FieldRef != nil, source.ResourceFieldRef != nil, source.ConfigMapKeyRef != nil, source.SecretKeyRef != nil} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return util.NewInvalidInputError("Environment variable %v must have exactly one value source", envVar.Name)
+	}
+	if ref := source.SecretKeyRef; ref != nil && (ref.Name == "" || ref.Key == "") {
+		return util.NewInvalidInputError("Environment variable %v must name the secret and the key it is read from", envVar.Name)
+	}
+	if ref := source.ConfigMapKeyRef; ref != nil && (ref.Name == "" || ref.Key == "") {
+		return util.NewInvalidInputError("Environment variable %v must name the config map and the key it is read from", envVar.Name)
+	}
+	return nil
+}
+
+// runScheduling is the node selector and tolerations a run adds to its workflow.
+type runScheduling struct {
+	NodeSelector map[string]string   `json:"nodeSelector,omitempty"`
+	Tolerations  []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// schedulingOf returns the node selector and tolerations set by the node_selector and tolerations fields of a v1
+// run, or else by the incoming gRPC metadata, or nil if the request doesn't set any.
+func schedulingOf(ctx context.Context, apiRunInterface interface{}) (*runScheduling, error) {
+	apiRun, ok := apiRunInterface.(*api.Run)
+	if !ok || (len(apiRun.GetNodeSelector()) == 0 && len(apiRun.GetTolerations()) == 0) {
+		return schedulingFromContext(ctx)
+	}
+	scheduling := &runScheduling{NodeSelector: apiRun.GetNodeSelector()}
+	for _, toleration := range apiRun.GetTolerations() {
+		var seconds *int64
+		if toleration.GetTolerationSeconds() != nil {
+			value := toleration.GetTolerationSeconds().GetValue()
+			seconds = &value
+		}
+		scheduling.Tolerations = append(scheduling.Tolerations, corev1.Toleration{
+			Key:               toleration.GetKey(),
+			Operator:          corev1.TolerationOperator(toleration.GetOperator()),
+			Value:             toleration.GetValue(),
+			Effect:            corev1.TaintEffect(toleration.GetEffect()),
+			TolerationSeconds: seconds,
+		})
+	}
+	return validateScheduling(scheduling)
+}
+
+// schedulingFromContext returns the node selector and tolerations in the incoming gRPC metadata, or nil if the
+// request doesn't set any.
+func schedulingFromContext(ctx context.Context) (*runScheduling, error) {
+	if ctx == nil {
+		return nil, nil
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	values := md.Get(common.SchedulingHeader)
+	if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+		return nil, nil
+	}
+	var scheduling runScheduling
+	if err := json.Unmarshal([]byte(values[0]), &scheduling); err != nil {
+		return nil, util.NewInvalidInputErrorWithDetails(err, "Invalid scheduling: must be an object with a node selector and tolerations")
+	}
+	return validateScheduling(&scheduling)
+}
+
+// validateScheduling checks the node selector and tolerations a run adds, or returns nil if it adds none.
+func validateScheduling(scheduling *runScheduling) (*runScheduling, error) {
+	if len(scheduling.NodeSelector) == 0 && len(scheduling.Tolerations) == 0 {
+		return nil, nil
+	}
+	for key, value := range scheduling.NodeSelector {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, util.NewInvalidInputError("Invalid node selector key %q: %v", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return nil, util.NewInvalidInputError("Invalid value %q of node selector %v: %v", value, key, strings.Join(errs, "; "))
+		}
+	}
+	for i := range scheduling.Tolerations {
+		if err := validateToleration(&scheduling.Tolerations[i]); err != nil {
+			return nil, err
+		}
+	}
+	return scheduling, nil
+}
+
+// validateToleration checks a toleration the way Kubernetes validates the tolerations of a pod.
+func validateToleration(toleration *corev1.Toleration) error {
+	if toleration.Key != "" {
+		if errs := validation.IsQualifiedName(toleration.Key); len(errs) > 0 {
+			return util.NewInvalidInputError("Invalid toleration key %q: %v", toleration.Key, strings.Join(errs, "; "))
+		}
+	}
+	switch toleration.Operator {
+	case corev1.TolerationOpEqual, "":
+		if toleration.Key == "" {
+			return util.NewInvalidInputError("Invalid toleration: a toleration without a key must use the Exists operator")
+		}
+		if errs := validation.IsValidLabelValue(toleration.Value); len(errs) > 0 {
+			return util.NewInvalidInputError("Invalid value %q of toleration %v: %v", toleration.Value, toleration.Key, strings.Join(errs, "; "))
+		}
+	case corev1.TolerationOpExists:
+		if toleration.Value != "" {
+			return util.NewInvalidInputError("Invalid toleration %v: a toleration with the Exists operator can't have a value", toleration.Key)
+		}
+	default:
+		return util.NewInvalidInputError("Invalid operator %q of toleration %v: must be Equal or Exists", toleration.Operator, toleration.Key)
+	}
+	switch toleration.Effect {
+	case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute, "":
+	default:
+		return util.NewInvalidInputError("Invalid effect %q of toleration %v: must be NoSchedule, PreferNoSchedule or NoExecute",
+			toleration.Effect, toleration.Key)
+	}
+	if toleration.TolerationSeconds != nil && toleration.Effect != corev1.TaintEffectNoExecute {
+		return util.NewInvalidInputError("Invalid toleration %v: toleration seconds only apply to the NoExecute effect", toleration.Key)
+	}
+	return nil
+}
+
+// ttlSecondsOf returns the seconds the workflow is kept after it finishes, or nil if it has no TTL.
+func ttlSecondsOf(execSpec util.ExecutionSpec) *int64 {
+	ttl := execSpec.TTLSecondsAfterFinished()
+	if ttl == nil {
+		return nil
+	}
+	seconds := int64(*ttl)
+	return &seconds
+}
+
+// keyedLocks serializes work on the same key, keeping a mutex only while it is in use.
+type keyedLocks struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+type keyedLock struct {
+	sync.Mutex
+	refs int
+}
+
+var idempotencyKeyLocks = &keyedLocks{locks: map[string]*keyedLock{}}
+
+// defaultExperimentLocks serializes the creation of the default experiment of a namespace.
+var defaultExperimentLocks = &keyedLocks{locks: map[string]*keyedLock{}}
+
+// runQuotaLocks serializes the creation of the runs of a namespace that has a run quota.
+var runQuotaLocks = &keyedLocks{locks: map[string]*keyedLock{}}
+
+// lock blocks until the lock for key is acquired, and returns the function that releases it.
+func (k *keyedLocks) lock(key string) func() {
+	k.mu.Lock()
+	l, ok := k.locks[key]
+	if !ok {
+		l = &keyedLock{}
+		k.locks[key] = l
+	}
+	l.refs++
+	k.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		k.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
+
+// isOneOffJob returns whether a job has no schedule, and so creates a single run.
+func isOneOffJob(job *model.Job) bool {
+	hasCron := job.Cron != nil && *job.Cron != ""
+	hasInterval := job.IntervalSecond != nil && *job.IntervalSecond != 0
+	return !hasCron && !hasInterval
+}
+
+// setJobNextRun sets when a job is expected to create its next run, from the
+// last time its ScheduledWorkflow triggered one, or why it won't create any.
+func setJobNextRun(job *model.Job, scheduledWorkflow *swfapi.ScheduledWorkflow, nowEpoch int64) error {
+	var lastRunAtInSec *int64
+	if scheduledWorkflow != nil && scheduledWorkflow.Status.Trigger.LastTriggeredTime != nil {
+		lastRunAtInSec = util.Int64Pointer(scheduledWorkflow.Status.Trigger.LastTriggeredTime.Unix())
+	}
+	nextRunAtInSec, reason, err := nextJobRun(job, lastRunAtInSec, nowEpoch)
+	if err != nil {
+		return err
+	}
+	job.NextRunAtInSec = nextRunAtInSec
+	job.NextRunReason = reason
+	return nil
+}
+
+// nextJobRun returns when a job is expected to create its next run, given when
+// it last created one, or 0 and the reason why it won't create any. A periodic
+// job runs an interval after its last run, and a cron job at the next matching
+// instant after now. Runs that are overdue are created right away.
+func nextJobRun(job *model.Job, lastRunAtInSec *int64, nowEpoch int64) (int64, string, error) {
+	if !job.Enabled {
+		return 0, "The job is disabled.", nil
+	}
+	var nextEpoch int64
+	var endTimeInSec *int64
+	switch {
+	case job.IntervalSecond != nil && *job.IntervalSecond != 0:
+		lastEpoch := job.CreatedAtInSec
+		if lastRunAtInSec != nil {
+			lastEpoch = *lastRunAtInSec
+		} else if job.PeriodicScheduleStartTimeInSec != nil {
+			lastEpoch = *job.PeriodicScheduleStartTimeInSec
+		}
+		nextEpoch = lastEpoch + *job.IntervalSecond
+		endTimeInSec = job.PeriodicScheduleEndTimeInSec
+	case job.Cron != nil && *job.Cron != "":
+		schedule, err := cron.Parse(*job.Cron)
+		if err != nil {
+			return 0, "", util.NewInternalServerError(err, "Failed to parse the cron schedule %q of job %v", *job.Cron, job.UUID)
+		}
+		location, err := swfutil.GetLocation()
+		if err != nil {
+			return 0, "", util.NewInternalServerError(err, "Failed to load the time zone of cron schedules")
+		}
+		after := time.Unix(nowEpoch, 0)
+		if job.CronScheduleStartTimeInSec != nil && *job.CronScheduleStartTimeInSec > nowEpoch {
+			after = time.Unix(*job.CronScheduleStartTimeInSec, 0)
+		}
+		nextEpoch = schedule.Next(after.In(location)).Unix()
+		endTimeInSec = job.CronScheduleEndTimeInSec
+	default:
+		if lastRunAtInSec != nil {
+			return 0, "The job has no schedule and already ran.", nil
+		}
+		return nowEpoch, "", nil
+	}
+	if endTimeInSec != nil && nextEpoch > *endTimeInSec {
+		return 0, fmt.Sprintf("The schedule of the job ended at %v.", time.Unix(*endTimeInSec, 0).UTC().Format(time.RFC3339)), nil
+	}
+	if nextEpoch < nowEpoch {
+		return nowEpoch, "", nil
+	}
+	return nextEpoch, "", nil
+}
+
+// parameterTokenPattern matches the tokens expanded in parameter values, such as {run_name}. Names in double
+// braces, such as Argo's {{workflow.name}}, are left to Argo.
+var parameterTokenPattern = regexp.MustCompile(`\{+[A-Za-z_][A-Za-z0-9_]*\}+`)
+
+// parameterTimestampLayout is the layout of the {timestamp} token, which can be used in paths and names.
+const parameterTimestampLayout = "20060102T150405Z"
+
+// expandParameterTokens replaces the tokens in the value of a parameter with their values. A token that isn't
+// one of the given ones is an error.
+func expandParameterTokens(name string, value string, tokens map[string]string) (string, error) {
+	var unknown []string
+	expanded := parameterTokenPattern.ReplaceAllStringFunc(value, func(match string) string {
+		if strings.HasPrefix(match, "{{") || strings.HasSuffix(match, "}}") {
+			return match
+		}
+		tokenValue, ok := tokens[match[1:len(match)-1]]
+		if !ok {
+			unknown = append(unknown, match)
+			return match
+		}
+		return tokenValue
+	})
+	if len(unknown) > 0 {
+		supported := make([]string, 0, len(tokens))
+		for token := range tokens {
+			supported = append(supported, "{"+token+"}")
+		}
+		sort.Strings(supported)
+		return "", util.NewInvalidInputError("Parameter %v has unknown token(s) %v. Supported tokens are %v",
+			name, strings.Join(unknown, ", "), strings.Join(supported, ", "))
+	}
+	return expanded, nil
+}
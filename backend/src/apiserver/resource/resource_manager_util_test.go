@@ -19,9 +19,13 @@ import (
 
 	"github.com/ghodss/yaml"
 	api "github.com/kubeflow/pipelines/backend/api/v1beta1/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
+	swfutil "github.com/kubeflow/pipelines/backend/src/crd/controller/scheduledworkflow/util"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
 )
 
 func TestRetryWorkflowWith(t *testing.T) {
@@ -343,3 +347,152 @@ func TestConvertPipelineIdToDefaultPipelineVersion_NoOp(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, expectedApiRun, apiRun)
 }
+
+func TestValidateTrigger(t *testing.T) {
+	tests := []struct {
+		name           string
+		trigger        model.Trigger
+		wantErr        bool
+		errMsgContains string
+	}{
+		{
+			name:    "six field cron",
+			trigger: model.Trigger{CronSchedule: model.CronSchedule{Cron: util.StringPointer("0 */5 * * * *")}},
+		},
+		{
+			name:    "five field cron",
+			trigger: model.Trigger{CronSchedule: model.CronSchedule{Cron: util.StringPointer("0 */5 * * *")}},
+		},
+		{
+			name:    "descriptor",
+			trigger: model.Trigger{CronSchedule: model.CronSchedule{Cron: util.StringPointer("@hourly")}},
+		},
+		{
+			name:           "invalid hour field",
+			trigger:        model.Trigger{CronSchedule: model.CronSchedule{Cron: util.StringPointer("0 0 25 * * *")}},
+			wantErr:        true,
+			errMsgContains: "invalid hour field \"25\" at position 3",
+		},
+		{
+			name:           "too few fields",
+			trigger:        model.Trigger{CronSchedule: model.CronSchedule{Cron: util.StringPointer("* * *")}},
+			wantErr:        true,
+			errMsgContains: "not a supported format",
+		},
+		{
+			name: "cron start after end",
+			trigger: model.Trigger{CronSchedule: model.CronSchedule{
+				Cron:                       util.StringPointer("0 0 * * * *"),
+				CronScheduleStartTimeInSec: util.Int64Pointer(20),
+				CronScheduleEndTimeInSec:   util.Int64Pointer(10),
+			}},
+			wantErr:        true,
+			errMsgContains: "after its end time",
+		},
+		{
+			name:    "periodic",
+			trigger: model.Trigger{PeriodicSchedule: model.PeriodicSchedule{IntervalSecond: util.Int64Pointer(60)}},
+		},
+		{
+			name:           "periodic zero interval",
+			trigger:        model.Trigger{PeriodicSchedule: model.PeriodicSchedule{IntervalSecond: util.Int64Pointer(0)}},
+			wantErr:        true,
+			errMsgContains: "invalid period schedule interval",
+		},
+		{
+			name: "periodic start after end",
+			trigger: model.Trigger{PeriodicSchedule: model.PeriodicSchedule{
+				IntervalSecond:                 util.Int64Pointer(60),
+				PeriodicScheduleStartTimeInSec: util.Int64Pointer(20),
+				PeriodicScheduleEndTimeInSec:   util.Int64Pointer(10),
+			}},
+			wantErr:        true,
+			errMsgContains: "after its end time",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTrigger(tt.trigger)
+			if !tt.wantErr {
+				assert.Nil(t, err)
+				return
+			}
+			assert.NotNil(t, err)
+			assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+			assert.Contains(t, err.Error(), tt.errMsgContains)
+		})
+	}
+}
+
+func TestNextJobRun(t *testing.T) {
+	viper.Set(swfutil.TimeZone, "UTC")
+	defer viper.Set(swfutil.TimeZone, "")
+
+	// 2023-01-01T00:00:30Z.
+	now := int64(1672531230)
+	tests := []struct {
+		name           string
+		job            model.Job
+		lastRunAtInSec *int64
+		nextRunAtInSec int64
+		reason         string
+	}{
+		{
+			name:   "disabled",
+			job:    model.Job{Trigger: model.Trigger{PeriodicSchedule: model.PeriodicSchedule{IntervalSecond: util.Int64Pointer(60)}}},
+			reason: "The job is disabled.",
+		},
+		{
+			name:           "periodic after the last run",
+			job:            model.Job{Enabled: true, Trigger: model.Trigger{PeriodicSchedule: model.PeriodicSchedule{IntervalSecond: util.Int64Pointer(60)}}},
+			lastRunAtInSec: util.Int64Pointer(now - 10),
+			nextRunAtInSec: now + 50,
+		},
+		{
+			name:           "periodic after the start time",
+			job:            model.Job{Enabled: true, Trigger: model.Trigger{PeriodicSchedule: model.PeriodicSchedule{IntervalSecond: util.Int64Pointer(60), PeriodicScheduleStartTimeInSec: util.Int64Pointer(now + 100)}}},
+			nextRunAtInSec: now + 160,
+		},
+		{
+			name:           "periodic overdue",
+			job:            model.Job{Enabled: true, CreatedAtInSec: now - 3600, Trigger: model.Trigger{PeriodicSchedule: model.PeriodicSchedule{IntervalSecond: util.Int64Pointer(60)}}},
+			nextRunAtInSec: now,
+		},
+		{
+			name:           "periodic past its end time",
+			job:            model.Job{Enabled: true, Trigger: model.Trigger{PeriodicSchedule: model.PeriodicSchedule{IntervalSecond: util.Int64Pointer(60), PeriodicScheduleEndTimeInSec: util.Int64Pointer(now)}}},
+			lastRunAtInSec: util.Int64Pointer(now - 10),
+			reason:         "The schedule of the job ended at 2023-01-01T00:00:30Z.",
+		},
+		{
+			name:           "cron",
+			job:            model.Job{Enabled: true, Trigger: model.Trigger{CronSchedule: model.CronSchedule{Cron: util.StringPointer("0 0 * * * *")}}},
+			lastRunAtInSec: util.Int64Pointer(now - 10),
+			nextRunAtInSec: now + 3570,
+		},
+		{
+			name:   "cron past its end time",
+			job:    model.Job{Enabled: true, Trigger: model.Trigger{CronSchedule: model.CronSchedule{Cron: util.StringPointer("0 0 * * * *"), CronScheduleEndTimeInSec: util.Int64Pointer(now + 60)}}},
+			reason: "The schedule of the job ended at 2023-01-01T00:01:30Z.",
+		},
+		{
+			name:           "one-off",
+			job:            model.Job{Enabled: true},
+			nextRunAtInSec: now,
+		},
+		{
+			name:           "one-off that already ran",
+			job:            model.Job{Enabled: true},
+			lastRunAtInSec: util.Int64Pointer(now - 10),
+			reason:         "The job has no schedule and already ran.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextRunAtInSec, reason, err := nextJobRun(&tt.job, tt.lastRunAtInSec, now)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.nextRunAtInSec, nextRunAtInSec)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func ToApiExperimentV1(experiment *model.Experiment) *apiv1beta1.Experiment {
@@ -56,6 +58,7 @@ func ToApiExperimentV1(experiment *model.Experiment) *apiv1beta1.Experiment {
 		CreatedAt:          &timestamp.Timestamp{Seconds: experiment.CreatedAtInSec},
 		ResourceReferences: resourceReferences,
 		StorageState:       storageState,
+		ResourceVersion:    experiment.ResourceVersion,
 	}
 }
 
@@ -77,12 +80,13 @@ func ToApiExperiment(experiment *model.Experiment) *apiv2beta1.Experiment {
 	}
 
 	return &apiv2beta1.Experiment{
-		ExperimentId: experiment.UUID,
-		DisplayName:  experiment.Name,
-		Description:  experiment.Description,
-		CreatedAt:    &timestamp.Timestamp{Seconds: experiment.CreatedAtInSec},
-		Namespace:    experiment.Namespace,
-		StorageState: storageState,
+		ExperimentId:    experiment.UUID,
+		DisplayName:     experiment.Name,
+		Description:     experiment.Description,
+		CreatedAt:       &timestamp.Timestamp{Seconds: experiment.CreatedAtInSec},
+		Namespace:       experiment.Namespace,
+		StorageState:    storageState,
+		ResourceVersion: experiment.ResourceVersion,
 	}
 }
 
@@ -132,6 +136,8 @@ func ToApiPipeline(pipeline *model.Pipeline) *apiv1beta1.Pipeline {
 		Parameters:         params,
 		DefaultVersion:     defaultVersion,
 		ResourceReferences: resourceRefs,
+		Labels:             pipeline.Labels,
+		VersionCount:       pipeline.VersionCount,
 	}
 }
 
@@ -144,7 +150,7 @@ func ToApiPipelineVersion(version *model.PipelineVersion) (*apiv1beta1.PipelineV
 		return nil, err
 	}
 
-	return &apiv1beta1.PipelineVersion{
+	apiVersion := &apiv1beta1.PipelineVersion{
 		Id:            version.UUID,
 		Name:          version.Name,
 		CreatedAt:     &timestamp.Timestamp{Seconds: version.CreatedAtInSec},
@@ -160,7 +166,11 @@ func ToApiPipelineVersion(version *model.PipelineVersion) (*apiv1beta1.PipelineV
 				Relationship: apiv1beta1.Relationship_OWNER,
 			},
 		},
-	}, nil
+	}
+	if version.PackageUrl != "" {
+		apiVersion.PackageUrl = &apiv1beta1.Url{PipelineUrl: version.PackageUrl}
+	}
+	return apiVersion, nil
 }
 
 func ToApiPipelineVersions(versions []*model.PipelineVersion) ([]*apiv1beta1.PipelineVersion, error) {
@@ -221,6 +231,134 @@ func toApiRuntimeConfigV1(modelRuntime model.RuntimeConfig) (*apiv1beta1.Pipelin
 	return apiRuntimeConfig, nil
 }
 
+// toApiResourceOverridesV1 converts the container resources a run set, stored as JSON, to those of a v1 run.
+func toApiResourceOverridesV1(resourceOverrides string) (map[string]*apiv1beta1.ResourceRequirements, error) {
+	if resourceOverrides == "" {
+		return nil, nil
+	}
+	var resources map[string]corev1.ResourceRequirements
+	if err := json.Unmarshal([]byte(resourceOverrides), &resources); err != nil {
+		return nil, util.NewInternalServerError(err, "Resource overrides with wrong format are stored")
+	}
+	apiResources := make(map[string]*apiv1beta1.ResourceRequirements, len(resources))
+	for templateName, requirements := range resources {
+		apiResources[templateName] = &apiv1beta1.ResourceRequirements{
+			Limits:   toApiResourceListV1(requirements.Limits),
+			Requests: toApiResourceListV1(requirements.Requests),
+		}
+	}
+	return apiResources, nil
+}
+
+func toApiResourceListV1(list corev1.ResourceList) map[string]string {
+	if len(list) == 0 {
+		return nil
+	}
+	quantities := make(map[string]string, len(list))
+	for name, quantity := range list {
+		quantities[string(name)] = quantity.String()
+	}
+	return quantities
+}
+
+// toApiSchedulingV1 converts the node selector and tolerations of the workflow of a run, stored as JSON, to those
+// of a v1 run.
+func toApiSchedulingV1(scheduling string) (map[string]string, []*apiv1beta1.Toleration, error) {
+	if scheduling == "" {
+		return nil, nil, nil
+	}
+	var stored struct {
+		NodeSelector map[string]string   `json:"nodeSelector,omitempty"`
+		Tolerations  []corev1.Toleration `json:"tolerations,omitempty"`
+	}
+	if err := json.Unmarshal([]byte(scheduling), &stored); err != nil {
+		return nil, nil, util.NewInternalServerError(err, "Scheduling constraints with wrong format are stored")
+	}
+	var tolerations []*apiv1beta1.Toleration
+	for _, toleration := range stored.Tolerations {
+		apiToleration := &apiv1beta1.Toleration{
+			Key:      toleration.Key,
+			Operator: string(toleration.Operator),
+			Value:    toleration.Value,
+			Effect:   string(toleration.Effect),
+		}
+		if toleration.TolerationSeconds != nil {
+			apiToleration.TolerationSeconds = wrapperspb.Int64(*toleration.TolerationSeconds)
+		}
+		tolerations = append(tolerations, apiToleration)
+	}
+	return stored.NodeSelector, tolerations, nil
+}
+
+// toApiEnvV1 converts the environment variables a run added to its containers, stored as JSON, to those of a v1
+// run, with the templates they were added to.
+func toApiEnvV1(env string) ([]*apiv1beta1.EnvVar, []string, error) {
+	if env == "" {
+		return nil, nil, nil
+	}
+	var stored struct {
+		Env       []corev1.EnvVar `json:"env"`
+		Templates []string        `json:"templates,omitempty"`
+	}
+	if err := json.Unmarshal([]byte(env), &stored); err != nil {
+		return nil, nil, util.NewInternalServerError(err, "Environment variables with wrong format are stored")
+	}
+	var apiEnv []*apiv1beta1.EnvVar
+	for _, envVar := range stored.Env {
+		apiEnvVar := &apiv1beta1.EnvVar{Name: envVar.Name, Value: envVar.Value}
+		if source := envVar.ValueFrom; source != nil {
+			apiEnvVar.ValueFrom = &apiv1beta1.EnvVarSource{}
+			if ref := source.SecretKeyRef; ref != nil {
+				apiEnvVar.ValueFrom.SecretKeyRef = &apiv1beta1.KeySelector{Name: ref.Name, Key: ref.Key, Optional: ref.Optional != nil && *ref.Optional}
+			}
+			if ref := source.ConfigMapKeyRef; ref != nil {
+				apiEnvVar.ValueFrom.ConfigMapKeyRef = &apiv1beta1.KeySelector{Name: ref.Name, Key: ref.Key, Optional: ref.Optional != nil && *ref.Optional}
+			}
+			if ref := source.FieldRef; ref != nil {
+				apiEnvVar.ValueFrom.FieldRef = &apiv1beta1.ObjectFieldSelector{ApiVersion: ref.APIVersion, FieldPath: ref.FieldPath}
+			}
+			if ref := source.ResourceFieldRef; ref != nil {
+				apiEnvVar.ValueFrom.ResourceFieldRef = &apiv1beta1.ResourceFieldSelector{ContainerName: ref.ContainerName, Resource: ref.Resource}
+				if !ref.Divisor.IsZero() {
+					apiEnvVar.ValueFrom.ResourceFieldRef.Divisor = ref.Divisor.String()
+				}
+			}
+		}
+		apiEnv = append(apiEnv, apiEnvVar)
+	}
+	return apiEnv, stored.Templates, nil
+}
+
+// toApiRetryStrategyV1 converts the retry strategy a run set on its templates, stored as JSON, to the one of a v1
+// run.
+func toApiRetryStrategyV1(retryStrategy string) (*apiv1beta1.RetryStrategy, error) {
+	if retryStrategy == "" {
+		return nil, nil
+	}
+	var stored struct {
+		util.RetryStrategy
+		Templates []string `json:"templates,omitempty"`
+		Force     bool     `json:"force,omitempty"`
+	}
+	if err := json.Unmarshal([]byte(retryStrategy), &stored); err != nil {
+		return nil, util.NewInternalServerError(err, "Retry strategy with wrong format is stored")
+	}
+	apiRetryStrategy := &apiv1beta1.RetryStrategy{
+		Limit:       stored.Limit,
+		RetryPolicy: stored.RetryPolicy,
+		Templates:   stored.Templates,
+		Force:       stored.Force,
+	}
+	if stored.Backoff != nil {
+		apiRetryStrategy.Backoff = &apiv1beta1.RetryBackoff{
+			Duration:    stored.Backoff.Duration,
+			Factor:      stored.Backoff.Factor,
+			MaxDuration: stored.Backoff.MaxDuration,
+		}
+	}
+	return apiRetryStrategy, nil
+}
+
 func toApiRuntimeConfig(modelRuntime model.RuntimeConfig) (*apiv2beta1.RuntimeConfig, error) {
 	if modelRuntime.Parameters == "" && modelRuntime.PipelineRoot == "" {
 		return nil, nil
@@ -239,7 +377,9 @@ func toApiRuntimeConfig(modelRuntime model.RuntimeConfig) (*apiv2beta1.RuntimeCo
 	return apiRuntimeConfig, nil
 }
 
-func toApiRunV1(run *model.Run) *apiv1beta1.Run {
+// toApiRunV1 converts a run; nowInSec is the time the queue wait and the duration of an active run are computed
+// against.
+func toApiRunV1(run *model.Run, nowInSec int64) *apiv1beta1.Run {
 	// v1 parameters
 	params, err := toApiParameters(run.Parameters)
 	if err != nil {
@@ -262,6 +402,48 @@ func toApiRunV1(run *model.Run) *apiv1beta1.Run {
 			metrics = append(metrics, ToApiRunMetric(metric))
 		}
 	}
+	resourceOverrides, err := toApiResourceOverridesV1(run.ResourceOverrides)
+	if err != nil {
+		return &apiv1beta1.Run{
+			Id:    run.UUID,
+			Error: err.Error(),
+		}
+	}
+	nodeSelector, tolerations, err := toApiSchedulingV1(run.Scheduling)
+	if err != nil {
+		return &apiv1beta1.Run{
+			Id:    run.UUID,
+			Error: err.Error(),
+		}
+	}
+	env, envTemplates, err := toApiEnvV1(run.Env)
+	if err != nil {
+		return &apiv1beta1.Run{
+			Id:    run.UUID,
+			Error: err.Error(),
+		}
+	}
+	retryStrategy, err := toApiRetryStrategyV1(run.RetryStrategy)
+	if err != nil {
+		return &apiv1beta1.Run{
+			Id:    run.UUID,
+			Error: err.Error(),
+		}
+	}
+	var podMetadata *apiv1beta1.PodMetadata
+	if run.PodMetadata != "" {
+		podMetadata = &apiv1beta1.PodMetadata{}
+		if err := json.Unmarshal([]byte(run.PodMetadata), podMetadata); err != nil {
+			return &apiv1beta1.Run{
+				Id:    run.UUID,
+				Error: util.NewInternalServerError(err, "Pod metadata with wrong format is stored").Error(),
+			}
+		}
+	}
+	var ttlSeconds *wrapperspb.Int32Value
+	if run.TTLSeconds != nil {
+		ttlSeconds = wrapperspb.Int32(int32(*run.TTLSeconds))
+	}
 	return &apiv1beta1.Run{
 		CreatedAt:      &timestamp.Timestamp{Seconds: run.CreatedAtInSec},
 		Id:             run.UUID,
@@ -281,21 +463,53 @@ func toApiRunV1(run *model.Run) *apiv1beta1.Run {
 			Parameters:       params,
 			RuntimeConfig:    runtimeConfig,
 		},
-		ResourceReferences: toApiResourceReferences(run.ResourceReferences),
+		ResourceReferences:        toApiResourceReferences(run.ResourceReferences),
+		TtlSecondsAfterFinished:   ttlSeconds,
+		ResourceOverrides:         resourceOverrides,
+		PodMetadata:               podMetadata,
+		NodeSelector:              nodeSelector,
+		Tolerations:               tolerations,
+		PriorityClassName:         run.PriorityClassName,
+		PipelineRoot:              run.PipelineSpec.RuntimeConfig.PipelineRoot,
+		Env:                       env,
+		EnvTemplates:              envTemplates,
+		RetryStrategy:             retryStrategy,
+		QueueWaitSeconds:          run.QueueWaitSeconds(nowInSec),
+		DurationSeconds:           run.DurationSeconds(nowInSec),
+		Namespace:                 run.Namespace,
+		WorkflowUid:               run.WorkflowUID,
+		CreatedBy:                 run.CreatedBy,
+		Labels:                    run.Labels,
+		CompletionWebhook:         run.CompletionWebhook,
+		CompletionWebhookDelivery: toApiCompletionWebhookDeliveryV1(run),
+		StatusSource:              run.StatusSource(nowInSec),
+	}
+}
+
+// toApiCompletionWebhookDeliveryV1 returns the last delivery of the completion webhook of a run, or nil if none
+// was attempted.
+func toApiCompletionWebhookDeliveryV1(run *model.Run) *apiv1beta1.CompletionWebhookDelivery {
+	if run.CompletionWebhookStatus == "" {
+		return nil
+	}
+	return &apiv1beta1.CompletionWebhookDelivery{
+		Status:      run.CompletionWebhookStatus,
+		Message:     run.CompletionWebhookMessage,
+		AttemptedAt: &timestamp.Timestamp{Seconds: run.CompletionWebhookAttemptedAtInSec},
 	}
 }
 
-func toApiRunsV1(runs []*model.Run) []*apiv1beta1.Run {
+func toApiRunsV1(runs []*model.Run, nowInSec int64) []*apiv1beta1.Run {
 	apiRuns := make([]*apiv1beta1.Run, 0)
 	for _, run := range runs {
-		apiRuns = append(apiRuns, toApiRunV1(run))
+		apiRuns = append(apiRuns, toApiRunV1(run, nowInSec))
 	}
 	return apiRuns
 }
 
-func ToApiRunDetailV1(run *model.RunDetail) *apiv1beta1.RunDetail {
+func ToApiRunDetailV1(run *model.RunDetail, nowInSec int64) *apiv1beta1.RunDetail {
 	return &apiv1beta1.RunDetail{
-		Run: toApiRunV1(&run.Run),
+		Run: toApiRunV1(&run.Run, nowInSec),
 		PipelineRuntime: &apiv1beta1.PipelineRuntime{
 			WorkflowManifest: run.WorkflowRuntimeManifest,
 			PipelineManifest: run.PipelineRuntimeManifest,
@@ -303,16 +517,25 @@ func ToApiRunDetailV1(run *model.RunDetail) *apiv1beta1.RunDetail {
 	}
 }
 
-func toApiRun(run *model.Run) *apiv2beta1.Run {
+// toApiRun converts a run; nowInSec is the time the queue wait and the duration of an active run are computed
+// against.
+func toApiRun(run *model.Run, nowInSec int64) *apiv2beta1.Run {
 	apiRun := &apiv2beta1.Run{
-		ExperimentId:   run.ExperimentUUID,
-		RunId:          run.UUID,
-		DisplayName:    run.DisplayName,
-		Description:    run.Description,
-		ServiceAccount: run.ServiceAccount,
-		CreatedAt:      &timestamp.Timestamp{Seconds: run.CreatedAtInSec},
-		ScheduledAt:    &timestamp.Timestamp{Seconds: run.ScheduledAtInSec},
-		FinishedAt:     &timestamp.Timestamp{Seconds: run.FinishedAtInSec},
+		ExperimentId:     run.ExperimentUUID,
+		RunId:            run.UUID,
+		DisplayName:      run.DisplayName,
+		Description:      run.Description,
+		ServiceAccount:   run.ServiceAccount,
+		CreatedAt:        &timestamp.Timestamp{Seconds: run.CreatedAtInSec},
+		ScheduledAt:      &timestamp.Timestamp{Seconds: run.ScheduledAtInSec},
+		FinishedAt:       &timestamp.Timestamp{Seconds: run.FinishedAtInSec},
+		QueueWaitSeconds: run.QueueWaitSeconds(nowInSec),
+		DurationSeconds:  run.DurationSeconds(nowInSec),
+		Namespace:        run.Namespace,
+		WorkflowUid:      run.WorkflowUID,
+		CreatedBy:        run.CreatedBy,
+		Labels:           run.Labels,
+		StatusSource:     run.StatusSource(nowInSec),
 	}
 
 	// Fill in PipelineSource.
@@ -356,10 +579,10 @@ func toApiRun(run *model.Run) *apiv2beta1.Run {
 	return apiRun
 }
 
-func toApiRuns(runs []*model.Run) []*apiv2beta1.Run {
+func toApiRuns(runs []*model.Run, nowInSec int64) []*apiv2beta1.Run {
 	apiRuns := make([]*apiv2beta1.Run, 0)
 	for _, run := range runs {
-		apiRuns = append(apiRuns, toApiRun(run))
+		apiRuns = append(apiRuns, toApiRun(run, nowInSec))
 	}
 	return apiRuns
 }
@@ -422,7 +645,17 @@ func ToApiJob(job *model.Job) *apiv1beta1.Job {
 			RuntimeConfig:    runtimeConfig,
 		},
 		ResourceReferences: toApiResourceReferences(job.ResourceReferences),
+		NextRunAt:          toApiNextRunAt(job.NextRunAtInSec),
+		NextRunReason:      job.NextRunReason,
+	}
+}
+
+// toApiNextRunAt returns when a job is expected to create its next run, or nil if it won't create any.
+func toApiNextRunAt(nextRunAtInSec int64) *timestamp.Timestamp {
+	if nextRunAtInSec == 0 {
+		return nil
 	}
+	return &timestamp.Timestamp{Seconds: nextRunAtInSec}
 }
 
 func ToApiJobs(jobs []*model.Job) []*apiv1beta1.Job {
@@ -446,6 +679,8 @@ func ToApiRecurringRun(job *model.Job) *apiv2beta1.RecurringRun {
 		UpdatedAt:      &timestamp.Timestamp{Seconds: job.UpdatedAtInSec},
 		NoCatchup:      job.NoCatchup,
 		Namespace:      job.Namespace,
+		NextRunAt:      toApiNextRunAt(job.NextRunAtInSec),
+		NextRunReason:  job.NextRunReason,
 	}
 
 	// Fill in PipelineSource
@@ -106,7 +106,7 @@ func TestToApiRunDetailV1_RuntimeParams(t *testing.T) {
 		},
 		PipelineRuntime: model.PipelineRuntime{WorkflowRuntimeManifest: "workflow123"},
 	}
-	apiRun := ToApiRunDetailV1(modelRun)
+	apiRun := ToApiRunDetailV1(modelRun, 1)
 
 	listParams := []interface{}{1, 2, 3}
 	v2RuntimeListParams, _ := structpb.NewList(listParams)
@@ -130,6 +130,8 @@ func TestToApiRunDetailV1_RuntimeParams(t *testing.T) {
 			StorageState: apiv1beta1.Run_STORAGESTATE_AVAILABLE,
 			CreatedAt:    &timestamp.Timestamp{Seconds: 1},
 			ScheduledAt:  &timestamp.Timestamp{Seconds: 1},
+			StatusSource: "live",
+			Namespace:    "ns123",
 			FinishedAt:   &timestamp.Timestamp{Seconds: 1},
 			Status:       "running",
 			PipelineSpec: &apiv1beta1.PipelineSpec{
@@ -143,6 +145,7 @@ func TestToApiRunDetailV1_RuntimeParams(t *testing.T) {
 				{Key: &apiv1beta1.ResourceKey{Type: apiv1beta1.ResourceType_JOB, Id: "job123"},
 					Name: "j123", Relationship: apiv1beta1.Relationship_CREATOR},
 			},
+			PipelineRoot: "model-pipeline-root",
 		},
 		PipelineRuntime: &apiv1beta1.PipelineRuntime{
 			WorkflowManifest: "workflow123",
@@ -178,7 +181,7 @@ func TestToApiRunDetailV1_V1Params(t *testing.T) {
 		},
 		PipelineRuntime: model.PipelineRuntime{WorkflowRuntimeManifest: "workflow123"},
 	}
-	apiRun := ToApiRunDetailV1(modelRun)
+	apiRun := ToApiRunDetailV1(modelRun, 1)
 	expectedApiRun := &apiv1beta1.RunDetail{
 		Run: &apiv1beta1.Run{
 			Id:           "run123",
@@ -186,6 +189,8 @@ func TestToApiRunDetailV1_V1Params(t *testing.T) {
 			StorageState: apiv1beta1.Run_STORAGESTATE_AVAILABLE,
 			CreatedAt:    &timestamp.Timestamp{Seconds: 1},
 			ScheduledAt:  &timestamp.Timestamp{Seconds: 1},
+			StatusSource: "live",
+			Namespace:    "ns123",
 			FinishedAt:   &timestamp.Timestamp{Seconds: 1},
 			Status:       "running",
 			PipelineSpec: &apiv1beta1.PipelineSpec{
@@ -265,7 +270,7 @@ func TesttoApiRunsV1(t *testing.T) {
 		},
 		Metrics: []*model.RunMetric{metric2},
 	}
-	apiRuns := toApiRunsV1([]*model.Run{&modelRun1, &modelRun2})
+	apiRuns := toApiRunsV1([]*model.Run{&modelRun1, &modelRun2}, 2)
 	expectedApiRun := []*apiv1beta1.Run{
 		{
 			Id:           "run1",
@@ -305,6 +310,62 @@ func TesttoApiRunsV1(t *testing.T) {
 	assert.Equal(t, expectedApiRun, apiRuns)
 }
 
+func TestToApiRunV1_QueueWaitAndDuration(t *testing.T) {
+	finished := &model.Run{UUID: "run1", CreatedAtInSec: 10, PodStartedAtInSec: 14, StartedAtInSec: 12, FinishedAtInSec: 30}
+	apiRun := toApiRunV1(finished, 100)
+	assert.Equal(t, int64(4), apiRun.QueueWaitSeconds)
+	assert.Equal(t, int64(18), apiRun.DurationSeconds)
+
+	// An active run whose pods haven't started yet is still waiting.
+	active := &model.Run{UUID: "run2", CreatedAtInSec: 10, StartedAtInSec: 12}
+	apiRun = toApiRunV1(active, 100)
+	assert.Equal(t, int64(90), apiRun.QueueWaitSeconds)
+	assert.Equal(t, int64(88), apiRun.DurationSeconds)
+}
+
+func TestToApiRun_QueueWaitAndDuration(t *testing.T) {
+	finished := &model.Run{UUID: "run1", CreatedAtInSec: 10, PodStartedAtInSec: 14, StartedAtInSec: 12, FinishedAtInSec: 30}
+	apiRun := toApiRun(finished, 100)
+	assert.Equal(t, int64(4), apiRun.QueueWaitSeconds)
+	assert.Equal(t, int64(18), apiRun.DurationSeconds)
+
+	active := &model.Run{UUID: "run2", CreatedAtInSec: 10, StartedAtInSec: 12}
+	apiRun = toApiRun(active, 100)
+	assert.Equal(t, int64(90), apiRun.QueueWaitSeconds)
+	assert.Equal(t, int64(88), apiRun.DurationSeconds)
+}
+
+func TestToApiRunV1_CompletionWebhook(t *testing.T) {
+	run := &model.Run{UUID: "run1", CompletionWebhook: "https://hooks.example.com/kfp"}
+	apiRun := toApiRunV1(run, 100)
+	assert.Equal(t, "https://hooks.example.com/kfp", apiRun.CompletionWebhook)
+	assert.Nil(t, apiRun.CompletionWebhookDelivery)
+
+	run.CompletionWebhookStatus = model.CompletionWebhookFailed
+	run.CompletionWebhookMessage = "503 Service Unavailable"
+	run.CompletionWebhookAttemptedAtInSec = 40
+	apiRun = toApiRunV1(run, 100)
+	assert.Equal(t, &apiv1beta1.CompletionWebhookDelivery{
+		Status:      "FAILED",
+		Message:     "503 Service Unavailable",
+		AttemptedAt: &timestamp.Timestamp{Seconds: 40},
+	}, apiRun.CompletionWebhookDelivery)
+}
+
+func TestToApiRun_StatusSource(t *testing.T) {
+	ttlSeconds := int64(60)
+	run := &model.Run{UUID: "run1", CreatedAtInSec: 10, FinishedAtInSec: 30, TTLSeconds: &ttlSeconds}
+	assert.Equal(t, model.RunStatusSourceLive, toApiRunV1(run, 89).StatusSource)
+	assert.Equal(t, model.RunStatusSourceArchived, toApiRunV1(run, 90).StatusSource)
+	assert.Equal(t, model.RunStatusSourceArchived, toApiRun(run, 90).StatusSource)
+
+	// Workflows without a TTL are kept, and active runs can't have been garbage collected.
+	run.TTLSeconds = nil
+	assert.Equal(t, model.RunStatusSourceLive, toApiRunV1(run, 1000).StatusSource)
+	active := &model.Run{UUID: "run2", CreatedAtInSec: 10, TTLSeconds: &ttlSeconds}
+	assert.Equal(t, model.RunStatusSourceLive, toApiRun(active, 1000).StatusSource)
+}
+
 func TestToApiTask(t *testing.T) {
 	modelTask := &model.Task{
 		UUID:              resource.DefaultFakeUUID,
@@ -584,6 +645,17 @@ func TestToApiJob_V2(t *testing.T) {
 	assert.Equal(t, expectedJob.String(), apiJob.String())
 }
 
+func TestToApiJob_NextRun(t *testing.T) {
+	job := &model.Job{UUID: "job1", NextRunAtInSec: 61}
+	assert.Equal(t, &timestamp.Timestamp{Seconds: 61}, ToApiJob(job).NextRunAt)
+	assert.Equal(t, &timestamp.Timestamp{Seconds: 61}, ToApiRecurringRun(job).NextRunAt)
+
+	job = &model.Job{UUID: "job1", NextRunReason: "The job is disabled."}
+	assert.Nil(t, ToApiJob(job).NextRunAt)
+	assert.Equal(t, "The job is disabled.", ToApiJob(job).NextRunReason)
+	assert.Equal(t, "The job is disabled.", ToApiRecurringRun(job).NextRunReason)
+}
+
 func TestToApiJobs(t *testing.T) {
 	modelJob1 := model.Job{
 		UUID:        "job1",
@@ -9,6 +9,7 @@ import (
 	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"google.golang.org/grpc/codes"
 	authorizationv1 "k8s.io/api/authorization/v1"
 )
 
@@ -52,6 +53,111 @@ func (s *AuthServer) AuthorizeV1(ctx context.Context, request *api.AuthorizeRequ
 	return &empty.Empty{}, nil
 }
 
+var checkAccessVerbs = map[string]bool{
+	common.RbacResourceVerbCreate: true,
+	common.RbacResourceVerbGet:    true,
+	common.RbacResourceVerbList:   true,
+	common.RbacResourceVerbDelete: true,
+}
+
+// CheckAccessV1 tells whether the user of the request is allowed to perform a verb on a resource, without
+// performing it. The resource is authorized with the same SubjectAccessReview as the calls that perform the
+// verb. For get and delete, the resource ID is the ID of the resource. For create and list, it is the namespace
+// of the resources, or the pipeline of pipeline versions. Namespaces are checked as the experiments in them.
+// Access is always allowed in single-user mode.
+func (s *AuthServer) CheckAccessV1(ctx context.Context, request *api.CheckAccessRequest) (*api.CheckAccessResponse, error) {
+	verb := strings.ToLower(request.GetVerb())
+	if !checkAccessVerbs[verb] {
+		return nil, util.NewInvalidInputError("Verb %q is not supported. Please specify create, get, list or delete.", verb)
+	}
+	resourceID := request.GetResourceId()
+	if resourceID == "" {
+		return nil, util.NewInvalidInputError("Resource ID is empty. Please specify a valid resource ID.")
+	}
+	if !common.IsMultiUserMode() {
+		return &api.CheckAccessResponse{Allowed: true}, nil
+	}
+	resourceAttributes, err := s.accessResourceAttributes(request.GetResourceType(), resourceID, verb)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to check access")
+	}
+	if resourceAttributes.Namespace == "" {
+		// Pipelines without a namespace are shared by all users.
+		return &api.CheckAccessResponse{Allowed: true}, nil
+	}
+	err = isAuthorized(s.resourceManager, ctx, resourceAttributes)
+	if util.IsUserErrorCodeMatch(err, codes.PermissionDenied) || util.IsUserErrorCodeMatch(err, codes.Unauthenticated) {
+		return &api.CheckAccessResponse{Allowed: false, Reason: err.(*util.UserError).ExternalMessage()}, nil
+	}
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to check access")
+	}
+	return &api.CheckAccessResponse{Allowed: true}, nil
+}
+
+// accessResourceAttributes returns the attributes that a verb on a resource is authorized with.
+func (s *AuthServer) accessResourceAttributes(resourceType api.ResourceType, resourceID string, verb string) (*authorizationv1.ResourceAttributes, error) {
+	byID := verb == common.RbacResourceVerbGet || verb == common.RbacResourceVerbDelete
+	resourceAttributes := &authorizationv1.ResourceAttributes{
+		Verb:    verb,
+		Group:   common.RbacPipelinesGroup,
+		Version: common.RbacPipelinesVersion,
+	}
+	switch resourceType {
+	case api.ResourceType_EXPERIMENT:
+		resourceAttributes.Resource = common.RbacResourceTypeExperiments
+		resourceAttributes.Namespace = resourceID
+		if byID {
+			experiment, err := s.resourceManager.GetExperiment(resourceID)
+			if err != nil {
+				return nil, err
+			}
+			resourceAttributes.Namespace = experiment.Namespace
+			resourceAttributes.Name = experiment.Name
+		}
+	case api.ResourceType_JOB:
+		resourceAttributes.Resource = common.RbacResourceTypeJobs
+		resourceAttributes.Namespace = resourceID
+		if byID {
+			job, err := s.resourceManager.GetJob(resourceID)
+			if err != nil {
+				return nil, err
+			}
+			resourceAttributes.Namespace = job.Namespace
+			resourceAttributes.Name = job.Name
+		}
+	case api.ResourceType_PIPELINE:
+		resourceAttributes.Resource = common.RbacResourceTypePipelines
+		resourceAttributes.Namespace = resourceID
+		if byID {
+			pipeline, err := s.resourceManager.GetPipeline(resourceID)
+			if err != nil {
+				return nil, err
+			}
+			resourceAttributes.Namespace = pipeline.Namespace
+			resourceAttributes.Name = pipeline.Name
+		}
+	case api.ResourceType_PIPELINE_VERSION:
+		// Pipeline versions are authorized as their pipeline.
+		resourceAttributes.Resource = common.RbacResourceTypePipelines
+		var err error
+		if byID {
+			resourceAttributes.Namespace, err = s.resourceManager.GetNamespaceFromPipelineVersion(resourceID)
+		} else {
+			resourceAttributes.Namespace, err = s.resourceManager.GetNamespaceFromPipelineID(resourceID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	case api.ResourceType_NAMESPACE:
+		resourceAttributes.Resource = common.RbacResourceTypeExperiments
+		resourceAttributes.Namespace = resourceID
+	default:
+		return nil, util.NewInvalidInputError("Resource type %v is not supported.", resourceType)
+	}
+	return resourceAttributes, nil
+}
+
 func ValidateAuthorizeRequest(request *api.AuthorizeRequest) error {
 	if request == nil {
 		return util.NewInvalidInputError("request object is empty.")
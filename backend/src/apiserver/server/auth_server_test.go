@@ -157,3 +157,70 @@ func TestAuthorizeRequest_Unauthenticated(t *testing.T) {
 		util.Wrap(kfpauth.IdentityHeaderMissingError, "Failed to authorize the request").Error(),
 	)
 }
+
+func TestCheckAccessV1_SingleUserMode(t *testing.T) {
+	clients, manager, experiment := initWithExperiment_SubjectAccessReview_Unauthorized(t)
+	defer clients.Close()
+	authServer := AuthServer{resourceManager: manager}
+
+	decision, err := authServer.CheckAccessV1(context.Background(), &api.CheckAccessRequest{ResourceType: api.ResourceType_EXPERIMENT, ResourceId: experiment.UUID, Verb: "delete"})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.CheckAccessResponse{Allowed: true}, decision)
+}
+
+func TestCheckAccessV1_Allowed(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+
+	clients, manager, experiment := initWithExperiment(t)
+	defer clients.Close()
+	authServer := AuthServer{resourceManager: manager}
+
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "user@google.com"})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	decision, err := authServer.CheckAccessV1(ctx, &api.CheckAccessRequest{ResourceType: api.ResourceType_EXPERIMENT, ResourceId: experiment.UUID, Verb: "GET"})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.CheckAccessResponse{Allowed: true}, decision)
+
+	decision, err = authServer.CheckAccessV1(ctx, &api.CheckAccessRequest{ResourceType: api.ResourceType_NAMESPACE, ResourceId: "ns1", Verb: "create"})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.CheckAccessResponse{Allowed: true}, decision)
+}
+
+func TestCheckAccessV1_Denied(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+
+	clients, manager, experiment := initWithExperiment_SubjectAccessReview_Unauthorized(t)
+	defer clients.Close()
+	authServer := AuthServer{resourceManager: manager}
+
+	userIdentity := "user@google.com"
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + userIdentity})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	decision, err := authServer.CheckAccessV1(ctx, &api.CheckAccessRequest{ResourceType: api.ResourceType_EXPERIMENT, ResourceId: experiment.UUID, Verb: "delete"})
+	assert.Nil(t, err)
+	assert.False(t, decision.Allowed)
+
+	resourceAttributes := &authorizationv1.ResourceAttributes{
+		Namespace: "ns1",
+		Verb:      common.RbacResourceVerbDelete,
+		Group:     common.RbacPipelinesGroup,
+		Version:   common.RbacPipelinesVersion,
+		Resource:  common.RbacResourceTypeExperiments,
+		Name:      experiment.Name,
+	}
+	assert.Equal(t, getPermissionDeniedError(userIdentity, resourceAttributes).(*util.UserError).ExternalMessage(), decision.Reason)
+}
+
+func TestCheckAccessV1_InvalidVerb(t *testing.T) {
+	clients, manager, experiment := initWithExperiment(t)
+	defer clients.Close()
+	authServer := AuthServer{resourceManager: manager}
+
+	_, err := authServer.CheckAccessV1(context.Background(), &api.CheckAccessRequest{ResourceType: api.ResourceType_EXPERIMENT, ResourceId: experiment.UUID, Verb: "update"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Verb \"update\" is not supported")
+}
@@ -39,6 +39,11 @@ var (
 		Help: "The total number of DeleteExperiment requests",
 	})
 
+	updateExperimentRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "experiment_server_update_requests",
+		Help: "The total number of UpdateExperiment requests",
+	})
+
 	archiveExperimentRequests = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "experiment_server_archive_requests",
 		Help: "The total number of ArchiveExperiment requests",
@@ -72,6 +77,13 @@ func (s *ExperimentServer) CreateExperimentV1(ctx context.Context, request *apiv
 		createExperimentRequests.Inc()
 	}
 
+	if request.GetExperiment() != nil {
+		references, err := stampImpersonatedNamespaceV1(ctx, request.Experiment.ResourceReferences)
+		if err != nil {
+			return nil, err
+		}
+		request.Experiment.ResourceReferences = references
+	}
 	err := ValidateCreateExperimentRequestV1(request)
 	if err != nil {
 		return nil, util.Wrap(err, "Validate experiment request failed.")
@@ -104,6 +116,13 @@ func (s *ExperimentServer) CreateExperiment(ctx context.Context, request *apiv2b
 		createExperimentRequests.Inc()
 	}
 
+	if request.GetExperiment() != nil {
+		namespace, err := stampImpersonatedNamespace(ctx, request.Experiment.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		request.Experiment.Namespace = namespace
+	}
 	err := ValidateCreateExperimentRequest(request)
 	if err != nil {
 		return nil, util.Wrap(err, "Validate experiment request failed.")
@@ -166,17 +185,48 @@ func (s *ExperimentServer) GetExperiment(ctx context.Context, request *apiv2beta
 	return ToApiExperiment(experiment), nil
 }
 
+func (s *ExperimentServer) UpdateExperiment(ctx context.Context, request *apiv2beta1.UpdateExperimentRequest) (
+	*apiv2beta1.Experiment, error) {
+	if s.options.CollectMetrics {
+		updateExperimentRequests.Inc()
+	}
+
+	experimentId := request.GetExperiment().GetExperimentId()
+	if experimentId == "" {
+		return nil, util.NewInvalidInputError("Experiment ID is required")
+	}
+	err := s.canAccessExperiment(ctx, experimentId, &authorizationv1.ResourceAttributes{Verb: common.RbacResourceVerbUpdate})
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to authorize the request")
+	}
+
+	experiment, err := s.resourceManager.UpdateExperiment(experimentId, request.GetExperiment().GetDisplayName(),
+		request.GetExperiment().GetDescription(), request.GetExperiment().GetResourceVersion())
+	if err != nil {
+		return nil, util.Wrap(err, "Update experiment failed.")
+	}
+	return ToApiExperiment(experiment), nil
+}
+
 func (s *ExperimentServer) ListExperimentsV1(ctx context.Context, request *apiv1beta1.ListExperimentsRequest) (
 	*apiv1beta1.ListExperimentsResponse, error) {
 	if s.options.CollectMetrics {
 		listExperimentsV1Requests.Inc()
 	}
 
-	opts, err := validatedListOptions(&model.Experiment{}, request.PageToken, int(request.PageSize), request.SortBy, request.Filter)
+	filter, err := normalizeExperimentStorageStateFilter(request.Filter)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to create list options")
+	}
+	opts, err := validatedListOptions(&model.Experiment{}, request.PageToken, int(request.PageSize), request.SortBy, filter)
 
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to create list options")
 	}
+	// The page token of the next pages keeps including them.
+	if request.GetIncludeDeleted() {
+		opts.IncludeDeleted = true
+	}
 
 	filterContext, err := ValidateFilterV1(request.ResourceReferenceKey)
 	if err != nil {
@@ -227,11 +277,19 @@ func (s *ExperimentServer) ListExperiments(ctx context.Context, request *apiv2be
 		listExperimentsV1Requests.Inc()
 	}
 
-	opts, err := validatedListOptions(&model.Experiment{}, request.PageToken, int(request.PageSize), request.SortBy, request.Filter)
+	filter, err := normalizeExperimentStorageStateFilter(request.Filter)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to create list options")
+	}
+	opts, err := validatedListOptions(&model.Experiment{}, request.PageToken, int(request.PageSize), request.SortBy, filter)
 
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to create list options")
 	}
+	// The page token of the next pages keeps including them.
+	if request.GetIncludeDeleted() {
+		opts.IncludeDeleted = true
+	}
 
 	filterContext := &common.FilterContext{}
 	if common.IsMultiUserMode() {
@@ -280,7 +338,7 @@ func (s *ExperimentServer) DeleteExperimentV1(ctx context.Context, request *apiv
 		return nil, util.Wrap(err, "Failed to authorize the request")
 	}
 
-	err = s.resourceManager.DeleteExperiment(request.Id)
+	err = s.resourceManager.DeleteExperiment(ctx, request.Id, common.IsExperimentSoftDeleteEnabled())
 	if err != nil {
 		return nil, err
 	}
@@ -300,7 +358,7 @@ func (s *ExperimentServer) DeleteExperiment(ctx context.Context, request *apiv2b
 		return nil, util.Wrap(err, "Failed to authorize the request")
 	}
 
-	err = s.resourceManager.DeleteExperiment(request.ExperimentId)
+	err = s.resourceManager.DeleteExperiment(ctx, request.ExperimentId, common.IsExperimentSoftDeleteEnabled())
 	if err != nil {
 		return nil, err
 	}
@@ -450,6 +508,66 @@ func (s *ExperimentServer) UnarchiveExperiment(ctx context.Context, request *api
 	return &empty.Empty{}, nil
 }
 
+// ExportExperiment returns an experiment and its runs as a tar archive that ImportExperiment can recreate them
+// from, on this or another instance. The status of the workflows of the runs is only included if
+// includeWorkflowStatus is set.
+func (s *ExperimentServer) ExportExperiment(ctx context.Context, experimentId string, includeWorkflowStatus bool) ([]byte, error) {
+	err := s.canAccessExperiment(ctx, experimentId, &authorizationv1.ResourceAttributes{Verb: common.RbacResourceVerbGet})
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to authorize the request")
+	}
+	return s.resourceManager.ExportExperiment(experimentId, includeWorkflowStatus)
+}
+
+// GetExperimentRunCounts returns the number of runs of an experiment in each state, leaving archived runs out
+// unless includeArchived is set.
+func (s *ExperimentServer) GetExperimentRunCounts(ctx context.Context, experimentId string, includeArchived bool) (map[string]int, error) {
+	err := s.canAccessExperiment(ctx, experimentId, &authorizationv1.ResourceAttributes{Verb: common.RbacResourceVerbGet})
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to authorize the request")
+	}
+	return s.resourceManager.GetExperimentRunCounts(ctx, experimentId, includeArchived)
+}
+
+// ImportExperiment creates an experiment, and its runs as finished runs, in a namespace from an archive made by
+// ExportExperiment.
+func (s *ExperimentServer) ImportExperiment(ctx context.Context, namespace string, bundle []byte) (*apiv2beta1.Experiment, error) {
+	namespace, err := stampImpersonatedNamespace(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if common.IsMultiUserMode() && namespace == "" {
+		return nil, util.NewInvalidInputError("In multi-user mode, experiment namespace is empty. Please specify a valid namespace.")
+	} else if !common.IsMultiUserMode() && namespace != "" {
+		return nil, util.NewInvalidInputError("In single-user mode, ImportExperiment shouldn't contain namespace.")
+	}
+	err = s.canAccessExperiment(ctx, "", &authorizationv1.ResourceAttributes{Namespace: namespace, Verb: common.RbacResourceVerbCreate})
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to authorize the request")
+	}
+	if common.IsMultiUserMode() {
+		err = isAuthorized(s.resourceManager, ctx, &authorizationv1.ResourceAttributes{
+			Namespace: namespace,
+			Verb:      common.RbacResourceVerbCreate,
+			Group:     common.RbacPipelinesGroup,
+			Version:   common.RbacPipelinesVersion,
+			Resource:  common.RbacResourceTypeRuns,
+		})
+		if err != nil {
+			return nil, util.Wrap(err, "Failed to authorize the request")
+		}
+	}
+
+	experiment, err := s.resourceManager.ImportExperiment(namespace, bundle)
+	if err != nil {
+		return nil, util.Wrap(err, "Import experiment failed.")
+	}
+	if s.options.CollectMetrics {
+		experimentCount.Inc()
+	}
+	return ToApiExperiment(experiment), nil
+}
+
 func NewExperimentServer(resourceManager *resource.ResourceManager, options *ExperimentServerOptions) *ExperimentServer {
 	return &ExperimentServer{resourceManager: resourceManager, options: options}
 }
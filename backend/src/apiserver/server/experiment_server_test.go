@@ -19,6 +19,7 @@ import (
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	authorizationv1 "k8s.io/api/authorization/v1"
 )
@@ -32,11 +33,12 @@ func TestCreateExperimentV1(t *testing.T) {
 	result, err := server.CreateExperimentV1(nil, &apiV1beta1.CreateExperimentRequest{Experiment: experiment})
 	assert.Nil(t, err)
 	expectedExperiment := &apiV1beta1.Experiment{
-		Id:           resource.DefaultFakeUUID,
-		Name:         "ex1",
-		Description:  "first experiment",
-		CreatedAt:    &timestamp.Timestamp{Seconds: 1},
-		StorageState: apiV1beta1.Experiment_STORAGESTATE_AVAILABLE,
+		Id:              resource.DefaultFakeUUID,
+		Name:            "ex1",
+		Description:     "first experiment",
+		CreatedAt:       &timestamp.Timestamp{Seconds: 1},
+		StorageState:    apiV1beta1.Experiment_STORAGESTATE_AVAILABLE,
+		ResourceVersion: 1,
 	}
 	assert.Equal(t, expectedExperiment, result)
 }
@@ -50,11 +52,12 @@ func TestCreateExperiment(t *testing.T) {
 	result, err := server.CreateExperiment(nil, &apiV2beta1.CreateExperimentRequest{Experiment: experiment})
 	assert.Nil(t, err)
 	expectedExperiment := &apiV2beta1.Experiment{
-		ExperimentId: resource.DefaultFakeUUID,
-		DisplayName:  "ex1",
-		Description:  "first experiment",
-		CreatedAt:    &timestamp.Timestamp{Seconds: 1},
-		StorageState: apiV2beta1.Experiment_AVAILABLE,
+		ExperimentId:    resource.DefaultFakeUUID,
+		DisplayName:     "ex1",
+		Description:     "first experiment",
+		CreatedAt:       &timestamp.Timestamp{Seconds: 1},
+		StorageState:    apiV2beta1.Experiment_AVAILABLE,
+		ResourceVersion: 1,
 	}
 	assert.Equal(t, expectedExperiment, result)
 }
@@ -216,6 +219,7 @@ func TestCreateExperimentV1_Multiuser(t *testing.T) {
 		CreatedAt:          &timestamp.Timestamp{Seconds: 1},
 		ResourceReferences: resourceReferences,
 		StorageState:       apiV1beta1.Experiment_STORAGESTATE_AVAILABLE,
+		ResourceVersion:    1,
 	}
 	assert.Equal(t, expectedExperiment, result)
 }
@@ -238,16 +242,123 @@ func TestCreateExperiment_Multiuser(t *testing.T) {
 	result, err := server.CreateExperiment(ctx, &apiV2beta1.CreateExperimentRequest{Experiment: experiment})
 	assert.Nil(t, err)
 	expectedExperiment := &apiV2beta1.Experiment{
-		ExperimentId: resource.DefaultFakeUUID,
-		DisplayName:  "exp1",
-		Description:  "first experiment",
-		CreatedAt:    &timestamp.Timestamp{Seconds: 1},
-		Namespace:    "ns1",
-		StorageState: apiV2beta1.Experiment_AVAILABLE,
+		ExperimentId:    resource.DefaultFakeUUID,
+		DisplayName:     "exp1",
+		Description:     "first experiment",
+		CreatedAt:       &timestamp.Timestamp{Seconds: 1},
+		Namespace:       "ns1",
+		StorageState:    apiV2beta1.Experiment_AVAILABLE,
+		ResourceVersion: 1,
 	}
 	assert.Equal(t, expectedExperiment, result)
 }
 
+func TestCreateExperiment_Multiuser_SameNameInDifferentNamespaces(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "user@google.com"})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	server := ExperimentServer{resourceManager: resource.NewResourceManager(clientManager), options: &ExperimentServerOptions{CollectMetrics: false}}
+	_, err := server.CreateExperiment(ctx, &apiV2beta1.CreateExperimentRequest{
+		Experiment: &apiV2beta1.Experiment{DisplayName: "default-exp", Namespace: "ns1"}})
+	assert.Nil(t, err)
+
+	clientManager.UpdateUUID(util.NewFakeUUIDGeneratorOrFatal(resource.NonDefaultFakeUUID, nil))
+	server = ExperimentServer{resourceManager: resource.NewResourceManager(clientManager), options: &ExperimentServerOptions{CollectMetrics: false}}
+	result, err := server.CreateExperiment(ctx, &apiV2beta1.CreateExperimentRequest{
+		Experiment: &apiV2beta1.Experiment{DisplayName: "default-exp", Namespace: "ns2"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "ns2", result.Namespace)
+
+	clientManager.UpdateUUID(util.NewFakeUUIDGeneratorOrFatal(resource.FakeUUIDOne, nil))
+	server = ExperimentServer{resourceManager: resource.NewResourceManager(clientManager), options: &ExperimentServerOptions{CollectMetrics: false}}
+	_, err = server.CreateExperiment(ctx, &apiV2beta1.CreateExperimentRequest{
+		Experiment: &apiV2beta1.Experiment{DisplayName: "default-exp", Namespace: "ns1"}})
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.AlreadyExists, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestCreateExperimentV1_ImpersonateNamespace(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+	viper.Set(common.ImpersonationAdminIdentities, "admin@google.com")
+	defer viper.Set(common.ImpersonationAdminIdentities, "")
+	md := metadata.New(map[string]string{
+		common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "admin@google.com",
+		common.ImpersonateNamespaceHeader:  "tenant",
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	resourceManager := resource.NewResourceManager(clientManager)
+	server := ExperimentServer{resourceManager: resourceManager, options: &ExperimentServerOptions{CollectMetrics: false}}
+	experiment := &apiV1beta1.Experiment{
+		Name: "exp1",
+		ResourceReferences: []*apiV1beta1.ResourceReference{
+			{
+				Key:          &apiV1beta1.ResourceKey{Type: apiV1beta1.ResourceType_NAMESPACE, Id: "ns1"},
+				Relationship: apiV1beta1.Relationship_OWNER,
+			},
+		},
+	}
+
+	result, err := server.CreateExperimentV1(ctx, &apiV1beta1.CreateExperimentRequest{Experiment: experiment})
+	assert.Nil(t, err)
+	assert.Equal(t, []*apiV1beta1.ResourceReference{
+		{
+			Key:          &apiV1beta1.ResourceKey{Type: apiV1beta1.ResourceType_NAMESPACE, Id: "tenant"},
+			Relationship: apiV1beta1.Relationship_OWNER,
+		},
+	}, result.ResourceReferences)
+}
+
+func TestCreateExperiment_ImpersonateNamespace_NotAdmin(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+	viper.Set(common.ImpersonationAdminIdentities, "admin@google.com")
+	defer viper.Set(common.ImpersonationAdminIdentities, "")
+	md := metadata.New(map[string]string{
+		common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "user@google.com",
+		common.ImpersonateNamespaceHeader:  "tenant",
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	resourceManager := resource.NewResourceManager(clientManager)
+	server := ExperimentServer{resourceManager: resourceManager, options: &ExperimentServerOptions{CollectMetrics: false}}
+	experiment := &apiV2beta1.Experiment{DisplayName: "exp1", Namespace: "ns1"}
+
+	_, err := server.CreateExperiment(ctx, &apiV2beta1.CreateExperimentRequest{Experiment: experiment})
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestGetExperiment_ImpersonateNamespace_OtherNamespace(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+	viper.Set(common.ImpersonationAdminIdentities, "admin@google.com")
+	defer viper.Set(common.ImpersonationAdminIdentities, "")
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "admin@google.com"})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	resourceManager := resource.NewResourceManager(clientManager)
+	server := ExperimentServer{resourceManager: resourceManager, options: &ExperimentServerOptions{CollectMetrics: false}}
+	experiment, err := server.CreateExperiment(ctx, &apiV2beta1.CreateExperimentRequest{
+		Experiment: &apiV2beta1.Experiment{DisplayName: "exp1", Namespace: "ns1"},
+	})
+	assert.Nil(t, err)
+
+	// An impersonated request can't reach resources of other namespaces.
+	md = metadata.Join(md, metadata.New(map[string]string{common.ImpersonateNamespaceHeader: "tenant"}))
+	ctx = metadata.NewIncomingContext(context.Background(), md)
+	_, err = server.GetExperiment(ctx, &apiV2beta1.GetExperimentRequest{ExperimentId: experiment.ExperimentId})
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+}
+
 func TestGetExperimentV1(t *testing.T) {
 	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
 	resourceManager := resource.NewResourceManager(clientManager)
@@ -259,11 +370,12 @@ func TestGetExperimentV1(t *testing.T) {
 	result, err := server.GetExperimentV1(nil, &apiV1beta1.GetExperimentRequest{Id: createResult.Id})
 	assert.Nil(t, err)
 	expectedExperiment := &apiV1beta1.Experiment{
-		Id:           createResult.Id,
-		Name:         "ex1",
-		Description:  "first experiment",
-		CreatedAt:    &timestamp.Timestamp{Seconds: 1},
-		StorageState: apiV1beta1.Experiment_STORAGESTATE_AVAILABLE,
+		Id:              createResult.Id,
+		Name:            "ex1",
+		Description:     "first experiment",
+		CreatedAt:       &timestamp.Timestamp{Seconds: 1},
+		StorageState:    apiV1beta1.Experiment_STORAGESTATE_AVAILABLE,
+		ResourceVersion: 1,
 	}
 	assert.Equal(t, expectedExperiment, result)
 }
@@ -279,15 +391,50 @@ func TestGetExperiment(t *testing.T) {
 	result, err := server.GetExperiment(nil, &apiV2beta1.GetExperimentRequest{ExperimentId: createResult.ExperimentId})
 	assert.Nil(t, err)
 	expectedExperiment := &apiV2beta1.Experiment{
-		ExperimentId: createResult.ExperimentId,
-		DisplayName:  "ex1",
-		Description:  "first experiment",
-		CreatedAt:    &timestamp.Timestamp{Seconds: 1},
-		StorageState: apiV2beta1.Experiment_AVAILABLE,
+		ExperimentId:    createResult.ExperimentId,
+		DisplayName:     "ex1",
+		Description:     "first experiment",
+		CreatedAt:       &timestamp.Timestamp{Seconds: 1},
+		StorageState:    apiV2beta1.Experiment_AVAILABLE,
+		ResourceVersion: 1,
 	}
 	assert.Equal(t, expectedExperiment, result)
 }
 
+func TestUpdateExperiment(t *testing.T) {
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	resourceManager := resource.NewResourceManager(clientManager)
+	server := ExperimentServer{resourceManager: resourceManager, options: &ExperimentServerOptions{CollectMetrics: false}}
+	experiment := &apiV2beta1.Experiment{DisplayName: "ex1", Description: "first experiment"}
+	createResult, err := server.CreateExperiment(nil, &apiV2beta1.CreateExperimentRequest{Experiment: experiment})
+	assert.Nil(t, err)
+
+	result, err := server.UpdateExperiment(nil, &apiV2beta1.UpdateExperimentRequest{Experiment: &apiV2beta1.Experiment{
+		ExperimentId:    createResult.ExperimentId,
+		DisplayName:     "ex2",
+		Description:     "second experiment",
+		ResourceVersion: createResult.ResourceVersion,
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, "ex2", result.DisplayName)
+	assert.Equal(t, "second experiment", result.Description)
+	assert.Equal(t, int64(2), result.ResourceVersion)
+
+	// An update of the version read before the first update conflicts with it.
+	_, err = server.UpdateExperiment(nil, &apiV2beta1.UpdateExperimentRequest{Experiment: &apiV2beta1.Experiment{
+		ExperimentId:    createResult.ExperimentId,
+		DisplayName:     "ex3",
+		ResourceVersion: createResult.ResourceVersion,
+	}})
+	assert.Equal(t, codes.Aborted, err.(*util.UserError).ExternalStatusCode())
+	result, err = server.GetExperiment(nil, &apiV2beta1.GetExperimentRequest{ExperimentId: createResult.ExperimentId})
+	assert.Nil(t, err)
+	assert.Equal(t, "ex2", result.DisplayName)
+
+	_, err = server.UpdateExperiment(nil, &apiV2beta1.UpdateExperimentRequest{Experiment: &apiV2beta1.Experiment{DisplayName: "ex3"}})
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+}
+
 func TestGetExperimentV1_Failed(t *testing.T) {
 	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
 	resourceManager := resource.NewResourceManager(clientManager)
@@ -376,6 +523,54 @@ func TestGetExperiment_Unauthorized(t *testing.T) {
 	)
 }
 
+func TestExportExperiment_Unauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+
+	userIdentity := "user@google.com"
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + userIdentity})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clients, resourceManager, experiment := initWithExperiment_SubjectAccessReview_Unauthorized(t)
+	defer clients.Close()
+	server := ExperimentServer{resourceManager: resourceManager, options: &ExperimentServerOptions{CollectMetrics: false}}
+
+	_, err := server.ExportExperiment(ctx, experiment.UUID, false)
+	assert.NotNil(t, err)
+	resourceAttributes := &authorizationv1.ResourceAttributes{
+		Namespace: "ns1",
+		Verb:      common.RbacResourceVerbGet,
+		Group:     common.RbacPipelinesGroup,
+		Version:   common.RbacPipelinesVersion,
+		Resource:  common.RbacResourceTypeExperiments,
+		Name:      "exp1",
+	}
+	assert.EqualError(
+		t,
+		err,
+		wrapFailedAuthzRequestError(wrapFailedAuthzApiResourcesError(getPermissionDeniedError(userIdentity, resourceAttributes))).Error(),
+	)
+}
+
+func TestImportExperiment(t *testing.T) {
+	clients, resourceManager, experiment := initWithExperiment(t)
+	defer clients.Close()
+	server := ExperimentServer{resourceManager: resourceManager, options: &ExperimentServerOptions{CollectMetrics: false}}
+
+	bundle, err := server.ExportExperiment(context.Background(), experiment.UUID, false)
+	assert.Nil(t, err)
+	_, err = server.ImportExperiment(context.Background(), "ns1", bundle)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "In single-user mode, ImportExperiment shouldn't contain namespace")
+
+	clients.UpdateUUID(util.NewFakeUUIDGeneratorOrFatal(resource.FakeUUIDOne, nil))
+	server.resourceManager = resource.NewResourceManager(clients)
+	imported, err := server.ImportExperiment(context.Background(), "", bundle)
+	assert.Nil(t, err)
+	assert.Equal(t, resource.FakeUUIDOne, imported.ExperimentId)
+	assert.Equal(t, experiment.Name+" (1)", imported.DisplayName)
+}
+
 func TestGetExperimentV1_Multiuser(t *testing.T) {
 	viper.Set(common.MultiUserMode, "true")
 	defer viper.Set(common.MultiUserMode, "false")
@@ -408,6 +603,7 @@ func TestGetExperimentV1_Multiuser(t *testing.T) {
 		CreatedAt:          &timestamp.Timestamp{Seconds: 1},
 		ResourceReferences: resourceReferences,
 		StorageState:       apiV1beta1.Experiment_STORAGESTATE_AVAILABLE,
+		ResourceVersion:    1,
 	}
 	assert.Equal(t, expectedExperiment, result)
 }
@@ -432,12 +628,13 @@ func TestGetExperiment_Multiuser(t *testing.T) {
 	result, err := server.GetExperiment(ctx, &apiV2beta1.GetExperimentRequest{ExperimentId: createResult.ExperimentId})
 	assert.Nil(t, err)
 	expectedExperiment := &apiV2beta1.Experiment{
-		ExperimentId: createResult.ExperimentId,
-		DisplayName:  "exp1",
-		Description:  "first experiment",
-		CreatedAt:    &timestamp.Timestamp{Seconds: 1},
-		Namespace:    "ns1",
-		StorageState: apiV2beta1.Experiment_AVAILABLE,
+		ExperimentId:    createResult.ExperimentId,
+		DisplayName:     "exp1",
+		Description:     "first experiment",
+		CreatedAt:       &timestamp.Timestamp{Seconds: 1},
+		Namespace:       "ns1",
+		StorageState:    apiV2beta1.Experiment_AVAILABLE,
+		ResourceVersion: 1,
 	}
 	assert.Equal(t, expectedExperiment, result)
 }
@@ -452,11 +649,12 @@ func TestListExperimentsV1(t *testing.T) {
 	assert.Nil(t, err)
 	result, err := server.ListExperimentsV1(nil, &apiV1beta1.ListExperimentsRequest{})
 	expectedExperiment := []*apiV1beta1.Experiment{{
-		Id:           createResult.Id,
-		Name:         "ex1",
-		Description:  "first experiment",
-		CreatedAt:    &timestamp.Timestamp{Seconds: 1},
-		StorageState: apiV1beta1.Experiment_STORAGESTATE_AVAILABLE,
+		Id:              createResult.Id,
+		Name:            "ex1",
+		Description:     "first experiment",
+		CreatedAt:       &timestamp.Timestamp{Seconds: 1},
+		StorageState:    apiV1beta1.Experiment_STORAGESTATE_AVAILABLE,
+		ResourceVersion: 1,
 	}}
 	assert.Nil(t, err)
 	assert.Equal(t, expectedExperiment, result.Experiments)
@@ -472,11 +670,12 @@ func TestListExperiments(t *testing.T) {
 	assert.Nil(t, err)
 	result, err := server.ListExperiments(nil, &apiV2beta1.ListExperimentsRequest{})
 	expectedExperiment := []*apiV2beta1.Experiment{{
-		ExperimentId: createResult.ExperimentId,
-		DisplayName:  "ex1",
-		Description:  "first experiment",
-		CreatedAt:    &timestamp.Timestamp{Seconds: 1},
-		StorageState: apiV2beta1.Experiment_AVAILABLE,
+		ExperimentId:    createResult.ExperimentId,
+		DisplayName:     "ex1",
+		Description:     "first experiment",
+		CreatedAt:       &timestamp.Timestamp{Seconds: 1},
+		StorageState:    apiV2beta1.Experiment_AVAILABLE,
+		ResourceVersion: 1,
 	}}
 	assert.Nil(t, err)
 	assert.Equal(t, expectedExperiment, result.Experiments)
@@ -652,6 +851,7 @@ func TestListExperimentsV1_Multiuser(t *testing.T) {
 				CreatedAt:          &timestamp.Timestamp{Seconds: 1},
 				ResourceReferences: resourceReferences,
 				StorageState:       apiV1beta1.Experiment_STORAGESTATE_AVAILABLE,
+				ResourceVersion:    1,
 			}},
 		},
 		{
@@ -749,12 +949,13 @@ func TestListExperiments_Multiuser(t *testing.T) {
 			false,
 			"",
 			[]*apiV2beta1.Experiment{{
-				ExperimentId: createResult.ExperimentId,
-				DisplayName:  "exp1",
-				Description:  "first experiment",
-				CreatedAt:    &timestamp.Timestamp{Seconds: 1},
-				Namespace:    "ns1",
-				StorageState: apiV2beta1.Experiment_AVAILABLE,
+				ExperimentId:    createResult.ExperimentId,
+				DisplayName:     "exp1",
+				Description:     "first experiment",
+				CreatedAt:       &timestamp.Timestamp{Seconds: 1},
+				Namespace:       "ns1",
+				StorageState:    apiV2beta1.Experiment_AVAILABLE,
+				ResourceVersion: 1,
 			}},
 		},
 		{
@@ -1007,6 +1208,9 @@ func TestValidateCreateExperimentRequest_Multiuser(t *testing.T) {
 	}
 }
 
+// archivedRunsFilter lists archived runs, which are left out of run lists by default.
+const archivedRunsFilter = `{"predicates": [{"key": "storage_state", "op": "EQUALS", "string_value": "STORAGESTATE_ARCHIVED"}]}`
+
 func TestArchiveAndUnarchiveExperimentV1(t *testing.T) {
 	// Create experiment and runs/jobs under it.
 	clients, manager, experiment := initWithExperimentAndPipelineVersion(t)
@@ -1015,6 +1219,9 @@ func TestArchiveAndUnarchiveExperimentV1(t *testing.T) {
 	run1 := &apiV1beta1.Run{
 		Name:               "run1",
 		ResourceReferences: validReferencesOfExperimentAndPipelineVersion,
+		PipelineSpec: &apiV1beta1.PipelineSpec{
+			Parameters: []*apiV1beta1.Parameter{{Name: "param1", Value: "world"}},
+		},
 	}
 	err := runServer.validateCreateRunRequestV1(&apiV1beta1.CreateRunRequest{Run: run1})
 	assert.Nil(t, err)
@@ -1026,6 +1233,9 @@ func TestArchiveAndUnarchiveExperimentV1(t *testing.T) {
 	run2 := &apiV1beta1.Run{
 		Name:               "run2",
 		ResourceReferences: validReferencesOfExperimentAndPipelineVersion,
+		PipelineSpec: &apiV1beta1.PipelineSpec{
+			Parameters: []*apiV1beta1.Parameter{{Name: "param1", Value: "world"}},
+		},
 	}
 	err = runServer.validateCreateRunRequestV1(&apiV1beta1.CreateRunRequest{Run: run2})
 	assert.Nil(t, err)
@@ -1057,7 +1267,7 @@ func TestArchiveAndUnarchiveExperimentV1(t *testing.T) {
 	result, err := experimentServer.GetExperimentV1(nil, &apiV1beta1.GetExperimentRequest{Id: experiment.UUID})
 	assert.Nil(t, err)
 	assert.Equal(t, apiV1beta1.Experiment_STORAGESTATE_ARCHIVED, result.StorageState)
-	runs, err := runServer.ListRunsV1(nil, &apiV1beta1.ListRunsRequest{ResourceReferenceKey: &apiV1beta1.ResourceKey{Id: experiment.UUID, Type: apiV1beta1.ResourceType_EXPERIMENT}})
+	runs, err := runServer.ListRunsV1(nil, &apiV1beta1.ListRunsRequest{ResourceReferenceKey: &apiV1beta1.ResourceKey{Id: experiment.UUID, Type: apiV1beta1.ResourceType_EXPERIMENT}, Filter: archivedRunsFilter})
 	assert.Nil(t, err)
 	assert.Equal(t, 2, len(runs.Runs))
 	assert.Equal(t, apiV1beta1.Run_STORAGESTATE_ARCHIVED, runs.Runs[0].StorageState)
@@ -1073,7 +1283,7 @@ func TestArchiveAndUnarchiveExperimentV1(t *testing.T) {
 	result, err = experimentServer.GetExperimentV1(nil, &apiV1beta1.GetExperimentRequest{Id: experiment.UUID})
 	assert.Nil(t, err)
 	assert.Equal(t, apiV1beta1.Experiment_STORAGESTATE_AVAILABLE, result.StorageState)
-	runs, err = runServer.ListRunsV1(nil, &apiV1beta1.ListRunsRequest{ResourceReferenceKey: &apiV1beta1.ResourceKey{Id: experiment.UUID, Type: apiV1beta1.ResourceType_EXPERIMENT}})
+	runs, err = runServer.ListRunsV1(nil, &apiV1beta1.ListRunsRequest{ResourceReferenceKey: &apiV1beta1.ResourceKey{Id: experiment.UUID, Type: apiV1beta1.ResourceType_EXPERIMENT}, Filter: archivedRunsFilter})
 	assert.Nil(t, err)
 	assert.Equal(t, 2, len(runs.Runs))
 	assert.Equal(t, apiV1beta1.Run_STORAGESTATE_ARCHIVED, runs.Runs[0].StorageState)
@@ -1092,6 +1302,9 @@ func TestArchiveAndUnarchiveExperiment(t *testing.T) {
 	run1 := &apiV1beta1.Run{
 		Name:               "run1",
 		ResourceReferences: validReferencesOfExperimentAndPipelineVersion,
+		PipelineSpec: &apiV1beta1.PipelineSpec{
+			Parameters: []*apiV1beta1.Parameter{{Name: "param1", Value: "world"}},
+		},
 	}
 	err := runServer.validateCreateRunRequestV1(&apiV1beta1.CreateRunRequest{Run: run1})
 	assert.Nil(t, err)
@@ -1103,6 +1316,9 @@ func TestArchiveAndUnarchiveExperiment(t *testing.T) {
 	run2 := &apiV1beta1.Run{
 		Name:               "run2",
 		ResourceReferences: validReferencesOfExperimentAndPipelineVersion,
+		PipelineSpec: &apiV1beta1.PipelineSpec{
+			Parameters: []*apiV1beta1.Parameter{{Name: "param1", Value: "world"}},
+		},
 	}
 	err = runServer.validateCreateRunRequestV1(&apiV1beta1.CreateRunRequest{Run: run2})
 	assert.Nil(t, err)
@@ -1134,7 +1350,7 @@ func TestArchiveAndUnarchiveExperiment(t *testing.T) {
 	result, err := experimentServer.GetExperiment(nil, &apiV2beta1.GetExperimentRequest{ExperimentId: experiment.UUID})
 	assert.Nil(t, err)
 	assert.Equal(t, apiV2beta1.Experiment_ARCHIVED, result.StorageState)
-	runs, err := runServer.ListRunsV1(nil, &apiV1beta1.ListRunsRequest{ResourceReferenceKey: &apiV1beta1.ResourceKey{Id: experiment.UUID, Type: apiV1beta1.ResourceType_EXPERIMENT}})
+	runs, err := runServer.ListRunsV1(nil, &apiV1beta1.ListRunsRequest{ResourceReferenceKey: &apiV1beta1.ResourceKey{Id: experiment.UUID, Type: apiV1beta1.ResourceType_EXPERIMENT}, Filter: archivedRunsFilter})
 	assert.Nil(t, err)
 	assert.Equal(t, 2, len(runs.Runs))
 	assert.Equal(t, apiV1beta1.Run_STORAGESTATE_ARCHIVED, runs.Runs[0].StorageState)
@@ -1150,7 +1366,7 @@ func TestArchiveAndUnarchiveExperiment(t *testing.T) {
 	result, err = experimentServer.GetExperiment(nil, &apiV2beta1.GetExperimentRequest{ExperimentId: experiment.UUID})
 	assert.Nil(t, err)
 	assert.Equal(t, apiV2beta1.Experiment_AVAILABLE, result.StorageState)
-	runs, err = runServer.ListRunsV1(nil, &apiV1beta1.ListRunsRequest{ResourceReferenceKey: &apiV1beta1.ResourceKey{Id: experiment.UUID, Type: apiV1beta1.ResourceType_EXPERIMENT}})
+	runs, err = runServer.ListRunsV1(nil, &apiV1beta1.ListRunsRequest{ResourceReferenceKey: &apiV1beta1.ResourceKey{Id: experiment.UUID, Type: apiV1beta1.ResourceType_EXPERIMENT}, Filter: archivedRunsFilter})
 	assert.Nil(t, err)
 	assert.Equal(t, 2, len(runs.Runs))
 	assert.Equal(t, apiV1beta1.Run_STORAGESTATE_ARCHIVED, runs.Runs[0].StorageState)
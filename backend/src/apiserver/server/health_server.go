@@ -0,0 +1,60 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+)
+
+type HealthServer struct {
+	resourceManager *resource.ResourceManager
+}
+
+// Readiness endpoint
+// It reports the status and latency of each dependency of the API server, and responds with 503 Service
+// Unavailable if a dependency required to serve traffic is down. Liveness is served by the healthz endpoint,
+// which doesn't depend on anything but the process.
+func (s *HealthServer) Readiness(w http.ResponseWriter, r *http.Request) {
+	status := s.resourceManager.HealthCheck(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	if !status.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		glog.Errorf("Failed to write the readiness response. Error: %+v", err)
+	}
+}
+
+// ServerConfiguration endpoint
+// It reports the configuration of the API server that clients may adapt to, such as whether it runs in
+// multi-user mode and the maximum size of the pipeline files it accepts.
+func (s *HealthServer) ServerConfiguration(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.resourceManager.GetServerConfiguration(r.Context())); err != nil {
+		glog.Errorf("Failed to write the server configuration response. Error: %+v", err)
+	}
+}
+
+func NewHealthServer(resourceManager *resource.ResourceManager) *HealthServer {
+	return &HealthServer{resourceManager: resourceManager}
+}
@@ -92,14 +92,13 @@ func (s *JobServer) CreateJob(ctx context.Context, request *apiv1beta1.CreateJob
 	}
 
 	if common.IsMultiUserMode() {
-		experimentID := common.GetExperimentIDFromAPIResourceReferences(request.Job.ResourceReferences)
-		if experimentID == "" {
-			return nil, util.NewInvalidInputError("Job has no experiment.")
-		}
-		namespace, err := s.resourceManager.GetNamespaceFromExperimentID(experimentID)
+		namespace, err := namespaceOfExperimentOrReference(s.resourceManager, request.Job.ResourceReferences)
 		if err != nil {
 			return nil, util.Wrap(err, "Failed to get experiment for job.")
 		}
+		if namespace == "" && common.GetExperimentIDFromAPIResourceReferences(request.Job.ResourceReferences) == "" {
+			return nil, util.NewInvalidInputError("Job has no experiment.")
+		}
 		if namespace == "" {
 			return nil, util.NewInvalidInputError("Job's experiment has no namespace.")
 		}
@@ -112,6 +111,14 @@ func (s *JobServer) CreateJob(ctx context.Context, request *apiv1beta1.CreateJob
 		if err != nil {
 			return nil, util.Wrap(err, "Failed to authorize the request")
 		}
+		err = canAccessResourceReferences(s.resourceManager, ctx, request.Job.GetResourceReferences())
+		if err != nil {
+			return nil, err
+		}
+		err = canAccessReferencedResource(s.resourceManager, ctx, apiv1beta1.ResourceType_PIPELINE, request.Job.GetPipelineSpec().GetPipelineId())
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	newJob, err := s.resourceManager.CreateJob(ctx, request.Job)
@@ -135,7 +142,7 @@ func (s *JobServer) GetJob(ctx context.Context, request *apiv1beta1.GetJobReques
 		return nil, util.Wrap(err, "Failed to authorize the request")
 	}
 
-	job, err := s.resourceManager.GetJob(request.Id)
+	job, err := s.resourceManager.GetJobWithNextRun(ctx, request.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -198,7 +205,7 @@ func (s *JobServer) ListJobs(ctx context.Context, request *apiv1beta1.ListJobsRe
 		}
 	}
 
-	jobs, total_size, nextPageToken, err := s.resourceManager.ListJobs(filterContext, opts)
+	jobs, total_size, nextPageToken, err := s.resourceManager.ListJobsWithNextRun(ctx, filterContext, opts)
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to list jobs.")
 	}
@@ -231,6 +238,29 @@ func (s *JobServer) DisableJob(ctx context.Context, request *apiv1beta1.DisableJ
 	return s.enableJob(ctx, request.Id, false)
 }
 
+// UpdateJobEnabled pauses or resumes a job, and returns it with its new status and the time of its next run.
+func (s *JobServer) UpdateJobEnabled(ctx context.Context, jobID string, enabled bool) (*model.Job, error) {
+	verb := common.RbacResourceVerbDisable
+	if enabled {
+		verb = common.RbacResourceVerbEnable
+	}
+	err := s.canAccessJob(ctx, jobID, &authorizationv1.ResourceAttributes{Verb: verb})
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to authorize the request")
+	}
+	return s.resourceManager.UpdateJobEnabled(ctx, jobID, enabled)
+}
+
+// RestartJobSchedule makes a job schedule its next run from now, so that resuming it doesn't create the runs it
+// missed. It is authorized like enabling the job.
+func (s *JobServer) RestartJobSchedule(ctx context.Context, jobID string) (*model.Job, error) {
+	err := s.canAccessJob(ctx, jobID, &authorizationv1.ResourceAttributes{Verb: common.RbacResourceVerbEnable})
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to authorize the request")
+	}
+	return s.resourceManager.RestartJobSchedule(ctx, jobID)
+}
+
 func (s *JobServer) DeleteJob(ctx context.Context, request *apiv1beta1.DeleteJobRequest) (*empty.Empty, error) {
 	if s.options.CollectMetrics {
 		deleteJobRequests.Inc()
@@ -351,6 +381,10 @@ func (s *JobServer) CreateRecurringRun(ctx context.Context, request *apiv2beta1.
 		if err != nil {
 			return nil, util.Wrap(err, "Failed to authorize the request")
 		}
+		err = canAccessReferencedResource(s.resourceManager, ctx, apiv1beta1.ResourceType_PIPELINE, request.RecurringRun.GetPipelineId())
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Send request to resource manager to create this recurring run.
@@ -378,7 +412,7 @@ func (s *JobServer) GetRecurringRun(ctx context.Context, request *apiv2beta1.Get
 		return nil, util.Wrap(err, "Failed to authorize the request")
 	}
 
-	recurringRun, err := s.resourceManager.GetJob(request.RecurringRunId)
+	recurringRun, err := s.resourceManager.GetJobWithNextRun(ctx, request.RecurringRunId)
 	if err != nil {
 		return nil, err
 	}
@@ -448,7 +482,7 @@ func (s *JobServer) ListRecurringRuns(ctx context.Context, request *apiv2beta1.L
 		}
 	}
 
-	jobs, total_size, nextPageToken, err := s.resourceManager.ListJobs(filterContext, opts)
+	jobs, total_size, nextPageToken, err := s.resourceManager.ListJobsWithNextRun(ctx, filterContext, opts)
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to list jobs.")
 	}
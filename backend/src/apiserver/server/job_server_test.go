@@ -16,6 +16,7 @@ package server
 
 import (
 	"context"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 	"strings"
 	"testing"
@@ -67,6 +68,7 @@ var (
 		ServiceAccount: "pipeline-runner",
 		Enabled:        true,
 		MaxConcurrency: 1,
+		NoCatchup:      true,
 		Trigger: &apiv1beta1.Trigger{
 			Trigger: &apiv1beta1.Trigger_CronSchedule{CronSchedule: &apiv1beta1.CronSchedule{
 				StartTime: &timestamp.Timestamp{Seconds: 1},
@@ -380,6 +382,7 @@ func TestCreateJob_V2(t *testing.T) {
 		ServiceAccount: "pipeline-runner",
 		Enabled:        true,
 		MaxConcurrency: 1,
+		NoCatchup:      true,
 		Trigger: &apiv1beta1.Trigger{
 			Trigger: &apiv1beta1.Trigger_CronSchedule{CronSchedule: &apiv1beta1.CronSchedule{
 				StartTime: &timestamp.Timestamp{Seconds: 1},
@@ -436,6 +439,33 @@ func TestCreateJob_Unauthorized(t *testing.T) {
 	)
 }
 
+func TestCreateJob_ReferencedPipelineUnauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "user@google.com"})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clients, manager, _ := initWithExperimentAndPipelineVersionInOtherNamespace(t)
+	defer clients.Close()
+	server := NewJobServer(manager, &JobServerOptions{CollectMetrics: false})
+	apiJob := &apiv1beta1.Job{
+		Name:           "job1",
+		Enabled:        true,
+		MaxConcurrency: 1,
+		Trigger:        commonApiJob.Trigger,
+		PipelineSpec: &apiv1beta1.PipelineSpec{
+			PipelineId: resource.DefaultFakeUUID,
+			Parameters: []*apiv1beta1.Parameter{{Name: "param1", Value: "world"}},
+		},
+		ResourceReferences: commonApiJob.ResourceReferences,
+	}
+	_, err := server.CreateJob(ctx, &apiv1beta1.CreateJobRequest{Job: apiJob})
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.(*util.UserError).ExternalMessage(), "PIPELINE "+resource.DefaultFakeUUID)
+}
+
 func TestGetJob_Unauthorized(t *testing.T) {
 	viper.Set(common.MultiUserMode, "true")
 	defer viper.Set(common.MultiUserMode, "false")
@@ -487,7 +517,14 @@ func TestGetJob_Multiuser(t *testing.T) {
 
 	job, err := server.GetJob(ctx, &apiv1beta1.GetJobRequest{Id: createdJob.Id})
 	assert.Nil(t, err)
-	assert.Equal(t, commonExpectedJob, job)
+	assert.Equal(t, withNextRunAt(commonExpectedJob, 61), job)
+}
+
+// withNextRunAt returns a copy of an expected job, as read back once its next run is known.
+func withNextRunAt(job *apiv1beta1.Job, nextRunAtInSec int64) *apiv1beta1.Job {
+	job = proto.Clone(job).(*apiv1beta1.Job)
+	job.NextRunAt = &timestamp.Timestamp{Seconds: nextRunAtInSec}
+	return job
 }
 
 func TestListJobs_Unauthorized(t *testing.T) {
@@ -556,7 +593,7 @@ func TestListJobs_Multiuser(t *testing.T) {
 	assert.Nil(t, err)
 
 	var expectedJobs []*apiv1beta1.Job
-	expectedJobs = append(expectedJobs, commonExpectedJob)
+	expectedJobs = append(expectedJobs, withNextRunAt(commonExpectedJob, 61))
 	expectedJobsEmpty := []*apiv1beta1.Job{}
 
 	tests := []struct {
@@ -696,6 +733,79 @@ func TestEnableJob_Multiuser(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestUpdateJobEnabled_Unauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+
+	userIdentity := "user@google.com"
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + userIdentity})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clients, manager, _ := initWithExperiment(t)
+	defer clients.Close()
+	server := NewJobServer(manager, &JobServerOptions{CollectMetrics: false})
+	job, err := server.CreateJob(ctx, &apiv1beta1.CreateJobRequest{Job: commonApiJob})
+	assert.Nil(t, err)
+
+	clients.SubjectAccessReviewClientFake = client.NewFakeSubjectAccessReviewClientUnauthorized()
+	manager = resource.NewResourceManager(clients)
+	server = NewJobServer(manager, &JobServerOptions{CollectMetrics: false})
+
+	_, err = server.UpdateJobEnabled(ctx, job.Id, false)
+	assert.NotNil(t, err)
+	resourceAttributes := &authorizationv1.ResourceAttributes{
+		Namespace: "ns1",
+		Verb:      common.RbacResourceVerbDisable,
+		Group:     common.RbacPipelinesGroup,
+		Version:   common.RbacPipelinesVersion,
+		Resource:  common.RbacResourceTypeJobs,
+		Name:      commonApiJob.Name,
+	}
+	assert.EqualError(
+		t,
+		err,
+		wrapFailedAuthzRequestError(wrapFailedAuthzApiResourcesError(getPermissionDeniedError(userIdentity, resourceAttributes))).Error(),
+	)
+	storedJob, err := manager.GetJob(job.Id)
+	assert.Nil(t, err)
+	assert.True(t, storedJob.Enabled)
+}
+
+func TestRestartJobSchedule_Unauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+
+	userIdentity := "user@google.com"
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + userIdentity})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clients, manager, _ := initWithExperiment(t)
+	defer clients.Close()
+	server := NewJobServer(manager, &JobServerOptions{CollectMetrics: false})
+	job, err := server.CreateJob(ctx, &apiv1beta1.CreateJobRequest{Job: commonApiJob})
+	assert.Nil(t, err)
+
+	clients.SubjectAccessReviewClientFake = client.NewFakeSubjectAccessReviewClientUnauthorized()
+	manager = resource.NewResourceManager(clients)
+	server = NewJobServer(manager, &JobServerOptions{CollectMetrics: false})
+
+	_, err = server.RestartJobSchedule(ctx, job.Id)
+	assert.NotNil(t, err)
+	resourceAttributes := &authorizationv1.ResourceAttributes{
+		Namespace: "ns1",
+		Verb:      common.RbacResourceVerbEnable,
+		Group:     common.RbacPipelinesGroup,
+		Version:   common.RbacPipelinesVersion,
+		Resource:  common.RbacResourceTypeJobs,
+		Name:      commonApiJob.Name,
+	}
+	assert.EqualError(
+		t,
+		err,
+		wrapFailedAuthzRequestError(wrapFailedAuthzApiResourcesError(getPermissionDeniedError(userIdentity, resourceAttributes))).Error(),
+	)
+}
+
 func TestDisableJob_Unauthorized(t *testing.T) {
 	viper.Set(common.MultiUserMode, "true")
 	defer viper.Set(common.MultiUserMode, "false")
@@ -832,6 +942,7 @@ func TestCreateRecurringRun(t *testing.T) {
 		Mode:           apiv2beta1.RecurringRun_ENABLE,
 		Namespace:      "ns1",
 		MaxConcurrency: 1,
+		NoCatchup:      true,
 		Trigger: &apiv2beta1.Trigger{
 			Trigger: &apiv2beta1.Trigger_CronSchedule{CronSchedule: &apiv2beta1.CronSchedule{
 				StartTime: &timestamp.Timestamp{Seconds: 1},
@@ -883,6 +994,7 @@ func TestGetRecurringRun(t *testing.T) {
 		Mode:           apiv2beta1.RecurringRun_ENABLE,
 		Namespace:      "ns1",
 		MaxConcurrency: 1,
+		NoCatchup:      true,
 		Trigger: &apiv2beta1.Trigger{
 			Trigger: &apiv2beta1.Trigger_CronSchedule{CronSchedule: &apiv2beta1.CronSchedule{
 				StartTime: &timestamp.Timestamp{Seconds: 1},
@@ -890,6 +1002,7 @@ func TestGetRecurringRun(t *testing.T) {
 			}}},
 		CreatedAt:      &timestamp.Timestamp{Seconds: 2},
 		UpdatedAt:      &timestamp.Timestamp{Seconds: 2},
+		NextRunAt:      &timestamp.Timestamp{Seconds: 61},
 		Status:         apiv2beta1.RecurringRun_STATUS_UNSPECIFIED,
 		PipelineSource: &apiv2beta1.RecurringRun_PipelineSpec{PipelineSpec: pipelineSpecStruct},
 		RuntimeConfig: &apiv2beta1.RuntimeConfig{
@@ -937,6 +1050,7 @@ func TestListRecurringRuns(t *testing.T) {
 		Mode:           apiv2beta1.RecurringRun_ENABLE,
 		Namespace:      "ns1",
 		MaxConcurrency: 1,
+		NoCatchup:      true,
 		Trigger: &apiv2beta1.Trigger{
 			Trigger: &apiv2beta1.Trigger_CronSchedule{CronSchedule: &apiv2beta1.CronSchedule{
 				StartTime: &timestamp.Timestamp{Seconds: 1},
@@ -955,6 +1069,8 @@ func TestListRecurringRuns(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, expectedRecurringRun, createdRecurringRun)
 
+	// Listing computes when the recurring run is expected to create its next run.
+	expectedRecurringRun.NextRunAt = &timestamp.Timestamp{Seconds: 61}
 	expectedRecurringRunsList := []*apiv2beta1.RecurringRun{expectedRecurringRun}
 
 	actualRecurringRunsList, err := server.ListRecurringRuns(nil, &apiv2beta1.ListRecurringRunsRequest{})
@@ -153,6 +153,49 @@ func parseAPIFilter(encoded string) (*api.Filter, error) {
 	return f, nil
 }
 
+// experimentStorageStateAll is the value of a storage_state predicate that selects experiments in
+// any storage state. Without a storage_state predicate, only available experiments are listed.
+const experimentStorageStateAll = "ALL"
+
+// normalizeExperimentStorageStateFilter rewrites the storage_state predicates of an encoded filter
+// to the values stored for experiments. It accepts both the v1 and the v2 names of a storage state,
+// e.g. STORAGESTATE_ARCHIVED and ARCHIVED, and turns storage_state EQUALS ALL into a predicate that
+// matches every storage state.
+func normalizeExperimentStorageStateFilter(filterSpec string) (string, error) {
+	f, err := parseAPIFilter(filterSpec)
+	if err != nil || f == nil {
+		return filterSpec, err
+	}
+	normalize := func(state string) string {
+		return strings.TrimPrefix(state, "STORAGESTATE_")
+	}
+	for _, p := range f.GetPredicates() {
+		if p.GetKey() != "storage_state" {
+			continue
+		}
+		switch v := p.GetValue().(type) {
+		case *api.Predicate_StringValue:
+			v.StringValue = normalize(v.StringValue)
+			if v.StringValue == experimentStorageStateAll {
+				if p.GetOp() != api.Predicate_EQUALS {
+					return "", util.NewInvalidInputError("storage_state %v can only be used with the EQUALS operator", experimentStorageStateAll)
+				}
+				p.Op = api.Predicate_IN
+				p.Value = &api.Predicate_StringValues{StringValues: &api.StringValues{Values: []string{"AVAILABLE", "ARCHIVED"}}}
+			}
+		case *api.Predicate_StringValues:
+			for i, state := range v.StringValues.GetValues() {
+				v.StringValues.Values[i] = normalize(state)
+			}
+		}
+	}
+	normalized, err := (&jsonpb.Marshaler{}).MarshalToString(f)
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Failed to marshal filter %q", filterSpec)
+	}
+	return normalized, nil
+}
+
 func validatedListOptions(listable list.Listable, pageToken string, pageSize int, sortBy string, filterSpec string) (*list.Options, error) {
 	defaultOpts := func() (*list.Options, error) {
 		if listable == nil {
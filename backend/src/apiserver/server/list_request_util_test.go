@@ -296,3 +296,55 @@ func TestValidatedListOptions_Errors(t *testing.T) {
 		t.Fatalf("validatedListOptions(fakeListable, 10, \"name desc\") = _, %+v; Want error", err)
 	}
 }
+
+func TestNormalizeExperimentStorageStateFilter(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    *api.Filter
+		wantErr bool
+	}{
+		{
+			in:   "",
+			want: nil,
+		},
+		{
+			in: `{"predicates": [{"key": "storage_state", "op": "EQUALS", "string_value": "STORAGESTATE_ARCHIVED"}]}`,
+			want: &api.Filter{Predicates: []*api.Predicate{{
+				Key: "storage_state", Op: api.Predicate_EQUALS,
+				Value: &api.Predicate_StringValue{StringValue: "ARCHIVED"},
+			}}},
+		},
+		{
+			in: `{"predicates": [{"key": "storage_state", "op": "EQUALS", "string_value": "ALL"}]}`,
+			want: &api.Filter{Predicates: []*api.Predicate{{
+				Key: "storage_state", Op: api.Predicate_IN,
+				Value: &api.Predicate_StringValues{StringValues: &api.StringValues{Values: []string{"AVAILABLE", "ARCHIVED"}}},
+			}}},
+		},
+		{
+			in: `{"predicates": [{"key": "name", "op": "EQUALS", "string_value": "STORAGESTATE_ARCHIVED"}]}`,
+			want: &api.Filter{Predicates: []*api.Predicate{{
+				Key: "name", Op: api.Predicate_EQUALS,
+				Value: &api.Predicate_StringValue{StringValue: "STORAGESTATE_ARCHIVED"},
+			}}},
+		},
+		{
+			in:      `{"predicates": [{"key": "storage_state", "op": "NOT_EQUALS", "string_value": "ALL"}]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := normalizeExperimentStorageStateFilter(test.in)
+		if test.wantErr {
+			assert.NotNil(t, err)
+			continue
+		}
+		assert.Nil(t, err)
+		filter, err := parseAPIFilter(got)
+		assert.Nil(t, err)
+		if !cmp.Equal(filter, test.want, protocmp.Transform()) {
+			t.Errorf("normalizeExperimentStorageStateFilter(%q) = %v, want %v", test.in, filter, test.want)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes/empty"
+	api "github.com/kubeflow/pipelines/backend/api/v1beta1/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+// readOnlyMethods are the gRPC methods that don't change anything, which are still served in read-only mode.
+// Any method missing from it is rejected, so that a new method is only served once it was listed here.
+var readOnlyMethods = map[string]bool{
+	"/api.AuthService/AuthorizeV1":                                                  true,
+	"/api.AuthService/CheckAccessV1":                                                true,
+	"/api.ExperimentService/GetExperimentV1":                                        true,
+	"/api.ExperimentService/ListExperimentsV1":                                      true,
+	"/api.HealthzService/GetHealthz":                                                true,
+	"/api.JobService/GetJob":                                                        true,
+	"/api.JobService/ListJobs":                                                      true,
+	"/api.MaintenanceService/GetReadOnlyModeV1":                                     true,
+	"/api.MaintenanceService/SetReadOnlyModeV1":                                     true,
+	"/api.PipelineService/GetPipelineByNameV1":                                      true,
+	"/api.PipelineService/GetPipelineV1":                                            true,
+	"/api.PipelineService/GetPipelineVersionTemplate":                               true,
+	"/api.PipelineService/GetPipelineVersionV1":                                     true,
+	"/api.PipelineService/GetTemplate":                                              true,
+	"/api.PipelineService/ListPipelineVersionsV1":                                   true,
+	"/api.PipelineService/ListPipelinesV1":                                          true,
+	"/api.PipelineService/StreamPipelineVersionTemplate":                            true,
+	"/api.RunService/GetRunV1":                                                      true,
+	"/api.RunService/ListRunsV1":                                                    true,
+	"/api.RunService/ReadArtifactV1":                                                true,
+	"/api.TaskService/ListTasksV1":                                                  true,
+	"/kubeflow.pipelines.backend.api.v2beta1.ExperimentService/GetExperiment":       true,
+	"/kubeflow.pipelines.backend.api.v2beta1.ExperimentService/ListExperiments":     true,
+	"/kubeflow.pipelines.backend.api.v2beta1.PipelineService/GetPipeline":           true,
+	"/kubeflow.pipelines.backend.api.v2beta1.PipelineService/GetPipelineByName":     true,
+	"/kubeflow.pipelines.backend.api.v2beta1.PipelineService/GetPipelineVersion":    true,
+	"/kubeflow.pipelines.backend.api.v2beta1.PipelineService/ListPipelineVersions":  true,
+	"/kubeflow.pipelines.backend.api.v2beta1.PipelineService/ListPipelines":         true,
+	"/kubeflow.pipelines.backend.api.v2beta1.RecurringRunService/GetRecurringRun":   true,
+	"/kubeflow.pipelines.backend.api.v2beta1.RecurringRunService/ListRecurringRuns": true,
+	"/kubeflow.pipelines.backend.api.v2beta1.RunService/GetRun":                     true,
+	"/kubeflow.pipelines.backend.api.v2beta1.RunService/ListRuns":                   true,
+	"/kubeflow.pipelines.backend.api.v2beta1.RunService/ReadArtifact":               true,
+}
+
+// CheckReadOnlyMode returns a FailedPrecondition error if the server is in read-only maintenance mode and
+// the gRPC method, e.g. /api.RunService/CreateRunV1, would change anything.
+func CheckReadOnlyMode(fullMethod string) error {
+	if !common.IsReadOnlyMode() || !IsMutatingMethod(fullMethod) {
+		return nil
+	}
+	return newReadOnlyModeError()
+}
+
+// IsMutatingMethod returns whether the gRPC method, e.g. /api.RunService/CreateRunV1, may change anything.
+func IsMutatingMethod(fullMethod string) bool {
+	return !readOnlyMethods[fullMethod]
+}
+
+// RejectInReadOnlyMode wraps an HTTP handler that changes something so it fails while the server is in
+// read-only maintenance mode or shutting down, and so that shutting down waits for it to finish.
+func RejectInReadOnlyMode(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if common.IsReadOnlyMode() {
+			writeMaintenanceError(w, http.StatusPreconditionFailed, newReadOnlyModeError())
+			return
+		}
+		done, err := BeginMutation()
+		if err != nil {
+			writeMaintenanceError(w, http.StatusServiceUnavailable, err)
+			return
+		}
+		defer done()
+		handler(w, r)
+	}
+}
+
+func newReadOnlyModeError() error {
+	return util.NewFailedPreconditionError(errors.New("read-only mode"),
+		"The server is in read-only maintenance mode. Only reads are served until maintenance is over")
+}
+
+// mutations tracks the requests that change something being served, so that shutting down can let them
+// finish, e.g. a CreateRun call that submitted its workflow but hasn't stored its run yet.
+var mutations = &mutationTracker{}
+
+type mutationTracker struct {
+	mu       sync.Mutex
+	inFlight int
+	// idle is closed once the server is draining and the last request in flight finishes.
+	idle chan struct{}
+}
+
+// BeginMutation records that a request that changes something is being served, unless the server is shutting
+// down, in which case it returns an Unavailable error for the client to retry against another replica. The
+// returned function must be called once the request was served.
+func BeginMutation() (func(), error) {
+	mutations.mu.Lock()
+	defer mutations.mu.Unlock()
+	if common.IsDraining() {
+		return nil, util.NewUnavailableError(errors.New("shutting down"),
+			"The server is shutting down. Please retry the request")
+	}
+	mutations.inFlight++
+	return func() {
+		mutations.mu.Lock()
+		defer mutations.mu.Unlock()
+		mutations.inFlight--
+		if mutations.inFlight == 0 && mutations.idle != nil {
+			close(mutations.idle)
+			mutations.idle = nil
+		}
+	}, nil
+}
+
+// Drain stops the server from accepting requests that change anything, and waits up to the timeout for those
+// being served to finish. It returns whether they all finished in time. Reads are still served, and the
+// readiness endpoint reports the server as draining, so that it stops receiving traffic.
+func Drain(timeout time.Duration) bool {
+	mutations.mu.Lock()
+	common.SetDraining(true)
+	if mutations.inFlight == 0 {
+		mutations.mu.Unlock()
+		return true
+	}
+	if mutations.idle == nil {
+		mutations.idle = make(chan struct{})
+	}
+	idle := mutations.idle
+	glog.Infof("Waiting for %v requests to finish before shutting down", mutations.inFlight)
+	mutations.mu.Unlock()
+	select {
+	case <-idle:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+type MaintenanceServer struct {
+	resourceManager *resource.ResourceManager
+}
+
+// GetReadOnlyModeV1 tells whether the server is in read-only maintenance mode.
+func (s *MaintenanceServer) GetReadOnlyModeV1(ctx context.Context, request *empty.Empty) (*api.ReadOnlyMode, error) {
+	return &api.ReadOnlyMode{ReadOnly: common.IsReadOnlyMode()}, nil
+}
+
+// SetReadOnlyModeV1 turns read-only maintenance mode on or off until the server restarts. Only the admin
+// identities may change it, so the request must be authenticated even in single-user mode.
+func (s *MaintenanceServer) SetReadOnlyModeV1(ctx context.Context, request *api.SetReadOnlyModeRequest) (*api.ReadOnlyMode, error) {
+	userIdentity, err := s.resourceManager.AuthenticateRequest(ctx)
+	if err != nil {
+		return nil, util.NewUnauthenticatedError(err, "Failed to authenticate the request to change the maintenance mode")
+	}
+	if !common.IsImpersonationAdmin(userIdentity) {
+		return nil, util.NewPermissionDeniedError(errors.New("not an admin"),
+			"User '%s' is not allowed to change the maintenance mode", userIdentity)
+	}
+	common.SetReadOnlyMode(request.GetEnabled())
+	glog.Infof("Read-only maintenance mode is set to %v by %v", request.GetEnabled(), userIdentity)
+	return &api.ReadOnlyMode{ReadOnly: common.IsReadOnlyMode()}, nil
+}
+
+func writeMaintenanceError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	errorResponse := &api.Error{ErrorMessage: err.Error(), ErrorDetails: fmt.Sprintf("%+v", err)}
+	if err := json.NewEncoder(w).Encode(errorResponse); err != nil {
+		glog.Errorf("Failed to write the maintenance error response. Error: %+v", err)
+	}
+}
+
+func NewMaintenanceServer(resourceManager *resource.ResourceManager) *MaintenanceServer {
+	return &MaintenanceServer{resourceManager: resourceManager}
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	api "github.com/kubeflow/pipelines/backend/api/v1beta1/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestCheckReadOnlyMode(t *testing.T) {
+	defer common.SetReadOnlyMode(false)
+
+	assert.Nil(t, CheckReadOnlyMode("/api.RunService/CreateRunV1"))
+
+	common.SetReadOnlyMode(true)
+	for _, method := range []string{
+		"/api.RunService/CreateRunV1",
+		"/kubeflow.pipelines.backend.api.v2beta1.ExperimentService/DeleteExperiment",
+		"/api.PipelineService/UpdatePipelineDefaultVersionV1",
+		"/api.ReportService/ReportWorkflowV1",
+		"/api.VisualizationService/CreateVisualizationV1",
+		// Methods missing from the table are rejected, whatever their names.
+		"/api.RunService/GetAndDeleteRunV1",
+	} {
+		err := CheckReadOnlyMode(method)
+		assert.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition), method)
+		assert.Contains(t, err.Error(), "read-only maintenance mode")
+	}
+	for _, method := range []string{
+		"/api.RunService/GetRunV1",
+		"/kubeflow.pipelines.backend.api.v2beta1.RunService/ListRuns",
+		"/api.RunService/ReadArtifactV1",
+		"/api.AuthService/AuthorizeV1",
+		"/api.MaintenanceService/SetReadOnlyModeV1",
+		"/api.PipelineService/StreamPipelineVersionTemplate",
+	} {
+		assert.Nil(t, CheckReadOnlyMode(method), method)
+	}
+}
+
+func TestRejectInReadOnlyMode(t *testing.T) {
+	defer common.SetReadOnlyMode(false)
+	handler := RejectInReadOnlyMode(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rr := httptest.NewRecorder()
+	handler(rr, httptest.NewRequest("POST", "/apis/v1beta1/pipelines/upload", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	common.SetReadOnlyMode(true)
+	rr = httptest.NewRecorder()
+	handler(rr, httptest.NewRequest("POST", "/apis/v1beta1/pipelines/upload", nil))
+	assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+	assert.Contains(t, rr.Body.String(), "read-only maintenance mode")
+}
+
+func TestDrain(t *testing.T) {
+	defer common.SetDraining(false)
+	done, err := BeginMutation()
+	require.Nil(t, err)
+	handler := RejectInReadOnlyMode(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// The mutation in flight keeps the drain from finishing, but new ones are rejected.
+	assert.False(t, Drain(10*time.Millisecond))
+	assert.True(t, common.IsDraining())
+	_, err = BeginMutation()
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.Unavailable))
+	rr := httptest.NewRecorder()
+	handler(rr, httptest.NewRequest("POST", "/apis/v1beta1/pipelines/upload", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Contains(t, rr.Body.String(), "shutting down")
+
+	drained := make(chan bool)
+	go func() { drained <- Drain(time.Minute) }()
+	done()
+	assert.True(t, <-drained)
+	assert.True(t, Drain(0))
+}
+
+func TestMaintenanceServer_ReadOnlyModeV1(t *testing.T) {
+	defer common.SetReadOnlyMode(false)
+	viper.Set(common.ImpersonationAdminIdentities, "admin@google.com")
+	defer viper.Set(common.ImpersonationAdminIdentities, "")
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer clientManager.Close()
+	server := NewMaintenanceServer(resource.NewResourceManager(clientManager))
+
+	// Changing the mode needs an admin identity even in single-user mode.
+	_, err := server.SetReadOnlyModeV1(context.Background(), &api.SetReadOnlyModeRequest{Enabled: true})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.Unauthenticated))
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "user@google.com"})
+	_, err = server.SetReadOnlyModeV1(metadata.NewIncomingContext(context.Background(), md), &api.SetReadOnlyModeRequest{Enabled: true})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.PermissionDenied))
+	assert.False(t, common.IsReadOnlyMode())
+
+	md = metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "admin@google.com"})
+	mode, err := server.SetReadOnlyModeV1(metadata.NewIncomingContext(context.Background(), md), &api.SetReadOnlyModeRequest{Enabled: true})
+	require.Nil(t, err)
+	assert.True(t, mode.GetReadOnly())
+	assert.True(t, common.IsReadOnlyMode())
+	mode, err = server.GetReadOnlyModeV1(context.Background(), &empty.Empty{})
+	require.Nil(t, err)
+	assert.True(t, mode.GetReadOnly())
+
+	mode, err = server.SetReadOnlyModeV1(metadata.NewIncomingContext(context.Background(), md), &api.SetReadOnlyModeRequest{Enabled: false})
+	require.Nil(t, err)
+	assert.False(t, mode.GetReadOnly())
+	assert.False(t, common.IsReadOnlyMode())
+}
+
+func TestMaintenanceServer_SetReadOnlyModeV1_Multiuser(t *testing.T) {
+	defer common.SetReadOnlyMode(false)
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+	viper.Set(common.ImpersonationAdminIdentities, "admin@google.com")
+	defer viper.Set(common.ImpersonationAdminIdentities, "")
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer clientManager.Close()
+	server := NewMaintenanceServer(resource.NewResourceManager(clientManager))
+
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "user@google.com"})
+	_, err := server.SetReadOnlyModeV1(metadata.NewIncomingContext(context.Background(), md), &api.SetReadOnlyModeRequest{Enabled: true})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.PermissionDenied))
+	assert.False(t, common.IsReadOnlyMode())
+
+	md = metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "admin@google.com"})
+	_, err = server.SetReadOnlyModeV1(metadata.NewIncomingContext(context.Background(), md), &api.SetReadOnlyModeRequest{Enabled: true})
+	assert.Nil(t, err)
+	assert.True(t, common.IsReadOnlyMode())
+}
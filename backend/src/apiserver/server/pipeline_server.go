@@ -16,6 +16,7 @@ package server
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
@@ -25,6 +26,7 @@ import (
 	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/template"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	authorizationv1 "k8s.io/api/authorization/v1"
 
@@ -32,6 +34,9 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
+// templateChunkSize bounds how much of a template StreamPipelineVersionTemplate reads and sends at a time.
+const templateChunkSize = 64 * 1024
+
 // Metric variables. Please prefix the metric names with pipeline_server_.
 var (
 	// Used to calculate the request rate.
@@ -136,7 +141,7 @@ func (s *PipelineServer) CreatePipelineV1(ctx context.Context, request *api.Crea
 		return nil, util.Wrap(err, "Failed to authorize with API")
 	}
 
-	pipeline, err := s.resourceManager.CreatePipeline(pipelineName, request.Pipeline.Description, namespace, pipelineFile)
+	pipeline, err := s.resourceManager.CreatePipeline(pipelineName, request.Pipeline.Description, namespace, request.Pipeline.GetLabels(), pipelineFile)
 	if err != nil {
 		return nil, util.Wrap(err, "Create pipeline failed.")
 	}
@@ -158,7 +163,7 @@ func (s *PipelineServer) UpdatePipelineDefaultVersionV1(ctx context.Context, req
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to authorize the requests.")
 	}
-	err = s.resourceManager.UpdatePipelineDefaultVersion(request.PipelineId, request.VersionId)
+	err = s.resourceManager.UpdatePipelineDefaultVersion(request.PipelineId, request.VersionId, 0)
 	if err != nil {
 		return nil, util.Wrap(err, "Update Pipeline Default Version failed.")
 	}
@@ -333,7 +338,7 @@ func (s *PipelineServer) CreatePipelineVersionV1(ctx context.Context, request *a
 		return nil, util.NewInternalServerError(err, "Failed to download the pipeline from %v. Please double check the URL is valid and can be accessed by the pipeline system.", pipelineUrl)
 	}
 	pipelineFileName := path.Base(pipelineUrl)
-	pipelineFile, err := ReadPipelineFile(pipelineFileName, resp.Body, MaxFileLength)
+	pipelineFile, err := ReadPipelineVersionFile(pipelineFileName, resp.Body, MaxFileLength)
 	if err != nil {
 		return nil, util.Wrap(err, "The URL is valid but pipeline system failed to read the file.")
 	}
@@ -430,7 +435,7 @@ func (s *PipelineServer) ListPipelineVersionsV1(ctx context.Context, request *ap
 		TotalSize:     int32(totalSize)}, nil
 }
 
-func (s *PipelineServer) DeletePipelineVersionV1(ctx context.Context, request *api.DeletePipelineVersionRequest) (*empty.Empty, error) {
+func (s *PipelineServer) DeletePipelineVersionV1(ctx context.Context, request *api.DeletePipelineVersionRequest) (*api.DeletePipelineVersionResponse, error) {
 	if s.options.CollectMetrics {
 		deletePipelineVersionRequests.Inc()
 	}
@@ -445,12 +450,16 @@ func (s *PipelineServer) DeletePipelineVersionV1(ctx context.Context, request *a
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to authorize with API")
 	}
-	err = s.resourceManager.DeletePipelineVersion(request.VersionId)
+	deletion, err := s.resourceManager.DeletePipelineVersionWithOptions(ctx, request.VersionId,
+		resource.DeletePipelineVersionOptions{Cascade: request.GetCascade()})
 	if err != nil {
 		return nil, util.Wrap(err, "Delete pipeline versions failed.")
 	}
 
-	return &empty.Empty{}, nil
+	return &api.DeletePipelineVersionResponse{
+		AffectedRuns: int32(deletion.AffectedRuns),
+		AffectedJobs: int32(deletion.AffectedJobs),
+	}, nil
 }
 
 func (s *PipelineServer) GetPipelineVersionTemplate(ctx context.Context, request *api.GetPipelineVersionTemplateRequest) (*api.GetTemplateResponse, error) {
@@ -461,12 +470,75 @@ func (s *PipelineServer) GetPipelineVersionTemplate(ctx context.Context, request
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to authorize the requests.")
 	}
-	template, err := s.resourceManager.GetPipelineVersionTemplate(request.VersionId)
+	versionTemplate, err := s.resourceManager.GetPipelineVersionTemplate(request.VersionId, toTemplateFormat(request.GetFormat()))
 	if err != nil {
 		return nil, util.Wrap(err, "Get pipeline template failed.")
 	}
 
-	return &api.GetTemplateResponse{Template: string(template)}, nil
+	return &api.GetTemplateResponse{Template: string(versionTemplate)}, nil
+}
+
+// StreamPipelineVersionTemplate sends the template of a pipeline version in chunks, converted like
+// GetPipelineVersionTemplate converts it. The stream ends with an error status if the template can't be read
+// to its end, so that clients never take a truncated template for a complete one.
+func (s *PipelineServer) StreamPipelineVersionTemplate(request *api.GetPipelineVersionTemplateRequest, stream api.PipelineService_StreamPipelineVersionTemplateServer) error {
+	ctx := stream.Context()
+	resourceAttributes := &authorizationv1.ResourceAttributes{
+		Verb: common.RbacResourceVerbGet,
+	}
+	err := s.CanAccessPipelineVersion(ctx, request.VersionId, resourceAttributes)
+	if err != nil {
+		return util.Wrap(err, "Failed to authorize the requests.")
+	}
+	reader, err := s.resourceManager.GetPipelineVersionTemplateReader(request.VersionId, toTemplateFormat(request.GetFormat()))
+	if err != nil {
+		return util.Wrap(err, "Stream pipeline template failed.")
+	}
+	defer reader.Close()
+
+	chunk := make([]byte, templateChunkSize)
+	for {
+		n, err := io.ReadFull(reader, chunk)
+		if n > 0 {
+			if err := stream.Send(&api.TemplateChunk{Data: chunk[:n]}); err != nil {
+				return util.Wrapf(err, "Failed to stream the template of pipeline version %v", request.VersionId)
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to read the template of pipeline version %v", request.VersionId)
+		}
+	}
+}
+
+// ComparePipelineVersions returns what changed in the manifest of pipeline version versionB compared to the one of
+// pipeline version versionA. The caller must be able to read both versions.
+func (s *PipelineServer) ComparePipelineVersions(ctx context.Context, versionA string, versionB string) (*template.ManifestDiff, error) {
+	for _, versionId := range []string{versionA, versionB} {
+		resourceAttributes := &authorizationv1.ResourceAttributes{
+			Verb: common.RbacResourceVerbList,
+		}
+		err := s.CanAccessPipelineVersion(ctx, versionId, resourceAttributes)
+		if err != nil {
+			return nil, util.Wrap(err, "Failed to authorize the requests.")
+		}
+	}
+	return s.resourceManager.ComparePipelineVersions(ctx, versionA, versionB)
+}
+
+// GetPipelineVersionDependencies returns the container images and the Kubernetes objects the manifest of a
+// pipeline version pulls in. The caller must be able to read the version.
+func (s *PipelineServer) GetPipelineVersionDependencies(ctx context.Context, versionId string) (*template.Dependencies, error) {
+	resourceAttributes := &authorizationv1.ResourceAttributes{
+		Verb: common.RbacResourceVerbList,
+	}
+	err := s.CanAccessPipelineVersion(ctx, versionId, resourceAttributes)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to authorize the requests.")
+	}
+	return s.resourceManager.GetPipelineVersionDependencies(ctx, versionId)
 }
 
 func (s *PipelineServer) CanAccessPipelineVersion(ctx context.Context, versionId string, resourceAttributes *authorizationv1.ResourceAttributes) error {
@@ -1,12 +1,14 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	api "github.com/kubeflow/pipelines/backend/api/v1beta1/go_client"
@@ -15,7 +17,10 @@ import (
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 )
 
 func TestCreatePipelineV1_YAML(t *testing.T) {
@@ -76,6 +81,45 @@ func TestCreatePipelineV1_Tarball(t *testing.T) {
 	assert.Equal(t, "pipeline description", newPipeline.Description)
 }
 
+func TestCreatePipelineV1_Labels(t *testing.T) {
+	httpServer := getMockServer(t)
+	// Close the server when test finishes
+	defer httpServer.Close()
+
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	resourceManager := resource.NewResourceManager(clientManager)
+
+	pipelineServer := PipelineServer{resourceManager: resourceManager, httpClient: httpServer.Client(), options: &PipelineServerOptions{CollectMetrics: false}}
+	pipeline, err := pipelineServer.CreatePipelineV1(context.Background(), &api.CreatePipelineRequest{
+		Pipeline: &api.Pipeline{
+			Url:    &api.Url{PipelineUrl: httpServer.URL + "/arguments-parameters.yaml"},
+			Name:   "argument-parameters",
+			Labels: map[string]string{"team": "ml", "env": "prod"},
+		}})
+	require.Nil(t, err)
+	expectedLabels := map[string]string{"team": "ml", "env": "prod"}
+	assert.Equal(t, expectedLabels, pipeline.Labels)
+
+	pipeline, err = pipelineServer.GetPipelineV1(context.Background(), &api.GetPipelineRequest{Id: pipeline.Id})
+	require.Nil(t, err)
+	assert.Equal(t, expectedLabels, pipeline.Labels)
+
+	response, err := pipelineServer.ListPipelinesV1(context.Background(), &api.ListPipelinesRequest{
+		Filter: `{"predicates": [{"key": "labels.team", "op": "EQUALS", "string_value": "ml"}]}`,
+	})
+	require.Nil(t, err)
+	require.Len(t, response.Pipelines, 1)
+	assert.Equal(t, expectedLabels, response.Pipelines[0].Labels)
+
+	_, err = pipelineServer.CreatePipelineV1(context.Background(), &api.CreatePipelineRequest{
+		Pipeline: &api.Pipeline{
+			Url:    &api.Url{PipelineUrl: httpServer.URL + "/arguments-parameters.yaml"},
+			Name:   "invalid-labels",
+			Labels: map[string]string{"team ml": "ml"},
+		}})
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+}
+
 func TestCreatePipelineV1_InvalidYAML(t *testing.T) {
 	httpServer := getMockServer(t)
 	// Close the server when test finishes
@@ -152,6 +196,89 @@ func TestCreatePipelineVersionV1_YAML(t *testing.T) {
 		{Name: "param1", Value: "hello"}, {Name: "param2"}}, params)
 }
 
+func TestGetPipelineV1_VersionCount(t *testing.T) {
+	httpServer := getMockServer(t)
+	// Close the server when test finishes
+	defer httpServer.Close()
+
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	resourceManager := resource.NewResourceManager(clientManager)
+
+	pipelineServer := PipelineServer{resourceManager: resourceManager, httpClient: httpServer.Client(), options: &PipelineServerOptions{CollectMetrics: false}}
+	pipeline, err := pipelineServer.CreatePipelineV1(context.Background(), &api.CreatePipelineRequest{
+		Pipeline: &api.Pipeline{
+			Url:  &api.Url{PipelineUrl: httpServer.URL + "/arguments-parameters.yaml"},
+			Name: "argument-parameters",
+		}})
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), pipeline.VersionCount)
+
+	clientManager.UpdateUUID(util.NewFakeUUIDGeneratorOrFatal(resource.FakeUUIDOne, nil))
+	pipelineServer.resourceManager = resource.NewResourceManager(clientManager)
+	_, err = pipelineServer.CreatePipelineVersionV1(context.Background(), &api.CreatePipelineVersionRequest{
+		Version: &api.PipelineVersion{
+			PackageUrl: &api.Url{PipelineUrl: httpServer.URL + "/arguments-parameters.yaml"},
+			Name:       "argument-parameters-v2",
+			ResourceReferences: []*api.ResourceReference{
+				{
+					Key:          &api.ResourceKey{Id: pipeline.Id, Type: api.ResourceType_PIPELINE},
+					Relationship: api.Relationship_OWNER,
+				},
+			},
+		}})
+	require.Nil(t, err)
+
+	pipeline, err = pipelineServer.GetPipelineV1(context.Background(), &api.GetPipelineRequest{Id: pipeline.Id})
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), pipeline.VersionCount)
+
+	response, err := pipelineServer.ListPipelinesV1(context.Background(), &api.ListPipelinesRequest{})
+	require.Nil(t, err)
+	require.Len(t, response.Pipelines, 1)
+	assert.Equal(t, int64(2), response.Pipelines[0].VersionCount)
+}
+
+func TestDeletePipelineVersionV1_Cascade(t *testing.T) {
+	clientManager, resourceManager, experiment := initWithExperiment(t)
+	defer clientManager.Close()
+	clientManager.UpdateUUID(util.NewFakeUUIDGeneratorOrFatal(resource.FakeUUIDOne, nil))
+	resourceManager = resource.NewResourceManager(clientManager)
+	pipeline, err := resourceManager.CreatePipeline("pipeline", "", "", nil, []byte(testWorkflow.ToStringForStore()))
+	require.Nil(t, err)
+	clientManager.UpdateUUID(util.NewFakeUUIDGeneratorOrFatal(resource.NonDefaultFakeUUID, nil))
+	resourceManager = resource.NewResourceManager(clientManager)
+	runServer := NewRunServer(resourceManager, &RunServerOptions{CollectMetrics: false})
+	_, err = runServer.CreateRunV1(context.Background(), &api.CreateRunRequest{Run: &api.Run{
+		Name: "run1",
+		ResourceReferences: []*api.ResourceReference{
+			{
+				Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: experiment.UUID},
+				Relationship: api.Relationship_OWNER,
+			},
+			{
+				Key:          &api.ResourceKey{Type: api.ResourceType_PIPELINE_VERSION, Id: pipeline.DefaultVersionId},
+				Relationship: api.Relationship_CREATOR,
+			},
+		},
+		PipelineSpec: &api.PipelineSpec{Parameters: []*api.Parameter{{Name: "param1", Value: "world"}}},
+	}})
+	require.Nil(t, err)
+
+	pipelineServer := PipelineServer{resourceManager: resourceManager, options: &PipelineServerOptions{CollectMetrics: false}}
+	_, err = pipelineServer.DeletePipelineVersionV1(context.Background(), &api.DeletePipelineVersionRequest{VersionId: pipeline.DefaultVersionId})
+	assert.Equal(t, codes.FailedPrecondition, err.(*util.UserError).ExternalStatusCode())
+
+	response, err := pipelineServer.DeletePipelineVersionV1(context.Background(), &api.DeletePipelineVersionRequest{
+		VersionId: pipeline.DefaultVersionId,
+		Cascade:   true,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, int32(1), response.AffectedRuns)
+	assert.Equal(t, int32(0), response.AffectedJobs)
+	_, err = resourceManager.GetPipelineVersion(pipeline.DefaultVersionId)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
 func TestCreatePipelineVersion_InvalidYAML(t *testing.T) {
 	httpServer := getMockServer(t)
 	// Close the server when test finishes
@@ -447,3 +574,114 @@ func getBadMockServer() *httptest.Server {
 	}))
 	return httpServer
 }
+
+func TestComparePipelineVersions_Unauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "user@google.com"})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clients, manager, version := initWithExperimentAndPipelineVersionInOtherNamespace(t)
+	defer clients.Close()
+	pipelineServer := PipelineServer{resourceManager: manager, httpClient: http.DefaultClient, options: &PipelineServerOptions{CollectMetrics: false}}
+
+	// The version is owned by a namespace the user can't read.
+	_, err := pipelineServer.ComparePipelineVersions(ctx, version.UUID, version.UUID)
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestGetPipelineVersionDependencies_Unauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "user@google.com"})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clients, manager, version := initWithExperimentAndPipelineVersionInOtherNamespace(t)
+	defer clients.Close()
+	pipelineServer := PipelineServer{resourceManager: manager, httpClient: http.DefaultClient, options: &PipelineServerOptions{CollectMetrics: false}}
+
+	_, err := pipelineServer.GetPipelineVersionDependencies(ctx, version.UUID)
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+}
+
+// fakeTemplateStream collects the chunks StreamPipelineVersionTemplate sends.
+type fakeTemplateStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks [][]byte
+}
+
+func (s *fakeTemplateStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeTemplateStream) Send(chunk *api.TemplateChunk) error {
+	s.chunks = append(s.chunks, append([]byte(nil), chunk.GetData()...))
+	return nil
+}
+
+func TestStreamPipelineVersionTemplate(t *testing.T) {
+	clientManager, uploadServer := setupClientManagerAndServer()
+	// The manifest spans several chunks.
+	spec := "apiVersion: argoproj.io/v1alpha1\nkind: Workflow\nmetadata:\n  annotations:\n    notes: " +
+		strings.Repeat("a", 3*templateChunkSize) + "\n"
+	bytesBuffer, writer := setupWriter("")
+	setWriterWithBuffer("uploadfile", "hello-world.yaml", spec, writer)
+	response := uploadPipeline("/apis/v1beta1/pipelines/upload",
+		bytes.NewReader(bytesBuffer.Bytes()), writer, uploadServer.UploadPipeline)
+	assert.Equal(t, 200, response.Code, response.Body.String())
+	pipelineServer := PipelineServer{resourceManager: uploadServer.resourceManager, httpClient: http.DefaultClient, options: &PipelineServerOptions{CollectMetrics: false}}
+	streamTemplate := func(versionId string, format api.GetPipelineVersionTemplateRequest_TemplateFormat) (*fakeTemplateStream, error) {
+		stream := &fakeTemplateStream{ctx: context.Background()}
+		err := pipelineServer.StreamPipelineVersionTemplate(&api.GetPipelineVersionTemplateRequest{VersionId: versionId, Format: format}, stream)
+		return stream, err
+	}
+
+	stream, err := streamTemplate(resource.DefaultFakeUUID, api.GetPipelineVersionTemplateRequest_RAW)
+	require.Nil(t, err)
+	assert.Equal(t, 4, len(stream.chunks))
+	objStore := clientManager.ObjectStore()
+	stored, err := objStore.GetFile(objStore.GetPipelineKey(resource.DefaultFakeUUID))
+	require.Nil(t, err)
+	assert.Equal(t, stored, bytes.Join(stream.chunks, nil))
+
+	// The template is converted like GetPipelineVersionTemplate converts it.
+	stream, err = streamTemplate(resource.DefaultFakeUUID, api.GetPipelineVersionTemplateRequest_JSON)
+	require.Nil(t, err)
+	var converted map[string]interface{}
+	assert.Nil(t, json.Unmarshal(bytes.Join(stream.chunks, nil), &converted))
+	assert.Equal(t, "Workflow", converted["kind"])
+	templateResponse, err := pipelineServer.GetPipelineVersionTemplate(context.Background(), &api.GetPipelineVersionTemplateRequest{
+		VersionId: resource.DefaultFakeUUID,
+		Format:    api.GetPipelineVersionTemplateRequest_JSON,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, string(bytes.Join(stream.chunks, nil)), templateResponse.Template)
+	_, err = streamTemplate(resource.DefaultFakeUUID, api.GetPipelineVersionTemplateRequest_TemplateFormat(7))
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+
+	_, err = streamTemplate("does-not-exist", api.GetPipelineVersionTemplateRequest_RAW)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
+func TestStreamPipelineVersionTemplate_Unauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "user@google.com"})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clients, manager, version := initWithExperimentAndPipelineVersionInOtherNamespace(t)
+	defer clients.Close()
+	pipelineServer := PipelineServer{resourceManager: manager, httpClient: http.DefaultClient, options: &PipelineServerOptions{CollectMetrics: false}}
+
+	stream := &fakeTemplateStream{ctx: ctx}
+	err := pipelineServer.StreamPipelineVersionTemplate(&api.GetPipelineVersionTemplateRequest{VersionId: version.UUID}, stream)
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+	assert.Empty(t, stream.chunks)
+}
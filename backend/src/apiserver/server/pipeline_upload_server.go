@@ -21,12 +21,14 @@ import (
 	"net/http"
 	"net/url"
 
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"github.com/golang/glog"
 	"github.com/golang/protobuf/jsonpb"
 	api "github.com/kubeflow/pipelines/backend/api/v1beta1/go_client"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/template"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -40,6 +42,8 @@ const (
 	NameQueryStringKey        = "name"
 	DescriptionQueryStringKey = "description"
 	NamespaceStringQuery      = "namespace"
+	// Labels in the query string are given as key=value pairs, e.g. ?labels=team=ml&labels=env=prod.
+	LabelsQueryStringKey = "labels"
 	// Pipeline Id in the query string specifies a pipeline when creating versions.
 	PipelineKey = "pipelineid"
 )
@@ -121,7 +125,12 @@ func (s *PipelineUploadServer) UploadPipeline(w http.ResponseWriter, r *http.Req
 		s.writeErrorToResponse(w, http.StatusBadRequest, util.Wrap(err, "Error read pipeline description."))
 		return
 	}
-	newPipeline, err := s.resourceManager.CreatePipeline(pipelineName, pipelineDescription, pipelineNamespace, pipelineFile)
+	pipelineLabels, err := common.ParseLabels(r.URL.Query()[LabelsQueryStringKey])
+	if err != nil {
+		s.writeErrorToResponse(w, http.StatusBadRequest, util.Wrap(err, "Invalid pipeline labels."))
+		return
+	}
+	newPipeline, err := s.resourceManager.CreatePipeline(pipelineName, pipelineDescription, pipelineNamespace, pipelineLabels, pipelineFile)
 	if err != nil {
 		s.writeErrorToResponse(w, http.StatusInternalServerError, util.Wrap(err, "Error creating pipeline"))
 		return
@@ -155,7 +164,7 @@ func (s *PipelineUploadServer) UploadPipelineVersion(w http.ResponseWriter, r *h
 	}
 	defer file.Close()
 
-	pipelineFile, err := ReadPipelineFile(header.Filename, file, MaxFileLength)
+	pipelineFile, err := ReadPipelineVersionFile(header.Filename, file, MaxFileLength)
 	if err != nil {
 		s.writeErrorToResponse(w, http.StatusBadRequest, util.Wrap(err, "Error read pipeline version file."))
 		return
@@ -230,6 +239,153 @@ func (s *PipelineUploadServer) UploadPipelineVersion(w http.ResponseWriter, r *h
 	}
 }
 
+// uploadPipelineVersionsResponse lists the ids of the versions created by UploadPipelineVersions.
+type uploadPipelineVersionsResponse struct {
+	PipelineVersionIds []string `json:"pipeline_version_ids"`
+}
+
+// HTTP multipart endpoint for uploading several versions of a pipeline at once. Each version is a file
+// under uploadfile, named by the name values of the query string in the order of the files, or by its file
+// name. Either all the versions are created or none is, and the response lists the ids of the created
+// versions in the order of the files.
+func (s *PipelineUploadServer) UploadPipelineVersions(w http.ResponseWriter, r *http.Request) {
+	if s.options.CollectMetrics {
+		uploadPipelineVersionRequests.Inc()
+	}
+
+	glog.Infof("Upload pipeline versions called")
+	// Same memory limit as r.FormFile.
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		s.writeErrorToResponse(w, http.StatusBadRequest, util.Wrap(err, "Failed to read pipeline versions from files"))
+		return
+	}
+	headers := r.MultipartForm.File[FormFileKey]
+	if len(headers) == 0 {
+		s.writeErrorToResponse(w, http.StatusBadRequest, errors.New("Please upload at least one pipeline version file."))
+		return
+	}
+
+	pipelineId := r.URL.Query().Get(PipelineKey)
+	if len(pipelineId) == 0 {
+		s.writeErrorToResponse(w, http.StatusBadRequest, errors.New("Please specify a pipeline id when creating versions."))
+		return
+	}
+	namespace, err := s.resourceManager.GetNamespaceFromPipelineID(pipelineId)
+	if err != nil {
+		s.writeErrorToResponse(w, http.StatusBadRequest, util.Wrap(err, "Failed to get namespace from pipelineId."))
+		return
+	}
+	resourceAttributes := &authorizationv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      common.RbacResourceVerbCreate,
+	}
+	err = s.canUploadVersionedPipeline(r, pipelineId, resourceAttributes)
+	if err != nil {
+		s.writeErrorToResponse(w, http.StatusBadRequest, util.Wrap(err, "Authorization to namespace failed."))
+		return
+	}
+
+	versionNames := r.URL.Query()[NameQueryStringKey]
+	versionDescription := r.URL.Query().Get(DescriptionQueryStringKey)
+	uploads := make([]*resource.PipelineVersionUpload, 0, len(headers))
+	for i, header := range headers {
+		file, err := header.Open()
+		if err != nil {
+			s.writeErrorToResponse(w, http.StatusBadRequest, util.Wrap(err, "Failed to read pipeline version from file"))
+			return
+		}
+		pipelineFile, err := ReadPipelineVersionFile(header.Filename, file, MaxFileLength)
+		file.Close()
+		if err != nil {
+			s.writeErrorToResponse(w, http.StatusBadRequest, util.Wrap(err, "Error read pipeline version file."))
+			return
+		}
+		versionNameQueryString := ""
+		if i < len(versionNames) {
+			versionNameQueryString = versionNames[i]
+		}
+		pipelineVersionName, err := GetPipelineName(versionNameQueryString, header.Filename)
+		if err != nil {
+			s.writeErrorToResponse(w, http.StatusBadRequest, util.Wrap(err, "Invalid pipeline version name."))
+			return
+		}
+		uploads = append(uploads, &resource.PipelineVersionUpload{
+			Name:         pipelineVersionName,
+			Description:  versionDescription,
+			PipelineFile: pipelineFile,
+		})
+	}
+
+	versions, err := s.resourceManager.CreatePipelineVersions(pipelineId, uploads, common.IsPipelineVersionUpdatedByDefault())
+	if err != nil {
+		code := http.StatusInternalServerError
+		if util.IsUserErrorCodeMatch(err, codes.InvalidArgument) {
+			code = http.StatusBadRequest
+		}
+		s.writeErrorToResponse(w, code, util.Wrap(err, "Error creating pipeline versions"))
+		return
+	}
+
+	response := &uploadPipelineVersionsResponse{PipelineVersionIds: make([]string, 0, len(versions))}
+	for _, version := range versions {
+		response.PipelineVersionIds = append(response.PipelineVersionIds, version.UUID)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		s.writeErrorToResponse(w, http.StatusInternalServerError, util.Wrap(err, "Error creating pipeline versions"))
+		return
+	}
+
+	if s.options.CollectMetrics {
+		pipelineCount.Add(float64(len(versions)))
+	}
+}
+
+// validatePipelineResponse lists the problems ValidatePipeline found in a pipeline file.
+type validatePipelineResponse struct {
+	Valid       bool                   `json:"valid"`
+	Diagnostics []*template.Diagnostic `json:"diagnostics"`
+}
+
+// HTTP multipart endpoint for validating a pipeline file without uploading it, e.g. to lint pipelines in CI.
+// The file is read like an uploaded one and nothing is stored. The response is valid if the file would be
+// accepted for upload, along with every problem found.
+func (s *PipelineUploadServer) ValidatePipeline(w http.ResponseWriter, r *http.Request) {
+	glog.Infof("Validate pipeline called")
+	file, header, err := r.FormFile(FormFileKey)
+	if err != nil {
+		s.writeErrorToResponse(w, http.StatusBadRequest, util.Wrap(err, "Failed to read pipeline from file"))
+		return
+	}
+	defer file.Close()
+
+	pipelineFile, err := ReadPipelineFile(header.Filename, file, MaxFileLength)
+	if err != nil {
+		s.writeErrorToResponse(w, http.StatusBadRequest, util.Wrap(err, "Error read pipeline file."))
+		return
+	}
+
+	md := metadata.MD{}
+	for key, values := range r.Header {
+		md.Set(key, values...)
+	}
+	diagnostics, err := s.resourceManager.ValidatePipelineSpec(metadata.NewIncomingContext(r.Context(), md), pipelineFile)
+	if err != nil {
+		s.writeErrorToResponse(w, http.StatusUnauthorized, util.Wrap(err, "Error validating pipeline"))
+		return
+	}
+
+	response := &validatePipelineResponse{Valid: !template.HasErrors(diagnostics), Diagnostics: diagnostics}
+	if response.Diagnostics == nil {
+		response.Diagnostics = []*template.Diagnostic{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		s.writeErrorToResponse(w, http.StatusInternalServerError, util.Wrap(err, "Error validating pipeline"))
+		return
+	}
+}
+
 func (s *PipelineUploadServer) canUploadVersionedPipeline(r *http.Request, pipelineId string, resourceAttributes *authorizationv1.ResourceAttributes) error {
 	if !common.IsMultiUserMode() {
 		// Skip authorization if not multi-user mode.
@@ -45,14 +45,16 @@ const (
 func TestUploadPipeline(t *testing.T) {
 	// TODO(v2): when we add a field to distinguish between v1 and v2 template, verify it's in the response
 	tt := []struct {
-		name string
-		spec []byte
+		name            string
+		spec            []byte
+		parameterSchema string
 	}{{
 		name: "upload argo workflow YAML",
 		spec: []byte("apiVersion: argoproj.io/v1alpha1\nkind: Workflow"),
 	}, {
-		name: "upload pipeline v2 job in proto yaml",
-		spec: []byte(v2SpecHelloWorld),
+		name:            "upload pipeline v2 job in proto yaml",
+		spec:            []byte(v2SpecHelloWorld),
+		parameterSchema: "{\"text\":\"string\"}",
 	}}
 	for _, test := range tt {
 		t.Run(test.name, func(t *testing.T) {
@@ -94,13 +96,16 @@ func TestUploadPipeline(t *testing.T) {
 					Parameters:       "[]",
 					Status:           model.PipelineReady,
 					DefaultVersionId: resource.DefaultFakeUUID,
+					ResourceVersion:  1,
+					VersionCount:     1,
 					DefaultVersion: &model.PipelineVersion{
-						UUID:           resource.DefaultFakeUUID,
-						CreatedAtInSec: 1,
-						Name:           "hello-world.yaml",
-						Parameters:     "[]",
-						Status:         model.PipelineVersionReady,
-						PipelineId:     resource.DefaultFakeUUID,
+						UUID:            resource.DefaultFakeUUID,
+						CreatedAtInSec:  1,
+						Name:            "hello-world.yaml",
+						Parameters:      "[]",
+						ParameterSchema: test.parameterSchema,
+						Status:          model.PipelineVersionReady,
+						PipelineId:      resource.DefaultFakeUUID,
 					}}}
 			pkg, totalSize, str, err := clientManager.PipelineStore().ListPipelines(&common.FilterContext{}, opts)
 			assert.Nil(t, err)
@@ -128,21 +133,23 @@ func TestUploadPipeline(t *testing.T) {
 			// Verify metadata in db
 			versionsExpect := []*model.PipelineVersion{
 				{
-					UUID:           resource.DefaultFakeUUID,
-					CreatedAtInSec: 1,
-					Name:           "hello-world.yaml",
-					Parameters:     "[]",
-					Status:         model.PipelineVersionReady,
-					PipelineId:     resource.DefaultFakeUUID,
+					UUID:            resource.DefaultFakeUUID,
+					CreatedAtInSec:  1,
+					Name:            "hello-world.yaml",
+					Parameters:      "[]",
+					ParameterSchema: test.parameterSchema,
+					Status:          model.PipelineVersionReady,
+					PipelineId:      resource.DefaultFakeUUID,
 				},
 				{
-					UUID:           fakeVersionUUID,
-					CreatedAtInSec: 2,
-					Name:           fakeVersionName,
-					Description:    fakeDescription,
-					Parameters:     "[]",
-					Status:         model.PipelineVersionReady,
-					PipelineId:     resource.DefaultFakeUUID,
+					UUID:            fakeVersionUUID,
+					CreatedAtInSec:  2,
+					Name:            fakeVersionName,
+					Description:     fakeDescription,
+					Parameters:      "[]",
+					ParameterSchema: test.parameterSchema,
+					Status:          model.PipelineVersionReady,
+					PipelineId:      resource.DefaultFakeUUID,
 				},
 			}
 			// Expect 2 versions, one is created by default when creating pipeline and the other is what we manually created
@@ -184,6 +191,8 @@ func TestUploadPipeline_Tarball(t *testing.T) {
 			Parameters:       "[{\"name\":\"param1\",\"value\":\"hello\"},{\"name\":\"param2\"}]",
 			Status:           model.PipelineReady,
 			DefaultVersionId: resource.DefaultFakeUUID,
+			ResourceVersion:  1,
+			VersionCount:     1,
 			DefaultVersion: &model.PipelineVersion{
 				UUID:           resource.DefaultFakeUUID,
 				CreatedAtInSec: 1,
@@ -233,6 +242,7 @@ func TestUploadPipeline_Tarball(t *testing.T) {
 			Parameters:     "[{\"name\":\"param1\",\"value\":\"hello\"},{\"name\":\"param2\"}]",
 			Status:         model.PipelineVersionReady,
 			PipelineId:     resource.DefaultFakeUUID,
+			PackageFormat:  model.PipelineVersionPackageFormatArchive,
 		},
 	}
 	// Expect 2 versions, one is created by default when creating pipeline and the other is what we manually created
@@ -279,6 +289,8 @@ func TestUploadPipeline_SpecifyFileName(t *testing.T) {
 			Parameters:       "[]",
 			Status:           model.PipelineReady,
 			DefaultVersionId: resource.DefaultFakeUUID,
+			ResourceVersion:  1,
+			VersionCount:     1,
 			DefaultVersion: &model.PipelineVersion{
 				UUID:           resource.DefaultFakeUUID,
 				CreatedAtInSec: 1,
@@ -332,6 +344,8 @@ func TestUploadPipeline_SpecifyFileDescription(t *testing.T) {
 			Parameters:       "[]",
 			Status:           model.PipelineReady,
 			DefaultVersionId: resource.DefaultFakeUUID,
+			ResourceVersion:  1,
+			VersionCount:     1,
 			DefaultVersion: &model.PipelineVersion{
 				UUID:           resource.DefaultFakeUUID,
 				CreatedAtInSec: 1,
@@ -447,12 +461,144 @@ func TestDefaultUpdatedPipelineVersion(t *testing.T) {
 	assert.Equal(t, pipeline.DefaultVersionId, fakeVersionUUID)
 }
 
+func TestUploadPipelineVersions(t *testing.T) {
+	clientManager, server := setupClientManagerAndServer()
+	bytesBuffer, writer := setupWriter("")
+	setWriterWithBuffer("uploadfile", "hello-world.yaml", "apiVersion: argoproj.io/v1alpha1\nkind: Workflow", writer)
+	response := uploadPipeline("/apis/v1beta1/pipelines/upload",
+		bytes.NewReader(bytesBuffer.Bytes()), writer, server.UploadPipeline)
+	assert.Equal(t, 200, response.Code)
+
+	server = updateClientManager(clientManager, util.NewUUIDGenerator())
+	bytesBuffer, writer = setupWriter("")
+	setWriterWithBuffers("uploadfile", map[string]string{
+		"a.yaml": "apiVersion: argoproj.io/v1alpha1\nkind: Workflow",
+		"b.yaml": "apiVersion: argoproj.io/v1alpha1\nkind: Workflow",
+	}, []string{"a.yaml", "b.yaml"}, writer)
+	response = uploadPipeline("/apis/v1beta1/pipelines/upload_versions?name=v1&pipelineid="+resource.DefaultFakeUUID,
+		bytes.NewReader(bytesBuffer.Bytes()), writer, server.UploadPipelineVersions)
+	assert.Equal(t, 200, response.Code)
+	var parsed uploadPipelineVersionsResponse
+	assert.Nil(t, json.Unmarshal(response.Body.Bytes(), &parsed))
+	if assert.Len(t, parsed.PipelineVersionIds, 2) {
+		// The second version is named after its file.
+		for i, name := range []string{"v1", "b.yaml"} {
+			version, err := clientManager.PipelineStore().GetPipelineVersion(parsed.PipelineVersionIds[i])
+			assert.Nil(t, err)
+			assert.Equal(t, name, version.Name)
+			assert.Equal(t, model.PipelineVersionReady, version.Status)
+		}
+		pipeline, err := clientManager.PipelineStore().GetPipeline(resource.DefaultFakeUUID)
+		assert.Nil(t, err)
+		assert.Equal(t, parsed.PipelineVersionIds[1], pipeline.DefaultVersionId)
+	}
+}
+
+func TestUploadPipelineVersions_NoVersionCreatedOnError(t *testing.T) {
+	clientManager, server := setupClientManagerAndServer()
+	bytesBuffer, writer := setupWriter("")
+	setWriterWithBuffer("uploadfile", "hello-world.yaml", "apiVersion: argoproj.io/v1alpha1\nkind: Workflow", writer)
+	response := uploadPipeline("/apis/v1beta1/pipelines/upload",
+		bytes.NewReader(bytesBuffer.Bytes()), writer, server.UploadPipeline)
+	assert.Equal(t, 200, response.Code)
+	server = updateClientManager(clientManager, util.NewUUIDGenerator())
+
+	tests := []struct {
+		name    string
+		query   string
+		files   map[string]string
+		message string
+	}{
+		{
+			name:  "duplicate names",
+			query: "name=v1&name=v1",
+			files: map[string]string{
+				"a.yaml": "apiVersion: argoproj.io/v1alpha1\nkind: Workflow",
+				"b.yaml": "apiVersion: argoproj.io/v1alpha1\nkind: Workflow",
+			},
+			message: "version name v1 is given more than once",
+		},
+		{
+			name: "invalid manifest",
+			files: map[string]string{
+				"a.yaml": "apiVersion: argoproj.io/v1alpha1\nkind: Workflow",
+				"b.yaml": "I am invalid",
+			},
+			message: "version b.yaml is invalid",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bytesBuffer, writer := setupWriter("")
+			setWriterWithBuffers("uploadfile", test.files, []string{"a.yaml", "b.yaml"}, writer)
+			response := uploadPipeline("/apis/v1beta1/pipelines/upload_versions?"+test.query+"&pipelineid="+resource.DefaultFakeUUID,
+				bytes.NewReader(bytesBuffer.Bytes()), writer, server.UploadPipelineVersions)
+			assert.Equal(t, 400, response.Code)
+			assert.Contains(t, response.Body.String(), test.message)
+
+			opts, err := list.NewOptions(&model.PipelineVersion{}, 10, "id", nil)
+			assert.Nil(t, err)
+			_, totalSize, _, err := clientManager.PipelineStore().ListPipelineVersions(resource.DefaultFakeUUID, opts)
+			assert.Nil(t, err)
+			assert.Equal(t, 1, totalSize)
+		})
+	}
+}
+
+func TestValidatePipeline(t *testing.T) {
+	clientManager, server := setupClientManagerAndServer()
+	tests := []struct {
+		name        string
+		spec        string
+		valid       bool
+		diagnostics int
+	}{
+		{name: "argo workflow without an entrypoint", spec: "apiVersion: argoproj.io/v1alpha1\nkind: Workflow", valid: true, diagnostics: 1},
+		{name: "pipeline v2 spec", spec: v2SpecHelloWorld, valid: true},
+		{name: "invalid file", spec: "I am invalid", diagnostics: 1},
+		{
+			name: "duplicate parameters",
+			spec: "apiVersion: argoproj.io/v1alpha1\nkind: Workflow\nspec:\n  arguments:\n    parameters:\n" +
+				"    - name: p\n      value: a\n    - name: p\n      value: b",
+			diagnostics: 2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bytesBuffer, writer := setupWriter("")
+			setWriterWithBuffer("uploadfile", "hello-world.yaml", test.spec, writer)
+			response := uploadPipeline("/apis/v1beta1/pipelines/validate",
+				bytes.NewReader(bytesBuffer.Bytes()), writer, server.ValidatePipeline)
+			assert.Equal(t, 200, response.Code)
+			var result validatePipelineResponse
+			assert.Nil(t, json.Unmarshal(response.Body.Bytes(), &result))
+			assert.Equal(t, test.valid, result.Valid)
+			assert.Len(t, result.Diagnostics, test.diagnostics)
+		})
+	}
+
+	// Nothing is stored.
+	opts, err := list.NewOptions(&model.Pipeline{}, 10, "", nil)
+	assert.Nil(t, err)
+	_, totalSize, _, err := clientManager.PipelineStore().ListPipelines(&common.FilterContext{}, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, totalSize)
+}
+
 func setWriterWithBuffer(fieldname string, filename string, buffer string, writer *multipart.Writer) {
 	part, _ := writer.CreateFormFile(fieldname, filename)
 	io.Copy(part, bytes.NewBufferString(buffer))
 	writer.Close()
 }
 
+func setWriterWithBuffers(fieldname string, buffers map[string]string, filenames []string, writer *multipart.Writer) {
+	for _, filename := range filenames {
+		part, _ := writer.CreateFormFile(fieldname, filename)
+		io.Copy(part, bytes.NewBufferString(buffers[filename]))
+	}
+	writer.Close()
+}
+
 func setWriterFromFile(fieldname string, filename string, filepath string, writer *multipart.Writer) {
 	part, _ := writer.CreateFormFile(fieldname, filename)
 	fileReader, _ := os.Open(filepath)
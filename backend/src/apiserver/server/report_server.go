@@ -23,6 +23,7 @@ import (
 	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	scheduledworkflow "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow/v1beta1"
+	"google.golang.org/grpc/status"
 )
 
 type ReportServer struct {
@@ -42,6 +43,38 @@ func (s *ReportServer) ReportWorkflowV1(ctx context.Context,
 	return &empty.Empty{}, nil
 }
 
+// ReportWorkflowsV1 reports several workflows at once, storing their run statuses in batches. The results
+// are aligned with the workflows of the request, so that the persistence agent knows which ones to retry.
+func (s *ReportServer) ReportWorkflowsV1(ctx context.Context,
+	request *api.ReportWorkflowsRequest) (*api.ReportWorkflowsResponse, error) {
+	results := make([]*api.Status, len(request.GetWorkflows()))
+	var updates []*resource.WorkflowStatusUpdate
+	var indexes []int
+	for i, workflow := range request.GetWorkflows() {
+		execSpec, err := ValidateReportWorkflowRequest(&api.ReportWorkflowRequest{Workflow: workflow})
+		if err != nil {
+			results[i] = toBatchStatus(util.Wrap(err, "Report workflow failed."))
+			continue
+		}
+		updates = append(updates, &resource.WorkflowStatusUpdate{Execution: execSpec})
+		indexes = append(indexes, i)
+	}
+	for k, err := range s.resourceManager.ReportWorkflows(ctx, updates) {
+		results[indexes[k]] = toBatchStatus(util.Wrap(err, "Report workflow failed."))
+	}
+	return &api.ReportWorkflowsResponse{Results: results}, nil
+}
+
+// toBatchStatus converts the outcome of one item of a batch request, such as reporting a workflow, to its
+// status in the batch response.
+func toBatchStatus(err error) *api.Status {
+	if err == nil {
+		return &api.Status{}
+	}
+	stat := status.Convert(util.ToGRPCError(err))
+	return &api.Status{Error: stat.Message(), Code: int32(stat.Code())}
+}
+
 func (s *ReportServer) ReportScheduledWorkflowV1(ctx context.Context,
 	request *api.ReportScheduledWorkflowRequest) (*empty.Empty, error) {
 	scheduledWorkflow, err := ValidateReportScheduledWorkflowRequest(request)
@@ -79,6 +79,37 @@ func TestReportWorkflow_ValidationFailed(t *testing.T) {
 	assert.Contains(t, err.Error(), "must have a name")
 }
 
+func TestReportWorkflows(t *testing.T) {
+	clientManager, resourceManager, run := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	reportServer := NewReportServer(resourceManager)
+
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Workflow",
+			APIVersion: "argoproj.io/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "run1",
+			Namespace: "default",
+			UID:       types.UID(run.UUID),
+			Labels:    map[string]string{util.LabelKeyWorkflowRunId: run.UUID},
+		},
+		Status: v1alpha1.WorkflowStatus{Phase: v1alpha1.WorkflowRunning},
+	})
+	response, err := reportServer.ReportWorkflowsV1(nil, &api.ReportWorkflowsRequest{
+		Workflows: []string{"invalid", workflow.ToStringForStore()},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, response.Results, 2)
+	assert.Equal(t, int32(codes.InvalidArgument), response.Results[0].Code)
+	assert.Contains(t, response.Results[0].Error, "Could not unmarshal workflow")
+	assert.Equal(t, &api.Status{}, response.Results[1])
+	run, err = resourceManager.GetRun(run.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Running", run.Conditions)
+}
+
 func TestValidateReportWorkflowRequest(t *testing.T) {
 	// Name
 	workflow := &v1alpha1.Workflow{
@@ -15,6 +15,7 @@
 package server
 
 import (
+	"math"
 	"regexp"
 
 	"github.com/golang/glog"
@@ -52,7 +53,7 @@ func ValidateRunMetricV1(metric *apiv1beta1.RunMetric) error {
 		return util.NewInvalidInputError(
 			"metric.node_id '%s' cannot be longer than 128 characters", metric.GetNodeId())
 	}
-	return nil
+	return validateRunMetricValue(metric.GetName(), metric.GetNumberValue())
 }
 
 // NewReportRunMetricResult turns error into a ReportRunMetricResult.
@@ -105,7 +106,7 @@ func ValidateRunMetric(metric *apiv2beta1.RunMetric) error {
 		return util.NewInvalidInputError(
 			"metric.node_id '%s' cannot be longer than 128 characters", metric.GetNodeId())
 	}
-	return nil
+	return validateRunMetricValue(metric.GetDisplayName(), metric.GetNumberValue())
 }
 
 // NewReportRunMetricResult turns error into a ReportRunMetricResult.
@@ -137,3 +138,35 @@ func NewReportRunMetricResult(metricName string, nodeID string, err error) *apiv
 	}
 	return result
 }
+
+// validateRunMetricValue rejects values that cannot be compared or aggregated.
+func validateRunMetricValue(metricName string, value float64) error {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return util.NewInvalidInputError(
+			"metric.number_value of metric '%s' must be a finite number, got %v", metricName, value)
+	}
+	return nil
+}
+
+// runMetricKey identifies a metric within a run.
+type runMetricKey struct {
+	nodeID string
+	name   string
+}
+
+// runMetricKeyCounts counts how many times each metric appears in a single report.
+type runMetricKeyCounts map[runMetricKey]int
+
+func (c runMetricKeyCounts) add(nodeID string, name string) {
+	c[runMetricKey{nodeID: nodeID, name: name}]++
+}
+
+// validateNotDuplicated rejects a metric that appears more than once in a report. Since it's unclear
+// which of the values is meant, none of them is stored.
+func (c runMetricKeyCounts) validateNotDuplicated(nodeID string, name string) error {
+	if c[runMetricKey{nodeID: nodeID, name: name}] > 1 {
+		return util.NewInvalidInputError(
+			"metric '%s/%s' is reported more than once in the same request", nodeID, name)
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ package server
 
 import (
 	"errors"
+	"math"
 	"testing"
 
 	"google.golang.org/grpc/codes"
@@ -76,6 +77,18 @@ func TestValidateRunMetricV1_InvalidNodeIDs(t *testing.T) {
 	AssertUserError(t, err, codes.InvalidArgument)
 }
 
+func TestValidateRunMetricV1_NonFiniteValues(t *testing.T) {
+	for _, value := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		metric := &apiv1beta1.RunMetric{
+			Name:   "a",
+			NodeId: "node-1",
+			Value:  &apiv1beta1.RunMetric_NumberValue{NumberValue: value},
+		}
+		err := ValidateRunMetricV1(metric)
+		AssertUserError(t, err, codes.InvalidArgument)
+	}
+}
+
 func TestNewReportRunMetricResultV1_OK(t *testing.T) {
 	tests := []struct {
 		metricName string
@@ -27,6 +27,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/codes"
 	authorizationv1 "k8s.io/api/authorization/v1"
 )
 
@@ -38,6 +39,11 @@ var (
 		Help: "The total number of CreateRun requests",
 	})
 
+	bulkCreateRunsRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "run_server_bulk_create_requests",
+		Help: "The total number of BulkCreateRuns requests",
+	})
+
 	getRunRequests = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "run_server_get_requests",
 		Help: "The total number of GetRun requests",
@@ -114,40 +120,99 @@ func (s *RunServer) CreateRunV1(ctx context.Context, request *apiv1beta1.CreateR
 		return nil, util.Wrap(err, "Validate create run request failed.")
 	}
 
-	// In multi-user mode, verify the user has access to the resources related to this run.
-	if common.IsMultiUserMode() {
-		// User must provide the experiment ID, which must belong to a namespace the user is authorized with.
-		experimentID := common.GetExperimentIDFromAPIResourceReferences(request.Run.ResourceReferences)
-		if experimentID == "" {
-			return nil, util.NewInvalidInputError("Run has no experiment.")
+	err = s.canCreateRunV1(ctx, request.Run)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := s.resourceManager.CreateRun(ctx, request.Run)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to create a new run.")
+	}
+
+	if s.options.CollectMetrics {
+		runCount.Inc()
+	}
+	return ToApiRunDetailV1(run, s.resourceManager.GetTime().Now().Unix()), nil
+}
+
+// BulkCreateRunsV1 creates several runs at once. Every run is validated and authorized the same way as in
+// CreateRunV1. Unless the request allows partial failure, a run that fails validation or authorization fails
+// the whole request before any run is created.
+func (s *RunServer) BulkCreateRunsV1(ctx context.Context, request *apiv1beta1.BulkCreateRunsRequest) (*apiv1beta1.BulkCreateRunsResponse, error) {
+	if s.options.CollectMetrics {
+		bulkCreateRunsRequests.Inc()
+	}
+
+	results := make([]*apiv1beta1.BulkCreateRunResult, len(request.GetRuns()))
+	var apiRuns []interface{}
+	var indexes []int
+	for i, run := range request.GetRuns() {
+		err := s.validateCreateRunRequestV1(&apiv1beta1.CreateRunRequest{Run: run})
+		if err == nil {
+			err = s.canCreateRunV1(ctx, run)
 		}
-		namespace, err := s.resourceManager.GetNamespaceFromExperimentID(experimentID)
 		if err != nil {
-			return nil, util.Wrap(err, "Failed to get namespace for run.")
-		}
-		if namespace == "" {
-			return nil, util.NewInvalidInputError("Run's experiment has no namespace.")
-		}
-		resourceAttributes := &authorizationv1.ResourceAttributes{
-			Namespace: namespace,
-			Verb:      common.RbacResourceVerbCreate,
-			Name:      request.Run.Name,
+			err = util.Wrapf(err, "Validate run at index %v failed.", i)
+			if !request.GetAllowPartialFailure() {
+				return nil, err
+			}
+			results[i] = &apiv1beta1.BulkCreateRunResult{Status: toBatchStatus(err)}
+			continue
 		}
-		err = s.canAccessRun(ctx, "", resourceAttributes)
+		apiRuns = append(apiRuns, run)
+		indexes = append(indexes, i)
+	}
+	if len(apiRuns) > 0 {
+		runs, runErrors, err := s.resourceManager.BulkCreateRuns(ctx, apiRuns, request.GetAllowPartialFailure())
 		if err != nil {
-			return nil, util.Wrap(err, "Failed to authorize the request")
+			return nil, util.Wrap(err, "Failed to create new runs.")
+		}
+		for k, run := range runs {
+			if run == nil {
+				results[indexes[k]] = &apiv1beta1.BulkCreateRunResult{Status: toBatchStatus(runErrors[k])}
+				continue
+			}
+			results[indexes[k]] = &apiv1beta1.BulkCreateRunResult{Run: ToApiRunDetailV1(run, s.resourceManager.GetTime().Now().Unix()), Status: toBatchStatus(nil)}
+			if s.options.CollectMetrics {
+				runCount.Inc()
+			}
 		}
 	}
+	return &apiv1beta1.BulkCreateRunsResponse{Results: results}, nil
+}
 
-	run, err := s.resourceManager.CreateRun(ctx, request.Run)
+// CreateRunFromPipelineVersion creates a run of a pipeline version in an experiment from the stored manifest
+// of the version. The run is validated and authorized the same way as in CreateRunV1, which covers both the
+// experiment and the version.
+func (s *RunServer) CreateRunFromPipelineVersion(ctx context.Context, versionId string, experimentId string,
+	params []*apiv1beta1.Parameter) (*apiv1beta1.RunDetail, error) {
+	run, err := s.resourceManager.NewRunFromPipelineVersion(versionId, experimentId, params)
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to create a new run.")
 	}
+	return s.CreateRunV1(ctx, &apiv1beta1.CreateRunRequest{Run: run})
+}
 
-	if s.options.CollectMetrics {
-		runCount.Inc()
+// DryRunRunV1 compiles the run in the request without submitting it. The request is validated and
+// authorized the same way as in CreateRunV1.
+func (s *RunServer) DryRunRunV1(ctx context.Context, request *apiv1beta1.CreateRunRequest) (*apiv1beta1.DryRunRunResponse, error) {
+	err := s.validateCreateRunRequestV1(request)
+	if err != nil {
+		return nil, util.Wrap(err, "Validate create run request failed.")
+	}
+	err = s.canCreateRunV1(ctx, request.Run)
+	if err != nil {
+		return nil, err
 	}
-	return ToApiRunDetailV1(run), nil
+	result, err := s.resourceManager.DryRunRun(ctx, request.Run)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to dry run the run.")
+	}
+	return &apiv1beta1.DryRunRunResponse{
+		WorkflowManifest: result.WorkflowManifest,
+		Diagnostics:      result.Diagnostics,
+	}, nil
 }
 
 func (s *RunServer) GetRunV1(ctx context.Context, request *apiv1beta1.GetRunRequest) (*apiv1beta1.RunDetail, error) {
@@ -164,7 +229,58 @@ func (s *RunServer) GetRunV1(ctx context.Context, request *apiv1beta1.GetRunRequ
 	if err != nil {
 		return nil, err
 	}
-	return ToApiRunDetailV1(run), nil
+	return ToApiRunDetailV1(run, s.resourceManager.GetTime().Now().Unix()), nil
+}
+
+// GetRunManifest returns the workflow that was submitted for a run as YAML. The request is authorized
+// the same way as in GetRun.
+func (s *RunServer) GetRunManifest(ctx context.Context, runId string) (string, error) {
+	err := s.canAccessRun(ctx, runId, &authorizationv1.ResourceAttributes{Verb: common.RbacResourceVerbGet})
+	if err != nil {
+		return "", util.Wrap(err, "Failed to authorize the request")
+	}
+	manifest, err := s.resourceManager.GetRunManifest(ctx, runId)
+	if err != nil {
+		return "", util.Wrap(err, "Failed to get the run manifest.")
+	}
+	return manifest, nil
+}
+
+// GetRunLogs returns where the logs of a node of a run are, and whether they can still be read from its pod.
+// The request is authorized the same way as in GetRun.
+func (s *RunServer) GetRunLogs(ctx context.Context, runId string, nodeId string) (*resource.RunLogLocation, bool, error) {
+	err := s.canAccessRun(ctx, runId, &authorizationv1.ResourceAttributes{Verb: common.RbacResourceVerbGet})
+	if err != nil {
+		return nil, false, util.Wrap(err, "Failed to authorize the request")
+	}
+	location, live, err := s.resourceManager.GetRunLogs(ctx, runId, nodeId)
+	if err != nil {
+		return nil, false, util.Wrap(err, "Failed to get the run logs.")
+	}
+	return location, live, nil
+}
+
+// GetRunArtifacts returns the output artifacts of the nodes of a run. The request is authorized the same way
+// as in GetRun.
+func (s *RunServer) GetRunArtifacts(ctx context.Context, runId string) ([]util.ExecutionArtifact, error) {
+	err := s.canAccessRun(ctx, runId, &authorizationv1.ResourceAttributes{Verb: common.RbacResourceVerbGet})
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to authorize the request")
+	}
+	artifacts, err := s.resourceManager.GetRunArtifacts(ctx, runId)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the run artifacts.")
+	}
+	return artifacts, nil
+}
+
+// UpdateRunName renames a run. The request is authorized as an update of the run.
+func (s *RunServer) UpdateRunName(ctx context.Context, runId string, name string) error {
+	err := s.canAccessRun(ctx, runId, &authorizationv1.ResourceAttributes{Verb: common.RbacResourceVerbUpdate})
+	if err != nil {
+		return util.Wrap(err, "Failed to authorize the request")
+	}
+	return s.resourceManager.UpdateRunName(ctx, runId, name)
 }
 
 func (s *RunServer) ListRunsV1(ctx context.Context, request *apiv1beta1.ListRunsRequest) (*apiv1beta1.ListRunsResponse, error) {
@@ -227,7 +343,7 @@ func (s *RunServer) ListRunsV1(ctx context.Context, request *apiv1beta1.ListRuns
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to list runs.")
 	}
-	return &apiv1beta1.ListRunsResponse{Runs: toApiRunsV1(runs), TotalSize: int32(total_size), NextPageToken: nextPageToken}, nil
+	return &apiv1beta1.ListRunsResponse{Runs: toApiRunsV1(runs, s.resourceManager.GetTime().Now().Unix()), TotalSize: int32(total_size), NextPageToken: nextPageToken}, nil
 }
 
 func (s *RunServer) ArchiveRunV1(ctx context.Context, request *apiv1beta1.ArchiveRunRequest) (*empty.Empty, error) {
@@ -239,7 +355,7 @@ func (s *RunServer) ArchiveRunV1(ctx context.Context, request *apiv1beta1.Archiv
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to authorize the request")
 	}
-	err = s.resourceManager.ArchiveRun(request.Id)
+	err = s.resourceManager.ArchiveRun(ctx, request.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -255,7 +371,7 @@ func (s *RunServer) UnarchiveRunV1(ctx context.Context, request *apiv1beta1.Unar
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to authorize the request")
 	}
-	err = s.resourceManager.UnarchiveRun(request.Id)
+	err = s.resourceManager.UnarchiveRun(ctx, request.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -300,8 +416,15 @@ func (s *RunServer) ReportRunMetricsV1(ctx context.Context, request *apiv1beta1.
 	response := &apiv1beta1.ReportRunMetricsResponse{
 		Results: []*apiv1beta1.ReportRunMetricsResponse_ReportRunMetricResult{},
 	}
+	counts := runMetricKeyCounts{}
+	for _, metric := range request.GetMetrics() {
+		counts.add(metric.GetNodeId(), metric.GetName())
+	}
 	for _, metric := range request.GetMetrics() {
 		err := ValidateRunMetricV1(metric)
+		if err == nil {
+			err = counts.validateNotDuplicated(metric.GetNodeId(), metric.GetName())
+		}
 		if err == nil {
 			err = s.resourceManager.ReportMetric(metric, request.GetRunId())
 		}
@@ -357,7 +480,7 @@ func (s *RunServer) RetryRunV1(ctx context.Context, request *apiv1beta1.RetryRun
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to authorize the request")
 	}
-	err = s.resourceManager.RetryRun(ctx, request.RunId)
+	_, err = s.resourceManager.RetryRun(ctx, request.RunId, !request.GetRestart())
 	if err != nil {
 		return nil, err
 	}
@@ -375,29 +498,9 @@ func (s *RunServer) CreateRun(ctx context.Context, request *apiv2beta1.CreateRun
 		return nil, util.Wrap(err, "Validate create run request failed.")
 	}
 
-	// In multi-user mode, verify the user has access to the resources related to this run.
-	if common.IsMultiUserMode() {
-		// User must provide the experiment ID and the namespace. The experiment must belong to the namespace,
-		// and user must be authorized with this namespace.
-		if request.Run.ExperimentId == "" {
-			return nil, util.NewInvalidInputError("Run experiment ID is empty.")
-		}
-		namespace, err := s.resourceManager.GetNamespaceFromExperimentID(request.Run.ExperimentId)
-		if err != nil {
-			return nil, util.Wrap(err, "Failed to get namespace for run.")
-		}
-		if namespace == "" {
-			return nil, util.NewInvalidInputError("Run's experiment has no namespace.")
-		}
-		resourceAttributes := &authorizationv1.ResourceAttributes{
-			Namespace: namespace,
-			Verb:      common.RbacResourceVerbCreate,
-			Name:      request.Run.DisplayName,
-		}
-		err = s.canAccessRun(ctx, "", resourceAttributes)
-		if err != nil {
-			return nil, util.Wrap(err, "Failed to authorize the request")
-		}
+	err = s.canCreateRun(ctx, request.Run)
+	if err != nil {
+		return nil, err
 	}
 
 	run, err := s.resourceManager.CreateRun(ctx, request.Run)
@@ -408,10 +511,31 @@ func (s *RunServer) CreateRun(ctx context.Context, request *apiv2beta1.CreateRun
 	if s.options.CollectMetrics {
 		runCount.Inc()
 	}
-	return toApiRun(&run.Run), nil
+	return toApiRun(&run.Run, s.resourceManager.GetTime().Now().Unix()), nil
 
 }
 
+// DryRunRun compiles the run in the request without submitting it. The request is validated and
+// authorized the same way as in CreateRun.
+func (s *RunServer) DryRunRun(ctx context.Context, request *apiv2beta1.CreateRunRequest) (*apiv2beta1.DryRunRunResponse, error) {
+	err := s.validateCreateRunRequest(request)
+	if err != nil {
+		return nil, util.Wrap(err, "Validate create run request failed.")
+	}
+	err = s.canCreateRun(ctx, request.Run)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.resourceManager.DryRunRun(ctx, request.Run)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to dry run the run.")
+	}
+	return &apiv2beta1.DryRunRunResponse{
+		WorkflowManifest: result.WorkflowManifest,
+		Diagnostics:      result.Diagnostics,
+	}, nil
+}
+
 func (s *RunServer) GetRun(ctx context.Context, request *apiv2beta1.GetRunRequest) (*apiv2beta1.Run, error) {
 	if s.options.CollectMetrics {
 		getRunRequests.Inc()
@@ -426,7 +550,7 @@ func (s *RunServer) GetRun(ctx context.Context, request *apiv2beta1.GetRunReques
 	if err != nil {
 		return nil, err
 	}
-	return toApiRun(&run.Run), nil
+	return toApiRun(&run.Run, s.resourceManager.GetTime().Now().Unix()), nil
 }
 
 func (s *RunServer) ListRuns(ctx context.Context, request *apiv2beta1.ListRunsRequest) (*apiv2beta1.ListRunsResponse, error) {
@@ -496,7 +620,7 @@ func (s *RunServer) ListRuns(ctx context.Context, request *apiv2beta1.ListRunsRe
 		return nil, util.Wrap(err, "Failed to list runs.")
 	}
 
-	return &apiv2beta1.ListRunsResponse{Runs: toApiRuns(runs), TotalSize: int32(total_size), NextPageToken: nextPageToken}, nil
+	return &apiv2beta1.ListRunsResponse{Runs: toApiRuns(runs, s.resourceManager.GetTime().Now().Unix()), TotalSize: int32(total_size), NextPageToken: nextPageToken}, nil
 
 }
 
@@ -509,7 +633,7 @@ func (s *RunServer) ArchiveRun(ctx context.Context, request *apiv2beta1.ArchiveR
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to authorize the request")
 	}
-	err = s.resourceManager.ArchiveRun(request.RunId)
+	err = s.resourceManager.ArchiveRun(ctx, request.RunId)
 	if err != nil {
 		return nil, err
 	}
@@ -525,7 +649,7 @@ func (s *RunServer) UnarchiveRun(ctx context.Context, request *apiv2beta1.Unarch
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to authorize the request")
 	}
-	err = s.resourceManager.UnarchiveRun(request.RunId)
+	err = s.resourceManager.UnarchiveRun(ctx, request.RunId)
 	if err != nil {
 		return nil, err
 	}
@@ -552,6 +676,41 @@ func (s *RunServer) DeleteRun(ctx context.Context, request *apiv2beta1.DeleteRun
 	return &empty.Empty{}, nil
 }
 
+// DeleteRuns deletes several runs. Each run is authorized the same way as in DeleteRun, and a run that
+// fails authorization or deletion doesn't stop the others from being deleted. Runs that don't exist are
+// skipped. The errors of the runs that couldn't be deleted are returned, keyed by run ID.
+func (s *RunServer) DeleteRuns(ctx context.Context, runIds []string) (map[string]error, error) {
+	if maxBatchSize := common.GetMaxDeleteRunsBatchSize(); len(runIds) > maxBatchSize {
+		return nil, util.NewInvalidInputError("Cannot delete %d runs at once, the maximum is %d", len(runIds), maxBatchSize)
+	}
+	failures := make(map[string]error)
+	var authorized []string
+	for _, runId := range runIds {
+		err := s.canAccessRun(ctx, runId, &authorizationv1.ResourceAttributes{Verb: common.RbacResourceVerbDelete})
+		if util.IsUserErrorCodeMatch(err, codes.NotFound) {
+			continue
+		}
+		if err != nil {
+			failures[runId] = util.Wrap(err, "Failed to authorize the request")
+			continue
+		}
+		authorized = append(authorized, runId)
+	}
+	deleted, deleteFailures, err := s.resourceManager.DeleteRuns(ctx, authorized)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to delete runs")
+	}
+	for runId, err := range deleteFailures {
+		failures[runId] = err
+	}
+
+	if s.options.CollectMetrics {
+		deleteRunRequests.Inc()
+		runCount.Sub(float64(len(deleted)))
+	}
+	return failures, nil
+}
+
 func (s *RunServer) ReportRunMetrics(ctx context.Context, request *apiv2beta1.ReportRunMetricsRequest) (*apiv2beta1.ReportRunMetricsResponse, error) {
 	if s.options.CollectMetrics {
 		reportRunMetricsRequests.Inc()
@@ -570,8 +729,15 @@ func (s *RunServer) ReportRunMetrics(ctx context.Context, request *apiv2beta1.Re
 	response := &apiv2beta1.ReportRunMetricsResponse{
 		Results: []*apiv2beta1.ReportRunMetricsResponse_ReportRunMetricResult{},
 	}
+	counts := runMetricKeyCounts{}
+	for _, metric := range request.GetMetrics() {
+		counts.add(metric.GetNodeId(), metric.GetDisplayName())
+	}
 	for _, metric := range request.GetMetrics() {
 		err := ValidateRunMetric(metric)
+		if err == nil {
+			err = counts.validateNotDuplicated(metric.GetNodeId(), metric.GetDisplayName())
+		}
 		if err == nil {
 			err = s.resourceManager.ReportMetric(metric, request.GetRunId())
 		}
@@ -618,11 +784,54 @@ func (s *RunServer) TerminateRun(ctx context.Context, request *apiv2beta1.Termin
 	return &empty.Empty{}, nil
 }
 
+// TerminateJobRuns terminates the runs of a job that haven't finished yet, without disabling the job. Each run
+// is authorized the same way as in TerminateRun, and a run that fails authorization or termination doesn't stop
+// the others from being terminated. Runs that finish before they are terminated are skipped. The result of each
+// of the other runs is returned, keyed by run ID, with a nil error for the runs that were terminated.
+func (s *RunServer) TerminateJobRuns(ctx context.Context, jobId string) (map[string]error, error) {
+	runIds, err := s.resourceManager.GetActiveJobRunIds(jobId)
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string]error)
+	var authorized []string
+	for _, runId := range runIds {
+		err := s.canAccessRun(ctx, runId, &authorizationv1.ResourceAttributes{Verb: common.RbacResourceVerbTerminate})
+		if util.IsUserErrorCodeMatch(err, codes.NotFound) {
+			continue
+		}
+		if err != nil {
+			results[runId] = util.Wrap(err, "Failed to authorize the request")
+			continue
+		}
+		authorized = append(authorized, runId)
+	}
+	for runId, err := range s.resourceManager.TerminateRuns(ctx, authorized) {
+		results[runId] = err
+	}
+	return results, nil
+}
+
+// GetRunStatusHistory returns the states a run went through, oldest first.
+func (s *RunServer) GetRunStatusHistory(ctx context.Context, runId string) ([]*model.RunStatusEvent, error) {
+	err := s.canAccessRun(ctx, runId, &authorizationv1.ResourceAttributes{Verb: common.RbacResourceVerbGet})
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to authorize the request")
+	}
+	return s.resourceManager.GetRunStatusHistory(ctx, runId)
+}
+
 func (s *RunServer) validateCreateRunRequestV1(request *apiv1beta1.CreateRunRequest) error {
 	run := request.Run
 	if run.Name == "" {
 		return util.NewInvalidInputError("The run name is empty. Please specify a valid name.")
 	}
+	// Runs without a pipeline use the default pipeline version of their experiment, if it has one.
+	references, err := s.resourceManager.AddExperimentDefaultVersion(run.PipelineSpec, run.ResourceReferences)
+	if err != nil {
+		return err
+	}
+	run.ResourceReferences = references
 	return ValidatePipelineSpecAndResourceReferences(s.resourceManager, run.PipelineSpec, run.ResourceReferences)
 }
 
@@ -635,6 +844,86 @@ func (s *RunServer) validateCreateRunRequest(request *apiv2beta1.CreateRunReques
 
 }
 
+// canCreateRunV1 verifies, in multi-user mode, that the user has access to the resources related to the run.
+func (s *RunServer) canCreateRunV1(ctx context.Context, run *apiv1beta1.Run) error {
+	if !common.IsMultiUserMode() {
+		return nil
+	}
+	// The run's experiment must belong to a namespace the user is authorized with. Without an experiment,
+	// the run goes to the default experiment of the namespace it references.
+	namespace, err := namespaceOfExperimentOrReference(s.resourceManager, run.ResourceReferences)
+	if err != nil {
+		return util.Wrap(err, "Failed to get namespace for run.")
+	}
+	if namespace == "" && common.GetExperimentIDFromAPIResourceReferences(run.ResourceReferences) == "" {
+		return util.NewInvalidInputError("Run has no experiment.")
+	}
+	if namespace == "" {
+		return util.NewInvalidInputError("Run's experiment has no namespace.")
+	}
+	resourceAttributes := &authorizationv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      common.RbacResourceVerbCreate,
+		Name:      run.Name,
+	}
+	err = s.canAccessRun(ctx, "", resourceAttributes)
+	if err != nil {
+		return util.Wrap(err, "Failed to authorize the request")
+	}
+	err = canUsePriorityClass(s.resourceManager, ctx, run, namespace)
+	if err != nil {
+		return err
+	}
+	err = canReadEnvSecrets(s.resourceManager, ctx, run, namespace)
+	if err != nil {
+		return err
+	}
+	// The user must also be able to read the resources the run references, such as a pipeline version
+	// owned by another namespace.
+	err = canAccessResourceReferences(s.resourceManager, ctx, run.GetResourceReferences())
+	if err != nil {
+		return err
+	}
+	return canAccessReferencedResource(s.resourceManager, ctx, apiv1beta1.ResourceType_PIPELINE, run.GetPipelineSpec().GetPipelineId())
+}
+
+// canCreateRun verifies, in multi-user mode, that the user has access to the resources related to the run.
+func (s *RunServer) canCreateRun(ctx context.Context, run *apiv2beta1.Run) error {
+	if !common.IsMultiUserMode() {
+		return nil
+	}
+	// User must provide the experiment ID and the namespace. The experiment must belong to the namespace,
+	// and user must be authorized with this namespace.
+	if run.ExperimentId == "" {
+		return util.NewInvalidInputError("Run experiment ID is empty.")
+	}
+	namespace, err := s.resourceManager.GetNamespaceFromExperimentID(run.ExperimentId)
+	if err != nil {
+		return util.Wrap(err, "Failed to get namespace for run.")
+	}
+	if namespace == "" {
+		return util.NewInvalidInputError("Run's experiment has no namespace.")
+	}
+	resourceAttributes := &authorizationv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      common.RbacResourceVerbCreate,
+		Name:      run.DisplayName,
+	}
+	err = s.canAccessRun(ctx, "", resourceAttributes)
+	if err != nil {
+		return util.Wrap(err, "Failed to authorize the request")
+	}
+	err = canUsePriorityClass(s.resourceManager, ctx, run, namespace)
+	if err != nil {
+		return err
+	}
+	err = canReadEnvSecrets(s.resourceManager, ctx, run, namespace)
+	if err != nil {
+		return err
+	}
+	return canAccessReferencedResource(s.resourceManager, ctx, apiv1beta1.ResourceType_PIPELINE, run.GetPipelineId())
+}
+
 func (s *RunServer) canAccessRun(ctx context.Context, runId string, resourceAttributes *authorizationv1.ResourceAttributes) error {
 	if common.IsMultiUserMode() == false {
 		// Skip authz if not multi-user mode.
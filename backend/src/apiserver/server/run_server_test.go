@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -22,10 +23,13 @@ import (
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	authorizationv1 "k8s.io/api/authorization/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -80,14 +84,18 @@ func TestCreateRunV1_V1Params(t *testing.T) {
 
 	expectedRunDetail := apiv1beta1.RunDetail{
 		Run: &apiv1beta1.Run{
-			Id:             "123e4567-e89b-12d3-a456-426655440000",
-			Name:           "run1",
-			ServiceAccount: "pipeline-runner",
-			StorageState:   apiv1beta1.Run_STORAGESTATE_AVAILABLE,
-			CreatedAt:      &timestamp.Timestamp{Seconds: 2},
-			ScheduledAt:    &timestamp.Timestamp{Seconds: 2},
-			FinishedAt:     &timestamp.Timestamp{},
-			Status:         "Running",
+			Id:               "123e4567-e89b-12d3-a456-426655440000",
+			Name:             "run1",
+			ServiceAccount:   "pipeline-runner",
+			StorageState:     apiv1beta1.Run_STORAGESTATE_AVAILABLE,
+			CreatedAt:        &timestamp.Timestamp{Seconds: 2},
+			ScheduledAt:      &timestamp.Timestamp{Seconds: 2},
+			StatusSource:     "live",
+			Namespace:        "ns1",
+			WorkflowUid:      "workflow1",
+			QueueWaitSeconds: 1,
+			FinishedAt:       &timestamp.Timestamp{},
+			Status:           "Running",
 			PipelineSpec: &apiv1beta1.PipelineSpec{
 				WorkflowManifest: testWorkflow.ToStringForStore(),
 				Parameters:       []*apiv1beta1.Parameter{{Name: "param1", Value: "world"}},
@@ -106,6 +114,213 @@ func TestCreateRunV1_V1Params(t *testing.T) {
 	assert.Equal(t, expectedRunDetail, *runDetail)
 }
 
+func TestBulkCreateRunsV1(t *testing.T) {
+	clients, _, _ := initWithExperiment(t)
+	defer clients.Close()
+	clients.UpdateUUID(util.NewUUIDGenerator())
+	server := NewRunServer(resource.NewResourceManager(clients), &RunServerOptions{CollectMetrics: false})
+	request := &apiv1beta1.BulkCreateRunsRequest{Runs: []*apiv1beta1.Run{newBulkRunV1("run1"), newBulkRunV1("run2")}}
+	response, err := server.BulkCreateRunsV1(context.Background(), request)
+	assert.Nil(t, err)
+	require.Len(t, response.Results, 2)
+	for i, result := range response.Results {
+		assert.Equal(t, int32(codes.OK), result.Status.Code)
+		assert.Equal(t, fmt.Sprintf("run%v", i+1), result.Run.Run.Name)
+	}
+	assert.NotEqual(t, response.Results[0].Run.Run.Id, response.Results[1].Run.Run.Id)
+}
+
+func TestBulkCreateRunsV1_InvalidRunCreatesNothing(t *testing.T) {
+	clients, _, _ := initWithExperiment(t)
+	defer clients.Close()
+	clients.UpdateUUID(util.NewUUIDGenerator())
+	server := NewRunServer(resource.NewResourceManager(clients), &RunServerOptions{CollectMetrics: false})
+	request := &apiv1beta1.BulkCreateRunsRequest{Runs: []*apiv1beta1.Run{newBulkRunV1("run1"), newBulkRunV1("")}}
+	_, err := server.BulkCreateRunsV1(context.Background(), request)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "index 1")
+	assert.Equal(t, 0, clients.ExecClientFake.GetWorkflowCount())
+}
+
+func TestBulkCreateRunsV1_AllowPartialFailure(t *testing.T) {
+	clients, _, _ := initWithExperiment(t)
+	defer clients.Close()
+	clients.UpdateUUID(util.NewUUIDGenerator())
+	server := NewRunServer(resource.NewResourceManager(clients), &RunServerOptions{CollectMetrics: false})
+	request := &apiv1beta1.BulkCreateRunsRequest{
+		Runs:                []*apiv1beta1.Run{newBulkRunV1("run1"), newBulkRunV1(""), newBulkRunV1("run3")},
+		AllowPartialFailure: true,
+	}
+	response, err := server.BulkCreateRunsV1(context.Background(), request)
+	assert.Nil(t, err)
+	require.Len(t, response.Results, 3)
+	assert.Equal(t, "run1", response.Results[0].Run.Run.Name)
+	assert.Nil(t, response.Results[1].Run)
+	assert.Equal(t, int32(codes.InvalidArgument), response.Results[1].Status.Code)
+	assert.Equal(t, "run3", response.Results[2].Run.Run.Name)
+}
+
+func TestCreateRunV1_TTLSecondsAfterFinished(t *testing.T) {
+	clients, manager, _ := initWithExperiment(t)
+	defer clients.Close()
+	server := NewRunServer(manager, &RunServerOptions{CollectMetrics: false})
+	run := newBulkRunV1("run1")
+	run.TtlSecondsAfterFinished = wrapperspb.Int32(3600)
+	runDetail, err := server.CreateRunV1(nil, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3600), runDetail.Run.TtlSecondsAfterFinished.GetValue())
+
+	runDetail, err = server.GetRunV1(nil, &apiv1beta1.GetRunRequest{RunId: runDetail.Run.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3600), runDetail.Run.TtlSecondsAfterFinished.GetValue())
+}
+
+func TestCreateRunV1_ResourceOverrides(t *testing.T) {
+	clients, manager, _ := initWithExperiment(t)
+	defer clients.Close()
+	server := NewRunServer(manager, &RunServerOptions{CollectMetrics: false})
+	run := newBulkRunV1("run1")
+	run.ResourceOverrides = map[string]*apiv1beta1.ResourceRequirements{
+		"testy": {Limits: map[string]string{"memory": "8Gi"}},
+	}
+	runDetail, err := server.CreateRunV1(nil, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.Nil(t, err)
+
+	runDetail, err = server.GetRunV1(nil, &apiv1beta1.GetRunRequest{RunId: runDetail.Run.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"memory": "8Gi"}, runDetail.Run.ResourceOverrides["testy"].GetLimits())
+}
+
+func TestCreateRunV1_PodMetadata(t *testing.T) {
+	clients, manager, _ := initWithExperiment(t)
+	defer clients.Close()
+	server := NewRunServer(manager, &RunServerOptions{CollectMetrics: false})
+	run := newBulkRunV1("run1")
+	run.PodMetadata = &apiv1beta1.PodMetadata{
+		Labels:      map[string]string{"cost-center": "ml-research"},
+		Annotations: map[string]string{"example.com/owner": "team-a"},
+	}
+	runDetail, err := server.CreateRunV1(nil, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.Nil(t, err)
+
+	runDetail, err = server.GetRunV1(nil, &apiv1beta1.GetRunRequest{RunId: runDetail.Run.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"cost-center": "ml-research"}, runDetail.Run.PodMetadata.GetLabels())
+	assert.Equal(t, map[string]string{"example.com/owner": "team-a"}, runDetail.Run.PodMetadata.GetAnnotations())
+}
+
+func TestCreateRunV1_Scheduling(t *testing.T) {
+	clients, manager, _ := initWithExperiment(t)
+	defer clients.Close()
+	server := NewRunServer(manager, &RunServerOptions{CollectMetrics: false})
+	run := newBulkRunV1("run1")
+	run.NodeSelector = map[string]string{"pool": "gpu"}
+	run.Tolerations = []*apiv1beta1.Toleration{
+		{Key: "nvidia.com/gpu", Operator: "Exists", Effect: "NoExecute", TolerationSeconds: wrapperspb.Int64(60)},
+	}
+	runDetail, err := server.CreateRunV1(nil, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.Nil(t, err)
+
+	runDetail, err = server.GetRunV1(nil, &apiv1beta1.GetRunRequest{RunId: runDetail.Run.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"pool": "gpu"}, runDetail.Run.NodeSelector)
+	require.Len(t, runDetail.Run.Tolerations, 1)
+	assert.Equal(t, "nvidia.com/gpu", runDetail.Run.Tolerations[0].GetKey())
+	assert.Equal(t, "NoExecute", runDetail.Run.Tolerations[0].GetEffect())
+	assert.Equal(t, int64(60), runDetail.Run.Tolerations[0].GetTolerationSeconds().GetValue())
+}
+
+func TestCreateRunV1_PipelineRoot(t *testing.T) {
+	clients, manager, _ := initWithExperiment(t)
+	defer clients.Close()
+	server := NewRunServer(manager, &RunServerOptions{CollectMetrics: false})
+	run := newBulkRunV1("run1")
+	run.PipelineRoot = "gs://team-a/outputs/"
+	runDetail, err := server.CreateRunV1(nil, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.Nil(t, err)
+
+	runDetail, err = server.GetRunV1(nil, &apiv1beta1.GetRunRequest{RunId: runDetail.Run.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, "gs://team-a/outputs", runDetail.Run.PipelineRoot)
+}
+
+func TestCreateRunV1_RetryStrategy(t *testing.T) {
+	clients, manager, _ := initWithExperiment(t)
+	defer clients.Close()
+	server := NewRunServer(manager, &RunServerOptions{CollectMetrics: false})
+	run := newBulkRunV1("run1")
+	run.RetryStrategy = &apiv1beta1.RetryStrategy{
+		Limit:       2,
+		RetryPolicy: "OnFailure",
+		Backoff:     &apiv1beta1.RetryBackoff{Duration: "30s"},
+		Force:       true,
+	}
+	runDetail, err := server.CreateRunV1(nil, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.Nil(t, err)
+
+	runDetail, err = server.GetRunV1(nil, &apiv1beta1.GetRunRequest{RunId: runDetail.Run.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), runDetail.Run.RetryStrategy.GetLimit())
+	assert.Equal(t, "OnFailure", runDetail.Run.RetryStrategy.GetRetryPolicy())
+	assert.Equal(t, "30s", runDetail.Run.RetryStrategy.GetBackoff().GetDuration())
+	assert.True(t, runDetail.Run.RetryStrategy.GetForce())
+}
+
+func TestCreateRunV1_WorkflowUidAndNamespace(t *testing.T) {
+	clients, manager, _ := initWithExperiment(t)
+	defer clients.Close()
+	server := NewRunServer(manager, &RunServerOptions{CollectMetrics: false})
+	runDetail, err := server.CreateRunV1(nil, &apiv1beta1.CreateRunRequest{Run: newBulkRunV1("run1")})
+	assert.Nil(t, err)
+	assert.Equal(t, "workflow1", runDetail.Run.WorkflowUid)
+	assert.Equal(t, "ns1", runDetail.Run.Namespace)
+
+	runDetail, err = server.GetRunV1(nil, &apiv1beta1.GetRunRequest{RunId: runDetail.Run.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, "workflow1", runDetail.Run.WorkflowUid)
+	assert.Equal(t, "ns1", runDetail.Run.Namespace)
+}
+
+func TestGetRun_Labels(t *testing.T) {
+	clients, manager, experiment := initWithExperiment(t)
+	defer clients.Close()
+	server := NewRunServer(manager, &RunServerOptions{CollectMetrics: false})
+	workflow := util.NewWorkflow(testWorkflow.DeepCopy())
+	workflow.Labels = map[string]string{"model-name": "resnet"}
+	run := newBulkRunV1("run1")
+	run.PipelineSpec.WorkflowManifest = workflow.ToStringForStore()
+	runDetail, err := server.CreateRunV1(nil, &apiv1beta1.CreateRunRequest{Run: run})
+	require.Nil(t, err)
+	expectedLabels := map[string]string{"model-name": "resnet"}
+	assert.Equal(t, expectedLabels, runDetail.Run.Labels)
+
+	runDetail, err = server.GetRunV1(nil, &apiv1beta1.GetRunRequest{RunId: runDetail.Run.Id})
+	require.Nil(t, err)
+	assert.Equal(t, expectedLabels, runDetail.Run.Labels)
+
+	apiRun, err := server.GetRun(nil, &apiv2beta1.GetRunRequest{ExperimentId: experiment.UUID, RunId: runDetail.Run.Id})
+	require.Nil(t, err)
+	assert.Equal(t, expectedLabels, apiRun.Labels)
+
+	response, err := server.ListRuns(nil, &apiv2beta1.ListRunsRequest{
+		Filter: `{"predicates": [{"key": "labels.model-name", "op": "EQUALS", "string_value": "resnet"}]}`,
+	})
+	require.Nil(t, err)
+	require.Len(t, response.Runs, 1)
+	assert.Equal(t, expectedLabels, response.Runs[0].Labels)
+}
+
+func newBulkRunV1(name string) *apiv1beta1.Run {
+	return &apiv1beta1.Run{
+		Name:               name,
+		ResourceReferences: validReference,
+		PipelineSpec: &apiv1beta1.PipelineSpec{
+			WorkflowManifest: testWorkflow.ToStringForStore(),
+			Parameters:       []*apiv1beta1.Parameter{{Name: "param1", Value: "world"}},
+		},
+	}
+}
+
 func TestCreateRunV1_RuntimeParams(t *testing.T) {
 	clients, manager, experiment := initWithExperiment(t)
 	defer clients.Close()
@@ -132,7 +347,7 @@ func TestCreateRunV1_RuntimeParams(t *testing.T) {
 			PipelineManifest: v2SpecHelloWorld,
 			RuntimeConfig: &apiv1beta1.PipelineSpec_RuntimeConfig{
 				Parameters:   v2RuntimeParams,
-				PipelineRoot: "model-pipeline-root",
+				PipelineRoot: "gs://model-pipeline-root",
 			},
 		},
 	}
@@ -141,20 +356,22 @@ func TestCreateRunV1_RuntimeParams(t *testing.T) {
 
 	expectedRunDetail := apiv1beta1.RunDetail{
 		Run: &apiv1beta1.Run{
-			Id:             "123e4567-e89b-12d3-a456-426655440000",
-			Name:           "run1",
-			ServiceAccount: "pipeline-runner",
-			StorageState:   apiv1beta1.Run_STORAGESTATE_AVAILABLE,
-			CreatedAt:      &timestamp.Timestamp{Seconds: 2},
-			ScheduledAt:    &timestamp.Timestamp{Seconds: 2},
-			FinishedAt:     &timestamp.Timestamp{},
+			Id:               "123e4567-e89b-12d3-a456-426655440000",
+			Name:             "run1",
+			ServiceAccount:   "pipeline-runner",
+			StorageState:     apiv1beta1.Run_STORAGESTATE_AVAILABLE,
+			CreatedAt:        &timestamp.Timestamp{Seconds: 2},
+			ScheduledAt:      &timestamp.Timestamp{Seconds: 2},
+			StatusSource:     "live",
+			QueueWaitSeconds: 1,
+			FinishedAt:       &timestamp.Timestamp{},
 			// Status:         "Running",
 			Status: "",
 			PipelineSpec: &apiv1beta1.PipelineSpec{
 				PipelineManifest: v2SpecHelloWorld,
 				RuntimeConfig: &apiv1beta1.PipelineSpec_RuntimeConfig{
 					Parameters:   v2RuntimeParams,
-					PipelineRoot: "model-pipeline-root",
+					PipelineRoot: "gs://model-pipeline-root",
 				},
 			},
 			ResourceReferences: []*apiv1beta1.ResourceReference{
@@ -163,6 +380,7 @@ func TestCreateRunV1_RuntimeParams(t *testing.T) {
 					Name: "exp1", Relationship: apiv1beta1.Relationship_OWNER,
 				},
 			},
+			PipelineRoot: "gs://model-pipeline-root",
 		},
 		PipelineRuntime: &apiv1beta1.PipelineRuntime{},
 	}
@@ -205,13 +423,16 @@ func TestCreateRunV1Patch(t *testing.T) {
 
 	expectedRunDetail := apiv1beta1.RunDetail{
 		Run: &apiv1beta1.Run{
-			Id:             "123e4567-e89b-12d3-a456-426655440000",
-			Name:           "run1",
-			ServiceAccount: "pipeline-runner",
-			StorageState:   apiv1beta1.Run_STORAGESTATE_AVAILABLE,
-			CreatedAt:      &timestamp.Timestamp{Seconds: 2},
-			ScheduledAt:    &timestamp.Timestamp{Seconds: 2},
-			FinishedAt:     &timestamp.Timestamp{},
+			Id:               "123e4567-e89b-12d3-a456-426655440000",
+			Name:             "run1",
+			ServiceAccount:   "pipeline-runner",
+			StorageState:     apiv1beta1.Run_STORAGESTATE_AVAILABLE,
+			CreatedAt:        &timestamp.Timestamp{Seconds: 2},
+			ScheduledAt:      &timestamp.Timestamp{Seconds: 2},
+			StatusSource:     "live",
+			WorkflowUid:      "workflow2",
+			QueueWaitSeconds: 1,
+			FinishedAt:       &timestamp.Timestamp{},
 			PipelineSpec: &apiv1beta1.PipelineSpec{
 				WorkflowManifest: testWorkflowPatch.ToStringForStore(),
 				Parameters: []*apiv1beta1.Parameter{
@@ -270,6 +491,311 @@ func TestCreateRunV1_Unauthorized(t *testing.T) {
 	)
 }
 
+func TestCreateRunV1_Multiuser_ServiceAccountUnauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "user@google.com"})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clients, _, _ := initWithExperiment(t)
+	defer clients.Close()
+	clients.SubjectAccessReviewClientFake = client.NewFakeSubjectAccessReviewClientDenyingResources(common.RbacResourceTypeServiceAccounts)
+	server := NewRunServer(resource.NewResourceManager(clients), &RunServerOptions{CollectMetrics: false})
+	run := &apiv1beta1.Run{
+		Name:               "run1",
+		ResourceReferences: validReference,
+		ServiceAccount:     "sa1",
+		PipelineSpec: &apiv1beta1.PipelineSpec{
+			WorkflowManifest: testWorkflow.ToStringForStore(),
+			Parameters:       []*apiv1beta1.Parameter{{Name: "param1", Value: "world"}},
+		},
+	}
+	_, err := server.CreateRunV1(ctx, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.(*util.UserError).ExternalMessage(), "service account sa1")
+	assert.Equal(t, 0, clients.ExecClientFake.GetWorkflowCount())
+
+	// Runs using the default service account don't need the permission.
+	run.ServiceAccount = ""
+	_, err = server.CreateRunV1(ctx, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.Nil(t, err)
+}
+
+func TestCreateRunV1_Multiuser_PriorityClassUnauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+
+	md := metadata.New(map[string]string{
+		common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "user@google.com",
+		common.PriorityClassNameHeader:     "production",
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clients, _, _ := initWithExperiment(t)
+	defer clients.Close()
+	_, err := clients.KubernetesCoreClient().PriorityClassClient().Create(context.Background(), &schedulingv1.PriorityClass{
+		ObjectMeta: v1.ObjectMeta{Name: "production"},
+	}, v1.CreateOptions{})
+	assert.Nil(t, err)
+	clients.SubjectAccessReviewClientFake = client.NewFakeSubjectAccessReviewClientDenyingResources(common.RbacResourceTypePriorityClasses)
+	server := NewRunServer(resource.NewResourceManager(clients), &RunServerOptions{CollectMetrics: false})
+	run := &apiv1beta1.Run{
+		Name:               "run1",
+		ResourceReferences: validReference,
+		PipelineSpec: &apiv1beta1.PipelineSpec{
+			WorkflowManifest: testWorkflow.ToStringForStore(),
+			Parameters:       []*apiv1beta1.Parameter{{Name: "param1", Value: "world"}},
+		},
+	}
+	_, err = server.CreateRunV1(ctx, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.(*util.UserError).ExternalMessage(), "priority class production in namespace ns1")
+	assert.Equal(t, 0, clients.ExecClientFake.GetWorkflowCount())
+
+	clients.SubjectAccessReviewClientFake = client.NewFakeSubjectAccessReviewClient()
+	server = NewRunServer(resource.NewResourceManager(clients), &RunServerOptions{CollectMetrics: false})
+	runDetail, err := server.CreateRunV1(ctx, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.Nil(t, err)
+	stored, err := clients.RunStore().GetRun(runDetail.GetRun().GetId())
+	assert.Nil(t, err)
+	assert.Equal(t, "production", stored.PriorityClassName)
+}
+
+func TestCreateRunV1_Multiuser_PriorityClassNameField(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "user@google.com"})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clients, _, _ := initWithExperiment(t)
+	defer clients.Close()
+	_, err := clients.KubernetesCoreClient().PriorityClassClient().Create(context.Background(), &schedulingv1.PriorityClass{
+		ObjectMeta: v1.ObjectMeta{Name: "production"},
+	}, v1.CreateOptions{})
+	assert.Nil(t, err)
+	clients.SubjectAccessReviewClientFake = client.NewFakeSubjectAccessReviewClientDenyingResources(common.RbacResourceTypePriorityClasses)
+	server := NewRunServer(resource.NewResourceManager(clients), &RunServerOptions{CollectMetrics: false})
+	run := newBulkRunV1("run1")
+	run.PriorityClassName = "production"
+	_, err = server.CreateRunV1(ctx, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+	assert.Equal(t, 0, clients.ExecClientFake.GetWorkflowCount())
+
+	clients.SubjectAccessReviewClientFake = client.NewFakeSubjectAccessReviewClient()
+	server = NewRunServer(resource.NewResourceManager(clients), &RunServerOptions{CollectMetrics: false})
+	runDetail, err := server.CreateRunV1(ctx, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.Nil(t, err)
+	runDetail, err = server.GetRunV1(ctx, &apiv1beta1.GetRunRequest{RunId: runDetail.Run.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, "production", runDetail.Run.PriorityClassName)
+}
+
+func TestCreateRunV1_Multiuser_SecretParameterUnauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "user@google.com"})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clients, _, _ := initWithExperiment(t)
+	defer clients.Close()
+	clients.SubjectAccessReviewClientFake = client.NewFakeSubjectAccessReviewClientDenyingResources(common.RbacResourceTypeSecrets)
+	server := NewRunServer(resource.NewResourceManager(clients), &RunServerOptions{CollectMetrics: false})
+	run := &apiv1beta1.Run{
+		Name:               "run1",
+		ResourceReferences: validReference,
+		PipelineSpec: &apiv1beta1.PipelineSpec{
+			WorkflowManifest: testWorkflow.ToStringForStore(),
+			Parameters:       []*apiv1beta1.Parameter{{Name: "param1", Value: "secretKeyRef://db/password"}},
+		},
+	}
+	_, err := server.CreateRunV1(ctx, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.(*util.UserError).ExternalMessage(), "secret db")
+	assert.Equal(t, 0, clients.ExecClientFake.GetWorkflowCount())
+}
+
+func TestCreateRunV1_Multiuser_EnvSecretUnauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+
+	md := metadata.New(map[string]string{
+		common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "user@google.com",
+		common.RunEnvHeader:                `{"env": [{"name": "TOKEN", "valueFrom": {"secretKeyRef": {"name": "db", "key": "password"}}}]}`,
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clients, _, _ := initWithExperiment(t)
+	defer clients.Close()
+	clients.SubjectAccessReviewClientFake = client.NewFakeSubjectAccessReviewClientDenyingResources(common.RbacResourceTypeSecrets)
+	server := NewRunServer(resource.NewResourceManager(clients), &RunServerOptions{CollectMetrics: false})
+	run := &apiv1beta1.Run{
+		Name:               "run1",
+		ResourceReferences: validReference,
+		PipelineSpec:       &apiv1beta1.PipelineSpec{WorkflowManifest: testWorkflow.ToStringForStore()},
+	}
+	_, err := server.CreateRunV1(ctx, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.(*util.UserError).ExternalMessage(), "environment variable TOKEN")
+	assert.Equal(t, 0, clients.ExecClientFake.GetWorkflowCount())
+}
+
+func TestCreateRunV1_Multiuser_EnvField(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "user@google.com"})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clients, _, _ := initWithExperiment(t)
+	defer clients.Close()
+	clients.SubjectAccessReviewClientFake = client.NewFakeSubjectAccessReviewClientDenyingResources(common.RbacResourceTypeSecrets)
+	server := NewRunServer(resource.NewResourceManager(clients), &RunServerOptions{CollectMetrics: false})
+	run := newBulkRunV1("run1")
+	run.Env = []*apiv1beta1.EnvVar{
+		{Name: "TOKEN", ValueFrom: &apiv1beta1.EnvVarSource{SecretKeyRef: &apiv1beta1.KeySelector{Name: "db", Key: "password"}}},
+	}
+	_, err := server.CreateRunV1(ctx, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+	assert.Equal(t, 0, clients.ExecClientFake.GetWorkflowCount())
+
+	clients.SubjectAccessReviewClientFake = client.NewFakeSubjectAccessReviewClient()
+	server = NewRunServer(resource.NewResourceManager(clients), &RunServerOptions{CollectMetrics: false})
+	runDetail, err := server.CreateRunV1(ctx, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.Nil(t, err)
+	runDetail, err = server.GetRunV1(ctx, &apiv1beta1.GetRunRequest{RunId: runDetail.Run.Id})
+	assert.Nil(t, err)
+	require.Len(t, runDetail.Run.Env, 1)
+	assert.Equal(t, "TOKEN", runDetail.Run.Env[0].GetName())
+	assert.Equal(t, "db", runDetail.Run.Env[0].GetValueFrom().GetSecretKeyRef().GetName())
+	assert.Equal(t, "password", runDetail.Run.Env[0].GetValueFrom().GetSecretKeyRef().GetKey())
+}
+
+func TestCreateRunV1_ServiceAccount(t *testing.T) {
+	clients, manager, _ := initWithExperiment(t)
+	defer clients.Close()
+	server := NewRunServer(manager, &RunServerOptions{CollectMetrics: false})
+	run := &apiv1beta1.Run{
+		Name:               "run1",
+		ResourceReferences: validReference,
+		ServiceAccount:     "sa1",
+		PipelineSpec: &apiv1beta1.PipelineSpec{
+			WorkflowManifest: testWorkflow.ToStringForStore(),
+			Parameters:       []*apiv1beta1.Parameter{{Name: "param1", Value: "world"}},
+		},
+	}
+	runDetail, err := server.CreateRunV1(nil, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.Nil(t, err)
+	assert.Equal(t, "sa1", runDetail.GetRun().GetServiceAccount())
+	assert.Contains(t, runDetail.GetPipelineRuntime().GetWorkflowManifest(), `"serviceAccountName":"sa1"`)
+}
+
+func TestDryRunRunV1(t *testing.T) {
+	clients, manager, _ := initWithExperiment(t)
+	defer clients.Close()
+	server := NewRunServer(manager, &RunServerOptions{CollectMetrics: false})
+	run := &apiv1beta1.Run{
+		Name:               "run1",
+		ResourceReferences: validReference,
+		PipelineSpec: &apiv1beta1.PipelineSpec{
+			WorkflowManifest: testWorkflow.ToStringForStore(),
+			Parameters:       []*apiv1beta1.Parameter{{Name: "param1", Value: "world"}},
+		},
+	}
+	result, err := server.DryRunRunV1(nil, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.Nil(t, err)
+	assert.Contains(t, result.WorkflowManifest, "value: world")
+	assert.Empty(t, result.Diagnostics)
+
+	// Nothing is persisted.
+	runs, err := server.ListRunsV1(nil, &apiv1beta1.ListRunsRequest{})
+	assert.Nil(t, err)
+	assert.Empty(t, runs.Runs)
+}
+
+func TestDryRunRunV1_Unauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+
+	userIdentity := "user@google.com"
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + userIdentity})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clients, manager, _ := initWithExperiment_SubjectAccessReview_Unauthorized(t)
+	defer clients.Close()
+
+	server := NewRunServer(manager, &RunServerOptions{CollectMetrics: false})
+	run := &apiv1beta1.Run{
+		Name:               "run1",
+		ResourceReferences: validReference,
+		PipelineSpec: &apiv1beta1.PipelineSpec{
+			WorkflowManifest: testWorkflow.ToStringForStore(),
+			Parameters:       []*apiv1beta1.Parameter{{Name: "param1", Value: "world"}},
+		},
+	}
+	_, err := server.DryRunRunV1(ctx, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestCreateRunV1_Multiuser_ReferencedPipelineVersionUnauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "user@google.com"})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clients, manager, version := initWithExperimentAndPipelineVersionInOtherNamespace(t)
+	defer clients.Close()
+	server := NewRunServer(manager, &RunServerOptions{CollectMetrics: false})
+	run := &apiv1beta1.Run{
+		Name: "run1",
+		ResourceReferences: []*apiv1beta1.ResourceReference{
+			{
+				Key:          &apiv1beta1.ResourceKey{Type: apiv1beta1.ResourceType_EXPERIMENT, Id: resource.DefaultFakeUUID},
+				Relationship: apiv1beta1.Relationship_OWNER,
+			},
+			{
+				Key:          &apiv1beta1.ResourceKey{Type: apiv1beta1.ResourceType_PIPELINE_VERSION, Id: version.UUID},
+				Relationship: apiv1beta1.Relationship_CREATOR,
+			},
+		},
+		PipelineSpec: &apiv1beta1.PipelineSpec{
+			Parameters: []*apiv1beta1.Parameter{{Name: "param1", Value: "world"}},
+		},
+	}
+	_, err := server.CreateRunV1(ctx, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.(*util.UserError).ExternalMessage(), "PIPELINE_VERSION "+version.UUID)
+	assert.Equal(t, 0, clients.ExecClientFake.GetWorkflowCount())
+}
+
+func TestCreateRunFromPipelineVersion_Multiuser_VersionUnauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "user@google.com"})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clients, manager, version := initWithExperimentAndPipelineVersionInOtherNamespace(t)
+	defer clients.Close()
+	server := NewRunServer(manager, &RunServerOptions{CollectMetrics: false})
+	_, err := server.CreateRunFromPipelineVersion(ctx, version.UUID, resource.DefaultFakeUUID,
+		[]*apiv1beta1.Parameter{{Name: "param1", Value: "world"}})
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.(*util.UserError).ExternalMessage(), "PIPELINE_VERSION "+version.UUID)
+	assert.Equal(t, 0, clients.ExecClientFake.GetWorkflowCount())
+}
+
 func TestCreateRunV1_Multiuser(t *testing.T) {
 	viper.Set(common.MultiUserMode, "true")
 	viper.Set(common.DefaultPipelineRunnerServiceAccountFlag, "default-editor")
@@ -310,14 +836,19 @@ func TestCreateRunV1_Multiuser(t *testing.T) {
 
 	expectedRunDetail := apiv1beta1.RunDetail{
 		Run: &apiv1beta1.Run{
-			Id:             "123e4567-e89b-12d3-a456-426655440000",
-			Name:           "run1",
-			Status:         "Running",
-			ServiceAccount: "default-editor",
-			StorageState:   apiv1beta1.Run_STORAGESTATE_AVAILABLE,
-			CreatedAt:      &timestamp.Timestamp{Seconds: 2},
-			ScheduledAt:    &timestamp.Timestamp{Seconds: 2},
-			FinishedAt:     &timestamp.Timestamp{},
+			Id:               "123e4567-e89b-12d3-a456-426655440000",
+			Name:             "run1",
+			Status:           "Running",
+			ServiceAccount:   "default-editor",
+			StorageState:     apiv1beta1.Run_STORAGESTATE_AVAILABLE,
+			CreatedAt:        &timestamp.Timestamp{Seconds: 2},
+			ScheduledAt:      &timestamp.Timestamp{Seconds: 2},
+			StatusSource:     "live",
+			Namespace:        "ns1",
+			WorkflowUid:      "workflow1",
+			CreatedBy:        "user@google.com",
+			QueueWaitSeconds: 1,
+			FinishedAt:       &timestamp.Timestamp{},
 			PipelineSpec: &apiv1beta1.PipelineSpec{
 				WorkflowManifest: testWorkflow.ToStringForStore(),
 				Parameters:       []*apiv1beta1.Parameter{{Name: "param1", Value: "world"}},
@@ -336,6 +867,40 @@ func TestCreateRunV1_Multiuser(t *testing.T) {
 	assert.Equal(t, expectedRunDetail, *runDetail)
 }
 
+func TestCreateRunV1_Multiuser_NamespaceDefaultExperiment(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "user@google.com"})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clients := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer clients.Close()
+	manager := resource.NewResourceManager(clients)
+	server := NewRunServer(manager, &RunServerOptions{CollectMetrics: false})
+	run := &apiv1beta1.Run{
+		Name: "run1",
+		ResourceReferences: []*apiv1beta1.ResourceReference{
+			{
+				Key:          &apiv1beta1.ResourceKey{Type: apiv1beta1.ResourceType_NAMESPACE, Id: "ns1"},
+				Relationship: apiv1beta1.Relationship_OWNER,
+			},
+		},
+		PipelineSpec: &apiv1beta1.PipelineSpec{
+			WorkflowManifest: testWorkflow.ToStringForStore(),
+			Parameters:       []*apiv1beta1.Parameter{{Name: "param1", Value: "world"}},
+		},
+	}
+	runDetail, err := server.CreateRunV1(ctx, &apiv1beta1.CreateRunRequest{Run: run})
+	assert.Nil(t, err)
+
+	experimentID := common.GetExperimentIDFromAPIResourceReferences(runDetail.GetRun().GetResourceReferences())
+	experiment, err := manager.GetExperiment(experimentID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Default", experiment.Name)
+	assert.Equal(t, "ns1", experiment.Namespace)
+}
+
 func TestCreateRun(t *testing.T) {
 	clients, manager, experiment := initWithExperiment(t)
 	defer clients.Close()
@@ -366,27 +931,29 @@ func TestCreateRun(t *testing.T) {
 		},
 		RuntimeConfig: &apiv2beta1.RuntimeConfig{
 			Parameters:   v2RuntimeParams,
-			PipelineRoot: "model-pipeline-root",
+			PipelineRoot: "gs://model-pipeline-root",
 		},
 	}
 	run, err := server.CreateRun(nil, &apiv2beta1.CreateRunRequest{Run: run})
 	assert.Nil(t, err)
 
 	expectedRun := &apiv2beta1.Run{
-		RunId:          "123e4567-e89b-12d3-a456-426655440000",
-		ExperimentId:   experiment.UUID,
-		DisplayName:    "run1",
-		ServiceAccount: "pipeline-runner",
-		StorageState:   apiv2beta1.Run_AVAILABLE,
-		CreatedAt:      &timestamp.Timestamp{Seconds: 2},
-		ScheduledAt:    &timestamp.Timestamp{Seconds: 2},
-		FinishedAt:     &timestamp.Timestamp{},
+		RunId:            "123e4567-e89b-12d3-a456-426655440000",
+		ExperimentId:     experiment.UUID,
+		DisplayName:      "run1",
+		ServiceAccount:   "pipeline-runner",
+		StorageState:     apiv2beta1.Run_AVAILABLE,
+		CreatedAt:        &timestamp.Timestamp{Seconds: 2},
+		ScheduledAt:      &timestamp.Timestamp{Seconds: 2},
+		StatusSource:     "live",
+		QueueWaitSeconds: 1,
+		FinishedAt:       &timestamp.Timestamp{},
 		PipelineSource: &apiv2beta1.Run_PipelineSpec{
 			PipelineSpec: pipelineSpecStruct,
 		},
 		RuntimeConfig: &apiv2beta1.RuntimeConfig{
 			Parameters:   v2RuntimeParams,
-			PipelineRoot: "model-pipeline-root",
+			PipelineRoot: "gs://model-pipeline-root",
 		},
 	}
 	assert.EqualValues(t, expectedRun, run)
@@ -422,27 +989,29 @@ func TestGetRun(t *testing.T) {
 		},
 		RuntimeConfig: &apiv2beta1.RuntimeConfig{
 			Parameters:   v2RuntimeParams,
-			PipelineRoot: "model-pipeline-root",
+			PipelineRoot: "gs://model-pipeline-root",
 		},
 	}
 	returnedRun, err := server.CreateRun(nil, &apiv2beta1.CreateRunRequest{Run: run})
 	assert.Nil(t, err)
 
 	expectedRun := &apiv2beta1.Run{
-		RunId:          "123e4567-e89b-12d3-a456-426655440000",
-		ExperimentId:   experiment.UUID,
-		DisplayName:    "run1",
-		ServiceAccount: "pipeline-runner",
-		StorageState:   apiv2beta1.Run_AVAILABLE,
-		CreatedAt:      &timestamp.Timestamp{Seconds: 2},
-		ScheduledAt:    &timestamp.Timestamp{Seconds: 2},
-		FinishedAt:     &timestamp.Timestamp{},
+		RunId:            "123e4567-e89b-12d3-a456-426655440000",
+		ExperimentId:     experiment.UUID,
+		DisplayName:      "run1",
+		ServiceAccount:   "pipeline-runner",
+		StorageState:     apiv2beta1.Run_AVAILABLE,
+		CreatedAt:        &timestamp.Timestamp{Seconds: 2},
+		ScheduledAt:      &timestamp.Timestamp{Seconds: 2},
+		StatusSource:     "live",
+		QueueWaitSeconds: 2,
+		FinishedAt:       &timestamp.Timestamp{},
 		PipelineSource: &apiv2beta1.Run_PipelineSpec{
 			PipelineSpec: pipelineSpecStruct,
 		},
 		RuntimeConfig: &apiv2beta1.RuntimeConfig{
 			Parameters:   v2RuntimeParams,
-			PipelineRoot: "model-pipeline-root",
+			PipelineRoot: "gs://model-pipeline-root",
 		},
 	}
 
@@ -467,14 +1036,18 @@ func TestListRunsV1(t *testing.T) {
 	assert.Nil(t, err)
 
 	expectedRun := &apiv1beta1.Run{
-		Id:             "123e4567-e89b-12d3-a456-426655440000",
-		Name:           "run1",
-		ServiceAccount: "pipeline-runner",
-		StorageState:   apiv1beta1.Run_STORAGESTATE_AVAILABLE,
-		CreatedAt:      &timestamp.Timestamp{Seconds: 2},
-		ScheduledAt:    &timestamp.Timestamp{Seconds: 2},
-		FinishedAt:     &timestamp.Timestamp{},
-		Status:         "Running",
+		Id:               "123e4567-e89b-12d3-a456-426655440000",
+		Name:             "run1",
+		ServiceAccount:   "pipeline-runner",
+		StorageState:     apiv1beta1.Run_STORAGESTATE_AVAILABLE,
+		CreatedAt:        &timestamp.Timestamp{Seconds: 2},
+		ScheduledAt:      &timestamp.Timestamp{Seconds: 2},
+		StatusSource:     "live",
+		Namespace:        "ns1",
+		WorkflowUid:      "workflow1",
+		QueueWaitSeconds: 2,
+		FinishedAt:       &timestamp.Timestamp{},
+		Status:           "Running",
 		PipelineSpec: &apiv1beta1.PipelineSpec{
 			WorkflowManifest: testWorkflow.ToStringForStore(),
 			Parameters:       []*apiv1beta1.Parameter{{Name: "param1", Value: "world"}},
@@ -527,6 +1100,66 @@ func TestListRunsV1_Unauthorized(t *testing.T) {
 	)
 }
 
+func TestListRunsV1_Multiuser_CreatedByFilter(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "alice@corp.com"})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clients, manager, _ := initWithExperiment(t)
+	defer clients.Close()
+	server := NewRunServer(manager, &RunServerOptions{CollectMetrics: false})
+	_, err := server.CreateRunV1(ctx, &apiv1beta1.CreateRunRequest{Run: newBulkRunV1("run1")})
+	require.Nil(t, err)
+
+	// Alice also created a run in a namespace the caller can't read.
+	clients.UpdateUUID(util.NewFakeUUIDGeneratorOrFatal(resource.FakeUUIDOne, nil))
+	manager = resource.NewResourceManager(clients)
+	experiment2, err := manager.CreateExperiment(&apiv1beta1.Experiment{
+		Name: "exp2",
+		ResourceReferences: []*apiv1beta1.ResourceReference{
+			{Key: &apiv1beta1.ResourceKey{Type: apiv1beta1.ResourceType_NAMESPACE, Id: "ns2"}, Relationship: apiv1beta1.Relationship_OWNER},
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "ns2", experiment2.Namespace)
+	clients.UpdateUUID(util.NewFakeUUIDGeneratorOrFatal(resource.NonDefaultFakeUUID, nil))
+	server = NewRunServer(resource.NewResourceManager(clients), &RunServerOptions{CollectMetrics: false})
+	workflow2 := util.NewWorkflow(testWorkflow.DeepCopy())
+	workflow2.Namespace = "ns2"
+	run2 := newBulkRunV1("run2")
+	run2.PipelineSpec.WorkflowManifest = workflow2.ToStringForStore()
+	run2.ResourceReferences = []*apiv1beta1.ResourceReference{
+		{Key: &apiv1beta1.ResourceKey{Type: apiv1beta1.ResourceType_EXPERIMENT, Id: experiment2.UUID}, Relationship: apiv1beta1.Relationship_OWNER},
+	}
+	_, err = server.CreateRunV1(ctx, &apiv1beta1.CreateRunRequest{Run: run2})
+	require.Nil(t, err)
+
+	createdByAlice := `{"predicates": [{"key": "created_by", "op": "EQUALS", "string_value": "alice@corp.com"}]}`
+	response, err := server.ListRunsV1(ctx, &apiv1beta1.ListRunsRequest{
+		ResourceReferenceKey: &apiv1beta1.ResourceKey{Type: apiv1beta1.ResourceType_NAMESPACE, Id: "ns1"},
+		Filter:               createdByAlice,
+	})
+	require.Nil(t, err)
+	require.Len(t, response.Runs, 1)
+	assert.Equal(t, "run1", response.Runs[0].Name)
+	assert.Equal(t, "alice@corp.com", response.Runs[0].CreatedBy)
+	assert.Equal(t, int32(1), response.TotalSize)
+
+	// The filter can't reach across namespaces.
+	_, err = server.ListRunsV1(ctx, &apiv1beta1.ListRunsRequest{Filter: createdByAlice})
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+
+	clients.SubjectAccessReviewClientFake = client.NewFakeSubjectAccessReviewClientDenyingResources(common.RbacResourceTypeRuns)
+	server = NewRunServer(resource.NewResourceManager(clients), &RunServerOptions{CollectMetrics: false})
+	_, err = server.ListRunsV1(ctx, &apiv1beta1.ListRunsRequest{
+		ResourceReferenceKey: &apiv1beta1.ResourceKey{Type: apiv1beta1.ResourceType_NAMESPACE, Id: "ns2"},
+		Filter:               createdByAlice,
+	})
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+}
+
 func TestListRunsV1_Multiuser(t *testing.T) {
 	viper.Set(common.MultiUserMode, "true")
 	defer viper.Set(common.MultiUserMode, "false")
@@ -555,6 +1188,10 @@ func TestListRunsV1_Multiuser(t *testing.T) {
 		StorageState:   apiv1beta1.Run_STORAGESTATE_AVAILABLE,
 		CreatedAt:      &timestamp.Timestamp{Seconds: 2},
 		ScheduledAt:    &timestamp.Timestamp{Seconds: 2},
+		StatusSource:   "live",
+		Namespace:      "ns1",
+		WorkflowUid:    "workflow1",
+		CreatedBy:      "user@google.com",
 		FinishedAt:     &timestamp.Timestamp{},
 		Status:         "Running",
 		PipelineSpec: &apiv1beta1.PipelineSpec{
@@ -646,7 +1283,7 @@ func TestListRunsV1_Multiuser(t *testing.T) {
 		} else {
 			if err != nil {
 				t.Errorf("TestListRuns_Multiuser(%v) expect no error but got %v", tc.name, err)
-			} else if !cmp.Equal(tc.expectedRuns, response.Runs, cmpopts.EquateEmpty(), protocmp.Transform(), cmpopts.IgnoreFields(apiv1beta1.Run{}, "ScheduledAt", "FinishedAt", "CreatedAt")) {
+			} else if !cmp.Equal(tc.expectedRuns, response.Runs, cmpopts.EquateEmpty(), protocmp.Transform(), cmpopts.IgnoreFields(apiv1beta1.Run{}, "ScheduledAt", "FinishedAt", "CreatedAt"), protocmp.IgnoreFields(&apiv1beta1.Run{}, "queue_wait_seconds")) {
 				t.Errorf("TestListRuns_Multiuser(%v) expect (%+v) but got (%+v)", tc.name, tc.expectedRuns, response.Runs)
 			}
 		}
@@ -668,26 +1305,28 @@ func TestListRuns(t *testing.T) {
 			PipelineSpec: pipelineSpecStruct,
 		},
 		RuntimeConfig: &apiv2beta1.RuntimeConfig{
-			PipelineRoot: "model-pipeline-root",
+			PipelineRoot: "gs://model-pipeline-root",
 		},
 	}
 	_, err := server.CreateRun(nil, &apiv2beta1.CreateRunRequest{Run: run})
 	assert.Nil(t, err)
 
 	expectedRun := &apiv2beta1.Run{
-		RunId:          "123e4567-e89b-12d3-a456-426655440000",
-		ExperimentId:   experiment.UUID,
-		DisplayName:    "run1",
-		ServiceAccount: "pipeline-runner",
-		StorageState:   apiv2beta1.Run_AVAILABLE,
-		CreatedAt:      &timestamp.Timestamp{Seconds: 2},
-		ScheduledAt:    &timestamp.Timestamp{Seconds: 2},
-		FinishedAt:     &timestamp.Timestamp{},
+		RunId:            "123e4567-e89b-12d3-a456-426655440000",
+		ExperimentId:     experiment.UUID,
+		DisplayName:      "run1",
+		ServiceAccount:   "pipeline-runner",
+		StorageState:     apiv2beta1.Run_AVAILABLE,
+		CreatedAt:        &timestamp.Timestamp{Seconds: 2},
+		ScheduledAt:      &timestamp.Timestamp{Seconds: 2},
+		StatusSource:     "live",
+		QueueWaitSeconds: 2,
+		FinishedAt:       &timestamp.Timestamp{},
 		PipelineSource: &apiv2beta1.Run_PipelineSpec{
 			PipelineSpec: pipelineSpecStruct,
 		},
 		RuntimeConfig: &apiv2beta1.RuntimeConfig{
-			PipelineRoot: "model-pipeline-root",
+			PipelineRoot: "gs://model-pipeline-root",
 		},
 	}
 
@@ -856,13 +1495,24 @@ func TestvalidateCreateRunRequest(t *testing.T) {
 			PipelineSpec: pipelineSpecStruct,
 		},
 		RuntimeConfig: &apiv2beta1.RuntimeConfig{
-			PipelineRoot: "model-pipeline-root",
+			PipelineRoot: "gs://model-pipeline-root",
 		},
 	}
 	err := server.validateCreateRunRequest(&apiv2beta1.CreateRunRequest{Run: run})
 	assert.Nil(t, err)
 }
 
+func TestRetryRunV1_Restart(t *testing.T) {
+	clientManager, resourceManager, runDetail := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	runServer := RunServer{resourceManager: resourceManager, options: &RunServerOptions{CollectMetrics: false}}
+
+	// Only failed runs can be restarted.
+	_, err := runServer.RetryRunV1(context.Background(), &apiv1beta1.RetryRunRequest{RunId: runDetail.UUID, Restart: true})
+	AssertUserError(t, err, codes.FailedPrecondition)
+	assert.Contains(t, err.Error(), "must be Failed/Error to be restarted")
+}
+
 func TestReportRunMetricsV1_RunNotFound(t *testing.T) {
 	httpServer := getMockServer(t)
 	// Close the server when test finishes
@@ -911,6 +1561,178 @@ func TestReportRunMetricsV1_Succeed(t *testing.T) {
 	assert.Equal(t, []*apiv1beta1.RunMetric{metricV1}, run.GetRun().GetMetrics())
 }
 
+func TestDeleteRuns(t *testing.T) {
+	clientManager, resourceManager, runDetails := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	runServer := RunServer{resourceManager: resourceManager, options: &RunServerOptions{CollectMetrics: false}}
+
+	failures, err := runServer.DeleteRuns(context.Background(), []string{runDetails.UUID, "missing"})
+	assert.Nil(t, err)
+	assert.Empty(t, failures)
+	_, err = resourceManager.GetRun(runDetails.UUID)
+	AssertUserError(t, err, codes.NotFound)
+}
+
+func TestDeleteRuns_Unauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+	userIdentity := "user@google.com"
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + userIdentity})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clientManager, resourceManager, runDetails := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	clientManager.SubjectAccessReviewClientFake = client.NewFakeSubjectAccessReviewClientUnauthorized()
+	resourceManager = resource.NewResourceManager(clientManager)
+	runServer := RunServer{resourceManager: resourceManager, options: &RunServerOptions{CollectMetrics: false}}
+
+	failures, err := runServer.DeleteRuns(ctx, []string{runDetails.UUID, "missing"})
+	assert.Nil(t, err)
+	assert.Len(t, failures, 1)
+	AssertUserError(t, failures[runDetails.UUID], codes.PermissionDenied)
+	_, err = resourceManager.GetRun(runDetails.UUID)
+	assert.Nil(t, err)
+}
+
+func TestTerminateJobRuns_Unauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + "user@google.com"})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clientManager, resourceManager, experiment := initWithExperiment(t)
+	defer clientManager.Close()
+	job, err := resourceManager.CreateJob(ctx, &apiv1beta1.Job{
+		Name:         "job1",
+		Enabled:      true,
+		PipelineSpec: &apiv1beta1.PipelineSpec{WorkflowManifest: testWorkflow.ToStringForStore()},
+		ResourceReferences: []*apiv1beta1.ResourceReference{
+			{
+				Key:          &apiv1beta1.ResourceKey{Type: apiv1beta1.ResourceType_EXPERIMENT, Id: experiment.UUID},
+				Relationship: apiv1beta1.Relationship_OWNER,
+			},
+		},
+	})
+	assert.Nil(t, err)
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "run1",
+			Namespace: "ns1",
+			UID:       "run1",
+			Labels:    map[string]string{util.LabelKeyWorkflowRunId: "run1"},
+			OwnerReferences: []v1.OwnerReference{{
+				APIVersion: "kubeflow.org/v1beta1",
+				Kind:       "ScheduledWorkflow",
+				Name:       "SCHEDULE_NAME",
+				UID:        types.UID(job.UUID),
+			}},
+		},
+		Status: v1alpha1.WorkflowStatus{Phase: v1alpha1.WorkflowRunning},
+	})
+	_, err = clientManager.ExecClientFake.Execution("ns1").Create(context.Background(), workflow, v1.CreateOptions{})
+	assert.Nil(t, err)
+	err = resourceManager.ReportWorkflowResource(context.Background(), workflow)
+	assert.Nil(t, err)
+
+	clientManager.SubjectAccessReviewClientFake = client.NewFakeSubjectAccessReviewClientUnauthorized()
+	resourceManager = resource.NewResourceManager(clientManager)
+	runServer := RunServer{resourceManager: resourceManager, options: &RunServerOptions{CollectMetrics: false}}
+
+	results, err := runServer.TerminateJobRuns(ctx, job.UUID)
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+	AssertUserError(t, results["run1"], codes.PermissionDenied)
+	runDetail, err := resourceManager.GetRun("run1")
+	assert.Nil(t, err)
+	assert.Equal(t, "Running", runDetail.Conditions)
+}
+
+func TestGetRunManifest(t *testing.T) {
+	clientManager, resourceManager, runDetails := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	runServer := RunServer{resourceManager: resourceManager, options: &RunServerOptions{CollectMetrics: false}}
+
+	manifest, err := runServer.GetRunManifest(context.Background(), runDetails.UUID)
+	assert.Nil(t, err)
+	assert.Contains(t, manifest, "kind: Workflow")
+	assert.Contains(t, manifest, "value: world")
+}
+
+func TestGetRunManifest_Unauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+	userIdentity := "user@google.com"
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + userIdentity})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clientManager, resourceManager, runDetails := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	clientManager.SubjectAccessReviewClientFake = client.NewFakeSubjectAccessReviewClientUnauthorized()
+	resourceManager = resource.NewResourceManager(clientManager)
+	runServer := RunServer{resourceManager: resourceManager, options: &RunServerOptions{CollectMetrics: false}}
+
+	_, err := runServer.GetRunManifest(ctx, runDetails.UUID)
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestUpdateRunName_Unauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+	userIdentity := "user@google.com"
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + userIdentity})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clientManager, resourceManager, runDetails := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	clientManager.SubjectAccessReviewClientFake = client.NewFakeSubjectAccessReviewClientUnauthorized()
+	resourceManager = resource.NewResourceManager(clientManager)
+	runServer := RunServer{resourceManager: resourceManager, options: &RunServerOptions{CollectMetrics: false}}
+
+	err := runServer.UpdateRunName(ctx, runDetails.UUID, "renamed")
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+	run, err := resourceManager.GetRun(runDetails.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, runDetails.DisplayName, run.DisplayName)
+}
+
+func TestGetRunLogs_Unauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+	userIdentity := "user@google.com"
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + userIdentity})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clientManager, resourceManager, runDetails := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	clientManager.SubjectAccessReviewClientFake = client.NewFakeSubjectAccessReviewClientUnauthorized()
+	resourceManager = resource.NewResourceManager(clientManager)
+	runServer := RunServer{resourceManager: resourceManager, options: &RunServerOptions{CollectMetrics: false}}
+
+	_, _, err := runServer.GetRunLogs(ctx, runDetails.UUID, "node")
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestGetRunArtifacts_Unauthorized(t *testing.T) {
+	viper.Set(common.MultiUserMode, "true")
+	defer viper.Set(common.MultiUserMode, "false")
+	userIdentity := "user@google.com"
+	md := metadata.New(map[string]string{common.GoogleIAPUserIdentityHeader: common.GoogleIAPUserIdentityPrefix + userIdentity})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	clientManager, resourceManager, runDetails := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	clientManager.SubjectAccessReviewClientFake = client.NewFakeSubjectAccessReviewClientUnauthorized()
+	resourceManager = resource.NewResourceManager(clientManager)
+	runServer := RunServer{resourceManager: resourceManager, options: &RunServerOptions{CollectMetrics: false}}
+
+	_, err := runServer.GetRunArtifacts(ctx, runDetails.UUID)
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+}
+
 func TestReportRunMetricsV1_Unauthorized(t *testing.T) {
 	viper.Set(common.MultiUserMode, "true")
 	defer viper.Set(common.MultiUserMode, "false")
@@ -993,6 +1815,70 @@ func TestReportRunMetricsV1_PartialFailures(t *testing.T) {
 	assert.Equal(t, expectedResponse, response)
 }
 
+func TestReportRunMetricsV1_DuplicatesInRequest(t *testing.T) {
+	clientManager, resourceManager, runDetail := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	runServer := RunServer{resourceManager: resourceManager, options: &RunServerOptions{CollectMetrics: false}}
+
+	duplicateMetric := &apiv1beta1.RunMetric{
+		Name:   metricV1.Name,
+		NodeId: metricV1.NodeId,
+		Value:  &apiv1beta1.RunMetric_NumberValue{NumberValue: 0.99},
+		Format: apiv1beta1.RunMetric_RAW,
+	}
+	otherMetric := &apiv1beta1.RunMetric{
+		Name:   "metric-2",
+		NodeId: metricV1.NodeId,
+		Value:  &apiv1beta1.RunMetric_NumberValue{NumberValue: 0.5},
+		Format: apiv1beta1.RunMetric_RAW,
+	}
+	response, err := runServer.ReportRunMetricsV1(context.Background(), &apiv1beta1.ReportRunMetricsRequest{
+		RunId:   runDetail.UUID,
+		Metrics: []*apiv1beta1.RunMetric{metricV1, duplicateMetric, otherMetric},
+	})
+	assert.Nil(t, err)
+	statuses := []apiv1beta1.ReportRunMetricsResponse_ReportRunMetricResult_Status{}
+	for _, result := range response.Results {
+		statuses = append(statuses, result.Status)
+	}
+	assert.Equal(t, []apiv1beta1.ReportRunMetricsResponse_ReportRunMetricResult_Status{
+		apiv1beta1.ReportRunMetricsResponse_ReportRunMetricResult_INVALID_ARGUMENT,
+		apiv1beta1.ReportRunMetricsResponse_ReportRunMetricResult_INVALID_ARGUMENT,
+		apiv1beta1.ReportRunMetricsResponse_ReportRunMetricResult_OK,
+	}, statuses)
+	assert.Contains(t, response.Results[0].Message, "reported more than once")
+
+	run, err := resourceManager.GetRun(runDetail.UUID)
+	assert.Nil(t, err)
+	assert.Len(t, run.Metrics, 1)
+	assert.Equal(t, "metric-2", run.Metrics[0].Name)
+}
+
+func TestReportRunMetricsV1_LastWriteWins(t *testing.T) {
+	clientManager, resourceManager, runDetail := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	runServer := RunServer{resourceManager: resourceManager, options: &RunServerOptions{CollectMetrics: false}}
+
+	for _, value := range []float64{0.5, 0.99} {
+		response, err := runServer.ReportRunMetricsV1(context.Background(), &apiv1beta1.ReportRunMetricsRequest{
+			RunId: runDetail.UUID,
+			Metrics: []*apiv1beta1.RunMetric{{
+				Name:   metricV1.Name,
+				NodeId: metricV1.NodeId,
+				Value:  &apiv1beta1.RunMetric_NumberValue{NumberValue: value},
+				Format: apiv1beta1.RunMetric_RAW,
+			}},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, apiv1beta1.ReportRunMetricsResponse_ReportRunMetricResult_OK, response.Results[0].Status)
+	}
+
+	run, err := resourceManager.GetRun(runDetail.UUID)
+	assert.Nil(t, err)
+	assert.Len(t, run.Metrics, 1)
+	assert.Equal(t, 0.99, run.Metrics[0].NumberValue)
+}
+
 func TestCanAccessRun_Unauthorized(t *testing.T) {
 	viper.Set(common.MultiUserMode, "true")
 	defer viper.Set(common.MultiUserMode, "false")
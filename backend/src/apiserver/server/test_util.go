@@ -177,7 +177,7 @@ func initWithExperimentAndPipelineVersion(t *testing.T) (*resource.FakeClientMan
 	assert.Nil(t, err)
 
 	// Create a pipeline and then a pipeline version.
-	_, err = resourceManager.CreatePipeline("pipeline", "", "", []byte(testWorkflow.ToStringForStore()))
+	_, err = resourceManager.CreatePipeline("pipeline", "", "", nil, []byte(testWorkflow.ToStringForStore()))
 	assert.Nil(t, err)
 	clientManager.UpdateUUID(util.NewFakeUUIDGeneratorOrFatal(resource.NonDefaultFakeUUID, nil))
 	_, err = resourceManager.CreatePipelineVersion(&api.PipelineVersion{
@@ -197,6 +197,29 @@ func initWithExperimentAndPipelineVersion(t *testing.T) (*resource.FakeClientMan
 	return clientManager, resourceManager, experiment
 }
 
+// initWithExperimentAndPipelineVersionInOtherNamespace creates an experiment in namespace ns1 and a pipeline
+// owned by namespace ns2, and returns the pipeline's default version. Only requests in ns1 are authorized.
+func initWithExperimentAndPipelineVersionInOtherNamespace(t *testing.T) (*resource.FakeClientManager, *resource.ResourceManager, *model.PipelineVersion) {
+	initEnvVars()
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	clientManager.SubjectAccessReviewClientFake = client.NewFakeSubjectAccessReviewClientForNamespaces("ns1")
+	resourceManager := resource.NewResourceManager(clientManager)
+	_, err := resourceManager.CreateExperiment(&api.Experiment{
+		Name: "exp1",
+		ResourceReferences: []*api.ResourceReference{
+			{
+				Key:          &api.ResourceKey{Type: api.ResourceType_NAMESPACE, Id: "ns1"},
+				Relationship: api.Relationship_OWNER,
+			},
+		},
+	})
+	assert.Nil(t, err)
+	pipeline, err := resourceManager.CreatePipeline("pipeline", "", "ns2", nil, []byte(testWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	version := pipeline.DefaultVersion
+	return clientManager, resourceManager, version
+}
+
 func initWithExperimentsAndTwoPipelineVersions(t *testing.T) *resource.FakeClientManager {
 	initEnvVars()
 	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
@@ -208,7 +231,7 @@ func initWithExperimentsAndTwoPipelineVersions(t *testing.T) *resource.FakeClien
 	assert.Nil(t, err)
 
 	// Create a pipeline and then a pipeline version.
-	_, err = resourceManager.CreatePipeline("pipeline", "", "", []byte("apiVersion: argoproj.io/v1alpha1\nkind: Workflow"))
+	_, err = resourceManager.CreatePipeline("pipeline", "", "", nil, []byte("apiVersion: argoproj.io/v1alpha1\nkind: Workflow"))
 	assert.Nil(t, err)
 	clientManager.UpdateUUID(util.NewFakeUUIDGeneratorOrFatal("123e4567-e89b-12d3-a456-426655441001", nil))
 	resourceManager = resource.NewResourceManager(clientManager)
@@ -229,7 +252,7 @@ func initWithExperimentsAndTwoPipelineVersions(t *testing.T) *resource.FakeClien
 	clientManager.UpdateUUID(util.NewFakeUUIDGeneratorOrFatal(resource.NonDefaultFakeUUID, nil))
 	resourceManager = resource.NewResourceManager(clientManager)
 	// Create another pipeline and then pipeline version.
-	_, err = resourceManager.CreatePipeline("anpther-pipeline", "", "", []byte("apiVersion: argoproj.io/v1alpha1\nkind: Workflow"))
+	_, err = resourceManager.CreatePipeline("anpther-pipeline", "", "", nil, []byte("apiVersion: argoproj.io/v1alpha1\nkind: Workflow"))
 	assert.Nil(t, err)
 
 	clientManager.UpdateUUID(util.NewFakeUUIDGeneratorOrFatal("123e4567-e89b-12d3-a456-426655441002", nil))
@@ -284,7 +307,7 @@ func initWithPipeline(t *testing.T) (*resource.FakeClientManager, *resource.Reso
 	initEnvVars()
 	store := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
 	manager := resource.NewResourceManager(store)
-	p, err := manager.CreatePipeline("p1", "", "", []byte(testWorkflow.ToStringForStore()))
+	p, err := manager.CreatePipeline("p1", "", "", nil, []byte(testWorkflow.ToStringForStore()))
 	assert.Nil(t, err)
 	return store, manager, p
 }
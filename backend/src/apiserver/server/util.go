@@ -36,8 +36,11 @@ import (
 	apiv2beta1 "github.com/kubeflow/pipelines/backend/api/v2beta1/go_client"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/template"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	authorizationv1 "k8s.io/api/authorization/v1"
 )
 
@@ -187,6 +190,25 @@ func ReadPipelineFile(fileName string, fileReader io.Reader, maxFileLength int)
 		return nil, util.Wrap(err, "Error read pipeline file.")
 	}
 
+	return processPipelineFile(fileName, pipelineFileBytes)
+}
+
+// ReadPipelineVersionFile reads a pipeline version file the way ReadPipelineFile does, except that zip files
+// and tarballs are kept as they are, for creating the pipeline version to assemble its manifest from the
+// whole archive.
+func ReadPipelineVersionFile(fileName string, fileReader io.Reader, maxFileLength int) ([]byte, error) {
+	pipelineFileBytes, err := loadFile(fileReader, maxFileLength)
+	if err != nil {
+		return nil, util.Wrap(err, "Error read pipeline file.")
+	}
+	if !isYamlFile(fileName) && !isJSONFile(fileName) && template.IsArchive(pipelineFileBytes) {
+		return pipelineFileBytes, nil
+	}
+	return processPipelineFile(fileName, pipelineFileBytes)
+}
+
+func processPipelineFile(fileName string, pipelineFileBytes []byte) ([]byte, error) {
+	var err error
 	var processedFile []byte
 	switch {
 	case isYamlFile(fileName):
@@ -400,13 +422,18 @@ func getPipelineVersionIdFromResourceReferences(resourceManager *resource.Resour
 // isAuthorized verifies whether the user identity, which is contained in the context object,
 // can perform some action (verb) on a resource (resourceType/resourceName) living in the
 // target namespace. If the returned error is nil, the authorization passes. Otherwise,
-// authorization fails with a non-nil error.
+// authorization fails with a non-nil error. A request that impersonates a namespace must come
+// from an admin identity, and is only authorized against the impersonated namespace.
 func isAuthorized(resourceManager *resource.ResourceManager, ctx context.Context, resourceAttributes *authorizationv1.ResourceAttributes) error {
 	if common.IsMultiUserMode() == false {
 		// Skip authz if not multi-user mode.
 		return nil
 	}
-	if common.IsMultiUserSharedReadMode() &&
+	impersonatedNamespace, err := getImpersonatedNamespace(ctx)
+	if err != nil {
+		return err
+	}
+	if impersonatedNamespace == "" && common.IsMultiUserSharedReadMode() &&
 		(resourceAttributes.Verb == common.RbacResourceVerbGet ||
 			resourceAttributes.Verb == common.RbacResourceVerbList) {
 		glog.Infof("Multi-user shared read mode is enabled. Request allowed: %+v", resourceAttributes)
@@ -423,6 +450,21 @@ func isAuthorized(resourceManager *resource.ResourceManager, ctx context.Context
 		return util.NewUnauthenticatedError(errors.New("Request header error: user identity is empty."), "Request header error: user identity is empty.")
 	}
 
+	if impersonatedNamespace != "" {
+		// Impersonated requests are authorized against the impersonated namespace only.
+		if !common.IsImpersonationAdmin(userIdentity) {
+			return util.NewPermissionDeniedError(errors.New("impersonation not allowed"),
+				"User '%s' is not allowed to use the %s header", userIdentity, common.ImpersonateNamespaceHeader)
+		}
+		if resourceAttributes.Namespace != "" && resourceAttributes.Namespace != impersonatedNamespace {
+			return util.NewPermissionDeniedError(errors.New("namespace mismatch"),
+				"Namespace '%s' of the request doesn't match the impersonated namespace '%s'",
+				resourceAttributes.Namespace, impersonatedNamespace)
+		}
+		resourceAttributes.Namespace = impersonatedNamespace
+		glog.Infof("Admin '%s' is impersonating namespace '%s'", userIdentity, impersonatedNamespace)
+	}
+
 	glog.Infof("User: %s, ResourceAttributes: %+v", userIdentity, resourceAttributes)
 	glog.Info("Authorizing request...")
 	err = resourceManager.IsRequestAuthorized(ctx, userIdentity, resourceAttributes)
@@ -434,3 +476,202 @@ func isAuthorized(resourceManager *resource.ResourceManager, ctx context.Context
 	glog.Infof("Authorized user '%s': %+v", userIdentity, resourceAttributes)
 	return nil
 }
+
+// getImpersonatedNamespace returns the namespace named by the impersonation header of the request, or an
+// empty string if the request doesn't impersonate a namespace.
+func getImpersonatedNamespace(ctx context.Context) (string, error) {
+	if ctx == nil {
+		return "", nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(common.ImpersonateNamespaceHeader)
+	switch len(values) {
+	case 0:
+		return "", nil
+	case 1:
+		return strings.TrimSpace(values[0]), nil
+	default:
+		return "", util.NewBadRequestError(errors.New("multiple impersonation headers"),
+			"Request header error: unexpected number of '%s' headers. Expect 1 got %d", common.ImpersonateNamespaceHeader, len(values))
+	}
+}
+
+// toTemplateFormat returns the template format of a GetPipelineVersionTemplate request. The names of the
+// API formats are the template formats.
+func toTemplateFormat(format apiv1beta1.GetPipelineVersionTemplateRequest_TemplateFormat) template.TemplateFormat {
+	return template.TemplateFormat(format.String())
+}
+
+// stampImpersonatedNamespaceV1 replaces the namespace owner reference of a resource created by an
+// impersonated request with the impersonated namespace. The references are returned unchanged if the
+// request doesn't impersonate a namespace.
+func stampImpersonatedNamespaceV1(ctx context.Context, references []*apiv1beta1.ResourceReference) ([]*apiv1beta1.ResourceReference, error) {
+	if !common.IsMultiUserMode() {
+		return references, nil
+	}
+	namespace, err := getImpersonatedNamespace(ctx)
+	if err != nil || namespace == "" {
+		return references, err
+	}
+	stamped := []*apiv1beta1.ResourceReference{{
+		Key:          &apiv1beta1.ResourceKey{Type: apiv1beta1.ResourceType_NAMESPACE, Id: namespace},
+		Relationship: apiv1beta1.Relationship_OWNER,
+	}}
+	for _, reference := range references {
+		if reference.GetKey().GetType() != apiv1beta1.ResourceType_NAMESPACE {
+			stamped = append(stamped, reference)
+		}
+	}
+	return stamped, nil
+}
+
+// stampImpersonatedNamespace returns the impersonated namespace of the request as the namespace of a
+// resource that it creates, or the given namespace if the request doesn't impersonate a namespace.
+func stampImpersonatedNamespace(ctx context.Context, namespace string) (string, error) {
+	if !common.IsMultiUserMode() {
+		return namespace, nil
+	}
+	impersonatedNamespace, err := getImpersonatedNamespace(ctx)
+	if err != nil || impersonatedNamespace == "" {
+		return namespace, err
+	}
+	return impersonatedNamespace, nil
+}
+
+// canAccessResourceReferences verifies, in multi-user mode, that the user can read every resource referenced
+// by a run or a job, in the namespace that owns the resource. Namespace references and shared resources,
+// i.e. resources without a namespace, are not checked.
+// namespaceOfExperimentOrReference returns the namespace of the experiment a v1 run or job references. Without
+// an experiment reference, it returns the namespace referenced directly, whose default experiment is then used.
+func namespaceOfExperimentOrReference(resourceManager *resource.ResourceManager, references []*apiv1beta1.ResourceReference) (string, error) {
+	experimentID := common.GetExperimentIDFromAPIResourceReferences(references)
+	if experimentID == "" {
+		return common.GetNamespaceFromAPIResourceReferences(references), nil
+	}
+	return resourceManager.GetNamespaceFromExperimentID(experimentID)
+}
+
+func canAccessResourceReferences(resourceManager *resource.ResourceManager, ctx context.Context, references []*apiv1beta1.ResourceReference) error {
+	for _, reference := range references {
+		if reference.GetKey() == nil {
+			continue
+		}
+		err := canAccessReferencedResource(resourceManager, ctx, reference.GetKey().GetType(), reference.GetKey().GetId())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// canUsePriorityClass verifies, in multi-user mode, that the namespace of a run is allowed to use the priority
+// class a CreateRun request sets, i.e. that the user may "use" the priorityclasses resource with that name in
+// the namespace.
+func canUsePriorityClass(resourceManager *resource.ResourceManager, ctx context.Context, run interface{}, namespace string) error {
+	if !common.IsMultiUserMode() {
+		return nil
+	}
+	priorityClassName, err := resource.PriorityClassNameOf(ctx, run)
+	if err != nil || priorityClassName == "" {
+		return err
+	}
+	resourceAttributes := &authorizationv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      common.RbacResourceVerbUse,
+		Group:     common.RbacSchedulingGroup,
+		Version:   "v1",
+		Resource:  common.RbacResourceTypePriorityClasses,
+		Name:      priorityClassName,
+	}
+	err = isAuthorized(resourceManager, ctx, resourceAttributes)
+	if util.IsUserErrorCodeMatch(err, codes.PermissionDenied) {
+		return util.NewPermissionDeniedError(err,
+			"Not authorized to use priority class %v in namespace %v", priorityClassName, namespace)
+	}
+	if err != nil {
+		return util.Wrapf(err, "Failed to authorize the priority class %v", priorityClassName)
+	}
+	return nil
+}
+
+// canReadEnvSecrets verifies, in multi-user mode, that the user may read the secrets of the namespace that the
+// environment variables a CreateRun request adds are read from.
+func canReadEnvSecrets(resourceManager *resource.ResourceManager, ctx context.Context, run interface{}, namespace string) error {
+	if !common.IsMultiUserMode() {
+		return nil
+	}
+	runEnv, err := resource.RunEnvOf(ctx, run)
+	if err != nil || runEnv == nil {
+		return err
+	}
+	for _, envVar := range runEnv.Env {
+		if envVar.ValueFrom == nil || envVar.ValueFrom.SecretKeyRef == nil {
+			continue
+		}
+		secretName := envVar.ValueFrom.SecretKeyRef.Name
+		resourceAttributes := &authorizationv1.ResourceAttributes{
+			Namespace: namespace,
+			Verb:      common.RbacResourceVerbGet,
+			Version:   "v1",
+			Resource:  common.RbacResourceTypeSecrets,
+			Name:      secretName,
+		}
+		err = isAuthorized(resourceManager, ctx, resourceAttributes)
+		if util.IsUserErrorCodeMatch(err, codes.PermissionDenied) {
+			return util.NewPermissionDeniedError(err,
+				"Not authorized to read secret %v in namespace %v, referenced by environment variable %v", secretName, namespace, envVar.Name)
+		}
+		if err != nil {
+			return util.Wrapf(err, "Failed to authorize the secret %v", secretName)
+		}
+	}
+	return nil
+}
+
+// canAccessReferencedResource verifies, in multi-user mode, that the user can read a referenced resource in
+// the namespace that owns it. A permission-denied error names the rejected reference.
+func canAccessReferencedResource(resourceManager *resource.ResourceManager, ctx context.Context, resourceType apiv1beta1.ResourceType, id string) error {
+	if !common.IsMultiUserMode() || id == "" {
+		return nil
+	}
+	var namespace, rbacResourceType string
+	var err error
+	switch resourceType {
+	case apiv1beta1.ResourceType_EXPERIMENT:
+		namespace, err = resourceManager.GetNamespaceFromExperimentID(id)
+		rbacResourceType = common.RbacResourceTypeExperiments
+	case apiv1beta1.ResourceType_JOB:
+		namespace, err = resourceManager.GetNamespaceFromJobID(id)
+		rbacResourceType = common.RbacResourceTypeJobs
+	case apiv1beta1.ResourceType_PIPELINE:
+		namespace, err = resourceManager.GetNamespaceFromPipelineID(id)
+		rbacResourceType = common.RbacResourceTypePipelines
+	case apiv1beta1.ResourceType_PIPELINE_VERSION:
+		namespace, err = resourceManager.GetNamespaceFromPipelineVersion(id)
+		rbacResourceType = common.RbacResourceTypePipelines
+	default:
+		return nil
+	}
+	if err != nil {
+		return util.Wrapf(err, "Failed to get the namespace of the referenced %v %v", resourceType, id)
+	}
+	if namespace == "" {
+		return nil
+	}
+	resourceAttributes := &authorizationv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      common.RbacResourceVerbGet,
+		Group:     common.RbacPipelinesGroup,
+		Version:   common.RbacPipelinesVersion,
+		Resource:  rbacResourceType,
+	}
+	err = isAuthorized(resourceManager, ctx, resourceAttributes)
+	if util.IsUserErrorCodeMatch(err, codes.PermissionDenied) {
+		return util.NewPermissionDeniedError(err,
+			"Not authorized to access the referenced %v %v in namespace %v", resourceType, id, namespace)
+	}
+	if err != nil {
+		return util.Wrapf(err, "Failed to authorize the referenced %v %v", resourceType, id)
+	}
+	return nil
+}
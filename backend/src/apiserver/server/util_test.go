@@ -206,6 +206,22 @@ func TestReadPipelineFile_MultifileTarball(t *testing.T) {
 	assert.Equal(t, expectedPipelineFile, pipelineFile)
 }
 
+func TestReadPipelineVersionFile(t *testing.T) {
+	file, _ := os.Open("test/arguments_tarball/arguments.tar.gz")
+	pipelineFile, err := ReadPipelineVersionFile("arguments.tar.gz", file, MaxFileLength)
+	assert.Nil(t, err)
+
+	// Archives are kept for the resource manager to assemble.
+	expectedPipelineFile, _ := ioutil.ReadFile("test/arguments_tarball/arguments.tar.gz")
+	assert.Equal(t, expectedPipelineFile, pipelineFile)
+
+	file, _ = os.Open("test/arguments-parameters.yaml")
+	pipelineFile, err = ReadPipelineVersionFile("arguments-parameters.yaml", file, MaxFileLength)
+	assert.Nil(t, err)
+	expectedPipelineFile, _ = ioutil.ReadFile("test/arguments-parameters.yaml")
+	assert.Equal(t, expectedPipelineFile, pipelineFile)
+}
+
 func TestReadPipelineFile_UnknownFileFormat(t *testing.T) {
 	file, _ := os.Open("test/unknown_format.foo")
 	_, err := ReadPipelineFile("unknown_format.foo", file, MaxFileLength)
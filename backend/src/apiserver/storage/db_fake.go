@@ -29,6 +29,8 @@ func NewFakeDb() (*DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("Could not create the GORM database: %v", err)
 	}
+	// Every connection to an in-memory database gets its own empty database, so concurrent callers share one.
+	db.DB().SetMaxOpenConns(1)
 	// Create tables
 	db.AutoMigrate(
 		&model.Experiment{},
@@ -40,7 +42,11 @@ func NewFakeDb() (*DB, error) {
 		&model.RunMetric{},
 		&model.Task{},
 		&model.DBStatus{},
-		&model.DefaultExperiment{})
+		&model.DefaultExperiment{},
+		&model.NamespaceDefaultExperiment{},
+		&model.IdempotencyKey{},
+		&model.Label{},
+		&model.RunStatusEvent{})
 
 	return NewDB(db.DB(), NewSQLiteDialect()), nil
 }
@@ -15,6 +15,8 @@
 package storage
 
 import (
+	"context"
+
 	sq "github.com/Masterminds/squirrel"
 	"github.com/golang/glog"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
@@ -27,6 +29,7 @@ var (
 type DBStatusStoreInterface interface {
 	HaveSamplesLoaded() (bool, error)
 	MarkSampleLoaded() error
+	CheckConnection(ctx context.Context) error
 }
 
 var (
@@ -117,6 +120,15 @@ func (s *DBStatusStore) MarkSampleLoaded() error {
 	return nil
 }
 
+// CheckConnection runs a trivial query to check that the database can be reached.
+func (s *DBStatusStore) CheckConnection(ctx context.Context) error {
+	var one int
+	if err := s.db.QueryRowContext(ctx, "SELECT 1").Scan(&one); err != nil {
+		return util.NewUnavailableError(err, "Failed to reach the database")
+	}
+	return nil
+}
+
 // factory function for database status store
 func NewDBStatusStore(db *DB) *DBStatusStore {
 	s := &DBStatusStore{db: db}
@@ -29,6 +29,9 @@ var (
 type DefaultExperimentStoreInterface interface {
 	GetDefaultExperimentId() (string, error)
 	SetDefaultExperimentId(id string) error
+	GetNamespaceDefaultExperimentId(namespace string) (string, error)
+	SetNamespaceDefaultExperimentId(namespace string, id string) error
+	GetDefaultExperimentNamespace(id string) (string, error)
 }
 
 // Implementation of a DefaultExperimentStoreInterface. This stores the default experiment's ID,
@@ -140,6 +143,68 @@ func (s *DefaultExperimentStore) UnsetDefaultExperimentIdIfIdMatches(tx *sql.Tx,
 	return nil
 }
 
+// Returns the ID of the default experiment of the namespace, or the empty string if the namespace has none yet.
+func (s *DefaultExperimentStore) GetNamespaceDefaultExperimentId(namespace string) (string, error) {
+	sql, args, err := sq.
+		Select("DefaultExperimentId").
+		From("namespace_default_experiments").
+		Where(sq.Eq{"Namespace": namespace}).
+		ToSql()
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Error creating query to get the default experiment ID of namespace %v.", namespace)
+	}
+	return s.queryOne(sql, args, "default experiment ID of namespace "+namespace)
+}
+
+// Records the default experiment of the namespace. Returns an AlreadyExists error if the namespace
+// already has one.
+func (s *DefaultExperimentStore) SetNamespaceDefaultExperimentId(namespace string, id string) error {
+	sql, args, err := sq.
+		Insert("namespace_default_experiments").
+		SetMap(sq.Eq{"Namespace": namespace, "DefaultExperimentId": id}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Error creating query to set the default experiment ID of namespace %v.", namespace)
+	}
+	_, err = s.db.Exec(sql, args...)
+	if err != nil {
+		if s.db.IsDuplicateError(err) {
+			return util.NewAlreadyExistError("Namespace %v already has a default experiment.", namespace)
+		}
+		return util.NewInternalServerError(err, "Error setting the default experiment ID of namespace %v.", namespace)
+	}
+	return nil
+}
+
+// Returns the namespace the experiment is the default of, or the empty string if it isn't a namespace default.
+func (s *DefaultExperimentStore) GetDefaultExperimentNamespace(id string) (string, error) {
+	sql, args, err := sq.
+		Select("Namespace").
+		From("namespace_default_experiments").
+		Where(sq.Eq{"DefaultExperimentId": id}).
+		ToSql()
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Error creating query to get the namespace of default experiment %v.", id)
+	}
+	return s.queryOne(sql, args, "namespace of default experiment "+id)
+}
+
+func (s *DefaultExperimentStore) queryOne(sql string, args []interface{}, description string) (string, error) {
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Error when getting the %v", description)
+	}
+	defer rows.Close()
+
+	var value string
+	if rows.Next() {
+		if err := rows.Scan(&value); err != nil {
+			return "", util.NewInternalServerError(err, "Error when scanning row to find the %v", description)
+		}
+	}
+	return value, nil
+}
+
 // factory function for creating default experiment store
 func NewDefaultExperimentStore(db *DB) *DefaultExperimentStore {
 	s := &DefaultExperimentStore{db: db}
@@ -17,7 +17,9 @@ package storage
 import (
 	"testing"
 
+	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
 )
 
 func TestInitializeDefaultExperimentTable(t *testing.T) {
@@ -93,3 +95,35 @@ func TestUnsetDefaultExperimentIdIfIdMatches(t *testing.T) {
 
 	db.Close()
 }
+
+func TestGetAndSetNamespaceDefaultExperimentId(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defaultExperimentStore := NewDefaultExperimentStore(db)
+
+	// A namespace has no default experiment until one is set
+	defaultExperimentId, err := defaultExperimentStore.GetNamespaceDefaultExperimentId("ns1")
+	assert.Nil(t, err)
+	assert.Equal(t, "", defaultExperimentId)
+
+	err = defaultExperimentStore.SetNamespaceDefaultExperimentId("ns1", "test-ID")
+	assert.Nil(t, err)
+	defaultExperimentId, err = defaultExperimentStore.GetNamespaceDefaultExperimentId("ns1")
+	assert.Nil(t, err)
+	assert.Equal(t, "test-ID", defaultExperimentId)
+	namespace, err := defaultExperimentStore.GetDefaultExperimentNamespace("test-ID")
+	assert.Nil(t, err)
+	assert.Equal(t, "ns1", namespace)
+
+	// Setting the default experiment of the namespace again is an error, and the ID is not changed
+	err = defaultExperimentStore.SetNamespaceDefaultExperimentId("ns1", "a-different-ID")
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.AlreadyExists, err.(*util.UserError).ExternalStatusCode())
+	defaultExperimentId, err = defaultExperimentStore.GetNamespaceDefaultExperimentId("ns1")
+	assert.Nil(t, err)
+	assert.Equal(t, "test-ID", defaultExperimentId)
+
+	// Other experiments aren't namespace defaults
+	namespace, err = defaultExperimentStore.GetDefaultExperimentNamespace("a-different-ID")
+	assert.Nil(t, err)
+	assert.Equal(t, "", namespace)
+}
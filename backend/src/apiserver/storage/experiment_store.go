@@ -12,15 +12,29 @@ import (
 	"github.com/kubeflow/pipelines/backend/src/apiserver/list"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
 )
 
 type ExperimentStoreInterface interface {
 	ListExperiments(filterContext *common.FilterContext, opts *list.Options) ([]*model.Experiment, int, string, error)
 	GetExperiment(uuid string) (*model.Experiment, error)
+	GetExperimentIncludingDeleted(uuid string) (*model.Experiment, error)
+	GetExperimentByNameAndNamespace(name string, namespace string) (*model.Experiment, error)
 	CreateExperiment(*model.Experiment) (*model.Experiment, error)
+	CreateClonedExperiment(experiment *model.Experiment, source *model.Experiment) (*model.Experiment, error)
 	DeleteExperiment(uuid string) error
 	ArchiveExperiment(expId string) error
 	UnarchiveExperiment(expId string) error
+	SoftDeleteExperiment(expId string) error
+	RestoreExperiment(expId string) error
+	ListExperimentsDeletedBefore(deletedAtInSec int64) ([]string, error)
+	CountExperiments(namespace string) (int, error)
+	SetExperimentDefaultPipelineVersion(expId string, pipelineVersionId string) error
+	SetExperimentDefaultParameters(expId string, parameters string) error
+	SetExperimentWorkflowNamePrefix(expId string, prefix string) error
+	SetExperimentCompletionWebhook(expId string, webhook string) error
+	SetExperimentLabels(expId string, labels map[string]string) error
+	UpdateExperiment(expId string, name string, description string, expectedVersion int64) error
 }
 
 type ExperimentStore struct {
@@ -29,6 +43,7 @@ type ExperimentStore struct {
 	uuid                   util.UUIDGeneratorInterface
 	resourceReferenceStore *ResourceReferenceStore
 	defaultExperimentStore *DefaultExperimentStore
+	labelStore             *LabelStore
 }
 
 var (
@@ -39,9 +54,20 @@ var (
 		"CreatedAtInSec",
 		"Namespace",
 		"StorageState",
+		"DeletedAtInSec",
+		"DefaultPipelineVersionId",
+		"DefaultParameters",
+		"ResourceVersion",
+		"LastRunCreatedAtInSec",
+		"WorkflowNamePrefix",
+		"CompletionWebhook",
 	}
 )
 
+// softDeletedExperimentsQuery selects the soft deleted experiments, so that their runs and jobs
+// can be excluded from list results.
+const softDeletedExperimentsQuery = "SELECT UUID FROM experiments WHERE DeletedAtInSec > 0"
+
 // Runs two SQL queries in a transaction to return a list of matching experiments, as well as their
 // total_size. The total_size does not reflect the page size.
 func (s *ExperimentStore) ListExperiments(filterContext *common.FilterContext, opts *list.Options) ([]*model.Experiment, int, string, error) {
@@ -54,7 +80,10 @@ func (s *ExperimentStore) ListExperiments(filterContext *common.FilterContext, o
 	if filterContext.ReferenceKey != nil && filterContext.ReferenceKey.Type == common.Namespace {
 		sqlBuilder = sqlBuilder.Where(sq.Eq{"Namespace": filterContext.ReferenceKey.ID})
 	}
+	sqlBuilder = s.excludeSoftDeleted(sqlBuilder, opts)
+	sqlBuilder = s.excludeArchived(sqlBuilder, opts)
 	sqlBuilder = opts.AddFilterToSelect(sqlBuilder)
+	sqlBuilder = opts.AddLabelFilterToSelect(sqlBuilder, common.Experiment)
 
 	rowsSql, rowsArgs, err := opts.AddPaginationToSelect(sqlBuilder).ToSql()
 	if err != nil {
@@ -67,7 +96,10 @@ func (s *ExperimentStore) ListExperiments(filterContext *common.FilterContext, o
 	if filterContext.ReferenceKey != nil && filterContext.ReferenceKey.Type == common.Namespace {
 		sqlBuilder = sqlBuilder.Where(sq.Eq{"Namespace": filterContext.ReferenceKey.ID})
 	}
-	sizeSql, sizeArgs, err := opts.AddFilterToSelect(sqlBuilder).ToSql()
+	sqlBuilder = s.excludeSoftDeleted(sqlBuilder, opts)
+	sqlBuilder = s.excludeArchived(sqlBuilder, opts)
+	sqlBuilder = opts.AddLabelFilterToSelect(opts.AddFilterToSelect(sqlBuilder), common.Experiment)
+	sizeSql, sizeArgs, err := sqlBuilder.ToSql()
 	if err != nil {
 		return errorF(err)
 	}
@@ -108,22 +140,53 @@ func (s *ExperimentStore) ListExperiments(filterContext *common.FilterContext, o
 		glog.Errorf("Failed to commit transaction to list experiments")
 		return errorF(err)
 	}
+	if err := s.addLabels(exps); err != nil {
+		return nil, 0, "", err
+	}
 
 	if len(exps) <= opts.PageSize {
 		return exps, total_size, "", nil
 	}
 
-	npt, err := opts.NextPageToken(exps[opts.PageSize])
+	npt, err := opts.NextPageToken(exps[opts.PageSize-1])
 	return exps[:opts.PageSize], total_size, npt, err
 }
 
+// Soft deleted experiments are excluded from list results, unless the options include deleted resources.
+func (s *ExperimentStore) excludeSoftDeleted(sqlBuilder sq.SelectBuilder, opts *list.Options) sq.SelectBuilder {
+	if opts.IncludeDeleted {
+		return sqlBuilder
+	}
+	return sqlBuilder.Where(sq.Eq{"DeletedAtInSec": 0})
+}
+
+// Archived experiments are excluded from list results, unless the filter has a predicate on storage_state.
+func (s *ExperimentStore) excludeArchived(sqlBuilder sq.SelectBuilder, opts *list.Options) sq.SelectBuilder {
+	if opts.Filter != nil && opts.Filter.HasPredicateOn("experiments.StorageState") {
+		return sqlBuilder
+	}
+	return sqlBuilder.Where(sq.NotEq{"StorageState": "ARCHIVED"})
+}
+
+// GetExperiment returns an experiment. Soft deleted experiments aren't found.
 func (s *ExperimentStore) GetExperiment(uuid string) (*model.Experiment, error) {
-	sql, args, err := sq.
+	return s.getExperiment(uuid, false)
+}
+
+// GetExperimentIncludingDeleted is like GetExperiment, but also returns soft deleted experiments.
+func (s *ExperimentStore) GetExperimentIncludingDeleted(uuid string) (*model.Experiment, error) {
+	return s.getExperiment(uuid, true)
+}
+
+func (s *ExperimentStore) getExperiment(uuid string, includeDeleted bool) (*model.Experiment, error) {
+	sqlBuilder := sq.
 		Select(experimentColumns...).
 		From("experiments").
-		Where(sq.Eq{"uuid": uuid}).
-		Limit(1).
-		ToSql()
+		Where(sq.Eq{"uuid": uuid})
+	if !includeDeleted {
+		sqlBuilder = sqlBuilder.Where(sq.Eq{"DeletedAtInSec": 0})
+	}
+	sql, args, err := sqlBuilder.Limit(1).ToSql()
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to get experiment: %v", err.Error())
 	}
@@ -140,25 +203,85 @@ func (s *ExperimentStore) GetExperiment(uuid string) (*model.Experiment, error)
 	if len(experiments) == 0 {
 		return nil, util.NewResourceNotFoundError("Experiment", fmt.Sprint(uuid))
 	}
+	if err := s.addLabels(experiments); err != nil {
+		return nil, err
+	}
+	return experiments[0], nil
+}
+
+func (s *ExperimentStore) GetExperimentByNameAndNamespace(name string, namespace string) (*model.Experiment, error) {
+	sql, args, err := sq.
+		Select(experimentColumns...).
+		From("experiments").
+		Where(sq.And{
+			sq.Eq{"Name": name},
+			sq.Eq{"Namespace": namespace},
+		}).
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get experiment by name and namespace: %v", err.Error())
+	}
+	r, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get experiment by name and namespace: %v", err.Error())
+	}
+	defer r.Close()
+	experiments, err := s.scanRows(r)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get experiment by name and namespace: %v", err.Error())
+	}
+	if len(experiments) == 0 {
+		return nil, util.NewResourceNotFoundError("Experiment", name)
+	}
+	if err := s.addLabels(experiments); err != nil {
+		return nil, err
+	}
 	return experiments[0], nil
 }
 
+// addLabels reads the labels of the given experiments from the labels table.
+func (s *ExperimentStore) addLabels(experiments []*model.Experiment) error {
+	ids := make([]string, 0, len(experiments))
+	for _, experiment := range experiments {
+		ids = append(ids, experiment.UUID)
+	}
+	labels, err := s.labelStore.GetLabels(common.Experiment, ids)
+	if err != nil {
+		return util.Wrap(err, "Failed to get experiment labels")
+	}
+	for _, experiment := range experiments {
+		experiment.Labels = labels[experiment.UUID]
+	}
+	return nil
+}
+
 func (s *ExperimentStore) scanRows(rows *sql.Rows) ([]*model.Experiment, error) {
 	var experiments []*model.Experiment
 	for rows.Next() {
 		var uuid, name, description, namespace, storageState string
-		var createdAtInSec int64
-		err := rows.Scan(&uuid, &name, &description, &createdAtInSec, &namespace, &storageState)
+		var createdAtInSec, deletedAtInSec, resourceVersion, lastRunCreatedAtInSec int64
+		var defaultPipelineVersionId, defaultParameters, workflowNamePrefix, completionWebhook sql.NullString
+		err := rows.Scan(&uuid, &name, &description, &createdAtInSec, &namespace, &storageState, &deletedAtInSec,
+			&defaultPipelineVersionId, &defaultParameters, &resourceVersion, &lastRunCreatedAtInSec, &workflowNamePrefix,
+			&completionWebhook)
 		if err != nil {
 			return experiments, err
 		}
 		experiment := &model.Experiment{
-			UUID:           uuid,
-			Name:           name,
-			Description:    description,
-			CreatedAtInSec: createdAtInSec,
-			Namespace:      namespace,
-			StorageState:   storageState,
+			UUID:                     uuid,
+			Name:                     name,
+			Description:              description,
+			CreatedAtInSec:           createdAtInSec,
+			Namespace:                namespace,
+			StorageState:             storageState,
+			DeletedAtInSec:           deletedAtInSec,
+			DefaultPipelineVersionId: defaultPipelineVersionId.String,
+			DefaultParameters:        defaultParameters.String,
+			ResourceVersion:          resourceVersion,
+			LastRunCreatedAtInSec:    lastRunCreatedAtInSec,
+			WorkflowNamePrefix:       workflowNamePrefix.String,
+			CompletionWebhook:        completionWebhook.String,
 		}
 		// Since storage state is a field added after initial KFP release, it is possible that existing experiments don't have this field and we use AVAILABLE in that case.
 		if experiment.StorageState == "" {
@@ -170,6 +293,16 @@ func (s *ExperimentStore) scanRows(rows *sql.Rows) ([]*model.Experiment, error)
 }
 
 func (s *ExperimentStore) CreateExperiment(experiment *model.Experiment) (*model.Experiment, error) {
+	return s.createExperiment(experiment, nil)
+}
+
+// CreateClonedExperiment creates an experiment along with a ClonedFrom reference to the experiment it was
+// cloned from.
+func (s *ExperimentStore) CreateClonedExperiment(experiment *model.Experiment, source *model.Experiment) (*model.Experiment, error) {
+	return s.createExperiment(experiment, source)
+}
+
+func (s *ExperimentStore) createExperiment(experiment *model.Experiment, source *model.Experiment) (*model.Experiment, error) {
 	newExperiment := *experiment
 	now := s.time.Now().Unix()
 	newExperiment.CreatedAtInSec = now
@@ -178,6 +311,7 @@ func (s *ExperimentStore) CreateExperiment(experiment *model.Experiment) (*model
 		return nil, util.NewInternalServerError(err, "Failed to create an experiment id.")
 	}
 	newExperiment.UUID = id.String()
+	newExperiment.ResourceVersion = 1
 
 	if newExperiment.StorageState == "" {
 		// Default to available if not set.
@@ -190,27 +324,65 @@ func (s *ExperimentStore) CreateExperiment(experiment *model.Experiment) (*model
 	sql, args, err := sq.
 		Insert("experiments").
 		SetMap(sq.Eq{
-			"UUID":           newExperiment.UUID,
-			"CreatedAtInSec": newExperiment.CreatedAtInSec,
-			"Name":           newExperiment.Name,
-			"Description":    newExperiment.Description,
-			"Namespace":      newExperiment.Namespace,
-			"StorageState":   newExperiment.StorageState,
+			"UUID":                     newExperiment.UUID,
+			"CreatedAtInSec":           newExperiment.CreatedAtInSec,
+			"Name":                     newExperiment.Name,
+			"Description":              newExperiment.Description,
+			"Namespace":                newExperiment.Namespace,
+			"StorageState":             newExperiment.StorageState,
+			"DefaultPipelineVersionId": newExperiment.DefaultPipelineVersionId,
+			"DefaultParameters":        newExperiment.DefaultParameters,
+			"ResourceVersion":          newExperiment.ResourceVersion,
+			"WorkflowNamePrefix":       newExperiment.WorkflowNamePrefix,
+			"CompletionWebhook":        newExperiment.CompletionWebhook,
 		}).
 		ToSql()
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to create query to insert experiment to experiment table: %v",
 			err.Error())
 	}
-	_, err = s.db.Exec(sql, args...)
+	tx, err := s.db.Begin()
 	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to start a transaction to create an experiment")
+	}
+	_, err = tx.Exec(sql, args...)
+	if err != nil {
+		tx.Rollback()
 		if s.db.IsDuplicateError(err) {
+			// Names are unique per namespace. In single-user mode the namespace is empty.
+			if newExperiment.Namespace != "" {
+				return nil, util.NewAlreadyExistError(
+					"Failed to create a new experiment. The name %v already exists in namespace %v. Please specify a new name.",
+					experiment.Name, newExperiment.Namespace)
+			}
 			return nil, util.NewAlreadyExistError(
 				"Failed to create a new experiment. The name %v already exists. Please specify a new name.", experiment.Name)
 		}
 		return nil, util.NewInternalServerError(err, "Failed to add experiment to experiment table: %v",
 			err.Error())
 	}
+	if err := s.labelStore.CreateLabels(tx, common.Experiment, newExperiment.UUID, newExperiment.Labels); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if source != nil {
+		err := s.resourceReferenceStore.CreateResourceReferences(tx, []*model.ResourceReference{{
+			ResourceUUID:  newExperiment.UUID,
+			ResourceType:  common.Experiment,
+			ReferenceUUID: source.UUID,
+			ReferenceName: source.Name,
+			ReferenceType: common.Experiment,
+			Relationship:  common.ClonedFrom,
+		}})
+		if err != nil {
+			tx.Rollback()
+			return nil, util.Wrapf(err, "Failed to store the reference of experiment %v to the experiment it was cloned from", newExperiment.Name)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to add experiment to experiment table: %v",
+			err.Error())
+	}
 	return &newExperiment, nil
 }
 
@@ -240,6 +412,24 @@ func (s *ExperimentStore) DeleteExperiment(id string) error {
 		tx.Rollback()
 		return util.NewInternalServerError(err, "Failed to delete resource references from table for experiment %v ", id)
 	}
+	// Delete the ClonedFrom references of the experiment to its source, and of its clones to it.
+	clonedFromSql, clonedFromArgs, err := sq.
+		Delete("resource_references").
+		Where(sq.Eq{"ResourceType": common.Experiment, "Relationship": common.ClonedFrom}).
+		Where(sq.Or{sq.Eq{"ResourceUUID": id}, sq.Eq{"ReferenceUUID": id}}).
+		ToSql()
+	if err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to create query to delete the clone references of experiment %v", id)
+	}
+	if _, err := tx.Exec(clonedFromSql, clonedFromArgs...); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to delete the clone references of experiment %v", id)
+	}
+	if err := s.labelStore.DeleteLabels(tx, common.Experiment, id); err != nil {
+		tx.Rollback()
+		return err
+	}
 	err = tx.Commit()
 	if err != nil {
 		return util.NewInternalServerError(err, "Failed to delete experiment %v and its resource references from table", id)
@@ -254,7 +444,8 @@ func (s *ExperimentStore) ArchiveExperiment(expId string) error {
 	sql, args, err := sq.
 		Update("experiments").
 		SetMap(sq.Eq{
-			"StorageState": "ARCHIVED",
+			"StorageState":    "ARCHIVED",
+			"ResourceVersion": nextResourceVersion,
 		}).
 		Where(sq.Eq{"UUID": expId}).
 		ToSql()
@@ -374,7 +565,8 @@ func (s *ExperimentStore) UnarchiveExperiment(expId string) error {
 	sql, args, err := sq.
 		Update("experiments").
 		SetMap(sq.Eq{
-			"StorageState": "AVAILABLE",
+			"StorageState":    "AVAILABLE",
+			"ResourceVersion": nextResourceVersion,
 		}).
 		Where(sq.Eq{"UUID": expId}).
 		ToSql()
@@ -392,6 +584,211 @@ func (s *ExperimentStore) UnarchiveExperiment(expId string) error {
 	return nil
 }
 
+func (s *ExperimentStore) SoftDeleteExperiment(expId string) error {
+	// SoftDeleteExperiment results in
+	// 1. The experiment getting marked as deleted, which hides it and its runs and jobs from list results
+	// 2. All the jobs in the experiment getting disabled
+	// 3. The default experiment getting cleared if it is this experiment
+	now := s.time.Now().Unix()
+	sql, args, err := sq.
+		Update("experiments").
+		SetMap(sq.Eq{
+			"DeletedAtInSec":  now,
+			"ResourceVersion": nextResourceVersion,
+		}).
+		Where(sq.Eq{"UUID": expId}).
+		Where(sq.Eq{"DeletedAtInSec": 0}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err,
+			"Failed to create query to soft delete experiment %s. error: '%v'", expId, err.Error())
+	}
+
+	filteredJobsSql, filteredJobsArgs, err := sq.Select("ResourceUUID").
+		From("resource_references as rf").
+		Where(sq.And{
+			sq.Eq{"rf.ResourceType": common.Job},
+			sq.Eq{"rf.ReferenceUUID": expId},
+			sq.Eq{"rf.ReferenceType": common.Experiment}}).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err,
+			"Failed to create query to filter the jobs in an experiment %s. error: '%v'", expId, err.Error())
+	}
+	updateJobsSql, updateJobsArgs, err := sq.
+		Update("jobs").
+		SetMap(sq.Eq{
+			"Enabled":        false,
+			"UpdatedAtInSec": now}).
+		Where(sq.Eq{"Enabled": true}).
+		Where(fmt.Sprintf("UUID in (%s)", filteredJobsSql), filteredJobsArgs...).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err,
+			"Failed to create query to disable the jobs in an experiment %s. error: '%v'", expId, err.Error())
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create a new transaction to soft delete an experiment.")
+	}
+	result, err := tx.Exec(sql, args...)
+	if err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err,
+			"Failed to soft delete experiment %s. error: '%v'", expId, err.Error())
+	}
+	if r, _ := result.RowsAffected(); r != 1 {
+		tx.Rollback()
+		return util.NewResourceNotFoundError("Experiment", expId)
+	}
+	_, err = tx.Exec(updateJobsSql, updateJobsArgs...)
+	if err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err,
+			"Failed to disable all jobs in an experiment %s. error: '%v'", expId, err.Error())
+	}
+	err = s.defaultExperimentStore.UnsetDefaultExperimentIdIfIdMatches(tx, expId)
+	if err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to clear default experiment ID for experiment %v ", expId)
+	}
+	if err = tx.Commit(); err != nil {
+		return util.NewInternalServerError(err, "Failed to soft delete experiment %s", expId)
+	}
+	return nil
+}
+
+func (s *ExperimentStore) RestoreExperiment(expId string) error {
+	// RestoreExperiment results in
+	// 1. The experiment, its runs and its jobs becoming visible again
+	// 2. The jobs disabled by the deletion will stay disabled
+	sql, args, err := sq.
+		Update("experiments").
+		SetMap(sq.Eq{
+			"DeletedAtInSec":  0,
+			"ResourceVersion": nextResourceVersion,
+		}).
+		Where(sq.Eq{"UUID": expId}).
+		Where(sq.Gt{"DeletedAtInSec": 0}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err,
+			"Failed to create query to restore experiment %s. error: '%v'", expId, err.Error())
+	}
+	result, err := s.db.Exec(sql, args...)
+	if err != nil {
+		return util.NewInternalServerError(err,
+			"Failed to restore experiment %s. error: '%v'", expId, err.Error())
+	}
+	if r, _ := result.RowsAffected(); r != 1 {
+		return util.NewResourceNotFoundError("Deleted experiment", expId)
+	}
+	return nil
+}
+
+// ListExperimentsDeletedBefore returns the IDs of the experiments soft deleted before the given time.
+func (s *ExperimentStore) ListExperimentsDeletedBefore(deletedAtInSec int64) ([]string, error) {
+	sql, args, err := sq.
+		Select("UUID").
+		From("experiments").
+		Where(sq.Gt{"DeletedAtInSec": 0}).
+		Where(sq.Lt{"DeletedAtInSec": deletedAtInSec}).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list deleted experiments: %v", err.Error())
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list deleted experiments: %v", err.Error())
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to scan deleted experiments: %v", err.Error())
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// CountExperiments counts the experiments of a namespace that are neither archived nor soft deleted. An empty
+// namespace counts the experiments of every namespace.
+func (s *ExperimentStore) CountExperiments(namespace string) (int, error) {
+	where := sq.And{sq.Eq{"DeletedAtInSec": 0}, sq.NotEq{"StorageState": "ARCHIVED"}}
+	if namespace != "" {
+		where = append(where, sq.Eq{"Namespace": namespace})
+	}
+	query, args, err := sq.Select("count(*)").From("experiments").Where(where).ToSql()
+	if err != nil {
+		return 0, util.NewInternalServerError(err, "Failed to create query to count the experiments of namespace %v", namespace)
+	}
+	var count int
+	if err := s.db.QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, util.NewInternalServerError(err, "Failed to count the experiments of namespace %v", namespace)
+	}
+	return count, nil
+}
+
+// SetExperimentDefaultPipelineVersion sets the pipeline version that runs of the experiment default to. An
+// empty version ID clears it.
+func (s *ExperimentStore) SetExperimentDefaultPipelineVersion(expId string, pipelineVersionId string) error {
+	return compareAndSwap(s.db, "experiments", "Experiment", expId, 0, sq.Eq{"DefaultPipelineVersionId": pipelineVersionId})
+}
+
+// SetExperimentDefaultParameters sets the serialized parameters that runs of the experiment default to. An
+// empty string clears them.
+func (s *ExperimentStore) SetExperimentDefaultParameters(expId string, parameters string) error {
+	return compareAndSwap(s.db, "experiments", "Experiment", expId, 0, sq.Eq{"DefaultParameters": parameters})
+}
+
+// SetExperimentWorkflowNamePrefix sets the prefix of the workflow names of the runs of the experiment. An
+// empty prefix clears it.
+func (s *ExperimentStore) SetExperimentWorkflowNamePrefix(expId string, prefix string) error {
+	return compareAndSwap(s.db, "experiments", "Experiment", expId, 0, sq.Eq{"WorkflowNamePrefix": prefix})
+}
+
+// SetExperimentCompletionWebhook sets the URL notified when a run of the experiment that doesn't set its own
+// webhook completes. An empty URL clears it.
+func (s *ExperimentStore) SetExperimentCompletionWebhook(expId string, webhook string) error {
+	return compareAndSwap(s.db, "experiments", "Experiment", expId, 0, sq.Eq{"CompletionWebhook": webhook})
+}
+
+// SetExperimentLabels replaces the labels of an experiment.
+func (s *ExperimentStore) SetExperimentLabels(expId string, labels map[string]string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to start a transaction to update labels of experiment %v", expId)
+	}
+	if err := compareAndSwap(tx, "experiments", "Experiment", expId, 0, sq.Eq{}); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := s.labelStore.ReplaceLabels(tx, common.Experiment, expId, labels); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return util.NewInternalServerError(err, "Failed to update labels of experiment %v", expId)
+	}
+	return nil
+}
+
+// UpdateExperiment changes the name and description of an experiment. If expectedVersion isn't 0, the update
+// fails with a Conflict error unless the experiment is still at that resource version.
+func (s *ExperimentStore) UpdateExperiment(expId string, name string, description string, expectedVersion int64) error {
+	err := compareAndSwap(s.db, "experiments", "Experiment", expId, expectedVersion, sq.Eq{
+		"Name":        name,
+		"Description": description,
+	})
+	if err != nil && s.db.IsDuplicateError(errors.Cause(err)) {
+		return util.NewAlreadyExistError(
+			"Failed to update experiment %v. The name %v already exists. Please specify a new name.", expId, name)
+	}
+	return err
+}
+
 // factory function for experiment store
 func NewExperimentStore(db *DB, time util.TimeInterface, uuid util.UUIDGeneratorInterface) *ExperimentStore {
 	return &ExperimentStore{
@@ -400,5 +797,6 @@ func NewExperimentStore(db *DB, time util.TimeInterface, uuid util.UUIDGenerator
 		uuid:                   uuid,
 		resourceReferenceStore: NewResourceReferenceStore(db),
 		defaultExperimentStore: NewDefaultExperimentStore(db),
+		labelStore:             NewLabelStore(db),
 	}
 }
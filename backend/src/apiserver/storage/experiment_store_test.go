@@ -46,18 +46,20 @@ func TestListExperiments_Pagination(t *testing.T) {
 	experimentStore.uuid = util.NewFakeUUIDGeneratorOrFatal(fakeIDFour, nil)
 	experimentStore.CreateExperiment(createExperiment("experiment2"))
 	expectedExperiment1 := &model.Experiment{
-		UUID:           fakeID,
-		CreatedAtInSec: 1,
-		Name:           "experiment1",
-		Description:    "My name is experiment1",
-		StorageState:   "AVAILABLE",
+		UUID:            fakeID,
+		CreatedAtInSec:  1,
+		Name:            "experiment1",
+		Description:     "My name is experiment1",
+		StorageState:    "AVAILABLE",
+		ResourceVersion: 1,
 	}
 	expectedExperiment4 := &model.Experiment{
-		UUID:           fakeIDFour,
-		CreatedAtInSec: 4,
-		Name:           "experiment2",
-		Description:    "My name is experiment2",
-		StorageState:   "AVAILABLE",
+		UUID:            fakeIDFour,
+		CreatedAtInSec:  4,
+		Name:            "experiment2",
+		Description:     "My name is experiment2",
+		StorageState:    "AVAILABLE",
+		ResourceVersion: 1,
 	}
 	experimentsExpected := []*model.Experiment{expectedExperiment1, expectedExperiment4}
 	opts, err := list.NewOptions(&model.Experiment{}, 2, "name", nil)
@@ -71,18 +73,20 @@ func TestListExperiments_Pagination(t *testing.T) {
 	assert.Equal(t, 4, total_size)
 
 	expectedExperiment2 := &model.Experiment{
-		UUID:           fakeIDTwo,
-		CreatedAtInSec: 2,
-		Name:           "experiment3",
-		Description:    "My name is experiment3",
-		StorageState:   "AVAILABLE",
+		UUID:            fakeIDTwo,
+		CreatedAtInSec:  2,
+		Name:            "experiment3",
+		Description:     "My name is experiment3",
+		StorageState:    "AVAILABLE",
+		ResourceVersion: 1,
 	}
 	expectedExperiment3 := &model.Experiment{
-		UUID:           fakeIDThree,
-		CreatedAtInSec: 3,
-		Name:           "experiment4",
-		Description:    "My name is experiment4",
-		StorageState:   "AVAILABLE",
+		UUID:            fakeIDThree,
+		CreatedAtInSec:  3,
+		Name:            "experiment4",
+		Description:     "My name is experiment4",
+		StorageState:    "AVAILABLE",
+		ResourceVersion: 1,
 	}
 	experimentsExpected2 := []*model.Experiment{expectedExperiment2, expectedExperiment3}
 
@@ -109,18 +113,20 @@ func TestListExperiments_Pagination_Descend(t *testing.T) {
 	experimentStore.CreateExperiment(createExperiment("experiment2"))
 
 	expectedExperiment2 := &model.Experiment{
-		UUID:           fakeIDTwo,
-		CreatedAtInSec: 2,
-		Name:           "experiment3",
-		Description:    "My name is experiment3",
-		StorageState:   "AVAILABLE",
+		UUID:            fakeIDTwo,
+		CreatedAtInSec:  2,
+		Name:            "experiment3",
+		Description:     "My name is experiment3",
+		StorageState:    "AVAILABLE",
+		ResourceVersion: 1,
 	}
 	expectedExperiment3 := &model.Experiment{
-		UUID:           fakeIDThree,
-		CreatedAtInSec: 3,
-		Name:           "experiment4",
-		Description:    "My name is experiment4",
-		StorageState:   "AVAILABLE",
+		UUID:            fakeIDThree,
+		CreatedAtInSec:  3,
+		Name:            "experiment4",
+		Description:     "My name is experiment4",
+		StorageState:    "AVAILABLE",
+		ResourceVersion: 1,
 	}
 	experimentsExpected := []*model.Experiment{expectedExperiment3, expectedExperiment2}
 
@@ -134,18 +140,20 @@ func TestListExperiments_Pagination_Descend(t *testing.T) {
 	assert.Equal(t, experimentsExpected, experiments)
 
 	expectedExperiment1 := &model.Experiment{
-		UUID:           fakeID,
-		CreatedAtInSec: 1,
-		Name:           "experiment1",
-		Description:    "My name is experiment1",
-		StorageState:   "AVAILABLE",
+		UUID:            fakeID,
+		CreatedAtInSec:  1,
+		Name:            "experiment1",
+		Description:     "My name is experiment1",
+		StorageState:    "AVAILABLE",
+		ResourceVersion: 1,
 	}
 	expectedExperiment4 := &model.Experiment{
-		UUID:           fakeIDFour,
-		CreatedAtInSec: 4,
-		Name:           "experiment2",
-		Description:    "My name is experiment2",
-		StorageState:   "AVAILABLE",
+		UUID:            fakeIDFour,
+		CreatedAtInSec:  4,
+		Name:            "experiment2",
+		Description:     "My name is experiment2",
+		StorageState:    "AVAILABLE",
+		ResourceVersion: 1,
 	}
 	experimentsExpected2 := []*model.Experiment{expectedExperiment4, expectedExperiment1}
 
@@ -165,11 +173,12 @@ func TestListExperiments_Pagination_LessThanPageSize(t *testing.T) {
 	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
 	experimentStore.CreateExperiment(createExperiment("experiment1"))
 	expectedExperiment1 := &model.Experiment{
-		UUID:           fakeID,
-		CreatedAtInSec: 1,
-		Name:           "experiment1",
-		Description:    "My name is experiment1",
-		StorageState:   "AVAILABLE",
+		UUID:            fakeID,
+		CreatedAtInSec:  1,
+		Name:            "experiment1",
+		Description:     "My name is experiment1",
+		StorageState:    "AVAILABLE",
+		ResourceVersion: 1,
 	}
 	experimentsExpected := []*model.Experiment{expectedExperiment1}
 
@@ -201,11 +210,12 @@ func TestGetExperiment(t *testing.T) {
 	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
 	experimentStore.CreateExperiment(createExperiment("experiment1"))
 	experimentExpected := model.Experiment{
-		UUID:           fakeID,
-		CreatedAtInSec: 1,
-		Name:           "experiment1",
-		Description:    "My name is experiment1",
-		StorageState:   "AVAILABLE",
+		UUID:            fakeID,
+		CreatedAtInSec:  1,
+		Name:            "experiment1",
+		Description:     "My name is experiment1",
+		StorageState:    "AVAILABLE",
+		ResourceVersion: 1,
 	}
 
 	experiment, err := experimentStore.GetExperiment(fakeID)
@@ -233,16 +243,177 @@ func TestGetExperiment_InternalError(t *testing.T) {
 		"Expected get experiment to return internal error")
 }
 
+func TestSetExperimentDefaultPipelineVersion(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+	experimentStore.CreateExperiment(createExperiment("experiment1"))
+
+	err := experimentStore.SetExperimentDefaultPipelineVersion(fakeID, "version1")
+	assert.Nil(t, err)
+	experiment, err := experimentStore.GetExperiment(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, "version1", experiment.DefaultPipelineVersionId)
+
+	err = experimentStore.SetExperimentDefaultPipelineVersion(fakeID, "")
+	assert.Nil(t, err)
+	experiment, err = experimentStore.GetExperiment(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, "", experiment.DefaultPipelineVersionId)
+
+	err = experimentStore.SetExperimentDefaultPipelineVersion("does-not-exist", "version1")
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestSetExperimentDefaultParameters(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+	experimentStore.CreateExperiment(createExperiment("experiment1"))
+
+	err := experimentStore.SetExperimentDefaultParameters(fakeID, `[{"name":"param1","value":"value1"}]`)
+	assert.Nil(t, err)
+	experiment, err := experimentStore.GetExperiment(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"name":"param1","value":"value1"}]`, experiment.DefaultParameters)
+
+	err = experimentStore.SetExperimentDefaultParameters("does-not-exist", "")
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestSetExperimentWorkflowNamePrefix(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+	experimentStore.CreateExperiment(createExperiment("experiment1"))
+
+	err := experimentStore.SetExperimentWorkflowNamePrefix(fakeID, "team-a")
+	assert.Nil(t, err)
+	experiment, err := experimentStore.GetExperiment(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, "team-a", experiment.WorkflowNamePrefix)
+	assert.Equal(t, int64(2), experiment.ResourceVersion)
+
+	err = experimentStore.SetExperimentWorkflowNamePrefix("does-not-exist", "")
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestSetExperimentCompletionWebhook(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+	experimentStore.CreateExperiment(createExperiment("experiment1"))
+
+	err := experimentStore.SetExperimentCompletionWebhook(fakeID, "https://hooks.example.com/kfp")
+	assert.Nil(t, err)
+	experiment, err := experimentStore.GetExperiment(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://hooks.example.com/kfp", experiment.CompletionWebhook)
+	assert.Equal(t, int64(2), experiment.ResourceVersion)
+
+	err = experimentStore.SetExperimentCompletionWebhook("does-not-exist", "")
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestSetExperimentLabels(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+	labeled := createExperiment("experiment1")
+	labeled.Labels = map[string]string{"team": "ml"}
+	experimentStore.CreateExperiment(labeled)
+	experimentStore.uuid = util.NewFakeUUIDGeneratorOrFatal(fakeIDTwo, nil)
+	experimentStore.CreateExperiment(createExperiment("experiment2"))
+
+	experiment, err := experimentStore.GetExperiment(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"team": "ml"}, experiment.Labels)
+
+	err = experimentStore.SetExperimentLabels(fakeIDTwo, map[string]string{"data-classification": "pii", "team": "ml"})
+	assert.Nil(t, err)
+	experiment, err = experimentStore.GetExperiment(fakeIDTwo)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"data-classification": "pii", "team": "ml"}, experiment.Labels)
+	assert.Equal(t, int64(2), experiment.ResourceVersion)
+
+	filterProto := &api.Filter{
+		Predicates: []*api.Predicate{
+			{
+				Key:   "labels.data-classification",
+				Op:    api.Predicate_EQUALS,
+				Value: &api.Predicate_StringValue{StringValue: "pii"},
+			},
+		},
+	}
+	opts, err := list.NewOptions(&model.Experiment{}, 10, "id", filterProto)
+	assert.Nil(t, err)
+	experiments, totalSize, _, err := experimentStore.ListExperiments(&common.FilterContext{}, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, totalSize)
+	assert.Len(t, experiments, 1)
+	assert.Equal(t, fakeIDTwo, experiments[0].UUID)
+	assert.Equal(t, map[string]string{"data-classification": "pii", "team": "ml"}, experiments[0].Labels)
+
+	// Empty labels clear them, and the labels of deleted experiments are deleted too.
+	err = experimentStore.SetExperimentLabels(fakeIDTwo, nil)
+	assert.Nil(t, err)
+	experiment, err = experimentStore.GetExperiment(fakeIDTwo)
+	assert.Nil(t, err)
+	assert.Empty(t, experiment.Labels)
+	assert.Nil(t, experimentStore.DeleteExperiment(fakeID))
+	labels, err := experimentStore.labelStore.GetLabels(common.Experiment, []string{fakeID})
+	assert.Nil(t, err)
+	assert.Empty(t, labels)
+
+	err = experimentStore.SetExperimentLabels("does-not-exist", nil)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestUpdateExperiment(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+	experimentStore.CreateExperiment(createExperiment("experiment1"))
+	experimentStore.uuid = util.NewFakeUUIDGeneratorOrFatal(fakeIDTwo, nil)
+	experimentStore.CreateExperiment(createExperiment("experiment2"))
+
+	err := experimentStore.UpdateExperiment(fakeID, "renamed", "new description", 1)
+	assert.Nil(t, err)
+	experiment, err := experimentStore.GetExperiment(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, "renamed", experiment.Name)
+	assert.Equal(t, "new description", experiment.Description)
+	assert.Equal(t, int64(2), experiment.ResourceVersion)
+
+	// The experiment was updated since version 1 was read.
+	err = experimentStore.UpdateExperiment(fakeID, "stale", "", 1)
+	assert.Equal(t, codes.Aborted, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.Error(), "resource version is 2, not 1")
+
+	// Other updates bump the version too.
+	err = experimentStore.ArchiveExperiment(fakeID)
+	assert.Nil(t, err)
+	experiment, err = experimentStore.GetExperiment(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), experiment.ResourceVersion)
+
+	err = experimentStore.UpdateExperiment(fakeID, "experiment2", "", 0)
+	assert.Equal(t, codes.AlreadyExists, err.(*util.UserError).ExternalStatusCode())
+	err = experimentStore.UpdateExperiment("does-not-exist", "name", "", 1)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
 func TestCreateExperiment(t *testing.T) {
 	db := NewFakeDbOrFatal()
 	defer db.Close()
 	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
 	experimentExpected := model.Experiment{
-		UUID:           fakeID,
-		CreatedAtInSec: 1,
-		Name:           "experiment1",
-		Description:    "My name is experiment1",
-		StorageState:   "AVAILABLE",
+		UUID:            fakeID,
+		CreatedAtInSec:  1,
+		Name:            "experiment1",
+		Description:     "My name is experiment1",
+		StorageState:    "AVAILABLE",
+		ResourceVersion: 1,
 	}
 
 	experiment := createExperiment("experiment1")
@@ -256,12 +427,13 @@ func TestCreateExperiment_DifferentNamespaces(t *testing.T) {
 	defer db.Close()
 	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
 	experimentExpected := model.Experiment{
-		UUID:           fakeID,
-		CreatedAtInSec: 1,
-		Name:           "experiment1",
-		Description:    "My name is experiment1",
-		Namespace:      "namespace1",
-		StorageState:   "AVAILABLE",
+		UUID:            fakeID,
+		CreatedAtInSec:  1,
+		Name:            "experiment1",
+		Description:     "My name is experiment1",
+		Namespace:       "namespace1",
+		StorageState:    "AVAILABLE",
+		ResourceVersion: 1,
 	}
 
 	experiment := createExperimentInNamespace("experiment1", "namespace1")
@@ -272,12 +444,13 @@ func TestCreateExperiment_DifferentNamespaces(t *testing.T) {
 	experimentStore = NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeIDTwo, nil))
 	experiment = createExperimentInNamespace("experiment1", "namespace2")
 	experimentExpected = model.Experiment{
-		UUID:           fakeIDTwo,
-		CreatedAtInSec: 1,
-		Name:           "experiment1",
-		Description:    "My name is experiment1",
-		Namespace:      "namespace2",
-		StorageState:   "AVAILABLE",
+		UUID:            fakeIDTwo,
+		CreatedAtInSec:  1,
+		Name:            "experiment1",
+		Description:     "My name is experiment1",
+		Namespace:       "namespace2",
+		StorageState:    "AVAILABLE",
+		ResourceVersion: 1,
 	}
 
 	experiment, err = experimentStore.CreateExperiment(experiment)
@@ -299,6 +472,20 @@ func TestCreateExperiment_DuplicatedKey(t *testing.T) {
 	assert.Contains(t, err.Error(), "The name experiment1 already exist")
 }
 
+func TestCreateExperiment_DuplicatedKeyInNamespace(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+	_, err := experimentStore.CreateExperiment(createExperimentInNamespace("default-exp", "namespace1"))
+	assert.Nil(t, err)
+
+	experimentStore = NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeIDTwo, nil))
+	_, err = experimentStore.CreateExperiment(createExperimentInNamespace("default-exp", "namespace1"))
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.AlreadyExists, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.Error(), "The name default-exp already exists in namespace namespace1")
+}
+
 func TestCreateExperiment_InternalServerError(t *testing.T) {
 	experiment := &model.Experiment{Name: "Experiment123"}
 	db := NewFakeDbOrFatal()
@@ -339,6 +526,35 @@ func TestDeleteExperiment(t *testing.T) {
 	assert.Contains(t, err.Error(), "not found")
 }
 
+func TestCreateClonedExperiment(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+	source, err := experimentStore.CreateExperiment(createExperiment("experiment1"))
+	assert.Nil(t, err)
+	experimentStore.uuid = util.NewFakeUUIDGeneratorOrFatal(fakeIDTwo, nil)
+	clone := createExperiment("experiment2")
+	clone.WorkflowNamePrefix = "nightly"
+	clone.DefaultPipelineVersionId = "version1"
+	_, err = experimentStore.CreateClonedExperiment(clone, source)
+	assert.Nil(t, err)
+
+	experiment, err := experimentStore.GetExperiment(fakeIDTwo)
+	assert.Nil(t, err)
+	assert.Equal(t, "nightly", experiment.WorkflowNamePrefix)
+	assert.Equal(t, "version1", experiment.DefaultPipelineVersionId)
+	reference, err := experimentStore.resourceReferenceStore.GetResourceReference(fakeIDTwo, common.Experiment, common.Experiment)
+	assert.Nil(t, err)
+	assert.Equal(t, fakeID, reference.ReferenceUUID)
+	assert.Equal(t, "experiment1", reference.ReferenceName)
+	assert.Equal(t, common.ClonedFrom, reference.Relationship)
+
+	// Deleting the source deletes the reference of its clone to it.
+	assert.Nil(t, experimentStore.DeleteExperiment(fakeID))
+	_, err = experimentStore.resourceReferenceStore.GetResourceReference(fakeIDTwo, common.Experiment, common.Experiment)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
 func TestDeleteExperiment_InternalError(t *testing.T) {
 	db := NewFakeDbOrFatal()
 	defer db.Close()
@@ -386,18 +602,20 @@ func TestListExperiments_Filtering(t *testing.T) {
 
 	expected := []*model.Experiment{
 		&model.Experiment{
-			UUID:           fakeIDTwo,
-			CreatedAtInSec: 2,
-			Name:           "experiment2",
-			Description:    "My name is experiment2",
-			StorageState:   "AVAILABLE",
+			UUID:            fakeIDTwo,
+			CreatedAtInSec:  2,
+			Name:            "experiment2",
+			Description:     "My name is experiment2",
+			StorageState:    "AVAILABLE",
+			ResourceVersion: 1,
 		},
 		&model.Experiment{
-			UUID:           fakeIDThree,
-			CreatedAtInSec: 3,
-			Name:           "experiment3",
-			Description:    "My name is experiment3",
-			StorageState:   "AVAILABLE",
+			UUID:            fakeIDThree,
+			CreatedAtInSec:  3,
+			Name:            "experiment3",
+			Description:     "My name is experiment3",
+			StorageState:    "AVAILABLE",
+			ResourceVersion: 1,
 		},
 	}
 
@@ -414,11 +632,12 @@ func TestListExperiments_Filtering(t *testing.T) {
 
 	expected = []*model.Experiment{
 		&model.Experiment{
-			UUID:           fakeIDFour,
-			CreatedAtInSec: 4,
-			Name:           "experiment4",
-			Description:    "My name is experiment4",
-			StorageState:   "AVAILABLE",
+			UUID:            fakeIDFour,
+			CreatedAtInSec:  4,
+			Name:            "experiment4",
+			Description:     "My name is experiment4",
+			StorageState:    "AVAILABLE",
+			ResourceVersion: 1,
 		},
 	}
 
@@ -429,6 +648,120 @@ func TestListExperiments_Filtering(t *testing.T) {
 	assert.Equal(t, 3, total_size)
 }
 
+func TestListExperiments_StorageState(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+	experimentStore.CreateExperiment(createExperiment("experiment1"))
+	experimentStore.uuid = util.NewFakeUUIDGeneratorOrFatal(fakeIDTwo, nil)
+	experimentStore.CreateExperiment(createExperiment("experiment2"))
+	experimentStore.uuid = util.NewFakeUUIDGeneratorOrFatal(fakeIDThree, nil)
+	experimentStore.CreateExperiment(createExperiment("experiment3"))
+	err := experimentStore.ArchiveExperiment(fakeIDTwo)
+	assert.Nil(t, err)
+
+	// Archived experiments are hidden by default, and the total size only counts the listed ones.
+	opts, err := list.NewOptions(&model.Experiment{}, 1, "id", nil)
+	assert.Nil(t, err)
+	experiments, total_size, nextPageToken, err := experimentStore.ListExperiments(&common.FilterContext{}, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, total_size)
+	assert.Equal(t, fakeID, experiments[0].UUID)
+	opts, err = list.NewOptionsFromToken(nextPageToken, 1)
+	assert.Nil(t, err)
+	experiments, total_size, nextPageToken, err = experimentStore.ListExperiments(&common.FilterContext{}, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, total_size)
+	assert.Equal(t, fakeIDThree, experiments[0].UUID)
+	assert.Equal(t, "", nextPageToken)
+
+	// A predicate on storage_state replaces the default.
+	filterProto := &api.Filter{
+		Predicates: []*api.Predicate{
+			{
+				Key:   "storage_state",
+				Op:    api.Predicate_EQUALS,
+				Value: &api.Predicate_StringValue{StringValue: "ARCHIVED"},
+			},
+		},
+	}
+	opts, err = list.NewOptions(&model.Experiment{}, 10, "id", filterProto)
+	assert.Nil(t, err)
+	experiments, total_size, _, err = experimentStore.ListExperiments(&common.FilterContext{}, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, total_size)
+	assert.Equal(t, fakeIDTwo, experiments[0].UUID)
+}
+
+func TestSoftDeleteAndRestoreExperiment(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+	experimentStore.CreateExperiment(createExperiment("experiment1"))
+	experimentStore.uuid = util.NewFakeUUIDGeneratorOrFatal(fakeIDTwo, nil)
+	experimentStore.CreateExperiment(createExperiment("experiment2"))
+
+	err := experimentStore.SoftDeleteExperiment(fakeID)
+	assert.Nil(t, err)
+	err = experimentStore.SoftDeleteExperiment(fakeID)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+
+	// Soft deleted experiments are hidden by default.
+	opts, err := list.NewOptions(&model.Experiment{}, 10, "id", nil)
+	assert.Nil(t, err)
+	experiments, total_size, _, err := experimentStore.ListExperiments(&common.FilterContext{}, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, total_size)
+	assert.Equal(t, fakeIDTwo, experiments[0].UUID)
+
+	_, err = experimentStore.GetExperiment(fakeID)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+	experiment, err := experimentStore.GetExperimentIncludingDeleted(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), experiment.DeletedAtInSec)
+
+	// They are listed if the options include deleted resources.
+	opts, err = list.NewOptions(&model.Experiment{}, 10, "id", nil)
+	assert.Nil(t, err)
+	opts.IncludeDeleted = true
+	experiments, total_size, _, err = experimentStore.ListExperiments(&common.FilterContext{}, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, total_size)
+	assert.Equal(t, fakeID, experiments[0].UUID)
+	assert.Equal(t, int64(3), experiments[0].DeletedAtInSec)
+
+	// A predicate on deleted_at alone doesn't include them.
+	deletedFilter := &api.Filter{
+		Predicates: []*api.Predicate{
+			{
+				Key:   "deleted_at",
+				Op:    api.Predicate_GREATER_THAN,
+				Value: &api.Predicate_LongValue{LongValue: 0},
+			},
+		},
+	}
+	opts, err = list.NewOptions(&model.Experiment{}, 10, "id", deletedFilter)
+	assert.Nil(t, err)
+	_, total_size, _, err = experimentStore.ListExperiments(&common.FilterContext{}, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, total_size)
+
+	ids, err := experimentStore.ListExperimentsDeletedBefore(4)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{fakeID}, ids)
+	ids, err = experimentStore.ListExperimentsDeletedBefore(3)
+	assert.Nil(t, err)
+	assert.Empty(t, ids)
+
+	err = experimentStore.RestoreExperiment(fakeID)
+	assert.Nil(t, err)
+	experiment, err = experimentStore.GetExperiment(fakeID)
+	assert.Nil(t, err)
+	assert.Zero(t, experiment.DeletedAtInSec)
+	err = experimentStore.RestoreExperiment(fakeID)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
 func TestArchiveExperiment_InternalError(t *testing.T) {
 	db := NewFakeDbOrFatal()
 	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
@@ -558,7 +891,18 @@ func TestArchiveAndUnarchiveExperiment(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "ARCHIVED", exp.StorageState)
 	opts, err := list.NewOptions(&model.Run{}, 10, "id", nil)
-	runs, total_run_size, _, err := runStore.ListRuns(&common.FilterContext{ReferenceKey: &common.ReferenceKey{Type: common.Experiment, ID: fakeID}}, opts)
+	// Archived runs are only listed when asked for.
+	archivedRunOpts, err := list.NewOptions(&model.Run{}, 10, "id", &api.Filter{
+		Predicates: []*api.Predicate{
+			{
+				Key:   "storage_state",
+				Op:    api.Predicate_EQUALS,
+				Value: &api.Predicate_StringValue{StringValue: api.Run_STORAGESTATE_ARCHIVED.String()},
+			},
+		},
+	})
+	assert.Nil(t, err)
+	runs, total_run_size, _, err := runStore.ListRuns(&common.FilterContext{ReferenceKey: &common.ReferenceKey{Type: common.Experiment, ID: fakeID}}, archivedRunOpts)
 	assert.Nil(t, err)
 	assert.Equal(t, total_run_size, 2)
 	assert.Equal(t, api.Run_STORAGESTATE_ARCHIVED.String(), runs[0].StorageState)
@@ -575,7 +919,7 @@ func TestArchiveAndUnarchiveExperiment(t *testing.T) {
 	exp, err = experimentStore.GetExperiment(fakeID)
 	assert.Nil(t, err)
 	assert.Equal(t, "AVAILABLE", exp.StorageState)
-	runs, total_run_size, _, err = runStore.ListRuns(&common.FilterContext{ReferenceKey: &common.ReferenceKey{Type: common.Experiment, ID: fakeID}}, opts)
+	runs, total_run_size, _, err = runStore.ListRuns(&common.FilterContext{ReferenceKey: &common.ReferenceKey{Type: common.Experiment, ID: fakeID}}, archivedRunOpts)
 	assert.Nil(t, err)
 	assert.Equal(t, total_run_size, 2)
 	assert.Equal(t, api.Run_STORAGESTATE_ARCHIVED.String(), runs[0].StorageState)
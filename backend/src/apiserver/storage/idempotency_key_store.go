@@ -0,0 +1,162 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+var idempotencyKeyColumns = []string{
+	"Namespace",
+	"IdempotencyKey",
+	"RunUUID",
+	"CreatedAtInSec",
+	"ExpiresAtInSec",
+}
+
+type IdempotencyKeyStoreInterface interface {
+	// ClaimIdempotencyKey records runId for the key in the namespace, unless the key already
+	// has an unexpired record. It returns the record for the key and whether it was created by
+	// this call.
+	ClaimIdempotencyKey(namespace string, key string, runId string, ttl time.Duration) (*model.IdempotencyKey, bool, error)
+	// ReleaseIdempotencyKey removes the record for the key in the namespace if it points to runId.
+	ReleaseIdempotencyKey(namespace string, key string, runId string) error
+}
+
+// Implementation of a IdempotencyKeyStoreInterface. This stores the runs created for
+// caller supplied idempotency keys.
+type IdempotencyKeyStore struct {
+	db   *DB
+	time util.TimeInterface
+}
+
+func (s *IdempotencyKeyStore) ClaimIdempotencyKey(namespace string, key string, runId string, ttl time.Duration) (*model.IdempotencyKey, bool, error) {
+	now := s.time.Now().Unix()
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, false, util.NewInternalServerError(err, "Failed to start a transaction to claim idempotency key %q", key)
+	}
+
+	// Expired keys are removed first so that they can be claimed again.
+	deleteSql, deleteArgs, err := sq.Delete("idempotency_keys").Where(sq.LtOrEq{"ExpiresAtInSec": now}).ToSql()
+	if err != nil {
+		tx.Rollback()
+		return nil, false, util.NewInternalServerError(err, "Failed to create query to delete expired idempotency keys")
+	}
+	if _, err = tx.Exec(deleteSql, deleteArgs...); err != nil {
+		tx.Rollback()
+		return nil, false, util.NewInternalServerError(err, "Failed to delete expired idempotency keys")
+	}
+
+	existing, err := s.getIdempotencyKey(tx, namespace, key)
+	if err != nil {
+		tx.Rollback()
+		return nil, false, err
+	}
+	if existing != nil {
+		if err = tx.Commit(); err != nil {
+			return nil, false, util.NewInternalServerError(err, "Failed to commit transaction to claim idempotency key %q", key)
+		}
+		return existing, false, nil
+	}
+
+	record := &model.IdempotencyKey{
+		Namespace:      namespace,
+		IdempotencyKey: key,
+		RunUUID:        runId,
+		CreatedAtInSec: now,
+		ExpiresAtInSec: now + int64(ttl.Seconds()),
+	}
+	insertSql, insertArgs, err := sq.
+		Insert("idempotency_keys").
+		SetMap(sq.Eq{
+			"Namespace":      record.Namespace,
+			"IdempotencyKey": record.IdempotencyKey,
+			"RunUUID":        record.RunUUID,
+			"CreatedAtInSec": record.CreatedAtInSec,
+			"ExpiresAtInSec": record.ExpiresAtInSec,
+		}).
+		ToSql()
+	if err != nil {
+		tx.Rollback()
+		return nil, false, util.NewInternalServerError(err, "Failed to create query to claim idempotency key %q", key)
+	}
+	if _, err = tx.Exec(insertSql, insertArgs...); err != nil {
+		tx.Rollback()
+		// A concurrent request may have claimed the key in the meantime.
+		existing, getErr := s.getIdempotencyKey(s.db, namespace, key)
+		if getErr == nil && existing != nil {
+			return existing, false, nil
+		}
+		return nil, false, util.NewInternalServerError(err, "Failed to claim idempotency key %q", key)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, false, util.NewInternalServerError(err, "Failed to commit transaction to claim idempotency key %q", key)
+	}
+	return record, true, nil
+}
+
+func (s *IdempotencyKeyStore) ReleaseIdempotencyKey(namespace string, key string, runId string) error {
+	sql, args, err := sq.
+		Delete("idempotency_keys").
+		Where(sq.Eq{"Namespace": namespace, "IdempotencyKey": key, "RunUUID": runId}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to release idempotency key %q", key)
+	}
+	if _, err = s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to release idempotency key %q", key)
+	}
+	return nil
+}
+
+// queryer is implemented by both *DB and *sql.Tx.
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func (s *IdempotencyKeyStore) getIdempotencyKey(q queryer, namespace string, key string) (*model.IdempotencyKey, error) {
+	sql, args, err := sq.
+		Select(idempotencyKeyColumns...).
+		From("idempotency_keys").
+		Where(sq.Eq{"Namespace": namespace, "IdempotencyKey": key}).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get idempotency key %q", key)
+	}
+	rows, err := q.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get idempotency key %q", key)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return nil, nil
+	}
+	var record model.IdempotencyKey
+	if err = rows.Scan(&record.Namespace, &record.IdempotencyKey, &record.RunUUID, &record.CreatedAtInSec, &record.ExpiresAtInSec); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to scan idempotency key %q", key)
+	}
+	return &record, nil
+}
+
+// NewIdempotencyKeyStore creates a new IdempotencyKeyStore.
+func NewIdempotencyKeyStore(db *DB, time util.TimeInterface) *IdempotencyKeyStore {
+	return &IdempotencyKeyStore{db: db, time: time}
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaimIdempotencyKey(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewIdempotencyKeyStore(db, util.NewFakeTimeForEpoch())
+
+	record, claimed, err := store.ClaimIdempotencyKey("ns1", "key1", "run1", time.Hour)
+	assert.Nil(t, err)
+	assert.True(t, claimed)
+	assert.Equal(t, &model.IdempotencyKey{
+		Namespace:      "ns1",
+		IdempotencyKey: "key1",
+		RunUUID:        "run1",
+		CreatedAtInSec: 1,
+		ExpiresAtInSec: 3601,
+	}, record)
+
+	// The same key returns the existing record.
+	record, claimed, err = store.ClaimIdempotencyKey("ns1", "key1", "run2", time.Hour)
+	assert.Nil(t, err)
+	assert.False(t, claimed)
+	assert.Equal(t, "run1", record.RunUUID)
+
+	// Keys are scoped by namespace.
+	record, claimed, err = store.ClaimIdempotencyKey("ns2", "key1", "run3", time.Hour)
+	assert.Nil(t, err)
+	assert.True(t, claimed)
+	assert.Equal(t, "run3", record.RunUUID)
+}
+
+func TestClaimIdempotencyKey_Expired(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewIdempotencyKeyStore(db, util.NewFakeTimeForEpoch())
+
+	_, claimed, err := store.ClaimIdempotencyKey("", "key1", "run1", time.Second)
+	assert.Nil(t, err)
+	assert.True(t, claimed)
+
+	// The fake clock has moved past the expiry.
+	record, claimed, err := store.ClaimIdempotencyKey("", "key1", "run2", time.Second)
+	assert.Nil(t, err)
+	assert.True(t, claimed)
+	assert.Equal(t, "run2", record.RunUUID)
+}
+
+func TestReleaseIdempotencyKey(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewIdempotencyKeyStore(db, util.NewFakeTimeForEpoch())
+
+	_, _, err := store.ClaimIdempotencyKey("", "key1", "run1", time.Hour)
+	assert.Nil(t, err)
+
+	// Releasing with another run ID is a no-op.
+	assert.Nil(t, store.ReleaseIdempotencyKey("", "key1", "run2"))
+	_, claimed, err := store.ClaimIdempotencyKey("", "key1", "run2", time.Hour)
+	assert.Nil(t, err)
+	assert.False(t, claimed)
+
+	assert.Nil(t, store.ReleaseIdempotencyKey("", "key1", "run1"))
+	record, claimed, err := store.ClaimIdempotencyKey("", "key1", "run2", time.Hour)
+	assert.Nil(t, err)
+	assert.True(t, claimed)
+	assert.Equal(t, "run2", record.RunUUID)
+}
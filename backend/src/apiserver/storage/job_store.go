@@ -30,16 +30,26 @@ var jobColumns = []string{"UUID", "DisplayName", "Name", "Namespace", "ServiceAc
 	"NoCatchup", "CreatedAtInSec", "UpdatedAtInSec", "Enabled", "CronScheduleStartTimeInSec", "CronScheduleEndTimeInSec",
 	"Schedule", "PeriodicScheduleStartTimeInSec", "PeriodicScheduleEndTimeInSec", "IntervalSecond",
 	"PipelineId", "PipelineName", "PipelineSpecManifest", "WorkflowSpecManifest", "Parameters", "Conditions",
-	"RuntimeParameters", "PipelineRoot",
+	"RuntimeParameters", "PipelineRoot", "ParameterResolution", "ResourceVersion",
 }
 
 type JobStoreInterface interface {
 	ListJobs(filterContext *common.FilterContext, opts *list.Options) ([]*model.Job, int, string, error)
 	GetJob(id string) (*model.Job, error)
+	CountJobs(namespace string) (int, error)
+	CountPipelineVersionJobs(versionId string) (map[string]int, error)
 	CreateJob(*model.Job) (*model.Job, error)
 	DeleteJob(id string) error
 	EnableJob(id string, enabled bool) error
 	UpdateJob(swf *util.ScheduledWorkflow) error
+	UpdateJobParameters(id string, parameters string, expectedVersion int64) error
+	UpdateJobParameterResolution(id string, resolution model.ParameterResolution) error
+	UpdateJobConditions(id string, conditions string) error
+
+	// CompressManifests compresses the manifests of a batch of jobs stored before manifests were compressed.
+	// It returns the UUID of the last job of the batch, or "" if there are no jobs left, and how many jobs were
+	// changed.
+	CompressManifests(afterUUID string, batchSize int) (string, int, error)
 }
 
 type JobStore struct {
@@ -107,7 +117,7 @@ func (s *JobStore) ListJobs(
 		return jobs, total_size, "", nil
 	}
 
-	npt, err := opts.NextPageToken(jobs[opts.PageSize])
+	npt, err := opts.NextPageToken(jobs[opts.PageSize-1])
 	return jobs[:opts.PageSize], total_size, npt, err
 }
 
@@ -128,6 +138,10 @@ func (s *JobStore) buildSelectJobsQuery(selectCount bool, opts *list.Options,
 	if err != nil {
 		return "", nil, util.NewInternalServerError(err, "Failed to list jobs: %v", err)
 	}
+	// Hide the jobs of soft deleted experiments.
+	filteredSelectBuilder = filteredSelectBuilder.Where(fmt.Sprintf(
+		"UUID NOT IN (SELECT ResourceUUID FROM resource_references WHERE ResourceType = ? AND ReferenceType = ? AND ReferenceUUID IN (%s))",
+		softDeletedExperimentsQuery), common.Job, common.Experiment)
 
 	sqlBuilder := opts.AddFilterToSelect(filteredSelectBuilder)
 
@@ -146,6 +160,60 @@ func (s *JobStore) buildSelectJobsQuery(selectCount bool, opts *list.Options,
 	return sql, args, err
 }
 
+// CountJobs counts the jobs of a namespace, leaving out those of soft deleted experiments. An empty namespace
+// counts the jobs of every namespace.
+func (s *JobStore) CountJobs(namespace string) (int, error) {
+	where := sq.And{sq.Expr(fmt.Sprintf(
+		"UUID NOT IN (SELECT ResourceUUID FROM resource_references WHERE ResourceType = ? AND ReferenceType = ? AND ReferenceUUID IN (%s))",
+		softDeletedExperimentsQuery), common.Job, common.Experiment)}
+	if namespace != "" {
+		where = append(where, sq.Eq{"Namespace": namespace})
+	}
+	query, args, err := sq.Select("count(*)").From("jobs").Where(where).ToSql()
+	if err != nil {
+		return 0, util.NewInternalServerError(err, "Failed to create query to count the jobs of namespace %v", namespace)
+	}
+	var count int
+	if err := s.db.QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, util.NewInternalServerError(err, "Failed to count the jobs of namespace %v", namespace)
+	}
+	return count, nil
+}
+
+// CountPipelineVersionJobs counts, by namespace, the jobs that create runs of a pipeline version, enabled or
+// not, with a single grouped query.
+func (s *JobStore) CountPipelineVersionJobs(versionId string) (map[string]int, error) {
+	query, args, err := sq.
+		Select("jobs.Namespace", "count(*)").
+		From("jobs").
+		Join("resource_references AS rr ON rr.ResourceUUID = jobs.UUID").
+		Where(sq.Eq{
+			"rr.ResourceType":  common.Job,
+			"rr.ReferenceType": common.PipelineVersion,
+			"rr.ReferenceUUID": versionId,
+		}).
+		GroupBy("jobs.Namespace").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to count the jobs of pipeline version %v", versionId)
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to count the jobs of pipeline version %v", versionId)
+	}
+	defer rows.Close()
+	counts := map[string]int{}
+	for rows.Next() {
+		var namespace sql.NullString
+		var count int
+		if err := rows.Scan(&namespace, &count); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to scan the job counts of pipeline version %v", versionId)
+		}
+		counts[namespace.String] += count
+	}
+	return counts, nil
+}
+
 func (s *JobStore) GetJob(id string) (*model.Job, error) {
 	sql, args, err := s.addResourceReferences(sq.Select(jobColumns...).From("jobs")).
 		Where(sq.Eq{"uuid": id}).
@@ -185,22 +253,26 @@ func (s *JobStore) scanRows(r *sql.Rows) ([]*model.Job, error) {
 	var jobs []*model.Job
 	for r.Next() {
 		var uuid, displayName, name, namespace, pipelineId, pipelineName, conditions, serviceAccount,
-			description, parameters, pipelineSpecManifest, workflowSpecManifest string
+			description, parameters, pipelineSpecManifest, workflowSpecManifest, parameterResolution string
 		var cronScheduleStartTimeInSec, cronScheduleEndTimeInSec,
 			periodicScheduleStartTimeInSec, periodicScheduleEndTimeInSec, intervalSecond sql.NullInt64
 		var cron, resourceReferencesInString, runtimeParameters, pipelineRoot sql.NullString
 		var enabled, noCatchup bool
-		var createdAtInSec, updatedAtInSec, maxConcurrency int64
+		var createdAtInSec, updatedAtInSec, maxConcurrency, resourceVersion int64
 		err := r.Scan(
 			&uuid, &displayName, &name, &namespace, &serviceAccount, &description,
 			&maxConcurrency, &noCatchup, &createdAtInSec, &updatedAtInSec, &enabled,
 			&cronScheduleStartTimeInSec, &cronScheduleEndTimeInSec, &cron,
 			&periodicScheduleStartTimeInSec, &periodicScheduleEndTimeInSec, &intervalSecond,
 			&pipelineId, &pipelineName, &pipelineSpecManifest, &workflowSpecManifest, &parameters,
-			&conditions, &runtimeParameters, &pipelineRoot, &resourceReferencesInString)
+			&conditions, &runtimeParameters, &pipelineRoot, &parameterResolution, &resourceVersion,
+			&resourceReferencesInString)
 		if err != nil {
 			return nil, err
 		}
+		if err := decompressManifests(&pipelineSpecManifest, &workflowSpecManifest); err != nil {
+			return nil, util.Wrapf(err, "Failed to read the manifests of job %v", uuid)
+		}
 		resourceReferences, err := parseResourceReferences(resourceReferencesInString)
 		runtimeConfig := parseRuntimeConfig(runtimeParameters, pipelineRoot)
 		jobs = append(jobs, &model.Job{
@@ -235,8 +307,10 @@ func (s *JobStore) scanRows(r *sql.Rows) ([]*model.Job, error) {
 				Parameters:           parameters,
 				RuntimeConfig:        runtimeConfig,
 			},
-			CreatedAtInSec: createdAtInSec,
-			UpdatedAtInSec: updatedAtInSec,
+			CreatedAtInSec:      createdAtInSec,
+			UpdatedAtInSec:      updatedAtInSec,
+			ParameterResolution: model.ParameterResolution(parameterResolution),
+			ResourceVersion:     resourceVersion,
 		})
 	}
 	return jobs, nil
@@ -272,6 +346,11 @@ func (s *JobStore) DeleteJob(id string) error {
 }
 
 func (s *JobStore) CreateJob(j *model.Job) (*model.Job, error) {
+	pipelineSpecManifest, workflowSpecManifest := j.PipelineSpecManifest, j.WorkflowSpecManifest
+	if err := compressManifests(&pipelineSpecManifest, &workflowSpecManifest); err != nil {
+		return nil, util.Wrapf(err, "Failed to store job %v", j.Name)
+	}
+	j.ResourceVersion = 1
 	jobSql, jobArgs, err := sq.
 		Insert("jobs").
 		SetMap(sq.Eq{
@@ -295,11 +374,13 @@ func (s *JobStore) CreateJob(j *model.Job) (*model.Job, error) {
 			"UpdatedAtInSec":                 j.UpdatedAtInSec,
 			"PipelineId":                     j.PipelineId,
 			"PipelineName":                   j.PipelineName,
-			"PipelineSpecManifest":           j.PipelineSpecManifest,
-			"WorkflowSpecManifest":           j.WorkflowSpecManifest,
+			"PipelineSpecManifest":           pipelineSpecManifest,
+			"WorkflowSpecManifest":           workflowSpecManifest,
 			"Parameters":                     j.Parameters,
 			"RuntimeParameters":              j.PipelineSpec.RuntimeConfig.Parameters,
 			"PipelineRoot":                   j.PipelineSpec.RuntimeConfig.PipelineRoot,
+			"ParameterResolution":            j.ParameterResolution,
+			"ResourceVersion":                j.ResourceVersion,
 		}).ToSql()
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to create query to add job to job table: %v",
@@ -335,8 +416,9 @@ func (s *JobStore) EnableJob(id string, enabled bool) error {
 	sql, args, err := sq.
 		Update("jobs").
 		SetMap(sq.Eq{
-			"Enabled":        enabled,
-			"UpdatedAtInSec": now}).
+			"Enabled":         enabled,
+			"UpdatedAtInSec":  now,
+			"ResourceVersion": nextResourceVersion}).
 		Where(sq.Eq{"UUID": string(id)}).
 		Where(sq.Eq{"Enabled": !enabled}).
 		ToSql()
@@ -350,6 +432,30 @@ func (s *JobStore) EnableJob(id string, enabled bool) error {
 	return nil
 }
 
+// UpdateJobParameters replaces the V1 parameters recorded for a job. If expectedVersion isn't 0, the update
+// fails with a Conflict error unless the job is still at that resource version.
+func (s *JobStore) UpdateJobParameters(id string, parameters string, expectedVersion int64) error {
+	return s.updateJobColumn(id, "Parameters", parameters, expectedVersion)
+}
+
+// UpdateJobParameterResolution changes how the runs of a job obtain their parameters.
+func (s *JobStore) UpdateJobParameterResolution(id string, resolution model.ParameterResolution) error {
+	return s.updateJobColumn(id, "ParameterResolution", resolution, 0)
+}
+
+// UpdateJobConditions records the status of a job until the persistence agent reports it.
+func (s *JobStore) UpdateJobConditions(id string, conditions string) error {
+	return s.updateJobColumn(id, "Conditions", conditions, 0)
+}
+
+func (s *JobStore) updateJobColumn(id string, column string, value interface{}, expectedVersion int64) error {
+	now := s.time.Now().Unix()
+	return compareAndSwap(s.db, "jobs", "Job", id, expectedVersion, sq.Eq{
+		column:           value,
+		"UpdatedAtInSec": now,
+	})
+}
+
 func (s *JobStore) UpdateJob(swf *util.ScheduledWorkflow) error {
 	now := s.time.Now().Unix()
 	parameters, err := swf.ParametersAsString()
@@ -412,3 +518,7 @@ func NewJobStore(db *DB, time util.TimeInterface) *JobStore {
 		time:                   time,
 	}
 }
+
+func (s *JobStore) CompressManifests(afterUUID string, batchSize int) (string, int, error) {
+	return compressManifestBatch(s.db, "jobs", []string{"PipelineSpecManifest", "WorkflowSpecManifest"}, afterUUID, batchSize)
+}
@@ -127,8 +127,9 @@ func TestListJobs_Pagination(t *testing.T) {
 					IntervalSecond:                 util.Int64Pointer(3),
 				},
 			},
-			CreatedAtInSec: 1,
-			UpdatedAtInSec: 1,
+			CreatedAtInSec:  1,
+			UpdatedAtInSec:  1,
+			ResourceVersion: 1,
 			ResourceReferences: []*model.ResourceReference{
 				{
 					ResourceUUID: "1", ResourceType: common.Job,
@@ -164,10 +165,11 @@ func TestListJobs_Pagination(t *testing.T) {
 					Cron:                       util.StringPointer("1 * *"),
 				},
 			},
-			NoCatchup:      true,
-			CreatedAtInSec: 2,
-			UpdatedAtInSec: 2,
-			Conditions:     "ready",
+			NoCatchup:       true,
+			CreatedAtInSec:  2,
+			UpdatedAtInSec:  2,
+			ResourceVersion: 1,
+			Conditions:      "ready",
 			ResourceReferences: []*model.ResourceReference{
 				{
 					ResourceUUID: "2", ResourceType: common.Job,
@@ -223,6 +225,37 @@ func TestListJobs_TotalSizeWithFilter(t *testing.T) {
 	assert.Equal(t, 1, total_size)
 }
 
+func TestListJobs_FilterByEnabledAndScheduleType(t *testing.T) {
+	db, jobStore := initializeDbAndStore()
+	defer db.Close()
+	listJobs := func(key string, op api.Predicate_Op, value string) []string {
+		opts, err := list.NewOptions(&model.Job{}, 10, "name", &api.Filter{
+			Predicates: []*api.Predicate{{Key: key, Op: op, Value: &api.Predicate_StringValue{StringValue: value}}},
+		})
+		assert.Nil(t, err)
+		jobs, totalSize, _, err := jobStore.ListJobs(&common.FilterContext{}, opts)
+		assert.Nil(t, err)
+		assert.Equal(t, len(jobs), totalSize)
+		ids := []string{}
+		for _, job := range jobs {
+			ids = append(ids, job.UUID)
+		}
+		return ids
+	}
+
+	// Both jobs are past their end time, yet they are still enabled.
+	assert.Equal(t, []string{"1", "2"}, listJobs("enabled", api.Predicate_EQUALS, "true"))
+	assert.Equal(t, []string{}, listJobs("enabled", api.Predicate_EQUALS, "false"))
+	err := jobStore.EnableJob("2", false)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1"}, listJobs("enabled", api.Predicate_EQUALS, "true"))
+	assert.Equal(t, []string{"2"}, listJobs("enabled", api.Predicate_NOT_EQUALS, "true"))
+
+	assert.Equal(t, []string{"2"}, listJobs("schedule_type", api.Predicate_EQUALS, "CRON"))
+	assert.Equal(t, []string{"1"}, listJobs("schedule_type", api.Predicate_EQUALS, "PERIODIC"))
+	assert.Equal(t, []string{"1"}, listJobs("schedule_type", api.Predicate_NOT_EQUALS, "CRON"))
+}
+
 func TestListJobs_Pagination_Descent(t *testing.T) {
 	db, jobStore := initializeDbAndStore()
 	defer db.Close()
@@ -246,9 +279,10 @@ func TestListJobs_Pagination_Descent(t *testing.T) {
 					Cron:                       util.StringPointer("1 * *"),
 				},
 			},
-			NoCatchup:      true,
-			CreatedAtInSec: 2,
-			UpdatedAtInSec: 2,
+			NoCatchup:       true,
+			CreatedAtInSec:  2,
+			UpdatedAtInSec:  2,
+			ResourceVersion: 1,
 			ResourceReferences: []*model.ResourceReference{
 				{
 					ResourceUUID: "2", ResourceType: common.Job,
@@ -284,9 +318,10 @@ func TestListJobs_Pagination_Descent(t *testing.T) {
 					IntervalSecond:                 util.Int64Pointer(3),
 				},
 			},
-			NoCatchup:      false,
-			CreatedAtInSec: 1,
-			UpdatedAtInSec: 1,
+			NoCatchup:       false,
+			CreatedAtInSec:  1,
+			UpdatedAtInSec:  1,
+			ResourceVersion: 1,
 			ResourceReferences: []*model.ResourceReference{
 				{
 					ResourceUUID: "1", ResourceType: common.Job,
@@ -328,8 +363,9 @@ func TestListJobs_Pagination_LessThanPageSize(t *testing.T) {
 					IntervalSecond:                 util.Int64Pointer(3),
 				},
 			},
-			CreatedAtInSec: 1,
-			UpdatedAtInSec: 1,
+			CreatedAtInSec:  1,
+			UpdatedAtInSec:  1,
+			ResourceVersion: 1,
 			ResourceReferences: []*model.ResourceReference{
 				{
 					ResourceUUID: "1", ResourceType: common.Job,
@@ -356,9 +392,10 @@ func TestListJobs_Pagination_LessThanPageSize(t *testing.T) {
 					Cron:                       util.StringPointer("1 * *"),
 				},
 			},
-			NoCatchup:      true,
-			CreatedAtInSec: 2,
-			UpdatedAtInSec: 2,
+			NoCatchup:       true,
+			CreatedAtInSec:  2,
+			UpdatedAtInSec:  2,
+			ResourceVersion: 1,
 			ResourceReferences: []*model.ResourceReference{
 				{
 					ResourceUUID: "2", ResourceType: common.Job,
@@ -400,8 +437,9 @@ func TestListJobs_FilterByReferenceKey(t *testing.T) {
 					IntervalSecond:                 util.Int64Pointer(3),
 				},
 			},
-			CreatedAtInSec: 1,
-			UpdatedAtInSec: 1,
+			CreatedAtInSec:  1,
+			UpdatedAtInSec:  1,
+			ResourceVersion: 1,
 			ResourceReferences: []*model.ResourceReference{
 				{
 					ResourceUUID: "1", ResourceType: common.Job,
@@ -461,9 +499,10 @@ func TestGetJob(t *testing.T) {
 				IntervalSecond:                 util.Int64Pointer(3),
 			},
 		},
-		Enabled:        true,
-		CreatedAtInSec: 1,
-		UpdatedAtInSec: 1,
+		Enabled:         true,
+		CreatedAtInSec:  1,
+		UpdatedAtInSec:  1,
+		ResourceVersion: 1,
 		ResourceReferences: []*model.ResourceReference{
 			{
 				ResourceUUID: "1", ResourceType: common.Job,
@@ -535,9 +574,10 @@ func TestCreateJob(t *testing.T) {
 			PipelineId:   "1",
 			PipelineName: "p1",
 		},
-		Enabled:        true,
-		CreatedAtInSec: 1,
-		UpdatedAtInSec: 1,
+		Enabled:         true,
+		CreatedAtInSec:  1,
+		UpdatedAtInSec:  1,
+		ResourceVersion: 1,
 		ResourceReferences: []*model.ResourceReference{
 			{
 				ResourceUUID: "1", ResourceType: common.Job,
@@ -600,9 +640,10 @@ func TestCreateJob_V2(t *testing.T) {
 				PipelineRoot: "gs://my-bucket/path/to/root/run1",
 			},
 		},
-		Enabled:        true,
-		CreatedAtInSec: 1,
-		UpdatedAtInSec: 1,
+		Enabled:         true,
+		CreatedAtInSec:  1,
+		UpdatedAtInSec:  1,
+		ResourceVersion: 1,
 		ResourceReferences: []*model.ResourceReference{
 			{
 				ResourceUUID: "1", ResourceType: common.Job,
@@ -673,8 +714,9 @@ func TestEnableJob(t *testing.T) {
 				IntervalSecond:                 util.Int64Pointer(3),
 			},
 		},
-		CreatedAtInSec: 1,
-		UpdatedAtInSec: 1,
+		CreatedAtInSec:  1,
+		UpdatedAtInSec:  1,
+		ResourceVersion: 2,
 		ResourceReferences: []*model.ResourceReference{
 			{
 				ResourceUUID: "1", ResourceType: common.Job,
@@ -714,8 +756,9 @@ func TestEnableJob_SkipUpdate(t *testing.T) {
 				IntervalSecond:                 util.Int64Pointer(3),
 			},
 		},
-		CreatedAtInSec: 1,
-		UpdatedAtInSec: 1,
+		CreatedAtInSec:  1,
+		UpdatedAtInSec:  1,
+		ResourceVersion: 1,
 		ResourceReferences: []*model.ResourceReference{
 			{
 				ResourceUUID: "1", ResourceType: common.Job,
@@ -741,6 +784,59 @@ func TestEnableJob_DatabaseError(t *testing.T) {
 	assert.Contains(t, err.Error(), "Error when enabling job 1 to true: sql: database is closed")
 }
 
+func TestUpdateJobParameters(t *testing.T) {
+	db, jobStore := initializeDbAndStore()
+	defer db.Close()
+
+	err := jobStore.UpdateJobParameters("1", `[{"name":"param1","value":"new"}]`, 0)
+	assert.Nil(t, err)
+
+	job, err := jobStore.GetJob("1")
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"name":"param1","value":"new"}]`, job.Parameters)
+	assert.Equal(t, int64(1), job.UpdatedAtInSec)
+
+	err = jobStore.UpdateJobParameters("unknown", "[]", 0)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestUpdateJobParameters_ResourceVersion(t *testing.T) {
+	db, jobStore := initializeDbAndStore()
+	defer db.Close()
+
+	err := jobStore.UpdateJobParameters("1", `[{"name":"param1","value":"first"}]`, 1)
+	assert.Nil(t, err)
+	job, err := jobStore.GetJob("1")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), job.ResourceVersion)
+
+	// An update based on the version read before the first update is rejected.
+	err = jobStore.UpdateJobParameters("1", `[{"name":"param1","value":"second"}]`, 1)
+	assert.Equal(t, codes.Aborted, err.(*util.UserError).ExternalStatusCode())
+	job, err = jobStore.GetJob("1")
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"name":"param1","value":"first"}]`, job.Parameters)
+	assert.Equal(t, int64(2), job.ResourceVersion)
+}
+
+func TestUpdateJobParameterResolution(t *testing.T) {
+	db, jobStore := initializeDbAndStore()
+	defer db.Close()
+
+	job, err := jobStore.GetJob("1")
+	assert.Nil(t, err)
+	assert.Equal(t, model.ParameterResolution(""), job.ParameterResolution)
+
+	err = jobStore.UpdateJobParameterResolution("1", model.ParameterResolutionInherit)
+	assert.Nil(t, err)
+	job, err = jobStore.GetJob("1")
+	assert.Nil(t, err)
+	assert.Equal(t, model.ParameterResolutionInherit, job.ParameterResolution)
+
+	err = jobStore.UpdateJobParameterResolution("unknown", model.ParameterResolutionInherit)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
 func TestUpdateJob_Success(t *testing.T) {
 	db, jobStore := initializeDbAndStore()
 	defer db.Close()
@@ -763,8 +859,9 @@ func TestUpdateJob_Success(t *testing.T) {
 				IntervalSecond:                 util.Int64Pointer(3),
 			},
 		},
-		CreatedAtInSec: 1,
-		UpdatedAtInSec: 1,
+		CreatedAtInSec:  1,
+		UpdatedAtInSec:  1,
+		ResourceVersion: 1,
 		ResourceReferences: []*model.ResourceReference{
 			{
 				ResourceUUID: "1", ResourceType: common.Job,
@@ -823,16 +920,17 @@ func TestUpdateJob_Success(t *testing.T) {
 	assert.Nil(t, err)
 
 	jobExpected = model.Job{
-		UUID:           "1",
-		DisplayName:    "pp 1",
-		Name:           "MY_NAME",
-		Namespace:      "MY_NAMESPACE",
-		Enabled:        false,
-		Conditions:     "Enabled",
-		CreatedAtInSec: 1,
-		UpdatedAtInSec: 1,
-		MaxConcurrency: 200,
-		NoCatchup:      true,
+		UUID:            "1",
+		DisplayName:     "pp 1",
+		Name:            "MY_NAME",
+		Namespace:       "MY_NAMESPACE",
+		Enabled:         false,
+		Conditions:      "Enabled",
+		CreatedAtInSec:  1,
+		UpdatedAtInSec:  1,
+		ResourceVersion: 1,
+		MaxConcurrency:  200,
+		NoCatchup:       true,
 		PipelineSpec: model.PipelineSpec{
 			PipelineId:   "1",
 			PipelineName: "p1",
@@ -886,8 +984,9 @@ func TestUpdateJob_MostlyEmptySpec(t *testing.T) {
 				IntervalSecond:                 util.Int64Pointer(3),
 			},
 		},
-		CreatedAtInSec: 1,
-		UpdatedAtInSec: 1,
+		CreatedAtInSec:  1,
+		UpdatedAtInSec:  1,
+		ResourceVersion: 1,
 		ResourceReferences: []*model.ResourceReference{
 			{
 				ResourceUUID: "1", ResourceType: common.Job,
@@ -913,14 +1012,15 @@ func TestUpdateJob_MostlyEmptySpec(t *testing.T) {
 	assert.Nil(t, err)
 
 	jobExpected = model.Job{
-		UUID:           "1",
-		DisplayName:    "pp 1",
-		Name:           "MY_NAME",
-		Namespace:      "MY_NAMESPACE",
-		Enabled:        false,
-		Conditions:     "NO_STATUS",
-		CreatedAtInSec: 1,
-		UpdatedAtInSec: 1,
+		UUID:            "1",
+		DisplayName:     "pp 1",
+		Name:            "MY_NAME",
+		Namespace:       "MY_NAMESPACE",
+		Enabled:         false,
+		Conditions:      "NO_STATUS",
+		CreatedAtInSec:  1,
+		UpdatedAtInSec:  1,
+		ResourceVersion: 1,
 		PipelineSpec: model.PipelineSpec{
 			PipelineId:   "1",
 			PipelineName: "p1",
@@ -0,0 +1,110 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// LabelStore stores the labels of resources. Labels are always written together with their
+// resource, so the write methods take the transaction of the resource as input.
+type LabelStore struct {
+	db *DB
+}
+
+// CreateLabels attaches labels to a resource.
+func (s *LabelStore) CreateLabels(tx *sql.Tx, resourceType model.ResourceType, resourceId string, labels map[string]string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	sqlBuilder := sq.Insert("labels").Columns("ResourceUUID", "ResourceType", "LabelKey", "LabelValue")
+	for key, value := range labels {
+		sqlBuilder = sqlBuilder.Values(resourceId, resourceType, key, value)
+	}
+	query, args, err := sqlBuilder.ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to store labels of %v %v", resourceType, resourceId)
+	}
+	_, err = tx.Exec(query, args...)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to store labels of %v %v", resourceType, resourceId)
+	}
+	return nil
+}
+
+// DeleteLabels removes all the labels of a resource.
+func (s *LabelStore) DeleteLabels(tx *sql.Tx, resourceType model.ResourceType, resourceId string) error {
+	query, args, err := sq.
+		Delete("labels").
+		Where(sq.Eq{"ResourceUUID": resourceId, "ResourceType": resourceType}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to delete labels of %v %v", resourceType, resourceId)
+	}
+	_, err = tx.Exec(query, args...)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to delete labels of %v %v", resourceType, resourceId)
+	}
+	return nil
+}
+
+// ReplaceLabels replaces all the labels of a resource.
+func (s *LabelStore) ReplaceLabels(tx *sql.Tx, resourceType model.ResourceType, resourceId string, labels map[string]string) error {
+	if err := s.DeleteLabels(tx, resourceType, resourceId); err != nil {
+		return err
+	}
+	return s.CreateLabels(tx, resourceType, resourceId, labels)
+}
+
+// GetLabels returns the labels of the given resources, keyed by resource ID. Resources without
+// labels are left out.
+func (s *LabelStore) GetLabels(resourceType model.ResourceType, resourceIds []string) (map[string]map[string]string, error) {
+	labels := make(map[string]map[string]string)
+	if len(resourceIds) == 0 {
+		return labels, nil
+	}
+	query, args, err := sq.
+		Select("ResourceUUID", "LabelKey", "LabelValue").
+		From("labels").
+		Where(sq.Eq{"ResourceType": resourceType, "ResourceUUID": resourceIds}).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get labels of %v", resourceType)
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get labels of %v", resourceType)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var resourceId, key, value string
+		if err := rows.Scan(&resourceId, &key, &value); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to scan labels of %v", resourceType)
+		}
+		if labels[resourceId] == nil {
+			labels[resourceId] = make(map[string]string)
+		}
+		labels[resourceId][key] = value
+	}
+	return labels, nil
+}
+
+func NewLabelStore(db *DB) *LabelStore {
+	return &LabelStore{db: db}
+}
@@ -0,0 +1,179 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"encoding/base64"
+	"io/ioutil"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// compressedManifestPrefix marks a manifest column that holds the base64 encoded gzip of the manifest. YAML and
+// JSON manifests never start with this control character, so rows stored before compression are read as they are.
+const compressedManifestPrefix = "\x01"
+
+// ManifestCompressor compresses the manifests of the rows stored before manifests were compressed.
+type ManifestCompressor interface {
+	CompressManifests(afterUUID string, batchSize int) (string, int, error)
+}
+
+// compressManifest returns the form of a manifest to store in a manifest column. Manifests that don't get
+// smaller when compressed are stored as they are.
+func compressManifest(manifest string) (string, error) {
+	if manifest == "" {
+		return "", nil
+	}
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if _, err := writer.Write([]byte(manifest)); err != nil {
+		return "", util.NewInternalServerError(err, "Failed to compress the manifest")
+	}
+	if err := writer.Close(); err != nil {
+		return "", util.NewInternalServerError(err, "Failed to compress the manifest")
+	}
+	compressed := compressedManifestPrefix + base64.StdEncoding.EncodeToString(buffer.Bytes())
+	if len(compressed) >= len(manifest) {
+		return manifest, nil
+	}
+	return compressed, nil
+}
+
+// decompressManifest returns the manifest stored in a manifest column, whether or not it was compressed.
+func decompressManifest(stored string) (string, error) {
+	if !strings.HasPrefix(stored, compressedManifestPrefix) {
+		return stored, nil
+	}
+	compressed, err := base64.StdEncoding.DecodeString(stored[len(compressedManifestPrefix):])
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Failed to decode the stored manifest")
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Failed to decompress the stored manifest")
+	}
+	defer reader.Close()
+	manifest, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Failed to decompress the stored manifest")
+	}
+	return string(manifest), nil
+}
+
+// compressManifests compresses, in place, the manifest columns of the given pointers.
+func compressManifests(manifests ...*string) error {
+	for _, manifest := range manifests {
+		compressed, err := compressManifest(*manifest)
+		if err != nil {
+			return err
+		}
+		*manifest = compressed
+	}
+	return nil
+}
+
+// decompressManifests decompresses, in place, the manifest columns of the given pointers.
+func decompressManifests(manifests ...*string) error {
+	for _, manifest := range manifests {
+		decompressed, err := decompressManifest(*manifest)
+		if err != nil {
+			return err
+		}
+		*manifest = decompressed
+	}
+	return nil
+}
+
+// compressManifestBatch compresses the plaintext manifest columns of up to batchSize rows of a table, taking
+// the rows in order of UUID after afterUUID. It returns the UUID of the last row it looked at, or "" if there
+// are no rows left, and how many rows it changed. Each row is updated by its own statement, so the table isn't
+// locked for long, and only if the manifest wasn't changed since it was read.
+func compressManifestBatch(db *DB, table string, columns []string, afterUUID string, batchSize int) (string, int, error) {
+	query, args, err := sq.
+		Select(append([]string{"UUID"}, columns...)...).
+		From(table).
+		Where(sq.Gt{"UUID": afterUUID}).
+		OrderBy("UUID").
+		Limit(uint64(batchSize)).
+		ToSql()
+	if err != nil {
+		return "", 0, util.NewInternalServerError(err, "Failed to create query to list the manifests of %v", table)
+	}
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return "", 0, util.NewInternalServerError(err, "Failed to list the manifests of %v", table)
+	}
+	type storedManifests struct {
+		uuid      string
+		manifests []sql.NullString
+	}
+	var batch []storedManifests
+	for rows.Next() {
+		row := storedManifests{manifests: make([]sql.NullString, len(columns))}
+		dest := []interface{}{&row.uuid}
+		for i := range row.manifests {
+			dest = append(dest, &row.manifests[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			rows.Close()
+			return "", 0, util.NewInternalServerError(err, "Failed to scan the manifests of %v", table)
+		}
+		batch = append(batch, row)
+	}
+	rows.Close()
+	if len(batch) == 0 {
+		return "", 0, nil
+	}
+
+	changed := 0
+	for _, row := range batch {
+		set := sq.Eq{}
+		where := sq.Eq{"UUID": row.uuid}
+		for i, column := range columns {
+			manifest := row.manifests[i].String
+			if strings.HasPrefix(manifest, compressedManifestPrefix) {
+				continue
+			}
+			compressed, err := compressManifest(manifest)
+			if err != nil {
+				return "", changed, err
+			}
+			if compressed != manifest {
+				set[column] = compressed
+				where[column] = manifest
+			}
+		}
+		if len(set) == 0 {
+			continue
+		}
+		query, args, err := sq.Update(table).SetMap(set).Where(where).ToSql()
+		if err != nil {
+			return "", changed, util.NewInternalServerError(err, "Failed to create query to compress the manifests of %v", row.uuid)
+		}
+		result, err := db.Exec(query, args...)
+		if err != nil {
+			return "", changed, util.NewInternalServerError(err, "Failed to compress the manifests of %v", row.uuid)
+		}
+		if affected, _ := result.RowsAffected(); affected > 0 {
+			changed++
+		}
+	}
+	return batch[len(batch)-1].uuid, changed, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var largeManifest = strings.Repeat("apiVersion: argoproj.io/v1alpha1\nkind: Workflow\n", 100)
+
+func storedWorkflowSpecManifest(t *testing.T, db *DB, runId string) string {
+	var manifest string
+	err := db.QueryRow("SELECT WorkflowSpecManifest FROM run_details WHERE UUID = ?", runId).Scan(&manifest)
+	require.Nil(t, err)
+	return manifest
+}
+
+func TestCompressManifest(t *testing.T) {
+	compressed, err := compressManifest(largeManifest)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(compressed, compressedManifestPrefix))
+	assert.Less(t, len(compressed), len(largeManifest))
+	manifest, err := decompressManifest(compressed)
+	assert.Nil(t, err)
+	assert.Equal(t, largeManifest, manifest)
+
+	// Manifests that don't get smaller are stored as they are.
+	compressed, err = compressManifest("kind: Workflow")
+	assert.Nil(t, err)
+	assert.Equal(t, "kind: Workflow", compressed)
+
+	manifest, err = decompressManifest("kind: Workflow")
+	assert.Nil(t, err)
+	assert.Equal(t, "kind: Workflow", manifest)
+
+	_, err = decompressManifest(compressedManifestPrefix + "not base64")
+	assert.NotNil(t, err)
+}
+
+func TestRunStore_CompressesManifests(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	run := &model.RunDetail{
+		Run: model.Run{
+			UUID:           "2000",
+			ExperimentUUID: defaultFakeExpId,
+			Name:           "run",
+			CreatedAtInSec: 1,
+			PipelineSpec:   model.PipelineSpec{WorkflowSpecManifest: largeManifest},
+		},
+	}
+	_, err := runStore.CreateRun(run)
+	require.Nil(t, err)
+	assert.Equal(t, largeManifest, run.WorkflowSpecManifest)
+	assert.True(t, strings.HasPrefix(storedWorkflowSpecManifest(t, db, "2000"), compressedManifestPrefix))
+
+	err = runStore.UpdateRun("2000", "Succeeded", 2, largeManifest)
+	require.Nil(t, err)
+	runDetail, err := runStore.GetRun("2000")
+	require.Nil(t, err)
+	assert.Equal(t, largeManifest, runDetail.WorkflowSpecManifest)
+	assert.Equal(t, largeManifest, runDetail.WorkflowRuntimeManifest)
+}
+
+func TestRunStore_CompressManifests(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	// Rows stored before manifests were compressed hold plaintext.
+	_, err := db.Exec("UPDATE run_details SET WorkflowSpecManifest = ?", largeManifest)
+	require.Nil(t, err)
+	runDetail, err := runStore.GetRun("1")
+	require.Nil(t, err)
+	assert.Equal(t, largeManifest, runDetail.WorkflowSpecManifest)
+
+	lastUUID, changed, err := runStore.CompressManifests("", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, "1", lastUUID)
+	assert.Equal(t, 1, changed)
+	assert.True(t, strings.HasPrefix(storedWorkflowSpecManifest(t, db, "1"), compressedManifestPrefix))
+	assert.False(t, strings.HasPrefix(storedWorkflowSpecManifest(t, db, "2"), compressedManifestPrefix))
+
+	total := changed
+	for lastUUID != "" {
+		lastUUID, changed, err = runStore.CompressManifests(lastUUID, 1)
+		require.Nil(t, err)
+		total += changed
+	}
+	assert.Equal(t, 3, total)
+	assert.True(t, strings.HasPrefix(storedWorkflowSpecManifest(t, db, "2"), compressedManifestPrefix))
+
+	runDetail, err = runStore.GetRun("2")
+	require.Nil(t, err)
+	assert.Equal(t, largeManifest, runDetail.WorkflowSpecManifest)
+
+	// Compressed rows are left alone.
+	_, changed, err = runStore.CompressManifests("", 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, changed)
+}
@@ -15,6 +15,7 @@
 package storage
 
 import (
+	"context"
 	"io"
 
 	minio "github.com/minio/minio-go/v6"
@@ -25,6 +26,7 @@ type MinioClientInterface interface {
 	PutObject(bucketName, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (n int64, err error)
 	GetObject(bucketName, objectName string, opts minio.GetObjectOptions) (io.Reader, error)
 	DeleteObject(bucketName, objectName string) error
+	BucketExistsWithContext(ctx context.Context, bucketName string) (bool, error)
 }
 
 type MinioClient struct {
@@ -42,3 +44,7 @@ func (c *MinioClient) GetObject(bucketName, objectName string, opts minio.GetObj
 func (c *MinioClient) DeleteObject(bucketName, objectName string) error {
 	return c.Client.RemoveObject(bucketName, objectName)
 }
+
+func (c *MinioClient) BucketExistsWithContext(ctx context.Context, bucketName string) (bool, error) {
+	return c.Client.BucketExistsWithContext(ctx, bucketName)
+}
@@ -16,6 +16,7 @@ package storage
 
 import (
 	"bytes"
+	"context"
 	"io"
 
 	"github.com/minio/minio-go/v6"
@@ -56,6 +57,10 @@ func (c *FakeMinioClient) DeleteObject(bucketName, objectName string) error {
 	return nil
 }
 
+func (c *FakeMinioClient) BucketExistsWithContext(ctx context.Context, bucketName string) (bool, error) {
+	return true, nil
+}
+
 func (c *FakeMinioClient) GetObjectCount() int {
 	return len(c.minioClient)
 }
@@ -16,12 +16,21 @@ package storage
 
 import (
 	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
 	"path"
 	"regexp"
+	"time"
 
+	"github.com/cenkalti/backoff"
 	"github.com/ghodss/yaml"
+	"github.com/golang/glog"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	minio "github.com/minio/minio-go/v6"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -33,9 +42,12 @@ type ObjectStoreInterface interface {
 	AddFile(template []byte, filePath string) error
 	DeleteFile(filePath string) error
 	GetFile(filePath string) ([]byte, error)
+	// Get a reader of a file, to read it in chunks rather than all at once.
+	GetFileReader(filePath string) (io.ReadCloser, error)
 	AddAsYamlFile(o interface{}, filePath string) error
 	GetFromYamlFile(o interface{}, filePath string) error
 	GetPipelineKey(pipelineId string) string
+	CheckBucket(ctx context.Context) error
 }
 
 // Managing pipeline using Minio
@@ -44,6 +56,8 @@ type MinioObjectStore struct {
 	bucketName       string
 	baseFolder       string
 	disableMultipart bool
+	// newBackOff returns how storing a file is retried. Nil means newObjectStoreBackOff.
+	newBackOff func() backoff.BackOff
 }
 
 // GetPipelineKey adds the configured base folder to pipeline id.
@@ -61,11 +75,83 @@ func (m *MinioObjectStore) AddFile(file []byte, filePath string) error {
 		parts = multipartDefaultSize
 	}
 
-	_, err := m.minioClient.PutObject(
-		m.bucketName, filePath, bytes.NewReader(file),
-		parts, minio.PutObjectOptions{ContentType: "application/octet-stream"})
+	operation := func() error {
+		_, err := m.minioClient.PutObject(
+			m.bucketName, filePath, bytes.NewReader(file),
+			parts, minio.PutObjectOptions{ContentType: "application/octet-stream"})
+		if err != nil && !isTransientObjectStoreError(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+	newBackOff := m.newBackOff
+	if newBackOff == nil {
+		newBackOff = newObjectStoreBackOff
+	}
+	err := backoff.RetryNotify(operation, newBackOff(), func(err error, wait time.Duration) {
+		glog.Warningf("Failed to store %v in bucket %v, retrying in %v: %v", filePath, m.bucketName, wait, err)
+	})
+	if err != nil {
+		return objectStoreError(err, m.bucketName, filePath)
+	}
+	return nil
+}
+
+// newObjectStoreBackOff returns how storing a file is retried when the object store fails transiently.
+func newObjectStoreBackOff() backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = 200 * time.Millisecond
+	b.MaxElapsedTime = 10 * time.Second
+	return b
+}
+
+// isTransientObjectStoreError returns whether a request to the object store may succeed if retried, because
+// the store couldn't be reached or is overloaded.
+func isTransientObjectStoreError(err error) bool {
+	if _, ok := err.(net.Error); ok {
+		return true
+	}
+	if err == io.ErrUnexpectedEOF {
+		return true
+	}
+	response := minio.ToErrorResponse(err)
+	switch response.Code {
+	case "InternalError", "ServiceUnavailable", "SlowDown", "RequestTimeout", "XMinioServerNotInitialized":
+		return true
+	}
+	return response.StatusCode >= http.StatusInternalServerError || response.StatusCode == http.StatusTooManyRequests
+}
+
+// objectStoreError converts an error of the object store into a user error that tells an unavailable store
+// and a misconfigured one apart from other failures. The messages name the bucket and key, never the
+// credentials.
+func objectStoreError(err error, bucketName string, filePath string) error {
+	if isTransientObjectStoreError(err) {
+		return util.NewUnavailableError(err,
+			"The object store is unavailable, failed to store %v in bucket %v. Retry later", filePath, bucketName)
+	}
+	switch minio.ToErrorResponse(err).Code {
+	case "AccessDenied", "AllAccessDisabled", "AccountProblem", "InvalidAccessKeyId", "SignatureDoesNotMatch":
+		return util.NewFailedPreconditionError(err,
+			"The object store denied storing %v in bucket %v. Check that the credentials of the API server can write to the bucket",
+			filePath, bucketName)
+	case "NoSuchBucket":
+		return util.NewFailedPreconditionError(err,
+			"Bucket %v of the object store doesn't exist. Create it, or configure the API server to use an existing bucket",
+			bucketName)
+	}
+	return util.NewInternalServerError(err, "Failed to store %v", filePath)
+}
+
+// CheckBucket checks that the bucket of the object store can be reached and exists.
+func (m *MinioObjectStore) CheckBucket(ctx context.Context) error {
+	exists, err := m.minioClient.BucketExistsWithContext(ctx, m.bucketName)
 	if err != nil {
-		return util.NewInternalServerError(err, "Failed to store %v", filePath)
+		return util.NewUnavailableError(err, "Failed to reach bucket %v of the object store", m.bucketName)
+	}
+	if !exists {
+		return util.NewFailedPreconditionError(errors.New("bucket doesn't exist"),
+			"Bucket %v of the object store doesn't exist", m.bucketName)
 	}
 	return nil
 }
@@ -98,6 +184,26 @@ func (m *MinioObjectStore) GetFile(filePath string) ([]byte, error) {
 	return bytes, nil
 }
 
+// GetFileReader returns a reader of a file that reads it from the bucket as it goes. The caller closes it.
+func (m *MinioObjectStore) GetFileReader(filePath string) (io.ReadCloser, error) {
+	// The single part signatures are removed from the whole file.
+	if m.disableMultipart {
+		file, err := m.GetFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.NopCloser(bytes.NewReader(file)), nil
+	}
+	reader, err := m.minioClient.GetObject(m.bucketName, filePath, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get %v", filePath)
+	}
+	if readCloser, ok := reader.(io.ReadCloser); ok {
+		return readCloser, nil
+	}
+	return ioutil.NopCloser(reader), nil
+}
+
 func (m *MinioObjectStore) AddAsYamlFile(o interface{}, filePath string) error {
 	bytes, err := yaml.Marshal(o)
 	if err != nil {
@@ -16,9 +16,13 @@ package storage
 
 import (
 	"bytes"
+	"context"
 	"io"
+	"net/http"
 	"testing"
 
+	"github.com/cenkalti/backoff"
+
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	minio "github.com/minio/minio-go/v6"
 	"github.com/pkg/errors"
@@ -44,6 +48,10 @@ func (c *FakeBadMinioClient) DeleteObject(bucketName, objectName string) error {
 	return errors.New("some error")
 }
 
+func (c *FakeBadMinioClient) BucketExistsWithContext(ctx context.Context, bucketName string) (bool, error) {
+	return false, errors.New("some error")
+}
+
 func TestAddFile(t *testing.T) {
 	minioClient := NewFakeMinioClient()
 	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
@@ -59,6 +67,64 @@ func TestAddFileError(t *testing.T) {
 	assert.Equal(t, codes.Internal, error.(*util.UserError).ExternalStatusCode())
 }
 
+// FakeFailingMinioClient fails the first `failures` puts with err, then stores the objects.
+type FakeFailingMinioClient struct {
+	*FakeMinioClient
+	err      error
+	failures int
+	attempts int
+}
+
+func (c *FakeFailingMinioClient) PutObject(bucketName, objectName string, reader io.Reader,
+	objectSize int64, opts minio.PutObjectOptions) (n int64, err error) {
+	c.attempts++
+	if c.attempts <= c.failures {
+		return 0, c.err
+	}
+	return c.FakeMinioClient.PutObject(bucketName, objectName, reader, objectSize, opts)
+}
+
+func newFakeFailingObjectStore(err error, failures int) (*MinioObjectStore, *FakeFailingMinioClient) {
+	minioClient := &FakeFailingMinioClient{FakeMinioClient: NewFakeMinioClient(), err: err, failures: failures}
+	return &MinioObjectStore{
+		minioClient: minioClient,
+		bucketName:  "mlpipeline",
+		baseFolder:  "pipeline",
+		newBackOff: func() backoff.BackOff {
+			return backoff.WithMaxRetries(&backoff.ZeroBackOff{}, 2)
+		},
+	}, minioClient
+}
+
+func TestAddFile_RetriesUnavailableStore(t *testing.T) {
+	unavailable := minio.ErrorResponse{Code: "ServiceUnavailable", StatusCode: http.StatusServiceUnavailable}
+	manager, minioClient := newFakeFailingObjectStore(unavailable, 2)
+	err := manager.AddFile([]byte("abc"), manager.GetPipelineKey("1"))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, minioClient.attempts)
+	assert.True(t, minioClient.ExistObject("pipeline/1"))
+
+	manager, minioClient = newFakeFailingObjectStore(unavailable, 3)
+	err = manager.AddFile([]byte("abc"), manager.GetPipelineKey("1"))
+	assert.Equal(t, 3, minioClient.attempts)
+	assert.Equal(t, codes.Unavailable, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.Error(), "failed to store pipeline/1 in bucket mlpipeline")
+}
+
+func TestAddFile_AccessDenied(t *testing.T) {
+	denied := minio.ErrorResponse{Code: "AccessDenied", StatusCode: http.StatusForbidden, Message: "Access Denied."}
+	manager, minioClient := newFakeFailingObjectStore(denied, 3)
+	err := manager.AddFile([]byte("abc"), manager.GetPipelineKey("1"))
+	assert.Equal(t, 1, minioClient.attempts)
+	assert.Equal(t, codes.FailedPrecondition, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.(*util.UserError).ExternalMessage(), "Check that the credentials of the API server can write to the bucket")
+
+	manager, _ = newFakeFailingObjectStore(minio.ErrorResponse{Code: "NoSuchBucket", StatusCode: http.StatusNotFound}, 3)
+	err = manager.AddFile([]byte("abc"), manager.GetPipelineKey("1"))
+	assert.Equal(t, codes.FailedPrecondition, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.(*util.UserError).ExternalMessage(), "Bucket mlpipeline of the object store doesn't exist")
+}
+
 func TestGetFile(t *testing.T) {
 	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
 	manager.AddFile([]byte("abc"), manager.GetPipelineKey("1"))
@@ -73,6 +139,22 @@ func TestGetFileError(t *testing.T) {
 	assert.Equal(t, codes.Internal, error.(*util.UserError).ExternalStatusCode())
 }
 
+func TestGetFileReader(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	manager.AddFile([]byte("abc"), manager.GetPipelineKey("1"))
+	reader, err := manager.GetFileReader(manager.GetPipelineKey("1"))
+	assert.Nil(t, err)
+	defer reader.Close()
+	file := new(bytes.Buffer)
+	_, err = file.ReadFrom(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", file.String())
+
+	manager = &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipeline"}
+	_, err = manager.GetFileReader(manager.GetPipelineKey("1"))
+	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
+}
+
 func TestDeleteFile(t *testing.T) {
 	minioClient := NewFakeMinioClient()
 	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
@@ -17,6 +17,7 @@ package storage
 import (
 	"database/sql"
 	"fmt"
+	"sort"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/golang/glog"
@@ -36,65 +37,102 @@ var pipelineColumns = []string{
 	"pipelines.Status",
 	"pipelines.Namespace",
 	"pipelines.DefaultVersionId",
+	"pipelines.ResourceVersion",
+	"pipelines.VersionCount",
 	"pipeline_versions.UUID",
 	"pipeline_versions.CreatedAtInSec",
 	"pipeline_versions.Name",
 	"pipeline_versions.Parameters",
+	"pipeline_versions.ParameterSchema",
 	"pipeline_versions.PipelineId",
 	"pipeline_versions.Status",
 	"pipeline_versions.CodeSourceUrl",
+	"pipeline_versions.PackageUrl",
+	"pipeline_versions.PackageFormat",
 	"pipeline_versions.Description",
+	"pipeline_versions.UpdatedAtInSec",
 }
 
+// pipelinesWithVersionCount is the pipelines table along with the number of ready versions of every pipeline,
+// which pipelines are read from. The number is counted by the query reading the pipeline, so that versions
+// being created or deleted at the same time can't make it drift, and can be filtered on as a column.
+var pipelinesWithVersionCount = fmt.Sprintf(
+	"(SELECT pipelines.*, (SELECT COUNT(*) FROM pipeline_versions AS versions WHERE versions.PipelineId = pipelines.UUID AND versions.Status = '%v') AS VersionCount FROM pipelines) AS pipelines",
+	model.PipelineVersionReady)
+
 var pipelineVersionColumns = []string{
 	"pipeline_versions.UUID",
 	"pipeline_versions.CreatedAtInSec",
 	"pipeline_versions.Name",
 	"pipeline_versions.Parameters",
+	"pipeline_versions.ParameterSchema",
 	"pipeline_versions.PipelineId",
 	"pipeline_versions.Status",
 	"pipeline_versions.CodeSourceUrl",
+	"pipeline_versions.PackageUrl",
+	"pipeline_versions.PackageFormat",
 	"pipeline_versions.Description",
+	"pipeline_versions.UpdatedAtInSec",
 }
 
 type PipelineStoreInterface interface {
 	ListPipelines(filterContext *common.FilterContext, opts *list.Options) ([]*model.Pipeline, int, string, error)
 	GetPipeline(pipelineId string) (*model.Pipeline, error)
+	CountPipelines(namespace string) (int, error)
 	GetPipelineByNameAndNamespace(pipelineName string, namespace string) (*model.Pipeline, error)
+	GetPipelineByName(pipelineName string) (*model.Pipeline, error)
 	GetPipelineWithStatus(id string, status model.PipelineStatus) (*model.Pipeline, error)
 	DeletePipeline(pipelineId string) error
 	CreatePipeline(*model.Pipeline) (*model.Pipeline, error)
 	UpdatePipelineStatus(string, model.PipelineStatus) error
-	UpdatePipelineDefaultVersion(string, string) error
+	UpdatePipelineDefaultVersion(pipelineId string, versionId string, expectedVersion int64) error
+	UpdatePipelineLabels(pipelineId string, labels map[string]string, expectedVersion int64) error
 
 	CreatePipelineVersion(*model.PipelineVersion, bool) (*model.PipelineVersion, error)
+	// Create versions of a pipeline, all of them or none.
+	CreatePipelineVersions(versions []*model.PipelineVersion, updatePipelineDefaultVersion bool) ([]*model.PipelineVersion, error)
 	GetPipelineVersion(versionId string) (*model.PipelineVersion, error)
 	GetPipelineVersionWithStatus(versionId string, status model.PipelineVersionStatus) (*model.PipelineVersion, error)
 	ListPipelineVersions(pipelineId string, opts *list.Options) (versions []*model.PipelineVersion, totalSize int, nextPageToken string, err error)
 	DeletePipelineVersion(pipelineVersionId string) error
 	// Change status of a particular version.
 	UpdatePipelineVersionStatus(pipelineVersionId string, status model.PipelineVersionStatus) error
+	// Change the description of a ready version, and nothing else.
+	UpdatePipelineVersionDescription(pipelineVersionId string, description string) error
 	// TODO(jingzhang36): remove this temporary method after resource manager's
 	// CreatePipeline stops using it.
 	UpdatePipelineAndVersionsStatus(id string, status model.PipelineStatus, pipelineVersionId string, pipelineVersionStatus model.PipelineVersionStatus) error
 }
 
 type PipelineStore struct {
-	db   *DB
-	time util.TimeInterface
-	uuid util.UUIDGeneratorInterface
+	db         *DB
+	time       util.TimeInterface
+	uuid       util.UUIDGeneratorInterface
+	labelStore *LabelStore
 }
 
 func (s *PipelineStore) GetPipelineByNameAndNamespace(name string, namespace string) (*model.Pipeline, error) {
+	return s.getReadyPipelineByName(name, sq.Eq{"pipelines.Namespace": namespace})
+}
+
+// GetPipelineByName returns the ready pipeline with the given name in any namespace, the oldest one if
+// several namespaces have a pipeline with that name. Like GetPipelineByNameAndNamespace, the lookup is served
+// by the index on the name and namespace of pipelines.
+func (s *PipelineStore) GetPipelineByName(name string) (*model.Pipeline, error) {
+	return s.getReadyPipelineByName(name, sq.Eq{})
+}
+
+func (s *PipelineStore) getReadyPipelineByName(name string, namespaceFilter sq.Eq) (*model.Pipeline, error) {
 	sql, args, err := sq.
 		Select(pipelineColumns...).
-		From("pipelines").
+		From(pipelinesWithVersionCount).
 		LeftJoin("pipeline_versions on pipelines.DefaultVersionId = pipeline_versions.UUID").
 		Where(sq.And{
 			sq.Eq{"pipelines.name": name},
-			sq.Eq{"pipelines.Namespace": namespace},
+			namespaceFilter,
 			sq.Eq{"pipelines.Status": model.PipelineReady},
 		}).
+		OrderBy("pipelines.CreatedAtInSec", "pipelines.UUID").
 		Limit(1).ToSql()
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to create query to get pipeline by name and namespace: %v", err.Error())
@@ -106,12 +144,15 @@ func (s *PipelineStore) GetPipelineByNameAndNamespace(name string, namespace str
 	defer r.Close()
 	pipelines, err := s.scanRows(r)
 
-	if err != nil || len(pipelines) > 1 {
+	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to get pipeline by name and namespace: %v", err.Error())
 	}
 	if len(pipelines) == 0 {
 		return nil, util.NewResourceNotFoundError("Pipeline", fmt.Sprint(name))
 	}
+	if err := s.addLabels(pipelines); err != nil {
+		return nil, err
+	}
 	return pipelines[0], nil
 }
 
@@ -123,8 +164,9 @@ func (s *PipelineStore) ListPipelines(filterContext *common.FilterContext, opts
 	}
 
 	buildQuery := func(sqlBuilder sq.SelectBuilder) sq.SelectBuilder {
-		query := opts.AddFilterToSelect(sqlBuilder).From("pipelines").
+		query := opts.AddFilterToSelect(sqlBuilder).From(pipelinesWithVersionCount).
 			LeftJoin("pipeline_versions ON pipelines.DefaultVersionId = pipeline_versions.UUID")
+		query = opts.AddLabelFilterToSelect(query, common.Pipeline)
 		if filterContext.ReferenceKey != nil && filterContext.ReferenceKey.Type == common.Namespace {
 			query = query.Where(
 				sq.Eq{"pipelines.Status": model.PipelineReady,
@@ -190,23 +232,43 @@ func (s *PipelineStore) ListPipelines(filterContext *common.FilterContext, opts
 		return errorF(err)
 	}
 
+	if err := s.addLabels(pipelines); err != nil {
+		return nil, 0, "", err
+	}
+
 	if len(pipelines) <= opts.PageSize {
 		return pipelines, total_size, "", nil
 	}
 
-	npt, err := opts.NextPageToken(pipelines[opts.PageSize])
+	npt, err := opts.NextPageToken(pipelines[opts.PageSize-1])
 	return pipelines[:opts.PageSize], total_size, npt, err
 }
 
+// addLabels reads the labels of the given pipelines from the labels table.
+func (s *PipelineStore) addLabels(pipelines []*model.Pipeline) error {
+	ids := make([]string, 0, len(pipelines))
+	for _, pipeline := range pipelines {
+		ids = append(ids, pipeline.UUID)
+	}
+	labels, err := s.labelStore.GetLabels(common.Pipeline, ids)
+	if err != nil {
+		return util.Wrap(err, "Failed to get pipeline labels")
+	}
+	for _, pipeline := range pipelines {
+		pipeline.Labels = labels[pipeline.UUID]
+	}
+	return nil
+}
+
 func (s *PipelineStore) scanRows(rows *sql.Rows) ([]*model.Pipeline, error) {
 	var pipelines []*model.Pipeline
 	for rows.Next() {
 		var uuid, name, parameters, description string
 		var defaultVersionId, namespace sql.NullString
-		var createdAtInSec int64
+		var createdAtInSec, resourceVersion, versionCount int64
 		var status model.PipelineStatus
-		var versionUUID, versionName, versionParameters, versionPipelineId, versionCodeSourceUrl, versionStatus, versionDescription sql.NullString
-		var versionCreatedAtInSec sql.NullInt64
+		var versionUUID, versionName, versionParameters, versionParameterSchema, versionPipelineId, versionCodeSourceUrl, versionPackageUrl, versionPackageFormat, versionStatus, versionDescription sql.NullString
+		var versionCreatedAtInSec, versionUpdatedAtInSec sql.NullInt64
 		if err := rows.Scan(
 			&uuid,
 			&createdAtInSec,
@@ -216,14 +278,20 @@ func (s *PipelineStore) scanRows(rows *sql.Rows) ([]*model.Pipeline, error) {
 			&status,
 			&namespace,
 			&defaultVersionId,
+			&resourceVersion,
+			&versionCount,
 			&versionUUID,
 			&versionCreatedAtInSec,
 			&versionName,
 			&versionParameters,
+			&versionParameterSchema,
 			&versionPipelineId,
 			&versionStatus,
 			&versionCodeSourceUrl,
-			&versionDescription); err != nil {
+			&versionPackageUrl,
+			&versionPackageFormat,
+			&versionDescription,
+			&versionUpdatedAtInSec); err != nil {
 			return nil, err
 		}
 		if defaultVersionId.Valid {
@@ -236,15 +304,21 @@ func (s *PipelineStore) scanRows(rows *sql.Rows) ([]*model.Pipeline, error) {
 				Status:           status,
 				Namespace:        namespace.String,
 				DefaultVersionId: defaultVersionId.String,
+				ResourceVersion:  resourceVersion,
+				VersionCount:     versionCount,
 				DefaultVersion: &model.PipelineVersion{
-					UUID:           versionUUID.String,
-					CreatedAtInSec: versionCreatedAtInSec.Int64,
-					Name:           versionName.String,
-					Parameters:     versionParameters.String,
-					PipelineId:     versionPipelineId.String,
-					Status:         model.PipelineVersionStatus(versionStatus.String),
-					CodeSourceUrl:  versionCodeSourceUrl.String,
-					Description:    versionDescription.String,
+					UUID:            versionUUID.String,
+					CreatedAtInSec:  versionCreatedAtInSec.Int64,
+					Name:            versionName.String,
+					Parameters:      versionParameters.String,
+					ParameterSchema: versionParameterSchema.String,
+					PipelineId:      versionPipelineId.String,
+					Status:          model.PipelineVersionStatus(versionStatus.String),
+					CodeSourceUrl:   versionCodeSourceUrl.String,
+					PackageUrl:      versionPackageUrl.String,
+					PackageFormat:   versionPackageFormat.String,
+					Description:     versionDescription.String,
+					UpdatedAtInSec:  versionUpdatedAtInSec.Int64,
 				}})
 		} else {
 			pipelines = append(pipelines, &model.Pipeline{
@@ -256,12 +330,32 @@ func (s *PipelineStore) scanRows(rows *sql.Rows) ([]*model.Pipeline, error) {
 				Status:           status,
 				Namespace:        namespace.String,
 				DefaultVersionId: "",
+				ResourceVersion:  resourceVersion,
+				VersionCount:     versionCount,
 				DefaultVersion:   nil})
 		}
 	}
 	return pipelines, nil
 }
 
+// CountPipelines counts the ready pipelines of a namespace. An empty namespace counts the pipelines of every
+// namespace, shared ones included.
+func (s *PipelineStore) CountPipelines(namespace string) (int, error) {
+	where := sq.And{sq.Eq{"Status": model.PipelineReady}}
+	if namespace != "" {
+		where = append(where, sq.Eq{"Namespace": namespace})
+	}
+	query, args, err := sq.Select("count(*)").From("pipelines").Where(where).ToSql()
+	if err != nil {
+		return 0, util.NewInternalServerError(err, "Failed to create query to count the pipelines of namespace %v", namespace)
+	}
+	var count int
+	if err := s.db.QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, util.NewInternalServerError(err, "Failed to count the pipelines of namespace %v", namespace)
+	}
+	return count, nil
+}
+
 func (s *PipelineStore) GetPipeline(id string) (*model.Pipeline, error) {
 	return s.GetPipelineWithStatus(id, model.PipelineReady)
 }
@@ -269,7 +363,7 @@ func (s *PipelineStore) GetPipeline(id string) (*model.Pipeline, error) {
 func (s *PipelineStore) GetPipelineWithStatus(id string, status model.PipelineStatus) (*model.Pipeline, error) {
 	sql, args, err := sq.
 		Select(pipelineColumns...).
-		From("pipelines").
+		From(pipelinesWithVersionCount).
 		LeftJoin("pipeline_versions on pipelines.DefaultVersionId = pipeline_versions.UUID").
 		Where(sq.And{sq.Eq{"pipelines.uuid": id}, sq.Eq{"pipelines.Status": status}}).
 		Limit(1).ToSql()
@@ -289,6 +383,9 @@ func (s *PipelineStore) GetPipelineWithStatus(id string, status model.PipelineSt
 	if len(pipelines) == 0 {
 		return nil, util.NewResourceNotFoundError("Pipeline", fmt.Sprint(id))
 	}
+	if err := s.addLabels(pipelines); err != nil {
+		return nil, err
+	}
 	return pipelines[0], nil
 }
 
@@ -298,8 +395,29 @@ func (s *PipelineStore) DeletePipeline(id string) error {
 		return util.NewInternalServerError(err, "Failed to create query to delete pipeline: %v", err.Error())
 	}
 
-	_, err = s.db.Exec(sql, args...)
+	tx, err := s.db.Begin()
 	if err != nil {
+		return util.NewInternalServerError(err, "Failed to start a transaction to delete pipeline: %v", err.Error())
+	}
+	// The versions of the pipeline are deleted with it, so experiments can't default to them anymore.
+	_, err = tx.Exec(
+		`update experiments set DefaultPipelineVersionId = '', ResourceVersion = ResourceVersion + 1
+		where DefaultPipelineVersionId in (select UUID from pipeline_versions where PipelineId = ?)`,
+		id)
+	if err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to clear the experiments defaulting to versions of pipeline: %v", err.Error())
+	}
+	_, err = tx.Exec(sql, args...)
+	if err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to delete pipeline: %v", err.Error())
+	}
+	if err := s.labelStore.DeleteLabels(tx, common.Pipeline, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
 		return util.NewInternalServerError(err, "Failed to delete pipeline: %v", err.Error())
 	}
 	return nil
@@ -318,6 +436,7 @@ func (s *PipelineStore) CreatePipeline(p *model.Pipeline) (*model.Pipeline, erro
 	// TODO(jingzhang36): remove default version id assignment after version API
 	// is ready.
 	newPipeline.DefaultVersionId = id.String()
+	newPipeline.ResourceVersion = 1
 	sql, args, err := sq.
 		Insert("pipelines").
 		SetMap(
@@ -329,7 +448,8 @@ func (s *PipelineStore) CreatePipeline(p *model.Pipeline) (*model.Pipeline, erro
 				"Parameters":       newPipeline.Parameters,
 				"Status":           string(newPipeline.Status),
 				"Namespace":        newPipeline.Namespace,
-				"DefaultVersionId": newPipeline.DefaultVersionId}).
+				"DefaultVersionId": newPipeline.DefaultVersionId,
+				"ResourceVersion":  newPipeline.ResourceVersion}).
 		ToSql()
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to create query to insert pipeline to pipeline table: %v",
@@ -352,18 +472,25 @@ func (s *PipelineStore) CreatePipeline(p *model.Pipeline) (*model.Pipeline, erro
 	newPipeline.DefaultVersion.CreatedAtInSec = now
 	newPipeline.DefaultVersion.PipelineId = id.String()
 	newPipeline.DefaultVersion.UUID = id.String()
+	newPipeline.VersionCount = 0
+	if newPipeline.DefaultVersion.Status == model.PipelineVersionReady {
+		newPipeline.VersionCount = 1
+	}
 	sqlPipelineVersions, argsPipelineVersions, err := sq.
 		Insert("pipeline_versions").
 		SetMap(
 			sq.Eq{
-				"UUID":           newPipeline.DefaultVersion.UUID,
-				"CreatedAtInSec": newPipeline.DefaultVersion.CreatedAtInSec,
-				"Name":           newPipeline.DefaultVersion.Name,
-				"Parameters":     newPipeline.DefaultVersion.Parameters,
-				"Status":         string(newPipeline.DefaultVersion.Status),
-				"PipelineId":     newPipeline.UUID,
-				"Description":    newPipeline.DefaultVersion.Description,
-				"CodeSourceUrl":  newPipeline.DefaultVersion.CodeSourceUrl}).
+				"UUID":            newPipeline.DefaultVersion.UUID,
+				"CreatedAtInSec":  newPipeline.DefaultVersion.CreatedAtInSec,
+				"Name":            newPipeline.DefaultVersion.Name,
+				"Parameters":      newPipeline.DefaultVersion.Parameters,
+				"ParameterSchema": newPipeline.DefaultVersion.ParameterSchema,
+				"Status":          string(newPipeline.DefaultVersion.Status),
+				"PipelineId":      newPipeline.UUID,
+				"Description":     newPipeline.DefaultVersion.Description,
+				"CodeSourceUrl":   newPipeline.DefaultVersion.CodeSourceUrl,
+				"PackageUrl":      newPipeline.DefaultVersion.PackageUrl,
+				"PackageFormat":   newPipeline.DefaultVersion.PackageFormat}).
 		ToSql()
 	if err != nil {
 		return nil, util.NewInternalServerError(err,
@@ -402,6 +529,10 @@ func (s *PipelineStore) CreatePipeline(p *model.Pipeline) (*model.Pipeline, erro
 			"Failed to add pipeline version to pipeline_versions table: %v",
 			err.Error())
 	}
+	if err := s.labelStore.CreateLabels(tx, common.Pipeline, newPipeline.UUID, newPipeline.Labels); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 	if err := tx.Commit(); err != nil {
 		return nil, util.NewInternalServerError(err,
 			`Failed to update pipelines and pipeline_versions in a
@@ -445,6 +576,31 @@ func (s *PipelineStore) UpdatePipelineVersionStatus(id string, status model.Pipe
 	return nil
 }
 
+// UpdatePipelineVersionDescription replaces the description of a ready pipeline version and sets its
+// UpdatedAtInSec to now. The manifest and the other fields of the version are not changed.
+func (s *PipelineStore) UpdatePipelineVersionDescription(id string, description string) error {
+	sql, args, err := sq.
+		Update("pipeline_versions").
+		SetMap(sq.Eq{"Description": description, "UpdatedAtInSec": s.time.Now().Unix()}).
+		Where(sq.Eq{"UUID": id, "Status": model.PipelineVersionReady}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to update the description of pipeline version %v", id)
+	}
+	result, err := s.db.Exec(sql, args...)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to update the description of pipeline version %v", id)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to update the description of pipeline version %v", id)
+	}
+	if rowsAffected == 0 {
+		return util.NewResourceNotFoundError("Version", id)
+	}
+	return nil
+}
+
 func (s *PipelineStore) UpdatePipelineAndVersionsStatus(id string, status model.PipelineStatus, pipelineVersionId string, pipelineVersionStatus model.PipelineVersionStatus) error {
 	tx, err := s.db.Begin()
 	if err != nil {
@@ -495,42 +651,42 @@ func (s *PipelineStore) UpdatePipelineAndVersionsStatus(id string, status model.
 
 // factory function for pipeline store
 func NewPipelineStore(db *DB, time util.TimeInterface, uuid util.UUIDGeneratorInterface) *PipelineStore {
-	return &PipelineStore{db: db, time: time, uuid: uuid}
+	return &PipelineStore{db: db, time: time, uuid: uuid, labelStore: NewLabelStore(db)}
 }
 
 func (s *PipelineStore) CreatePipelineVersion(v *model.PipelineVersion, updatePipelineDefaultVersion bool) (*model.PipelineVersion, error) {
-	newPipelineVersion := *v
-	newPipelineVersion.CreatedAtInSec = s.time.Now().Unix()
-	id, err := s.uuid.NewRandom()
+	versions, err := s.CreatePipelineVersions([]*model.PipelineVersion{v}, updatePipelineDefaultVersion)
 	if err != nil {
-		return nil, util.NewInternalServerError(err, "Failed to create a pipeline version id.")
+		return nil, err
 	}
-	newPipelineVersion.UUID = id.String()
+	return versions[0], nil
+}
 
-	// Prepare queries of inserting new version and updating default version.
-	versionSql, versionArgs, versionErr := sq.
-		Insert("pipeline_versions").
-		SetMap(
-			sq.Eq{
-				"UUID":           newPipelineVersion.UUID,
-				"CreatedAtInSec": newPipelineVersion.CreatedAtInSec,
-				"Name":           newPipelineVersion.Name,
-				"Parameters":     newPipelineVersion.Parameters,
-				"PipelineId":     newPipelineVersion.PipelineId,
-				"Status":         string(newPipelineVersion.Status),
-				"CodeSourceUrl":  newPipelineVersion.CodeSourceUrl,
-				"Description":    newPipelineVersion.Description}).
-		ToSql()
-	if versionErr != nil {
-		return nil, util.NewInternalServerError(
-			versionErr,
-			"Failed to create query to insert version to pipeline version table: %v",
-			versionErr.Error())
+// CreatePipelineVersions creates versions of a pipeline in a single transaction, so either all of them are
+// created or none is. If updatePipelineDefaultVersion is set, the last version becomes the default version
+// of the pipeline.
+func (s *PipelineStore) CreatePipelineVersions(versions []*model.PipelineVersion, updatePipelineDefaultVersion bool) ([]*model.PipelineVersion, error) {
+	if len(versions) == 0 {
+		return nil, util.NewInvalidInputError("Failed to create pipeline versions: no version is given")
 	}
+	newPipelineVersions := make([]*model.PipelineVersion, 0, len(versions))
+	for _, v := range versions {
+		newPipelineVersion := *v
+		newPipelineVersion.CreatedAtInSec = s.time.Now().Unix()
+		id, err := s.uuid.NewRandom()
+		if err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to create a pipeline version id.")
+		}
+		newPipelineVersion.UUID = id.String()
+		newPipelineVersions = append(newPipelineVersions, &newPipelineVersion)
+	}
+	defaultVersion := newPipelineVersions[len(newPipelineVersions)-1]
+
+	// Prepare the query of updating default version.
 	pipelineSql, pipelineArgs, pipelineErr := sq.
 		Update("pipelines").
-		SetMap(sq.Eq{"DefaultVersionId": newPipelineVersion.UUID}).
-		Where(sq.Eq{"UUID": newPipelineVersion.PipelineId}).
+		SetMap(sq.Eq{"DefaultVersionId": defaultVersion.UUID, "ResourceVersion": nextResourceVersion}).
+		Where(sq.Eq{"UUID": defaultVersion.PipelineId}).
 		ToSql()
 	if pipelineErr != nil {
 		return nil, util.NewInternalServerError(
@@ -539,7 +695,7 @@ func (s *PipelineStore) CreatePipelineVersion(v *model.PipelineVersion, updatePi
 			pipelineErr.Error())
 	}
 
-	// In a single transaction, insert new version and update default version.
+	// In a single transaction, insert new versions and update default version.
 	tx, err := s.db.Begin()
 	if err != nil {
 		return nil, util.NewInternalServerError(
@@ -548,15 +704,40 @@ func (s *PipelineStore) CreatePipelineVersion(v *model.PipelineVersion, updatePi
 			err.Error())
 	}
 
-	_, err = tx.Exec(versionSql, versionArgs...)
-	if err != nil {
-		tx.Rollback()
-		if s.db.IsDuplicateError(err) {
-			return nil, util.NewAlreadyExistError(
-				"Failed to create a new pipeline version. The name %v already exist. Please specify a new name.", v.Name)
+	for _, newPipelineVersion := range newPipelineVersions {
+		versionSql, versionArgs, versionErr := sq.
+			Insert("pipeline_versions").
+			SetMap(
+				sq.Eq{
+					"UUID":            newPipelineVersion.UUID,
+					"CreatedAtInSec":  newPipelineVersion.CreatedAtInSec,
+					"Name":            newPipelineVersion.Name,
+					"Parameters":      newPipelineVersion.Parameters,
+					"ParameterSchema": newPipelineVersion.ParameterSchema,
+					"PipelineId":      newPipelineVersion.PipelineId,
+					"Status":          string(newPipelineVersion.Status),
+					"CodeSourceUrl":   newPipelineVersion.CodeSourceUrl,
+					"PackageUrl":      newPipelineVersion.PackageUrl,
+					"PackageFormat":   newPipelineVersion.PackageFormat,
+					"Description":     newPipelineVersion.Description}).
+			ToSql()
+		if versionErr != nil {
+			tx.Rollback()
+			return nil, util.NewInternalServerError(
+				versionErr,
+				"Failed to create query to insert version to pipeline version table: %v",
+				versionErr.Error())
+		}
+		_, err = tx.Exec(versionSql, versionArgs...)
+		if err != nil {
+			tx.Rollback()
+			if s.db.IsDuplicateError(err) {
+				return nil, util.NewAlreadyExistError(
+					"Failed to create a new pipeline version. The name %v already exist. Please specify a new name.", newPipelineVersion.Name)
+			}
+			return nil, util.NewInternalServerError(err, "Failed to add version to pipeline version table: %v",
+				err.Error())
 		}
-		return nil, util.NewInternalServerError(err, "Failed to add version to pipeline version table: %v",
-			err.Error())
 	}
 
 	if updatePipelineDefaultVersion {
@@ -573,23 +754,44 @@ func (s *PipelineStore) CreatePipelineVersion(v *model.PipelineVersion, updatePi
 			err.Error())
 	}
 
-	return &newPipelineVersion, nil
+	return newPipelineVersions, nil
 }
 
-func (s *PipelineStore) UpdatePipelineDefaultVersion(pipelineId string, versionId string) error {
-	sql, args, err := sq.
-		Update("pipelines").
-		SetMap(sq.Eq{"DefaultVersionId": versionId}).
-		Where(sq.Eq{"UUID": pipelineId}).
-		ToSql()
+// UpdatePipelineDefaultVersion sets the default version of a pipeline. If expectedVersion isn't 0, the update
+// fails with a Conflict error unless the pipeline is still at that resource version.
+func (s *PipelineStore) UpdatePipelineDefaultVersion(pipelineId string, versionId string, expectedVersion int64) error {
+	return compareAndSwap(s.db, "pipelines", "Pipeline", pipelineId, expectedVersion, sq.Eq{"DefaultVersionId": versionId})
+}
+
+// UpdatePipelineLabels replaces the labels of a pipeline. The pipeline's versions are not changed. If
+// expectedVersion isn't 0, the update fails with a Conflict error unless the pipeline is still at that
+// resource version.
+func (s *PipelineStore) UpdatePipelineLabels(pipelineId string, labels map[string]string, expectedVersion int64) error {
+	tx, err := s.db.Begin()
 	if err != nil {
-		return util.NewInternalServerError(err, "Failed to create query to update the pipeline default version: %s", err.Error())
+		return util.NewInternalServerError(err, "Failed to start a transaction to update labels of pipeline %v", pipelineId)
 	}
-	_, err = s.db.Exec(sql, args...)
+	var exists bool
+	err = tx.QueryRow("SELECT exists (SELECT 1 FROM pipelines WHERE UUID = ? AND Status = ?)", pipelineId, model.PipelineReady).Scan(&exists)
 	if err != nil {
-		return util.NewInternalServerError(err, "Failed to update the pipeline default version: %s", err.Error())
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to check whether pipeline %v exists", pipelineId)
+	}
+	if !exists {
+		tx.Rollback()
+		return util.NewResourceNotFoundError("Pipeline", pipelineId)
+	}
+	if err := compareAndSwap(tx, "pipelines", "Pipeline", pipelineId, expectedVersion, sq.Eq{}); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := s.labelStore.ReplaceLabels(tx, common.Pipeline, pipelineId, labels); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return util.NewInternalServerError(err, "Failed to update labels of pipeline %v", pipelineId)
 	}
-
 	return nil
 }
 
@@ -626,30 +828,38 @@ func (s *PipelineStore) GetPipelineVersionWithStatus(versionId string, status mo
 func (s *PipelineStore) scanPipelineVersionRows(rows *sql.Rows) ([]*model.PipelineVersion, error) {
 	var pipelineVersions []*model.PipelineVersion
 	for rows.Next() {
-		var uuid, name, parameters, pipelineId, codeSourceUrl, status, description sql.NullString
-		var createdAtInSec sql.NullInt64
+		var uuid, name, parameters, parameterSchema, pipelineId, codeSourceUrl, packageUrl, packageFormat, status, description sql.NullString
+		var createdAtInSec, updatedAtInSec sql.NullInt64
 		if err := rows.Scan(
 			&uuid,
 			&createdAtInSec,
 			&name,
 			&parameters,
+			&parameterSchema,
 			&pipelineId,
 			&status,
 			&codeSourceUrl,
+			&packageUrl,
+			&packageFormat,
 			&description,
+			&updatedAtInSec,
 		); err != nil {
 			return nil, err
 		}
 		if uuid.Valid {
 			pipelineVersions = append(pipelineVersions, &model.PipelineVersion{
-				UUID:           uuid.String,
-				CreatedAtInSec: createdAtInSec.Int64,
-				Name:           name.String,
-				Parameters:     parameters.String,
-				PipelineId:     pipelineId.String,
-				CodeSourceUrl:  codeSourceUrl.String,
-				Status:         model.PipelineVersionStatus(status.String),
-				Description:    description.String})
+				UUID:            uuid.String,
+				CreatedAtInSec:  createdAtInSec.Int64,
+				Name:            name.String,
+				Parameters:      parameters.String,
+				ParameterSchema: parameterSchema.String,
+				PipelineId:      pipelineId.String,
+				CodeSourceUrl:   codeSourceUrl.String,
+				PackageUrl:      packageUrl.String,
+				PackageFormat:   packageFormat.String,
+				Status:          model.PipelineVersionStatus(status.String),
+				Description:     description.String,
+				UpdatedAtInSec:  updatedAtInSec.Int64})
 		}
 	}
 	return pipelineVersions, nil
@@ -659,6 +869,9 @@ func (s *PipelineStore) ListPipelineVersions(pipelineId string, opts *list.Optio
 	errorF := func(err error) ([]*model.PipelineVersion, int, string, error) {
 		return nil, 0, "", util.NewInternalServerError(err, "Failed to list pipeline versions: %v", err)
 	}
+	if opts.SortByFieldName == model.PipelineVersionSemVerSortField {
+		return s.listPipelineVersionsBySemVer(pipelineId, opts)
+	}
 
 	buildQuery := func(sqlBuilder sq.SelectBuilder) sq.SelectBuilder {
 		return opts.AddFilterToSelect(sqlBuilder).
@@ -721,10 +934,75 @@ func (s *PipelineStore) ListPipelineVersions(pipelineId string, opts *list.Optio
 		return pipelineVersions, total_size, "", nil
 	}
 
-	npt, err := opts.NextPageToken(pipelineVersions[opts.PageSize])
+	npt, err := opts.NextPageToken(pipelineVersions[opts.PageSize-1])
 	return pipelineVersions[:opts.PageSize], total_size, npt, err
 }
 
+// listPipelineVersionsBySemVer lists the versions of a pipeline ordered by the semantic versions in their names,
+// which SQL can't sort by. All the matching versions are read and sorted, and then paged. Equal versions are
+// ordered by creation time and then by UUID.
+func (s *PipelineStore) listPipelineVersionsBySemVer(pipelineId string, opts *list.Options) ([]*model.PipelineVersion, int, string, error) {
+	errorF := func(err error) ([]*model.PipelineVersion, int, string, error) {
+		return nil, 0, "", util.NewInternalServerError(err, "Failed to list pipeline versions: %v", err)
+	}
+	rowsSql, rowsArgs, err := opts.AddFilterToSelect(sq.Select(pipelineVersionColumns...)).
+		From("pipeline_versions").
+		Where(sq.And{sq.Eq{"PipelineId": pipelineId}, sq.Eq{"status": model.PipelineVersionReady}}).
+		ToSql()
+	if err != nil {
+		return errorF(err)
+	}
+	rows, err := s.db.Query(rowsSql, rowsArgs...)
+	if err != nil {
+		return errorF(err)
+	}
+	pipelineVersions, err := s.scanPipelineVersionRows(rows)
+	rows.Close()
+	if err != nil {
+		return errorF(err)
+	}
+
+	less := func(a *model.PipelineVersion, b *model.PipelineVersion) bool {
+		if c := util.CompareSemVerNames(a.Name, b.Name); c != 0 {
+			return (c < 0) != opts.IsDesc
+		}
+		if a.CreatedAtInSec != b.CreatedAtInSec {
+			return (a.CreatedAtInSec < b.CreatedAtInSec) != opts.IsDesc
+		}
+		return (a.UUID < b.UUID) != opts.IsDesc
+	}
+	sort.SliceStable(pipelineVersions, func(i, j int) bool { return less(pipelineVersions[i], pipelineVersions[j]) })
+
+	// The page token holds the name and UUID of the last version of the previous page.
+	start := 0
+	if name, ok := opts.SortByFieldValue.(string); ok {
+		lastUUID, _ := opts.KeyFieldValue.(string)
+		start = -1
+		for i, version := range pipelineVersions {
+			if version.UUID == lastUUID {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			// The last version was deleted since. Resume at the first version after it, ignoring creation time.
+			start = sort.Search(len(pipelineVersions), func(i int) bool {
+				c := util.CompareSemVerNames(pipelineVersions[i].Name, name)
+				if c == 0 {
+					return (pipelineVersions[i].UUID > lastUUID) != opts.IsDesc
+				}
+				return (c > 0) != opts.IsDesc
+			})
+		}
+	}
+	page := pipelineVersions[start:]
+	if len(page) <= opts.PageSize {
+		return page, len(pipelineVersions), "", nil
+	}
+	npt, err := opts.NextPageToken(page[opts.PageSize-1])
+	return page[:opts.PageSize], len(pipelineVersions), npt, err
+}
+
 func (s *PipelineStore) DeletePipelineVersion(versionId string) error {
 	// If this version is used as default version for a pipeline, we have to
 	// find a new default version for that pipeline, which is usually the latest
@@ -750,6 +1028,28 @@ func (s *PipelineStore) DeletePipelineVersion(versionId string) error {
 			"Failed to delete pipeline version: %v",
 			err.Error())
 	}
+	// Experiments that default to the version no longer have a default version.
+	_, err = tx.Exec(
+		"update experiments set DefaultPipelineVersionId = '', ResourceVersion = ResourceVersion + 1 where DefaultPipelineVersionId = ?",
+		versionId)
+	if err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(
+			err,
+			"Failed to clear the experiments defaulting to pipeline version: %v",
+			err.Error())
+	}
+	// Runs and jobs created from the version no longer reference it.
+	_, err = tx.Exec(
+		"delete from resource_references where ReferenceUUID = ? and ReferenceType = ?",
+		versionId, common.PipelineVersion)
+	if err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(
+			err,
+			"Failed to delete the references to pipeline version: %v",
+			err.Error())
+	}
 
 	// (2) check whether this version is used as default version.
 	r, err := tx.Query(
@@ -816,7 +1116,7 @@ func (s *PipelineStore) DeletePipelineVersion(versionId string) error {
 		// No new default version. The pipeline's default version id will be
 		// null.
 		_, err = tx.Exec(
-			"update pipelines set DefaultVersionId = null where UUID = ?",
+			"update pipelines set DefaultVersionId = null, ResourceVersion = ResourceVersion + 1 where UUID = ?",
 			pipelineId)
 		if err != nil {
 			tx.Rollback()
@@ -827,7 +1127,7 @@ func (s *PipelineStore) DeletePipelineVersion(versionId string) error {
 		}
 	} else {
 		_, err = tx.Exec(
-			"update pipelines set DefaultVersionId = ? where UUID = ?",
+			"update pipelines set DefaultVersionId = ?, ResourceVersion = ResourceVersion + 1 where UUID = ?",
 			newDefaultVersionId, pipelineId)
 		if err != nil {
 			tx.Rollback()
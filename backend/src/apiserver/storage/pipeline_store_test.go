@@ -68,6 +68,8 @@ func TestListPipelines_FilterOutNotReady(t *testing.T) {
 		Parameters:       `[{"Name": "param1"}]`,
 		Status:           model.PipelineReady,
 		DefaultVersionId: defaultFakePipelineId,
+		ResourceVersion:  1,
+		VersionCount:     1,
 		DefaultVersion: &model.PipelineVersion{
 			UUID:           defaultFakePipelineId,
 			CreatedAtInSec: 1,
@@ -83,6 +85,8 @@ func TestListPipelines_FilterOutNotReady(t *testing.T) {
 		Parameters:       `[{"Name": "param1"}]`,
 		Status:           model.PipelineReady,
 		DefaultVersionId: defaultFakePipelineIdTwo,
+		ResourceVersion:  1,
+		VersionCount:     1,
 		DefaultVersion: &model.PipelineVersion{
 			UUID:           defaultFakePipelineIdTwo,
 			CreatedAtInSec: 2,
@@ -120,6 +124,8 @@ func TestListPipelines_WithFilter(t *testing.T) {
 		Parameters:       `[{"Name": "param1"}]`,
 		Status:           model.PipelineReady,
 		DefaultVersionId: defaultFakePipelineId,
+		ResourceVersion:  1,
+		VersionCount:     1,
 		DefaultVersion: &model.PipelineVersion{
 			UUID:           defaultFakePipelineId,
 			CreatedAtInSec: 1,
@@ -150,6 +156,52 @@ func TestListPipelines_WithFilter(t *testing.T) {
 	assert.Equal(t, pipelinesExpected, pipelines)
 }
 
+func TestListPipelines_HasVersions(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineId, nil))
+	pipelineStore.CreatePipeline(createPipeline("pipeline1"))
+	pipelineStore.uuid = util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineIdTwo, nil)
+	pipelineStore.CreatePipeline(createPipeline("pipeline2"))
+	pipelineStore.uuid = util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineIdThree, nil)
+	_, err := pipelineStore.CreatePipelineVersion(&model.PipelineVersion{
+		Name:       "pipeline1_v2",
+		PipelineId: defaultFakePipelineId,
+		Status:     model.PipelineVersionReady,
+	}, false)
+	assert.Nil(t, err)
+	// Versions being deleted aren't counted.
+	assert.Nil(t, pipelineStore.UpdatePipelineVersionStatus(defaultFakePipelineIdTwo, model.PipelineVersionDeleting))
+
+	pipeline, err := pipelineStore.GetPipeline(defaultFakePipelineId)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), pipeline.VersionCount)
+
+	listPipelines := func(hasVersions string) []*model.Pipeline {
+		opts, err := list.NewOptions(&model.Pipeline{}, 10, "id", &api.Filter{
+			Predicates: []*api.Predicate{{Key: "has_versions", Op: api.Predicate_EQUALS, Value: &api.Predicate_StringValue{StringValue: hasVersions}}},
+		})
+		assert.Nil(t, err)
+		pipelines, totalSize, _, err := pipelineStore.ListPipelines(&common.FilterContext{}, opts)
+		assert.Nil(t, err)
+		assert.Equal(t, len(pipelines), totalSize)
+		return pipelines
+	}
+	pipelines := listPipelines("true")
+	assert.Equal(t, 1, len(pipelines))
+	assert.Equal(t, defaultFakePipelineId, pipelines[0].UUID)
+	assert.Equal(t, int64(2), pipelines[0].VersionCount)
+	pipelines = listPipelines("false")
+	assert.Equal(t, 1, len(pipelines))
+	assert.Equal(t, defaultFakePipelineIdTwo, pipelines[0].UUID)
+	assert.Equal(t, int64(0), pipelines[0].VersionCount)
+
+	assert.Nil(t, pipelineStore.DeletePipelineVersion(defaultFakePipelineIdThree))
+	pipeline, err = pipelineStore.GetPipeline(defaultFakePipelineId)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), pipeline.VersionCount)
+}
+
 func TestListPipelines_Pagination(t *testing.T) {
 	db := NewFakeDbOrFatal()
 	defer db.Close()
@@ -168,6 +220,8 @@ func TestListPipelines_Pagination(t *testing.T) {
 		Parameters:       `[{"Name": "param1"}]`,
 		Status:           model.PipelineReady,
 		DefaultVersionId: defaultFakePipelineId,
+		ResourceVersion:  1,
+		VersionCount:     1,
 		DefaultVersion: &model.PipelineVersion{
 			UUID:           defaultFakePipelineId,
 			CreatedAtInSec: 1,
@@ -183,6 +237,8 @@ func TestListPipelines_Pagination(t *testing.T) {
 		Parameters:       `[{"Name": "param1"}]`,
 		Status:           model.PipelineReady,
 		DefaultVersionId: defaultFakePipelineIdFour,
+		ResourceVersion:  1,
+		VersionCount:     1,
 		DefaultVersion: &model.PipelineVersion{
 			UUID:           defaultFakePipelineIdFour,
 			CreatedAtInSec: 4,
@@ -208,6 +264,8 @@ func TestListPipelines_Pagination(t *testing.T) {
 		Parameters:       `[{"Name": "param1"}]`,
 		Status:           model.PipelineReady,
 		DefaultVersionId: defaultFakePipelineIdTwo,
+		ResourceVersion:  1,
+		VersionCount:     1,
 		DefaultVersion: &model.PipelineVersion{
 			UUID:           defaultFakePipelineIdTwo,
 			CreatedAtInSec: 2,
@@ -223,6 +281,8 @@ func TestListPipelines_Pagination(t *testing.T) {
 		Parameters:       `[{"Name": "param1"}]`,
 		Status:           model.PipelineReady,
 		DefaultVersionId: defaultFakePipelineIdThree,
+		ResourceVersion:  1,
+		VersionCount:     1,
 		DefaultVersion: &model.PipelineVersion{
 			UUID:           defaultFakePipelineIdThree,
 			CreatedAtInSec: 3,
@@ -262,6 +322,8 @@ func TestListPipelines_Pagination_Descend(t *testing.T) {
 		Parameters:       `[{"Name": "param1"}]`,
 		Status:           model.PipelineReady,
 		DefaultVersionId: defaultFakePipelineIdTwo,
+		ResourceVersion:  1,
+		VersionCount:     1,
 		DefaultVersion: &model.PipelineVersion{
 			UUID:           defaultFakePipelineIdTwo,
 			CreatedAtInSec: 2,
@@ -277,6 +339,8 @@ func TestListPipelines_Pagination_Descend(t *testing.T) {
 		Parameters:       `[{"Name": "param1"}]`,
 		Status:           model.PipelineReady,
 		DefaultVersionId: defaultFakePipelineIdThree,
+		ResourceVersion:  1,
+		VersionCount:     1,
 		DefaultVersion: &model.PipelineVersion{
 			UUID:           defaultFakePipelineIdThree,
 			CreatedAtInSec: 3,
@@ -302,6 +366,8 @@ func TestListPipelines_Pagination_Descend(t *testing.T) {
 		Parameters:       `[{"Name": "param1"}]`,
 		Status:           model.PipelineReady,
 		DefaultVersionId: defaultFakePipelineId,
+		ResourceVersion:  1,
+		VersionCount:     1,
 		DefaultVersion: &model.PipelineVersion{
 			UUID:           defaultFakePipelineId,
 			CreatedAtInSec: 1,
@@ -317,6 +383,8 @@ func TestListPipelines_Pagination_Descend(t *testing.T) {
 		Parameters:       `[{"Name": "param1"}]`,
 		Status:           model.PipelineReady,
 		DefaultVersionId: defaultFakePipelineIdFour,
+		ResourceVersion:  1,
+		VersionCount:     1,
 		DefaultVersion: &model.PipelineVersion{
 			UUID:           defaultFakePipelineIdFour,
 			CreatedAtInSec: 4,
@@ -348,6 +416,8 @@ func TestListPipelines_Pagination_LessThanPageSize(t *testing.T) {
 		Parameters:       `[{"Name": "param1"}]`,
 		Status:           model.PipelineReady,
 		DefaultVersionId: defaultFakePipelineId,
+		ResourceVersion:  1,
+		VersionCount:     1,
 		DefaultVersion: &model.PipelineVersion{
 			UUID:           defaultFakePipelineId,
 			CreatedAtInSec: 1,
@@ -390,6 +460,8 @@ func TestGetPipeline(t *testing.T) {
 		Parameters:       `[{"Name": "param1"}]`,
 		Status:           model.PipelineReady,
 		DefaultVersionId: defaultFakePipelineId,
+		ResourceVersion:  1,
+		VersionCount:     1,
 		DefaultVersion: &model.PipelineVersion{
 			UUID:           defaultFakePipelineId,
 			CreatedAtInSec: 1,
@@ -479,6 +551,8 @@ func TestCreatePipeline(t *testing.T) {
 		Parameters:       `[{"Name": "param1"}]`,
 		Status:           model.PipelineReady,
 		DefaultVersionId: defaultFakePipelineId,
+		ResourceVersion:  1,
+		VersionCount:     1,
 		DefaultVersion: &model.PipelineVersion{
 			UUID:           defaultFakePipelineId,
 			CreatedAtInSec: 1,
@@ -541,6 +615,115 @@ func TestDeletePipelineError(t *testing.T) {
 	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
 }
 
+func TestCreatePipeline_WithLabels(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineId, nil))
+	p := createPipeline("pipeline1")
+	p.Labels = map[string]string{"team": "ml", "env": "prod"}
+	_, err := pipelineStore.CreatePipeline(p)
+	assert.Nil(t, err)
+
+	pipeline, err := pipelineStore.GetPipeline(defaultFakePipelineId)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"team": "ml", "env": "prod"}, pipeline.Labels)
+}
+
+func TestListPipelines_FilterByLabel(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineId, nil))
+	p1 := createPipeline("pipeline1")
+	p1.Labels = map[string]string{"team": "ml"}
+	pipelineStore.CreatePipeline(p1)
+	pipelineStore.uuid = util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineIdTwo, nil)
+	p2 := createPipeline("pipeline2")
+	p2.Labels = map[string]string{"team": "infra"}
+	pipelineStore.CreatePipeline(p2)
+	pipelineStore.uuid = util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineIdThree, nil)
+	pipelineStore.CreatePipeline(createPipeline("pipeline3"))
+
+	filterProto := &api.Filter{
+		Predicates: []*api.Predicate{
+			{
+				Key:   "labels.team",
+				Op:    api.Predicate_EQUALS,
+				Value: &api.Predicate_StringValue{StringValue: "ml"},
+			},
+		},
+	}
+	opts, err := list.NewOptions(&model.Pipeline{}, 10, "id", filterProto)
+	assert.Nil(t, err)
+	pipelines, totalSize, nextPageToken, err := pipelineStore.ListPipelines(&common.FilterContext{}, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, "", nextPageToken)
+	assert.Equal(t, 1, totalSize)
+	assert.Len(t, pipelines, 1)
+	assert.Equal(t, defaultFakePipelineId, pipelines[0].UUID)
+	assert.Equal(t, map[string]string{"team": "ml"}, pipelines[0].Labels)
+}
+
+func TestUpdatePipelineLabels(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineId, nil))
+	p := createPipeline("pipeline1")
+	p.Labels = map[string]string{"team": "ml", "env": "prod"}
+	pipelineStore.CreatePipeline(p)
+
+	err := pipelineStore.UpdatePipelineLabels(defaultFakePipelineId, map[string]string{"team": "infra"}, 0)
+	assert.Nil(t, err)
+	pipeline, err := pipelineStore.GetPipeline(defaultFakePipelineId)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"team": "infra"}, pipeline.Labels)
+}
+
+func TestUpdatePipelineLabels_ResourceVersion(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineId, nil))
+	p := createPipeline("pipeline1")
+	p.Labels = map[string]string{"team": "ml"}
+	pipelineStore.CreatePipeline(p)
+
+	err := pipelineStore.UpdatePipelineLabels(defaultFakePipelineId, map[string]string{"team": "infra"}, 1)
+	assert.Nil(t, err)
+	err = pipelineStore.UpdatePipelineLabels(defaultFakePipelineId, map[string]string{"team": "data"}, 1)
+	assert.Equal(t, codes.Aborted, err.(*util.UserError).ExternalStatusCode())
+	pipeline, err := pipelineStore.GetPipeline(defaultFakePipelineId)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"team": "infra"}, pipeline.Labels)
+	assert.Equal(t, int64(2), pipeline.ResourceVersion)
+
+	err = pipelineStore.UpdatePipelineDefaultVersion(defaultFakePipelineId, defaultFakePipelineId, 2)
+	assert.Nil(t, err)
+	pipeline, err = pipelineStore.GetPipeline(defaultFakePipelineId)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), pipeline.ResourceVersion)
+}
+
+func TestUpdatePipelineLabels_NotFound(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineId, nil))
+	err := pipelineStore.UpdatePipelineLabels(defaultFakePipelineId, map[string]string{"team": "ml"}, 0)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestDeletePipeline_DeletesLabels(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineId, nil))
+	p := createPipeline("pipeline1")
+	p.Labels = map[string]string{"team": "ml"}
+	pipelineStore.CreatePipeline(p)
+	err := pipelineStore.DeletePipeline(defaultFakePipelineId)
+	assert.Nil(t, err)
+	labels, err := pipelineStore.labelStore.GetLabels(common.Pipeline, []string{defaultFakePipelineId})
+	assert.Nil(t, err)
+	assert.Empty(t, labels)
+}
+
 func TestUpdatePipelineStatus(t *testing.T) {
 	db := NewFakeDbOrFatal()
 	defer db.Close()
@@ -554,6 +737,7 @@ func TestUpdatePipelineStatus(t *testing.T) {
 		Parameters:       `[{"Name": "param1"}]`,
 		Status:           model.PipelineDeleting,
 		DefaultVersionId: defaultFakePipelineId,
+		ResourceVersion:  1,
 		DefaultVersion: &model.PipelineVersion{
 			UUID:           defaultFakePipelineId,
 			CreatedAtInSec: 1,
@@ -726,6 +910,49 @@ func TestCreatePipelineVersion_DuplicateKey(t *testing.T) {
 	assert.Contains(t, err.Error(), "The name pipeline_version_1 already exist")
 }
 
+func TestCreatePipelineVersions(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(
+		db,
+		util.NewFakeTimeForEpoch(),
+		util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineId, nil))
+	pipelineStore.CreatePipeline(
+		&model.Pipeline{
+			Name:   "pipeline_1",
+			Status: model.PipelineReady,
+		})
+
+	pipelineStore.uuid = util.NewUUIDGenerator()
+	versions, err := pipelineStore.CreatePipelineVersions([]*model.PipelineVersion{
+		{Name: "v1", PipelineId: defaultFakePipelineId, Status: model.PipelineVersionReady},
+		{Name: "v2", PipelineId: defaultFakePipelineId, Status: model.PipelineVersionReady},
+	}, true)
+	assert.Nil(t, err)
+	assert.Len(t, versions, 2)
+	assert.Equal(t, "v1", versions[0].Name)
+	assert.Equal(t, "v2", versions[1].Name)
+	pipeline, err := pipelineStore.GetPipeline(defaultFakePipelineId)
+	assert.Nil(t, err)
+	assert.Equal(t, versions[1].UUID, pipeline.DefaultVersionId)
+
+	// A version of the batch fails, so none is created.
+	_, err = pipelineStore.CreatePipelineVersions([]*model.PipelineVersion{
+		{Name: "v3", PipelineId: defaultFakePipelineId, Status: model.PipelineVersionReady},
+		{Name: "v1", PipelineId: defaultFakePipelineId, Status: model.PipelineVersionReady},
+	}, true)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "The name v1 already exist")
+	opts, err := list.NewOptions(&model.PipelineVersion{}, 10, "id", nil)
+	assert.Nil(t, err)
+	_, totalSize, _, err := pipelineStore.ListPipelineVersions(defaultFakePipelineId, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, totalSize)
+	pipeline, err = pipelineStore.GetPipeline(defaultFakePipelineId)
+	assert.Nil(t, err)
+	assert.Equal(t, versions[1].UUID, pipeline.DefaultVersionId)
+}
+
 func TestCreatePipelineVersion_InternalServerError_DBClosed(t *testing.T) {
 	db := NewFakeDbOrFatal()
 	defer db.Close()
@@ -796,6 +1023,54 @@ func TestDeletePipelineVersion(t *testing.T) {
 	assert.Equal(t, pipeline.DefaultVersionId, defaultFakePipelineIdTwo)
 }
 
+func TestDeletePipelineVersion_ClearsExperimentDefaultVersion(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(
+		db,
+		util.NewFakeTimeForEpoch(),
+		util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineId, nil))
+	pipelineStore.CreatePipeline(
+		&model.Pipeline{
+			Name:   "pipeline_1",
+			Status: model.PipelineReady,
+		})
+	pipelineStore.uuid = util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineIdTwo, nil)
+	pipelineStore.CreatePipelineVersion(
+		&model.PipelineVersion{
+			Name:       "pipeline_version_1",
+			PipelineId: defaultFakePipelineId,
+			Status:     model.PipelineVersionReady,
+		}, true)
+	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+	experimentStore.CreateExperiment(&model.Experiment{Name: "experiment1"})
+	err := experimentStore.SetExperimentDefaultPipelineVersion(fakeID, defaultFakePipelineIdTwo)
+	assert.Nil(t, err)
+
+	err = pipelineStore.DeletePipelineVersion(defaultFakePipelineIdTwo)
+	assert.Nil(t, err)
+
+	experiment, err := experimentStore.GetExperiment(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, "", experiment.DefaultPipelineVersionId)
+
+	// Deleting a pipeline clears the experiments defaulting to any of its versions.
+	pipelineStore.uuid = util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineIdThree, nil)
+	pipelineStore.CreatePipelineVersion(
+		&model.PipelineVersion{
+			Name:       "pipeline_version_2",
+			PipelineId: defaultFakePipelineId,
+			Status:     model.PipelineVersionReady,
+		}, true)
+	err = experimentStore.SetExperimentDefaultPipelineVersion(fakeID, defaultFakePipelineIdThree)
+	assert.Nil(t, err)
+	err = pipelineStore.DeletePipeline(defaultFakePipelineId)
+	assert.Nil(t, err)
+	experiment, err = experimentStore.GetExperiment(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, "", experiment.DefaultPipelineVersionId)
+}
+
 func TestDeletePipelineVersionError(t *testing.T) {
 	db := NewFakeDbOrFatal()
 	defer db.Close()
@@ -1363,6 +1638,75 @@ func TestListPipelineVersions_WithFilter(t *testing.T) {
 	assert.Equal(t, 2, totalSize)
 }
 
+func TestListPipelineVersions_SortBySemVer(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(
+		db,
+		util.NewFakeTimeForEpoch(),
+		util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineId, nil))
+	pipelineStore.CreatePipeline(
+		&model.Pipeline{
+			Name:       "pipeline_1",
+			Parameters: `[{"Name": "param1"}]`,
+			Status:     model.PipelineReady,
+		})
+	for name, uuid := range map[string]string{
+		"v1.10": defaultFakePipelineIdTwo,
+		"draft": defaultFakePipelineIdThree,
+		"v1.9":  defaultFakePipelineIdFour,
+		"v1.2":  defaultFakePipelineIdFive,
+	} {
+		pipelineStore.uuid = util.NewFakeUUIDGeneratorOrFatal(uuid, nil)
+		_, err := pipelineStore.CreatePipelineVersion(
+			&model.PipelineVersion{
+				Name:       name,
+				Parameters: `[{"Name": "param1"}]`,
+				PipelineId: defaultFakePipelineId,
+				Status:     model.PipelineVersionReady,
+			}, false)
+		assert.Nil(t, err)
+	}
+	names := func(versions []*model.PipelineVersion) []string {
+		var names []string
+		for _, version := range versions {
+			names = append(names, version.Name)
+		}
+		return names
+	}
+
+	opts, err := list.NewOptions(&model.PipelineVersion{}, 2, "semver", nil)
+	assert.Nil(t, err)
+	pipelineVersions, totalSize, nextPageToken, err := pipelineStore.ListPipelineVersions(defaultFakePipelineId, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, totalSize)
+	assert.Equal(t, []string{"v1.2", "v1.9"}, names(pipelineVersions))
+	assert.NotEmpty(t, nextPageToken)
+
+	opts, err = list.NewOptionsFromToken(nextPageToken, 2)
+	assert.Nil(t, err)
+	pipelineVersions, totalSize, nextPageToken, err = pipelineStore.ListPipelineVersions(defaultFakePipelineId, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, totalSize)
+	assert.Equal(t, []string{"v1.10", "draft"}, names(pipelineVersions))
+	assert.Empty(t, nextPageToken)
+
+	opts, err = list.NewOptions(&model.PipelineVersion{}, 3, "semver desc", nil)
+	assert.Nil(t, err)
+	pipelineVersions, _, nextPageToken, err = pipelineStore.ListPipelineVersions(defaultFakePipelineId, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"draft", "v1.10", "v1.9"}, names(pipelineVersions))
+
+	// Resume after the last version of the page even if it was deleted since.
+	err = pipelineStore.DeletePipelineVersion(defaultFakePipelineIdFour)
+	assert.Nil(t, err)
+	opts, err = list.NewOptionsFromToken(nextPageToken, 3)
+	assert.Nil(t, err)
+	pipelineVersions, _, _, err = pipelineStore.ListPipelineVersions(defaultFakePipelineId, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"v1.2"}, names(pipelineVersions))
+}
+
 func TestListPipelineVersionsError(t *testing.T) {
 	db := NewFakeDbOrFatal()
 	defer db.Close()
@@ -1425,6 +1769,52 @@ func TestUpdatePipelineVersionStatus(t *testing.T) {
 	})
 }
 
+func TestUpdatePipelineVersionDescription(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(
+		db,
+		util.NewFakeTimeForEpoch(),
+		util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineId, nil))
+	pipelineStore.CreatePipeline(
+		&model.Pipeline{
+			Name:       "pipeline_1",
+			Parameters: `[{"Name": "param1"}]`,
+			Status:     model.PipelineReady,
+		})
+	pipelineStore.uuid = util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineIdTwo, nil)
+	pipelineStore.CreatePipelineVersion(
+		&model.PipelineVersion{
+			Name:          "pipeline_version_1",
+			Parameters:    `[{"Name": "param1"}]`,
+			PipelineId:    defaultFakePipelineId,
+			Status:        model.PipelineVersionReady,
+			CodeSourceUrl: "code_source_url",
+			Description:   "first release",
+		}, true)
+
+	err := pipelineStore.UpdatePipelineVersionDescription(defaultFakePipelineIdTwo, "first release, fixes the trainer")
+	assert.Nil(t, err)
+	pipelineVersion, err := pipelineStore.GetPipelineVersion(defaultFakePipelineIdTwo)
+	assert.Nil(t, err)
+	assert.Equal(t, model.PipelineVersion{
+		UUID:           defaultFakePipelineIdTwo,
+		Name:           "pipeline_version_1",
+		CreatedAtInSec: 2,
+		Parameters:     `[{"Name": "param1"}]`,
+		PipelineId:     defaultFakePipelineId,
+		Status:         model.PipelineVersionReady,
+		CodeSourceUrl:  "code_source_url",
+		Description:    "first release, fixes the trainer",
+		UpdatedAtInSec: 3,
+	}, *pipelineVersion)
+
+	// Versions being deleted can't be changed.
+	assert.Nil(t, pipelineStore.UpdatePipelineVersionStatus(defaultFakePipelineIdTwo, model.PipelineVersionDeleting))
+	err = pipelineStore.UpdatePipelineVersionDescription(defaultFakePipelineIdTwo, "")
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
 func TestUpdatePipelineVersionStatusError(t *testing.T) {
 	db := NewFakeDbOrFatal()
 	defer db.Close()
@@ -0,0 +1,71 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// nextResourceVersion bumps the ResourceVersion column of the experiments, pipelines and jobs rows an update
+// changes, so that callers updating a row they read earlier can tell whether it changed in between.
+var nextResourceVersion = sq.Expr("ResourceVersion + 1")
+
+// execQueryer is implemented by both *DB and *sql.Tx.
+type execQueryer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// compareAndSwap sets the given columns of the row of a table with the given UUID and bumps its resource
+// version, provided the row is still at expectedVersion. An expectedVersion of 0 updates the row whatever its
+// version. It returns a NotFound error if there is no such row, and a Conflict error if the row is at another
+// version.
+func compareAndSwap(db execQueryer, table string, resourceType string, id string, expectedVersion int64, set sq.Eq) error {
+	values := sq.Eq{"ResourceVersion": nextResourceVersion}
+	for column, value := range set {
+		values[column] = value
+	}
+	where := sq.Eq{"UUID": id}
+	if expectedVersion > 0 {
+		where["ResourceVersion"] = expectedVersion
+	}
+	query, args, err := sq.Update(table).SetMap(values).Where(where).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to update %v %v", resourceType, id)
+	}
+	result, err := db.Exec(query, args...)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to update %v %v", resourceType, id)
+	}
+	if r, _ := result.RowsAffected(); r > 0 {
+		return nil
+	}
+	var currentVersion int64
+	err = db.QueryRow("SELECT ResourceVersion FROM "+table+" WHERE UUID = ?", id).Scan(&currentVersion)
+	if err == sql.ErrNoRows {
+		return util.NewResourceNotFoundError(resourceType, id)
+	}
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to get the resource version of %v %v", resourceType, id)
+	}
+	if expectedVersion > 0 && currentVersion != expectedVersion {
+		return util.NewConflictError("%v %v was modified since it was read: its resource version is %d, not %d",
+			resourceType, id, currentVersion, expectedVersion)
+	}
+	return nil
+}
@@ -33,13 +33,22 @@ import (
 )
 
 var runColumns = []string{"UUID", "ExperimentUUID", "DisplayName", "Name", "StorageState", "Namespace", "ServiceAccount", "Description",
-	"CreatedAtInSec", "ScheduledAtInSec", "FinishedAtInSec", "Conditions", "PipelineId", "PipelineName", "PipelineSpecManifest",
+	"CreatedAtInSec", "ScheduledAtInSec", "FinishedAtInSec", "StartedAtInSec", "PodStartedAtInSec", "Conditions", "State", "WorkflowUID", "TTLSeconds", "ResourceOverrides", "PodMetadata", "Scheduling", "Env", "RetryStrategy", "TerminatedBy", "CreatedBy", "PriorityClassName", "CompletionWebhook", "CompletionWebhookStatus", "CompletionWebhookMessage", "CompletionWebhookAttemptedAtInSec", "PipelineId", "PipelineName", "PipelineSpecManifest",
 	"WorkflowSpecManifest", "Parameters", "RuntimeParameters", "PipelineRoot", "pipelineRuntimeManifest", "WorkflowRuntimeManifest",
 }
 
+// maxCompletionWebhookMessageLength is the size of the CompletionWebhookMessage column.
+const maxCompletionWebhookMessageLength = 1024
+
 type RunStoreInterface interface {
 	GetRun(runId string) (*model.RunDetail, error)
 
+	// Get the latest run whose workflow has the given name in a namespace.
+	GetRunByWorkflowName(namespace string, workflowName string) (*model.RunDetail, error)
+
+	// Get the run whose workflow has the given UID.
+	GetRunByWorkflowUID(workflowUID string) (*model.RunDetail, error)
+
 	ListRuns(filterContext *common.FilterContext, opts *list.Options) ([]*model.Run, int, string, error)
 
 	// Create a run entry in the database
@@ -60,16 +69,89 @@ type RunStoreInterface interface {
 	// Update the run table or create one if the run doesn't exist
 	CreateOrUpdateRun(run *model.RunDetail) error
 
+	// Record when the workflow of a run and its first pod started.
+	UpdateRunStartTimes(runId string, startedAtInSec int64, podStartedAtInSec int64) error
+
+	// Replace the labels of a run.
+	UpdateRunLabels(runId string, labels map[string]string) error
+
+	// Change the display name of a run.
+	UpdateRunDisplayName(runId string, displayName string) error
+
 	// Store a new metric entry to run_metrics table.
 	ReportMetric(metric *model.RunMetric) (err error)
 
+	// List the values of a metric reported by the runs of an experiment.
+	ListExperimentRunMetrics(experimentId string, metricName string, states []string) ([]*model.RunMetric, error)
+
+	// Count the runs of a namespace that are in one of the given states.
+	CountRuns(namespace string, states []string) (int, error)
+	CountAvailableRuns(namespace string) (int, error)
+	GetPipelineVersionRunUsage(versionId string, since int64) (map[string]*RunUsage, error)
+
+	// List the IDs of the runs created by a job that are in one of the given states.
+	ListJobRunIds(jobId string, states []string) ([]string, error)
+
+	// List the IDs of the given runs that have another run retried from them.
+	ListRetriedRunIds(runIds []string) ([]string, error)
+
+	// List the IDs of the runs of an experiment, archived or not.
+	ListExperimentRunIds(experimentId string) ([]string, error)
+
+	// List the IDs of at most limit runs of a namespace that finished before the given time, oldest first.
+	ListRunIdsFinishedBefore(namespace string, excludedNamespaces []string, finishedBefore int64, excludeArchived bool, limit int) ([]string, error)
+
+	// List the limit most recently created runs of a namespace, across all its experiments, newest first.
+	ListRecentRuns(namespace string, includeArchived bool, limit int) ([]*model.Run, error)
+
+	// Delete or archive several runs in a single transaction, keeping or dropping their metrics and status
+	// snapshots.
+	ReapRuns(runIds []string, archive bool, keepMetrics bool, keepStatusSnapshots bool) error
+
+	// Count the runs of an experiment by state.
+	CountExperimentRunsByState(experimentId string, includeArchived bool) (map[string]int, error)
+
+	// Record that a run changed to a state, keeping at most maxEvents events of the run.
+	AppendRunStatusEvent(runId string, state string, createdAtInSec int64, maxEvents int) (bool, error)
+
+	// List the status events of a run, oldest first.
+	ListRunStatusEvents(runId string) ([]*model.RunStatusEvent, error)
+
 	// Terminate a run
-	TerminateRun(runId string) error
+	TerminateRun(runId string, terminatedBy string) error
+
+	// Store the compressed status snapshot of a finished run.
+	UpdateRunStatusSnapshot(runId string, snapshot []byte) error
+
+	// Record the outcome of the last delivery of the completion webhook of a run.
+	UpdateRunCompletionWebhookStatus(runId string, status string, message string, attemptedAtInSec int64) error
+
+	// List the IDs of the runs whose completion webhook delivery has the given status.
+	ListRunsWithCompletionWebhookStatus(status string) ([]string, error)
+
+	// Get the compressed status snapshot of a run, or nil if the run has none.
+	GetRunStatusSnapshot(runId string) ([]byte, error)
+
+	// Compress the manifests of a batch of runs stored before manifests were compressed. Returns the UUID of
+	// the last run of the batch, or "" if there are no runs left, and how many runs were changed.
+	CompressManifests(afterUUID string, batchSize int) (string, int, error)
+
+	// Store the statuses of several runs in a single transaction, returning an error for each of them.
+	UpdateRunStatuses(updates []*RunStatusUpdate) []error
+}
+
+// RunStatusUpdate is the status of a run reported from its workflow. Run holds the UUID, the conditions, the
+// finish and start times, the runtime manifest and the labels of the run. The runs of recurring runs are
+// created by their first report, so their Run is complete and CreateIfMissing is set.
+type RunStatusUpdate struct {
+	Run             *model.RunDetail
+	CreateIfMissing bool
 }
 
 type RunStore struct {
 	db                     *DB
 	resourceReferenceStore *ResourceReferenceStore
+	labelStore             *LabelStore
 	time                   util.TimeInterface
 }
 
@@ -128,6 +210,10 @@ func (s *RunStore) ListRuns(
 		return errorF(err)
 	}
 
+	if err := s.addLabels(runDetails); err != nil {
+		return nil, 0, "", err
+	}
+
 	var runs []*model.Run
 	for _, rd := range runDetails {
 		r := rd.Run
@@ -138,7 +224,7 @@ func (s *RunStore) ListRuns(
 		return runs, total_size, "", nil
 	}
 
-	npt, err := opts.NextPageToken(runs[opts.PageSize])
+	npt, err := opts.NextPageToken(runs[opts.PageSize-1])
 	return runs[:opts.PageSize], total_size, npt, err
 }
 
@@ -164,8 +250,16 @@ func (s *RunStore) buildSelectRunsQuery(selectCount bool, opts *list.Options,
 	if err != nil {
 		return "", nil, util.NewInternalServerError(err, "Failed to list runs: %v", err)
 	}
+	// Hide the runs of soft deleted experiments.
+	filteredSelectBuilder = filteredSelectBuilder.Where(fmt.Sprintf("ExperimentUUID NOT IN (%s)", softDeletedExperimentsQuery))
+	if filterContext.Namespaces != nil {
+		filteredSelectBuilder = filteredSelectBuilder.Where(sq.Eq{"Namespace": filterContext.Namespaces})
+	}
 
 	sqlBuilder := opts.AddFilterToSelect(filteredSelectBuilder)
+	sqlBuilder = opts.AddLabelFilterToSelect(sqlBuilder, common.Run)
+	sqlBuilder = opts.AddMetricFilterToSelect(sqlBuilder)
+	sqlBuilder = s.excludeArchived(sqlBuilder, opts)
 
 	// If we're not just counting, then also add select columns and perform a left join
 	// to get resource reference information. Also add pagination.
@@ -182,14 +276,46 @@ func (s *RunStore) buildSelectRunsQuery(selectCount bool, opts *list.Options,
 	return sql, args, err
 }
 
+// Archived runs are excluded from list results, unless the filter has a predicate on storage_state.
+func (s *RunStore) excludeArchived(sqlBuilder sq.SelectBuilder, opts *list.Options) sq.SelectBuilder {
+	if opts.Filter != nil && opts.Filter.HasPredicateOn("StorageState") {
+		return sqlBuilder
+	}
+	return sqlBuilder.Where(sq.NotEq{"StorageState": api.Run_STORAGESTATE_ARCHIVED.String()})
+}
+
 // GetRun Get the run manifest from Workflow CRD
 func (s *RunStore) GetRun(runId string) (*model.RunDetail, error) {
-	sql, args, err := s.addMetricsAndResourceReferences(
-		sq.Select(runColumns...).
-			From("run_details").
-			Where(sq.Eq{"UUID": runId}).
-			Limit(1), nil).
-		ToSql()
+	return s.getRun(sq.Select(runColumns...).
+		From("run_details").
+		Where(sq.Eq{"UUID": runId}).
+		Limit(1), runId)
+}
+
+// GetRunByWorkflowName returns the run of the workflow with the given name in a namespace. Workflow names can be
+// reused once a workflow is deleted, so the latest run is returned if several had a workflow of that name.
+func (s *RunStore) GetRunByWorkflowName(namespace string, workflowName string) (*model.RunDetail, error) {
+	return s.getRun(sq.Select(runColumns...).
+		From("run_details").
+		Where(sq.Eq{"Namespace": namespace, "Name": workflowName}).
+		OrderBy("CreatedAtInSec DESC").
+		Limit(1), fmt.Sprintf("%s/%s", namespace, workflowName))
+}
+
+// GetRunByWorkflowUID returns the run of the workflow with the given UID.
+func (s *RunStore) GetRunByWorkflowUID(workflowUID string) (*model.RunDetail, error) {
+	if workflowUID == "" {
+		return nil, util.NewResourceNotFoundError("Run", workflowUID)
+	}
+	return s.getRun(sq.Select(runColumns...).
+		From("run_details").
+		Where(sq.Eq{"WorkflowUID": workflowUID}).
+		Limit(1), workflowUID)
+}
+
+// getRun returns the single run selected by query, or a NotFound error naming runId.
+func (s *RunStore) getRun(query sq.SelectBuilder, runId string) (*model.RunDetail, error) {
+	sql, args, err := s.addMetricsAndResourceReferences(query, nil).ToSql()
 
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to get run: %v", err.Error())
@@ -211,9 +337,28 @@ func (s *RunStore) GetRun(runId string) (*model.RunDetail, error) {
 		// This can only happen when workflow reporting is failed.
 		return nil, util.NewResourceNotFoundError("Failed to get run: %s", runId)
 	}
+	if err := s.addLabels(runs); err != nil {
+		return nil, err
+	}
 	return runs[0], nil
 }
 
+// addLabels reads the labels of the given runs from the labels table.
+func (s *RunStore) addLabels(runs []*model.RunDetail) error {
+	ids := make([]string, 0, len(runs))
+	for _, run := range runs {
+		ids = append(ids, run.UUID)
+	}
+	labels, err := s.labelStore.GetLabels(common.Run, ids)
+	if err != nil {
+		return util.Wrap(err, "Failed to get run labels")
+	}
+	for _, run := range runs {
+		run.Labels = labels[run.UUID]
+	}
+	return nil
+}
+
 // Apply func f to every string in a given string slice.
 func Map(vs []string, f func(string) string) []string {
 	vsm := make([]string, len(vs))
@@ -255,10 +400,12 @@ func (s *RunStore) scanRowsToRunDetails(rows *sql.Rows) ([]*model.RunDetail, err
 	var runs []*model.RunDetail
 	for rows.Next() {
 		var uuid, experimentUUID, displayName, name, storageState, namespace, serviceAccount, description, pipelineId,
-			pipelineName, pipelineSpecManifest, workflowSpecManifest, parameters, conditions, pipelineRuntimeManifest,
+			pipelineName, pipelineSpecManifest, workflowSpecManifest, parameters, conditions, state, workflowUID, pipelineRuntimeManifest,
 			workflowRuntimeManifest string
-		var createdAtInSec, scheduledAtInSec, finishedAtInSec int64
-		var metricsInString, resourceReferencesInString, runtimeParameters, pipelineRoot sql.NullString
+		var createdAtInSec, scheduledAtInSec, finishedAtInSec, startedAtInSec, podStartedAtInSec int64
+		var metricsInString, resourceReferencesInString, runtimeParameters, pipelineRoot, resourceOverrides, podMetadata, scheduling, env, retryStrategy, terminatedBy, createdBy, priorityClassName sql.NullString
+		var completionWebhook, completionWebhookStatus, completionWebhookMessage sql.NullString
+		var ttlSeconds, completionWebhookAttemptedAtInSec sql.NullInt64
 		err := rows.Scan(
 			&uuid,
 			&experimentUUID,
@@ -271,7 +418,24 @@ func (s *RunStore) scanRowsToRunDetails(rows *sql.Rows) ([]*model.RunDetail, err
 			&createdAtInSec,
 			&scheduledAtInSec,
 			&finishedAtInSec,
+			&startedAtInSec,
+			&podStartedAtInSec,
 			&conditions,
+			&state,
+			&workflowUID,
+			&ttlSeconds,
+			&resourceOverrides,
+			&podMetadata,
+			&scheduling,
+			&env,
+			&retryStrategy,
+			&terminatedBy,
+			&createdBy,
+			&priorityClassName,
+			&completionWebhook,
+			&completionWebhookStatus,
+			&completionWebhookMessage,
+			&completionWebhookAttemptedAtInSec,
 			&pipelineId,
 			&pipelineName,
 			&pipelineSpecManifest,
@@ -288,6 +452,10 @@ func (s *RunStore) scanRowsToRunDetails(rows *sql.Rows) ([]*model.RunDetail, err
 			glog.Errorf("Failed to scan row: %v", err)
 			return runs, nil
 		}
+		err = decompressManifests(&pipelineSpecManifest, &workflowSpecManifest, &pipelineRuntimeManifest, &workflowRuntimeManifest)
+		if err != nil {
+			return nil, util.Wrapf(err, "Failed to read the manifests of run %v", uuid)
+		}
 		metrics, err := parseMetrics(metricsInString)
 		if err != nil {
 			glog.Errorf("Failed to parse metrics (%v) from DB: %v", metricsInString, err)
@@ -313,7 +481,21 @@ func (s *RunStore) scanRowsToRunDetails(rows *sql.Rows) ([]*model.RunDetail, err
 			CreatedAtInSec:     createdAtInSec,
 			ScheduledAtInSec:   scheduledAtInSec,
 			FinishedAtInSec:    finishedAtInSec,
+			StartedAtInSec:     startedAtInSec,
+			PodStartedAtInSec:  podStartedAtInSec,
 			Conditions:         conditions,
+			State:              state,
+			WorkflowUID:        workflowUID,
+			TTLSeconds:         NullInt64ToPointer(ttlSeconds),
+			ResourceOverrides:  resourceOverrides.String,
+			PodMetadata:        podMetadata.String,
+			Scheduling:         scheduling.String,
+			Env:                env.String,
+			RetryStrategy:      retryStrategy.String,
+			TerminatedBy:       terminatedBy.String,
+			CreatedBy:          createdBy.String,
+			PriorityClassName:  priorityClassName.String,
+			CompletionWebhook:  completionWebhook.String,
 			Metrics:            metrics,
 			ResourceReferences: resourceReferences,
 			PipelineSpec: model.PipelineSpec{
@@ -324,6 +506,9 @@ func (s *RunStore) scanRowsToRunDetails(rows *sql.Rows) ([]*model.RunDetail, err
 				Parameters:           parameters,
 				RuntimeConfig:        runtimeConfig,
 			},
+			CompletionWebhookStatus:           completionWebhookStatus.String,
+			CompletionWebhookMessage:          completionWebhookMessage.String,
+			CompletionWebhookAttemptedAtInSec: completionWebhookAttemptedAtInSec.Int64,
 		},
 			PipelineRuntime: model.PipelineRuntime{
 				PipelineRuntimeManifest: pipelineRuntimeManifest,
@@ -366,11 +551,41 @@ func parseResourceReferences(resourceRefString sql.NullString) ([]*model.Resourc
 }
 
 func (s *RunStore) CreateRun(r *model.RunDetail) (*model.RunDetail, error) {
+	// Use a transaction to make sure both run and its resource references are stored.
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create a new transaction to create run.")
+	}
+	if err := s.insertRun(tx, r); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, util.NewInternalServerError(err, "Failed to store run %v and its resource references to table", r.Name)
+	}
+	return r, nil
+}
+
+// insertRun stores a run, its resource references and its labels within a transaction.
+func (s *RunStore) insertRun(tx *sql.Tx, r *model.RunDetail) error {
 	if r.StorageState == "" {
 		r.StorageState = api.Run_STORAGESTATE_AVAILABLE.String()
 	} else if r.StorageState != api.Run_STORAGESTATE_AVAILABLE.String() &&
 		r.StorageState != api.Run_STORAGESTATE_ARCHIVED.String() {
-		return nil, util.NewInvalidInputError("Invalid value for StorageState field: %q.", r.StorageState)
+		return util.NewInvalidInputError("Invalid value for StorageState field: %q.", r.StorageState)
+	}
+
+	if r.State == "" {
+		r.State = model.RunStateFromConditions(r.Conditions)
+	}
+
+	pipelineSpecManifest, workflowSpecManifest := r.PipelineSpecManifest, r.WorkflowSpecManifest
+	pipelineRuntimeManifest, workflowRuntimeManifest := r.PipelineRuntimeManifest, r.WorkflowRuntimeManifest
+	err := compressManifests(&pipelineSpecManifest, &workflowSpecManifest, &pipelineRuntimeManifest, &workflowRuntimeManifest)
+	if err != nil {
+		return util.Wrapf(err, "Failed to store run %v", r.Name)
 	}
 
 	runSql, runArgs, err := sq.
@@ -387,47 +602,75 @@ func (s *RunStore) CreateRun(r *model.RunDetail) (*model.RunDetail, error) {
 			"CreatedAtInSec":          r.CreatedAtInSec,
 			"ScheduledAtInSec":        r.ScheduledAtInSec,
 			"FinishedAtInSec":         r.FinishedAtInSec,
+			"StartedAtInSec":          r.StartedAtInSec,
+			"PodStartedAtInSec":       r.PodStartedAtInSec,
 			"Conditions":              r.Conditions,
-			"WorkflowRuntimeManifest": r.WorkflowRuntimeManifest,
-			"PipelineRuntimeManifest": r.PipelineRuntimeManifest,
+			"State":                   r.State,
+			"WorkflowUID":             r.WorkflowUID,
+			"TTLSeconds":              PointerToNullInt64(r.TTLSeconds),
+			"ResourceOverrides":       r.ResourceOverrides,
+			"PodMetadata":             r.PodMetadata,
+			"Scheduling":              r.Scheduling,
+			"Env":                     r.Env,
+			"RetryStrategy":           r.RetryStrategy,
+			"TerminatedBy":            r.TerminatedBy,
+			"CreatedBy":               r.CreatedBy,
+			"PriorityClassName":       r.PriorityClassName,
+			"CompletionWebhook":       r.CompletionWebhook,
+			"WorkflowRuntimeManifest": workflowRuntimeManifest,
+			"PipelineRuntimeManifest": pipelineRuntimeManifest,
 			"PipelineId":              r.PipelineId,
 			"PipelineName":            r.PipelineName,
-			"PipelineSpecManifest":    r.PipelineSpecManifest,
-			"WorkflowSpecManifest":    r.WorkflowSpecManifest,
+			"PipelineSpecManifest":    pipelineSpecManifest,
+			"WorkflowSpecManifest":    workflowSpecManifest,
 			"Parameters":              r.Parameters,
 			"RuntimeParameters":       r.PipelineSpec.RuntimeConfig.Parameters,
 			"PipelineRoot":            r.PipelineSpec.RuntimeConfig.PipelineRoot,
 		}).ToSql()
 	if err != nil {
-		return nil, util.NewInternalServerError(err, "Failed to create query to store run to run table: '%v/%v",
+		return util.NewInternalServerError(err, "Failed to create query to store run to run table: '%v/%v",
 			r.Namespace, r.Name)
 	}
-
-	// Use a transaction to make sure both run and its resource references are stored.
-	tx, err := s.db.Begin()
-	if err != nil {
-		return nil, util.NewInternalServerError(err, "Failed to create a new transaction to create run.")
-	}
 	_, err = tx.Exec(runSql, runArgs...)
 	if err != nil {
-		tx.Rollback()
-		return nil, util.NewInternalServerError(err, "Failed to store run %v to table", r.Name)
+		return util.NewInternalServerError(err, "Failed to store run %v to table", r.Name)
 	}
 
 	err = s.resourceReferenceStore.CreateResourceReferences(tx, r.ResourceReferences)
 	if err != nil {
-		tx.Rollback()
-		return nil, util.NewInternalServerError(err, "Failed to store resource references to table for run %v ", r.Name)
+		return util.NewInternalServerError(err, "Failed to store resource references to table for run %v ", r.Name)
 	}
-	err = tx.Commit()
+	if err := s.labelStore.CreateLabels(tx, common.Run, r.UUID, r.Labels); err != nil {
+		return err
+	}
+	return updateExperimentLastRunCreatedAt(tx, r.ExperimentUUID, r.CreatedAtInSec)
+}
+
+// updateExperimentLastRunCreatedAt records that a run of an experiment was created at the given time, unless
+// the experiment already has a later run. Archived experiments are updated too, so that the time always
+// reflects the latest run.
+func updateExperimentLastRunCreatedAt(tx *sql.Tx, experimentId string, createdAtInSec int64) error {
+	if experimentId == "" {
+		return nil
+	}
+	query, args, err := sq.
+		Update("experiments").
+		Set("LastRunCreatedAtInSec", createdAtInSec).
+		Where(sq.And{sq.Eq{"UUID": experimentId}, sq.Lt{"LastRunCreatedAtInSec": createdAtInSec}}).
+		ToSql()
 	if err != nil {
-		tx.Rollback()
-		return nil, util.NewInternalServerError(err, "Failed to store run %v and its resource references to table", r.Name)
+		return util.NewInternalServerError(err, "Failed to create query to update the last run of experiment %v", experimentId)
 	}
-	return r, nil
+	if _, err := tx.Exec(query, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to update the last run of experiment %v", experimentId)
+	}
+	return nil
 }
 
 func (s *RunStore) UpdateRun(runID string, condition string, finishedAtInSec int64, workflowRuntimeManifest string) (err error) {
+	if err := compressManifests(&workflowRuntimeManifest); err != nil {
+		return util.Wrapf(err, "Failed to update run %s", runID)
+	}
 	tx, err := s.db.DB.Begin()
 	if err != nil {
 		return util.NewInternalServerError(err, "transaction creation failed")
@@ -437,6 +680,7 @@ func (s *RunStore) UpdateRun(runID string, condition string, finishedAtInSec int
 		Update("run_details").
 		SetMap(sq.Eq{
 			"Conditions":              condition,
+			"State":                   model.RunStateFromConditions(condition),
 			"FinishedAtInSec":         finishedAtInSec,
 			"WorkflowRuntimeManifest": workflowRuntimeManifest}).
 		Where(sq.Eq{"UUID": runID}).
@@ -479,6 +723,12 @@ func (s *RunStore) CreateOrUpdateRun(runDetail *model.RunDetail) error {
 	}
 
 	updateError := s.UpdateRun(runDetail.UUID, runDetail.Conditions, runDetail.FinishedAtInSec, runDetail.WorkflowRuntimeManifest)
+	if updateError == nil {
+		updateError = s.UpdateRunStartTimes(runDetail.UUID, runDetail.StartedAtInSec, runDetail.PodStartedAtInSec)
+	}
+	if updateError == nil {
+		updateError = s.UpdateRunLabels(runDetail.UUID, runDetail.Labels)
+	}
 	if updateError != nil {
 		return util.Wrap(updateError, fmt.Sprintf(
 			"Error while creating or updating run for workflow: '%v/%v'. Create error: '%v'. Update error: '%v'",
@@ -487,6 +737,143 @@ func (s *RunStore) CreateOrUpdateRun(runDetail *model.RunDetail) error {
 	return nil
 }
 
+// UpdateRunStatuses stores the statuses of several runs in a single transaction. The returned errors are
+// aligned with updates. A run that doesn't exist, and isn't to be created, gets a not found error without
+// affecting the others, while an error storing any of the runs rolls the transaction back and is returned for
+// all of them.
+func (s *RunStore) UpdateRunStatuses(updates []*RunStatusUpdate) []error {
+	errs := make([]error, len(updates))
+	failAll := func(err error) []error {
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return failAll(util.NewInternalServerError(err, "Failed to start a transaction to update the statuses of runs"))
+	}
+	for i, update := range updates {
+		run := update.Run
+		var exists bool
+		err := tx.QueryRow("SELECT exists (SELECT 1 FROM run_details WHERE UUID = ?)", run.UUID).Scan(&exists)
+		if err != nil {
+			tx.Rollback()
+			return failAll(util.NewInternalServerError(err, "Failed to check whether run %v exists", run.UUID))
+		}
+		switch {
+		case exists:
+			err = s.updateRunStatus(tx, run)
+		case update.CreateIfMissing:
+			err = s.insertRun(tx, run)
+		default:
+			errs[i] = util.NewResourceNotFoundError("Run", run.UUID)
+			continue
+		}
+		if err != nil {
+			tx.Rollback()
+			return failAll(util.Wrapf(err, "Failed to update the status of run %v", run.UUID))
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return failAll(util.NewInternalServerError(err, "Failed to commit the statuses of runs"))
+	}
+	return errs
+}
+
+// updateRunStatus updates the status, the start times and the labels of an existing run within a transaction.
+func (s *RunStore) updateRunStatus(tx *sql.Tx, r *model.RunDetail) error {
+	workflowRuntimeManifest := r.WorkflowRuntimeManifest
+	if err := compressManifests(&workflowRuntimeManifest); err != nil {
+		return err
+	}
+	values := sq.Eq{
+		"Conditions":              r.Conditions,
+		"State":                   model.RunStateFromConditions(r.Conditions),
+		"FinishedAtInSec":         r.FinishedAtInSec,
+		"WorkflowRuntimeManifest": workflowRuntimeManifest,
+	}
+	if r.StartedAtInSec != 0 {
+		values["StartedAtInSec"] = r.StartedAtInSec
+	}
+	if r.PodStartedAtInSec != 0 {
+		values["PodStartedAtInSec"] = r.PodStartedAtInSec
+	}
+	sql, args, err := sq.Update("run_details").SetMap(values).Where(sq.Eq{"UUID": r.UUID}).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to update the status of run %v", r.UUID)
+	}
+	if _, err := tx.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to update the status of run %v", r.UUID)
+	}
+	return s.labelStore.ReplaceLabels(tx, common.Run, r.UUID, r.Labels)
+}
+
+// UpdateRunStartTimes records when the workflow of a run and its first pod started. Zero times, i.e.
+// events that haven't happened yet, are ignored.
+func (s *RunStore) UpdateRunStartTimes(runId string, startedAtInSec int64, podStartedAtInSec int64) error {
+	times := sq.Eq{}
+	if startedAtInSec != 0 {
+		times["StartedAtInSec"] = startedAtInSec
+	}
+	if podStartedAtInSec != 0 {
+		times["PodStartedAtInSec"] = podStartedAtInSec
+	}
+	if len(times) == 0 {
+		return nil
+	}
+	sql, args, err := sq.Update("run_details").SetMap(times).Where(sq.Eq{"UUID": runId}).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to update the start times of run %v", runId)
+	}
+	if _, err := s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to update the start times of run %v", runId)
+	}
+	return nil
+}
+
+// UpdateRunLabels replaces the labels of a run.
+func (s *RunStore) UpdateRunLabels(runId string, labels map[string]string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to start a transaction to update labels of run %v", runId)
+	}
+	var exists bool
+	err = tx.QueryRow("SELECT exists (SELECT 1 FROM run_details WHERE UUID = ?)", runId).Scan(&exists)
+	if err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to check whether run %v exists", runId)
+	}
+	if !exists {
+		tx.Rollback()
+		return util.NewResourceNotFoundError("Run", runId)
+	}
+	if err := s.labelStore.ReplaceLabels(tx, common.Run, runId, labels); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return util.NewInternalServerError(err, "Failed to update labels of run %v", runId)
+	}
+	return nil
+}
+
+// UpdateRunDisplayName changes the name users see for a run. The name of its workflow isn't changed.
+func (s *RunStore) UpdateRunDisplayName(runId string, displayName string) error {
+	sql, args, err := sq.Update("run_details").Set("DisplayName", displayName).Where(sq.Eq{"UUID": runId}).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to rename run %v", runId)
+	}
+	result, err := s.db.Exec(sql, args...)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to rename run %v", runId)
+	}
+	if r, _ := result.RowsAffected(); r == 0 {
+		return util.NewResourceNotFoundError("Run", runId)
+	}
+	return nil
+}
+
 func (s *RunStore) ArchiveRun(runId string) error {
 	sql, args, err := sq.
 		Update("run_details").
@@ -554,6 +941,19 @@ func (s *RunStore) DeleteRun(id string) error {
 		tx.Rollback()
 		return util.NewInternalServerError(err, "Failed to delete resource references from table for run %v ", id)
 	}
+	if err := s.labelStore.DeleteLabels(tx, common.Run, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	eventsSql, eventsArgs, err := sq.Delete("run_status_events").Where(sq.Eq{"RunUUID": id}).ToSql()
+	if err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to create query to delete the status events of run %s", id)
+	}
+	if _, err := tx.Exec(eventsSql, eventsArgs...); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to delete the status events of run %s", id)
+	}
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
@@ -564,21 +964,38 @@ func (s *RunStore) DeleteRun(id string) error {
 
 // ReportMetric inserts a new metric to run_metrics table. Conflicting metrics
 // are ignored.
+// ReportMetric stores a metric of a run. A metric that was reported before is overwritten, so the
+// last report wins.
 func (s *RunStore) ReportMetric(metric *model.RunMetric) (err error) {
 	payloadBytes, err := json.Marshal(metric)
 	if err != nil {
 		return util.NewInternalServerError(err,
 			"failed to marshal metric to json: %+v", metric)
 	}
+	values := sq.Eq{
+		"NumberValue":     metric.NumberValue,
+		"Format":          metric.Format,
+		"Payload":         string(payloadBytes),
+		"ReportedAtInSec": s.time.Now().Unix()}
+	key := sq.Eq{
+		"RunUUID": metric.RunUUID,
+		"NodeID":  metric.NodeID,
+		"Name":    metric.Name}
+
+	updated, err := s.updateMetric(key, values)
+	if err != nil || updated {
+		return err
+	}
 	sql, args, err := sq.
 		Insert("run_metrics").
 		SetMap(sq.Eq{
-			"RunUUID":     metric.RunUUID,
-			"NodeID":      metric.NodeID,
-			"Name":        metric.Name,
-			"NumberValue": metric.NumberValue,
-			"Format":      metric.Format,
-			"Payload":     string(payloadBytes)}).ToSql()
+			"RunUUID":         metric.RunUUID,
+			"NodeID":          metric.NodeID,
+			"Name":            metric.Name,
+			"NumberValue":     metric.NumberValue,
+			"Format":          metric.Format,
+			"Payload":         string(payloadBytes),
+			"ReportedAtInSec": values["ReportedAtInSec"]}).ToSql()
 	if err != nil {
 		return util.NewInternalServerError(err,
 			"failed to create query for inserting metric: %+v", metric)
@@ -586,14 +1003,527 @@ func (s *RunStore) ReportMetric(metric *model.RunMetric) (err error) {
 	_, err = s.db.Exec(sql, args...)
 	if err != nil {
 		if s.db.IsDuplicateError(err) {
-			return util.NewAlreadyExistError(
-				"same metric has been reported before: %s/%s", metric.NodeID, metric.Name)
+			// Either the metric was reported concurrently, or the update above didn't change any
+			// row because the values were the same. Overwrite it in both cases.
+			_, err = s.updateMetric(key, values)
+			return err
 		}
 		return util.NewInternalServerError(err, "failed to insert metric: %v", metric)
 	}
 	return nil
 }
 
+// updateMetric overwrites the values of a stored metric and reports whether a row was changed.
+func (s *RunStore) updateMetric(key sq.Eq, values sq.Eq) (bool, error) {
+	sql, args, err := sq.Update("run_metrics").SetMap(values).Where(key).ToSql()
+	if err != nil {
+		return false, util.NewInternalServerError(err, "failed to create query for updating metric: %+v", key)
+	}
+	result, err := s.db.Exec(sql, args...)
+	if err != nil {
+		return false, util.NewInternalServerError(err, "failed to update metric: %+v", key)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, util.NewInternalServerError(err, "failed to update metric: %+v", key)
+	}
+	return rowsAffected > 0, nil
+}
+
+// ListExperimentRunMetrics returns the values of a metric reported by the runs of an experiment. If states
+// is not empty, only the metrics of runs in one of the given states are returned.
+func (s *RunStore) ListExperimentRunMetrics(experimentId string, metricName string, states []string) ([]*model.RunMetric, error) {
+	sqlBuilder := sq.
+		Select("rm.RunUUID", "rm.NodeID", "rm.Name", "rm.NumberValue", "rm.Format").
+		From("run_metrics AS rm").
+		Join("run_details AS rd ON rm.RunUUID=rd.UUID").
+		Where(sq.Eq{"rd.ExperimentUUID": experimentId, "rm.Name": metricName}).
+		OrderBy("rm.RunUUID", "rm.NodeID")
+	if len(states) > 0 {
+		sqlBuilder = sqlBuilder.Where(sq.Eq{"rd.State": states})
+	}
+	query, args, err := sqlBuilder.ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err,
+			"Failed to create query to list metric %v of experiment %v", metricName, experimentId)
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err,
+			"Failed to list metric %v of experiment %v", metricName, experimentId)
+	}
+	defer rows.Close()
+	var metrics []*model.RunMetric
+	for rows.Next() {
+		var metric model.RunMetric
+		var format sql.NullString
+		if err := rows.Scan(&metric.RunUUID, &metric.NodeID, &metric.Name, &metric.NumberValue, &format); err != nil {
+			return nil, util.NewInternalServerError(err,
+				"Failed to scan metric %v of experiment %v", metricName, experimentId)
+		}
+		metric.Format = format.String
+		metrics = append(metrics, &metric)
+	}
+	return metrics, nil
+}
+
+// CountRuns counts the runs of a namespace that are in one of the given states.
+func (s *RunStore) CountRuns(namespace string, states []string) (int, error) {
+	query, args, err := sq.
+		Select("count(*)").
+		From("run_details").
+		Where(sq.Eq{"Namespace": namespace, "State": states}).
+		ToSql()
+	if err != nil {
+		return 0, util.NewInternalServerError(err, "Failed to create query to count the runs of namespace %v", namespace)
+	}
+	var count int
+	if err := s.db.QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, util.NewInternalServerError(err, "Failed to count the runs of namespace %v", namespace)
+	}
+	return count, nil
+}
+
+// CountAvailableRuns counts the runs of a namespace that are not archived, leaving out those of soft deleted
+// experiments. An empty namespace counts the runs of every namespace.
+func (s *RunStore) CountAvailableRuns(namespace string) (int, error) {
+	where := sq.And{
+		sq.Expr(fmt.Sprintf("ExperimentUUID NOT IN (%s)", softDeletedExperimentsQuery)),
+		sq.NotEq{"StorageState": api.Run_STORAGESTATE_ARCHIVED.String()},
+	}
+	if namespace != "" {
+		where = append(where, sq.Eq{"Namespace": namespace})
+	}
+	query, args, err := sq.Select("count(*)").From("run_details").Where(where).ToSql()
+	if err != nil {
+		return 0, util.NewInternalServerError(err, "Failed to create query to count the runs of namespace %v", namespace)
+	}
+	var count int
+	if err := s.db.QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, util.NewInternalServerError(err, "Failed to count the runs of namespace %v", namespace)
+	}
+	return count, nil
+}
+
+// RunUsage sums up the runs of a namespace that used something, e.g. a pipeline version.
+type RunUsage struct {
+	Runs int
+	// RecentRuns are the runs created since the time asked for.
+	RecentRuns            int
+	LastRunCreatedAtInSec int64
+}
+
+// GetPipelineVersionRunUsage sums up, by namespace, the runs created from a pipeline version, archived or not,
+// with a single grouped query. The runs created at or after since are also counted as recent.
+func (s *RunStore) GetPipelineVersionRunUsage(versionId string, since int64) (map[string]*RunUsage, error) {
+	query, args, err := sq.
+		Select("run_details.Namespace", "count(*)").
+		Column("SUM(CASE WHEN run_details.CreatedAtInSec >= ? THEN 1 ELSE 0 END)", since).
+		Column("MAX(run_details.CreatedAtInSec)").
+		From("run_details").
+		Join("resource_references AS rr ON rr.ResourceUUID = run_details.UUID").
+		Where(sq.Eq{
+			"rr.ResourceType":  common.Run,
+			"rr.ReferenceType": common.PipelineVersion,
+			"rr.ReferenceUUID": versionId,
+		}).
+		GroupBy("run_details.Namespace").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to sum up the runs of pipeline version %v", versionId)
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to sum up the runs of pipeline version %v", versionId)
+	}
+	defer rows.Close()
+	usage := map[string]*RunUsage{}
+	for rows.Next() {
+		var namespace sql.NullString
+		var runs, recentRuns int
+		var lastRunCreatedAtInSec int64
+		if err := rows.Scan(&namespace, &runs, &recentRuns, &lastRunCreatedAtInSec); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to scan the runs of pipeline version %v", versionId)
+		}
+		u, ok := usage[namespace.String]
+		if !ok {
+			u = &RunUsage{}
+			usage[namespace.String] = u
+		}
+		u.Runs += runs
+		u.RecentRuns += recentRuns
+		if lastRunCreatedAtInSec > u.LastRunCreatedAtInSec {
+			u.LastRunCreatedAtInSec = lastRunCreatedAtInSec
+		}
+	}
+	return usage, nil
+}
+
+// ListJobRunIds lists the IDs of the runs created by a job that are in one of the given states, archived or
+// not, in order of creation.
+func (s *RunStore) ListJobRunIds(jobId string, states []string) ([]string, error) {
+	query, args, err := sq.
+		Select("run_details.UUID").
+		From("run_details").
+		Join("resource_references AS rr ON rr.ResourceUUID = run_details.UUID").
+		Where(sq.Eq{
+			"rr.ResourceType":   common.Run,
+			"rr.ReferenceType":  common.Job,
+			"rr.ReferenceUUID":  jobId,
+			"run_details.State": states,
+		}).
+		OrderBy("run_details.CreatedAtInSec", "run_details.UUID").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the runs of job %v", jobId)
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the runs of job %v", jobId)
+	}
+	defer rows.Close()
+	var runIds []string
+	for rows.Next() {
+		var runId string
+		if err := rows.Scan(&runId); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to scan the runs of job %v", jobId)
+		}
+		runIds = append(runIds, runId)
+	}
+	return runIds, nil
+}
+
+// ListRetriedRunIds lists the IDs of the given runs that another run has a RetriedFrom reference to.
+func (s *RunStore) ListRetriedRunIds(runIds []string) ([]string, error) {
+	if len(runIds) == 0 {
+		return nil, nil
+	}
+	query, args, err := sq.
+		Select("DISTINCT ReferenceUUID").
+		From("resource_references").
+		Where(sq.Eq{
+			"ResourceType":  common.Run,
+			"ReferenceType": common.Run,
+			"Relationship":  common.RetriedFrom,
+			"ReferenceUUID": runIds,
+		}).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the retried runs")
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the retried runs")
+	}
+	defer rows.Close()
+	var retriedRunIds []string
+	for rows.Next() {
+		var runId string
+		if err := rows.Scan(&runId); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to scan the retried runs")
+		}
+		retriedRunIds = append(retriedRunIds, runId)
+	}
+	return retriedRunIds, nil
+}
+
+// ListRunIdsFinishedBefore lists the IDs of at most limit runs that finished before finishedBefore, in the
+// order they finished. An empty namespace lists the runs of every namespace but the excluded ones.
+func (s *RunStore) ListRunIdsFinishedBefore(namespace string, excludedNamespaces []string, finishedBefore int64, excludeArchived bool, limit int) ([]string, error) {
+	where := sq.And{
+		sq.NotEq{"State": model.ActiveRunStates},
+		sq.Gt{"FinishedAtInSec": 0},
+		sq.Lt{"FinishedAtInSec": finishedBefore},
+	}
+	if namespace != "" {
+		where = append(where, sq.Eq{"Namespace": namespace})
+	}
+	if len(excludedNamespaces) > 0 {
+		where = append(where, sq.NotEq{"Namespace": excludedNamespaces})
+	}
+	if excludeArchived {
+		where = append(where, sq.NotEq{"StorageState": api.Run_STORAGESTATE_ARCHIVED.String()})
+	}
+	query, args, err := sq.
+		Select("UUID").
+		From("run_details").
+		Where(where).
+		OrderBy("FinishedAtInSec", "UUID").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the runs finished before %v", finishedBefore)
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the runs finished before %v", finishedBefore)
+	}
+	defer rows.Close()
+	var runIds []string
+	for rows.Next() {
+		var runId string
+		if err := rows.Scan(&runId); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to scan the runs finished before %v", finishedBefore)
+		}
+		runIds = append(runIds, runId)
+	}
+	return runIds, nil
+}
+
+// ListRecentRuns lists the limit most recently created runs, newest first, without paging through the runs of
+// each experiment. An empty namespace lists the runs of every namespace. Only the latest rows are read from the
+// index on the namespace and the creation time of runs, however many runs there are.
+func (s *RunStore) ListRecentRuns(namespace string, includeArchived bool, limit int) ([]*model.Run, error) {
+	where := sq.And{sq.Expr(fmt.Sprintf("ExperimentUUID NOT IN (%s)", softDeletedExperimentsQuery))}
+	if namespace != "" {
+		where = append(where, sq.Eq{"Namespace": namespace})
+	}
+	if !includeArchived {
+		where = append(where, sq.NotEq{"StorageState": api.Run_STORAGESTATE_ARCHIVED.String()})
+	}
+	latest := sq.
+		Select(runColumns...).
+		From("run_details").
+		Where(where).
+		OrderBy("CreatedAtInSec DESC", "UUID DESC").
+		Limit(uint64(limit))
+	query, args, err := s.addMetricsAndResourceReferences(latest, nil).
+		OrderBy("subq.CreatedAtInSec DESC", "subq.UUID DESC").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the recent runs of namespace %v", namespace)
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the recent runs of namespace %v", namespace)
+	}
+	defer rows.Close()
+	runDetails, err := s.scanRowsToRunDetails(rows)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the recent runs of namespace %v", namespace)
+	}
+	if err := s.addLabels(runDetails); err != nil {
+		return nil, err
+	}
+	runs := make([]*model.Run, 0, len(runDetails))
+	for _, runDetail := range runDetails {
+		run := runDetail.Run
+		runs = append(runs, &run)
+	}
+	return runs, nil
+}
+
+// ReapRuns deletes runs, along with their resource references, labels and status events, or archives them. The
+// metrics of the runs are deleted unless keepMetrics is set. The status snapshots are stored on the runs, so
+// they are only kept by archived runs, and only if keepStatusSnapshots is set.
+func (s *RunStore) ReapRuns(runIds []string, archive bool, keepMetrics bool, keepStatusSnapshots bool) error {
+	if len(runIds) == 0 {
+		return nil
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create a new transaction to reap runs")
+	}
+	var queries []sq.Sqlizer
+	if archive {
+		update := sq.Update("run_details").
+			Set("StorageState", api.Run_STORAGESTATE_ARCHIVED.String()).
+			Where(sq.Eq{"UUID": runIds})
+		if !keepStatusSnapshots {
+			update = update.Set("StatusSnapshot", nil)
+		}
+		queries = append(queries, update)
+	} else {
+		queries = append(queries,
+			sq.Delete("run_details").Where(sq.Eq{"UUID": runIds}),
+			sq.Delete("run_status_events").Where(sq.Eq{"RunUUID": runIds}))
+		for _, runId := range runIds {
+			if err := s.resourceReferenceStore.DeleteResourceReferences(tx, runId, common.Run); err != nil {
+				tx.Rollback()
+				return util.NewInternalServerError(err, "Failed to delete the resource references of run %v", runId)
+			}
+			if err := s.labelStore.DeleteLabels(tx, common.Run, runId); err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+	if !keepMetrics {
+		queries = append(queries, sq.Delete("run_metrics").Where(sq.Eq{"RunUUID": runIds}))
+	}
+	for _, query := range queries {
+		sql, args, err := query.ToSql()
+		if err != nil {
+			tx.Rollback()
+			return util.NewInternalServerError(err, "Failed to create query to reap %v runs", len(runIds))
+		}
+		if _, err := tx.Exec(sql, args...); err != nil {
+			tx.Rollback()
+			return util.NewInternalServerError(err, "Failed to reap %v runs", len(runIds))
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return util.NewInternalServerError(err, "Failed to reap %v runs", len(runIds))
+	}
+	return nil
+}
+
+// ListExperimentRunIds lists the IDs of the runs of an experiment, archived or not, in order of creation.
+func (s *RunStore) ListExperimentRunIds(experimentId string) ([]string, error) {
+	query, args, err := sq.
+		Select("UUID").
+		From("run_details").
+		Where(sq.Eq{"ExperimentUUID": experimentId}).
+		OrderBy("CreatedAtInSec", "UUID").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the runs of experiment %v", experimentId)
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the runs of experiment %v", experimentId)
+	}
+	defer rows.Close()
+	var runIds []string
+	for rows.Next() {
+		var runId string
+		if err := rows.Scan(&runId); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to scan the runs of experiment %v", experimentId)
+		}
+		runIds = append(runIds, runId)
+	}
+	return runIds, nil
+}
+
+// CountExperimentRunsByState counts the runs of an experiment in each state with a single grouped query.
+// Archived runs are only counted if includeArchived is set.
+func (s *RunStore) CountExperimentRunsByState(experimentId string, includeArchived bool) (map[string]int, error) {
+	sqlBuilder := sq.
+		Select("State", "count(*)").
+		From("run_details").
+		Where(sq.Eq{"ExperimentUUID": experimentId})
+	if !includeArchived {
+		sqlBuilder = sqlBuilder.Where(sq.NotEq{"StorageState": api.Run_STORAGESTATE_ARCHIVED.String()})
+	}
+	query, args, err := sqlBuilder.GroupBy("State").ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to count the runs of experiment %v", experimentId)
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to count the runs of experiment %v", experimentId)
+	}
+	defer rows.Close()
+	counts := map[string]int{}
+	for rows.Next() {
+		var state sql.NullString
+		var count int
+		if err := rows.Scan(&state, &count); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to scan the run counts of experiment %v", experimentId)
+		}
+		counts[state.String] += count
+	}
+	return counts, nil
+}
+
+// AppendRunStatusEvent records that a run changed to a state. Nothing is recorded if the last event of the
+// run has the same state, and it returns whether an event was added. The oldest events of the run beyond
+// maxEvents are pruned.
+func (s *RunStore) AppendRunStatusEvent(runId string, state string, createdAtInSec int64, maxEvents int) (bool, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return false, util.NewInternalServerError(err, "Failed to create a new transaction to record the status of run %v", runId)
+	}
+	added, err := appendRunStatusEvent(tx, runId, state, createdAtInSec, maxEvents)
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		tx.Rollback()
+		return false, util.NewInternalServerError(err, "Failed to record the status of run %v", runId)
+	}
+	return added, nil
+}
+
+func appendRunStatusEvent(tx *sql.Tx, runId string, state string, createdAtInSec int64, maxEvents int) (bool, error) {
+	query, args, err := sq.
+		Select("Sequence", "State").
+		From("run_status_events").
+		Where(sq.Eq{"RunUUID": runId}).
+		OrderBy("Sequence DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return false, util.NewInternalServerError(err, "Failed to create query to get the last status of run %v", runId)
+	}
+	var lastSequence int64
+	var lastState string
+	err = tx.QueryRow(query, args...).Scan(&lastSequence, &lastState)
+	if err != nil && err != sql.ErrNoRows {
+		return false, util.NewInternalServerError(err, "Failed to get the last status of run %v", runId)
+	}
+	if err == nil && lastState == state {
+		return false, nil
+	}
+
+	sequence := lastSequence + 1
+	query, args, err = sq.
+		Insert("run_status_events").
+		SetMap(sq.Eq{
+			"RunUUID":        runId,
+			"Sequence":       sequence,
+			"State":          state,
+			"CreatedAtInSec": createdAtInSec,
+		}).
+		ToSql()
+	if err != nil {
+		return false, util.NewInternalServerError(err, "Failed to create query to record the status of run %v", runId)
+	}
+	if _, err := tx.Exec(query, args...); err != nil {
+		return false, util.NewInternalServerError(err, "Failed to record the status of run %v", runId)
+	}
+
+	query, args, err = sq.
+		Delete("run_status_events").
+		Where(sq.And{sq.Eq{"RunUUID": runId}, sq.LtOrEq{"Sequence": sequence - int64(maxEvents)}}).
+		ToSql()
+	if err != nil {
+		return false, util.NewInternalServerError(err, "Failed to create query to prune the status events of run %v", runId)
+	}
+	if _, err := tx.Exec(query, args...); err != nil {
+		return false, util.NewInternalServerError(err, "Failed to prune the status events of run %v", runId)
+	}
+	return true, nil
+}
+
+// ListRunStatusEvents lists the status events of a run, oldest first.
+func (s *RunStore) ListRunStatusEvents(runId string) ([]*model.RunStatusEvent, error) {
+	query, args, err := sq.
+		Select("RunUUID", "Sequence", "State", "CreatedAtInSec").
+		From("run_status_events").
+		Where(sq.Eq{"RunUUID": runId}).
+		OrderBy("Sequence").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the status events of run %v", runId)
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the status events of run %v", runId)
+	}
+	defer rows.Close()
+	events := []*model.RunStatusEvent{}
+	for rows.Next() {
+		event := &model.RunStatusEvent{}
+		if err := rows.Scan(&event.RunUUID, &event.Sequence, &event.State, &event.CreatedAtInSec); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to scan the status events of run %v", runId)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
 func (s *RunStore) toListableModels(runs []model.RunDetail) []model.ListableDataModel {
 	models := make([]model.ListableDataModel, len(runs))
 	for i := range models {
@@ -615,16 +1545,18 @@ func NewRunStore(db *DB, time util.TimeInterface) *RunStore {
 	return &RunStore{
 		db:                     db,
 		resourceReferenceStore: NewResourceReferenceStore(db),
+		labelStore:             NewLabelStore(db),
 		time:                   time,
 	}
 }
 
-func (s *RunStore) TerminateRun(runId string) error {
+// TerminateRun marks a pending or running run as terminating, and records who terminated it.
+func (s *RunStore) TerminateRun(runId string, terminatedBy string) error {
 	result, err := s.db.Exec(`
 		UPDATE run_details
-		SET Conditions = ?
+		SET Conditions = ?, State = ?, TerminatedBy = ?
 		WHERE UUID = ? AND (Conditions = ? OR Conditions = ? OR Conditions = ?)`,
-		model.RunTerminatingConditions, runId, string(workflowapi.NodeRunning), string(workflowapi.NodePending), "")
+		model.RunTerminatingConditions, model.RunStateTerminating, terminatedBy, runId, string(workflowapi.NodeRunning), string(workflowapi.NodePending), "")
 
 	if err != nil {
 		return util.NewInternalServerError(err,
@@ -638,6 +1570,95 @@ func (s *RunStore) TerminateRun(runId string) error {
 	return nil
 }
 
+// UpdateRunStatusSnapshot stores the compressed status snapshot of a run, replacing any earlier one.
+func (s *RunStore) UpdateRunStatusSnapshot(runId string, snapshot []byte) error {
+	sql, args, err := sq.Update("run_details").Set("StatusSnapshot", snapshot).Where(sq.Eq{"UUID": runId}).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to update the status snapshot of run %v", runId)
+	}
+	result, err := s.db.Exec(sql, args...)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to update the status snapshot of run %v", runId)
+	}
+	if r, _ := result.RowsAffected(); r == 0 {
+		return util.NewResourceNotFoundError("Run", runId)
+	}
+	return nil
+}
+
+// UpdateRunCompletionWebhookStatus records the outcome of the last delivery of the completion webhook of a run.
+// Messages longer than the column are truncated.
+func (s *RunStore) UpdateRunCompletionWebhookStatus(runId string, status string, message string, attemptedAtInSec int64) error {
+	if len(message) > maxCompletionWebhookMessageLength {
+		message = message[:maxCompletionWebhookMessageLength]
+	}
+	sql, args, err := sq.Update("run_details").
+		SetMap(sq.Eq{
+			"CompletionWebhookStatus":           status,
+			"CompletionWebhookMessage":          message,
+			"CompletionWebhookAttemptedAtInSec": attemptedAtInSec,
+		}).
+		Where(sq.Eq{"UUID": runId}).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to update the completion webhook status of run %v", runId)
+	}
+	result, err := s.db.Exec(sql, args...)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to update the completion webhook status of run %v", runId)
+	}
+	if r, _ := result.RowsAffected(); r == 0 {
+		return util.NewResourceNotFoundError("Run", runId)
+	}
+	return nil
+}
+
+// ListRunsWithCompletionWebhookStatus lists the IDs of the runs whose completion webhook delivery has the given
+// status, in order of creation.
+func (s *RunStore) ListRunsWithCompletionWebhookStatus(status string) ([]string, error) {
+	query, args, err := sq.
+		Select("UUID").
+		From("run_details").
+		Where(sq.Eq{"CompletionWebhookStatus": status}).
+		OrderBy("CreatedAtInSec", "UUID").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the runs with completion webhook status %v", status)
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the runs with completion webhook status %v", status)
+	}
+	defer rows.Close()
+	var runIds []string
+	for rows.Next() {
+		var runId string
+		if err := rows.Scan(&runId); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to scan the runs with completion webhook status %v", status)
+		}
+		runIds = append(runIds, runId)
+	}
+	return runIds, nil
+}
+
+// GetRunStatusSnapshot returns the compressed status snapshot of a run, or nil if none was stored.
+func (s *RunStore) GetRunStatusSnapshot(runId string) ([]byte, error) {
+	query, args, err := sq.Select("StatusSnapshot").From("run_details").Where(sq.Eq{"UUID": runId}).ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get the status snapshot of run %v", runId)
+	}
+	var snapshot []byte
+	if err := s.db.QueryRow(query, args...).Scan(&snapshot); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, util.NewResourceNotFoundError("Run", runId)
+		}
+		return nil, util.NewInternalServerError(err, "Failed to get the status snapshot of run %v", runId)
+	}
+	if len(snapshot) == 0 {
+		return nil, nil
+	}
+	return snapshot, nil
+}
+
 // Add a metric as a new field to the select clause by join the passed-in SQL query with run_metrics table.
 // With the metric as a field in the select clause enable sorting on this metric afterwards.
 // TODO(jingzhang36): example of resulting SQL query and explanation for it.
@@ -652,3 +1673,9 @@ func (s *RunStore) AddSortByRunMetricToSelect(sqlBuilder sq.SelectBuilder, opts
 		FromSelect(sqlBuilder, "selected_runs").
 		LeftJoin("run_metrics ON selected_runs.uuid=run_metrics.runuuid AND run_metrics.name='" + opts.SortByFieldName + "'")
 }
+
+func (s *RunStore) CompressManifests(afterUUID string, batchSize int) (string, int, error) {
+	return compressManifestBatch(s.db, "run_details", []string{
+		"PipelineSpecManifest", "WorkflowSpecManifest", "PipelineRuntimeManifest", "WorkflowRuntimeManifest",
+	}, afterUUID, batchSize)
+}
@@ -18,6 +18,7 @@ import (
 	"database/sql"
 	"fmt"
 	"sort"
+	"strings"
 	"testing"
 
 	sq "github.com/Masterminds/squirrel"
@@ -62,6 +63,7 @@ func initializeRunStore() (*DB, *RunStore) {
 			CreatedAtInSec:   1,
 			ScheduledAtInSec: 1,
 			Conditions:       "Running",
+			State:            "RUNNING",
 			ResourceReferences: []*model.ResourceReference{
 				{
 					ResourceUUID: "1", ResourceType: common.Run,
@@ -91,6 +93,7 @@ func initializeRunStore() (*DB, *RunStore) {
 			CreatedAtInSec:   2,
 			ScheduledAtInSec: 2,
 			Conditions:       "done",
+			State:            "DONE",
 			ResourceReferences: []*model.ResourceReference{
 				{
 					ResourceUUID: "2", ResourceType: common.Run,
@@ -120,6 +123,7 @@ func initializeRunStore() (*DB, *RunStore) {
 			StorageState:     api.Run_STORAGESTATE_AVAILABLE.String(),
 			ScheduledAtInSec: 3,
 			Conditions:       "done",
+			State:            "DONE",
 			ResourceReferences: []*model.ResourceReference{
 				{
 					ResourceUUID: "3", ResourceType: common.Run,
@@ -177,6 +181,7 @@ func TestListRuns_Pagination(t *testing.T) {
 			ScheduledAtInSec: 1,
 			StorageState:     api.Run_STORAGESTATE_AVAILABLE.String(),
 			Conditions:       "Running",
+			State:            "RUNNING",
 			Metrics: []*model.RunMetric{
 				{
 					RunUUID:     "1",
@@ -211,6 +216,7 @@ func TestListRuns_Pagination(t *testing.T) {
 			ScheduledAtInSec: 2,
 			StorageState:     api.Run_STORAGESTATE_AVAILABLE.String(),
 			Conditions:       "done",
+			State:            "DONE",
 			Metrics: []*model.RunMetric{
 				{
 					RunUUID:     "2",
@@ -255,6 +261,52 @@ func TestListRuns_Pagination(t *testing.T) {
 	assert.Empty(t, nextPageToken)
 }
 
+func TestListRuns_Pagination_ConcurrentInsertAndDelete(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+	filterContext := &common.FilterContext{ReferenceKey: &common.ReferenceKey{Type: common.Experiment, ID: defaultFakeExpId}}
+
+	opts, err := list.NewOptions(&model.Run{}, 1, "", nil)
+	assert.Nil(t, err)
+	runs, _, nextPageToken, err := runStore.ListRuns(filterContext, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(runs))
+	assert.Equal(t, "1", runs[0].UUID)
+
+	// Insert a run that sorts right after the last returned run, and delete the last returned run.
+	_, err = runStore.CreateRun(&model.RunDetail{
+		Run: model.Run{
+			UUID:           "15",
+			ExperimentUUID: defaultFakeExpId,
+			Name:           "run15",
+			DisplayName:    "run15",
+			Namespace:      "n1",
+			CreatedAtInSec: 1,
+			ResourceReferences: []*model.ResourceReference{
+				{
+					ResourceUUID: "15", ResourceType: common.Run,
+					ReferenceUUID: defaultFakeExpId, ReferenceName: "e1",
+					ReferenceType: common.Experiment, Relationship: common.Creator,
+				},
+			},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, runStore.DeleteRun("1"))
+
+	var listed []string
+	for nextPageToken != "" {
+		opts, err = list.NewOptionsFromToken(nextPageToken, 1)
+		assert.Nil(t, err)
+		runs, _, nextPageToken, err = runStore.ListRuns(filterContext, opts)
+		assert.Nil(t, err)
+		for _, run := range runs {
+			listed = append(listed, run.UUID)
+		}
+	}
+	assert.Equal(t, []string{"15", "2"}, listed)
+}
+
 func TestListRuns_Pagination_WithSortingOnMetrics(t *testing.T) {
 	db, runStore := initializeRunStore()
 	defer db.Close()
@@ -270,6 +322,7 @@ func TestListRuns_Pagination_WithSortingOnMetrics(t *testing.T) {
 			ScheduledAtInSec: 1,
 			StorageState:     api.Run_STORAGESTATE_AVAILABLE.String(),
 			Conditions:       "Running",
+			State:            "RUNNING",
 			Metrics: []*model.RunMetric{
 				{
 					RunUUID:     "1",
@@ -304,6 +357,7 @@ func TestListRuns_Pagination_WithSortingOnMetrics(t *testing.T) {
 			ScheduledAtInSec: 2,
 			StorageState:     api.Run_STORAGESTATE_AVAILABLE.String(),
 			Conditions:       "done",
+			State:            "DONE",
 			Metrics: []*model.RunMetric{
 				{
 					RunUUID:     "2",
@@ -406,6 +460,268 @@ func TestListRuns_TotalSizeWithFilter(t *testing.T) {
 	assert.Equal(t, 2, total_size)
 }
 
+func TestListRuns_FilterByNamespaces(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	opts, _ := list.NewOptions(&model.Run{}, 1, "", nil)
+	runs, total_size, nextPageToken, err := runStore.ListRuns(&common.FilterContext{Namespaces: []string{"n1", "n3"}}, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(runs))
+	assert.Equal(t, 2, total_size)
+	assert.NotEmpty(t, nextPageToken)
+
+	// No namespace at all matches no run.
+	runs, total_size, _, err = runStore.ListRuns(&common.FilterContext{Namespaces: []string{}}, opts)
+	assert.Nil(t, err)
+	assert.Empty(t, runs)
+	assert.Equal(t, 0, total_size)
+}
+
+func TestListRuns_FilterByState(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	stateFilter := func(state string) *list.Options {
+		opts, err := list.NewOptions(&model.Run{}, 4, "", &api.Filter{
+			Predicates: []*api.Predicate{
+				{
+					Key:   "state",
+					Op:    api.Predicate_EQUALS,
+					Value: &api.Predicate_StringValue{StringValue: state},
+				},
+			},
+		})
+		assert.Nil(t, err)
+		return opts
+	}
+
+	runs, total_size, _, err := runStore.ListRuns(&common.FilterContext{}, stateFilter(model.RunStateRunning))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, total_size)
+	assert.Equal(t, "1", runs[0].UUID)
+
+	// The state follows the workflow status reported by the persistence agent, and is kept
+	// once the workflow is garbage collected.
+	err = runStore.UpdateRun("1", "Succeeded", 10, "workflow_done")
+	assert.Nil(t, err)
+	runs, total_size, _, err = runStore.ListRuns(&common.FilterContext{}, stateFilter(model.RunStateRunning))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, total_size)
+	runs, total_size, _, err = runStore.ListRuns(&common.FilterContext{}, stateFilter(model.RunStateSucceeded))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, total_size)
+	assert.Equal(t, "1", runs[0].UUID)
+}
+
+func TestCountRuns(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	count, err := runStore.CountRuns("n1", model.ActiveRunStates)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+	count, err = runStore.CountRuns("n2", model.ActiveRunStates)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+	count, err = runStore.CountRuns("n2", []string{"DONE"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	err = runStore.UpdateRun("1", "Succeeded", 10, "workflow_done")
+	assert.Nil(t, err)
+	count, err = runStore.CountRuns("n1", model.ActiveRunStates)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestGetPipelineVersionRunUsage(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineId, nil))
+	_, err := pipelineStore.CreatePipeline(&model.Pipeline{Name: "pipeline_1", Status: model.PipelineReady})
+	assert.Nil(t, err)
+	pipelineStore.uuid = util.NewFakeUUIDGeneratorOrFatal(defaultFakePipelineIdTwo, nil)
+	_, err = pipelineStore.CreatePipelineVersion(&model.PipelineVersion{
+		Name: "version_1", PipelineId: defaultFakePipelineId, Status: model.PipelineVersionReady,
+	}, false)
+	assert.Nil(t, err)
+	for _, run := range []struct {
+		id, namespace string
+		createdAt     int64
+	}{
+		{"v1", "n1", 5},
+		{"v2", "n1", 20},
+		{"v3", "n2", 30},
+	} {
+		_, err := runStore.CreateRun(&model.RunDetail{Run: model.Run{
+			UUID: run.id, Name: run.id, ExperimentUUID: defaultFakeExpId, Namespace: run.namespace,
+			StorageState: api.Run_STORAGESTATE_AVAILABLE.String(), CreatedAtInSec: run.createdAt,
+			ResourceReferences: []*model.ResourceReference{{
+				ResourceUUID: run.id, ResourceType: common.Run, ReferenceUUID: defaultFakePipelineIdTwo, ReferenceName: "version_1",
+				ReferenceType: common.PipelineVersion, Relationship: common.Creator,
+			}},
+		}})
+		assert.Nil(t, err)
+	}
+
+	usage, err := runStore.GetPipelineVersionRunUsage(defaultFakePipelineIdTwo, 20)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]*RunUsage{
+		"n1": {Runs: 2, RecentRuns: 1, LastRunCreatedAtInSec: 20},
+		"n2": {Runs: 1, RecentRuns: 1, LastRunCreatedAtInSec: 30},
+	}, usage)
+	usage, err = runStore.GetPipelineVersionRunUsage(defaultFakePipelineId, 20)
+	assert.Nil(t, err)
+	assert.Empty(t, usage)
+}
+
+func TestCountExperimentRunsByState(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	counts, err := runStore.CountExperimentRunsByState(defaultFakeExpId, false)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{"RUNNING": 1, "DONE": 1}, counts)
+
+	err = runStore.ArchiveRun("2")
+	assert.Nil(t, err)
+	counts, err = runStore.CountExperimentRunsByState(defaultFakeExpId, false)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{"RUNNING": 1}, counts)
+	counts, err = runStore.CountExperimentRunsByState(defaultFakeExpId, true)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{"RUNNING": 1, "DONE": 1}, counts)
+
+	counts, err = runStore.CountExperimentRunsByState("unknown", true)
+	assert.Nil(t, err)
+	assert.Empty(t, counts)
+}
+
+func TestUpdateRunStatuses(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	errs := runStore.UpdateRunStatuses([]*RunStatusUpdate{
+		{Run: &model.RunDetail{
+			Run: model.Run{
+				UUID:            "1",
+				Conditions:      "Succeeded",
+				FinishedAtInSec: 10,
+				StartedAtInSec:  4,
+				Labels:          map[string]string{"model-name": "resnet"},
+			},
+			PipelineRuntime: model.PipelineRuntime{WorkflowRuntimeManifest: "workflow_done"},
+		}},
+		{Run: &model.RunDetail{Run: model.Run{UUID: "unknown", Conditions: "Running"}}},
+		{
+			Run: &model.RunDetail{Run: model.Run{
+				UUID:           "new",
+				ExperimentUUID: defaultFakeExpId,
+				Name:           "new",
+				Namespace:      "n1",
+				Conditions:     "Running",
+				CreatedAtInSec: 5,
+			}},
+			CreateIfMissing: true,
+		},
+	})
+	assert.Len(t, errs, 3)
+	assert.Nil(t, errs[0])
+	assert.Equal(t, codes.NotFound, errs[1].(*util.UserError).ExternalStatusCode())
+	assert.Nil(t, errs[2])
+
+	run, err := runStore.GetRun("1")
+	assert.Nil(t, err)
+	assert.Equal(t, "Succeeded", run.Conditions)
+	assert.Equal(t, "SUCCEEDED", run.State)
+	assert.Equal(t, int64(10), run.FinishedAtInSec)
+	assert.Equal(t, int64(4), run.StartedAtInSec)
+	assert.Equal(t, "workflow_done", run.WorkflowRuntimeManifest)
+	assert.Equal(t, map[string]string{"model-name": "resnet"}, run.Labels)
+
+	run, err = runStore.GetRun("new")
+	assert.Nil(t, err)
+	assert.Equal(t, "Running", run.Conditions)
+}
+
+func TestListRuns_FilterByLabel(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	assert.Nil(t, runStore.UpdateRunLabels("1", map[string]string{"model-name": "resnet", "git-sha": "abc"}))
+	assert.Nil(t, runStore.UpdateRunLabels("3", map[string]string{"model-name": "resnet"}))
+	assert.Nil(t, runStore.UpdateRunLabels("2", map[string]string{"model-name": "bert"}))
+
+	opts, err := list.NewOptions(&model.Run{}, 10, "", &api.Filter{
+		Predicates: []*api.Predicate{
+			{
+				Key:   "labels.model-name",
+				Op:    api.Predicate_EQUALS,
+				Value: &api.Predicate_StringValue{StringValue: "resnet"},
+			},
+		},
+	})
+	assert.Nil(t, err)
+	runs, total_size, _, err := runStore.ListRuns(&common.FilterContext{}, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, total_size)
+	assert.Len(t, runs, 2)
+	assert.Equal(t, "1", runs[0].UUID)
+	assert.Equal(t, map[string]string{"model-name": "resnet", "git-sha": "abc"}, runs[0].Labels)
+	assert.Equal(t, "3", runs[1].UUID)
+
+	run, err := runStore.GetRun("2")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"model-name": "bert"}, run.Labels)
+
+	err = runStore.UpdateRunLabels("unknown", map[string]string{"model-name": "resnet"})
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+
+	assert.Nil(t, runStore.DeleteRun("1"))
+	labels, err := runStore.labelStore.GetLabels(common.Run, []string{"1"})
+	assert.Nil(t, err)
+	assert.Empty(t, labels)
+}
+
+func TestListRuns_FilterByMetric(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	for runId, accuracy := range map[string]float64{"1": 0.95, "2": 0.5, "3": 0.97} {
+		assert.Nil(t, runStore.ReportMetric(&model.RunMetric{RunUUID: runId, NodeID: "node1", Name: "accuracy", NumberValue: accuracy}))
+	}
+	listRunIds := func(filterContext *common.FilterContext, key string, op api.Predicate_Op, value string) []string {
+		opts, err := list.NewOptions(&model.Run{}, 10, "", &api.Filter{
+			Predicates: []*api.Predicate{{Key: key, Op: op, Value: &api.Predicate_StringValue{StringValue: value}}},
+		})
+		assert.Nil(t, err)
+		runs, totalSize, _, err := runStore.ListRuns(filterContext, opts)
+		assert.Nil(t, err)
+		assert.Equal(t, len(runs), totalSize)
+		runIds := []string{}
+		for _, run := range runs {
+			runIds = append(runIds, run.UUID)
+		}
+		return runIds
+	}
+
+	assert.Equal(t, []string{"1", "3"}, listRunIds(&common.FilterContext{}, "metrics.accuracy", api.Predicate_GREATER_THAN, "0.9"))
+	// Run 3 didn't report the metric.
+	assert.Equal(t, []string{"1", "2"}, listRunIds(&common.FilterContext{}, "metrics.dummymetric", api.Predicate_GREATER_THAN_EQUALS, "1"))
+	experimentContext := &common.FilterContext{ReferenceKey: &common.ReferenceKey{Type: common.Experiment, ID: defaultFakeExpId}}
+	assert.Equal(t, []string{"1"}, listRunIds(experimentContext, "metrics.accuracy", api.Predicate_GREATER_THAN, "0.9"))
+
+	// The last value reported is the one compared.
+	assert.Nil(t, runStore.ReportMetric(&model.RunMetric{RunUUID: "1", NodeID: "node1", Name: "accuracy", NumberValue: 0.8}))
+	assert.Equal(t, []string{"3"}, listRunIds(&common.FilterContext{}, "metrics.accuracy", api.Predicate_GREATER_THAN, "0.9"))
+
+	// So is the value of the node that reported the metric last, even if another node's older value matches.
+	assert.Nil(t, runStore.ReportMetric(&model.RunMetric{RunUUID: "3", NodeID: "node2", Name: "accuracy", NumberValue: 0.6}))
+	assert.Empty(t, listRunIds(&common.FilterContext{}, "metrics.accuracy", api.Predicate_GREATER_THAN, "0.9"))
+	assert.Equal(t, []string{"2", "3"}, listRunIds(&common.FilterContext{}, "metrics.accuracy", api.Predicate_LESS_THAN, "0.7"))
+}
+
 func TestListRuns_Pagination_Descend(t *testing.T) {
 	db, runStore := initializeRunStore()
 	defer db.Close()
@@ -421,6 +737,7 @@ func TestListRuns_Pagination_Descend(t *testing.T) {
 			ScheduledAtInSec: 2,
 			StorageState:     api.Run_STORAGESTATE_AVAILABLE.String(),
 			Conditions:       "done",
+			State:            "DONE",
 			Metrics: []*model.RunMetric{
 				{
 					RunUUID:     "2",
@@ -455,6 +772,7 @@ func TestListRuns_Pagination_Descend(t *testing.T) {
 			ScheduledAtInSec: 1,
 			StorageState:     api.Run_STORAGESTATE_AVAILABLE.String(),
 			Conditions:       "Running",
+			State:            "RUNNING",
 			Metrics: []*model.RunMetric{
 				{
 					RunUUID:     "1",
@@ -518,6 +836,7 @@ func TestListRuns_Pagination_LessThanPageSize(t *testing.T) {
 			ScheduledAtInSec: 1,
 			StorageState:     api.Run_STORAGESTATE_AVAILABLE.String(),
 			Conditions:       "Running",
+			State:            "RUNNING",
 			Metrics: []*model.RunMetric{
 				{
 					RunUUID:     "1",
@@ -551,6 +870,7 @@ func TestListRuns_Pagination_LessThanPageSize(t *testing.T) {
 			ScheduledAtInSec: 2,
 			StorageState:     api.Run_STORAGESTATE_AVAILABLE.String(),
 			Conditions:       "done",
+			State:            "DONE",
 			Metrics: []*model.RunMetric{
 				{
 					RunUUID:     "2",
@@ -611,6 +931,7 @@ func TestGetRun(t *testing.T) {
 			ScheduledAtInSec: 1,
 			StorageState:     api.Run_STORAGESTATE_AVAILABLE.String(),
 			Conditions:       "Running",
+			State:            "RUNNING",
 			Metrics: []*model.RunMetric{
 				{
 					RunUUID:     "1",
@@ -660,6 +981,124 @@ func TestGetRun_InternalError(t *testing.T) {
 		"Expected get run to return internal error")
 }
 
+func TestGetRunByWorkflowNameAndUID(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	_, err := runStore.CreateRun(&model.RunDetail{
+		Run: model.Run{
+			UUID:           "4",
+			ExperimentUUID: defaultFakeExpId,
+			Name:           "run1",
+			DisplayName:    "run1 again",
+			Namespace:      "n1",
+			WorkflowUID:    "workflow-uid-4",
+			CreatedAtInSec: 4,
+			StorageState:   api.Run_STORAGESTATE_AVAILABLE.String(),
+			Conditions:     "Running",
+		},
+		PipelineRuntime: model.PipelineRuntime{WorkflowRuntimeManifest: "workflow4"},
+	})
+	assert.Nil(t, err)
+
+	// The latest run is returned when a workflow name was used more than once.
+	runDetail, err := runStore.GetRunByWorkflowName("n1", "run1")
+	assert.Nil(t, err)
+	assert.Equal(t, "4", runDetail.UUID)
+	runDetail, err = runStore.GetRunByWorkflowName("n2", "run2")
+	assert.Nil(t, err)
+	assert.Equal(t, "2", runDetail.UUID)
+	_, err = runStore.GetRunByWorkflowName("n2", "run1")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+
+	runDetail, err = runStore.GetRunByWorkflowUID("workflow-uid-4")
+	assert.Nil(t, err)
+	assert.Equal(t, "4", runDetail.UUID)
+	_, err = runStore.GetRunByWorkflowUID("unknown-uid")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	_, err = runStore.GetRunByWorkflowUID("")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
+func TestListRecentRuns(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+	runIds := func(runs []*model.Run) []string {
+		ids := []string{}
+		for _, run := range runs {
+			ids = append(ids, run.UUID)
+		}
+		return ids
+	}
+
+	runs, err := runStore.ListRecentRuns("", false, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"3", "2", "1"}, runIds(runs))
+	runs, err = runStore.ListRecentRuns("", false, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"3", "2"}, runIds(runs))
+	runs, err = runStore.ListRecentRuns("n1", false, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1"}, runIds(runs))
+	assert.Equal(t, 1, len(runs[0].Metrics))
+	assert.Equal(t, 1, len(runs[0].ResourceReferences))
+
+	assert.Nil(t, runStore.ArchiveRun("3"))
+	runs, err = runStore.ListRecentRuns("", false, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2", "1"}, runIds(runs))
+	runs, err = runStore.ListRecentRuns("", true, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"3", "2", "1"}, runIds(runs))
+}
+
+func TestListRunIdsFinishedBeforeAndReapRuns(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+	assert.Nil(t, runStore.UpdateRun("2", "Succeeded", 10, "workflow2"))
+	assert.Nil(t, runStore.UpdateRun("3", "Failed", 20, "workflow3"))
+
+	runIds, err := runStore.ListRunIdsFinishedBefore("", nil, 100, false, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2", "3"}, runIds)
+	runIds, err = runStore.ListRunIdsFinishedBefore("", nil, 100, false, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2"}, runIds)
+	runIds, err = runStore.ListRunIdsFinishedBefore("", nil, 15, false, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2"}, runIds)
+	runIds, err = runStore.ListRunIdsFinishedBefore("n3", nil, 100, false, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"3"}, runIds)
+	runIds, err = runStore.ListRunIdsFinishedBefore("", []string{"n3"}, 100, false, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2"}, runIds)
+
+	countMetrics := func(runId string) int {
+		var count int
+		assert.Nil(t, db.QueryRow("SELECT count(*) FROM run_metrics WHERE RunUUID = ?", runId).Scan(&count))
+		return count
+	}
+	assert.Nil(t, runStore.UpdateRunStatusSnapshot("2", []byte("snapshot")))
+	assert.Nil(t, runStore.ReapRuns([]string{"2"}, true, true, false))
+	run, err := runStore.GetRun("2")
+	assert.Nil(t, err)
+	assert.Equal(t, api.Run_STORAGESTATE_ARCHIVED.String(), run.StorageState)
+	snapshot, err := runStore.GetRunStatusSnapshot("2")
+	assert.Nil(t, err)
+	assert.Nil(t, snapshot)
+	assert.Equal(t, 1, countMetrics("2"))
+	runIds, err = runStore.ListRunIdsFinishedBefore("", nil, 100, true, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"3"}, runIds)
+
+	assert.Nil(t, runStore.ReapRuns([]string{"2"}, false, false, false))
+	_, err = runStore.GetRun("2")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	assert.Equal(t, 0, countMetrics("2"))
+	assert.Equal(t, 1, countMetrics("1"))
+}
+
 func TestCreateOrUpdateRun_UpdateSuccess(t *testing.T) {
 	db, runStore := initializeRunStore()
 	defer db.Close()
@@ -675,6 +1114,7 @@ func TestCreateOrUpdateRun_UpdateSuccess(t *testing.T) {
 			ScheduledAtInSec: 1,
 			StorageState:     api.Run_STORAGESTATE_AVAILABLE.String(),
 			Conditions:       "Running",
+			State:            "RUNNING",
 			Metrics: []*model.RunMetric{
 				{
 					RunUUID:     "1",
@@ -711,6 +1151,7 @@ func TestCreateOrUpdateRun_UpdateSuccess(t *testing.T) {
 			ScheduledAtInSec: 2, // This is will be ignored
 			StorageState:     api.Run_STORAGESTATE_AVAILABLE.String(),
 			Conditions:       "done",
+			State:            "DONE",
 		},
 		PipelineRuntime: model.PipelineRuntime{WorkflowRuntimeManifest: "workflow1_done"},
 	}
@@ -728,6 +1169,7 @@ func TestCreateOrUpdateRun_UpdateSuccess(t *testing.T) {
 			ScheduledAtInSec: 1,
 			StorageState:     api.Run_STORAGESTATE_AVAILABLE.String(),
 			Conditions:       "done",
+			State:            "DONE",
 			Metrics: []*model.RunMetric{
 				{
 					RunUUID:     "1",
@@ -776,6 +1218,8 @@ func TestCreateOrUpdateRun_CreateSuccess(t *testing.T) {
 			Namespace:      "MY_NAMESPACE",
 			CreatedAtInSec: 11,
 			Conditions:     "Running",
+			State:          "RUNNING",
+			WorkflowUID:    "workflow-uid",
 			PipelineSpec: model.PipelineSpec{
 				WorkflowSpecManifest: "workflow_spec",
 			},
@@ -804,6 +1248,8 @@ func TestCreateOrUpdateRun_CreateSuccess(t *testing.T) {
 			Namespace:      "MY_NAMESPACE",
 			CreatedAtInSec: 11,
 			Conditions:     "Running",
+			State:          "RUNNING",
+			WorkflowUID:    "workflow-uid",
 			PipelineSpec: model.PipelineSpec{
 				WorkflowSpecManifest: "workflow_spec",
 			},
@@ -834,6 +1280,7 @@ func TestCreateOrUpdateRun_UpdateNotFound(t *testing.T) {
 	runDetail := &model.RunDetail{
 		Run: model.Run{
 			Conditions: "done",
+			State:      "DONE",
 		},
 		PipelineRuntime: model.PipelineRuntime{WorkflowRuntimeManifest: "workflow1_done"},
 	}
@@ -854,6 +1301,7 @@ func TestCreateOrUpdateRun_NoStorageStateValue(t *testing.T) {
 			CreatedAtInSec:   1,
 			ScheduledAtInSec: 1,
 			Conditions:       "Running",
+			State:            "RUNNING",
 		},
 		PipelineRuntime: model.PipelineRuntime{
 			WorkflowRuntimeManifest: "workflow1",
@@ -879,6 +1327,7 @@ func TestCreateOrUpdateRun_BadStorageStateValue(t *testing.T) {
 			CreatedAtInSec:   1,
 			ScheduledAtInSec: 1,
 			Conditions:       "Running",
+			State:            "RUNNING",
 			Metrics: []*model.RunMetric{
 				{
 					RunUUID:     "1",
@@ -920,7 +1369,7 @@ func TestTerminateRun(t *testing.T) {
 	db, runStore := initializeRunStore()
 	defer db.Close()
 
-	err := runStore.TerminateRun("1")
+	err := runStore.TerminateRun("1", "user@google.com")
 	assert.Nil(t, err)
 
 	expectedRun := &model.RunDetail{
@@ -934,6 +1383,8 @@ func TestTerminateRun(t *testing.T) {
 			ScheduledAtInSec: 1,
 			StorageState:     api.Run_STORAGESTATE_AVAILABLE.String(),
 			Conditions:       "Terminating",
+			State:            "TERMINATING",
+			TerminatedBy:     "user@google.com",
 			Metrics: []*model.RunMetric{
 				{
 					RunUUID:     "1",
@@ -969,7 +1420,7 @@ func TestTerminateRun_RunDoesNotExist(t *testing.T) {
 	db, runStore := initializeRunStore()
 	defer db.Close()
 
-	err := runStore.TerminateRun("does-not-exist")
+	err := runStore.TerminateRun("does-not-exist", "")
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "Row not found")
 }
@@ -978,11 +1429,69 @@ func TestTerminateRun_RunHasAlreadyFinished(t *testing.T) {
 	db, runStore := initializeRunStore()
 	defer db.Close()
 
-	err := runStore.TerminateRun("2")
+	err := runStore.TerminateRun("2", "")
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "Row not found")
 }
 
+func TestUpdateAndGetRunStatusSnapshot(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	snapshot, err := runStore.GetRunStatusSnapshot("1")
+	assert.Nil(t, err)
+	assert.Nil(t, snapshot)
+
+	err = runStore.UpdateRunStatusSnapshot("1", []byte("snapshot"))
+	assert.Nil(t, err)
+	snapshot, err = runStore.GetRunStatusSnapshot("1")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("snapshot"), snapshot)
+
+	err = runStore.UpdateRunStatusSnapshot("does-not-exist", []byte("snapshot"))
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+	_, err = runStore.GetRunStatusSnapshot("does-not-exist")
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestUpdateRunCompletionWebhookStatus(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	err := runStore.UpdateRunCompletionWebhookStatus("1", model.CompletionWebhookFailed, strings.Repeat("a", 2000), 5)
+	assert.Nil(t, err)
+	run, err := runStore.GetRun("1")
+	assert.Nil(t, err)
+	assert.Equal(t, model.CompletionWebhookFailed, run.CompletionWebhookStatus)
+	assert.Equal(t, strings.Repeat("a", 1024), run.CompletionWebhookMessage)
+	assert.Equal(t, int64(5), run.CompletionWebhookAttemptedAtInSec)
+
+	err = runStore.UpdateRunCompletionWebhookStatus("1", model.CompletionWebhookDelivered, "", 6)
+	assert.Nil(t, err)
+	run, err = runStore.GetRun("1")
+	assert.Nil(t, err)
+	assert.Equal(t, model.CompletionWebhookDelivered, run.CompletionWebhookStatus)
+	assert.Equal(t, "", run.CompletionWebhookMessage)
+
+	err = runStore.UpdateRunCompletionWebhookStatus("does-not-exist", model.CompletionWebhookDelivered, "", 6)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestListRunsWithCompletionWebhookStatus(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	runIds, err := runStore.ListRunsWithCompletionWebhookStatus(model.CompletionWebhookPending)
+	assert.Nil(t, err)
+	assert.Empty(t, runIds)
+
+	assert.Nil(t, runStore.UpdateRunCompletionWebhookStatus("1", model.CompletionWebhookPending, "", 5))
+	assert.Nil(t, runStore.UpdateRunCompletionWebhookStatus("2", model.CompletionWebhookDelivered, "", 5))
+	runIds, err = runStore.ListRunsWithCompletionWebhookStatus(model.CompletionWebhookPending)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1"}, runIds)
+}
+
 func TestReportMetric_Success(t *testing.T) {
 	db, runStore := initializeRunStore()
 	defer db.Close()
@@ -1010,7 +1519,7 @@ func TestReportMetric_Success(t *testing.T) {
 		}}, runDetail.Run.Metrics)
 }
 
-func TestReportMetric_DupReports_Fail(t *testing.T) {
+func TestReportMetric_DupReports_LastWriteWins(t *testing.T) {
 	db, runStore := initializeRunStore()
 	defer db.Close()
 
@@ -1028,11 +1537,46 @@ func TestReportMetric_DupReports_Fail(t *testing.T) {
 		NumberValue: 0.88,
 		Format:      "PERCENTAGE",
 	}
-	runStore.ReportMetric(metric1)
+	err := runStore.ReportMetric(metric1)
+	assert.Nil(t, err)
+	err = runStore.ReportMetric(metric2)
+	assert.Nil(t, err)
+	// Reporting the same values again is not an error either.
+	err = runStore.ReportMetric(metric2)
+	assert.Nil(t, err)
+
+	runDetail, err := runStore.GetRun("1")
+	assert.Nil(t, err)
+	metrics := []*model.RunMetric{}
+	for _, metric := range runDetail.Metrics {
+		if metric.Name == "acurracy" {
+			metrics = append(metrics, metric)
+		}
+	}
+	assert.Len(t, metrics, 1)
+	assert.Equal(t, 0.88, metrics[0].NumberValue)
+}
+
+func TestListExperimentRunMetrics(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	metrics, err := runStore.ListExperimentRunMetrics(defaultFakeExpId, "dummymetric", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.RunMetric{
+		{RunUUID: "1", NodeID: "node1", Name: "dummymetric", NumberValue: 1.0, Format: "PERCENTAGE"},
+		{RunUUID: "2", NodeID: "node2", Name: "dummymetric", NumberValue: 2.0, Format: "PERCENTAGE"},
+	}, metrics)
+
+	metrics, err = runStore.ListExperimentRunMetrics(defaultFakeExpId, "dummymetric", []string{"RUNNING"})
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.RunMetric{
+		{RunUUID: "1", NodeID: "node1", Name: "dummymetric", NumberValue: 1.0, Format: "PERCENTAGE"},
+	}, metrics)
 
-	err := runStore.ReportMetric(metric2)
-	_, ok := err.(*util.UserError)
-	assert.True(t, ok)
+	metrics, err = runStore.ListExperimentRunMetrics(defaultFakeExpIdTwo, "dummymetric", nil)
+	assert.Nil(t, err)
+	assert.Empty(t, metrics)
 }
 
 func TestGetRun_InvalidMetricPayload_Ignore(t *testing.T) {
@@ -1093,6 +1637,7 @@ func TestListRuns_WithMetrics(t *testing.T) {
 			ScheduledAtInSec: 1,
 			StorageState:     api.Run_STORAGESTATE_AVAILABLE.String(),
 			Conditions:       "Running",
+			State:            "RUNNING",
 			ResourceReferences: []*model.ResourceReference{
 				{
 					ResourceUUID: "1", ResourceType: common.Run,
@@ -1127,6 +1672,7 @@ func TestListRuns_WithMetrics(t *testing.T) {
 			ScheduledAtInSec: 2,
 			StorageState:     api.Run_STORAGESTATE_AVAILABLE.String(),
 			Conditions:       "done",
+			State:            "DONE",
 			ResourceReferences: []*model.ResourceReference{
 				{
 					ResourceUUID: "2", ResourceType: common.Run,
@@ -1259,6 +1805,7 @@ func TestArchiveRun_IncludedInRunList(t *testing.T) {
 			ScheduledAtInSec: 1,
 			StorageState:     api.Run_STORAGESTATE_ARCHIVED.String(),
 			Conditions:       "Running",
+			State:            "RUNNING",
 			Metrics: []*model.RunMetric{
 				{
 					RunUUID:     "1",
@@ -1282,13 +1829,37 @@ func TestArchiveRun_IncludedInRunList(t *testing.T) {
 				},
 			},
 		}}
-	opts, err := list.NewOptions(&model.Run{}, 1, "", nil)
+	opts, err := list.NewOptions(&model.Run{}, 1, "", &api.Filter{
+		Predicates: []*api.Predicate{
+			{
+				Key:   "storage_state",
+				Op:    api.Predicate_EQUALS,
+				Value: &api.Predicate_StringValue{StringValue: api.Run_STORAGESTATE_ARCHIVED.String()},
+			},
+		},
+	})
+	assert.Nil(t, err)
 	runs, total_size, nextPageToken, err := runStore.ListRuns(
 		&common.FilterContext{ReferenceKey: &common.ReferenceKey{Type: common.Experiment, ID: defaultFakeExpId}}, opts)
 	assert.Nil(t, err)
-	assert.Equal(t, 2, total_size)
+	assert.Equal(t, 1, total_size)
 	assert.Equal(t, expectedRuns, runs)
-	assert.NotEmpty(t, nextPageToken)
+	assert.Empty(t, nextPageToken)
+}
+
+func TestArchiveRun_ExcludedFromRunListByDefault(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	err := runStore.ArchiveRun("1")
+	assert.Nil(t, err)
+	opts, err := list.NewOptions(&model.Run{}, 10, "", nil)
+	assert.Nil(t, err)
+	runs, total_size, _, err := runStore.ListRuns(
+		&common.FilterContext{ReferenceKey: &common.ReferenceKey{Type: common.Experiment, ID: defaultFakeExpId}}, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, total_size)
+	assert.Equal(t, "2", runs[0].UUID)
 }
 
 func TestDeleteRun(t *testing.T) {
@@ -1322,6 +1893,46 @@ func TestDeleteRun_InternalError(t *testing.T) {
 		"Expected delete run to return internal error")
 }
 
+func TestAppendRunStatusEvent(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	for i, state := range []string{"PENDING", "RUNNING", "RUNNING", "FAILED"} {
+		added, err := runStore.AppendRunStatusEvent("1", state, int64(10+i), 10)
+		assert.Nil(t, err)
+		assert.Equal(t, i != 2, added)
+	}
+	events, err := runStore.ListRunStatusEvents("1")
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.RunStatusEvent{
+		{RunUUID: "1", Sequence: 1, State: "PENDING", CreatedAtInSec: 10},
+		{RunUUID: "1", Sequence: 2, State: "RUNNING", CreatedAtInSec: 11},
+		{RunUUID: "1", Sequence: 3, State: "FAILED", CreatedAtInSec: 13},
+	}, events)
+
+	// The oldest events beyond the cap are pruned.
+	added, err := runStore.AppendRunStatusEvent("1", "RUNNING", 14, 2)
+	assert.Nil(t, err)
+	assert.True(t, added)
+	events, err = runStore.ListRunStatusEvents("1")
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.RunStatusEvent{
+		{RunUUID: "1", Sequence: 3, State: "FAILED", CreatedAtInSec: 13},
+		{RunUUID: "1", Sequence: 4, State: "RUNNING", CreatedAtInSec: 14},
+	}, events)
+
+	events, err = runStore.ListRunStatusEvents("2")
+	assert.Nil(t, err)
+	assert.Empty(t, events)
+
+	// The events are deleted with the run.
+	err = runStore.DeleteRun("1")
+	assert.Nil(t, err)
+	events, err = runStore.ListRunStatusEvents("1")
+	assert.Nil(t, err)
+	assert.Empty(t, events)
+}
+
 func TestParseMetrics(t *testing.T) {
 	expectedModelRunMetrics := []*model.RunMetric{
 		{
@@ -180,7 +180,7 @@ func (s *TaskStore) ListTasks(filterContext *common.FilterContext, opts *list.Op
 		return exps, total_size, "", nil
 	}
 
-	npt, err := opts.NextPageToken(exps[opts.PageSize])
+	npt, err := opts.NextPageToken(exps[opts.PageSize-1])
 	return exps[:opts.PageSize], total_size, npt, err
 }
 
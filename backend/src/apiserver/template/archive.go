@@ -0,0 +1,267 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+const (
+	// archiveRootSpec is the name of the root pipeline spec of a pipeline archive.
+	archiveRootSpec = "pipeline.yaml"
+	// archiveComponentsDir is the directory, next to the root spec, holding the compiled components of a
+	// pipeline archive.
+	archiveComponentsDir = "components"
+)
+
+// IsArchive returns whether a pipeline file is a zip file or a tarball, compressed or not.
+func IsArchive(file []byte) bool {
+	return isZip(file) || isGzip(file) || isTar(file)
+}
+
+func isZip(file []byte) bool {
+	return len(file) > 2 && file[0] == '\x50' && file[1] == '\x4B'
+}
+
+func isGzip(file []byte) bool {
+	return len(file) > 2 && file[0] == '\x1F' && file[1] == '\x8B'
+}
+
+func isTar(file []byte) bool {
+	return len(file) > 262 && string(file[257:262]) == "ustar"
+}
+
+// AssembleArchive returns the pipeline manifest of a zip file or a tarball compiled from a directory. The
+// root pipeline spec is the pipeline.yaml file at the top of the archive, or in its only top-level
+// directory, and the compiled components are the YAML files of the components directory next to it, whose
+// components and deployment spec executors are merged into those of the root spec. An archive holding a
+// single YAML file has it as the root spec. The decompressed files of the archive can't be larger than
+// maxBytes in total, if maxBytes is positive.
+func AssembleArchive(file []byte, maxBytes int) ([]byte, error) {
+	files, err := extractArchive(file, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	rootPath, err := archiveRootSpecPath(files)
+	if err != nil {
+		return nil, err
+	}
+	componentsPrefix := path.Join(path.Dir(rootPath), archiveComponentsDir) + "/"
+	var componentPaths []string
+	for name := range files {
+		if strings.HasPrefix(name, componentsPrefix) && isYaml(name) {
+			componentPaths = append(componentPaths, name)
+		}
+	}
+	if len(componentPaths) == 0 {
+		return files[rootPath], nil
+	}
+	if inferTemplateFormat(files[rootPath]) != V2 {
+		return nil, util.NewInvalidInputError("Failed to assemble the pipeline archive: components can only be added to a v2 pipeline spec, which %v is not", rootPath)
+	}
+	var root map[string]interface{}
+	if err := yaml.Unmarshal(files[rootPath], &root); err != nil {
+		return nil, util.NewInvalidInputErrorWithDetails(err, "Failed to assemble the pipeline archive: invalid root pipeline spec")
+	}
+	sort.Strings(componentPaths)
+	for _, name := range componentPaths {
+		var component map[string]interface{}
+		if err := yaml.Unmarshal(files[name], &component); err != nil {
+			return nil, util.NewInvalidInputErrorWithDetails(err, "Failed to assemble the pipeline archive: invalid component "+name)
+		}
+		if err := mergeArchiveComponent(root, component, name); err != nil {
+			return nil, err
+		}
+	}
+	manifest, err := yaml.Marshal(root)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to assemble the pipeline archive")
+	}
+	return manifest, nil
+}
+
+// extractArchive returns the regular files of an archive by their cleaned paths.
+func extractArchive(file []byte, maxBytes int) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	remaining := int64(maxBytes)
+	add := func(name string, reader io.Reader) error {
+		cleaned, err := archiveEntryPath(name)
+		if err != nil {
+			return err
+		}
+		if maxBytes > 0 {
+			reader = io.LimitReader(reader, remaining+1)
+		}
+		content, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return util.NewInvalidInputErrorWithDetails(err, "Failed to read "+name+" from the pipeline archive")
+		}
+		if maxBytes > 0 {
+			remaining -= int64(len(content))
+			if remaining < 0 {
+				return util.NewInvalidInputError("The decompressed pipeline archive exceeds the maximum allowed size of %v bytes", maxBytes)
+			}
+		}
+		files[cleaned] = content
+		return nil
+	}
+
+	if isZip(file) {
+		reader, err := zip.NewReader(bytes.NewReader(file), int64(len(file)))
+		if err != nil {
+			return nil, util.NewInvalidInputErrorWithDetails(err, "Not a valid zip file")
+		}
+		for _, f := range reader.File {
+			if f.FileInfo().IsDir() {
+				continue
+			}
+			if f.Mode()&os.ModeSymlink != 0 {
+				return nil, util.NewInvalidInputError("The pipeline archive can't have links: %v", f.Name)
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, util.NewInvalidInputErrorWithDetails(err, "Failed to read "+f.Name+" from the pipeline archive")
+			}
+			err = add(f.Name, rc)
+			rc.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+		return files, nil
+	}
+
+	var tarFile io.Reader = bytes.NewReader(file)
+	if isGzip(file) {
+		gzipReader, err := gzip.NewReader(tarFile)
+		if err != nil {
+			return nil, util.NewInvalidInputErrorWithDetails(err, "Not a valid tarball file")
+		}
+		tarFile = gzipReader
+	}
+	tarReader := tar.NewReader(tarFile)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, util.NewInvalidInputErrorWithDetails(err, "Not a valid tarball file")
+		}
+		switch header.Typeflag {
+		case tar.TypeReg, tar.TypeRegA:
+			if err := add(header.Name, tarReader); err != nil {
+				return nil, err
+			}
+		case tar.TypeSymlink, tar.TypeLink:
+			return nil, util.NewInvalidInputError("The pipeline archive can't have links: %v", header.Name)
+		}
+	}
+	return files, nil
+}
+
+// archiveEntryPath returns the cleaned path of an archive entry, rejecting paths that would leave the
+// directory the archive was compiled from.
+func archiveEntryPath(name string) (string, error) {
+	cleaned := path.Clean(strings.TrimPrefix(name, "./"))
+	if strings.HasPrefix(name, "/") || strings.Contains(name, "\\") || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", util.NewInvalidInputError("The pipeline archive has an entry outside of its directory: %v", name)
+	}
+	return cleaned, nil
+}
+
+// archiveRootSpecPath returns the path of the root pipeline spec among the files of an archive.
+func archiveRootSpecPath(files map[string][]byte) (string, error) {
+	if _, ok := files[archiveRootSpec]; ok {
+		return archiveRootSpec, nil
+	}
+	topLevel := map[string]bool{}
+	var yamlFiles []string
+	for name := range files {
+		topLevel[strings.SplitN(name, "/", 2)[0]] = true
+		if isYaml(name) {
+			yamlFiles = append(yamlFiles, name)
+		}
+	}
+	if len(topLevel) == 1 {
+		for dir := range topLevel {
+			if _, ok := files[path.Join(dir, archiveRootSpec)]; ok {
+				return path.Join(dir, archiveRootSpec), nil
+			}
+		}
+	}
+	if len(files) == 1 && len(yamlFiles) == 1 {
+		return yamlFiles[0], nil
+	}
+	return "", util.NewInvalidInputError("The pipeline archive is missing the root pipeline spec %v", archiveRootSpec)
+}
+
+// mergeArchiveComponent adds the components and the deployment spec executors of a compiled component to a
+// root pipeline spec. A name can only be defined more than once if every definition is the same.
+func mergeArchiveComponent(root map[string]interface{}, component map[string]interface{}, name string) error {
+	components, _ := component["components"].(map[string]interface{})
+	deploymentSpec, _ := component["deploymentSpec"].(map[string]interface{})
+	executors, _ := deploymentSpec["executors"].(map[string]interface{})
+	if len(components) == 0 && len(executors) == 0 {
+		return util.NewInvalidInputError("Failed to assemble the pipeline archive: %v has no components or executors", name)
+	}
+	if err := mergeArchiveDefinitions(root, "components", components, name); err != nil {
+		return err
+	}
+	if len(executors) == 0 {
+		return nil
+	}
+	rootDeploymentSpec, ok := root["deploymentSpec"].(map[string]interface{})
+	if !ok {
+		rootDeploymentSpec = map[string]interface{}{}
+		root["deploymentSpec"] = rootDeploymentSpec
+	}
+	return mergeArchiveDefinitions(rootDeploymentSpec, "executors", executors, name)
+}
+
+func mergeArchiveDefinitions(parent map[string]interface{}, key string, definitions map[string]interface{}, name string) error {
+	if len(definitions) == 0 {
+		return nil
+	}
+	existing, ok := parent[key].(map[string]interface{})
+	if !ok {
+		existing = map[string]interface{}{}
+		parent[key] = existing
+	}
+	for definitionName, definition := range definitions {
+		if current, ok := existing[definitionName]; ok && !reflect.DeepEqual(current, definition) {
+			return util.NewInvalidInputError("Failed to assemble the pipeline archive: %v redefines %v %v", name, key, definitionName)
+		}
+		existing[definitionName] = definition
+	}
+	return nil
+}
+
+func isYaml(name string) bool {
+	return strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")
+}
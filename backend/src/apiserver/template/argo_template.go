@@ -41,8 +41,8 @@ func (t *Argo) RunWorkflow(modelRun *model.Run, options RunWorkflowOptions) (uti
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to convert parameters.")
 	}
-	// Verify no additional parameter provided
-	if err := workflow.VerifyParameters(parameters); err != nil {
+	// Verify no additional parameter provided and no required parameter missing
+	if err := workflow.VerifyRunParameters(parameters); err != nil {
 		return nil, util.Wrap(err, "Failed to verify parameters.")
 	}
 	// Append provided parameter
@@ -199,10 +199,33 @@ func (t *Argo) ParametersJSON() (string, error) {
 	return util.MarshalParameters(util.ArgoWorkflow, t.wf.SpecParameters())
 }
 
+// StringParameterDefaults returns the values of the workflow parameters that have one.
+func (t *Argo) StringParameterDefaults() map[string]string {
+	defaults := map[string]string{}
+	for _, param := range t.wf.Spec.Arguments.Parameters {
+		if param.Value != nil {
+			defaults[param.Name] = param.Value.String()
+		}
+	}
+	return defaults
+}
+
+// ParameterSchema returns nil, since Argo workflow parameters are untyped.
+func (t *Argo) ParameterSchema() ParameterSchema {
+	return nil
+}
+
 func NewArgoTemplateFromWorkflow(wf *workflowapi.Workflow) (*Argo, error) {
 	return &Argo{wf: &util.Workflow{Workflow: wf}}, nil
 }
 
+// argoValidateOpts are the options pipeline workflows are validated with.
+var argoValidateOpts = validate.ValidateOpts{
+	Lint:                       true,
+	IgnoreEntrypoint:           true,
+	WorkflowTemplateValidation: false, // not used by kubeflow
+}
+
 func ValidateWorkflow(template []byte) (*util.Workflow, error) {
 	var wf workflowapi.Workflow
 	err := yaml.Unmarshal(template, &wf)
@@ -215,11 +238,7 @@ func ValidateWorkflow(template []byte) (*util.Workflow, error) {
 	if wf.Kind != argoK8sResource {
 		return nil, util.NewInvalidInputError("Unexpected resource type. Expected: %v. Received: %v", argoK8sResource, wf.Kind)
 	}
-	_, err = validate.ValidateWorkflow(nil, nil, &wf, validate.ValidateOpts{
-		Lint:                       true,
-		IgnoreEntrypoint:           true,
-		WorkflowTemplateValidation: false, // not used by kubeflow
-	})
+	_, err = validate.ValidateWorkflow(nil, nil, &wf, argoValidateOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,265 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// maxLineDiffCells bounds the work of the line diff of two manifests. Past it, the lines that differ are
+// listed as removed and added without looking for common lines between them.
+const maxLineDiffCells = 4 << 20
+
+// ManifestDiff describes what changed between two pipeline manifests.
+type ManifestDiff struct {
+	// Degraded is set when the manifests couldn't both be parsed in the same format. Only LineDiff is set
+	// then.
+	Degraded bool
+	// The templates of an Argo workflow, or the components of a pipeline spec, by name.
+	AddedTemplates   []string
+	RemovedTemplates []string
+	ChangedTemplates []string
+	ParameterChanges []ParameterChange
+	ImageChanges     []ImageChange
+	// LineDiff lists the lines only in the first manifest, prefixed with "- ", and the lines only in the
+	// second, prefixed with "+ ", in the order they appear.
+	LineDiff []string
+}
+
+// ParameterChange is a pipeline parameter that was added, removed, or whose default value changed.
+type ParameterChange struct {
+	Name string
+	// OldValue and NewValue are nil when the parameter doesn't exist in that manifest.
+	OldValue *string
+	NewValue *string
+}
+
+// ImageChange is a template, present in both manifests, that runs a different container image.
+type ImageChange struct {
+	Template string
+	OldImage string
+	NewImage string
+}
+
+// manifestSummary holds the parts of a manifest that are compared.
+type manifestSummary struct {
+	templateType TemplateType
+	templates    map[string]interface{}
+	parameters   map[string]string
+	images       map[string]string
+	equal        func(a, b interface{}) bool
+}
+
+// CompareManifests returns what changed from manifest a to manifest b. Manifests that can't both be
+// parsed, or that aren't in the same format, are compared line by line.
+func CompareManifests(a []byte, b []byte) *ManifestDiff {
+	summaryA, errA := summarizeManifest(a)
+	summaryB, errB := summarizeManifest(b)
+	if errA != nil || errB != nil || summaryA.templateType != summaryB.templateType {
+		return &ManifestDiff{Degraded: true, LineDiff: diffLines(string(a), string(b))}
+	}
+	diff := &ManifestDiff{}
+	for name, templateA := range summaryA.templates {
+		templateB, ok := summaryB.templates[name]
+		switch {
+		case !ok:
+			diff.RemovedTemplates = append(diff.RemovedTemplates, name)
+		case !summaryA.equal(templateA, templateB):
+			diff.ChangedTemplates = append(diff.ChangedTemplates, name)
+		}
+	}
+	for name := range summaryB.templates {
+		if _, ok := summaryA.templates[name]; !ok {
+			diff.AddedTemplates = append(diff.AddedTemplates, name)
+		}
+	}
+	sort.Strings(diff.AddedTemplates)
+	sort.Strings(diff.RemovedTemplates)
+	sort.Strings(diff.ChangedTemplates)
+
+	for _, name := range unionOfKeys(summaryA.parameters, summaryB.parameters) {
+		valueA, okA := summaryA.parameters[name]
+		valueB, okB := summaryB.parameters[name]
+		if okA && okB && valueA == valueB {
+			continue
+		}
+		change := ParameterChange{Name: name}
+		if okA {
+			change.OldValue = &valueA
+		}
+		if okB {
+			change.NewValue = &valueB
+		}
+		diff.ParameterChanges = append(diff.ParameterChanges, change)
+	}
+	for _, name := range unionOfKeys(summaryA.images, summaryB.images) {
+		imageA, okA := summaryA.images[name]
+		imageB, okB := summaryB.images[name]
+		if okA && okB && imageA != imageB {
+			diff.ImageChanges = append(diff.ImageChanges, ImageChange{Template: name, OldImage: imageA, NewImage: imageB})
+		}
+	}
+	return diff
+}
+
+func summarizeManifest(manifest []byte) (*manifestSummary, error) {
+	tmpl, err := New(manifest)
+	if err != nil {
+		return nil, err
+	}
+	switch t := tmpl.(type) {
+	case *Argo:
+		return summarizeArgo(t), nil
+	case *V2Spec:
+		return summarizeV2Spec(t), nil
+	}
+	return nil, ErrorInvalidPipelineSpec
+}
+
+func summarizeArgo(t *Argo) *manifestSummary {
+	summary := &manifestSummary{
+		templateType: V1,
+		templates:    map[string]interface{}{},
+		parameters:   map[string]string{},
+		images:       map[string]string{},
+		equal:        reflect.DeepEqual,
+	}
+	for _, template := range t.wf.Spec.Templates {
+		summary.templates[template.Name] = template
+		switch {
+		case template.Container != nil:
+			summary.images[template.Name] = template.Container.Image
+		case template.Script != nil:
+			summary.images[template.Name] = template.Script.Image
+		}
+	}
+	for _, param := range t.wf.Spec.Arguments.Parameters {
+		value := ""
+		if param.Value != nil {
+			value = param.Value.String()
+		}
+		summary.parameters[param.Name] = value
+	}
+	return summary
+}
+
+func summarizeV2Spec(t *V2Spec) *manifestSummary {
+	summary := &manifestSummary{
+		templateType: V2,
+		templates:    map[string]interface{}{},
+		parameters:   map[string]string{},
+		images:       map[string]string{},
+		equal: func(a, b interface{}) bool {
+			return proto.Equal(a.(proto.Message), b.(proto.Message))
+		},
+	}
+	// The root component holds the DAG wiring the other components together.
+	summary.templates["root"] = t.spec.GetRoot()
+	for name, component := range t.spec.GetComponents() {
+		summary.templates[name] = component
+	}
+	for name, param := range t.spec.GetRoot().GetInputDefinitions().GetParameters() {
+		value := ""
+		if param.GetDefaultValue() != nil {
+			if bytes, err := json.Marshal(param.GetDefaultValue().AsInterface()); err == nil {
+				value = string(bytes)
+			}
+		}
+		summary.parameters[name] = value
+	}
+	executors, _ := t.spec.GetDeploymentSpec().AsMap()["executors"].(map[string]interface{})
+	for name, executor := range executors {
+		executorMap, _ := executor.(map[string]interface{})
+		container, _ := executorMap["container"].(map[string]interface{})
+		if image, ok := container["image"].(string); ok {
+			summary.images[name] = image
+		}
+	}
+	return summary
+}
+
+func unionOfKeys(a map[string]string, b map[string]string) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// diffLines returns the lines removed from a and added in b, keeping the lines common to both out of the
+// diff along a longest common subsequence.
+func diffLines(a string, b string) []string {
+	linesA := strings.Split(a, "\n")
+	linesB := strings.Split(b, "\n")
+	// Common leading and trailing lines don't take part in the diff.
+	for len(linesA) > 0 && len(linesB) > 0 && linesA[0] == linesB[0] {
+		linesA, linesB = linesA[1:], linesB[1:]
+	}
+	for len(linesA) > 0 && len(linesB) > 0 && linesA[len(linesA)-1] == linesB[len(linesB)-1] {
+		linesA, linesB = linesA[:len(linesA)-1], linesB[:len(linesB)-1]
+	}
+	diff := []string{}
+	if len(linesA)*len(linesB) > maxLineDiffCells {
+		for _, line := range linesA {
+			diff = append(diff, "- "+line)
+		}
+		for _, line := range linesB {
+			diff = append(diff, "+ "+line)
+		}
+		return diff
+	}
+	// common[i][j] is the length of the longest common subsequence of linesA[i:] and linesB[j:].
+	common := make([][]int, len(linesA)+1)
+	for i := range common {
+		common[i] = make([]int, len(linesB)+1)
+	}
+	for i := len(linesA) - 1; i >= 0; i-- {
+		for j := len(linesB) - 1; j >= 0; j-- {
+			if linesA[i] == linesB[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(linesA) || j < len(linesB) {
+		switch {
+		case i < len(linesA) && j < len(linesB) && linesA[i] == linesB[j]:
+			i++
+			j++
+		case j == len(linesB) || (i < len(linesA) && common[i+1][j] >= common[i][j+1]):
+			diff = append(diff, "- "+linesA[i])
+			i++
+		default:
+			diff = append(diff, "+ "+linesB[j])
+			j++
+		}
+	}
+	return diff
+}
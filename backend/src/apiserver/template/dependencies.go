@@ -0,0 +1,239 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	workflowapi "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Dependencies are the container images and the Kubernetes objects a pipeline manifest pulls in.
+type Dependencies struct {
+	Images []*ImageDependency
+	// Names of the secrets, config maps and persistent volume claims of the run's namespace the pods use.
+	Secrets      []string
+	ConfigMaps   []string
+	VolumeClaims []string
+	// Warnings are about dependencies that can't be pinned down by reviewing the manifest, such as images
+	// referenced by a floating tag.
+	Warnings []string
+}
+
+// ImageDependency is a container image run by a manifest, along with the templates, or the executors of a
+// pipeline spec, that run it.
+type ImageDependency struct {
+	Image      string
+	Repository string
+	// Tag and Digest are empty when the image reference doesn't have them.
+	Tag       string
+	Digest    string
+	Templates []string
+}
+
+// dependencySet collects the dependencies of a manifest without duplicates.
+type dependencySet struct {
+	images       map[string]map[string]bool
+	secrets      map[string]bool
+	configMaps   map[string]bool
+	volumeClaims map[string]bool
+}
+
+func newDependencySet() *dependencySet {
+	return &dependencySet{
+		images:       map[string]map[string]bool{},
+		secrets:      map[string]bool{},
+		configMaps:   map[string]bool{},
+		volumeClaims: map[string]bool{},
+	}
+}
+
+// ExtractDependencies returns the dependencies of an Argo workflow or of a pipeline spec. Pipeline specs only
+// declare the images of their executors.
+func ExtractDependencies(manifest []byte) (*Dependencies, error) {
+	tmpl, err := New(manifest)
+	if err != nil {
+		return nil, err
+	}
+	set := newDependencySet()
+	switch t := tmpl.(type) {
+	case *Argo:
+		set.addArgo(&t.wf.Spec)
+	case *V2Spec:
+		executors, _ := t.spec.GetDeploymentSpec().AsMap()["executors"].(map[string]interface{})
+		for name, executor := range executors {
+			executorMap, _ := executor.(map[string]interface{})
+			container, _ := executorMap["container"].(map[string]interface{})
+			if image, ok := container["image"].(string); ok {
+				set.addImage(image, name)
+			}
+		}
+	default:
+		return nil, ErrorInvalidPipelineSpec
+	}
+	return set.dependencies(), nil
+}
+
+func (s *dependencySet) addArgo(spec *workflowapi.WorkflowSpec) {
+	for _, secret := range spec.ImagePullSecrets {
+		s.add(s.secrets, secret.Name)
+	}
+	s.addVolumes(spec.Volumes)
+	for index := range spec.Templates {
+		t := &spec.Templates[index]
+		s.addVolumes(t.Volumes)
+		var containers []*corev1.Container
+		if t.Container != nil {
+			containers = append(containers, t.Container)
+		}
+		if t.Script != nil {
+			containers = append(containers, &t.Script.Container)
+		}
+		if t.ContainerSet != nil {
+			for i := range t.ContainerSet.Containers {
+				containers = append(containers, &t.ContainerSet.Containers[i].Container)
+			}
+		}
+		for i := range t.InitContainers {
+			containers = append(containers, &t.InitContainers[i].Container)
+		}
+		for i := range t.Sidecars {
+			containers = append(containers, &t.Sidecars[i].Container)
+		}
+		for _, container := range containers {
+			s.addImage(container.Image, t.Name)
+			s.addContainerEnv(container)
+		}
+	}
+}
+
+func (s *dependencySet) addContainerEnv(container *corev1.Container) {
+	for _, envVar := range container.Env {
+		if envVar.ValueFrom == nil {
+			continue
+		}
+		if ref := envVar.ValueFrom.SecretKeyRef; ref != nil {
+			s.add(s.secrets, ref.Name)
+		}
+		if ref := envVar.ValueFrom.ConfigMapKeyRef; ref != nil {
+			s.add(s.configMaps, ref.Name)
+		}
+	}
+	for _, source := range container.EnvFrom {
+		if source.SecretRef != nil {
+			s.add(s.secrets, source.SecretRef.Name)
+		}
+		if source.ConfigMapRef != nil {
+			s.add(s.configMaps, source.ConfigMapRef.Name)
+		}
+	}
+}
+
+func (s *dependencySet) addVolumes(volumes []corev1.Volume) {
+	for _, volume := range volumes {
+		if volume.Secret != nil {
+			s.add(s.secrets, volume.Secret.SecretName)
+		}
+		if volume.ConfigMap != nil {
+			s.add(s.configMaps, volume.ConfigMap.Name)
+		}
+		if volume.PersistentVolumeClaim != nil {
+			s.add(s.volumeClaims, volume.PersistentVolumeClaim.ClaimName)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					s.add(s.secrets, source.Secret.Name)
+				}
+				if source.ConfigMap != nil {
+					s.add(s.configMaps, source.ConfigMap.Name)
+				}
+			}
+		}
+	}
+}
+
+func (s *dependencySet) addImage(image string, templateName string) {
+	if image == "" {
+		return
+	}
+	if s.images[image] == nil {
+		s.images[image] = map[string]bool{}
+	}
+	s.images[image][templateName] = true
+}
+
+func (s *dependencySet) add(names map[string]bool, name string) {
+	if name != "" {
+		names[name] = true
+	}
+}
+
+func (s *dependencySet) dependencies() *Dependencies {
+	dependencies := &Dependencies{
+		Images:       []*ImageDependency{},
+		Secrets:      sortedKeys(s.secrets),
+		ConfigMaps:   sortedKeys(s.configMaps),
+		VolumeClaims: sortedKeys(s.volumeClaims),
+		Warnings:     []string{},
+	}
+	images := make([]string, 0, len(s.images))
+	for image := range s.images {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	for _, image := range images {
+		repository, tag, digest := splitImageReference(image)
+		dependency := &ImageDependency{Image: image, Repository: repository, Tag: tag, Digest: digest, Templates: sortedKeys(s.images[image])}
+		dependencies.Images = append(dependencies.Images, dependency)
+		templates := strings.Join(dependency.Templates, ", ")
+		switch {
+		case strings.Contains(image, "{{"):
+			dependencies.Warnings = append(dependencies.Warnings,
+				fmt.Sprintf("Image %v of %v is only known when the pipeline runs", image, templates))
+		case digest == "" && (tag == "" || tag == "latest"):
+			dependencies.Warnings = append(dependencies.Warnings,
+				fmt.Sprintf("Image %v of %v is referenced by the floating tag latest, so it can change without the pipeline changing", image, templates))
+		}
+	}
+	return dependencies
+}
+
+// splitImageReference splits an image reference, e.g. gcr.io/project/image:tag@sha256:digest, into its
+// repository, tag and digest.
+func splitImageReference(image string) (string, string, string) {
+	repository, digest := image, ""
+	if i := strings.Index(image, "@"); i >= 0 {
+		repository, digest = image[:i], image[i+1:]
+	}
+	tag := ""
+	// A colon before the last slash separates the port of the registry.
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository, tag = repository[:i], repository[i+1:]
+	}
+	return repository, tag, digest
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"fmt"
+	"sort"
+
+	workflowapi "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/validate"
+	"github.com/ghodss/yaml"
+	"github.com/kubeflow/pipelines/api/v2alpha1/go/pipelinespec"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// DiagnosticSeverity tells whether a problem of a pipeline file keeps it from being uploaded.
+type DiagnosticSeverity string
+
+const (
+	// DiagnosticError is a problem the pipeline file is rejected for on upload.
+	DiagnosticError DiagnosticSeverity = "ERROR"
+	// DiagnosticWarning is a problem the pipeline file is accepted with, but its runs would likely fail for.
+	DiagnosticWarning DiagnosticSeverity = "WARNING"
+)
+
+// Diagnostic is a problem found in a pipeline file.
+type Diagnostic struct {
+	Severity DiagnosticSeverity `json:"severity"`
+	// Path of the field the problem is in, e.g. spec.arguments.parameters[0]. Empty if it is about the whole file.
+	Location string `json:"location,omitempty"`
+	Message  string `json:"message"`
+}
+
+// HasErrors returns whether any of the diagnostics is an error.
+func HasErrors(diagnostics []*Diagnostic) bool {
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == DiagnosticError {
+			return true
+		}
+	}
+	return false
+}
+
+// Diagnose validates a pipeline file the way New does, but returns all the problems found rather than the
+// first error, along with warnings about what New accepts but runs would fail for. The file is valid for
+// New if none of the diagnostics is an error.
+func Diagnose(bytes []byte) []*Diagnostic {
+	switch inferTemplateFormat(bytes) {
+	case V1:
+		return diagnoseArgoWorkflow(bytes)
+	case V2:
+		return diagnoseV2Spec(bytes)
+	default:
+		return []*Diagnostic{{Severity: DiagnosticError,
+			Message: "unknown template format: the file is neither an Argo workflow nor a v2 pipeline spec"}}
+	}
+}
+
+func diagnoseArgoWorkflow(bytes []byte) []*Diagnostic {
+	var wf workflowapi.Workflow
+	if err := yaml.Unmarshal(bytes, &wf); err != nil {
+		return []*Diagnostic{{Severity: DiagnosticError, Message: fmt.Sprintf("Failed to parse the workflow template: %v", err)}}
+	}
+	var diagnostics []*Diagnostic
+	addDiagnostic := func(severity DiagnosticSeverity, location string, format string, a ...interface{}) {
+		diagnostics = append(diagnostics, &Diagnostic{Severity: severity, Location: location, Message: fmt.Sprintf(format, a...)})
+	}
+	if wf.APIVersion != argoVersion {
+		addDiagnostic(DiagnosticError, "apiVersion", "Unsupported argo version. Expected: %v. Received: %v", argoVersion, wf.APIVersion)
+	}
+	if wf.Kind != argoK8sResource {
+		addDiagnostic(DiagnosticError, "kind", "Unexpected resource type. Expected: %v. Received: %v", argoK8sResource, wf.Kind)
+	}
+
+	names := map[string]bool{}
+	for i, param := range wf.Spec.Arguments.Parameters {
+		location := fmt.Sprintf("spec.arguments.parameters[%d]", i)
+		switch {
+		case param.Name == "":
+			addDiagnostic(DiagnosticError, location, "The parameter has no name")
+		case names[param.Name]:
+			addDiagnostic(DiagnosticError, location, "Parameter %q is declared more than once", param.Name)
+		case param.Value == nil && param.ValueFrom == nil:
+			addDiagnostic(DiagnosticWarning, location, "Parameter %q has no value, so every run must set it", param.Name)
+		}
+		names[param.Name] = true
+	}
+
+	hasEntrypoint := false
+	for _, tmpl := range wf.Spec.Templates {
+		hasEntrypoint = hasEntrypoint || tmpl.Name == wf.Spec.Entrypoint
+	}
+	if wf.Spec.Entrypoint == "" {
+		addDiagnostic(DiagnosticWarning, "spec.entrypoint", "The workflow has no entrypoint, so its runs can't start")
+	} else if !hasEntrypoint && wf.Spec.WorkflowTemplateRef == nil {
+		addDiagnostic(DiagnosticWarning, "spec.entrypoint", "The entrypoint %q is not a template of the workflow", wf.Spec.Entrypoint)
+	}
+
+	// Argo stops at the first problem, which would repeat one of the errors found above.
+	if !HasErrors(diagnostics) {
+		if _, err := validate.ValidateWorkflow(nil, nil, &wf, argoValidateOpts); err != nil {
+			addDiagnostic(DiagnosticError, "spec", "%v", err)
+		}
+	}
+	return diagnostics
+}
+
+func diagnoseV2Spec(bytes []byte) []*Diagnostic {
+	var spec pipelinespec.PipelineSpec
+	templateJson, err := yaml.YAMLToJSON(bytes)
+	if err != nil {
+		return []*Diagnostic{{Severity: DiagnosticError, Message: fmt.Sprintf("cannot convert v2 pipeline spec to json format: %v", err)}}
+	}
+	if err := protojson.Unmarshal(templateJson, &spec); err != nil {
+		return []*Diagnostic{{Severity: DiagnosticError, Message: fmt.Sprintf("invalid v2 pipeline spec: %v", err)}}
+	}
+	var diagnostics []*Diagnostic
+	if location, message := v2SpecViolation(&spec); message != "" {
+		diagnostics = append(diagnostics, &Diagnostic{Severity: DiagnosticError, Location: location, Message: "invalid v2 pipeline spec: " + message})
+	}
+	params := spec.GetRoot().GetInputDefinitions().GetParameters()
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if parameterTypeOf(params[name]) == "" {
+			diagnostics = append(diagnostics, &Diagnostic{
+				Severity: DiagnosticWarning,
+				Location: fmt.Sprintf("root.inputDefinitions.parameters.%s", name),
+				Message:  fmt.Sprintf("Parameter %q has no known type, so its values aren't checked", name),
+			})
+		}
+	}
+	return diagnostics
+}
@@ -0,0 +1,199 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/kubeflow/pipelines/api/v2alpha1/go/pipelinespec"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"google.golang.org/protobuf/encoding/protojson"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+)
+
+// Parameter types of a parameter schema.
+const (
+	ParameterTypeInt    = "int"
+	ParameterTypeFloat  = "float"
+	ParameterTypeBool   = "bool"
+	ParameterTypeString = "string"
+	ParameterTypeJSON   = "json"
+)
+
+// ParameterSchema maps the name of each input parameter of a pipeline to its declared type.
+type ParameterSchema map[string]string
+
+// parameterSchemaOf returns the parameter types declared by the root component of a v2 pipeline spec.
+// Parameters without a known type are left out.
+func parameterSchemaOf(spec *pipelinespec.PipelineSpec) ParameterSchema {
+	schema := ParameterSchema{}
+	for name, param := range spec.GetRoot().GetInputDefinitions().GetParameters() {
+		if paramType := parameterTypeOf(param); paramType != "" {
+			schema[name] = paramType
+		}
+	}
+	if len(schema) == 0 {
+		return nil
+	}
+	return schema
+}
+
+func parameterTypeOf(param *pipelinespec.ComponentInputsSpec_ParameterSpec) string {
+	switch param.GetParameterType() {
+	case pipelinespec.ParameterType_NUMBER_INTEGER:
+		return ParameterTypeInt
+	case pipelinespec.ParameterType_NUMBER_DOUBLE:
+		return ParameterTypeFloat
+	case pipelinespec.ParameterType_BOOLEAN:
+		return ParameterTypeBool
+	case pipelinespec.ParameterType_STRING:
+		return ParameterTypeString
+	case pipelinespec.ParameterType_LIST, pipelinespec.ParameterType_STRUCT:
+		return ParameterTypeJSON
+	}
+	// Older pipeline specs only carry the deprecated primitive type.
+	switch param.GetType() {
+	case pipelinespec.PrimitiveType_INT:
+		return ParameterTypeInt
+	case pipelinespec.PrimitiveType_DOUBLE:
+		return ParameterTypeFloat
+	case pipelinespec.PrimitiveType_STRING:
+		return ParameterTypeString
+	}
+	return ""
+}
+
+// JSON returns the schema serialized for storage, or the empty string if there is no schema.
+func (s ParameterSchema) JSON() (string, error) {
+	if len(s) == 0 {
+		return "", nil
+	}
+	bytes, err := json.Marshal(s)
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Failed to marshal the parameter schema")
+	}
+	return string(bytes), nil
+}
+
+// ValidateRunParameters checks the parameter values of a run against the schema. Parameters the schema
+// doesn't declare are not checked. All mismatches are returned as field violations of one InvalidInput
+// error.
+func (s ParameterSchema) ValidateRunParameters(run *model.Run) error {
+	if len(s) == 0 {
+		return nil
+	}
+	violations := map[string]string{}
+	params, err := modelToParametersMap(run.Parameters)
+	if err != nil {
+		return util.NewInvalidInputError("Failed to parse the run parameters: %v", err)
+	}
+	for name, value := range params {
+		if paramType, ok := s[name]; ok {
+			if err := validateStringParameter(paramType, value); err != nil {
+				violations["parameters."+name] = err.Error()
+			}
+		}
+	}
+	if run.RuntimeConfig.Parameters != "" {
+		runtimeParams := map[string]*structpb.Value{}
+		if err := json.Unmarshal([]byte(run.RuntimeConfig.Parameters), &runtimeParams); err != nil {
+			return util.NewInvalidInputError("Failed to parse the run runtime parameters: %v", err)
+		}
+		for name, value := range runtimeParams {
+			if paramType, ok := s[name]; ok {
+				if err := validateValueParameter(paramType, value); err != nil {
+					violations["runtime_config.parameters."+name] = err.Error()
+				}
+			}
+		}
+	}
+	if len(violations) > 0 {
+		return util.NewInvalidInputErrorWithFieldViolations(violations,
+			"%d parameter value(s) don't match the types declared by the pipeline", len(violations))
+	}
+	return nil
+}
+
+// RuntimeValue converts the string value of a parameter to a value of its declared type, for the runtime
+// config of a v2 run. Parameters the schema doesn't declare are passed as strings.
+func (s ParameterSchema) RuntimeValue(name string, value string) (*structpb.Value, error) {
+	paramType := s[name]
+	if err := validateStringParameter(paramType, value); err != nil {
+		return nil, util.NewInvalidInputErrorWithFieldViolations(map[string]string{"parameters." + name: err.Error()},
+			"Parameter %v doesn't match the type declared by the pipeline", name)
+	}
+	switch paramType {
+	case ParameterTypeInt, ParameterTypeFloat:
+		number, _ := strconv.ParseFloat(value, 64)
+		return structpb.NewNumberValue(number), nil
+	case ParameterTypeBool:
+		b, _ := strconv.ParseBool(value)
+		return structpb.NewBoolValue(b), nil
+	case ParameterTypeJSON:
+		v := &structpb.Value{}
+		if err := protojson.Unmarshal([]byte(value), v); err != nil {
+			return nil, util.NewInvalidInputError("Parameter %v isn't valid JSON: %v", name, err)
+		}
+		return v, nil
+	}
+	return structpb.NewStringValue(value), nil
+}
+
+func validateStringParameter(paramType string, value string) error {
+	var err error
+	switch paramType {
+	case ParameterTypeInt:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case ParameterTypeFloat:
+		_, err = strconv.ParseFloat(value, 64)
+	case ParameterTypeBool:
+		_, err = strconv.ParseBool(value)
+	case ParameterTypeJSON:
+		if !json.Valid([]byte(value)) {
+			err = fmt.Errorf("malformed JSON")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%q is not a valid %v", value, paramType)
+	}
+	return nil
+}
+
+func validateValueParameter(paramType string, value *structpb.Value) error {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		return validateStringParameter(paramType, kind.StringValue)
+	case *structpb.Value_NumberValue:
+		number := kind.NumberValue
+		if paramType == ParameterTypeFloat || (paramType == ParameterTypeInt && number == math.Trunc(number)) {
+			return nil
+		}
+	case *structpb.Value_BoolValue:
+		if paramType == ParameterTypeBool {
+			return nil
+		}
+	case *structpb.Value_ListValue, *structpb.Value_StructValue:
+		if paramType == ParameterTypeJSON {
+			return nil
+		}
+	case *structpb.Value_NullValue, nil:
+		return nil
+	}
+	return fmt.Errorf("value %v is not a valid %v", value.AsInterface(), paramType)
+}
@@ -48,6 +48,53 @@ const (
 
 var ErrorInvalidPipelineSpec = fmt.Errorf("pipeline spec is invalid")
 
+// TemplateFormat is a format that a stored template can be returned in.
+type TemplateFormat string
+
+const (
+	// TemplateFormatRaw returns the template as it was stored.
+	TemplateFormatRaw TemplateFormat = "RAW"
+	// TemplateFormatArgoYAML returns the template as an Argo workflow in YAML.
+	TemplateFormatArgoYAML TemplateFormat = "ARGO_YAML"
+	// TemplateFormatJSON returns the template in JSON.
+	TemplateFormatJSON TemplateFormat = "JSON"
+)
+
+// ConvertTemplate converts a stored template to the requested format. The format of the stored template
+// is detected from its content. An empty format is the same as TemplateFormatRaw. An Unimplemented error
+// is returned if the stored template can't be converted to the requested format.
+func ConvertTemplate(template []byte, format TemplateFormat) ([]byte, error) {
+	switch format {
+	case "", TemplateFormatRaw:
+		return template, nil
+	case TemplateFormatArgoYAML, TemplateFormatJSON:
+	default:
+		return nil, util.NewInvalidInputError("Unknown template format %q. Supported formats are %s, %s and %s",
+			format, TemplateFormatRaw, TemplateFormatArgoYAML, TemplateFormatJSON)
+	}
+	storedFormat := inferTemplateFormat(template)
+	if storedFormat == Unknown {
+		return nil, util.NewUnimplementedError(ErrorInvalidPipelineSpec,
+			"Cannot convert a template of unknown format to %s", format)
+	}
+	if format == TemplateFormatArgoYAML && storedFormat != V1 {
+		return nil, util.NewUnimplementedError(fmt.Errorf("template is in format %s", storedFormat),
+			"Cannot convert a %s template to %s", storedFormat, format)
+	}
+	templateJson, err := yaml.YAMLToJSON(template)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to convert the template to JSON")
+	}
+	if format == TemplateFormatJSON {
+		return templateJson, nil
+	}
+	templateYaml, err := yaml.JSONToYAML(templateJson)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to convert the template to YAML")
+	}
+	return templateYaml, nil
+}
+
 // inferTemplateFormat infers format from pipeline template.
 // There is no guarantee that the template is valid in inferred format, so validation
 // is still needed.
@@ -95,6 +142,10 @@ type Template interface {
 	OverrideV2PipelineName(name, namespace string)
 	// Gets parameters in JSON format.
 	ParametersJSON() (string, error)
+	// Gets the declared type of each input parameter. Nil if the template doesn't declare types.
+	ParameterSchema() ParameterSchema
+	// Gets the string default values of the input parameters, by name.
+	StringParameterDefaults() map[string]string
 	// Get bytes content.
 	Bytes() []byte
 	GetTemplateType() TemplateType
@@ -15,6 +15,12 @@
 package template
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -53,6 +59,142 @@ func TestValidateWorkflow_ParametersTooLong(t *testing.T) {
 	assert.Equal(t, codes.InvalidArgument, err.(*commonutil.UserError).ExternalStatusCode())
 }
 
+func TestDiagnose(t *testing.T) {
+	assert.Empty(t, Diagnose([]byte(template)))
+	assert.Empty(t, Diagnose([]byte(v2SpecHelloWorldYAML)))
+
+	diagnostics := Diagnose([]byte(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: hello-world-
+spec:
+  entrypoint: missing
+  arguments:
+    parameters:
+    - name: param1
+      value: value1
+    - name: param1
+      value: value2
+    - name: param2
+  templates:
+  - name: whalesay
+    container:
+      image: docker/whalesay:latest`))
+	assert.Equal(t, []*Diagnostic{
+		{Severity: DiagnosticError, Location: "spec.arguments.parameters[1]", Message: `Parameter "param1" is declared more than once`},
+		{Severity: DiagnosticWarning, Location: "spec.arguments.parameters[2]", Message: `Parameter "param2" has no value, so every run must set it`},
+		{Severity: DiagnosticWarning, Location: "spec.entrypoint", Message: `The entrypoint "missing" is not a template of the workflow`},
+	}, diagnostics)
+	assert.True(t, HasErrors(diagnostics))
+
+	// Argo's own validation runs once nothing else is wrong.
+	diagnostics = Diagnose([]byte(emptyName))
+	if assert.Len(t, diagnostics, 1) {
+		assert.Equal(t, DiagnosticError, diagnostics[0].Severity)
+		assert.Equal(t, "spec", diagnostics[0].Location)
+		assert.Contains(t, diagnostics[0].Message, "name is required")
+	}
+
+	diagnostics = Diagnose([]byte(strings.Replace(template, "argoproj.io/v1alpha1", "argoproj.io/v1alpha2", 1)))
+	assert.Equal(t, []*Diagnostic{{Severity: DiagnosticError, Location: "apiVersion",
+		Message: "Unsupported argo version. Expected: argoproj.io/v1alpha1. Received: argoproj.io/v1alpha2"}}, diagnostics)
+
+	diagnostics = Diagnose([]byte(strings.Replace(v2SpecHelloWorldYAML,
+		"      text:\n        type: STRING\nschemaVersion", "      text: {}\nschemaVersion", 1)))
+	assert.Equal(t, []*Diagnostic{
+		{Severity: DiagnosticWarning, Location: "root.inputDefinitions.parameters.text", Message: `Parameter "text" has no known type, so its values aren't checked`},
+	}, diagnostics)
+
+	diagnostics = Diagnose([]byte("not a pipeline"))
+	if assert.Len(t, diagnostics, 1) {
+		assert.Equal(t, DiagnosticError, diagnostics[0].Severity)
+		assert.Contains(t, diagnostics[0].Message, "unknown template format")
+	}
+}
+
+func TestExtractDependencies(t *testing.T) {
+	dependencies, err := ExtractDependencies([]byte(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: hello-world-
+spec:
+  entrypoint: train
+  arguments:
+    parameters:
+    - name: image
+      value: python:3.7
+  imagePullSecrets:
+  - name: registry
+  volumes:
+  - name: data
+    persistentVolumeClaim:
+      claimName: datasets
+  templates:
+  - name: train
+    container:
+      image: registry.example.com:5000/ml/train:1.2@sha256:abc
+      env:
+      - name: TOKEN
+        valueFrom:
+          secretKeyRef:
+            name: db
+            key: password
+      envFrom:
+      - configMapRef:
+          name: settings
+    sidecars:
+    - name: proxy
+      image: envoyproxy/envoy
+  - name: evaluate
+    script:
+      image: python:latest
+      source: print("hi")
+    volumes:
+    - name: config
+      configMap:
+        name: eval-config
+    - name: creds
+      projected:
+        sources:
+        - secret:
+            name: gcp
+  - name: tuned
+    container:
+      image: "{{workflow.parameters.image}}"
+  - name: train-again
+    container:
+      image: registry.example.com:5000/ml/train:1.2@sha256:abc`))
+	assert.Nil(t, err)
+	assert.Equal(t, []*ImageDependency{
+		{Image: "envoyproxy/envoy", Repository: "envoyproxy/envoy", Templates: []string{"train"}},
+		{Image: "python:latest", Repository: "python", Tag: "latest", Templates: []string{"evaluate"}},
+		{Image: "registry.example.com:5000/ml/train:1.2@sha256:abc", Repository: "registry.example.com:5000/ml/train",
+			Tag: "1.2", Digest: "sha256:abc", Templates: []string{"train", "train-again"}},
+		{Image: "{{workflow.parameters.image}}", Repository: "{{workflow.parameters.image}}", Templates: []string{"tuned"}},
+	}, dependencies.Images)
+	assert.Equal(t, []string{"db", "gcp", "registry"}, dependencies.Secrets)
+	assert.Equal(t, []string{"eval-config", "settings"}, dependencies.ConfigMaps)
+	assert.Equal(t, []string{"datasets"}, dependencies.VolumeClaims)
+	if assert.Len(t, dependencies.Warnings, 3) {
+		assert.Contains(t, dependencies.Warnings[0], "envoyproxy/envoy of train is referenced by the floating tag latest")
+		assert.Contains(t, dependencies.Warnings[1], "python:latest of evaluate is referenced by the floating tag latest")
+		assert.Contains(t, dependencies.Warnings[2], "of tuned is only known when the pipeline runs")
+	}
+
+	dependencies, err = ExtractDependencies([]byte(v2SpecHelloWorldYAML))
+	assert.Nil(t, err)
+	assert.Equal(t, []*ImageDependency{
+		{Image: "python:3.7", Repository: "python", Tag: "3.7", Templates: []string{"exec-hello-world"}},
+	}, dependencies.Images)
+	assert.Empty(t, dependencies.Secrets)
+	assert.Empty(t, dependencies.Warnings)
+
+	_, err = ExtractDependencies([]byte("not a pipeline"))
+	assert.NotNil(t, err)
+}
+
 func TestParseSpecFormat(t *testing.T) {
 	tt := []struct {
 		template     string
@@ -209,6 +351,124 @@ sdkVersion: kfp-1.6.5
 var WorkflowSpecV1 = "{\"kind\":\"Workflow\",\"apiVersion\":\"argoproj.io/v1alpha1\",\"metadata\":{\"generateName\":\"hello-world-\",\"creationTimestamp\":null,\"annotations\":{\"pipelines.kubeflow.org/components-comp-hello-world\":\"{\\\"executorLabel\\\":\\\"exec-hello-world\\\",\\\"inputDefinitions\\\":{\\\"parameters\\\":{\\\"text\\\":{\\\"type\\\":\\\"STRING\\\"}}}}\",\"pipelines.kubeflow.org/components-root\":\"{\\\"dag\\\":{\\\"tasks\\\":{\\\"hello-world\\\":{\\\"cachingOptions\\\":{\\\"enableCache\\\":true},\\\"componentRef\\\":{\\\"name\\\":\\\"comp-hello-world\\\"},\\\"inputs\\\":{\\\"parameters\\\":{\\\"text\\\":{\\\"componentInputParameter\\\":\\\"text\\\"}}},\\\"taskInfo\\\":{\\\"name\\\":\\\"hello-world\\\"}}}},\\\"inputDefinitions\\\":{\\\"parameters\\\":{\\\"text\\\":{\\\"type\\\":\\\"STRING\\\"}}}}\",\"pipelines.kubeflow.org/implementations-comp-hello-world\":\"{\\\"args\\\":[\\\"--text\\\",\\\"{{$.inputs.parameters['text']}}\\\"],\\\"command\\\":[\\\"sh\\\",\\\"-ec\\\",\\\"program_path=$(mktemp)\\\\nprintf \\\\\\\"%s\\\\\\\" \\\\\\\"$0\\\\\\\" \\\\u003e \\\\\\\"$program_path\\\\\\\"\\\\npython3 -u \\\\\\\"$program_path\\\\\\\" \\\\\\\"$@\\\\\\\"\\\\n\\\",\\\"def hello_world(text):\\\\n    print(text)\\\\n    return text\\\\n\\\\nimport argparse\\\\n_parser = argparse.ArgumentParser(prog='Hello world', description='')\\\\n_parser.add_argument(\\\\\\\"--text\\\\\\\", dest=\\\\\\\"text\\\\\\\", type=str, required=True, default=argparse.SUPPRESS)\\\\n_parsed_args = vars(_parser.parse_args())\\\\n\\\\n_outputs = hello_world(**_parsed_args)\\\\n\\\"],\\\"image\\\":\\\"python:3.7\\\"}\"}},\"spec\":{\"templates\":[{\"name\":\"system-container-driver\",\"inputs\":{\"parameters\":[{\"name\":\"component\"},{\"name\":\"task\"},{\"name\":\"container\"},{\"name\":\"parent-dag-id\"},{\"name\":\"iteration-index\",\"default\":\"-1\"}]},\"outputs\":{\"parameters\":[{\"name\":\"pod-spec-patch\",\"valueFrom\":{\"path\":\"/tmp/outputs/pod-spec-patch\",\"default\":\"\"}},{\"name\":\"cached-decision\",\"default\":\"false\",\"valueFrom\":{\"path\":\"/tmp/outputs/cached-decision\",\"default\":\"false\"}},{\"name\":\"condition\",\"valueFrom\":{\"path\":\"/tmp/outputs/condition\",\"default\":\"true\"}}]},\"metadata\":{\"annotations\":{\"sidecar.istio.io/inject\":\"false\"}},\"container\":{\"name\":\"\",\"image\":\"gcr.io/ml-pipeline-test/dev/kfp-driver@sha256:a2efa29022573d9bcc92ee0a843ac37bef20333d3e6b8d9d7fbe97cbd346d84c\",\"command\":[\"driver\"],\"args\":[\"--type\",\"CONTAINER\",\"--pipeline_name\",\"namespace/n1/pipeline/hello-world\",\"--run_id\",\"{{workflow.uid}}\",\"--dag_execution_id\",\"{{inputs.parameters.parent-dag-id}}\",\"--component\",\"{{inputs.parameters.component}}\",\"--task\",\"{{inputs.parameters.task}}\",\"--container\",\"{{inputs.parameters.container}}\",\"--iteration_index\",\"{{inputs.parameters.iteration-index}}\",\"--cached_decision_path\",\"{{outputs.parameters.cached-decision.path}}\",\"--pod_spec_patch_path\",\"{{outputs.parameters.pod-spec-patch.path}}\",\"--condition_path\",\"{{outputs.parameters.condition.path}}\"],\"resources\":{\"limits\":{\"cpu\":\"500m\",\"memory\":\"512Mi\"},\"requests\":{\"cpu\":\"100m\",\"memory\":\"64Mi\"}}}},{\"name\":\"system-container-executor\",\"inputs\":{\"parameters\":[{\"name\":\"pod-spec-patch\"},{\"name\":\"cached-decision\",\"default\":\"false\"}]},\"outputs\":{},\"metadata\":{\"annotations\":{\"sidecar.istio.io/inject\":\"false\"}},\"dag\":{\"tasks\":[{\"name\":\"executor\",\"template\":\"system-container-impl\",\"arguments\":{\"parameters\":[{\"name\":\"pod-spec-patch\",\"value\":\"{{inputs.parameters.pod-spec-patch}}\"}]},\"when\":\"{{inputs.parameters.cached-decision}} != true\"}]}},{\"name\":\"system-container-impl\",\"inputs\":{\"parameters\":[{\"name\":\"pod-spec-patch\"}]},\"outputs\":{},\"metadata\":{\"annotations\":{\"sidecar.istio.io/inject\":\"false\"}},\"container\":{\"name\":\"\",\"image\":\"gcr.io/ml-pipeline/should-be-overridden-during-runtime\",\"command\":[\"should-be-overridden-during-runtime\"],\"envFrom\":[{\"configMapRef\":{\"name\":\"metadata-grpc-configmap\",\"optional\":true}}],\"env\":[{\"name\":\"KFP_POD_NAME\",\"valueFrom\":{\"fieldRef\":{\"fieldPath\":\"metadata.name\"}}},{\"name\":\"KFP_POD_UID\",\"valueFrom\":{\"fieldRef\":{\"fieldPath\":\"metadata.uid\"}}}],\"resources\":{},\"volumeMounts\":[{\"name\":\"kfp-launcher\",\"mountPath\":\"/kfp-launcher\"}]},\"volumes\":[{\"name\":\"kfp-launcher\",\"emptyDir\":{}}],\"initContainers\":[{\"name\":\"kfp-launcher\",\"image\":\"gcr.io/ml-pipeline-test/dev/kfp-launcher-v2@sha256:4513cf5c10c252d94f383ce51a890514799c200795e3de5e90f91b98b2e2f959\",\"command\":[\"launcher-v2\",\"--copy\",\"/kfp-launcher/launch\"],\"resources\":{\"limits\":{\"cpu\":\"500m\",\"memory\":\"128Mi\"},\"requests\":{\"cpu\":\"100m\"}},\"volumeMounts\":[{\"name\":\"kfp-launcher\",\"mountPath\":\"/kfp-launcher\"}]}],\"podSpecPatch\":\"{{inputs.parameters.pod-spec-patch}}\"},{\"name\":\"root\",\"inputs\":{\"parameters\":[{\"name\":\"parent-dag-id\"}]},\"outputs\":{},\"metadata\":{\"annotations\":{\"sidecar.istio.io/inject\":\"false\"}},\"dag\":{\"tasks\":[{\"name\":\"hello-world-driver\",\"template\":\"system-container-driver\",\"arguments\":{\"parameters\":[{\"name\":\"component\",\"value\":\"{{workflow.annotations.pipelines.kubeflow.org/components-comp-hello-world}}\"},{\"name\":\"task\",\"value\":\"{\\\"cachingOptions\\\":{\\\"enableCache\\\":true},\\\"componentRef\\\":{\\\"name\\\":\\\"comp-hello-world\\\"},\\\"inputs\\\":{\\\"parameters\\\":{\\\"text\\\":{\\\"componentInputParameter\\\":\\\"text\\\"}}},\\\"taskInfo\\\":{\\\"name\\\":\\\"hello-world\\\"}}\"},{\"name\":\"container\",\"value\":\"{{workflow.annotations.pipelines.kubeflow.org/implementations-comp-hello-world}}\"},{\"name\":\"parent-dag-id\",\"value\":\"{{inputs.parameters.parent-dag-id}}\"}]}},{\"name\":\"hello-world\",\"template\":\"system-container-executor\",\"arguments\":{\"parameters\":[{\"name\":\"pod-spec-patch\",\"value\":\"{{tasks.hello-world-driver.outputs.parameters.pod-spec-patch}}\"},{\"name\":\"cached-decision\",\"default\":\"false\",\"value\":\"{{tasks.hello-world-driver.outputs.parameters.cached-decision}}\"}]},\"depends\":\"hello-world-driver.Succeeded\"}]}},{\"name\":\"system-dag-driver\",\"inputs\":{\"parameters\":[{\"name\":\"component\"},{\"name\":\"runtime-config\",\"default\":\"\"},{\"name\":\"task\",\"default\":\"\"},{\"name\":\"parent-dag-id\",\"default\":\"0\"},{\"name\":\"iteration-index\",\"default\":\"-1\"},{\"name\":\"driver-type\",\"default\":\"DAG\"}]},\"outputs\":{\"parameters\":[{\"name\":\"execution-id\",\"valueFrom\":{\"path\":\"/tmp/outputs/execution-id\"}},{\"name\":\"iteration-count\",\"valueFrom\":{\"path\":\"/tmp/outputs/iteration-count\",\"default\":\"0\"}},{\"name\":\"condition\",\"valueFrom\":{\"path\":\"/tmp/outputs/condition\",\"default\":\"true\"}}]},\"metadata\":{\"annotations\":{\"sidecar.istio.io/inject\":\"false\"}},\"container\":{\"name\":\"\",\"image\":\"gcr.io/ml-pipeline-test/dev/kfp-driver@sha256:a2efa29022573d9bcc92ee0a843ac37bef20333d3e6b8d9d7fbe97cbd346d84c\",\"command\":[\"driver\"],\"args\":[\"--type\",\"{{inputs.parameters.driver-type}}\",\"--pipeline_name\",\"namespace/n1/pipeline/hello-world\",\"--run_id\",\"{{workflow.uid}}\",\"--dag_execution_id\",\"{{inputs.parameters.parent-dag-id}}\",\"--component\",\"{{inputs.parameters.component}}\",\"--task\",\"{{inputs.parameters.task}}\",\"--runtime_config\",\"{{inputs.parameters.runtime-config}}\",\"--iteration_index\",\"{{inputs.parameters.iteration-index}}\",\"--execution_id_path\",\"{{outputs.parameters.execution-id.path}}\",\"--iteration_count_path\",\"{{outputs.parameters.iteration-count.path}}\",\"--condition_path\",\"{{outputs.parameters.condition.path}}\"],\"resources\":{\"limits\":{\"cpu\":\"500m\",\"memory\":\"512Mi\"},\"requests\":{\"cpu\":\"100m\",\"memory\":\"64Mi\"}}}},{\"name\":\"entrypoint\",\"inputs\":{},\"outputs\":{},\"metadata\":{\"annotations\":{\"sidecar.istio.io/inject\":\"false\"}},\"dag\":{\"tasks\":[{\"name\":\"root-driver\",\"template\":\"system-dag-driver\",\"arguments\":{\"parameters\":[{\"name\":\"component\",\"value\":\"{{workflow.annotations.pipelines.kubeflow.org/components-root}}\"},{\"name\":\"runtime-config\",\"value\":\"{}\"},{\"name\":\"driver-type\",\"value\":\"ROOT_DAG\"}]}},{\"name\":\"root\",\"template\":\"root\",\"arguments\":{\"parameters\":[{\"name\":\"parent-dag-id\",\"value\":\"{{tasks.root-driver.outputs.parameters.execution-id}}\"},{\"name\":\"condition\",\"value\":\"\"}]},\"depends\":\"root-driver.Succeeded\"}]}}],\"entrypoint\":\"entrypoint\",\"arguments\":{},\"serviceAccountName\":\"pipeline-runner\",\"podMetadata\":{\"annotations\":{\"pipelines.kubeflow.org/v2_component\":\"true\"},\"labels\":{\"pipelines.kubeflow.org/v2_component\":\"true\"}}},\"status\":{\"startedAt\":null,\"finishedAt\":null}}"
 var ExpectedWorkflowSpecV2 = "{\"kind\":\"Workflow\",\"apiVersion\":\"argoproj.io/v1alpha1\",\"metadata\":{\"generateName\":\"hello-world-\",\"creationTimestamp\":null,\"annotations\":{\"pipelines.kubeflow.org/components-comp-hello-world\":\"{\\\"executorLabel\\\":\\\"exec-hello-world\\\",\\\"inputDefinitions\\\":{\\\"parameters\\\":{\\\"text\\\":{\\\"type\\\":\\\"STRING\\\"}}}}\",\"pipelines.kubeflow.org/components-root\":\"{\\\"dag\\\":{\\\"tasks\\\":{\\\"hello-world\\\":{\\\"cachingOptions\\\":{\\\"enableCache\\\":true},\\\"componentRef\\\":{\\\"name\\\":\\\"comp-hello-world\\\"},\\\"inputs\\\":{\\\"parameters\\\":{\\\"text\\\":{\\\"componentInputParameter\\\":\\\"text\\\"}}},\\\"taskInfo\\\":{\\\"name\\\":\\\"hello-world\\\"}}}},\\\"inputDefinitions\\\":{\\\"parameters\\\":{\\\"text\\\":{\\\"type\\\":\\\"STRING\\\"}}}}\",\"pipelines.kubeflow.org/implementations-comp-hello-world\":\"{\\\"args\\\":[\\\"--text\\\",\\\"{{$.inputs.parameters['text']}}\\\"],\\\"command\\\":[\\\"sh\\\",\\\"-ec\\\",\\\"program_path=$(mktemp)\\\\nprintf \\\\\\\"%s\\\\\\\" \\\\\\\"$0\\\\\\\" \\\\u003e \\\\\\\"$program_path\\\\\\\"\\\\npython3 -u \\\\\\\"$program_path\\\\\\\" \\\\\\\"$@\\\\\\\"\\\\n\\\",\\\"def hello_world(text):\\\\n    print(text)\\\\n    return text\\\\n\\\\nimport argparse\\\\n_parser = argparse.ArgumentParser(prog='Hello world', description='')\\\\n_parser.add_argument(\\\\\\\"--text\\\\\\\", dest=\\\\\\\"text\\\\\\\", type=str, required=True, default=argparse.SUPPRESS)\\\\n_parsed_args = vars(_parser.parse_args())\\\\n\\\\n_outputs = hello_world(**_parsed_args)\\\\n\\\"],\\\"image\\\":\\\"python:3.7\\\"}\"}},\"spec\":{\"templates\":[{\"name\":\"system-container-driver\",\"inputs\":{\"parameters\":[{\"name\":\"component\"},{\"name\":\"task\"},{\"name\":\"container\"},{\"name\":\"parent-dag-id\"},{\"name\":\"iteration-index\",\"default\":\"-1\"}]},\"outputs\":{\"parameters\":[{\"name\":\"pod-spec-patch\",\"valueFrom\":{\"path\":\"/tmp/outputs/pod-spec-patch\",\"default\":\"\"}},{\"name\":\"cached-decision\",\"default\":\"false\",\"valueFrom\":{\"path\":\"/tmp/outputs/cached-decision\",\"default\":\"false\"}},{\"name\":\"condition\",\"valueFrom\":{\"path\":\"/tmp/outputs/condition\",\"default\":\"true\"}}]},\"metadata\":{\"annotations\":{\"sidecar.istio.io/inject\":\"false\"}},\"container\":{\"name\":\"\",\"image\":\"gcr.io/ml-pipeline-test/dev/kfp-driver@sha256:a2efa29022573d9bcc92ee0a843ac37bef20333d3e6b8d9d7fbe97cbd346d84c\",\"command\":[\"driver\"],\"args\":[\"--type\",\"CONTAINER\",\"--pipeline_name\",\"namespace/n1/pipeline/hello-world\",\"--run_id\",\"{{workflow.uid}}\",\"--dag_execution_id\",\"{{inputs.parameters.parent-dag-id}}\",\"--component\",\"{{inputs.parameters.component}}\",\"--task\",\"{{inputs.parameters.task}}\",\"--container\",\"{{inputs.parameters.container}}\",\"--iteration_index\",\"{{inputs.parameters.iteration-index}}\",\"--cached_decision_path\",\"{{outputs.parameters.cached-decision.path}}\",\"--pod_spec_patch_path\",\"{{outputs.parameters.pod-spec-patch.path}}\",\"--condition_path\",\"{{outputs.parameters.condition.path}}\"],\"resources\":{\"limits\":{\"cpu\":\"500m\",\"memory\":\"512Mi\"},\"requests\":{\"cpu\":\"100m\",\"memory\":\"64Mi\"}}}},{\"name\":\"system-container-executor\",\"inputs\":{\"parameters\":[{\"name\":\"pod-spec-patch\"},{\"name\":\"cached-decision\",\"default\":\"false\"}]},\"outputs\":{},\"metadata\":{\"annotations\":{\"sidecar.istio.io/inject\":\"false\"}},\"dag\":{\"tasks\":[{\"name\":\"executor\",\"template\":\"system-container-impl\",\"arguments\":{\"parameters\":[{\"name\":\"pod-spec-patch\",\"value\":\"{{inputs.parameters.pod-spec-patch}}\"}]},\"when\":\"{{inputs.parameters.cached-decision}} != true\"}]}},{\"name\":\"system-container-impl\",\"inputs\":{\"parameters\":[{\"name\":\"pod-spec-patch\"}]},\"outputs\":{},\"metadata\":{\"annotations\":{\"sidecar.istio.io/inject\":\"false\"}},\"container\":{\"name\":\"\",\"image\":\"gcr.io/ml-pipeline/should-be-overridden-during-runtime\",\"command\":[\"should-be-overridden-during-runtime\"],\"envFrom\":[{\"configMapRef\":{\"name\":\"metadata-grpc-configmap\",\"optional\":true}}],\"env\":[{\"name\":\"KFP_POD_NAME\",\"valueFrom\":{\"fieldRef\":{\"fieldPath\":\"metadata.name\"}}},{\"name\":\"KFP_POD_UID\",\"valueFrom\":{\"fieldRef\":{\"fieldPath\":\"metadata.uid\"}}}],\"resources\":{},\"volumeMounts\":[{\"name\":\"kfp-launcher\",\"mountPath\":\"/kfp-launcher\"}]},\"volumes\":[{\"name\":\"kfp-launcher\",\"emptyDir\":{}}],\"initContainers\":[{\"name\":\"kfp-launcher\",\"image\":\"gcr.io/ml-pipeline-test/dev/kfp-launcher-v2@sha256:4513cf5c10c252d94f383ce51a890514799c200795e3de5e90f91b98b2e2f959\",\"command\":[\"launcher-v2\",\"--copy\",\"/kfp-launcher/launch\"],\"resources\":{\"limits\":{\"cpu\":\"500m\",\"memory\":\"128Mi\"},\"requests\":{\"cpu\":\"100m\"}},\"volumeMounts\":[{\"name\":\"kfp-launcher\",\"mountPath\":\"/kfp-launcher\"}]}],\"podSpecPatch\":\"{{inputs.parameters.pod-spec-patch}}\"},{\"name\":\"root\",\"inputs\":{\"parameters\":[{\"name\":\"parent-dag-id\"}]},\"outputs\":{},\"metadata\":{\"annotations\":{\"sidecar.istio.io/inject\":\"false\"}},\"dag\":{\"tasks\":[{\"name\":\"hello-world-driver\",\"template\":\"system-container-driver\",\"arguments\":{\"parameters\":[{\"name\":\"component\",\"value\":\"{{workflow.annotations.pipelines.kubeflow.org/components-comp-hello-world}}\"},{\"name\":\"task\",\"value\":\"{\\\"cachingOptions\\\":{\\\"enableCache\\\":true},\\\"componentRef\\\":{\\\"name\\\":\\\"comp-hello-world\\\"},\\\"inputs\\\":{\\\"parameters\\\":{\\\"text\\\":{\\\"componentInputParameter\\\":\\\"text\\\"}}},\\\"taskInfo\\\":{\\\"name\\\":\\\"hello-world\\\"}}\"},{\"name\":\"container\",\"value\":\"{{workflow.annotations.pipelines.kubeflow.org/implementations-comp-hello-world}}\"},{\"name\":\"parent-dag-id\",\"value\":\"{{inputs.parameters.parent-dag-id}}\"}]}},{\"name\":\"hello-world\",\"template\":\"system-container-executor\",\"arguments\":{\"parameters\":[{\"name\":\"pod-spec-patch\",\"value\":\"{{tasks.hello-world-driver.outputs.parameters.pod-spec-patch}}\"},{\"name\":\"cached-decision\",\"default\":\"false\",\"value\":\"{{tasks.hello-world-driver.outputs.parameters.cached-decision}}\"}]},\"depends\":\"hello-world-driver.Succeeded\"}]}},{\"name\":\"system-dag-driver\",\"inputs\":{\"parameters\":[{\"name\":\"component\"},{\"name\":\"runtime-config\",\"default\":\"\"},{\"name\":\"task\",\"default\":\"\"},{\"name\":\"parent-dag-id\",\"default\":\"0\"},{\"name\":\"iteration-index\",\"default\":\"-1\"},{\"name\":\"driver-type\",\"default\":\"DAG\"}]},\"outputs\":{\"parameters\":[{\"name\":\"execution-id\",\"valueFrom\":{\"path\":\"/tmp/outputs/execution-id\"}},{\"name\":\"iteration-count\",\"valueFrom\":{\"path\":\"/tmp/outputs/iteration-count\",\"default\":\"0\"}},{\"name\":\"condition\",\"valueFrom\":{\"path\":\"/tmp/outputs/condition\",\"default\":\"true\"}}]},\"metadata\":{\"annotations\":{\"sidecar.istio.io/inject\":\"false\"}},\"container\":{\"name\":\"\",\"image\":\"gcr.io/ml-pipeline-test/dev/kfp-driver@sha256:a2efa29022573d9bcc92ee0a843ac37bef20333d3e6b8d9d7fbe97cbd346d84c\",\"command\":[\"driver\"],\"args\":[\"--type\",\"{{inputs.parameters.driver-type}}\",\"--pipeline_name\",\"namespace/n1/pipeline/hello-world\",\"--run_id\",\"{{workflow.uid}}\",\"--dag_execution_id\",\"{{inputs.parameters.parent-dag-id}}\",\"--component\",\"{{inputs.parameters.component}}\",\"--task\",\"{{inputs.parameters.task}}\",\"--runtime_config\",\"{{inputs.parameters.runtime-config}}\",\"--iteration_index\",\"{{inputs.parameters.iteration-index}}\",\"--execution_id_path\",\"{{outputs.parameters.execution-id.path}}\",\"--iteration_count_path\",\"{{outputs.parameters.iteration-count.path}}\",\"--condition_path\",\"{{outputs.parameters.condition.path}}\"],\"resources\":{\"limits\":{\"cpu\":\"500m\",\"memory\":\"512Mi\"},\"requests\":{\"cpu\":\"100m\",\"memory\":\"64Mi\"}}}},{\"name\":\"entrypoint\",\"inputs\":{},\"outputs\":{},\"metadata\":{\"annotations\":{\"sidecar.istio.io/inject\":\"false\"}},\"dag\":{\"tasks\":[{\"name\":\"root-driver\",\"template\":\"system-dag-driver\",\"arguments\":{\"parameters\":[{\"name\":\"component\",\"value\":\"{{workflow.annotations.pipelines.kubeflow.org/components-root}}\"},{\"name\":\"runtime-config\",\"value\":\"{\\\"parameterValues\\\":{\\\"param2\\\":\\\"world\\\"}}\"},{\"name\":\"driver-type\",\"value\":\"ROOT_DAG\"}]}},{\"name\":\"root\",\"template\":\"root\",\"arguments\":{\"parameters\":[{\"name\":\"parent-dag-id\",\"value\":\"{{tasks.root-driver.outputs.parameters.execution-id}}\"},{\"name\":\"condition\",\"value\":\"\"}]},\"depends\":\"root-driver.Succeeded\"}]}}],\"entrypoint\":\"entrypoint\",\"arguments\":{},\"serviceAccountName\":\"pipeline-runner\",\"podMetadata\":{\"annotations\":{\"pipelines.kubeflow.org/v2_component\":\"true\"},\"labels\":{\"pipelines.kubeflow.org/v2_component\":\"true\"}}},\"status\":{\"startedAt\":null,\"finishedAt\":null}}"
 
+func TestConvertTemplate(t *testing.T) {
+	argoJson, err := yaml.YAMLToJSON([]byte(template))
+	assert.Nil(t, err)
+	argoYaml, err := yaml.JSONToYAML(argoJson)
+	assert.Nil(t, err)
+	v2Json, err := yaml.YAMLToJSON([]byte(v2SpecHelloWorldYAML))
+	assert.Nil(t, err)
+
+	tt := []struct {
+		msg       string
+		template  string
+		format    TemplateFormat
+		expected  string
+		errorCode codes.Code
+	}{
+		{msg: "DefaultIsRaw", template: template, format: "", expected: template},
+		{msg: "Raw", template: string(argoJson), format: TemplateFormatRaw, expected: string(argoJson)},
+		{msg: "ArgoYamlToJson", template: template, format: TemplateFormatJSON, expected: string(argoJson)},
+		{msg: "ArgoJsonToYaml", template: string(argoJson), format: TemplateFormatArgoYAML, expected: string(argoYaml)},
+		{msg: "V2ToJson", template: v2SpecHelloWorldYAML, format: TemplateFormatJSON, expected: string(v2Json)},
+		{msg: "V2ToArgoYaml", template: v2SpecHelloWorldYAML, format: TemplateFormatArgoYAML, errorCode: codes.Unimplemented},
+		{msg: "UnknownStoredFormat", template: "foo: bar", format: TemplateFormatJSON, errorCode: codes.Unimplemented},
+		{msg: "UnknownRequestedFormat", template: template, format: "XML", errorCode: codes.InvalidArgument},
+	}
+	for _, test := range tt {
+		t.Run(test.msg, func(t *testing.T) {
+			converted, err := ConvertTemplate([]byte(test.template), test.format)
+			if test.errorCode != codes.OK {
+				assert.NotNil(t, err)
+				assert.Equal(t, test.errorCode, err.(*util.UserError).ExternalStatusCode())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, string(converted))
+		})
+	}
+}
+
+func TestParameterSchema(t *testing.T) {
+	tmpl, err := New([]byte(v2SpecHelloWorldYAML))
+	assert.Nil(t, err)
+	assert.Equal(t, ParameterSchema{"text": ParameterTypeString}, tmpl.ParameterSchema())
+	schemaJSON, err := tmpl.ParameterSchema().JSON()
+	assert.Nil(t, err)
+	assert.Equal(t, `{"text":"string"}`, schemaJSON)
+
+	tmpl, err = New([]byte(template))
+	assert.Nil(t, err)
+	assert.Nil(t, tmpl.ParameterSchema())
+	schemaJSON, err = tmpl.ParameterSchema().JSON()
+	assert.Nil(t, err)
+	assert.Equal(t, "", schemaJSON)
+}
+
+func TestParameterSchema_ValidateRunParameters(t *testing.T) {
+	schema := ParameterSchema{
+		"count":  ParameterTypeInt,
+		"rate":   ParameterTypeFloat,
+		"flag":   ParameterTypeBool,
+		"config": ParameterTypeJSON,
+		"name":   ParameterTypeString,
+	}
+	valid := &model.Run{
+		PipelineSpec: model.PipelineSpec{
+			Parameters: `[{"name":"count","value":"3"},{"name":"flag","value":"true"},{"name":"other","value":"x"}]`,
+			RuntimeConfig: model.RuntimeConfig{
+				Parameters: `{"rate":0.5,"config":{"a":[1,2]},"name":"n","count":2}`,
+			},
+		},
+	}
+	assert.Nil(t, schema.ValidateRunParameters(valid))
+
+	invalid := &model.Run{
+		PipelineSpec: model.PipelineSpec{
+			Parameters: `[{"name":"flag","value":"tru"},{"name":"config","value":"{\"a\":"}]`,
+			RuntimeConfig: model.RuntimeConfig{
+				Parameters: `{"count":1.5,"name":true}`,
+			},
+		},
+	}
+	err := schema.ValidateRunParameters(invalid)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+		assert.Equal(t, map[string]string{
+			"parameters.flag":                 `"tru" is not a valid bool`,
+			"parameters.config":               `"{\"a\":" is not a valid json`,
+			"runtime_config.parameters.count": "value 1.5 is not a valid int",
+			"runtime_config.parameters.name":  "value true is not a valid string",
+		}, err.(*util.UserError).FieldViolations())
+	}
+}
+
+func TestParameterSchema_RuntimeValue(t *testing.T) {
+	schema := ParameterSchema{
+		"count":  ParameterTypeInt,
+		"flag":   ParameterTypeBool,
+		"config": ParameterTypeJSON,
+	}
+	value, err := schema.RuntimeValue("count", "3")
+	assert.Nil(t, err)
+	assert.Equal(t, float64(3), value.GetNumberValue())
+	value, err = schema.RuntimeValue("flag", "true")
+	assert.Nil(t, err)
+	assert.True(t, value.GetBoolValue())
+	value, err = schema.RuntimeValue("config", `{"a":[1,2]}`)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"a": []interface{}{float64(1), float64(2)}}, value.AsInterface())
+	// Parameters the schema doesn't declare are strings.
+	value, err = schema.RuntimeValue("other", "3")
+	assert.Nil(t, err)
+	assert.Equal(t, "3", value.GetStringValue())
+
+	_, err = schema.RuntimeValue("count", "three")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+	}
+}
+
 func TestToSwfCRDResourceGeneratedName_SpecialCharsAndSpace(t *testing.T) {
 	name, err := toSWFCRDResourceGeneratedName("! HaVe ä £unky name")
 	assert.Nil(t, err)
@@ -304,3 +564,192 @@ func TestModelToCRDTrigger_Cron(t *testing.T) {
 	assert.Equal(t, expectedCRDTrigger, actualCRDTrigger)
 
 }
+
+func TestCompareManifests_Argo(t *testing.T) {
+	a := `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: hello-world-
+spec:
+  entrypoint: whalesay
+  arguments:
+    parameters:
+    - name: message
+      value: hello
+    - name: removed
+      value: "1"
+  templates:
+  - name: whalesay
+    container:
+      image: docker/whalesay:latest
+  - name: goodbye
+    container:
+      image: alpine:3.17`
+	b := `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: hello-world-
+spec:
+  entrypoint: whalesay
+  arguments:
+    parameters:
+    - name: message
+      value: bonjour
+    - name: added
+      value: "2"
+  templates:
+  - name: whalesay
+    container:
+      image: docker/whalesay:v2
+  - name: goodbye
+    container:
+      image: alpine:3.17
+  - name: welcome
+    script:
+      image: python:3.9
+      source: print("welcome")`
+
+	diff := CompareManifests([]byte(a), []byte(b))
+	assert.False(t, diff.Degraded)
+	assert.Equal(t, []string{"welcome"}, diff.AddedTemplates)
+	assert.Empty(t, diff.RemovedTemplates)
+	assert.Equal(t, []string{"whalesay"}, diff.ChangedTemplates)
+	assert.Equal(t, []ParameterChange{
+		{Name: "added", NewValue: util.StringPointer("2")},
+		{Name: "message", OldValue: util.StringPointer("hello"), NewValue: util.StringPointer("bonjour")},
+		{Name: "removed", OldValue: util.StringPointer("1")},
+	}, diff.ParameterChanges)
+	assert.Equal(t, []ImageChange{{Template: "whalesay", OldImage: "docker/whalesay:latest", NewImage: "docker/whalesay:v2"}}, diff.ImageChanges)
+	assert.Empty(t, diff.LineDiff)
+}
+
+func TestCompareManifests_V2(t *testing.T) {
+	b := strings.Replace(v2SpecHelloWorldYAML, "image: python:3.7", "image: python:3.9", 1)
+	b = strings.Replace(b, "      text:\n        type: STRING\nschemaVersion", "      text:\n        type: STRING\n        defaultValue: hi\nschemaVersion", 1)
+
+	diff := CompareManifests([]byte(v2SpecHelloWorldYAML), []byte(b))
+	assert.False(t, diff.Degraded)
+	assert.Empty(t, diff.AddedTemplates)
+	assert.Empty(t, diff.RemovedTemplates)
+	assert.Equal(t, []string{"root"}, diff.ChangedTemplates)
+	assert.Equal(t, []ParameterChange{{Name: "text", OldValue: util.StringPointer(""), NewValue: util.StringPointer(`"hi"`)}}, diff.ParameterChanges)
+	assert.Equal(t, []ImageChange{{Template: "exec-hello-world", OldImage: "python:3.7", NewImage: "python:3.9"}}, diff.ImageChanges)
+
+	diff = CompareManifests([]byte(v2SpecHelloWorldYAML), []byte(v2SpecHelloWorldYAML))
+	assert.Equal(t, &ManifestDiff{}, diff)
+}
+
+func TestCompareManifests_Degraded(t *testing.T) {
+	diff := CompareManifests([]byte(template), []byte(v2SpecHelloWorldYAML))
+	assert.True(t, diff.Degraded)
+	assert.Contains(t, diff.LineDiff, "- kind: Workflow")
+	assert.Contains(t, diff.LineDiff, "+ schemaVersion: 2.0.0")
+	assert.Empty(t, diff.ChangedTemplates)
+
+	diff = CompareManifests([]byte("a\nb\nc\nd"), []byte("a\nx\nc\nd\ne"))
+	assert.True(t, diff.Degraded)
+	assert.Equal(t, []string{"- b", "+ x", "+ e"}, diff.LineDiff)
+}
+
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, name := range sortedFileNames(files) {
+		f, err := writer.Create(name)
+		assert.Nil(t, err)
+		_, err = f.Write([]byte(files[name]))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, writer.Close())
+	return buf.Bytes()
+}
+
+func tarballArchive(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	writer := tar.NewWriter(gzipWriter)
+	for _, name := range sortedFileNames(files) {
+		assert.Nil(t, writer.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(files[name])), Typeflag: tar.TypeReg}))
+		_, err := writer.Write([]byte(files[name]))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, writer.Close())
+	assert.Nil(t, gzipWriter.Close())
+	return buf.Bytes()
+}
+
+func sortedFileNames(files map[string]string) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestAssembleArchive(t *testing.T) {
+	i := strings.Index(v2SpecHelloWorldYAML, "pipelineInfo:")
+	component, rootSpec := v2SpecHelloWorldYAML[:i], v2SpecHelloWorldYAML[i:]
+	files := map[string]string{
+		"hello-world/pipeline.yaml":              rootSpec,
+		"hello-world/components/hello-world.yml": component,
+		"hello-world/README.md":                  "Says hello",
+	}
+	for _, archive := range [][]byte{zipArchive(t, files), tarballArchive(t, files)} {
+		assert.True(t, IsArchive(archive))
+		manifest, err := AssembleArchive(archive, 0)
+		assert.Nil(t, err)
+		tmpl, err := New(manifest)
+		assert.Nil(t, err)
+		assert.True(t, tmpl.IsV2())
+		dependencies, err := ExtractDependencies(manifest)
+		assert.Nil(t, err)
+		assert.Equal(t, "python:3.7", dependencies.Images[0].Image)
+		assert.Equal(t, []string{"exec-hello-world"}, dependencies.Images[0].Templates)
+	}
+	assert.False(t, IsArchive([]byte(v2SpecHelloWorldYAML)))
+
+	// Without components the root spec is kept as it is, and a lone YAML file is the root spec.
+	manifest, err := AssembleArchive(zipArchive(t, map[string]string{"pipeline.yaml": template, "component.yaml": component}), 0)
+	assert.Nil(t, err)
+	assert.Equal(t, template, string(manifest))
+	manifest, err = AssembleArchive(tarballArchive(t, map[string]string{"my-pipeline.yaml": template}), 0)
+	assert.Nil(t, err)
+	assert.Equal(t, template, string(manifest))
+
+	// The same component can be in more than one file.
+	files["hello-world/components/copy.yaml"] = component
+	_, err = AssembleArchive(zipArchive(t, files), 0)
+	assert.Nil(t, err)
+}
+
+func TestAssembleArchive_Invalid(t *testing.T) {
+	i := strings.Index(v2SpecHelloWorldYAML, "pipelineInfo:")
+	component, rootSpec := v2SpecHelloWorldYAML[:i], v2SpecHelloWorldYAML[i:]
+	tests := []struct {
+		msg      string
+		files    map[string]string
+		maxBytes int
+		errMsg   string
+	}{
+		{msg: "NoRootSpec", files: map[string]string{"a.yaml": rootSpec, "components/b.yaml": component}, errMsg: "missing the root pipeline spec"},
+		{msg: "PathTraversal", files: map[string]string{"pipeline.yaml": rootSpec, "../components/b.yaml": component}, errMsg: "outside of its directory"},
+		{msg: "AbsolutePath", files: map[string]string{"/pipeline.yaml": rootSpec}, errMsg: "outside of its directory"},
+		{msg: "ArgoRoot", files: map[string]string{"pipeline.yaml": template, "components/b.yaml": component}, errMsg: "only be added to a v2 pipeline spec"},
+		{msg: "NotComponent", files: map[string]string{"pipeline.yaml": rootSpec, "components/b.yaml": "a: b"}, errMsg: "has no components or executors"},
+		{msg: "Redefined", files: map[string]string{"pipeline.yaml": rootSpec, "components/a.yaml": component,
+			"components/b.yaml": strings.Replace(component, "python:3.7", "python:3.9", 1)}, errMsg: "redefines"},
+		{msg: "TooLarge", files: map[string]string{"pipeline.yaml": rootSpec, "components/b.yaml": component}, maxBytes: len(rootSpec), errMsg: "exceeds the maximum allowed size"},
+	}
+	for _, test := range tests {
+		for _, archive := range [][]byte{zipArchive(t, test.files), tarballArchive(t, test.files)} {
+			_, err := AssembleArchive(archive, test.maxBytes)
+			if assert.NotNil(t, err, test.msg) {
+				assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument), test.msg)
+				assert.Contains(t, err.Error(), test.errMsg, test.msg)
+			}
+		}
+	}
+}
@@ -112,18 +112,27 @@ func NewV2SpecTemplate(template []byte) (*V2Spec, error) {
 	if err != nil {
 		return nil, util.NewInvalidInputErrorWithDetails(ErrorInvalidPipelineSpec, fmt.Sprintf("invalid v2 pipeline spec: %s", err.Error()))
 	}
+	if _, message := v2SpecViolation(&spec); message != "" {
+		return nil, util.NewInvalidInputErrorWithDetails(ErrorInvalidPipelineSpec, "invalid v2 pipeline spec: "+message)
+	}
+
+	return &V2Spec{spec: &spec}, nil
+}
+
+// v2SpecViolation returns the field of a parsed v2 pipeline spec that makes it invalid and why, or empty
+// strings if the spec is valid.
+func v2SpecViolation(spec *pipelinespec.PipelineSpec) (string, string) {
 	if spec.GetPipelineInfo().GetName() == "" {
-		return nil, util.NewInvalidInputErrorWithDetails(ErrorInvalidPipelineSpec, "invalid v2 pipeline spec: name is empty")
+		return "pipelineInfo.name", "name is empty"
 	}
 	match, _ := regexp.MatchString("[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*", spec.GetPipelineInfo().GetName())
 	if !match {
-		return nil, util.NewInvalidInputErrorWithDetails(ErrorInvalidPipelineSpec, "invalid v2 pipeline spec: name should consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character")
+		return "pipelineInfo.name", "name should consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character"
 	}
 	if spec.GetRoot() == nil {
-		return nil, util.NewInvalidInputErrorWithDetails(ErrorInvalidPipelineSpec, "invalid v2 pipeline spec: root component is empty")
+		return "root", "root component is empty"
 	}
-
-	return &V2Spec{spec: &spec}, nil
+	return "", ""
 }
 
 func (t *V2Spec) Bytes() []byte {
@@ -172,6 +181,22 @@ func (t *V2Spec) ParametersJSON() (string, error) {
 	return "[]", nil
 }
 
+// StringParameterDefaults returns the default values of the input parameters of the root component that
+// default to a string.
+func (t *V2Spec) StringParameterDefaults() map[string]string {
+	defaults := map[string]string{}
+	for name, param := range t.spec.GetRoot().GetInputDefinitions().GetParameters() {
+		if value, ok := param.GetDefaultValue().GetKind().(*structpb.Value_StringValue); ok {
+			defaults[name] = value.StringValue
+		}
+	}
+	return defaults
+}
+
+func (t *V2Spec) ParameterSchema() ParameterSchema {
+	return parameterSchemaOf(t.spec)
+}
+
 func (t *V2Spec) RunWorkflow(modelRun *model.Run, options RunWorkflowOptions) (util.ExecutionSpec, error) {
 	bytes, err := protojson.Marshal(t.spec)
 	if err != nil {
@@ -0,0 +1,87 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Schemes of the object stores artifacts can be stored in.
+const (
+	ArtifactRootSchemeGCS   = "gs"
+	ArtifactRootSchemeS3    = "s3"
+	ArtifactRootSchemeMinio = "minio"
+)
+
+// bucketNamePattern matches the bucket names GCS and S3 both accept.
+var bucketNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{1,61}[a-z0-9]$`)
+
+// ArtifactRoot is an object store location under which the output artifacts of a workflow are stored.
+type ArtifactRoot struct {
+	Scheme string
+	Bucket string
+	// Path of the root in the bucket, without leading or trailing slashes. Empty for the whole bucket.
+	Prefix string
+
+	// Endpoint, Insecure and CredentialsSecret tell how to reach s3 and minio buckets. The secret holds the
+	// credentials in its accesskey and secretkey keys.
+	Endpoint          string
+	Insecure          bool
+	CredentialsSecret string
+}
+
+// ParseArtifactRoot parses an object store URI of the form <scheme>://<bucket>/<path>, where the scheme is gs,
+// s3 or minio and the path is optional.
+func ParseArtifactRoot(uri string) (*ArtifactRoot, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, NewInvalidInputErrorWithDetails(err, fmt.Sprintf("Invalid pipeline root %q", uri))
+	}
+	switch u.Scheme {
+	case ArtifactRootSchemeGCS, ArtifactRootSchemeS3, ArtifactRootSchemeMinio:
+	default:
+		return nil, NewInvalidInputError("Invalid pipeline root %q: the scheme must be gs, s3 or minio", uri)
+	}
+	if u.User != nil || u.Port() != "" || u.RawQuery != "" || u.Fragment != "" || u.Opaque != "" {
+		return nil, NewInvalidInputError("Invalid pipeline root %q: must be of the form %s://<bucket>/<path>", uri, u.Scheme)
+	}
+	if !bucketNamePattern.MatchString(u.Host) {
+		return nil, NewInvalidInputError("Invalid pipeline root %q: %q is not a valid bucket name", uri, u.Host)
+	}
+	prefix := strings.Trim(u.Path, "/")
+	if prefix != "" {
+		for _, segment := range strings.Split(prefix, "/") {
+			if segment == "" || segment == "." || segment == ".." {
+				return nil, NewInvalidInputError("Invalid pipeline root %q: the path can't have empty, . or .. segments", uri)
+			}
+		}
+		// Argo would substitute the variables of the path.
+		if strings.Contains(prefix, "{{") {
+			return nil, NewInvalidInputError("Invalid pipeline root %q: the path can't have {{", uri)
+		}
+	}
+	return &ArtifactRoot{Scheme: u.Scheme, Bucket: u.Host, Prefix: prefix}, nil
+}
+
+// URI returns the normalized URI of the root.
+func (r *ArtifactRoot) URI() string {
+	if r.Prefix == "" {
+		return fmt.Sprintf("%s://%s", r.Scheme, r.Bucket)
+	}
+	return fmt.Sprintf("%s://%s/%s", r.Scheme, r.Bucket, r.Prefix)
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestParseArtifactRoot(t *testing.T) {
+	root, err := ParseArtifactRoot("gs://team-a/outputs/runs/")
+	assert.Nil(t, err)
+	assert.Equal(t, &ArtifactRoot{Scheme: "gs", Bucket: "team-a", Prefix: "outputs/runs"}, root)
+	assert.Equal(t, "gs://team-a/outputs/runs", root.URI())
+
+	root, err = ParseArtifactRoot("minio://mlpipeline")
+	assert.Nil(t, err)
+	assert.Equal(t, "minio://mlpipeline", root.URI())
+
+	for _, uri := range []string{
+		"",
+		"team-a/outputs",
+		"http://team-a/outputs",
+		"s3://Team_A/outputs",
+		"s3://ab/outputs",
+		"s3://user@team-a/outputs",
+		"s3://team-a:9000/outputs",
+		"s3://team-a/outputs?versioned=true",
+		"s3://team-a/outputs//runs",
+		"s3://team-a/../team-b",
+		"s3://team-a/{{workflow.namespace}}",
+	} {
+		_, err := ParseArtifactRoot(uri)
+		assert.True(t, IsUserErrorCodeMatch(err, codes.InvalidArgument), uri)
+	}
+}
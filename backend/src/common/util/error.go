@@ -16,11 +16,14 @@ package util
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/go-openapi/runtime"
 	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
 	api "github.com/kubeflow/pipelines/backend/api/v1beta1/go_client"
 	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	k8errors "k8s.io/apimachinery/pkg/api/errors"
@@ -86,6 +89,8 @@ type UserError struct {
 	externalMessage string
 	// Status code for the external client.
 	externalStatusCode codes.Code
+	// Per-field messages for the external client, keyed by field name.
+	fieldViolations map[string]string
 }
 
 func newUserError(internalError error, externalMessage string,
@@ -181,11 +186,25 @@ func NewInvalidInputErrorWithDetails(err error, externalMessage string) *UserErr
 		codes.InvalidArgument)
 }
 
+// NewInvalidInputErrorWithFieldViolations creates an invalid input error that also carries a message
+// per offending field. The violations are returned to the client as a BadRequest error detail.
+func NewInvalidInputErrorWithFieldViolations(violations map[string]string, messageFormat string, a ...interface{}) *UserError {
+	userError := NewInvalidInputError(messageFormat, a...)
+	userError.fieldViolations = violations
+	return userError
+}
+
 func NewAlreadyExistError(messageFormat string, a ...interface{}) *UserError {
 	message := fmt.Sprintf(messageFormat, a...)
 	return newUserError(errors.Errorf("Already exist error: %v", message), message, codes.AlreadyExists)
 }
 
+// NewConflictError is returned when a resource was modified since the caller read it.
+func NewConflictError(messageFormat string, a ...interface{}) *UserError {
+	message := fmt.Sprintf(messageFormat, a...)
+	return newUserError(errors.Errorf("Conflict error: %v", message), message, codes.Aborted)
+}
+
 func NewBadRequestError(err error, externalFormat string, a ...interface{}) *UserError {
 	externalMessage := fmt.Sprintf(externalFormat, a...)
 	return newUserError(
@@ -202,6 +221,22 @@ func NewFailedPreconditionError(err error, externalFormat string, a ...interface
 		codes.FailedPrecondition)
 }
 
+func NewResourceExhaustedError(err error, externalFormat string, a ...interface{}) *UserError {
+	externalMessage := fmt.Sprintf(externalFormat, a...)
+	return newUserError(
+		errors.Wrapf(err, fmt.Sprintf("ResourceExhaustedError: %v", externalMessage)),
+		externalMessage,
+		codes.ResourceExhausted)
+}
+
+func NewUnimplementedError(err error, externalFormat string, a ...interface{}) *UserError {
+	externalMessage := fmt.Sprintf(externalFormat, a...)
+	return newUserError(
+		errors.Wrapf(err, fmt.Sprintf("Unimplemented: %v", externalMessage)),
+		externalMessage,
+		codes.Unimplemented)
+}
+
 func NewUnauthenticatedError(err error, externalFormat string, a ...interface{}) *UserError {
 	externalMessage := fmt.Sprintf(externalFormat, a...)
 	return newUserError(
@@ -218,6 +253,14 @@ func NewPermissionDeniedError(err error, externalFormat string, a ...interface{}
 		codes.PermissionDenied)
 }
 
+func NewUnavailableError(err error, externalFormat string, a ...interface{}) *UserError {
+	externalMessage := fmt.Sprintf(externalFormat, a...)
+	return newUserError(
+		errors.Wrapf(err, fmt.Sprintf("Unavailable: %v", externalMessage)),
+		externalMessage,
+		codes.Unavailable)
+}
+
 func (e *UserError) ExternalMessage() string {
 	return e.externalMessage
 }
@@ -226,6 +269,11 @@ func (e *UserError) ExternalStatusCode() codes.Code {
 	return e.externalStatusCode
 }
 
+// FieldViolations returns the per-field messages of the error, if any.
+func (e *UserError) FieldViolations() map[string]string {
+	return e.fieldViolations
+}
+
 func (e *UserError) Error() string {
 	return e.internalError.Error()
 }
@@ -244,13 +292,17 @@ func (e *UserError) Unwrap() error {
 }
 
 func (e *UserError) wrapf(format string, args ...interface{}) *UserError {
-	return newUserError(errors.Wrapf(e.internalError, format, args...),
+	wrapped := newUserError(errors.Wrapf(e.internalError, format, args...),
 		e.externalMessage, e.externalStatusCode)
+	wrapped.fieldViolations = e.fieldViolations
+	return wrapped
 }
 
 func (e *UserError) wrap(message string) *UserError {
-	return newUserError(errors.Wrap(e.internalError, message),
+	wrapped := newUserError(errors.Wrap(e.internalError, message),
 		e.externalMessage, e.externalStatusCode)
+	wrapped.fieldViolations = e.fieldViolations
+	return wrapped
 }
 
 func (e *UserError) Log() {
@@ -303,11 +355,14 @@ func ToGRPCError(err error) error {
 	case *UserError:
 		userError := err.(*UserError)
 		stat := status.New(userError.externalStatusCode, userError.internalError.Error())
-		statWithDetail, statErr := stat.
-			WithDetails(&api.Error{
-				ErrorMessage: userError.externalMessage,
-				ErrorDetails: userError.internalError.Error(),
-			})
+		details := []proto.Message{&api.Error{
+			ErrorMessage: userError.externalMessage,
+			ErrorDetails: userError.internalError.Error(),
+		}}
+		if len(userError.fieldViolations) > 0 {
+			details = append(details, toBadRequestDetail(userError.fieldViolations))
+		}
+		statWithDetail, statErr := stat.WithDetails(details...)
 
 		if statErr != nil {
 			// Failed to stream error message as proto.
@@ -331,6 +386,23 @@ func ToGRPCError(err error) error {
 	}
 }
 
+// toBadRequestDetail converts field violations into a BadRequest detail, ordered by field name.
+func toBadRequestDetail(violations map[string]string) *errdetails.BadRequest {
+	fields := make([]string, 0, len(violations))
+	for field := range violations {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	badRequest := &errdetails.BadRequest{}
+	for _, field := range fields {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: violations[field],
+		})
+	}
+	return badRequest
+}
+
 // TerminateIfError Check if error is nil. Terminate if not.
 func TerminateIfError(err error) {
 	if err != nil {
@@ -18,6 +18,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -26,3 +29,23 @@ func TestIsNotFound(t *testing.T) {
 	assert.Equal(t, true, IsNotFound(errors.NewNotFound(schema.GroupResource{}, "NAME")))
 	assert.Equal(t, false, IsNotFound(errors.NewAlreadyExists(schema.GroupResource{}, "NAME")))
 }
+
+func TestToGRPCError_FieldViolations(t *testing.T) {
+	err := Wrap(NewInvalidInputErrorWithFieldViolations(
+		map[string]string{"b": "Missing", "a": "Unrecognized"}, "Invalid input"), "Failed to create run")
+
+	stat, ok := status.FromError(ToGRPCError(err))
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, stat.Code())
+	var badRequest *errdetails.BadRequest
+	for _, detail := range stat.Details() {
+		if d, ok := detail.(*errdetails.BadRequest); ok {
+			badRequest = d
+		}
+	}
+	assert.NotNil(t, badRequest)
+	assert.Equal(t, 2, len(badRequest.FieldViolations))
+	assert.Equal(t, "a", badRequest.FieldViolations[0].Field)
+	assert.Equal(t, "Unrecognized", badRequest.FieldViolations[0].Description)
+	assert.Equal(t, "b", badRequest.FieldViolations[1].Field)
+}
@@ -21,6 +21,7 @@ import (
 	workflowapi "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/ghodss/yaml"
 	swfapi "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -71,6 +72,42 @@ type ExecutionSpec interface {
 
 	ReplaceUID(id string) error
 	SetPodMetadataLabels(key string, value string)
+	SetPodMetadataAnnotations(key string, value string)
+
+	// Set the number of seconds the ExecutionSpec is kept after it completes
+	SetTTLSecondsAfterFinished(seconds int32)
+
+	// Get the number of seconds the ExecutionSpec is kept after it completes, if set
+	TTLSecondsAfterFinished() *int32
+
+	// Set the resource requests and limits of the containers of the named templates
+	SetContainerResources(resources map[string]corev1.ResourceRequirements) error
+
+	// Add node selector labels and tolerations that apply to every pod of the ExecutionSpec
+	AddScheduling(nodeSelector map[string]string, tolerations []corev1.Toleration)
+
+	// Get the node selector and tolerations that apply to every pod of the ExecutionSpec
+	Scheduling() (map[string]string, []corev1.Toleration)
+
+	// Add environment variables to the containers of the named templates, or of every template
+	AddEnv(env []corev1.EnvVar, templateNames []string) error
+
+	// Set the retry strategy of the named templates, or of every template that runs a pod, keeping the retry
+	// strategies the templates have unless forced
+	SetRetryStrategy(strategy *RetryStrategy, templateNames []string, force bool) error
+
+	// Set the priority class of every pod of the ExecutionSpec
+	SetPriorityClassName(name string)
+
+	// Get the priority class of the pods of the ExecutionSpec, if set
+	PriorityClassName() string
+
+	// Store the output artifacts that have no location of their own under an object store root
+	SetOutputArtifactRoot(root *ArtifactRoot)
+
+	// Replace the parameter values that refer to secrets with references to the secrets, returning the
+	// secret references by parameter name
+	ResolveSecretParameters() (map[string]SecretKeyRef, error)
 
 	// Get ServiceAccountName
 	ServiceAccount() string
@@ -133,6 +170,9 @@ type ExecutionSpec interface {
 	// If the ExecutionSpec was terminated and not finished yet
 	IsTerminating() bool
 
+	// If the ExecutionSpec was terminated and has finished
+	IsTerminated() bool
+
 	// Get schedule time from label in second
 	ScheduledAtInSecOr0() int64
 
@@ -41,6 +41,12 @@ type ExecutionStatus interface {
 	// StartedAt in Time format
 	StartedAtTime() v1.Time
 
+	// UNIX time the execution started. If Execution is not started, return 0
+	StartedAt() int64
+
+	// UNIX time the first pod of the execution started. If no pod has started, return 0
+	FirstPodStartedAt() int64
+
 	// IsInFinalState whether the workflow is in a final state.
 	IsInFinalState() bool
 
@@ -54,4 +60,45 @@ type ExecutionStatus interface {
 
 	// does ExecutionStatus contain any finished node or not
 	HasMetrics() bool
+
+	// Nodes returns the nodes of the execution graph, sorted by ID.
+	Nodes() []ExecutionNode
+
+	// OutputArtifacts returns the output artifacts of the nodes of the execution, sorted by node ID and
+	// artifact name, leaving out the logs archived by the orchestration engine.
+	OutputArtifacts() []ExecutionArtifact
+
+	// NodePod returns the ID of the node that ran the pod of a node of the execution, and the name of the
+	// pod. A retried node resolves to its latest attempt.
+	NodePod(nodeID string) (podNodeID string, podName string, err error)
+
+	// StatusSnapshot returns the status of the execution serialized and gzip compressed, so that it can be
+	// stored after the execution is garbage collected.
+	StatusSnapshot() ([]byte, error)
+}
+
+// ExecutionNode is the status of one node of an execution graph, independent of the orchestration engine.
+type ExecutionNode struct {
+	ID          string
+	DisplayName string
+	Type        string
+	Phase       string
+	// UNIX times the node started and finished, or 0 if it hasn't.
+	StartedAt  int64
+	FinishedAt int64
+	ParentIDs  []string
+	ChildIDs   []string
+}
+
+// ExecutionArtifact is an output artifact of a node of an execution, independent of the orchestration engine.
+type ExecutionArtifact struct {
+	NodeID   string
+	NodeName string
+	Name     string
+	// Type is the kind of storage holding the artifact, e.g. s3 or gcs.
+	Type string
+	URI  string
+	// Redacted is set when the URI was left out because the location holds the contents of the artifact or
+	// credentials.
+	Redacted bool
 }
@@ -0,0 +1,127 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SemVer is a version of the form [v]MAJOR[.MINOR[.PATCH]][-PRERELEASE][+BUILD]. Missing minor and patch
+// numbers are 0.
+type SemVer struct {
+	Numbers    [3]uint64
+	PreRelease []string
+}
+
+// ParseSemVer parses a semantic version, allowing a "v" prefix and a missing minor or patch number. It returns
+// false if the string isn't a semantic version.
+func ParseSemVer(version string) (SemVer, bool) {
+	var v SemVer
+	if strings.HasPrefix(version, "v") || strings.HasPrefix(version, "V") {
+		version = version[1:]
+	}
+	// Build metadata doesn't take part in the ordering.
+	if i := strings.Index(version, "+"); i >= 0 {
+		version = version[:i]
+	}
+	if i := strings.Index(version, "-"); i >= 0 {
+		v.PreRelease = strings.Split(version[i+1:], ".")
+		for _, identifier := range v.PreRelease {
+			if identifier == "" {
+				return SemVer{}, false
+			}
+		}
+		version = version[:i]
+	}
+	numbers := strings.Split(version, ".")
+	if len(numbers) > len(v.Numbers) {
+		return SemVer{}, false
+	}
+	for i, number := range numbers {
+		n, err := strconv.ParseUint(number, 10, 64)
+		if err != nil {
+			return SemVer{}, false
+		}
+		v.Numbers[i] = n
+	}
+	return v, true
+}
+
+// Compare returns -1, 0 or 1 if v is lower than, equal to or higher than other. A pre-release is lower than
+// its release.
+func (v SemVer) Compare(other SemVer) int {
+	for i := range v.Numbers {
+		if c := compareUint(v.Numbers[i], other.Numbers[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(v.PreRelease) == 0 && len(other.PreRelease) == 0:
+		return 0
+	case len(v.PreRelease) == 0:
+		return 1
+	case len(other.PreRelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.PreRelease) && i < len(other.PreRelease); i++ {
+		if c := comparePreReleaseIdentifier(v.PreRelease[i], other.PreRelease[i]); c != 0 {
+			return c
+		}
+	}
+	return compareUint(uint64(len(v.PreRelease)), uint64(len(other.PreRelease)))
+}
+
+// CompareSemVerNames orders names by the semantic versions they hold. Names that are semantic versions come
+// before names that aren't, which are ordered lexically.
+func CompareSemVerNames(a string, b string) int {
+	va, aOk := ParseSemVer(a)
+	vb, bOk := ParseSemVer(b)
+	switch {
+	case aOk && bOk:
+		return va.Compare(vb)
+	case aOk:
+		return -1
+	case bOk:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// comparePreReleaseIdentifier compares numeric identifiers numerically and others lexically. Numeric
+// identifiers are lower than others.
+func comparePreReleaseIdentifier(a string, b string) int {
+	na, aErr := strconv.ParseUint(a, 10, 64)
+	nb, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareUint(na, nb)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func compareUint(a uint64, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSemVer(t *testing.T) {
+	v, ok := ParseSemVer("v1.10.2-rc.1+build5")
+	assert.True(t, ok)
+	assert.Equal(t, SemVer{Numbers: [3]uint64{1, 10, 2}, PreRelease: []string{"rc", "1"}}, v)
+
+	v, ok = ParseSemVer("2")
+	assert.True(t, ok)
+	assert.Equal(t, SemVer{Numbers: [3]uint64{2, 0, 0}}, v)
+
+	for _, version := range []string{"", "v", "1.", "1.2.3.4", "1.x", "1.2-", "1.2-rc..1", "latest"} {
+		_, ok = ParseSemVer(version)
+		assert.False(t, ok, version)
+	}
+}
+
+func TestCompareSemVerNames(t *testing.T) {
+	names := []string{"v1.10", "latest", "v1.9", "v1.10.0-rc.2", "v1.10.0-rc.10", "v1.10.0-alpha", "2.0", "draft"}
+	sort.Slice(names, func(i, j int) bool { return CompareSemVerNames(names[i], names[j]) < 0 })
+	assert.Equal(t, []string{"v1.9", "v1.10.0-alpha", "v1.10.0-rc.2", "v1.10.0-rc.10", "v1.10", "2.0", "draft", "latest"}, names)
+
+	assert.Equal(t, 0, CompareSemVerNames("v1.2", "1.2.0"))
+}
@@ -15,8 +15,16 @@
 package util
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
+	"hash/fnv"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -37,11 +45,14 @@ import (
 	swfapi "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow/v1beta1"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -285,9 +296,18 @@ func (w *Workflow) ExecutionTypeMeta() *metav1.TypeMeta {
 }
 
 func (w *Workflow) IsTerminating() bool {
-	return w.Spec.ActiveDeadlineSeconds != nil &&
-		*w.Spec.ActiveDeadlineSeconds == 0 &&
-		!w.IsInFinalState()
+	return w.isShutdownRequested() && !w.IsInFinalState()
+}
+
+func (w *Workflow) IsTerminated() bool {
+	return w.isShutdownRequested() && w.IsInFinalState()
+}
+
+// isShutdownRequested returns whether the workflow was asked to stop, either through its shutdown strategy
+// or by setting activeDeadlineSeconds to 0.
+func (w *Workflow) isShutdownRequested() bool {
+	return w.Spec.Shutdown.Enabled() ||
+		(w.Spec.ActiveDeadlineSeconds != nil && *w.Spec.ActiveDeadlineSeconds == 0)
 }
 
 // OverrideParameters overrides some of the parameters of a Workflow.
@@ -310,6 +330,23 @@ func (w *Workflow) OverrideParameters(desiredParams map[string]string) {
 	w.Spec.Arguments.Parameters = desiredSlice
 }
 
+// RedactParameterValuesFrom clears the values of the workflow and template parameters that are read from
+// another source, such as a config map, so that only the reference to the source is kept.
+func (w *Workflow) RedactParameterValuesFrom() {
+	redact := func(params []workflowapi.Parameter) {
+		for i := range params {
+			if params[i].ValueFrom != nil {
+				params[i].Value = nil
+			}
+		}
+	}
+	redact(w.Spec.Arguments.Parameters)
+	for i := range w.Spec.Templates {
+		redact(w.Spec.Templates[i].Inputs.Parameters)
+		redact(w.Spec.Templates[i].Outputs.Parameters)
+	}
+}
+
 func (w *Workflow) GetWorkflowParametersAsMap() map[string]string {
 	resultAsArray := w.Spec.Arguments.Parameters
 	resultAsMap := make(map[string]string)
@@ -323,18 +360,53 @@ func (w *Workflow) GetWorkflowParametersAsMap() map[string]string {
 	return resultAsMap
 }
 
+// VerifyParameters checks that every desired parameter is declared in the workflow arguments.
+// All unrecognized parameters are reported in a single invalid input error.
 func (w *Workflow) VerifyParameters(desiredParams map[string]string) error {
-	templateParamsMap := make(map[string]*string)
+	return w.verifyParameters(desiredParams, false)
+}
+
+// VerifyRunParameters is like VerifyParameters, but also reports every declared parameter that
+// is neither provided nor has a value, a default or a ValueFrom in the workflow.
+func (w *Workflow) VerifyRunParameters(desiredParams map[string]string) error {
+	return w.verifyParameters(desiredParams, true)
+}
+
+func (w *Workflow) verifyParameters(desiredParams map[string]string, checkMissing bool) error {
+	templateParamsMap := make(map[string]workflowapi.Parameter)
 	for _, param := range w.Spec.Arguments.Parameters {
-		templateParamsMap[param.Name] = ToStringPointer(param.Value)
+		templateParamsMap[param.Name] = param
 	}
+	violations := make(map[string]string)
 	for k := range desiredParams {
-		_, ok := templateParamsMap[k]
-		if !ok {
-			return NewInvalidInputError("Unrecognized input parameter: %v", k)
+		if _, ok := templateParamsMap[k]; !ok {
+			violations[k] = "Unrecognized input parameter"
 		}
 	}
-	return nil
+	if checkMissing {
+		for name, param := range templateParamsMap {
+			if _, ok := desiredParams[name]; ok {
+				continue
+			}
+			// Parameters with a ValueFrom get their value at runtime, e.g. from a ConfigMap.
+			if param.Value == nil && param.Default == nil && param.ValueFrom == nil {
+				violations[name] = "Missing required input parameter"
+			}
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(violations))
+	for name := range violations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	messages := make([]string, 0, len(names))
+	for _, name := range names {
+		messages = append(messages, fmt.Sprintf("%v: %v", violations[name], name))
+	}
+	return NewInvalidInputErrorWithFieldViolations(violations, "%v", strings.Join(messages, "; "))
 }
 
 // Get converts this object to a workflowapi.Workflow.
@@ -428,6 +500,224 @@ func (w *Workflow) StartedAtTime() metav1.Time {
 	return w.Status.StartedAt
 }
 
+func (w *Workflow) StartedAt() int64 {
+	if w.Status.StartedAt.IsZero() {
+		// If workflow is not started
+		return 0
+	}
+	return w.Status.StartedAt.Unix()
+}
+
+// FirstPodStartedAt returns the UNIX time the earliest pod node of the workflow started, or 0 if no pod
+// has started yet.
+func (w *Workflow) FirstPodStartedAt() int64 {
+	var first int64
+	for _, node := range w.Status.Nodes {
+		if node.Type != workflowapi.NodeTypePod || node.StartedAt.IsZero() {
+			continue
+		}
+		if startedAt := node.StartedAt.Unix(); first == 0 || startedAt < first {
+			first = startedAt
+		}
+	}
+	return first
+}
+
+// Nodes returns the nodes of the workflow status, sorted by ID. The parents of a node are the nodes that list
+// it as a child.
+func (w *Workflow) Nodes() []ExecutionNode {
+	parents := map[string][]string{}
+	for id, node := range w.Status.Nodes {
+		for _, child := range node.Children {
+			parents[child] = append(parents[child], id)
+		}
+	}
+	nodes := make([]ExecutionNode, 0, len(w.Status.Nodes))
+	for id, node := range w.Status.Nodes {
+		executionNode := ExecutionNode{
+			ID:          id,
+			DisplayName: node.DisplayName,
+			Type:        string(node.Type),
+			Phase:       string(node.Phase),
+			ParentIDs:   parents[id],
+			ChildIDs:    append([]string(nil), node.Children...),
+		}
+		if !node.StartedAt.IsZero() {
+			executionNode.StartedAt = node.StartedAt.Unix()
+		}
+		if !node.FinishedAt.IsZero() {
+			executionNode.FinishedAt = node.FinishedAt.Unix()
+		}
+		sort.Strings(executionNode.ParentIDs)
+		nodes = append(nodes, executionNode)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	return nodes
+}
+
+// archivedLogsArtifactName is the name of the output artifact Argo archives the logs of a pod as.
+const archivedLogsArtifactName = "main-logs"
+
+// OutputArtifacts returns the output artifacts of the nodes of the workflow status, sorted by node ID and
+// artifact name. The logs Argo archives are left out. Artifacts stored without a bucket are in the bucket of
+// the artifact repository the workflow used.
+func (w *Workflow) OutputArtifacts() []ExecutionArtifact {
+	var repository *workflowapi.ArtifactRepository
+	if w.Status.ArtifactRepositoryRef != nil {
+		repository = w.Status.ArtifactRepositoryRef.ArtifactRepository
+	}
+	artifacts := []ExecutionArtifact{}
+	for id, node := range w.Status.Nodes {
+		if node.Outputs == nil {
+			continue
+		}
+		for _, artifact := range node.Outputs.Artifacts {
+			if artifact.Name == archivedLogsArtifactName {
+				continue
+			}
+			executionArtifact := ExecutionArtifact{NodeID: id, NodeName: node.DisplayName, Name: artifact.Name}
+			executionArtifact.Type, executionArtifact.URI = artifactLocationURI(&artifact.ArtifactLocation, repository)
+			if executionArtifact.Type == "raw" || uriHasCredentials(executionArtifact.URI) {
+				executionArtifact.URI = ""
+				executionArtifact.Redacted = true
+			}
+			artifacts = append(artifacts, executionArtifact)
+		}
+	}
+	sort.Slice(artifacts, func(i, j int) bool {
+		if artifacts[i].NodeID != artifacts[j].NodeID {
+			return artifacts[i].NodeID < artifacts[j].NodeID
+		}
+		return artifacts[i].Name < artifacts[j].Name
+	})
+	return artifacts
+}
+
+// artifactLocationURI returns the kind of storage of an artifact location and the URI of the artifact.
+func artifactLocationURI(location *workflowapi.ArtifactLocation, repository *workflowapi.ArtifactRepository) (string, string) {
+	if repository == nil {
+		repository = &workflowapi.ArtifactRepository{}
+	}
+	switch {
+	case location.S3 != nil:
+		bucket := location.S3.Bucket
+		if bucket == "" && repository.S3 != nil {
+			bucket = repository.S3.Bucket
+		}
+		return "s3", bucketURI("s3", bucket, location.S3.Key)
+	case location.GCS != nil:
+		bucket := location.GCS.Bucket
+		if bucket == "" && repository.GCS != nil {
+			bucket = repository.GCS.Bucket
+		}
+		return "gcs", bucketURI("gs", bucket, location.GCS.Key)
+	case location.OSS != nil:
+		bucket := location.OSS.Bucket
+		if bucket == "" && repository.OSS != nil {
+			bucket = repository.OSS.Bucket
+		}
+		return "oss", bucketURI("oss", bucket, location.OSS.Key)
+	case location.HDFS != nil:
+		return "hdfs", "hdfs://" + location.HDFS.Path
+	case location.HTTP != nil:
+		return "http", location.HTTP.URL
+	case location.Artifactory != nil:
+		return "artifactory", location.Artifactory.URL
+	case location.Git != nil:
+		return "git", location.Git.Repo
+	case location.Raw != nil:
+		return "raw", ""
+	}
+	return "", ""
+}
+
+func bucketURI(scheme string, bucket string, key string) string {
+	return fmt.Sprintf("%s://%s/%s", scheme, bucket, strings.TrimPrefix(key, "/"))
+}
+
+// uriHasCredentials returns whether a URI embeds credentials: a password, or a user name, which is often a
+// token, in any but an SSH URI.
+func uriHasCredentials(uri string) bool {
+	u, err := url.Parse(uri)
+	if err != nil || u.User == nil {
+		// Git repositories given as user@host:path aren't URLs, and only name the SSH user.
+		return false
+	}
+	_, hasPassword := u.User.Password()
+	return hasPassword || u.Scheme != "ssh"
+}
+
+// NodePod returns the ID of the node that ran the pod of a node of the workflow, and the name of the pod. A
+// retried node resolves to its latest attempt.
+func (w *Workflow) NodePod(nodeID string) (string, string, error) {
+	node, ok := w.Status.Nodes[nodeID]
+	if !ok {
+		return "", "", NewNotFoundError(errors.New("node not found"), "Node %v of workflow %v", nodeID, w.Name)
+	}
+	// The children of a retry node are its attempts, in the order they ran.
+	for node.Type == workflowapi.NodeTypeRetry && len(node.Children) > 0 {
+		attempt, ok := w.Status.Nodes[node.Children[len(node.Children)-1]]
+		if !ok {
+			break
+		}
+		node = attempt
+	}
+	if node.Type != workflowapi.NodeTypePod {
+		return "", "", NewInvalidInputError("Node %v of workflow %v does not run a pod", nodeID, w.Name)
+	}
+	if w.GetAnnotations()[common.AnnotationKeyPodNameVersion] != "v2" {
+		return node.ID, node.ID, nil
+	}
+	// Pods named by the v2 scheme are named after the workflow, the template and a hash of the node name,
+	// see PodName in github.com/argoproj/argo-workflows/v3/workflow/util.
+	if node.Name == w.Name {
+		return node.ID, w.Name, nil
+	}
+	prefix := fmt.Sprintf("%s-%s", w.Name, node.TemplateName)
+	// Leave room for the hash in the 253 characters of a Kubernetes name.
+	if maxPrefixLength := 253 - 11; len(prefix) > maxPrefixLength {
+		prefix = prefix[:maxPrefixLength]
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(node.Name))
+	return node.ID, fmt.Sprintf("%s-%v", prefix, h.Sum32()), nil
+}
+
+// StatusSnapshot returns the workflow status as gzip compressed JSON.
+func (w *Workflow) StatusSnapshot() ([]byte, error) {
+	status, err := json.Marshal(w.Status)
+	if err != nil {
+		return nil, NewInternalServerError(err, "Failed to marshal the status of workflow %v", w.Name)
+	}
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if _, err := writer.Write(status); err != nil {
+		return nil, NewInternalServerError(err, "Failed to compress the status of workflow %v", w.Name)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, NewInternalServerError(err, "Failed to compress the status of workflow %v", w.Name)
+	}
+	return buffer.Bytes(), nil
+}
+
+// NewWorkflowFromStatusSnapshot returns a workflow with the status of a snapshot made by StatusSnapshot.
+func NewWorkflowFromStatusSnapshot(snapshot []byte) (*Workflow, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(snapshot))
+	if err != nil {
+		return nil, NewInvalidInputError("Failed to decompress the workflow status snapshot: %v", err)
+	}
+	defer reader.Close()
+	statusBytes, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, NewInvalidInputError("Failed to decompress the workflow status snapshot: %v", err)
+	}
+	var status workflowapi.WorkflowStatus
+	if err := json.Unmarshal(statusBytes, &status); err != nil {
+		return nil, NewInvalidInputError("Failed to unmarshal the workflow status snapshot: %v", err)
+	}
+	return NewWorkflow(&workflowapi.Workflow{Status: status}), nil
+}
+
 const (
 	metricsArtifactName = "mlpipeline-metrics"
 	// More than 50 metrics is not scalable with current UI design.
@@ -640,6 +930,478 @@ func (w *Workflow) SetPodMetadataLabels(key string, value string) {
 	w.Workflow.Spec.PodMetadata.Labels[key] = value
 }
 
+func (w *Workflow) SetPodMetadataAnnotations(key string, value string) {
+	if w.Workflow.Spec.PodMetadata == nil {
+		w.Workflow.Spec.PodMetadata = &workflowapi.Metadata{}
+	}
+	if w.Workflow.Spec.PodMetadata.Annotations == nil {
+		w.Workflow.Spec.PodMetadata.Annotations = make(map[string]string)
+	}
+	w.Workflow.Spec.PodMetadata.Annotations[key] = value
+}
+
+// SetTTLSecondsAfterFinished replaces the TTL strategy of the workflow, so that the workflow is deleted
+// the given number of seconds after it completes, whether it succeeded or failed.
+func (w *Workflow) SetTTLSecondsAfterFinished(seconds int32) {
+	w.Workflow.Spec.TTLStrategy = &workflowapi.TTLStrategy{SecondsAfterCompletion: &seconds}
+}
+
+// TTLSecondsAfterFinished returns how many seconds the workflow is kept after it completes, or nil if
+// the workflow doesn't have a TTL that applies to every completion.
+func (w *Workflow) TTLSecondsAfterFinished() *int32 {
+	if w.Workflow.Spec.TTLStrategy == nil {
+		return nil
+	}
+	return w.Workflow.Spec.TTLStrategy.SecondsAfterCompletion
+}
+
+// SetContainerResources sets the resource requests and limits of the containers of the templates with the
+// given names. Resources that aren't mentioned keep their current values. The workflow is left unchanged
+// if any of the templates doesn't exist or doesn't run a container.
+func (w *Workflow) SetContainerResources(resources map[string]corev1.ResourceRequirements) error {
+	containers := make(map[string]*corev1.Container, len(resources))
+	for index := range w.Spec.Templates {
+		t := &w.Spec.Templates[index]
+		if _, ok := resources[t.Name]; ok && t.Container != nil {
+			containers[t.Name] = t.Container
+		}
+	}
+	for name := range resources {
+		if _, ok := containers[name]; !ok {
+			return NewInvalidInputError("Template %q does not exist or does not run a container", name)
+		}
+	}
+	for name, requirements := range resources {
+		container := containers[name]
+		container.Resources.Requests = mergeResourceList(container.Resources.Requests, requirements.Requests)
+		container.Resources.Limits = mergeResourceList(container.Resources.Limits, requirements.Limits)
+	}
+	return nil
+}
+
+// AddScheduling adds node selector labels and tolerations to the workflow spec, so that they apply to every pod
+// of the workflow. Node selector labels replace those with the same key, and tolerations the workflow already
+// has aren't added again.
+func (w *Workflow) AddScheduling(nodeSelector map[string]string, tolerations []corev1.Toleration) {
+	if len(nodeSelector) > 0 && w.Workflow.Spec.NodeSelector == nil {
+		w.Workflow.Spec.NodeSelector = make(map[string]string, len(nodeSelector))
+	}
+	for key, value := range nodeSelector {
+		w.Workflow.Spec.NodeSelector[key] = value
+	}
+	for _, toleration := range tolerations {
+		exists := false
+		for _, current := range w.Workflow.Spec.Tolerations {
+			if current.MatchToleration(&toleration) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			w.Workflow.Spec.Tolerations = append(w.Workflow.Spec.Tolerations, toleration)
+		}
+	}
+}
+
+// Scheduling returns the node selector and tolerations of the workflow spec.
+func (w *Workflow) Scheduling() (map[string]string, []corev1.Toleration) {
+	return w.Workflow.Spec.NodeSelector, w.Workflow.Spec.Tolerations
+}
+
+// SetPriorityClassName sets the priority class of every pod of the workflow. Templates that set a priority
+// class of their own get this one instead.
+func (w *Workflow) SetPriorityClassName(name string) {
+	w.Workflow.Spec.PodPriorityClassName = name
+	for i := range w.Workflow.Spec.Templates {
+		if w.Workflow.Spec.Templates[i].PriorityClassName != "" {
+			w.Workflow.Spec.Templates[i].PriorityClassName = name
+		}
+	}
+}
+
+// SetOutputArtifactRoot stores the output artifacts of every template under root, at
+// <prefix>/<workflow name>/<pod name>/<artifact name>.tgz. Artifacts with a location or a key set by the
+// manifest are kept where they are.
+func (w *Workflow) SetOutputArtifactRoot(root *ArtifactRoot) {
+	for i := range w.Workflow.Spec.Templates {
+		artifacts := w.Workflow.Spec.Templates[i].Outputs.Artifacts
+		for j := range artifacts {
+			if artifacts[j].HasLocationOrKey() {
+				continue
+			}
+			key := path.Join(root.Prefix, "{{workflow.name}}", "{{pod.name}}", artifacts[j].Name+".tgz")
+			if root.Scheme == ArtifactRootSchemeGCS {
+				artifacts[j].GCS = &workflowapi.GCSArtifact{GCSBucket: workflowapi.GCSBucket{Bucket: root.Bucket}, Key: key}
+				continue
+			}
+			insecure := root.Insecure
+			artifacts[j].S3 = &workflowapi.S3Artifact{
+				S3Bucket: workflowapi.S3Bucket{
+					Endpoint: root.Endpoint,
+					Bucket:   root.Bucket,
+					Insecure: &insecure,
+					AccessKeySecret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: root.CredentialsSecret},
+						Key:                  "accesskey",
+					},
+					SecretKeySecret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: root.CredentialsSecret},
+						Key:                  "secretkey",
+					},
+				},
+				Key: key,
+			}
+		}
+	}
+}
+
+// PriorityClassName returns the priority class of the pods of the workflow spec.
+func (w *Workflow) PriorityClassName() string {
+	return w.Workflow.Spec.PodPriorityClassName
+}
+
+// SecretKeyRefPrefix starts a parameter value that refers to a key of a Kubernetes secret, in the form
+// secretKeyRef://<secretName>/<key>, rather than holding the value itself.
+const SecretKeyRefPrefix = "secretKeyRef://"
+
+// secretParameterEnvPrefix starts the names of the environment variables holding secret parameter values.
+const secretParameterEnvPrefix = "KFP_SECRET_"
+
+// SecretKeyRef is a key of a Kubernetes secret in the namespace of a run.
+type SecretKeyRef struct {
+	Name string
+	Key  string
+}
+
+// ParseSecretKeyRef parses a parameter value of the form secretKeyRef://<secretName>/<key>. It returns false
+// if the value isn't a secret reference, and an error if it is a malformed one.
+func ParseSecretKeyRef(value string) (*SecretKeyRef, bool, error) {
+	if !strings.HasPrefix(value, SecretKeyRefPrefix) {
+		return nil, false, nil
+	}
+	parts := strings.Split(strings.TrimPrefix(value, SecretKeyRefPrefix), "/")
+	if len(parts) != 2 || len(validation.IsDNS1123Subdomain(parts[0])) > 0 || len(validation.IsConfigMapKey(parts[1])) > 0 {
+		return nil, true, NewInvalidInputError(
+			"Secret reference %q must be of the form %s<secretName>/<key>, with a valid secret name and key", value, SecretKeyRefPrefix)
+	}
+	return &SecretKeyRef{Name: parts[0], Key: parts[1]}, true, nil
+}
+
+// ResolveSecretParameters replaces the values of the workflow parameters that refer to secrets with references
+// to environment variables, which the containers of the templates using a parameter read from its secret.
+// Kubernetes expands these references in the command and arguments of the containers, so the secret values
+// never are part of the workflow, and templates that don't use a parameter can't read its secret. The secret
+// references are returned by parameter name.
+func (w *Workflow) ResolveSecretParameters() (map[string]SecretKeyRef, error) {
+	refs := make(map[string]SecretKeyRef)
+	for index := range w.Spec.Arguments.Parameters {
+		param := &w.Spec.Arguments.Parameters[index]
+		if param.Value == nil {
+			continue
+		}
+		ref, ok, err := ParseSecretKeyRef(param.Value.String())
+		if err != nil {
+			return nil, Wrapf(err, "Invalid value of parameter %v", param.Name)
+		}
+		if !ok {
+			continue
+		}
+		envName := secretParameterEnvName(param.Name)
+		env := corev1.EnvVar{
+			Name: envName,
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: ref.Name},
+				Key:                  ref.Key,
+			}},
+		}
+		// The templates using the parameter are found before its value stops referring to it.
+		using := w.templatesUsingWorkflowParameter(param.Name)
+		for index := range w.Spec.Templates {
+			t := &w.Spec.Templates[index]
+			if !using[t.Name] {
+				continue
+			}
+			if t.Container != nil {
+				t.Container.Env = append(t.Container.Env, env)
+			}
+			if t.Script != nil {
+				t.Script.Env = append(t.Script.Env, env)
+			}
+		}
+		param.Value = workflowapi.AnyStringPtr("$(" + envName + ")")
+		refs[param.Name] = *ref
+	}
+	return refs, nil
+}
+
+// templatesUsingWorkflowParameter returns the names of the container and script templates that use a workflow
+// parameter, either directly or through the inputs that DAG tasks and steps pass it to. The workflow arguments
+// are also the inputs of the entrypoint.
+func (w *Workflow) templatesUsingWorkflowParameter(paramName string) map[string]bool {
+	// inputs holds the inputs the parameter flows into, by template name.
+	inputs := map[string]map[string]bool{}
+	addInput := func(templateName string, inputName string) bool {
+		if inputs[templateName][inputName] {
+			return false
+		}
+		if inputs[templateName] == nil {
+			inputs[templateName] = map[string]bool{}
+		}
+		inputs[templateName][inputName] = true
+		return true
+	}
+	refersToParameter := func(templateName string, value string) bool {
+		if strings.Contains(value, "{{workflow.parameters."+paramName+"}}") {
+			return true
+		}
+		for inputName := range inputs[templateName] {
+			if strings.Contains(value, "{{inputs.parameters."+inputName+"}}") {
+				return true
+			}
+		}
+		return false
+	}
+	if w.Spec.Entrypoint != "" {
+		addInput(w.Spec.Entrypoint, paramName)
+	}
+	for changed := true; changed; {
+		changed = false
+		passArguments := func(templateName string, callee string, arguments workflowapi.Arguments) {
+			for _, argument := range arguments.Parameters {
+				if argument.Value != nil && refersToParameter(templateName, argument.Value.String()) && addInput(callee, argument.Name) {
+					changed = true
+				}
+			}
+		}
+		for index := range w.Spec.Templates {
+			t := &w.Spec.Templates[index]
+			if t.DAG != nil {
+				for _, task := range t.DAG.Tasks {
+					passArguments(t.Name, task.Template, task.Arguments)
+				}
+			}
+			for _, parallelSteps := range t.Steps {
+				for _, step := range parallelSteps.Steps {
+					passArguments(t.Name, step.Template, step.Arguments)
+				}
+			}
+		}
+	}
+
+	using := map[string]bool{}
+	for index := range w.Spec.Templates {
+		t := &w.Spec.Templates[index]
+		var spec interface{}
+		if t.Container != nil {
+			spec = t.Container
+		} else if t.Script != nil {
+			spec = t.Script
+		} else {
+			continue
+		}
+		specJSON, err := json.Marshal(spec)
+		if err == nil && refersToParameter(t.Name, string(specJSON)) {
+			using[t.Name] = true
+		}
+	}
+	return using
+}
+
+// secretParameterEnvName returns the name of the environment variable holding the value of a parameter, with
+// the characters that can't be part of an environment variable name replaced by underscores.
+func secretParameterEnvName(paramName string) string {
+	return secretParameterEnvPrefix + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '_'
+	}, paramName)
+}
+
+// AddEnv adds environment variables to every container of the templates with the given names, or of all the
+// templates if no name is given, including init containers, sidecars and the containers of container sets.
+// Variables replace those of the container with the same name. The workflow is left unchanged if any of the
+// templates doesn't exist.
+func (w *Workflow) AddEnv(env []corev1.EnvVar, templateNames []string) error {
+	if len(env) == 0 {
+		return nil
+	}
+	selected, err := w.selectTemplates(templateNames)
+	if err != nil {
+		return err
+	}
+	for index := range w.Spec.Templates {
+		t := &w.Spec.Templates[index]
+		if _, ok := selected[t.Name]; len(selected) > 0 && !ok {
+			continue
+		}
+		for _, container := range templateContainers(t) {
+			container.Env = mergeEnv(container.Env, env)
+		}
+	}
+	return nil
+}
+
+// selectTemplates returns the set of the given template names, failing if any of the templates doesn't exist.
+func (w *Workflow) selectTemplates(templateNames []string) (map[string]bool, error) {
+	selected := make(map[string]bool, len(templateNames))
+	for _, name := range templateNames {
+		selected[name] = false
+	}
+	for index := range w.Spec.Templates {
+		if _, ok := selected[w.Spec.Templates[index].Name]; ok {
+			selected[w.Spec.Templates[index].Name] = true
+		}
+	}
+	for name, found := range selected {
+		if !found {
+			return nil, NewInvalidInputError("Template %q does not exist", name)
+		}
+	}
+	return selected, nil
+}
+
+// templateContainers returns every container a template runs.
+func templateContainers(t *workflowapi.Template) []*corev1.Container {
+	var containers []*corev1.Container
+	if t.Container != nil {
+		containers = append(containers, t.Container)
+	}
+	if t.Script != nil {
+		containers = append(containers, &t.Script.Container)
+	}
+	if t.ContainerSet != nil {
+		for index := range t.ContainerSet.Containers {
+			containers = append(containers, &t.ContainerSet.Containers[index].Container)
+		}
+	}
+	for index := range t.InitContainers {
+		containers = append(containers, &t.InitContainers[index].Container)
+	}
+	for index := range t.Sidecars {
+		containers = append(containers, &t.Sidecars[index].Container)
+	}
+	return containers
+}
+
+func mergeEnv(current []corev1.EnvVar, added []corev1.EnvVar) []corev1.EnvVar {
+	merged := make([]corev1.EnvVar, 0, len(current)+len(added))
+	for _, envVar := range current {
+		replaced := false
+		for _, addedVar := range added {
+			replaced = replaced || addedVar.Name == envVar.Name
+		}
+		if !replaced {
+			merged = append(merged, envVar)
+		}
+	}
+	return append(merged, added...)
+}
+
+// RetryStrategy is how the failed steps of a run are retried: up to Limit times, for the failures the retry
+// policy covers, waiting as the backoff tells between retries.
+type RetryStrategy struct {
+	Limit int32 `json:"limit"`
+	// One of Always, OnFailure, OnError and OnTransientError. Argo retries on failures only if empty.
+	RetryPolicy string        `json:"retryPolicy,omitempty"`
+	Backoff     *RetryBackoff `json:"backoff,omitempty"`
+}
+
+// RetryBackoff is the wait before a retry, Duration the first time, then multiplied by Factor each time up to
+// MaxDuration. Durations are either a number of seconds or a Go duration, e.g. 90s or 2m.
+type RetryBackoff struct {
+	Duration    string `json:"duration,omitempty"`
+	Factor      int32  `json:"factor,omitempty"`
+	MaxDuration string `json:"maxDuration,omitempty"`
+}
+
+// Validate checks that the limit isn't negative, that the retry policy is one Argo knows and that the
+// durations of the backoff parse.
+func (s *RetryStrategy) Validate() error {
+	if s.Limit < 0 {
+		return NewInvalidInputError("Invalid retry strategy: the limit must not be negative, got %d", s.Limit)
+	}
+	switch workflowapi.RetryPolicy(s.RetryPolicy) {
+	case "", workflowapi.RetryPolicyAlways, workflowapi.RetryPolicyOnFailure, workflowapi.RetryPolicyOnError, workflowapi.RetryPolicyOnTransientError:
+	default:
+		return NewInvalidInputError("Invalid retry strategy: unknown retry policy %q, must be one of %v, %v, %v and %v", s.RetryPolicy,
+			workflowapi.RetryPolicyAlways, workflowapi.RetryPolicyOnFailure, workflowapi.RetryPolicyOnError, workflowapi.RetryPolicyOnTransientError)
+	}
+	if s.Backoff == nil {
+		return nil
+	}
+	for field, duration := range map[string]string{"duration": s.Backoff.Duration, "maxDuration": s.Backoff.MaxDuration} {
+		if duration == "" {
+			continue
+		}
+		if _, err := strconv.Atoi(duration); err == nil {
+			continue
+		}
+		if d, err := time.ParseDuration(duration); err != nil || d < 0 {
+			return NewInvalidInputError("Invalid retry strategy: the backoff %v %q must be a number of seconds or a duration such as 90s", field, duration)
+		}
+	}
+	if s.Backoff.Factor < 0 {
+		return NewInvalidInputError("Invalid retry strategy: the backoff factor must not be negative, got %d", s.Backoff.Factor)
+	}
+	return nil
+}
+
+func (s *RetryStrategy) toArgo() *workflowapi.RetryStrategy {
+	limit := intstr.FromInt(int(s.Limit))
+	strategy := &workflowapi.RetryStrategy{Limit: &limit, RetryPolicy: workflowapi.RetryPolicy(s.RetryPolicy)}
+	if s.Backoff != nil {
+		strategy.Backoff = &workflowapi.Backoff{Duration: s.Backoff.Duration, MaxDuration: s.Backoff.MaxDuration}
+		if s.Backoff.Factor != 0 {
+			factor := intstr.FromInt(int(s.Backoff.Factor))
+			strategy.Backoff.Factor = &factor
+		}
+	}
+	return strategy
+}
+
+// SetRetryStrategy sets the retry strategy of the templates with the given names, or of all the templates that
+// run a pod or a resource if no name is given. Templates that have a retry strategy of their own keep it unless
+// force is set. The workflow is left unchanged if any of the templates doesn't exist.
+func (w *Workflow) SetRetryStrategy(strategy *RetryStrategy, templateNames []string, force bool) error {
+	selected, err := w.selectTemplates(templateNames)
+	if err != nil {
+		return err
+	}
+	for index := range w.Spec.Templates {
+		t := &w.Spec.Templates[index]
+		if len(selected) > 0 {
+			if _, ok := selected[t.Name]; !ok {
+				continue
+			}
+		} else if t.Container == nil && t.Script == nil && t.ContainerSet == nil && t.Resource == nil {
+			// Retrying a DAG or steps template would run all of its steps again.
+			continue
+		}
+		if t.RetryStrategy != nil && !force {
+			continue
+		}
+		t.RetryStrategy = strategy.toArgo()
+	}
+	return nil
+}
+
+func mergeResourceList(current corev1.ResourceList, overrides corev1.ResourceList) corev1.ResourceList {
+	if len(overrides) == 0 {
+		return current
+	}
+	if current == nil {
+		current = make(corev1.ResourceList, len(overrides))
+	}
+	for name, quantity := range overrides {
+		current[name] = quantity
+	}
+	return current
+}
+
 func (w *Workflow) ReplaceUID(id string) error {
 	newWorkflowString := strings.Replace(w.ToStringForStore(), "{{workflow.uid}}", id, -1)
 	var workflow *workflowapi.Workflow
@@ -22,6 +22,7 @@ import (
 	"github.com/kubeflow/pipelines/backend/src/crd/controller/scheduledworkflow/util"
 	swfapi "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow/v1beta1"
 	wraperror "github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
@@ -130,15 +131,16 @@ func retrieveIndex(workflow commonutil.ExecutionSpec) int64 {
 	return result
 }
 
-// Create creates a workflow given a namespace and its specification.
+// Create creates a workflow given a namespace and its specification. It also returns whether the creation
+// failed because a workflow of the same name already exists.
 func (p *WorkflowClient) Create(ctx context.Context, namespace string, workflow commonutil.ExecutionSpec) (
-	commonutil.ExecutionSpec, error) {
+	wf commonutil.ExecutionSpec, isAlreadyExistsError bool, err error) {
 	result, err := p.clientSet.Execution(namespace).Create(ctx, workflow, metav1.CreateOptions{})
 	if err != nil {
-		return nil, wraperror.Wrapf(err, "Error creating workflow in namespace (%v): %v: %+v", namespace,
+		return nil, apierrors.IsAlreadyExists(err), wraperror.Wrapf(err, "Error creating workflow in namespace (%v): %v: %+v", namespace,
 			err, workflow.ToStringForStore())
 	}
-	return result, nil
+	return result, false, nil
 }
 
 func getLabelSelectorToGetWorkflows(swfName string, completed bool, minIndex int64) *labels.Selector {
@@ -481,7 +481,7 @@ func (c *Controller) submitNewWorkflowIfNotAlreadySubmitted(
 	swf *util.ScheduledWorkflow, nextScheduledEpoch int64, nowEpoch int64) (
 	bool, string, error) {
 
-	workflowName := swf.NextResourceName()
+	workflowName := swf.ScheduledResourceName(nextScheduledEpoch)
 
 	// Try to fetch this workflow
 	// If it already exists, it means that it was already created in a previous iteration
@@ -502,7 +502,21 @@ func (c *Controller) submitNewWorkflowIfNotAlreadySubmitted(
 
 	// If the workflow is not found, we need to create it.
 	newWorkflow, err := swf.NewWorkflow(nextScheduledEpoch, nowEpoch)
-	createdWorkflow, err := c.workflowClient.Create(ctx, swf.Namespace, newWorkflow)
+	if err != nil {
+		return false, "", err
+	}
+	createdWorkflow, isAlreadyExistsError, err := c.workflowClient.Create(ctx, swf.Namespace, newWorkflow)
+	if isAlreadyExistsError {
+		// The informer hasn't seen the workflow of this tick yet, e.g. right after a restart of the
+		// controller. The workflow name is derived from the scheduled time, so this is the same tick
+		// submitted again. The existing workflow is reported to the API server as usual.
+		log.WithFields(log.Fields{
+			ScheduledWorkflow: swf.Name,
+			Workflow:          workflowName,
+		}).Infof("Submitting workflow for ScheduledWorkflow (%v): workflow (%v) was already submitted",
+			swf.Name, workflowName)
+		return true, workflowName, nil
+	}
 	if err != nil {
 		return false, "", err
 	}
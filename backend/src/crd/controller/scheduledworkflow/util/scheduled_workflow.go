@@ -126,16 +126,15 @@ func (s *ScheduledWorkflow) isOneOffRun() bool {
 		s.Spec.Trigger.PeriodicSchedule == nil
 }
 
-func (s *ScheduledWorkflow) nextResourceID() string {
-	return s.Name + "-" + strconv.FormatInt(s.nextIndex(), 10)
-}
-
-// NextResourceName creates a deterministic resource name for the next resource.
-func (s *ScheduledWorkflow) NextResourceName() string {
-	nextResourceID := s.nextResourceID()
+// ScheduledResourceName creates a deterministic resource name for the resource scheduled at the given epoch.
+// The name only depends on the ScheduledWorkflow and the scheduled time, so a controller that submits the
+// same tick again, e.g. after a restart before its status was updated, gets an AlreadyExists error instead
+// of creating a duplicate. The UID keeps a ScheduledWorkflow recreated with the same name from colliding
+// with the resources of the previous one.
+func (s *ScheduledWorkflow) ScheduledResourceName(scheduledEpoch int64) string {
 	h := fnv.New32a()
-	_, _ = h.Write([]byte(nextResourceID))
-	return fmt.Sprintf("%s-%v", nextResourceID, h.Sum32())
+	_, _ = h.Write([]byte(s.UID))
+	return fmt.Sprintf("%s-%v-%v", s.Name, scheduledEpoch, h.Sum32())
 }
 
 func (s *ScheduledWorkflow) getWorkflowParametersAsMap() map[string]string {
@@ -165,7 +164,7 @@ func (s *ScheduledWorkflow) NewWorkflow(
 	}
 
 	// Set the name of the workflow.
-	execSpec.SetExecutionName(s.NextResourceName())
+	execSpec.SetExecutionName(s.ScheduledResourceName(nextScheduledEpoch))
 
 	// Get the workflow parameters and format them.
 	formatter := commonutil.NewSWFParameterFormatter(uuid.String(), nextScheduledEpoch, nowEpoch, s.nextIndex())
@@ -180,34 +180,33 @@ func TestScheduledWorkflow_isOneOffRun(t *testing.T) {
 	assert.Equal(t, false, schedule.isOneOffRun())
 }
 
-func TestScheduledWorkflow_nextResourceID(t *testing.T) {
-	// No schedule
-	schedule := NewScheduledWorkflow(&swfapi.ScheduledWorkflow{
+func TestScheduledWorkflow_ScheduledResourceName(t *testing.T) {
+	swf := &swfapi.ScheduledWorkflow{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "WORKFLOW_NAME",
+			UID:  "SWF_UID",
 		},
 		Status: swfapi.ScheduledWorkflowStatus{
 			Trigger: swfapi.TriggerStatus{
 				LastIndex: commonutil.Int64Pointer(50),
 			},
 		},
-	})
-	assert.Equal(t, "WORKFLOW_NAME-51", schedule.nextResourceID())
-}
-
-func TestScheduledWorkflow_NextResourceName(t *testing.T) {
-	// No schedule
-	schedule := NewScheduledWorkflow(&swfapi.ScheduledWorkflow{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "WORKFLOW_NAME",
-		},
-		Status: swfapi.ScheduledWorkflowStatus{
-			Trigger: swfapi.TriggerStatus{
-				LastIndex: commonutil.Int64Pointer(50),
-			},
-		},
-	})
-	assert.Equal(t, "WORKFLOW_NAME-51-2626342551", schedule.NextResourceName())
+	}
+	schedule := NewScheduledWorkflow(swf)
+	assert.Equal(t, "WORKFLOW_NAME-3600-3296609670", schedule.ScheduledResourceName(3600))
+	assert.NotEqual(t, schedule.ScheduledResourceName(3600), schedule.ScheduledResourceName(7200))
+
+	// A controller restarted before it updated the status after submitting the tick derives the same name.
+	restarted := NewScheduledWorkflow(swf.DeepCopy())
+	assert.Equal(t, schedule.ScheduledResourceName(3600), restarted.ScheduledResourceName(3600))
+	// The name doesn't depend on the index of the run.
+	restarted.Status.Trigger.LastIndex = commonutil.Int64Pointer(51)
+	assert.Equal(t, schedule.ScheduledResourceName(3600), restarted.ScheduledResourceName(3600))
+
+	// A ScheduledWorkflow recreated with the same name doesn't collide with the runs of the previous one.
+	recreated := swf.DeepCopy()
+	recreated.UID = "OTHER_SWF_UID"
+	assert.NotEqual(t, schedule.ScheduledResourceName(3600), NewScheduledWorkflow(recreated).ScheduledResourceName(3600))
 }
 
 func TestScheduledWorkflow_GetNextScheduledEpoch_OneTimeRun(t *testing.T) {
@@ -744,7 +743,7 @@ func TestScheduledWorkflow_NewWorkflow(t *testing.T) {
 					APIVersion: "argoproj.io/v1alpha1",
 				},
 				ObjectMeta: metav1.ObjectMeta{
-					Name: "SCHEDULE1-1-3321103997",
+					Name: "SCHEDULE1-36000-2166136261",
 					Labels: map[string]string{
 						"pipeline/runid": "123e4567-e89b-12d3-a456-426655440001",
 						"scheduledworkflows.kubeflow.org/isOwnedByScheduledWorkflow": "true",
@@ -857,7 +856,7 @@ func TestScheduledWorkflow_NewWorkflow_Parameterized(t *testing.T) {
 					APIVersion: "argoproj.io/v1alpha1",
 				},
 				ObjectMeta: metav1.ObjectMeta{
-					Name: "SCHEDULE1-1-3321103997",
+					Name: "SCHEDULE1-36000-2166136261",
 					Labels: map[string]string{
 						"pipeline/runid": "123e4567-e89b-12d3-a456-426655440001",
 						"scheduledworkflows.kubeflow.org/isOwnedByScheduledWorkflow": "true",